@@ -0,0 +1,176 @@
+package buildcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/indaco/tempo/cmd/tempo/synccmd"
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/cmdrunner"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/urfave/cli/v3"
+)
+
+// Statuses of a build step.
+const (
+	stepOK      = "ok"
+	stepFailed  = "failed"
+	stepSkipped = "skipped"
+)
+
+// runExternal runs an external command in dir, streaming its output. Tests replace it.
+var runExternal cmdrunner.Runner = cmdrunner.RunCommandContext
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// buildStep is a step of the build and its outcome.
+type buildStep struct {
+	Name     string
+	Status   string
+	Duration time.Duration
+	run      func(ctx context.Context) error
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupBuildCommand creates the "build" command running the sync, templ generate and
+// optionally go build.
+func SetupBuildCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "build",
+		Usage:     "Sync the assets, run 'templ generate', then optionally 'go build ./...', stopping at the first failing step",
+		UsageText: "tempo build [options]",
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.CheckTempoProject(cmdCtx.Config.App.RequiresGoModule())
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "templ",
+				Usage: "Command running templ, e.g. \"go tool templ\" (default: build.templ or templ)",
+			},
+			&cli.BoolFlag{
+				Name:  "go-build",
+				Usage: "Run 'go build ./...' after 'templ generate' (default: build.go_build)",
+			},
+			&cli.BoolFlag{
+				Name:    "prod",
+				Aliases: []string{"p"},
+				Usage:   "Sync in production mode, minifying the injected content",
+			},
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Sync all files, ignoring modification timestamps",
+			},
+		},
+		Action: runBuildCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runBuildCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		templCommand := cmdCtx.Config.Build.TemplCommand()
+		if value := strings.Fields(cmd.String("templ")); len(value) > 0 {
+			templCommand = value
+		}
+		goBuild := cmdCtx.Config.Build.GoBuildEnabled()
+		if cmd.IsSet("go-build") {
+			goBuild = cmd.Bool("go-build")
+		}
+
+		steps := []*buildStep{
+			{Name: "sync", run: func(ctx context.Context) error { return runSync(ctx, cmdCtx, cmd) }},
+			{Name: "templ generate", run: func(ctx context.Context) error {
+				return runExternal(ctx, cmdCtx.CWD, append(templCommand, "generate"))
+			}},
+		}
+		if goBuild {
+			steps = append(steps, &buildStep{Name: "go build", run: func(ctx context.Context) error {
+				return runExternal(ctx, cmdCtx.CWD, []string{"go", "build", "./..."})
+			}})
+		}
+
+		failed, err := runSteps(ctx, steps)
+
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+		if err := writeSummary(os.Stdout, steps); err != nil {
+			return err
+		}
+
+		if failed != nil {
+			if errors.Is(err, exec.ErrNotFound) {
+				err = apperrors.Wrap("command not found. Install it or set 'build.templ' in tempo.yaml", err)
+			}
+			return apperrors.WrapCode(apperrors.CodeBuildStepFailed, "build failed at step '%s'", err, failed.Name)
+		}
+		cmdCtx.Logger.Success("Build completed").WithAttrs("steps", len(steps))
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// runSteps runs the steps in order, recording their outcome, and skips the steps
+// following a failed one. It returns the failed step with its error.
+func runSteps(ctx context.Context, steps []*buildStep) (*buildStep, error) {
+	for i, step := range steps {
+		start := time.Now()
+		err := step.run(ctx)
+		step.Duration = time.Since(start)
+		if err == nil {
+			step.Status = stepOK
+			continue
+		}
+
+		step.Status = stepFailed
+		for _, next := range steps[i+1:] {
+			next.Status = stepSkipped
+		}
+		return step, err
+	}
+	return nil, nil
+}
+
+// runSync runs the sync command with the sync flags of the build.
+func runSync(ctx context.Context, cmdCtx *app.AppContext, cmd *cli.Command) error {
+	args := []string{"sync"}
+	for _, flag := range []string{"prod", "force"} {
+		if cmd.Bool(flag) {
+			args = append(args, "--"+flag)
+		}
+	}
+	return synccmd.SetupSyncCommand(cmdCtx).Run(ctx, args)
+}
+
+// writeSummary writes the outcome of the steps as an aligned table.
+func writeSummary(w io.Writer, steps []*buildStep) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nSTEP\tSTATUS\tDURATION\n")
+	for _, step := range steps {
+		duration := "-"
+		if step.Status != stepSkipped {
+			duration = step.Duration.Round(time.Millisecond).String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", step.Name, step.Status, duration)
+	}
+	return tw.Flush()
+}
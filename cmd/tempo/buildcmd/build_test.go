@@ -0,0 +1,124 @@
+package buildcmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+// setupBuildProject creates a project with one asset to sync and returns its config
+// and the .templ file receiving the asset.
+func setupBuildProject(t *testing.T) (*config.Config, string) {
+	t.Helper()
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	startMarker, endMarker := processor.GuardMarkers(cfg.Templates.GuardMarker)
+	templPath := filepath.Join(cfg.App.GoPackage, "button", "base.templ")
+	testutils.CreateFile(t, filepath.Join(cfg.App.AssetsDir, "button", "base.css"), ".btn { color: red; }")
+	testutils.CreateFile(t, templPath, startMarker+"\n"+endMarker+"\n")
+	return cfg, templPath
+}
+
+func newBuildApp(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Commands: []*cli.Command{
+			SetupBuildCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    ".",
+			}),
+		},
+	}
+}
+
+func TestBuildCommand(t *testing.T) {
+	cfg, templPath := setupBuildProject(t)
+	cfg.Build.Templ = "go tool templ"
+	calls := testutils.StubRunner(t, &runExternal, nil, nil)
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := newBuildApp(cfg).Run(context.Background(), []string{"tempo", "build", "--go-build"}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	if want := []string{"go tool templ generate", "go build ./..."}; !slices.Equal(*calls, want) {
+		t.Errorf("Expected commands %v, got %v", want, *calls)
+	}
+	content, err := os.ReadFile(templPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), ".btn { color: red; }") {
+		t.Errorf("Expected the assets to be synced, got:\n%s", content)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"STEP", "sync", "templ generate", "go build", "Build completed"})
+}
+
+func TestBuildCommand_StepFailure(t *testing.T) {
+	cfg, _ := setupBuildProject(t)
+	goBuild := true
+	cfg.Build.GoBuild = &goBuild
+	calls := testutils.StubRunner(t, &runExternal, nil, map[string]error{"templ generate": errors.New("exit status 1")})
+
+	var runErr error
+	output, err := testutils.CaptureStdout(func() {
+		runErr = newBuildApp(cfg).Run(context.Background(), []string{"tempo", "build"})
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	if runErr == nil || !strings.Contains(runErr.Error(), "templ generate") {
+		t.Fatalf("Expected the templ step to fail, got: %v", runErr)
+	}
+	if code := apperrors.CodeOf(runErr); code != apperrors.CodeBuildStepFailed {
+		t.Errorf("Expected code %s, got %q", apperrors.CodeBuildStepFailed, code)
+	}
+	if want := []string{"templ generate"}; !slices.Equal(*calls, want) {
+		t.Errorf("Expected go build not to run, got %v", *calls)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"failed", "skipped"})
+}
+
+func TestBuildCommand_TemplFlagOverridesConfig(t *testing.T) {
+	cfg, _ := setupBuildProject(t)
+	cfg.Build.Templ = "go tool templ"
+	calls := testutils.StubRunner(t, &runExternal, nil, nil)
+
+	if _, err := testutils.CaptureStdout(func() {
+		if err := newBuildApp(cfg).Run(context.Background(), []string{"tempo", "build", "--templ", "/opt/bin/templ"}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	if want := []string{"/opt/bin/templ generate"}; !slices.Equal(*calls, want) {
+		t.Errorf("Expected commands %v, got %v", want, *calls)
+	}
+}
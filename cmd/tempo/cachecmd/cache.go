@@ -0,0 +1,354 @@
+package cachecmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/rendercache"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/worker"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// CacheEntry describes a file of the sync cache.
+type CacheEntry struct {
+	Name     string    `json:"name"`
+	Path     string    `json:"path"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// LastRunStats summarizes the last sync run saved in the cache.
+type LastRunStats struct {
+	Time           time.Time     `json:"time"`
+	Elapsed        time.Duration `json:"elapsed"`
+	FilesProcessed int           `json:"files_processed"`
+	Failed         int           `json:"failed"`
+	Skipped        int           `json:"skipped"`
+}
+
+// CacheStats holds the sync cache state reported by "cache show".
+type CacheStats struct {
+	Entries    []CacheEntry  `json:"entries"`
+	TotalBytes int64         `json:"total_bytes"`
+	LastSync   *time.Time    `json:"last_sync,omitempty"`
+	LastRun    *LastRunStats `json:"last_run,omitempty"`
+}
+
+// cacheFile is a file the sync command may write to the cache.
+type cacheFile struct {
+	name string
+	path string
+}
+
+// DefaultPruneAge is the age after which "cache prune" removes cache entries.
+const DefaultPruneAge = "30d"
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupCacheCommand creates the "cache" command with its "show", "clear" and "prune" subcommands.
+func SetupCacheCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "cache",
+		Usage:     "Inspect and clean the sync cache (last run timestamp and summary) and the render cache",
+		UsageText: "tempo cache <subcommand> [options]",
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Commands: []*cli.Command{
+			setupCacheShowSubCommand(cmdCtx),
+			setupCacheClearSubCommand(cmdCtx),
+			setupCachePruneSubCommand(cmdCtx),
+		},
+	}
+}
+
+func setupCacheShowSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "show",
+		Usage:     "Show the sync cache entries and statistics",
+		UsageText: "tempo cache show [--json]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Print the cache statistics as JSON",
+			},
+		},
+		Action: runCacheShowSubCommand(cmdCtx),
+	}
+}
+
+func setupCacheClearSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "clear",
+		Usage:     "Remove the sync cache and the render cache, so the next sync processes every file and the templates are rendered again",
+		UsageText: "tempo cache clear",
+		Action:    runCacheClearSubCommand(cmdCtx),
+	}
+}
+
+func setupCachePruneSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "prune",
+		Usage:     "Remove the sync cache entries older than the given age",
+		UsageText: "tempo cache prune [--older-than 30d]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "older-than",
+				Value: DefaultPruneAge,
+				Usage: "Minimum age of the removed entries (e.g. 12h, 30d, 2w)",
+			},
+		},
+		Action: runCachePruneSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runCacheShowSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		stats, err := collectCacheStats(cacheFiles(cmdCtx))
+		if err != nil {
+			return err
+		}
+
+		if cmd.Bool("json") {
+			return writeJSON(os.Stdout, stats)
+		}
+		return writeTable(os.Stdout, stats)
+	}
+}
+
+func runCacheClearSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		removed, err := removeCacheEntries(cacheFiles(cmdCtx), func(CacheEntry) bool { return true })
+		if err != nil {
+			return err
+		}
+
+		if len(removed) == 0 {
+			cmdCtx.Logger.Info("Sync and render caches are already empty")
+			return nil
+		}
+		cmdCtx.Logger.Success("Sync and render caches cleared").WithAttrs("removed", removedNames(removed))
+		return nil
+	}
+}
+
+func runCachePruneSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		olderThan := cmd.String("older-than")
+		age, err := utils.ParseDuration(olderThan)
+		if err != nil {
+			return apperrors.Wrap("invalid value for '--older-than'", err)
+		}
+
+		cutoff := time.Now().Add(-age)
+		removed, err := removeCacheEntries(cacheFiles(cmdCtx), func(entry CacheEntry) bool {
+			return entry.Modified.Before(cutoff)
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(removed) == 0 {
+			cmdCtx.Logger.Info("No sync cache entries to prune").WithAttrs("older_than", olderThan)
+			return nil
+		}
+		cmdCtx.Logger.Success("Sync cache pruned").
+			WithAttrs(
+				"older_than", olderThan,
+				"removed", removedNames(removed),
+			)
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// cacheFiles returns the files of the sync cache: the last run timestamp in the
+// working directory and the last run summary in the tempo root folder, along with the
+// folder of the rendered templates cache.
+func cacheFiles(cmdCtx *app.AppContext) []cacheFile {
+	return []cacheFile{
+		{name: "last-run", path: filepath.Join(cmdCtx.CWD, worker.LastRunFile)},
+		{name: "last-summary", path: filepath.Join(cmdCtx.Config.TempoRoot, worker.LastSummaryFile)},
+		{name: "render-cache", path: rendercache.Path(cmdCtx.Config.TempoRoot)},
+	}
+}
+
+// readCacheEntries returns the entries of the existing cache files.
+func readCacheEntries(files []cacheFile) ([]CacheEntry, error) {
+	entries := []CacheEntry{}
+	for _, file := range files {
+		info, err := os.Stat(file.path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, apperrors.Wrap("failed to read cache entry", err, file.path)
+		}
+		size := info.Size()
+		if info.IsDir() {
+			if size, err = dirSize(file.path); err != nil {
+				return nil, apperrors.Wrap("failed to read cache entry", err, file.path)
+			}
+		}
+		entries = append(entries, CacheEntry{
+			Name:     file.name,
+			Path:     file.path,
+			Size:     size,
+			Modified: info.ModTime(),
+		})
+	}
+	return entries, nil
+}
+
+// collectCacheStats gathers the cache entries along with the last sync time and
+// the outcome of the last run, when cached.
+func collectCacheStats(files []cacheFile) (*CacheStats, error) {
+	entries, err := readCacheEntries(files)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &CacheStats{Entries: entries}
+	for _, entry := range entries {
+		stats.TotalBytes += entry.Size
+
+		switch entry.Name {
+		case "last-run":
+			if lastSync, ok := worker.LastRunTime(filepath.Dir(entry.Path)); ok {
+				stats.LastSync = &lastSync
+			}
+		case "last-summary":
+			summary, ok, err := worker.LoadRunSummary(entry.Path)
+			if err != nil {
+				return nil, apperrors.Wrap("failed to read the last run summary", err, entry.Path)
+			}
+			if ok {
+				stats.LastRun = &LastRunStats{
+					Time:           summary.Time,
+					Elapsed:        summary.Elapsed,
+					FilesProcessed: summary.FilesProcessed,
+					Failed:         len(summary.Failed),
+					Skipped:        len(summary.Skipped),
+				}
+			}
+		}
+	}
+	return stats, nil
+}
+
+// removeCacheEntries removes the existing cache files whose entry matches and
+// returns the removed entries.
+func removeCacheEntries(files []cacheFile, match func(CacheEntry) bool) ([]CacheEntry, error) {
+	entries, err := readCacheEntries(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []CacheEntry
+	for _, entry := range entries {
+		if !match(entry) {
+			continue
+		}
+		if err := os.RemoveAll(entry.Path); err != nil {
+			return removed, apperrors.Wrap("failed to remove cache entry", err, entry.Path)
+		}
+		removed = append(removed, entry)
+	}
+	return removed, nil
+}
+
+// dirSize returns the total size of the files of dir.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// removedNames returns the names of the removed entries, comma-separated.
+func removedNames(entries []CacheEntry) string {
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// writeJSON writes the cache statistics as indented JSON.
+func writeJSON(w io.Writer, stats *CacheStats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return apperrors.Wrap("failed to marshal cache stats", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// writeTable writes the cache statistics as an aligned table.
+func writeTable(w io.Writer, stats *CacheStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if len(stats.Entries) == 0 {
+		fmt.Fprintf(tw, "Sync cache is empty\n")
+		return tw.Flush()
+	}
+
+	for _, entry := range stats.Entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			entry.Name, entry.Path, utils.FormatBytes(entry.Size), entry.Modified.Format(time.DateTime))
+	}
+	fmt.Fprintf(tw, "Total\t\t%s\t\n", utils.FormatBytes(stats.TotalBytes))
+
+	if stats.LastSync != nil {
+		fmt.Fprintf(tw, "\nLast sync\t%s\n", stats.LastSync.Format(time.DateTime))
+	}
+	if stats.LastRun != nil {
+		fmt.Fprintf(tw, "\nLast run\t%s (%s)\n", stats.LastRun.Time.Format(time.DateTime), stats.LastRun.Elapsed.Round(time.Millisecond))
+		fmt.Fprintf(tw, "  Processed\t%d\n", stats.LastRun.FilesProcessed)
+		fmt.Fprintf(tw, "  Failed\t%d\n", stats.LastRun.Failed)
+		fmt.Fprintf(tw, "  Skipped\t%d\n", stats.LastRun.Skipped)
+	}
+
+	return tw.Flush()
+}
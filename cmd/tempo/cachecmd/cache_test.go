@@ -0,0 +1,197 @@
+package cachecmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/rendercache"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/indaco/tempo/internal/worker"
+	"github.com/urfave/cli/v3"
+)
+
+// setupCacheProject creates a project with a last run timestamp written a day ago
+// and a last run summary written 60 days ago.
+func setupCacheProject(t *testing.T) (string, *config.Config) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	lastRunPath := filepath.Join(tempDir, worker.LastRunFile)
+	lastRun := time.Now().Add(-24 * time.Hour)
+	testutils.CreateFile(t, lastRunPath, strconv.FormatInt(lastRun.Unix(), 10))
+	if err := os.Chtimes(lastRunPath, lastRun, lastRun); err != nil {
+		t.Fatalf("Failed to change file times: %v", err)
+	}
+
+	summaryPath := filepath.Join(cfg.TempoRoot, worker.LastSummaryFile)
+	summary := worker.RunSummary{
+		Time:           time.Now().Add(-60 * 24 * time.Hour),
+		Elapsed:        time.Second,
+		FilesProcessed: 3,
+		Failed:         map[string]string{"a.css": "boom"},
+		Skipped:        map[string]worker.SkipType{"b.txt": worker.SkipUnsupportedFile, "c.css": worker.SkipMissingTemplFile},
+	}
+	if err := os.MkdirAll(cfg.TempoRoot, 0755); err != nil {
+		t.Fatalf("Failed to create tempo root: %v", err)
+	}
+	if err := worker.SaveRunSummary(summaryPath, summary); err != nil {
+		t.Fatalf("Failed to save run summary: %v", err)
+	}
+	if err := os.Chtimes(summaryPath, summary.Time, summary.Time); err != nil {
+		t.Fatalf("Failed to change file times: %v", err)
+	}
+
+	return tempDir, cfg
+}
+
+func runCache(t *testing.T, tempDir string, cfg *config.Config, args ...string) string {
+	t.Helper()
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupCacheCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    tempDir,
+			}),
+		},
+	}
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), append([]string{"tempo", "cache"}, args...)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	return output
+}
+
+func TestCacheCommand_ShowJSON(t *testing.T) {
+	tempDir, cfg := setupCacheProject(t)
+
+	output := runCache(t, tempDir, cfg, "show", "--json")
+
+	var stats CacheStats
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		t.Fatalf("Failed to parse JSON output %q: %v", output, err)
+	}
+
+	if len(stats.Entries) != 2 {
+		t.Fatalf("Expected 2 cache entries, got %+v", stats.Entries)
+	}
+	if stats.TotalBytes != stats.Entries[0].Size+stats.Entries[1].Size {
+		t.Errorf("Expected the total size of the entries, got %d", stats.TotalBytes)
+	}
+	if stats.LastSync == nil {
+		t.Error("Expected the last sync time")
+	}
+	if stats.LastRun == nil {
+		t.Fatal("Expected the last run statistics")
+	}
+	if stats.LastRun.FilesProcessed != 3 || stats.LastRun.Failed != 1 || stats.LastRun.Skipped != 2 {
+		t.Errorf("Unexpected last run statistics: %+v", stats.LastRun)
+	}
+}
+
+func TestCacheCommand_ShowTable(t *testing.T) {
+	tempDir, cfg := setupCacheProject(t)
+
+	output := runCache(t, tempDir, cfg, "show")
+	testutils.ValidateCLIOutput(t, output, []string{"last-run", "last-summary", "Total", "Last sync", "Processed"})
+
+	runCache(t, tempDir, cfg, "clear")
+	output = runCache(t, tempDir, cfg, "show")
+	testutils.ValidateCLIOutput(t, output, []string{"Sync cache is empty"})
+}
+
+func TestCacheCommand_Clear(t *testing.T) {
+	tempDir, cfg := setupCacheProject(t)
+
+	output := runCache(t, tempDir, cfg, "clear")
+	testutils.ValidateCLIOutput(t, output, []string{"Sync and render caches cleared", "last-run, last-summary"})
+
+	for _, path := range []string{
+		filepath.Join(tempDir, worker.LastRunFile),
+		filepath.Join(cfg.TempoRoot, worker.LastSummaryFile),
+	} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", path)
+		}
+	}
+
+	output = runCache(t, tempDir, cfg, "clear")
+	testutils.ValidateCLIOutput(t, output, []string{"Sync and render caches are already empty"})
+}
+
+func TestCacheCommand_ClearRenderCache(t *testing.T) {
+	tempDir, cfg := setupCacheProject(t)
+
+	renderCache := rendercache.Open(cfg.TempoRoot, true, nil)
+	if err := renderCache.Put("key", "rendered"); err != nil {
+		t.Fatalf("Failed to fill the render cache: %v", err)
+	}
+
+	var stats CacheStats
+	if err := json.Unmarshal([]byte(runCache(t, tempDir, cfg, "show", "--json")), &stats); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(stats.Entries) != 3 || stats.Entries[2].Name != "render-cache" || stats.Entries[2].Size != int64(len("rendered")) {
+		t.Fatalf("Expected the render cache entry with the size of its files, got %+v", stats.Entries)
+	}
+
+	output := runCache(t, tempDir, cfg, "clear")
+	testutils.ValidateCLIOutput(t, output, []string{"last-run, last-summary, render-cache"})
+	if _, err := os.Stat(renderCache.Dir); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be removed", renderCache.Dir)
+	}
+}
+
+func TestCacheCommand_Prune(t *testing.T) {
+	tempDir, cfg := setupCacheProject(t)
+
+	output := runCache(t, tempDir, cfg, "prune", "--older-than", "30d")
+	testutils.ValidateCLIOutput(t, output, []string{"Sync cache pruned", "last-summary"})
+
+	if _, err := os.Stat(filepath.Join(cfg.TempoRoot, worker.LastSummaryFile)); !os.IsNotExist(err) {
+		t.Error("Expected the last run summary to be pruned")
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, worker.LastRunFile)); err != nil {
+		t.Errorf("Expected the last run timestamp to be kept, got %v", err)
+	}
+
+	output = runCache(t, tempDir, cfg, "prune", "--older-than", "2w")
+	testutils.ValidateCLIOutput(t, output, []string{"No sync cache entries to prune"})
+}
+
+func TestCacheCommand_PruneInvalidAge(t *testing.T) {
+	tempDir, cfg := setupCacheProject(t)
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupCacheCommand(&app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}),
+		},
+	}
+	err := cliApp.Run(context.Background(), []string{"tempo", "cache", "prune", "--older-than", "soon"})
+	if err == nil {
+		t.Fatal("Expected an error for an invalid age")
+	}
+}
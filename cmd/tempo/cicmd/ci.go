@@ -0,0 +1,432 @@
+package cicmd
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/indaco/tempo/cmd/tempo/fmtcmd"
+	"github.com/indaco/tempo/cmd/tempo/statuscmd"
+	"github.com/indaco/tempo/cmd/tempo/synccmd"
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/header"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/sarif"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/version"
+	"github.com/indaco/tempo/internal/worker"
+	"github.com/urfave/cli/v3"
+)
+
+// Names of the checks chained by "tempo ci", in their default order.
+const (
+	checkConfig = "config"
+	checkDoctor = "doctor"
+	checkFmt    = "fmt"
+	checkSync   = "sync"
+)
+
+// Statuses of a check.
+const (
+	statusOK      = "ok"
+	statusFailed  = "failed"
+	statusSkipped = "skipped"
+)
+
+// ruleMissingFolder is the SARIF rule of the folders missing for the doctor check.
+const ruleMissingFolder = "tempo/missing-folder"
+
+// checkNames lists the available checks in their default order.
+var checkNames = []string{checkConfig, checkDoctor, checkFmt, checkSync}
+
+// lookPath finds the executables required by the doctor check. Tests replace it.
+var lookPath = exec.LookPath
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// check is a check of the CI run and its outcome.
+type check struct {
+	Name       string        `json:"name"`
+	Status     string        `json:"status"`
+	Code       string        `json:"code,omitempty"`
+	Detail     string        `json:"detail,omitempty"`
+	DurationMs int64         `json:"duration_ms"`
+	Duration   time.Duration `json:"-"`
+	run        func(ctx context.Context) error
+}
+
+// report is the aggregated outcome of the checks, written with --report.
+type report struct {
+	Passed bool     `json:"passed"`
+	Checks []*check `json:"checks"`
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupCICommand creates the "ci" command chaining the project checks.
+func SetupCICommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "ci",
+		Usage:     "Run the config, doctor, fmt and sync checks in one go, with an aggregated report and a failing exit status",
+		UsageText: "tempo ci [options]",
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "steps",
+				Usage: "Checks to run, in order: config, doctor, fmt, sync (default: ci.steps or all)",
+			},
+			&cli.BoolFlag{
+				Name:  "fail-fast",
+				Usage: "Stop at the first failing check (default: ci.fail_fast)",
+			},
+			&cli.StringFlag{
+				Name:  "report",
+				Usage: "Write the outcome of the checks to a JSON file (default: ci.report)",
+			},
+			&cli.StringFlag{
+				Name:  "sarif",
+				Usage: "Write the problems found by the checks to a SARIF file, for code scanning annotations",
+			},
+		},
+		Action: runCICommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runCICommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		ciConfig := cmdCtx.Config.CI
+
+		// Step 1: Resolve the checks to run
+		names := ciConfig.Steps
+		if cmd.IsSet("steps") {
+			names = cmd.StringSlice("steps")
+		}
+		var annotations *sarif.Log
+		if cmd.String("sarif") != "" {
+			annotations = sarif.New("tempo", version.GetVersion(), "https://github.com/indaco/tempo")
+		}
+		checks, err := buildChecks(cmdCtx, names, annotations)
+		if err != nil {
+			return err
+		}
+		failFast := ciConfig.FailFast
+		if cmd.IsSet("fail-fast") {
+			failFast = cmd.Bool("fail-fast")
+		}
+
+		// Step 2: Run them
+		failed := runChecks(ctx, checks, failFast)
+
+		// Step 3: Report their outcome
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+		if err := writeSummary(os.Stdout, checks); err != nil {
+			return err
+		}
+		if reportFile := cmp.Or(cmd.String("report"), ciConfig.Report); reportFile != "" {
+			if err := writeReport(reportFile, checks, len(failed) == 0); err != nil {
+				return err
+			}
+			cmdCtx.Logger.Info("CI report written").WithAttrs("file", reportFile)
+		}
+		if sarifFile := cmd.String("sarif"); sarifFile != "" {
+			if err := writeSARIF(sarifFile, annotations); err != nil {
+				return err
+			}
+			cmdCtx.Logger.Info("SARIF results written").WithAttrs("file", sarifFile, "results", len(annotations.Results()))
+		}
+		cmdCtx.Summary = map[string]any{"checks": len(checks), "failed": failed}
+
+		if len(failed) > 0 {
+			return apperrors.WrapCode(apperrors.CodeCIFailed, "CI failed: %s of %s check(s) failed: %s",
+				len(failed), len(checks), strings.Join(failed, ", "))
+		}
+		cmdCtx.Logger.Success("All CI checks passed").WithAttrs("checks", len(checks))
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Checks                                                                    */
+/* ------------------------------------------------------------------------- */
+
+// buildChecks returns the checks named in names, in their order, or all the checks when
+// names is empty. The problems they find are added to annotations, unless nil.
+func buildChecks(cmdCtx *app.AppContext, names []string, annotations *sarif.Log) ([]*check, error) {
+	if len(names) == 0 {
+		names = checkNames
+	}
+
+	runs := map[string]func(ctx context.Context) error{
+		checkConfig: func(ctx context.Context) error { return checkConfigFile(cmdCtx) },
+		checkDoctor: func(ctx context.Context) error { return checkPrerequisites(cmdCtx, annotations) },
+		checkFmt: func(ctx context.Context) error {
+			err := fmtcmd.SetupFmtCommand(cmdCtx).Run(ctx, []string{"fmt", "--check"})
+			if err != nil && annotations != nil {
+				changed, listErr := fmtcmd.Unformatted(cmdCtx.Config)
+				if listErr == nil {
+					listErr = fmtcmd.AddSARIFResults(annotations, cmdCtx.CWD, changed)
+				}
+				return errors.Join(err, listErr)
+			}
+			return err
+		},
+		checkSync: func(ctx context.Context) error {
+			if err := synccmd.SetupSyncCommand(cmdCtx).Run(ctx, []string{"sync", "--check"}); err != nil {
+				return err
+			}
+			return checkOutputsInSync(cmdCtx, annotations)
+		},
+	}
+
+	checks := make([]*check, 0, len(names))
+	for _, name := range names {
+		run, ok := runs[name]
+		if !ok {
+			return nil, apperrors.Wrap("unknown CI check '%s'; valid checks are: %s", name, strings.Join(checkNames, ", "))
+		}
+		if slices.ContainsFunc(checks, func(c *check) bool { return c.Name == name }) {
+			continue
+		}
+		if annotations != nil {
+			run = annotateFailure(cmdCtx, annotations, name, run)
+		}
+		checks = append(checks, &check{Name: name, run: run})
+	}
+	return checks, nil
+}
+
+// runChecks runs the checks in order, recording their outcome, and returns the names of
+// the failed ones. With failFast, the checks following a failed one are skipped.
+func runChecks(ctx context.Context, checks []*check, failFast bool) []string {
+	var failed []string
+	for i, c := range checks {
+		start := time.Now()
+		err := c.run(ctx)
+		c.Duration = time.Since(start)
+		c.DurationMs = c.Duration.Milliseconds()
+		if err == nil {
+			c.Status = statusOK
+			continue
+		}
+
+		c.Status = statusFailed
+		c.Code = apperrors.CodeOf(err)
+		c.Detail = errorDetail(err)
+		failed = append(failed, c.Name)
+		if failFast {
+			for _, next := range checks[i+1:] {
+				next.Status = statusSkipped
+			}
+			break
+		}
+	}
+	return failed
+}
+
+// checkConfigFile checks that the project config file only holds known settings and
+// that the values parsed by the commands are valid.
+func checkConfigFile(cmdCtx *app.AppContext) error {
+	path, err := cmdCtx.ConfigPath()
+	switch {
+	case err == nil:
+		if err := config.ValidateFile(path); err != nil {
+			return apperrors.WrapCode(apperrors.CodeConfigInvalid, "invalid configuration", err)
+		}
+	case apperrors.CodeOf(err) != apperrors.CodeConfigNotFound:
+		return err
+	}
+
+	cfg := cmdCtx.Config
+	validators := []func() error{
+		func() error { _, err := cfg.FileModes.Policy(); return err },
+		func() error { _, _, err := cfg.Processor.Retry.Delays(); return err },
+		func() error { _, err := cfg.Notifications.DeliveryTimeout(); return err },
+		func() error { _, err := cfg.History.Age(); return err },
+		func() error { _, err := cfg.Journal.Age(); return err },
+		cfg.Layout.Validate,
+		func() error { _, err := header.New(cfg.Templates.Header, cfg.Templates.UserData); return err },
+		func() error { _, err := worker.ConfigLanguages(cfg.Processor.Languages); return err },
+		func() error { _, err := worker.ParseIOThrottle(cfg.Processor.IOThrottle); return err },
+		func() error {
+			_, err := worker.ParseAssetBudget(cfg.Processor.Budgets.CSS, cfg.Processor.Budgets.JS, cfg.Processor.Budgets.OnExceed)
+			return err
+		},
+	}
+	if cfg.Processor.Symlinks != "" {
+		validators = append(validators, func() error { return worker.ValidateSymlinkPolicy(cfg.Processor.Symlinks) })
+	}
+	for _, validate := range validators {
+		if err := validate(); err != nil {
+			return apperrors.WrapCode(apperrors.CodeConfigInvalid, "invalid configuration", err)
+		}
+	}
+	return nil
+}
+
+// checkPrerequisites checks the project setup: the Go module when required, the
+// templates and actions folders and the templ executable. The missing folders are added
+// to annotations, unless nil.
+func checkPrerequisites(cmdCtx *app.AppContext, annotations *sarif.Log) error {
+	cfg := cmdCtx.Config
+	if err := cmdCtx.CheckTempoProject(cfg.App.RequiresGoModule()); err != nil {
+		return err
+	}
+
+	missing, err := utils.CheckMissingFolders(map[string]string{
+		"templates_dir": cfg.Paths.TemplatesDir,
+		"actions_dir":   cfg.Paths.ActionsDir,
+	})
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		folders := make([]string, 0, len(missing))
+		for _, folder := range missing {
+			folders = append(folders, folder)
+		}
+		slices.Sort(folders)
+		if annotations != nil {
+			annotations.AddRule(ruleMissingFolder, "Folder set in the configuration is missing")
+			for _, folder := range folders {
+				annotations.AddResult(ruleMissingFolder, sarif.LevelError,
+					fmt.Sprintf("Folder %s is missing. Run 'tempo component define' first", folder), cmdCtx.CWD, configFile(cmdCtx), 0)
+			}
+		}
+		return apperrors.WrapCode(apperrors.CodeTemplatesNotFound, "missing folder(s): %s. Run 'tempo component define' first", strings.Join(folders, ", "))
+	}
+
+	templ := cfg.Build.TemplCommand()[0]
+	if _, err := lookPath(templ); err != nil {
+		return apperrors.Wrap("templ command '%s' not found. Install it or set 'build.templ' in tempo.yaml", err, templ)
+	}
+	return nil
+}
+
+// checkOutputsInSync checks that no .templ file is older than its asset, missing or
+// without guard markers, as reported by "tempo status". The files out of sync are added
+// to annotations, unless nil.
+func checkOutputsInSync(cmdCtx *app.AppContext, annotations *sarif.Log) error {
+	status, err := statuscmd.CollectStatus(cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+	if annotations != nil {
+		statuscmd.AddSARIFResults(annotations, cmdCtx.CWD, status)
+	}
+	if len(status.Files) == 0 {
+		return nil
+	}
+
+	files := make([]string, 0, len(status.Files))
+	for _, f := range status.Files {
+		files = append(files, fmt.Sprintf("%s (%s)", f.Asset, f.Status))
+	}
+	return apperrors.WrapCode(apperrors.CodeOutOfSync, "%s asset(s) out of sync: %s. Run 'tempo sync'",
+		len(status.Files), strings.Join(files, ", "))
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// annotateFailure wraps the run of the check name so that a failure it did not add to
+// annotations itself is reported on the config file.
+func annotateFailure(cmdCtx *app.AppContext, annotations *sarif.Log, name string, run func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		results := len(annotations.Results())
+		err := run(ctx)
+		if err != nil && len(annotations.Results()) == results {
+			rule := "tempo/ci-" + name
+			annotations.AddRule(rule, fmt.Sprintf("The %s check failed", name))
+			annotations.AddResult(rule, sarif.LevelError, errorDetail(err), cmdCtx.CWD, configFile(cmdCtx), 0)
+		}
+		return err
+	}
+}
+
+// configFile returns the path of the project config file, where the problems without a
+// file of their own are reported.
+func configFile(cmdCtx *app.AppContext) string {
+	if path, err := cmdCtx.ConfigPath(); err == nil {
+		return path
+	}
+	return config.TempoConfigFiles[0]
+}
+
+// errorDetail returns the messages of the error chain of err, joined with ": ".
+func errorDetail(err error) string {
+	var messages []string
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		msg := e.Error()
+		if len(messages) > 0 && strings.Contains(messages[len(messages)-1], msg) {
+			continue
+		}
+		messages = append(messages, msg)
+	}
+	return strings.Join(messages, ": ")
+}
+
+// writeSummary writes the outcome of the checks as an aligned table.
+func writeSummary(w io.Writer, checks []*check) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nCHECK\tSTATUS\tDURATION\tDETAIL\n")
+	for _, c := range checks {
+		duration := "-"
+		if c.Status != statusSkipped {
+			duration = c.Duration.Round(time.Millisecond).String()
+		}
+		detail := c.Detail
+		if c.Code != "" {
+			detail = "[" + c.Code + "] " + detail
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Name, c.Status, duration, detail)
+	}
+	return tw.Flush()
+}
+
+// writeSARIF writes the problems found by the checks to the SARIF file at path.
+func writeSARIF(path string, annotations *sarif.Log) error {
+	var buf bytes.Buffer
+	if err := annotations.Write(&buf); err != nil {
+		return err
+	}
+	if err := utils.WriteToFile(path, buf.Bytes()); err != nil {
+		return apperrors.Wrap("failed to write SARIF results", err, path)
+	}
+	return nil
+}
+
+// writeReport writes the outcome of the checks to the JSON file at path.
+func writeReport(path string, checks []*check, passed bool) error {
+	data, err := json.MarshalIndent(report{Passed: passed, Checks: checks}, "", "  ")
+	if err != nil {
+		return apperrors.Wrap("failed to marshal CI report", err)
+	}
+	if err := utils.WriteToFile(path, append(data, '\n')); err != nil {
+		return apperrors.Wrap("failed to write CI report", err, path)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package cicmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/sarif"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+// setupCITest creates a tempo project passing all the checks, with templ found.
+func setupCITest(t *testing.T) (*cli.Command, *app.AppContext) {
+	t.Helper()
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+	for _, dir := range []string{
+		filepath.Join(cfg.Paths.TemplatesDir, "component"),
+		filepath.Join(cfg.Paths.TemplatesDir, "component-variant"),
+		cfg.Paths.ActionsDir,
+		cfg.App.AssetsDir,
+		cfg.App.GoPackage,
+	} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create folder: %v", err)
+		}
+	}
+
+	original := lookPath
+	lookPath = func(file string) (string, error) { return "/usr/bin/" + file, nil }
+	t.Cleanup(func() { lookPath = original })
+
+	cliCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}
+	return &cli.Command{Commands: []*cli.Command{SetupCICommand(cliCtx)}}, cliCtx
+}
+
+func runCI(t *testing.T, cliApp *cli.Command, args ...string) (string, error) {
+	t.Helper()
+	var runErr error
+	output, err := testutils.CaptureStdout(func() {
+		runErr = cliApp.Run(context.Background(), append([]string{"tempo", "ci"}, args...))
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	return output, runErr
+}
+
+func TestCICommand_Passes(t *testing.T) {
+	cliApp, cliCtx := setupCITest(t)
+	reportFile := filepath.Join(cliCtx.CWD, "ci.json")
+
+	output, err := runCI(t, cliApp, "--report", reportFile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, output)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"CHECK", "config", "doctor", "fmt", "sync", "All CI checks passed"})
+
+	data, err := os.ReadFile(reportFile)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	var got report
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+	if !got.Passed || len(got.Checks) != 4 {
+		t.Errorf("Expected 4 passed checks, got %+v", got)
+	}
+}
+
+func TestCICommand_Failures(t *testing.T) {
+	t.Run("unknown config key", func(t *testing.T) {
+		cliApp, cliCtx := setupCITest(t)
+		configPath := filepath.Join(cliCtx.CWD, "tempo.yaml")
+		content, _ := os.ReadFile(configPath)
+		if err := os.WriteFile(configPath, append(content, []byte("\nunknown_section:\n  enabled: true\n")...), 0644); err != nil {
+			t.Fatalf("Failed to update config file: %v", err)
+		}
+
+		sarifFile := filepath.Join(cliCtx.CWD, "ci.sarif")
+		output, err := runCI(t, cliApp, "--sarif", sarifFile)
+		if apperrors.CodeOf(err) != apperrors.CodeCIFailed {
+			t.Fatalf("Expected a CI error, got %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{apperrors.CodeConfigInvalid, "unknown_section"})
+		if failed := cliCtx.Summary["failed"].([]string); len(failed) != 1 || failed[0] != checkConfig {
+			t.Errorf("Expected only the config check to fail, got %v", failed)
+		}
+
+		// The failure is annotated on the config file
+		data, _ := os.ReadFile(sarifFile)
+		var log sarif.Log
+		if err := json.Unmarshal(data, &log); err != nil {
+			t.Fatalf("Invalid SARIF file: %v\n%s", err, data)
+		}
+		if results := log.Results(); len(results) != 1 || results[0].RuleID != "tempo/ci-config" ||
+			results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI != "tempo.yaml" {
+			t.Errorf("Expected the config failure on tempo.yaml, got %+v", results)
+		}
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		cliApp, cliCtx := setupCITest(t)
+		lookPath = func(file string) (string, error) { return "", exec.ErrNotFound }
+
+		output, err := runCI(t, cliApp, "--steps", "doctor", "--steps", "fmt", "--fail-fast")
+		if err == nil {
+			t.Fatalf("Expected an error for the missing templ command")
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"templ command 'templ' not found", "skipped"})
+		if got := cliCtx.Summary["checks"]; got != 2 {
+			t.Errorf("Expected 2 checks, got %v", got)
+		}
+	})
+
+	t.Run("budget exceeded", func(t *testing.T) {
+		cliApp, cliCtx := setupCITest(t)
+		cliCtx.Config.Processor.Budgets = config.Budgets{CSS: "1KB"}
+		testutils.CreateFile(t, filepath.Join(cliCtx.Config.App.AssetsDir, "button", "base.css"), strings.Repeat("a", 2048))
+
+		output, err := runCI(t, cliApp, "--steps", "sync")
+		if err == nil {
+			t.Fatalf("Expected an error for the exceeded budget")
+		}
+		testutils.ValidateCLIOutput(t, output, []string{apperrors.CodeBudgetExceeded})
+	})
+
+	t.Run("stale output", func(t *testing.T) {
+		cliApp, cliCtx := setupCITest(t)
+		cfg := cliCtx.Config
+		asset := filepath.Join(cfg.App.AssetsDir, "button", "css", "base.css")
+		templ := filepath.Join(cfg.App.GoPackage, "button", "css", "base.templ")
+		testutils.CreateFile(t, templ, "package button\n\n"+
+			"/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n"+
+			"/* [tempo] END */\n")
+		testutils.CreateFile(t, asset, ".btn { color: red; }")
+
+		// The asset is edited after the last sync
+		past := time.Now().Add(-time.Hour)
+		if err := os.Chtimes(templ, past, past); err != nil {
+			t.Fatalf("Failed to change file times: %v", err)
+		}
+
+		output, err := runCI(t, cliApp, "--steps", "sync")
+		if apperrors.CodeOf(err) != apperrors.CodeCIFailed {
+			t.Fatalf("Expected a CI error for the stale output, got %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{apperrors.CodeOutOfSync, asset + " (stale)"})
+	})
+
+	t.Run("sarif annotations", func(t *testing.T) {
+		cliApp, cliCtx := setupCITest(t)
+		cfg := cliCtx.Config
+		if err := os.RemoveAll(cfg.Paths.ActionsDir); err != nil {
+			t.Fatalf("Failed to remove the actions folder: %v", err)
+		}
+		asset := filepath.Join(cfg.App.AssetsDir, "button", "css", "base.css")
+		templ := filepath.Join(cfg.App.GoPackage, "button", "css", "base.templ")
+		testutils.CreateFile(t, templ, "package button\n")
+		testutils.CreateFile(t, asset, ".btn { color: red; }")
+		sarifFile := filepath.Join(cliCtx.CWD, "ci.sarif")
+
+		if _, err := runCI(t, cliApp, "--steps", "doctor", "--steps", "sync", "--sarif", sarifFile); err == nil {
+			t.Fatal("Expected the doctor and sync checks to fail")
+		}
+
+		data, err := os.ReadFile(sarifFile)
+		if err != nil {
+			t.Fatalf("Failed to read the SARIF file: %v", err)
+		}
+		var log sarif.Log
+		if err := json.Unmarshal(data, &log); err != nil {
+			t.Fatalf("Invalid SARIF file: %v\n%s", err, data)
+		}
+		var got []string
+		for _, result := range log.Results() {
+			got = append(got, result.RuleID+" "+result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		}
+		rel, _ := filepath.Rel(cliCtx.CWD, templ)
+		expected := []string{ruleMissingFolder + " tempo.yaml", "tempo/missing-guard-markers " + filepath.ToSlash(rel)}
+		if !slices.Equal(got, expected) {
+			t.Errorf("Expected the results %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("unknown check", func(t *testing.T) {
+		cliApp, _ := setupCITest(t)
+		_, err := runCI(t, cliApp, "--steps", "lint")
+		if err == nil || !strings.Contains(err.Error(), "unknown CI check") {
+			t.Errorf("Expected an unknown check error, got %v", err)
+		}
+		var tempoErr *apperrors.TempoError
+		if !errors.As(err, &tempoErr) {
+			t.Errorf("Expected a TempoError, got %T", err)
+		}
+	})
+}
@@ -7,18 +7,23 @@ import (
 	"github.com/urfave/cli/v3"
 )
 
-// SetupComponentCommand creates the "component" command with its "define" and "new" subcommand.
+// SetupComponentCommand creates the "component" command with its "define", "new" and "rename" subcommands.
 func SetupComponentCommand(cmdCtx *app.AppContext) *cli.Command {
 	return &cli.Command{
 		Name:      "component",
 		Usage:     "Define component templates and generate instances from them",
 		UsageText: "tempo component <subcommand> [options] [arguments]",
 		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
-			return ctx, app.IsTempoProject(cmdCtx.CWD)
+			return ctx, cmdCtx.CheckTempoProject(cmdCtx.Config.App.RequiresGoModule())
 		},
 		Commands: []*cli.Command{
 			setupComponentDefineSubCommand(cmdCtx),
 			setupComponentNewSubCommand(cmdCtx),
+			setupComponentRenameSubCommand(cmdCtx),
+			setupComponentEjectSubCommand(cmdCtx),
+			setupComponentWhereUsedSubCommand(cmdCtx),
+			setupComponentDeprecateSubCommand(cmdCtx),
+			setupComponentRegenerateSubCommand(cmdCtx),
 		},
 	}
 }
@@ -33,7 +33,7 @@ func TestSetupComponentCommand(t *testing.T) {
 	}
 
 	// Check Subcommands Exist
-	subcommands := map[string]bool{"define": false, "new": false}
+	subcommands := map[string]bool{"define": false, "new": false, "rename": false, "eject": false, "where-used": false, "deprecate": false, "regenerate": false}
 	for _, sub := range command.Commands {
 		if _, exists := subcommands[sub.Name]; exists {
 			subcommands[sub.Name] = true
@@ -2,6 +2,7 @@ package componentcmd
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 
 	"github.com/indaco/tempo/internal/app"
@@ -42,14 +43,31 @@ func getDefineFlags() []cli.Flag {
 			Name:  "js",
 			Usage: "Whether or not JS is needed for the component",
 		},
+		&cli.BoolFlag{
+			Name:  "ts-decls",
+			Usage: "Generate a TypeScript declaration stub (.d.ts) next to the JS asset",
+		},
+		&cli.BoolFlag{
+			Name:  "minimal",
+			Usage: "Define only the .templ file and the base CSS, leaving out the themes and the JS",
+		},
+		&cli.BoolFlag{
+			Name:  "full",
+			Usage: "Define the JS, the docs and a render test on top of the default files, generated by every 'component new'",
+		},
 		&cli.BoolFlag{
 			Name:  "force",
 			Usage: "Force overwriting if already exists",
 		},
+		helpers.YesFlag(),
 		&cli.BoolFlag{
 			Name:  "dry-run",
 			Usage: "Preview actions without making changes",
 		},
+		&cli.StringFlag{
+			Name:  "props",
+			Usage: "Props schema file (YAML list of props with name, type, default, description) exposed to the component templates as .Props",
+		},
 	}
 }
 
@@ -62,6 +80,10 @@ func runComponentDefineSubCommand(cmdCtx *app.AppContext) func(ctx context.Conte
 		helpers.EnableLoggerIndentation(cmdCtx.Logger)
 
 		// Step 1: Create template data
+		tier, err := resolveComponentTier(cmd)
+		if err != nil {
+			return err
+		}
 		data, err := createTemplateData(cmd, cmdCtx.Config)
 		if err != nil {
 			return apperrors.Wrap("Failed to create template data for component", err)
@@ -71,36 +93,58 @@ func runComponentDefineSubCommand(cmdCtx *app.AppContext) func(ctx context.Conte
 			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.")
 		}
 
-		// Step 2: Check if templates folder for component already exists
+		// Step 2: Validate the props schema, if any
+		var propsSchema []byte
+		if propsFile := cmd.String("props"); propsFile != "" {
+			if propsSchema, err = readPropsSchema(propsFile); err != nil {
+				return err
+			}
+		}
+
+		// Step 3: Check if templates folder for component already exists
 		// Display a warning and stop if `--force` is not set
 		outputPath := filepath.Join(data.TemplatesDir, "component")
 		exists, err := utils.DirExists(outputPath)
 		if err != nil {
 			return err
 		} else if exists {
-			helpers.CheckEntityForDefine("component", outputPath, data.Force, cmdCtx.Logger)
+			helpers.CheckEntityForDefine("component", outputPath, data.Force, helpers.AssumeYes(cmd, cmdCtx.Config), cmdCtx.Logger)
 
 			if !data.Force {
 				return nil
 			}
 		}
 
-		// Step 3: Retrieve component actions
-		builtInActions, err := generator.BuildComponentActions(generator.CopyActionID, data.Force, data.WithJs)
+		// Step 4: Retrieve component actions
+		builtInActions, err := generator.BuildComponentTierActions(generator.CopyActionID, tier, data.Force, data.WithJs, data.WithTsDecls)
 		if err != nil {
 			return apperrors.Wrap("Failed to build component actions", err)
 		}
 
-		// Step 4: Process actions
+		// Step 5: Process actions
 		if err := generator.ProcessActions(ctx, cmdCtx.Logger, builtInActions, data); err != nil {
 			return apperrors.Wrap("Failed to process actions for component", err)
 		}
 
 		if !data.DryRun {
-			// Step 5: Log success and asset information
+			// Step 6: Check that the CSS and JS templates can be synced
+			if err := generator.CheckTemplateGuardMarkers(outputPath, cmdCtx.Config.Templates.GuardMarker); err != nil {
+				return err
+			}
+
+			// Step 7: Save the props schema next to the component templates
+			if propsSchema != nil {
+				propsPath := filepath.Join(outputPath, generator.PropsSchemaFile)
+				if err := utils.WriteToFile(propsPath, propsSchema); err != nil {
+					return apperrors.Wrap("Failed to save the props schema", err, propsPath)
+				}
+				cmdCtx.Logger.Success("Props schema saved").WithAttrs("path", propsPath)
+			}
+
+			// Step 8: Log success and asset information
 			helpers.LogSuccessMessages("component", cmdCtx.Config, cmdCtx.Logger)
 
-			// Step 6: Generate JSON action file
+			// Step 9: Generate JSON action file
 			if err := generator.GenerateActionFile("component", data, builtInActions, cmdCtx.Logger); err != nil {
 				return err
 			}
@@ -119,16 +163,49 @@ func runComponentDefineSubCommand(cmdCtx *app.AppContext) func(ctx context.Conte
 // by resolving configuration values and CLI flags.
 func createTemplateData(cmd *cli.Command, cfg *config.Config) (*generator.TemplateData, error) {
 	TemplatesDir, ActionsDir := config.DerivedFolderPaths(cfg.TempoRoot)
-	isWithJs := resolver.ResolveBool(cmd.Bool("js"), cfg.App.WithJs)
+	isWithJs := resolver.ResolveBool(cmd.Bool("js"), cfg.App.JsEnabled())
+	isWithTsDecls := resolver.ResolveBool(cmd.Bool("ts-decls"), cfg.App.TsDeclsEnabled())
 	isForce := cmd.Bool("force")
 	isDryRun := cmd.Bool("dry-run")
 
 	// Initialize common fields
-	return &generator.TemplateData{
-		TemplatesDir: TemplatesDir,
-		ActionsDir:   ActionsDir,
-		WithJs:       isWithJs,
-		Force:        isForce,
-		DryRun:       isDryRun,
-	}, nil
+	data := &generator.TemplateData{
+		TemplatesDir:       TemplatesDir,
+		ActionsDir:         ActionsDir,
+		WithJs:             isWithJs,
+		WithTsDecls:        isWithTsDecls,
+		Force:              isForce,
+		DryRun:             isDryRun,
+		TemplateExtensions: cfg.Templates.Extensions,
+	}
+	data.SetTemplateLayers(cfg.Templates.LayeredEnabled())
+	return data, nil
+}
+
+// resolveComponentTier returns the tier of the component definition selected by the
+// '--minimal' and '--full' flags, the standard one by default.
+func resolveComponentTier(cmd *cli.Command) (string, error) {
+	switch minimal, full := cmd.Bool("minimal"), cmd.Bool("full"); {
+	case minimal && full:
+		return "", apperrors.Wrap("flags '--minimal' and '--full' cannot be used together")
+	case minimal:
+		return generator.TierMinimal, nil
+	case full:
+		return generator.TierFull, nil
+	default:
+		return generator.TierStandard, nil
+	}
+}
+
+// readPropsSchema reads the props schema file at path and returns its content
+// once validated.
+func readPropsSchema(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, apperrors.Wrap("Failed to read the props schema", err, path)
+	}
+	if _, err := generator.ParsePropsSchema(content); err != nil {
+		return nil, apperrors.Wrap("Invalid props schema", err, path)
+	}
+	return content, nil
 }
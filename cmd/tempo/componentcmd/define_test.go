@@ -5,6 +5,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/indaco/tempo/internal/app"
@@ -309,7 +310,8 @@ func TestComponentCommand_DefineSubCmd_Func_createBaseTemplateData_WithJs(t *tes
 	tempDir := t.TempDir()
 
 	cfg := testutils.SetupConfig(tempDir, nil)
-	cfg.App.WithJs = true
+	withJs := true
+	cfg.App.WithJs = &withJs
 
 	appCmd := &cli.Command{
 		Flags: getNewFlags(),
@@ -328,7 +330,7 @@ func TestComponentCommand_DefineSubCmd_Func_createBaseTemplateData_WithJs(t *tes
 	if data.AssetsDir != cfg.App.AssetsDir {
 		t.Errorf("Expected default AssetsDir, got: %s", data.AssetsDir)
 	}
-	if data.WithJs != cfg.App.WithJs {
+	if data.WithJs != cfg.App.JsEnabled() {
 		t.Errorf("Expected default WithJs value, got: %v", data.WithJs)
 	}
 	if !data.WithJs {
@@ -344,3 +346,232 @@ func TestComponentCommand_DefineSubCmd_Func_createBaseTemplateData_WithJs(t *tes
 		t.Errorf("Expected default UserData to be nil, got: %v", data.UserData)
 	}
 }
+
+func TestComponentCommand_DefineSubCmd_Props(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupComponentCommand(cliCtx),
+		},
+	}
+
+	t.Run("Invalid schema", func(t *testing.T) {
+		propsFile := filepath.Join(tempDir, "invalid-props.yaml")
+		testutils.CreateFile(t, propsFile, "props:\n  - name: 1st\n    type: string\n")
+
+		err := cliApp.Run(context.Background(), []string{"tempo", "component", "define", "--props", propsFile})
+		if err == nil {
+			t.Fatal("Expected an error for an invalid props schema")
+		}
+		if exists, _ := utils.DirExists(filepath.Join(cfg.Paths.TemplatesDir, "component")); exists {
+			t.Error("Expected no templates to be defined with an invalid props schema")
+		}
+	})
+
+	t.Run("Define and generate", func(t *testing.T) {
+		propsFile := filepath.Join(tempDir, "props.yaml")
+		testutils.CreateFile(t, propsFile, `props:
+  - name: label
+    type: string
+    default: Click me
+    description: Text of the button
+  - name: disabled
+    type: bool
+`)
+
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "component", "define", "--props", propsFile}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"✔ Props schema saved"})
+
+		if _, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "component", "new", "--name", "button"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}); err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(cfg.App.GoPackage, "button", "button.templ"))
+		if err != nil {
+			t.Fatalf("Failed to read generated component: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, string(content), []string{
+			"//   - label string: Text of the button (default: \"Click me\")",
+			"//   - disabled bool",
+			"templ Button(label string, disabled bool) {",
+		})
+	})
+}
+
+func TestComponentCommand_DefineSubCmd_TsDecls(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupComponentCommand(cliCtx),
+		},
+	}
+
+	if _, err := testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), []string{"tempo", "component", "define", "--js", "--ts-decls"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	templateFile := filepath.Join(cfg.Paths.TemplatesDir, "component", "assets", "js", "script.d.ts.gotxt")
+	if _, err := os.Stat(templateFile); err != nil {
+		t.Fatalf("Expected the TypeScript declarations template to be defined: %v", err)
+	}
+
+	declsFile := filepath.Join(cfg.App.AssetsDir, "button", "js", "script.d.ts")
+
+	t.Run("Without --ts-decls", func(t *testing.T) {
+		if _, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "component", "new", "--name", "button", "--js"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}); err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		if _, err := os.Stat(filepath.Join(cfg.App.AssetsDir, "button", "js", "script.js")); err != nil {
+			t.Errorf("Expected the JS asset to be generated: %v", err)
+		}
+		if _, err := os.Stat(declsFile); !os.IsNotExist(err) {
+			t.Errorf("Expected no TypeScript declarations without --ts-decls, got: %v", err)
+		}
+	})
+
+	t.Run("With --ts-decls", func(t *testing.T) {
+		if _, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "component", "new", "--name", "button", "--js", "--ts-decls", "--force"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}); err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		content, err := os.ReadFile(declsFile)
+		if err != nil {
+			t.Fatalf("Expected the TypeScript declarations to be generated: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, string(content), []string{"// Type declarations for the JS file of 'button'", "export {};"})
+	})
+}
+
+func TestComponentCommand_DefineSubCmd_Tiers(t *testing.T) {
+	tests := []struct {
+		name       string
+		flag       string
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:       "Minimal",
+			flag:       "--minimal",
+			expected:   []string{"button/button.templ", "button/css/base.templ"},
+			unexpected: []string{"button/css/themes", "button/js/script.templ", "button/README.md", "button/button_test.go"},
+		},
+		{
+			name:     "Full",
+			flag:     "--full",
+			expected: []string{"button/button.templ", "button/css/themes", "button/js/script.templ", "button/README.md", "button/button_test.go"},
+		},
+	}
+
+	setup := func(t *testing.T) (*config.Config, *cli.Command) {
+		t.Helper()
+		tempDir := t.TempDir()
+		if err := testutils.CreateModFile(tempDir); err != nil {
+			t.Fatalf("Failed to create go.mod file: %v", err)
+		}
+
+		cfg := testutils.SetupConfig(tempDir, nil)
+		if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+			t.Fatalf("Failed to create mock config file: %v", err)
+		}
+		return cfg, &cli.Command{
+			Commands: []*cli.Command{
+				SetupComponentCommand(&app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}),
+			},
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, cliApp := setup(t)
+
+			// The tier is recorded in the actions file: 'new' needs no flag, not even --js
+			if _, err := testutils.CaptureStdout(func() {
+				for _, args := range [][]string{
+					{"tempo", "component", "define", tt.flag},
+					{"tempo", "component", "new", "--name", "button"},
+				} {
+					if err := cliApp.Run(context.Background(), args); err != nil {
+						t.Fatalf("Unexpected error: %v", err)
+					}
+				}
+			}); err != nil {
+				t.Fatalf("Failed to capture stdout: %v", err)
+			}
+
+			for _, file := range tt.expected {
+				if _, err := os.Stat(filepath.Join(cfg.App.GoPackage, file)); err != nil {
+					t.Errorf("Expected %s to be generated: %v", file, err)
+				}
+			}
+			for _, file := range tt.unexpected {
+				if _, err := os.Stat(filepath.Join(cfg.App.GoPackage, file)); !os.IsNotExist(err) {
+					t.Errorf("Expected %s not to be generated, got: %v", file, err)
+				}
+			}
+		})
+	}
+
+	t.Run("Minimal and full together", func(t *testing.T) {
+		_, cliApp := setup(t)
+		err := cliApp.Run(context.Background(), []string{"tempo", "component", "define", "--minimal", "--full"})
+		if err == nil || !strings.Contains(err.Error(), "cannot be used together") {
+			t.Errorf("Expected error for conflicting flags, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package componentcmd
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/deprecation"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupComponentDeprecateSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "deprecate",
+		Usage:                  "Mark a component as deprecated: record it in the manifest and add a deprecation notice to its templ functions",
+		UsageText:              "tempo component deprecate --name <name> [--replacement <name>] [options]",
+		UseShortOptionHandling: true,
+		Flags:                  getDeprecateFlags(),
+		Action:                 runComponentDeprecateSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getDeprecateFlags defines the CLI flags for the deprecate subcommand.
+func getDeprecateFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "name",
+			Aliases:  []string{"n"},
+			Usage:    "Name of the component to deprecate",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:    "replacement",
+			Aliases: []string{"r"},
+			Usage:   "Name of the component to use instead",
+		},
+		&cli.StringFlag{
+			Name:    "package",
+			Aliases: []string{"p"},
+			Usage:   "The Go package name where components are generated (default: components)",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Preview actions without making changes",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runComponentDeprecateSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Resolve the component and its replacement
+		name, componentDir, err := resolveComponentFolder(cmd, cmdCtx.Config)
+		if err != nil {
+			return err
+		}
+		exists, err := utils.DirExists(componentDir)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return apperrors.WrapCode(apperrors.CodeComponentNotFound, "component '%s' does not exist in %s", name, filepath.Dir(componentDir))
+		}
+
+		replacement := ""
+		if value := cmd.String("replacement"); value != "" {
+			replacement = newComponentNames(value).Package
+			if replacement == "" {
+				return apperrors.Wrap("'--replacement' must be a valid component name")
+			}
+			if replacement == name {
+				return apperrors.Wrap("component '%s' cannot replace itself", name)
+			}
+			if exists, err := utils.DirExists(filepath.Join(filepath.Dir(componentDir), replacement)); err != nil {
+				return err
+			} else if !exists {
+				cmdCtx.Logger.Warning("The replacement component does not exist yet").
+					WithAttrs("replacement", replacement)
+			}
+		}
+
+		// Step 2: Add the deprecation notice to the templ functions
+		dryRun := cmd.Bool("dry-run")
+		marked, err := markDeprecatedTemplFiles(componentDir, deprecation.Notice(name, replacement), dryRun)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.").
+				WithAttrs("files", strings.Join(marked, ", "))
+			return nil
+		}
+
+		// Step 3: Record the deprecation in the manifest
+		manifestPath := deprecation.ManifestPath(cmdCtx.Config.TempoRoot)
+		manifest, err := deprecation.Load(manifestPath)
+		if err != nil {
+			return err
+		}
+		manifest[name] = deprecation.Entry{Replacement: replacement, Since: time.Now().Format(time.DateOnly)}
+		if err := deprecation.Save(manifestPath, manifest); err != nil {
+			return err
+		}
+
+		cmdCtx.Summary = map[string]any{"component": name, "replacement": replacement, "updated_files": len(marked)}
+		cmdCtx.Logger.Success("Component has been deprecated").
+			WithAttrs(
+				"name", name,
+				"replacement", replacement,
+				"updated_files", len(marked),
+			)
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// markDeprecatedTemplFiles adds the deprecation notice to the templ functions of the
+// templ files in dir, unless dryRun is set, and returns the files updated.
+func markDeprecatedTemplFiles(dir, notice string, dryRun bool) ([]string, error) {
+	var marked []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".templ" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return apperrors.Wrap("failed to read file", err, path)
+		}
+		updated, changed := deprecation.MarkTempl(string(content), notice)
+		if !changed {
+			return nil
+		}
+		marked = append(marked, path)
+		if dryRun {
+			return nil
+		}
+		if err := utils.WriteStringToFile(path, updated); err != nil {
+			return apperrors.Wrap("failed to write file", err, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return marked, apperrors.Wrap("failed to deprecate component files", err, dir)
+	}
+	return marked, nil
+}
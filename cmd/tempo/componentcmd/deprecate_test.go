@@ -0,0 +1,83 @@
+package componentcmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/deprecation"
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestComponentCommand_DeprecateSubCmd(t *testing.T) {
+	cliApp, cliCtx := setupRenameTest(t)
+	cfg := cliCtx.Config
+
+	mainTempl := filepath.Join(cfg.App.GoPackage, "button", "button.templ")
+	manifestPath := deprecation.ManifestPath(cfg.TempoRoot)
+	notice := deprecation.Notice("button", "card")
+
+	t.Run("dry run", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "deprecate", "--name", "button", "--replacement", "card", "--dry-run"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"Dry Run Mode", "button.templ"})
+		if content, _ := os.ReadFile(mainTempl); strings.Contains(string(content), notice) {
+			t.Errorf("Expected dry run to keep the templ file unchanged")
+		}
+		if _, err := os.Stat(manifestPath); !os.IsNotExist(err) {
+			t.Errorf("Expected dry run not to write the manifest")
+		}
+	})
+
+	t.Run("deprecate", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "deprecate", "--name", "button", "--replacement", "card"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"replacement component does not exist", "Component has been deprecated"})
+
+		content, err := os.ReadFile(mainTempl)
+		if err != nil {
+			t.Fatalf("Failed to read templ file: %v", err)
+		}
+		if !strings.Contains(string(content), notice+"\ntempl Button(") {
+			t.Errorf("Expected the deprecation notice above the templ function, got:\n%s", content)
+		}
+
+		manifest, err := deprecation.Load(manifestPath)
+		if err != nil {
+			t.Fatalf("Failed to load manifest: %v", err)
+		}
+		if entry, ok := manifest["button"]; !ok || entry.Replacement != "card" || entry.Since == "" {
+			t.Errorf("Unexpected manifest entry: %+v", manifest)
+		}
+	})
+
+	t.Run("self replacement", func(t *testing.T) {
+		args := []string{"tempo", "component", "deprecate", "--name", "button", "--replacement", "button"}
+		if err := cliApp.Run(context.Background(), args); err == nil {
+			t.Errorf("Expected an error for a component replacing itself")
+		}
+	})
+
+	t.Run("missing component", func(t *testing.T) {
+		args := []string{"tempo", "component", "deprecate", "--name", "missing"}
+		if err := cliApp.Run(context.Background(), args); err == nil {
+			t.Errorf("Expected an error for a missing component")
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package componentcmd
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/resolver"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupComponentEjectSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "eject",
+		Usage:                  "Stop managing a component: remove its guard markers, keeping the injected CSS and JS",
+		UsageText:              "tempo component eject --name <name> [options]",
+		UseShortOptionHandling: true,
+		Flags:                  getEjectFlags(),
+		Action:                 runComponentEjectSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getEjectFlags defines the CLI flags for the eject subcommand.
+func getEjectFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "name",
+			Aliases:  []string{"n"},
+			Usage:    "Name of the component to eject",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:    "package",
+			Aliases: []string{"p"},
+			Usage:   "The Go package name where components are generated (default: components)",
+		},
+		&cli.StringFlag{
+			Name:    "assets",
+			Aliases: []string{"a"},
+			Usage:   "The directory where asset files (e.g., CSS, JS) are generated (default: assets)",
+		},
+		&cli.BoolFlag{
+			Name:  "delete-assets",
+			Usage: "Delete the source CSS and JS files of the component, now inlined in its .templ files",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Preview actions without making changes",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runComponentEjectSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Resolve the component folders
+		name, componentDir, assetsDir, err := resolveEjectFolders(cmd, cmdCtx.Config)
+		if err != nil {
+			return err
+		}
+
+		exists, err := utils.DirExists(componentDir)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return apperrors.WrapCode(apperrors.CodeComponentNotFound, "component '%s' does not exist in %s", name, filepath.Dir(componentDir))
+		}
+
+		// Step 2: Remove the guard markers of the templ files
+		dryRun := cmd.Bool("dry-run")
+		ejected, err := ejectTemplFiles(componentDir, cmdCtx.Config.Templates.GuardMarker, dryRun)
+		if err != nil {
+			return err
+		}
+
+		deleteAssets := cmd.Bool("delete-assets")
+		if dryRun {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.").
+				WithAttrs("files", strings.Join(ejected, ", "))
+			if deleteAssets {
+				cmdCtx.Logger.Info("Would delete assets").WithAttrs("asset_path", assetsDir)
+			}
+			return nil
+		}
+
+		// Step 3: Delete the source assets if requested
+		if deleteAssets {
+			if err := os.RemoveAll(assetsDir); err != nil {
+				return apperrors.Wrap("failed to delete component assets", err, assetsDir)
+			}
+		}
+
+		// Step 4: Warn about dependencies still declared with the component
+		if refs := findDependencyReferences(cmdCtx.Config.Components.Dependencies, name); len(refs) > 0 {
+			cmdCtx.Logger.Warning("Component dependencies still reference the ejected component. Update 'components.dependencies' in the config file.").
+				WithAttrs("components", strings.Join(refs, ", "))
+		}
+
+		if len(ejected) == 0 {
+			cmdCtx.Logger.Info("No guard markers found: the component is not managed by tempo").
+				WithAttrs("component_path", componentDir)
+			return nil
+		}
+		cmdCtx.Logger.Success("Component has been ejected").
+			WithAttrs(
+				"name", name,
+				"component_path", componentDir,
+				"updated_files", len(ejected),
+				"assets_deleted", deleteAssets,
+			)
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// resolveEjectFolders returns the package name of the component, its folder and the
+// folder of its assets.
+func resolveEjectFolders(cmd *cli.Command, cfg *config.Config) (name, componentDir, assetsDir string, err error) {
+	goPackage, err := resolver.ResolveString(cmd.String("package"), cfg.App.GoPackage, "package", config.DefaultGoPackage, nil)
+	if err != nil {
+		return "", "", "", err
+	}
+	assets, err := resolver.ResolveString(cmd.String("assets"), cfg.App.AssetsDir, "assets folder", config.DefaultAssetsDir, nil)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	name = newComponentNames(cmd.String("name")).Package
+	if name == "" {
+		return "", "", "", apperrors.Wrap("'--name' must be a valid component name")
+	}
+	return name, filepath.Join(goPackage, name), filepath.Join(assets, name), nil
+}
+
+// ejectTemplFiles removes the guard markers of the templ files in dir, unless dryRun is
+// set, and returns the files holding markers.
+func ejectTemplFiles(dir, markerName string, dryRun bool) ([]string, error) {
+	var ejected []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".templ" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return apperrors.Wrap("failed to read file", err, path)
+		}
+		stripped, found := processor.RemoveGuardMarkers(string(content), markerName)
+		if !found {
+			return nil
+		}
+		ejected = append(ejected, path)
+		if dryRun {
+			return nil
+		}
+		if err := utils.WriteStringToFile(path, stripped); err != nil {
+			return apperrors.Wrap("failed to write file", err, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return ejected, apperrors.Wrap("failed to eject component files", err, dir)
+	}
+	return ejected, nil
+}
@@ -0,0 +1,78 @@
+package componentcmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestComponentCommand_EjectSubCmd(t *testing.T) {
+	cliApp, cliCtx := setupRenameTest(t)
+	cfg := cliCtx.Config
+
+	baseTempl := filepath.Join(cfg.App.GoPackage, "button", "css", "base.templ")
+	startMarker, endMarker := processor.GuardMarkers(cfg.Templates.GuardMarker)
+	content, err := os.ReadFile(baseTempl)
+	if err != nil {
+		t.Fatalf("Failed to read templ file: %v", err)
+	}
+	injected := strings.Replace(string(content), endMarker, ".button { color: red; }\n"+endMarker, 1)
+	if err := os.WriteFile(baseTempl, []byte(injected), 0644); err != nil {
+		t.Fatalf("Failed to write templ file: %v", err)
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "eject", "--name", "button", "--delete-assets", "--dry-run"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"Dry Run Mode", "base.templ", "Would delete assets"})
+		if content, _ := os.ReadFile(baseTempl); !strings.Contains(string(content), startMarker) {
+			t.Errorf("Expected dry run to keep the guard markers")
+		}
+	})
+
+	t.Run("eject", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "eject", "--name", "button", "--delete-assets"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"Component has been ejected"})
+
+		content, err := os.ReadFile(baseTempl)
+		if err != nil {
+			t.Fatalf("Failed to read templ file: %v", err)
+		}
+		if strings.Contains(string(content), startMarker) || strings.Contains(string(content), endMarker) {
+			t.Errorf("Expected guard markers to be removed, got:\n%s", content)
+		}
+		if !strings.Contains(string(content), ".button { color: red; }") {
+			t.Errorf("Expected injected content to be kept, got:\n%s", content)
+		}
+		if _, err := os.Stat(filepath.Join(cfg.App.AssetsDir, "button")); !os.IsNotExist(err) {
+			t.Errorf("Expected the component assets to be deleted")
+		}
+	})
+
+	t.Run("missing component", func(t *testing.T) {
+		args := []string{"tempo", "component", "eject", "--name", "missing"}
+		if err := cliApp.Run(context.Background(), args); err == nil {
+			t.Errorf("Expected an error for a missing component")
+		}
+	})
+}
@@ -1,20 +1,37 @@
 package componentcmd
 
 import (
+	"bufio"
 	"context"
+	"io"
+	"io/fs"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/indaco/tempo/internal/app"
 	"github.com/indaco/tempo/internal/apperrors"
 	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/dependency"
 	"github.com/indaco/tempo/internal/generator"
 	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/htmlimport"
+	"github.com/indaco/tempo/internal/journal"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/progress"
+	"github.com/indaco/tempo/internal/rendercache"
 	"github.com/indaco/tempo/internal/resolver"
 	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/textprovider"
 	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/pkg/tempo"
 	"github.com/urfave/cli/v3"
 )
 
+// progressOutput is where the progress events of '--progress-format' are written.
+// Tests replace it.
+var progressOutput io.Writer = os.Stderr
+
 /* ------------------------------------------------------------------------- */
 /* Command Setup                                                             */
 /* ------------------------------------------------------------------------- */
@@ -24,11 +41,14 @@ func setupComponentNewSubCommand(cmdCtx *app.AppContext) *cli.Command {
 	return &cli.Command{
 		Name:                   "new",
 		Usage:                  "Generate a component instance from a template",
-		UsageText:              "tempo component new [options]",
+		UsageText:              "tempo component new [options] [name]",
 		UseShortOptionHandling: true,
 		Flags:                  flags,
-		Before:                 validateComponentNewPrerequisites(cmdCtx.Config),
+		Before:                 validateComponentNewPrerequisites(cmdCtx.Config, cmdCtx.Logger),
 		Action:                 runComponentNewSubCommand(cmdCtx),
+
+		// --data values may contain commas
+		DisableSliceFlagSeparator: true,
 	}
 }
 
@@ -50,23 +70,88 @@ func getNewFlags() []cli.Flag {
 			Usage:   "The directory where asset files (e.g., CSS, JS) will be generated (default: assets)",
 		},
 		&cli.StringFlag{
-			Name:     "name",
-			Aliases:  []string{"n"},
-			Usage:    "Name of the component",
-			Required: true,
+			Name:    "name",
+			Aliases: []string{"n"},
+			Usage:   "Name of the component",
+		},
+		&cli.StringFlag{
+			Name:  "from-file",
+			Usage: "Create the components listed in a file, one name per line ('-' reads from stdin)",
+		},
+		&cli.StringFlag{
+			Name:  "from-html",
+			Usage: "Start the component from an HTML snippet: its markup goes into the .templ file, its <style> elements and inline styles into the CSS asset",
+		},
+		&cli.StringFlag{
+			Name:  "print-data",
+			Usage: "Print the data received by the templates (config, flags and user data merged) as yaml or json, and exit without generating",
+		},
+		&cli.StringFlag{
+			Name:  "progress-format",
+			Usage: "With --from-file, stream one progress event per component (queued, started, done, skipped, error) to stderr: none, ndjson (default: none)",
 		},
 		&cli.BoolFlag{
 			Name:  "js",
 			Usage: "Whether or not JS is needed for the component",
 		},
+		&cli.BoolFlag{
+			Name:  "ts-decls",
+			Usage: "Generate the TypeScript declaration stub (.d.ts) of the JS asset, when defined",
+		},
 		&cli.BoolFlag{
 			Name:  "force",
 			Usage: "Force overwriting if already exists",
 		},
+		helpers.YesFlag(),
+		&cli.BoolFlag{
+			Name:  "no-diff",
+			Usage: "Skip the diff of the changed files with '--force'",
+		},
 		&cli.BoolFlag{
 			Name:  "dry-run",
 			Usage: "Preview actions without making changes",
 		},
+		&cli.BoolFlag{
+			Name:  "a11y-audit",
+			Usage: "Check the generated .templ files for accessibility issues, e.g. <img> without alt (default: templates.a11y_audit)",
+		},
+		&cli.BoolFlag{
+			Name:  "with-deps",
+			Usage: "Also generate the missing components this component depends on",
+		},
+		&cli.BoolFlag{
+			Name:  "sync",
+			Usage: "Sync the component assets into its .templ files right after scaffolding",
+		},
+		&cli.StringSliceFlag{
+			Name:  "data",
+			Usage: "Set a user data value (key=value, dotted keys for nested values); repeatable, overrides --data-file",
+		},
+		&cli.StringFlag{
+			Name:  "data-file",
+			Usage: "YAML file of user data deep-merged over the templates.user_data config and the .tempo-data.yaml file",
+		},
+		&cli.BoolFlag{
+			Name:  "trace",
+			Usage: "Log each executed action with its destination, elapsed time and rendered bytes",
+		},
+		&cli.StringFlag{
+			Name:  "trace-file",
+			Usage: "Export the action trace to a JSON file",
+		},
+		&cli.BoolFlag{
+			Name:  "git-add",
+			Usage: "Stage the files created or modified by the command",
+		},
+		&cli.BoolFlag{
+			Name:  "git-commit",
+			Usage: "Stage and commit the files created or modified by the command",
+		},
+		&cli.StringFlag{
+			Name:    "message",
+			Aliases: []string{"m"},
+			Usage:   "Commit message used with --git-commit (default: \"" + helpers.DefaultGitCommitMessage + "\")",
+		},
 	}
 }
 
@@ -78,61 +163,252 @@ func runComponentNewSubCommand(cmdCtx *app.AppContext) func(ctx context.Context,
 	return func(ctx context.Context, cmd *cli.Command) error {
 		helpers.EnableLoggerIndentation(cmdCtx.Logger)
 
-		// Step 1: Create template data
-		data, err := createComponentData(cmd, cmdCtx.Config)
+		// Step 1: Resolve the names of the components to create
+		names, err := resolveComponentNames(cmd)
+		if err != nil {
+			return err
+		}
+		reporter, err := progress.New(cmd.String("progress-format"), progressOutput)
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Create template data
+		data, err := createBaseTemplateData(cmd, cmdCtx.Config)
 		if err != nil {
 			return apperrors.Wrap("Failed to create template data for component", err)
 		}
 
+		// Debug mode: the template data is printed instead of generating the component
+		if format := cmd.String("print-data"); format != "" {
+			defer cmdCtx.Logger.Reset()
+			if cmd.String("from-file") != "" {
+				return apperrors.Wrap("flags '--print-data' and '--from-file' cannot be used together")
+			}
+			data.ComponentName = gonameprovider.ToGoPackageName(names[0])
+			if err := helpers.ResolveComponentImportPath(cmdCtx, data); err != nil {
+				return err
+			}
+			if err := loadHTMLSnippet(cmd.String("from-html"), data); err != nil {
+				return err
+			}
+			return helpers.PrintTemplateData(os.Stdout, data, "component", format)
+		}
+
 		if data.DryRun {
 			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.\n")
 			cmdCtx.Logger.Reset()
 			return nil
 		}
 
-		// Step 2: Check if "component define" command has been executed
+		// Step 3: Check if "component define" command has been executed
 		pathToComponentActionsFile := filepath.Join(data.ActionsDir, "component.json")
 		exists, err := utils.FileExistsFunc(pathToComponentActionsFile)
 		if err != nil {
 			return err
 		}
 		if !exists {
-			return apperrors.Wrap("Cannot find actions folder. Did you run 'tempo component define' before?")
+			return apperrors.WrapCode(apperrors.CodeTemplatesNotFound, "Cannot find actions folder. Did you run 'tempo component define' before?")
+		}
+
+		// Step 4: Create the components, tracing the actions of the whole run
+		// and tracking the changed files for git
+		snapshot, err := helpers.StartGitTracking(cmdCtx.CWD, cmd.Bool("git-add"), cmd.Bool("git-commit"))
+		if err != nil {
+			return err
+		}
+		traceFile := cmd.String("trace-file")
+		ctx, tracer := helpers.StartActionTrace(ctx, cmd.Bool("trace"), traceFile, cmdCtx.Logger)
+
+		var processErr error
+		if cmd.String("from-file") == "" {
+			componentData := *data
+			componentData.ComponentName = gonameprovider.ToGoPackageName(names[0])
+			processErr = loadHTMLSnippet(cmd.String("from-html"), &componentData)
+			if processErr == nil && componentData.Markup != "" {
+				warnUnusedHTMLSnippet(cmdCtx.Logger, componentData.TemplatesDir)
+			}
+			if processErr == nil {
+				_, processErr = createComponent(ctx, cmdCtx, cmd, pathToComponentActionsFile, &componentData)
+			}
+		} else {
+			processErr = createComponentBatch(ctx, cmdCtx, cmd, pathToComponentActionsFile, data, names, reporter)
+		}
+
+		// The trace is exported even when the processing failed, but that failure wins
+		traceErr := helpers.FinishActionTrace(tracer, traceFile, cmdCtx.Logger)
+		if processErr != nil {
+			helpers.WarnTraceNotExported(cmdCtx.Logger, traceErr)
+			cmdCtx.Logger.Reset()
+			return processErr
+		}
+		if traceErr != nil {
+			return traceErr
 		}
 
-		// Step 3: Check if the component already exists
-		// Display a warning and stop if `--force` is not set
-		outputPath := filepath.Join(data.GoPackage, data.ComponentName)
-		if exists, err = utils.DirExists(outputPath); err != nil {
+		// Step 5: Stage or commit the changed files
+		if err := helpers.FinishGitTracking(snapshot, cmd.Bool("git-commit"), cmd.String("message"), cmdCtx.Logger); err != nil {
 			return err
-		} else if exists {
-			helpers.CheckEntityForNew("component", data.ComponentName, data.GoPackage, data.Force, cmdCtx.Logger)
+		}
 
-			if !data.Force {
-				return nil
+		cmdCtx.Logger.Reset()
+
+		return nil
+	}
+}
+
+// createComponent generates a single component. It returns false when the component
+// already exists and has been left untouched.
+func createComponent(ctx context.Context, cmdCtx *app.AppContext, cmd *cli.Command, actionsFile string, data *generator.TemplateData) (bool, error) {
+	if err := helpers.ResolveComponentImportPath(cmdCtx, data); err != nil {
+		return false, err
+	}
+
+	// Step 1: Check if the component already exists
+	// Display a warning and stop if `--force` is not set
+	outputPath := filepath.Join(data.GoPackage, data.ComponentName)
+	exists, err := utils.DirExists(outputPath)
+	if err != nil {
+		return false, err
+	} else if exists {
+		helpers.CheckEntityForNew("component", data.ComponentName, data.GoPackage, data.Force, helpers.AssumeYes(cmd, cmdCtx.Config), cmdCtx.Logger)
+
+		if !data.Force {
+			// Still refresh the injected assets of the existing component
+			if cmd.Bool("sync") {
+				return false, syncComponent(ctx, cmdCtx, data)
 			}
+			return false, nil
 		}
+	}
 
-		// Step 4: Retrieve and process actions
-		if err := generator.ProcessEntityActions(ctx, cmdCtx.Logger, pathToComponentActionsFile, data, cmdCtx.Config); err != nil {
+	// Step 2: Check the declared dependencies
+	// Stop with a hint if some are missing and `--with-deps` is not set
+	missingDeps, err := findMissingDependencies(cmdCtx.Config, data)
+	if err != nil {
+		return false, apperrors.Wrap("failed to resolve dependencies for component", err, data.ComponentName)
+	}
+	if len(missingDeps) > 0 && !cmd.Bool("with-deps") {
+		return false, apperrors.Wrap(
+			"component '%s' depends on missing component(s): %s. Create them first or re-run with '--with-deps'",
+			data.ComponentName, strings.Join(missingDeps, ", "),
+		)
+	}
+
+	// Step 3: Review the changes to the existing files before overwriting them
+	process := func(ctx context.Context) error {
+		err := generateDependencies(ctx, cmdCtx, actionsFile, data, missingDeps)
+		if err == nil {
+			err = generator.ProcessEntityActions(ctx, cmdCtx.Logger, actionsFile, data, cmdCtx.Config)
+		}
+		if err != nil {
 			return apperrors.Wrap("failed to process actions for component", err, data.ComponentName)
 		}
+		return nil
+	}
+	if exists {
+		if err := helpers.ConfirmOverwrite(ctx, data.Force, cmd.Bool("no-diff"), helpers.AssumeYes(cmd, cmdCtx.Config), os.Stdout, process); err != nil {
+			return false, err
+		}
+	}
 
-		// Step 5: Log success and asset information
-		componentPath := filepath.Join(data.GoPackage, data.ComponentName)
-		assetPath := filepath.Join(data.AssetsDir, data.ComponentName)
+	// Step 4: Retrieve and process actions, generating missing dependencies first
+	if err := process(ctx); err != nil {
+		return false, err
+	}
 
-		cmdCtx.Logger.Success("Templ component files have been created").
-			WithAttrs(
-				"component", data.ComponentName,
-				"component_path", componentPath,
-				"asset_path", assetPath,
-			)
+	// Step 5: Log success and asset information
+	componentPath := filepath.Join(data.GoPackage, data.ComponentName)
+	assetPath := filepath.Join(data.AssetsDir, data.ComponentName)
 
-		cmdCtx.Logger.Reset()
+	cmdCtx.Logger.Success("Templ component files have been created").
+		WithAttrs(
+			"component", data.ComponentName,
+			"component_path", componentPath,
+			"asset_path", assetPath,
+		)
+	cmdCtx.Summary = map[string]any{"component": data.ComponentName, "component_path": componentPath, "asset_path": assetPath}
 
-		return nil
+	// Record the generation, so that the component can be regenerated as it was
+	retention, err := journal.RetentionFromConfig(cmdCtx.Config.Journal)
+	if err == nil {
+		_, err = journal.New(cmdCtx.Config.TempoRoot, retention).Record("component", data.ComponentName, actionsFile, data)
+	}
+	if err != nil {
+		cmdCtx.Logger.Warning("Cannot record the generation in the journal").WithAttrs("error", err)
+	}
+
+	// Step 6: Audit the generated markup for accessibility issues
+	if cmd.Bool("a11y-audit") || cmdCtx.Config.Templates.A11yAuditEnabled() {
+		if _, err := helpers.AuditAccessibility(componentPath, cmdCtx.Logger); err != nil {
+			return false, err
+		}
+	}
+
+	// Step 7: Inject the component assets into its .templ files
+	if cmd.Bool("sync") {
+		if err := syncComponent(ctx, cmdCtx, data); err != nil {
+			return false, err
+		}
 	}
+
+	return true, nil
+}
+
+// createComponentBatch generates each listed component, going on after a failure, and
+// logs a consolidated summary. It fails when at least one component could not be created.
+// The progress of each component is streamed to reporter (nil to disable).
+func createComponentBatch(ctx context.Context, cmdCtx *app.AppContext, cmd *cli.Command, actionsFile string, data *generator.TemplateData, names []string, reporter *progress.Reporter) error {
+	var created, skipped, failed []string
+
+	components := make([]string, len(names))
+	for i, name := range names {
+		components[i] = gonameprovider.ToGoPackageName(name)
+		reporter.Emit(progress.Record{Event: progress.EventQueued, Component: components[i], Dest: filepath.Join(data.GoPackage, components[i])})
+	}
+
+	for _, component := range components {
+		componentData := *data
+		componentData.ComponentName = component
+		event := progress.Record{Component: component, Dest: filepath.Join(data.GoPackage, component)}
+
+		event.Event = progress.EventStarted
+		reporter.Emit(event)
+
+		ok, err := createComponent(ctx, cmdCtx, cmd, actionsFile, &componentData)
+		switch {
+		case err != nil:
+			failed = append(failed, component)
+			cmdCtx.Logger.Error(err.Error()).WithAttrs("component", component)
+			event.Event, event.Error = progress.EventError, err.Error()
+		case ok:
+			created = append(created, component)
+			event.Event = progress.EventDone
+		default:
+			skipped = append(skipped, component)
+			event.Event, event.Reason = progress.EventSkipped, "Component already exists"
+		}
+		reporter.Emit(event)
+	}
+
+	attrs := []any{
+		"total", len(names),
+		"created", len(created),
+		"skipped", len(skipped),
+		"failed", len(failed),
+	}
+	if len(skipped) > 0 {
+		attrs = append(attrs, "skipped_components", strings.Join(skipped, ", "))
+	}
+	if len(failed) > 0 {
+		attrs = append(attrs, "failed_components", strings.Join(failed, ", "))
+	}
+	cmdCtx.Logger.Info("Batch summary").WithAttrs(attrs...)
+
+	if len(failed) > 0 {
+		return apperrors.Wrap("failed to create %s of %s components: %s", len(failed), len(names), strings.Join(failed, ", "))
+	}
+	return nil
 }
 
 /* ------------------------------------------------------------------------- */
@@ -141,26 +417,24 @@ func runComponentNewSubCommand(cmdCtx *app.AppContext) func(ctx context.Context,
 
 // validateComponentNewPrerequisites checks prerequisites for the "component new" subcommand, including:
 // - Initialized Tempo project (inherited from the main define command).
-// - Existence of the component templates folder.
-func validateComponentNewPrerequisites(cfg *config.Config) func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+// - Existence of the component templates folder, only warned about when strict mode is disabled.
+func validateComponentNewPrerequisites(cfg *config.Config, log logger.Logger) func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 	return func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 		foldersToCheck := map[string]string{
 			"templates_directory": filepath.Join(cfg.Paths.TemplatesDir, "component"),
 		}
 
-		missingFolders, err := utils.CheckMissingFolders(foldersToCheck)
+		_, err := helpers.CheckPrerequisiteFolders(
+			cfg,
+			log,
+			foldersToCheck,
+			"Have you run 'tempo component define' or 'tempo component new' to set up your components?\nMake sure your templates, actions, and implementations exist before creating a new component.",
+			[]string{"tempo component -h"},
+		)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(missingFolders) > 0 {
-			return nil, helpers.BuildMissingFoldersError(
-				missingFolders,
-				"Have you run 'tempo component define' or 'tempo component new' to set up your components?\nMake sure your templates, actions, and implementations exist before creating a new component.",
-				[]string{"tempo component -h"},
-			)
-		}
-
 		return ctx, nil
 	}
 }
@@ -169,17 +443,190 @@ func validateComponentNewPrerequisites(cfg *config.Config) func(ctx context.Cont
 /* Helper Functions                                                          */
 /* ------------------------------------------------------------------------- */
 
-// createComponentData initializes TemplateData for a component.
-func createComponentData(cmd *cli.Command, cfg *config.Config) (*generator.TemplateData, error) {
-	data, err := createBaseTemplateData(cmd, cfg)
+// findMissingDependencies returns, in generation order, the declared dependencies
+// of the component that do not exist yet in the Go package folder.
+func findMissingDependencies(cfg *config.Config, data *generator.TemplateData) ([]string, error) {
+	graph := dependency.NewGraph(cfg.Components.Dependencies, gonameprovider.ToGoPackageName)
+	deps, err := graph.Resolve(data.ComponentName)
 	if err != nil {
 		return nil, err
 	}
 
-	// Add component-specific fields
-	data.ComponentName = gonameprovider.ToGoPackageName(cmd.String("name"))
+	var missing []string
+	for _, dep := range deps {
+		exists, err := utils.DirExists(filepath.Join(data.GoPackage, dep))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			missing = append(missing, dep)
+		}
+	}
+	return missing, nil
+}
+
+// generateDependencies processes the component actions for each missing dependency.
+func generateDependencies(ctx context.Context, cmdCtx *app.AppContext, actionsFile string, data *generator.TemplateData, deps []string) error {
+	for _, dep := range deps {
+		depData := *data
+		depData.ComponentName = dep
+		if err := helpers.ResolveComponentImportPath(cmdCtx, &depData); err != nil {
+			return err
+		}
+
+		if err := generator.ProcessEntityActions(ctx, cmdCtx.Logger, actionsFile, &depData, cmdCtx.Config); err != nil {
+			return apperrors.Wrap("failed to generate dependency", err, dep)
+		}
 
-	return data, nil
+		cmdCtx.Logger.Success("Dependency component has been created").
+			WithAttrs(
+				"component", dep,
+				"component_path", filepath.Join(data.GoPackage, dep),
+			)
+	}
+	return nil
+}
+
+// syncComponent runs the sync pipeline restricted to the assets of the component,
+// injecting them into the guard markers of its .templ files.
+func syncComponent(ctx context.Context, cmdCtx *app.AppContext, data *generator.TemplateData) error {
+	assetPath := filepath.Join(data.AssetsDir, data.ComponentName)
+	componentPath := filepath.Join(data.GoPackage, data.ComponentName)
+
+	if exists, err := utils.DirExists(assetPath); err != nil {
+		return err
+	} else if !exists {
+		cmdCtx.Logger.Info("No assets to sync for component").WithAttrs("component", data.ComponentName)
+		return nil
+	}
+
+	result, err := tempo.Sync(ctx, cmdCtx.Config, tempo.SyncOptions{
+		InputDir:  assetPath,
+		OutputDir: componentPath,
+	})
+	if err != nil {
+		return apperrors.Wrap("failed to sync assets for component", err, data.ComponentName)
+	}
+
+	if len(result.Failed) > 0 {
+		failed := make([]string, 0, len(result.Failed))
+		for _, f := range result.Failed {
+			failed = append(failed, f.Source)
+		}
+		return apperrors.Wrap("failed to sync %s asset(s) of component %s: %s", len(failed), data.ComponentName, strings.Join(failed, ", "))
+	}
+
+	cmdCtx.Logger.Success("Component assets have been synced").
+		WithAttrs(
+			"component", data.ComponentName,
+			"processed", len(result.Processed),
+			"skipped", len(result.Skipped),
+		)
+	return nil
+}
+
+// resolveComponentNames returns the component names given with '--name', as argument,
+// or listed in the '--from-file' file.
+func resolveComponentNames(cmd *cli.Command) ([]string, error) {
+	args, err := helpers.ResolvePositionalArgs(cmd, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	name, fromFile := args[0], cmd.String("from-file")
+	switch {
+	case name != "" && fromFile != "":
+		return nil, apperrors.Wrap("flags '--name' and '--from-file' cannot be used together")
+	case fromFile != "" && cmd.String("from-html") != "":
+		return nil, apperrors.Wrap("flags '--from-html' and '--from-file' cannot be used together")
+	case fromFile != "":
+		return readComponentNames(fromFile, cmd.Root().Reader)
+	case name != "":
+		return []string{name}, nil
+	default:
+		return nil, apperrors.Wrap(`Required flag "name" not set. Use '--name', pass the name as argument or use '--from-file'`)
+	}
+}
+
+// loadHTMLSnippet sets the Markup and Styles of data from the HTML snippet at path, the
+// inline styles becoming classes named after the component. It does nothing when path
+// is empty.
+func loadHTMLSnippet(path string, data *generator.TemplateData) error {
+	if path == "" {
+		return nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return apperrors.Wrap("failed to open HTML snippet", err, path)
+	}
+	defer file.Close()
+
+	result, err := htmlimport.Convert(file, textprovider.KebabCase(data.ComponentName))
+	if err != nil {
+		return apperrors.Wrap("failed to convert HTML snippet", err, path)
+	}
+	data.Markup, data.Styles = result.Markup, result.Styles
+	return nil
+}
+
+// warnUnusedHTMLSnippet warns when the component templates, defined before
+// '--from-html' existed, do not render the converted markup.
+func warnUnusedHTMLSnippet(log logger.Logger, templatesDir string) {
+	dir := filepath.Join(templatesDir, "component")
+	used := false
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || used {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err == nil && strings.Contains(string(content), ".Markup") {
+			used = true
+		}
+		return nil
+	})
+	if !used {
+		log.Warning("The component templates do not render the HTML snippet (.Markup and .Styles). Re-run 'tempo component define --force' or add them to your templates").
+			WithAttrs("templates", dir)
+	}
+}
+
+// readComponentNames reads the component names from a file, or from stdin when path is "-".
+// Names are read one per line; blank lines and lines starting with '#' are ignored,
+// duplicates are dropped.
+func readComponentNames(path string, stdin io.Reader) ([]string, error) {
+	var r io.Reader = stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, apperrors.Wrap("failed to open components file", err, path)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name := gonameprovider.ToGoPackageName(line)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, apperrors.Wrap("failed to read components file", err, path)
+	}
+
+	if len(names) == 0 {
+		return nil, apperrors.Wrap("no component names found", path)
+	}
+	return names, nil
 }
 
 // createBaseTemplateData initializes common fields for TemplateData.
@@ -207,22 +654,50 @@ func createBaseTemplateData(cmd *cli.Command, cfg *config.Config) (*generator.Te
 	}
 
 	TemplatesDir, ActionsDir := config.DerivedFolderPaths(cfg.TempoRoot)
-	isWithJs := resolver.ResolveBool(cmd.Bool("js"), cfg.App.WithJs)
+	isWithJs := resolver.ResolveBool(cmd.Bool("js"), cfg.App.JsEnabled())
+	isWithTsDecls := resolver.ResolveBool(cmd.Bool("ts-decls"), cfg.App.TsDeclsEnabled())
 	isForce := cmd.Bool("force")
 	isDryRun := cmd.Bool("dry-run")
 
+	// User data: config, then --data-file, then --data
+	cliUserData, err := generator.ResolveCLIUserData(cmd.String("data-file"), cmd.StringSlice("data"))
+	if err != nil {
+		return nil, err
+	}
+	userData := cfg.Templates.UserData
+	if cliUserData != nil {
+		userData = generator.MergeUserData(userData, cliUserData)
+	}
+
 	// Initialize common fields
-	return &generator.TemplateData{
-		TemplatesDir: TemplatesDir,
-		ActionsDir:   ActionsDir,
-		GoModule:     cfg.App.GoModule,
-		GoPackage:    goPackage,
-		AssetsDir:    assetsDir,
-		WithJs:       isWithJs,
-		CssLayer:     cfg.App.CssLayer,
-		GuardMarker:  cfg.Templates.GuardMarker,
-		Force:        isForce,
-		DryRun:       isDryRun,
-		UserData:     cfg.Templates.UserData,
-	}, nil
+	if err := cfg.Layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	data := &generator.TemplateData{
+		TemplatesDir:       TemplatesDir,
+		ActionsDir:         ActionsDir,
+		GoModule:           cfg.App.GoModule,
+		GoModuleOptional:   !cfg.App.RequiresGoModule(),
+		GoPackage:          goPackage,
+		AssetsDir:          assetsDir,
+		StylesDir:          cfg.Layout.Styles(),
+		VariantsDir:        cfg.Layout.Variants(),
+		ScriptsDir:         cfg.Layout.Scripts(),
+		WithJs:             isWithJs,
+		WithTsDecls:        isWithTsDecls,
+		CssLayer:           cfg.App.CssLayer,
+		GuardMarker:        cfg.Templates.GuardMarker,
+		Watermark:          cfg.Templates.Watermark,
+		Force:              isForce,
+		DryRun:             isDryRun,
+		UserData:           userData,
+		CLIUserData:        cliUserData,
+		Header:             cfg.Templates.Header,
+		FileModes:          cfg.FileModes,
+		TemplateExtensions: cfg.Templates.Extensions,
+		RenderCache:        rendercache.Open(cfg.TempoRoot, cfg.Templates.RenderCacheEnabled(), cfg.Templates.FunctionProviders),
+	}
+	data.SetTemplateLayers(cfg.Templates.LayeredEnabled())
+	return data, nil
 }
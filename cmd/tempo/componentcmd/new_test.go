@@ -1,16 +1,23 @@
 package componentcmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
 	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
 	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/progress"
 	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
 	"github.com/indaco/tempo/internal/testutils"
 	"github.com/indaco/tempo/internal/utils"
@@ -87,6 +94,122 @@ func TestComponentCommand_NewSubCmd_DefaultConfig(t *testing.T) {
 			filepath.Join(cfg.App.AssetsDir, "button", "css", "base.css"),
 		}
 		testutils.ValidateGeneratedFiles(t, expectedFiles)
+
+		content, err := os.ReadFile(filepath.Join(cfg.App.GoPackage, "button", "button.templ"))
+		if err != nil {
+			t.Fatalf("Failed to read component file: %v", err)
+		}
+		if want := `"example.com/myproject/custom-package/button/css"`; !strings.Contains(string(content), want) {
+			t.Errorf("Expected the component to import %s, got:\n%s", want, content)
+		}
+	})
+}
+
+func TestComponentCommand_NewSubCmd_TemplateExtensions(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, func(cfg *config.Config) {
+		cfg.Templates.Extensions = []string{".tmpl"}
+	})
+	cliCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+	cliApp := &cli.Command{Commands: []*cli.Command{SetupComponentCommand(cliCtx)}}
+
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	testutils.ValidateGeneratedFiles(t, []string{
+		filepath.Join(cfg.Paths.TemplatesDir, "component", "templ", "component.templ.tmpl"),
+		filepath.Join(cfg.Paths.TemplatesDir, "component", "assets", "css", "themes", "dark.css.tmpl"),
+	})
+	if exists, _ := utils.FileExists(filepath.Join(cfg.Paths.TemplatesDir, "component", "templ", "component.templ.gotxt")); exists {
+		t.Errorf("Expected the built-in template to be copied with the configured extension")
+	}
+	actions, err := os.ReadFile(filepath.Join(cfg.Paths.ActionsDir, "component.json"))
+	if err != nil {
+		t.Fatalf("Failed to read the actions file: %v", err)
+	}
+	if !strings.Contains(string(actions), "component.templ.tmpl") || strings.Contains(string(actions), ".gotxt") {
+		t.Errorf("Expected the actions file to reference the .tmpl templates, got:\n%s", actions)
+	}
+
+	_, err = testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), []string{"tempo", "component", "new", "--name", "button"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	testutils.ValidateGeneratedFiles(t, []string{
+		filepath.Join(cfg.App.GoPackage, "button", "button.templ"),
+		filepath.Join(cfg.App.AssetsDir, "button", "css", "themes", "dark.css"),
+	})
+}
+
+func TestComponentCommand_NewSubCmd_Layout(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, func(cfg *config.Config) {
+		withJs := true
+		cfg.App.WithJs = &withJs
+		cfg.Layout = config.Layout{StylesDir: "styles", VariantsDir: "styles/variants", ScriptsDir: "scripts"}
+	})
+	cliCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+	cliApp := &cli.Command{Commands: []*cli.Command{SetupComponentCommand(cliCtx)}}
+
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	_, err := testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), []string{"tempo", "component", "new", "--name", "button"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	testutils.ValidateGeneratedFiles(t, []string{
+		filepath.Join(cfg.App.AssetsDir, "button", "styles", "base.css"),
+		filepath.Join(cfg.App.AssetsDir, "button", "styles", "themes", "dark.css"),
+		filepath.Join(cfg.App.AssetsDir, "button", "scripts", "script.js"),
+		filepath.Join(cfg.App.GoPackage, "button", "styles", "base.templ"),
+		filepath.Join(cfg.App.GoPackage, "button", "scripts", "script.templ"),
+	})
+
+	content, err := os.ReadFile(filepath.Join(cfg.App.GoPackage, "button", "styles", "base.templ"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "package styles\n") || !strings.Contains(string(content), "/button/styles/themes\"") {
+		t.Errorf("Expected the styles package to follow the layout, got:\n%s", content)
+	}
+	content, err = os.ReadFile(filepath.Join(cfg.App.GoPackage, "button", "button.templ"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "/button/styles\"") || !strings.Contains(string(content), "@styles.ButtonCSS()") {
+		t.Errorf("Expected the component to import the styles package, got:\n%s", content)
+	}
+
+	t.Run("invalid layout", func(t *testing.T) {
+		cfg.Layout.ScriptsDir = "../scripts"
+		t.Cleanup(func() { cfg.Layout.ScriptsDir = "scripts" })
+		if err := cliApp.Run(context.Background(), []string{"tempo", "component", "new", "--name", "card"}); err == nil {
+			t.Error("Expected an invalid layout error")
+		}
+		if exists, _ := utils.DirExists(filepath.Join(cfg.App.GoPackage, "card")); exists {
+			t.Error("Expected no component to be generated with an invalid layout")
+		}
 	})
 }
 
@@ -682,7 +805,7 @@ func TestComponentCommand_NewSubCmd_Func_validateComponentNewPrerequisites(t *te
 			t.Fatalf("Failed to create component template directory: %v", err)
 		}
 
-		validate := validateComponentNewPrerequisites(cfg)
+		validate := validateComponentNewPrerequisites(cfg, logger.NewDefaultLogger())
 		_, err := validate(context.Background(), &cli.Command{})
 
 		if err != nil {
@@ -698,7 +821,7 @@ func TestComponentCommand_NewSubCmd_Func_validateComponentNewPrerequisites(t *te
 			t.Errorf("Unexpected error message from os.RemoveAll: %v", err)
 		}
 
-		validate := validateComponentNewPrerequisites(cfg)
+		validate := validateComponentNewPrerequisites(cfg, logger.NewDefaultLogger())
 		_, err = validate(context.Background(), &cli.Command{})
 
 		if err == nil {
@@ -709,6 +832,17 @@ func TestComponentCommand_NewSubCmd_Func_validateComponentNewPrerequisites(t *te
 			t.Errorf("Expected missing folders error message, got: %v", err)
 		}
 	})
+
+	t.Run("Missing component templates directory with strict mode disabled", func(t *testing.T) {
+		strict := false
+		cfg.Strict = &strict
+		defer func() { cfg.Strict = nil }()
+
+		validate := validateComponentNewPrerequisites(cfg, logger.NewDefaultLogger())
+		if _, err := validate(context.Background(), &cli.Command{}); err != nil {
+			t.Errorf("Expected a warning instead of an error, got: %v", err)
+		}
+	})
 }
 
 func TestComponentCommand_NewSubCmd_Func_validateComponentNewPrerequisites_ErrorOnCheckMissingFolders(t *testing.T) {
@@ -719,7 +853,7 @@ func TestComponentCommand_NewSubCmd_Func_validateComponentNewPrerequisites_Error
 	originalFunc := utils.CheckMissingFoldersFunc
 	defer func() { utils.CheckMissingFoldersFunc = originalFunc }() // Restore after test
 
-	validate := validateComponentNewPrerequisites(cfg)
+	validate := validateComponentNewPrerequisites(cfg, logger.NewDefaultLogger())
 	_, err := validate(context.Background(), &cli.Command{})
 
 	if err == nil {
@@ -755,7 +889,7 @@ func TestComponentCommand_NewSubCmd_Func_createBaseTemplateData_DefaultValues(t
 	if data.AssetsDir != cfg.App.AssetsDir {
 		t.Errorf("Expected default AssetsDir, got: %s", data.AssetsDir)
 	}
-	if data.WithJs != cfg.App.WithJs {
+	if data.WithJs != cfg.App.JsEnabled() {
 		t.Errorf("Expected default WithJs value, got: %v", data.WithJs)
 	}
 	if data.Force != false {
@@ -814,3 +948,693 @@ func TestComponentCommand_NewSubCmd_Func_createBaseTemplateData_UserData(t *test
 		t.Errorf("Expected UserData.config.option1 = 'value1', got: %v", val)
 	}
 }
+
+func TestComponentCommand_NewSubCmd_Func_createBaseTemplateData_DataFlags(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cfg.Templates.UserData = map[string]any{
+		"author": "Jane Doe",
+		"config": map[string]any{"option1": "value1", "option2": "value2"},
+	}
+
+	dataFile := filepath.Join(tempDir, "data.yaml")
+	testutils.CreateFile(t, dataFile, "year: 2026\nconfig:\n  option1: file\n")
+
+	var data *generator.TemplateData
+	appCmd := &cli.Command{
+		Name:                      "new",
+		Flags:                     getNewFlags(),
+		DisableSliceFlagSeparator: true,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			var err error
+			data, err = createBaseTemplateData(cmd, cfg)
+			return err
+		},
+	}
+
+	args := []string{"new", "--data-file", dataFile, "--data", "config.option1=flag", "--data", "tags=a,b"}
+	if err := appCmd.Run(context.Background(), args); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	expected := map[string]any{
+		"author": "Jane Doe",
+		"year":   2026,
+		"tags":   "a,b",
+		"config": map[string]any{"option1": "flag", "option2": "value2"},
+	}
+	if !reflect.DeepEqual(data.UserData, expected) {
+		t.Errorf("Expected UserData %v, got: %v", expected, data.UserData)
+	}
+	if _, ok := data.CLIUserData["author"]; ok {
+		t.Errorf("Expected CLIUserData to hold only the command line values, got: %v", data.CLIUserData)
+	}
+	if _, ok := cfg.Templates.UserData["year"]; ok {
+		t.Errorf("Expected the config user data to be left unchanged, got: %v", cfg.Templates.UserData)
+	}
+}
+
+func TestComponentCommand_NewSubCmd_Trace(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// Create go.mod inside tempDir (the correct working directory)
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+
+	// Write `tempo.yaml`
+	configPath := filepath.Join(tempDir, "tempo.yaml")
+	if err := testutils.WriteConfigToFile(configPath, cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	// Prepare CLI app
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupComponentCommand(cliCtx),
+		},
+	}
+
+	// Step 1: Run "component define"
+	t.Run("Define Component Setup", func(t *testing.T) {
+		_, err := testutils.SetupComponentDefine(cliApp, t)
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+	})
+
+	// Step 2: Run "component new" with tracing enabled
+	t.Run("Component with trace", func(t *testing.T) {
+		traceFile := filepath.Join(tempDir, "trace.json")
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{
+				"tempo", "component", "new",
+				"--name", "button",
+				"--trace",
+				"--trace-file", traceFile,
+			}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		testutils.ValidateCLIOutput(t, output, []string{
+			"Trace: render file",
+			"destination: " + filepath.Join(cfg.App.GoPackage, "button", "button.templ"),
+			"Action trace exported",
+		})
+
+		testutils.ValidateGeneratedFiles(t, []string{traceFile})
+	})
+
+	// Step 3: A processing failure wins over a trace that can't be exported
+	t.Run("Processing error not hidden by the trace", func(t *testing.T) {
+		blocker := filepath.Join(tempDir, "blocker")
+		testutils.CreateFile(t, blocker, "")
+		args := []string{
+			"tempo", "component", "new",
+			"--name", "card",
+			"--from-html", filepath.Join(tempDir, "missing.html"),
+			"--trace",
+			"--trace-file", filepath.Join(blocker, "trace.json"),
+		}
+		var runErr error
+		output, err := testutils.CaptureStdout(func() {
+			runErr = cliApp.Run(context.Background(), args)
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		if runErr == nil || !strings.Contains(runErr.Error(), "failed to open HTML snippet") {
+			t.Fatalf("Expected the HTML snippet error, got: %v", runErr)
+		}
+
+		testutils.ValidateCLIOutput(t, output, []string{"Action trace not exported"})
+	})
+}
+
+func TestComponentCommand_NewSubCmd_Dependencies(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, func(cfg *config.Config) {
+		cfg.Components.Dependencies = map[string][]string{
+			"dropdown": {"button", "menu"},
+			"menu":     {"button"},
+		}
+	})
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupComponentCommand(cliCtx),
+		},
+	}
+
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to run component define: %v", err)
+	}
+
+	t.Run("Missing dependencies without --with-deps", func(t *testing.T) {
+		var runErr error
+		if _, err := testutils.CaptureStdout(func() {
+			runErr = cliApp.Run(context.Background(), []string{"tempo", "component", "new", "--name", "dropdown"})
+		}); err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		if runErr == nil {
+			t.Fatalf("Expected an error for missing dependencies")
+		}
+		testutils.ValidateCLIOutput(t, runErr.Error(), []string{"button", "menu", "--with-deps"})
+
+		if exists, _ := utils.DirExists(filepath.Join(cfg.App.GoPackage, "dropdown")); exists {
+			t.Errorf("Expected dropdown not to be generated")
+		}
+	})
+
+	t.Run("Generate dependencies with --with-deps", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "new", "--name", "dropdown", "--with-deps"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		testutils.ValidateCLIOutput(t, output, []string{
+			"Dependency component has been created",
+			"Templ component files have been created",
+		})
+
+		testutils.ValidateGeneratedFiles(t, []string{
+			filepath.Join(cfg.App.GoPackage, "button", "button.templ"),
+			filepath.Join(cfg.App.GoPackage, "menu", "menu.templ"),
+			filepath.Join(cfg.App.GoPackage, "dropdown", "dropdown.templ"),
+		})
+	})
+}
+
+func TestComponentCommand_NewSubCmd_FromFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupComponentCommand(cliCtx),
+		},
+	}
+
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to define component templates: %v", err)
+	}
+
+	// An existing component is skipped without --force
+	if err := os.MkdirAll(filepath.Join(cfg.App.GoPackage, "card"), 0755); err != nil {
+		t.Fatalf("Failed to create existing component: %v", err)
+	}
+
+	t.Run("From file", func(t *testing.T) {
+		listFile := filepath.Join(tempDir, "components.txt")
+		testutils.CreateFile(t, listFile, "# design system\nbutton\n\nicon-button\ncard\nbutton\n")
+
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "new", "--from-file", listFile}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		testutils.ValidateCLIOutput(t, output, []string{
+			"Batch summary",
+			"total: 3",
+			"created: 2",
+			"skipped: 1",
+			"skipped_components: card",
+		})
+		testutils.ValidateGeneratedFiles(t, []string{
+			filepath.Join(cfg.App.GoPackage, "button", "button.templ"),
+			filepath.Join(cfg.App.GoPackage, "icon_button", "icon_button.templ"),
+		})
+	})
+
+	t.Run("From stdin", func(t *testing.T) {
+		cliApp.Reader = strings.NewReader("badge\n")
+
+		_, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "new", "--from-file", "-"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		testutils.ValidateGeneratedFiles(t, []string{
+			filepath.Join(cfg.App.GoPackage, "badge", "badge.templ"),
+		})
+	})
+
+	t.Run("Progress events", func(t *testing.T) {
+		var events bytes.Buffer
+		origOutput := progressOutput
+		progressOutput = &events
+		t.Cleanup(func() { progressOutput = origOutput })
+		cliApp.Reader = strings.NewReader("avatar\ncard\n")
+
+		_, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "new", "--from-file", "-", "--progress-format", "ndjson"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		var got []string
+		for line := range strings.Lines(events.String()) {
+			var rec progress.Record
+			if err := json.Unmarshal([]byte(line), &rec); err != nil {
+				t.Fatalf("Invalid progress event %q: %v", line, err)
+			}
+			got = append(got, rec.Component+":"+string(rec.Event))
+		}
+		want := []string{"avatar:queued", "card:queued", "avatar:started", "avatar:done", "card:started", "card:skipped"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected the events %v, got %v", want, got)
+		}
+	})
+
+	t.Run("Invalid progress format", func(t *testing.T) {
+		cliApp.Reader = strings.NewReader("tag\n")
+		args := []string{"tempo", "component", "new", "--from-file", "-", "--progress-format", "xml"}
+		err := cliApp.Run(context.Background(), args)
+		if err == nil || !strings.Contains(err.Error(), "invalid progress format") {
+			t.Errorf("Expected error for invalid progress format, got %v", err)
+		}
+	})
+
+	t.Run("Name and file together", func(t *testing.T) {
+		args := []string{"tempo", "component", "new", "--name", "tag", "--from-file", "-"}
+		err := cliApp.Run(context.Background(), args)
+		if err == nil || !strings.Contains(err.Error(), "cannot be used together") {
+			t.Errorf("Expected error for conflicting flags, got %v", err)
+		}
+	})
+
+	t.Run("Empty file", func(t *testing.T) {
+		listFile := filepath.Join(tempDir, "empty.txt")
+		testutils.CreateFile(t, listFile, "# nothing yet\n")
+
+		args := []string{"tempo", "component", "new", "--from-file", listFile}
+		err := cliApp.Run(context.Background(), args)
+		if err == nil || !strings.Contains(err.Error(), "no component names found") {
+			t.Errorf("Expected error for empty file, got %v", err)
+		}
+	})
+}
+
+func TestComponentCommand_NewSubCmd_Sync(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupComponentCommand(cliCtx),
+		},
+	}
+
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to run component define: %v", err)
+	}
+
+	baseCSS := filepath.Join(cfg.App.AssetsDir, "button", "css", "base.css")
+	baseTempl := filepath.Join(cfg.App.GoPackage, "button", "css", "base.templ")
+
+	t.Run("Create and sync", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "new", "--name", "button", "--sync"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		testutils.ValidateCLIOutput(t, output, []string{
+			"Templ component files have been created",
+			"Component assets have been synced",
+		})
+
+		content, err := os.ReadFile(baseTempl)
+		if err != nil {
+			t.Fatalf("Failed to read templ file: %v", err)
+		}
+		if !strings.Contains(string(content), ":root {") {
+			t.Errorf("Expected the CSS to be injected into %s, got:\n%s", baseTempl, content)
+		}
+	})
+
+	t.Run("Sync an existing component", func(t *testing.T) {
+		testutils.CreateFile(t, baseCSS, ".button-updated { color: red; }")
+
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "new", "--name", "button", "--sync"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		testutils.ValidateCLIOutput(t, output, []string{"Component assets have been synced"})
+
+		content, err := os.ReadFile(baseTempl)
+		if err != nil {
+			t.Fatalf("Failed to read templ file: %v", err)
+		}
+		if !strings.Contains(string(content), ".button-updated") {
+			t.Errorf("Expected the updated CSS to be injected, got:\n%s", content)
+		}
+	})
+}
+
+func TestComponentCommand_NewSubCmd_A11yAudit(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupComponentCommand(cliCtx),
+		},
+	}
+
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to run component define: %v", err)
+	}
+	testutils.CreateFile(t, filepath.Join(cfg.Paths.TemplatesDir, "component", "templ", "component.templ.gotxt"),
+		"package {{ .ComponentName }}\n\ntempl Card() {\n\t<img src=\"card.png\">\n\t<button>Close</button>\n}\n")
+
+	output, err := testutils.CaptureStdout(func() {
+		args := []string{"tempo", "component", "new", "--name", "card", "--a11y-audit"}
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, output, []string{
+		"Templ component files have been created",
+		"img-alt",
+		"button-type",
+		"Accessibility audit found issues",
+	})
+}
+
+func TestComponentCommand_NewSubCmd_ForceDiff(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupComponentCommand(cliCtx),
+		},
+	}
+
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to define component templates: %v", err)
+	}
+	if err := cliApp.Run(context.Background(), []string{"tempo", "component", "new", "--name", "button"}); err != nil {
+		t.Fatalf("Failed to create component: %v", err)
+	}
+
+	componentFile := filepath.Join(cfg.App.GoPackage, "button", "button.templ")
+	generated, err := os.ReadFile(componentFile)
+	if err != nil {
+		t.Fatalf("Failed to read component file: %v", err)
+	}
+	edited := string(generated) + "// local change\n"
+	testutils.CreateFile(t, componentFile, edited)
+
+	readComponent := func() string {
+		content, err := os.ReadFile(componentFile)
+		if err != nil {
+			t.Fatalf("Failed to read component file: %v", err)
+		}
+		return string(content)
+	}
+
+	t.Run("Force without yes shows the diff", func(t *testing.T) {
+		var runErr error
+		output, err := testutils.CaptureStdout(func() {
+			runErr = cliApp.Run(context.Background(), []string{"tempo", "component", "new", "--name", "button", "--force"})
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		if runErr == nil || apperrors.CodeOf(runErr) != apperrors.CodeOverwriteNotConfirmed {
+			t.Fatalf("Expected error %s, got %v", apperrors.CodeOverwriteNotConfirmed, runErr)
+		}
+
+		testutils.ValidateCLIOutput(t, output, []string{"--- " + componentFile, "-// local change"})
+		if readComponent() != edited {
+			t.Errorf("Expected the component file to be left untouched")
+		}
+	})
+
+	t.Run("Force with yes overwrites", func(t *testing.T) {
+		_, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "new", "--name", "button", "--force", "--yes"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		if readComponent() != string(generated) {
+			t.Errorf("Expected the component file to be overwritten")
+		}
+	})
+
+	t.Run("Force with no-diff overwrites", func(t *testing.T) {
+		testutils.CreateFile(t, componentFile, edited)
+
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "new", "--name", "button", "--force", "--no-diff"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		if strings.Contains(output, "-// local change") {
+			t.Errorf("Expected no diff with --no-diff, got:\n%s", output)
+		}
+		if readComponent() != string(generated) {
+			t.Errorf("Expected the component file to be overwritten")
+		}
+	})
+}
+
+func TestComponentCommand_NewSubCmd_PrintData(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupComponentCommand(&app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}),
+		},
+	}
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to define component templates: %v", err)
+	}
+
+	t.Run("Prints the data without generating", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "new", "--name", "icon-button", "--data", "theme=dark", "--print-data", "json"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		var data generator.TemplateData
+		if err := json.Unmarshal([]byte(output), &data); err != nil {
+			t.Fatalf("Expected the template data as JSON, got %q: %v", output, err)
+		}
+		if data.ComponentName != "icon_button" || data.UserData["theme"] != "dark" || data.ComponentImportPath == "" {
+			t.Errorf("Expected the resolved template data, got %+v", data)
+		}
+		if _, err := os.Stat(filepath.Join(cfg.App.GoPackage, "icon_button")); !os.IsNotExist(err) {
+			t.Errorf("Expected no component to be generated, got: %v", err)
+		}
+	})
+
+	t.Run("With --from-file", func(t *testing.T) {
+		cliApp.Reader = strings.NewReader("badge\n")
+		args := []string{"tempo", "component", "new", "--from-file", "-", "--print-data", "yaml"}
+		err := cliApp.Run(context.Background(), args)
+		if err == nil || !strings.Contains(err.Error(), "cannot be used together") {
+			t.Errorf("Expected error for conflicting flags, got %v", err)
+		}
+	})
+}
+
+func TestComponentCommand_NewSubCmd_FromHTML(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupComponentCommand(&app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}),
+		},
+	}
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to define component templates: %v", err)
+	}
+
+	snippet := filepath.Join(tempDir, "snippet.html")
+	testutils.CreateFile(t, snippet, `<style>.hero { padding: 2rem; }</style>
+<section class="hero" style="color: navy">
+  <h1>Welcome</h1>
+  <a href="/start">Start</a>
+</section>`)
+
+	_, err := testutils.CaptureStdout(func() {
+		args := []string{"tempo", "component", "new", "--name", "hero-banner", "--from-html", snippet}
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	templ, err := os.ReadFile(filepath.Join(cfg.App.GoPackage, "hero_banner", "hero_banner.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read component: %v", err)
+	}
+	for _, want := range []string{`    <section class="hero hero-banner-1">`, `        <h1>Welcome</h1>`} {
+		if !strings.Contains(string(templ), want) {
+			t.Errorf("Expected %q in the component, got:\n%s", want, templ)
+		}
+	}
+	if strings.Contains(string(templ), "continue here") {
+		t.Errorf("Expected the markup to replace the placeholder, got:\n%s", templ)
+	}
+
+	css, err := os.ReadFile(filepath.Join(cfg.App.AssetsDir, "hero_banner", "css", "base.css"))
+	if err != nil {
+		t.Fatalf("Failed to read CSS asset: %v", err)
+	}
+	for _, want := range []string{".hero { padding: 2rem; }", ".hero-banner-1 {\n  color: navy;\n}"} {
+		if !strings.Contains(string(css), want) {
+			t.Errorf("Expected %q in the CSS asset, got:\n%s", want, css)
+		}
+	}
+}
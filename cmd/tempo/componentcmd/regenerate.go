@@ -0,0 +1,140 @@
+package componentcmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/journal"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupComponentRegenerateSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "regenerate",
+		Usage:                  "Regenerate a component from the journal, replaying its actions with the recorded template data",
+		UsageText:              "tempo component regenerate --name <name> [--at <id|time>] [options]",
+		UseShortOptionHandling: true,
+		Flags:                  getRegenerateFlags(),
+		Action:                 runComponentRegenerateSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getRegenerateFlags defines the CLI flags for the regenerate subcommand.
+func getRegenerateFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "name",
+			Aliases:  []string{"n"},
+			Usage:    "Name of the component to regenerate",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "at",
+			Usage: "Journal entry ID, or time (e.g. 2026-10-16T10:15:00 or 2026-10-16) of the generation to replay (default: latest)",
+		},
+		&cli.BoolFlag{
+			Name:  "current-templates",
+			Usage: "Render the current actions file and templates instead of the snapshot recorded with the generation",
+		},
+		&cli.BoolFlag{
+			Name:  "no-diff",
+			Usage: "Overwrite the existing files without reviewing the diff",
+		},
+		helpers.YesFlag(),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runComponentRegenerateSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Find the recorded generation
+		name := newComponentNames(cmd.String("name")).Package
+		if name == "" {
+			return apperrors.Wrap("'--name' must be a valid component name")
+		}
+		j := journal.New(cmdCtx.Config.TempoRoot, journal.Retention{})
+		entries, err := j.Load()
+		if err != nil {
+			return err
+		}
+		entry, err := journal.Find(entries, "component", name, cmd.String("at"))
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Restore its template data, with the snapshot or the current templates
+		data := entry.Data
+		data.CLIUserData = entry.CLIUserData
+		data.FileModes = cmdCtx.Config.FileModes
+		data.Header = cmdCtx.Config.Templates.Header
+		data.TemplateExtensions = cmdCtx.Config.Templates.Extensions
+		data.SetLayout(cmdCtx.Config.Layout)
+		data.Force = true
+		data.DryRun = false
+
+		actionsFile := entry.ActionsFile
+		if cmd.Bool("current-templates") {
+			data.TemplatesDir = cmdCtx.Config.Paths.TemplatesDir
+		} else {
+			snapshot := j.SnapshotDir(entry.ID)
+			actionsFile = filepath.Join(snapshot, journal.ActionsFile)
+			if exists, err := utils.FileExists(actionsFile); err != nil {
+				return err
+			} else if !exists {
+				return apperrors.Wrap("the templates snapshot of journal entry %s is missing. Re-run with '--current-templates'", entry.ID)
+			}
+			data.TemplatesDir = filepath.Join(snapshot, journal.TemplatesDir)
+		}
+
+		// Step 3: Review the changes to the existing files, then replay the actions
+		process := func(ctx context.Context) error {
+			if err := generator.ProcessEntityActions(ctx, cmdCtx.Logger, actionsFile, &data, cmdCtx.Config); err != nil {
+				return apperrors.Wrap("failed to process actions for component", err, name)
+			}
+			return nil
+		}
+		componentPath := filepath.Join(data.GoPackage, name)
+		exists, err := utils.DirExists(componentPath)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if err := helpers.ConfirmOverwrite(ctx, true, cmd.Bool("no-diff"), helpers.AssumeYes(cmd, cmdCtx.Config), os.Stdout, process); err != nil {
+				return err
+			}
+		}
+		if err := process(ctx); err != nil {
+			return err
+		}
+
+		cmdCtx.Summary = map[string]any{"component": name, "journal_entry": entry.ID}
+		cmdCtx.Logger.Success("Component has been regenerated").
+			WithAttrs(
+				"component", name,
+				"journal_entry", entry.ID,
+				"generated_at", entry.Time.Format("2006-01-02 15:04:05"),
+				"component_path", componentPath,
+			)
+		return nil
+	}
+}
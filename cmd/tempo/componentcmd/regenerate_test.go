@@ -0,0 +1,119 @@
+package componentcmd
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestComponentCommand_RegenerateSubCmd(t *testing.T) {
+	cliApp, cliCtx := setupRenameTest(t)
+	cfg := cliCtx.Config
+
+	componentDir := filepath.Join(cfg.App.GoPackage, "button")
+	mainTempl := filepath.Join(componentDir, "button.templ")
+	original, err := os.ReadFile(mainTempl)
+	if err != nil {
+		t.Fatalf("Failed to read templ file: %v", err)
+	}
+
+	originalFiles := listFiles(t, componentDir)
+
+	// Templates edited after the generation
+	componentTemplate := filepath.Join(cfg.Paths.TemplatesDir, "component", "templ", "component.templ.gotxt")
+	template, err := os.ReadFile(componentTemplate)
+	if err != nil {
+		t.Fatalf("Failed to read template: %v", err)
+	}
+	if err := os.WriteFile(componentTemplate, append(template, []byte("\n// edited template\n")...), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	t.Run("deleted component", func(t *testing.T) {
+		if err := os.RemoveAll(componentDir); err != nil {
+			t.Fatalf("Failed to delete component: %v", err)
+		}
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "component", "regenerate", "--name", "button"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"Component has been regenerated"})
+
+		content, err := os.ReadFile(mainTempl)
+		if err != nil {
+			t.Fatalf("Expected the component to be regenerated: %v", err)
+		}
+		if string(content) != string(original) {
+			t.Errorf("Expected the recorded templates to be used, got:\n%s", content)
+		}
+		if files := listFiles(t, componentDir); !slices.Equal(files, originalFiles) {
+			t.Errorf("Expected the files %v to be regenerated, got %v", originalFiles, files)
+		}
+	})
+
+	t.Run("manual edits need a review", func(t *testing.T) {
+		if err := os.WriteFile(mainTempl, []byte("broken"), 0644); err != nil {
+			t.Fatalf("Failed to write templ file: %v", err)
+		}
+		_, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "component", "regenerate", "--name", "button"}); err == nil {
+				t.Errorf("Expected an error without --yes")
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		if content, _ := os.ReadFile(mainTempl); string(content) != "broken" {
+			t.Errorf("Expected the file to be kept until the review is confirmed")
+		}
+	})
+
+	t.Run("current templates", func(t *testing.T) {
+		_, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "regenerate", "--name", "button", "--current-templates", "--yes"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		if content, _ := os.ReadFile(mainTempl); !strings.Contains(string(content), "// edited template") {
+			t.Errorf("Expected the current templates to be used, got:\n%s", content)
+		}
+	})
+
+	t.Run("never generated", func(t *testing.T) {
+		if err := cliApp.Run(context.Background(), []string{"tempo", "component", "regenerate", "--name", "modal"}); err == nil {
+			t.Errorf("Expected an error for a component missing from the journal")
+		}
+	})
+}
+
+// listFiles returns the files of dir, relative to it.
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		files = append(files, rel)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to list files: %v", err)
+	}
+	return files
+}
@@ -0,0 +1,644 @@
+package componentcmd
+
+import (
+	"cmp"
+	"context"
+	"go/scanner"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/resolver"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// componentNames holds the names derived from a component name by the name providers.
+type componentNames struct {
+	Package    string // e.g. my_button
+	Exported   string // e.g. MyButton
+	Unexported string // e.g. myButton
+}
+
+func newComponentNames(name string) componentNames {
+	pkg := gonameprovider.ToGoPackageName(name)
+	return componentNames{
+		Package:    pkg,
+		Exported:   gonameprovider.ToGoExportedName(pkg),
+		Unexported: gonameprovider.ToGoUnexportedName(pkg),
+	}
+}
+
+// renamePlan describes the changes applied by the rename subcommand.
+type renamePlan struct {
+	From       componentNames
+	To         componentNames
+	ImportPath string // Import path of the Go package holding the components
+	SrcDir     string
+	DestDir    string
+	SrcAssets  string
+	DestAssets string
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupComponentRenameSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "rename",
+		Usage:                  "Rename a component, its package, templ functions and assets",
+		UsageText:              "tempo component rename --from <name> --to <name> [options]",
+		UseShortOptionHandling: true,
+		Flags:                  getRenameFlags(),
+		Action:                 runComponentRenameSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getRenameFlags defines the CLI flags for the rename subcommand.
+func getRenameFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "from",
+			Usage:    "Current name of the component",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "to",
+			Usage:    "New name of the component",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:    "package",
+			Aliases: []string{"p"},
+			Usage:   "The Go package name where components are generated (default: components)",
+		},
+		&cli.StringFlag{
+			Name:    "assets",
+			Aliases: []string{"a"},
+			Usage:   "The directory where asset files (e.g., CSS, JS) are generated (default: assets)",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Preview actions without making changes",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runComponentRenameSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer cmdCtx.Logger.Reset()
+
+		// Step 1: Build the rename plan
+		plan, err := createRenamePlan(cmd, cmdCtx)
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Check the source exists and the destination does not
+		if err := validateRenamePlan(plan); err != nil {
+			return err
+		}
+
+		if cmd.Bool("dry-run") {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.").
+				WithAttrs(
+					"component_path", plan.SrcDir+" -> "+plan.DestDir,
+					"asset_path", plan.SrcAssets+" -> "+plan.DestAssets,
+				)
+			return nil
+		}
+
+		// Step 3: Move the component package and its assets
+		if err := os.Rename(plan.SrcDir, plan.DestDir); err != nil {
+			return apperrors.Wrap("failed to move component folder", err, plan.SrcDir)
+		}
+		if err := moveAssets(plan.SrcAssets, plan.DestAssets); err != nil {
+			return err
+		}
+		if err := renameNamedFiles(plan.DestDir, plan.From.Package, plan.To.Package); err != nil {
+			return err
+		}
+
+		// Step 4: Rewrite the package, functions and import paths
+		updated, err := rewriteComponentFiles(plan)
+		if err != nil {
+			return err
+		}
+
+		// Step 5: Repair the guard markers of the renamed templ files
+		if err := repairRenamedGuardMarkers(cmdCtx.Logger, plan.DestDir, cmdCtx.Config.Templates.GuardMarker); err != nil {
+			return err
+		}
+
+		// Step 6: Warn about dependencies still declared with the old name
+		if refs := findDependencyReferences(cmdCtx.Config.Components.Dependencies, plan.From.Package); len(refs) > 0 {
+			cmdCtx.Logger.Warning("Component dependencies still reference the old name. Update 'components.dependencies' in the config file.").
+				WithAttrs("components", strings.Join(refs, ", "))
+		}
+
+		cmdCtx.Logger.Success("Component has been renamed").
+			WithAttrs(
+				"from", plan.From.Package,
+				"to", plan.To.Package,
+				"component_path", plan.DestDir,
+				"asset_path", plan.DestAssets,
+				"updated_files", len(updated),
+			)
+
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// createRenamePlan resolves the component names and folders involved in the rename.
+func createRenamePlan(cmd *cli.Command, cmdCtx *app.AppContext) (*renamePlan, error) {
+	cfg := cmdCtx.Config
+	goPackage, err := resolver.ResolveString(cmd.String("package"), cfg.App.GoPackage, "package", config.DefaultGoPackage, nil)
+	if err != nil {
+		return nil, err
+	}
+	assetsDir, err := resolver.ResolveString(cmd.String("assets"), cfg.App.AssetsDir, "assets folder", config.DefaultAssetsDir, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	from := newComponentNames(cmd.String("from"))
+	to := newComponentNames(cmd.String("to"))
+	if from.Package == "" || to.Package == "" {
+		return nil, apperrors.Wrap("both '--from' and '--to' must be valid component names")
+	}
+	if from.Package == to.Package {
+		return nil, apperrors.Wrap("component '%s' already has this name", from.Package)
+	}
+
+	if cfg.App.GoModule == "" && !cfg.App.RequiresGoModule() {
+		return nil, apperrors.Wrap("renaming a component rewrites its Go imports and requires a Go module; set app.go_module in the config file")
+	}
+
+	// The import path follows the folders of the Go package in the module, as in the
+	// generated components
+	moduleRoot, moduleName, err := cmdCtx.ResolveModule()
+	if err != nil {
+		return nil, err
+	}
+	importPath, err := generator.ComponentImportPath(moduleRoot, moduleName, goPackage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &renamePlan{
+		From:       from,
+		To:         to,
+		ImportPath: importPath,
+		SrcDir:     filepath.Join(goPackage, from.Package),
+		DestDir:    filepath.Join(goPackage, to.Package),
+		SrcAssets:  filepath.Join(assetsDir, from.Package),
+		DestAssets: filepath.Join(assetsDir, to.Package),
+	}, nil
+}
+
+// validateRenamePlan ensures the component exists and the new name is free.
+func validateRenamePlan(plan *renamePlan) error {
+	exists, err := utils.DirExists(plan.SrcDir)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apperrors.WrapCode(apperrors.CodeComponentNotFound, "component '%s' does not exist in %s", plan.From.Package, filepath.Dir(plan.SrcDir))
+	}
+
+	for _, dest := range []string{plan.DestDir, plan.DestAssets} {
+		exists, _, err := utils.FileOrDirExists(dest)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return apperrors.Wrap("cannot rename component to '%s': %s already exists", plan.To.Package, dest)
+		}
+	}
+	return nil
+}
+
+// moveAssets moves the asset tree of the component, when present, and refreshes the
+// modification time of its files so that the next sync processes them again.
+func moveAssets(src, dest string) error {
+	exists, err := utils.DirExists(src)
+	if err != nil || !exists {
+		return err
+	}
+
+	if err := utils.EnsureDirExists(filepath.Dir(dest)); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dest); err != nil {
+		return apperrors.Wrap("failed to move component assets", err, src)
+	}
+
+	now := time.Now()
+	return filepath.WalkDir(dest, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, now, now)
+	})
+}
+
+// renameNamedFiles renames the files of dir named after the component (e.g. button.templ
+// to btn.templ).
+func renameNamedFiles(dir, oldName, newName string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return apperrors.Wrap("failed to read component folder", err, dir)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, oldName+".") {
+			continue
+		}
+		src := filepath.Join(dir, name)
+		dest := filepath.Join(dir, newName+strings.TrimPrefix(name, oldName))
+		if err := os.Rename(src, dest); err != nil {
+			return apperrors.Wrap("failed to rename file", err, src)
+		}
+	}
+	return nil
+}
+
+// rewriteComponentFiles updates the .templ and .go files of the Go package: the files of
+// the renamed component get the new package name, identifiers and import paths, the
+// other components get their imports and qualified calls updated. It returns the
+// updated files.
+func rewriteComponentFiles(plan *renamePlan) ([]string, error) {
+	var updated []string
+
+	renamed, err := collectRenamedNames(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	root := filepath.Dir(plan.DestDir)
+	err = walkComponentSources(root, func(path, content string) error {
+		var rewritten string
+		if strings.HasPrefix(path, plan.DestDir+string(filepath.Separator)) {
+			rewritten = renameInComponent(content, plan, renamed, filepath.Ext(path) == ".go")
+		} else {
+			rewritten = renameReferences(content, plan, renamed)
+		}
+		if rewritten == content {
+			return nil
+		}
+
+		if err := utils.WriteStringToFile(path, rewritten); err != nil {
+			return apperrors.Wrap("failed to write file", err, path)
+		}
+		updated = append(updated, path)
+		return nil
+	})
+	if err != nil {
+		return updated, apperrors.Wrap("failed to update component files", err, root)
+	}
+	return updated, nil
+}
+
+// walkComponentSources calls fn with the content of the .templ and .go files under root,
+// skipping the files generated by templ.
+func walkComponentSources(root string, fn func(path, content string) error) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(path)
+		if d.IsDir() || (ext != ".templ" && ext != ".go") || strings.HasSuffix(path, "_templ.go") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return apperrors.Wrap("failed to read file", err, path)
+		}
+		return fn(path, string(content))
+	})
+}
+
+// collectRenamedNames returns the identifiers declared in the files of the renamed
+// component (func, templ, css, script, var, const and type names) that are derived from
+// the component name, mapped to their new names (e.g. ButtonCSS to BtnCSS).
+func collectRenamedNames(plan *renamePlan) (map[string]string, error) {
+	renamed := make(map[string]string)
+	err := walkComponentSources(plan.DestDir, func(_, content string) error {
+		tokens := scanSourceTokens(content)
+		for i := 1; i < len(tokens); i++ {
+			if tokens[i].tok != token.IDENT || !isDeclarationKeyword(tokens[i-1]) {
+				continue
+			}
+			if newName, ok := renameIdentifier(tokens[i].lit, plan.From.Exported, plan.To.Exported); ok {
+				renamed[tokens[i].lit] = newName
+			} else if newName, ok := renameIdentifier(tokens[i].lit, plan.From.Unexported, plan.To.Unexported); ok {
+				renamed[tokens[i].lit] = newName
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to read component files", err, plan.DestDir)
+	}
+	return renamed, nil
+}
+
+// renameInComponent renames the package clause, the identifiers declared by the component
+// (see collectRenamedNames) and the import paths in a file of the renamed component.
+// Strings are left untouched and, in the bodies of templ components, so is the markup:
+// only the calls, selectors and composite literals are renamed, so that e.g.
+// <button type="button"> is kept.
+func renameInComponent(content string, plan *renamePlan, renamed map[string]string, isGoFile bool) string {
+	content = renameImportPaths(content, plan)
+
+	tokens := scanSourceTokens(content)
+	inBody := make([]bool, len(tokens))
+	if !isGoFile {
+		inBody = templBodies(tokens)
+	}
+
+	var edits []tokenEdit
+	for i, t := range tokens {
+		switch {
+		case t.tok == token.COMMENT:
+			if comment := renameInComment(t.lit, renamed); comment != t.lit {
+				edits = append(edits, tokenEdit{sourceToken: t, text: comment})
+			}
+		case t.tok != token.IDENT:
+		case i > 0 && tokens[i-1].tok == token.PACKAGE:
+			if t.lit == plan.From.Package {
+				edits = append(edits, tokenEdit{sourceToken: t, text: plan.To.Package})
+			}
+		case renamed[t.lit] != "" && (!inBody[i] || isTemplExpression(tokens, i)):
+			edits = append(edits, tokenEdit{sourceToken: t, text: renamed[t.lit]})
+		}
+	}
+	return applyTokenEdits(content, edits)
+}
+
+// renameReferences updates the imports of the renamed component and the references
+// qualified with its package name, in a file importing it.
+func renameReferences(content string, plan *renamePlan, renamed map[string]string) string {
+	rewritten := renameImportPaths(content, plan)
+	if rewritten == content {
+		return content
+	}
+
+	tokens := scanSourceTokens(rewritten)
+	var edits []tokenEdit
+	for i := 0; i+2 < len(tokens); i++ {
+		pkg, selector := tokens[i], tokens[i+2]
+		if pkg.tok != token.IDENT || pkg.lit != plan.From.Package || tokens[i+1].tok != token.PERIOD || selector.tok != token.IDENT {
+			continue
+		}
+		edits = append(edits, tokenEdit{sourceToken: pkg, text: plan.To.Package})
+		if newName, ok := renamed[selector.lit]; ok {
+			edits = append(edits, tokenEdit{sourceToken: selector, text: newName})
+		}
+	}
+	return applyTokenEdits(rewritten, edits)
+}
+
+// renameImportPaths rewrites the import paths pointing to the renamed component.
+func renameImportPaths(content string, plan *renamePlan) string {
+	importPath := regexp.MustCompile(`"` + regexp.QuoteMeta(plan.ImportPath+"/"+plan.From.Package) + `(/[^"]*)?"`)
+	return importPath.ReplaceAllString(content, `"`+plan.ImportPath+"/"+plan.To.Package+`${1}"`)
+}
+
+// renameIdentifier returns the new name of name when it is oldName or made of oldName
+// followed by an uppercase suffix (e.g. ButtonCSS, buttonJsHandle).
+func renameIdentifier(name, oldName, newName string) (string, bool) {
+	if oldName == "" || oldName == newName || !strings.HasPrefix(name, oldName) {
+		return "", false
+	}
+	suffix := name[len(oldName):]
+	if suffix != "" && !strings.ContainsAny(suffix[:1], "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_") {
+		return "", false
+	}
+	return newName + suffix, true
+}
+
+// renameInComment replaces the renamed identifiers mentioned as whole words in a comment,
+// e.g. in the doc comment of a templ function.
+func renameInComment(comment string, renamed map[string]string) string {
+	word := regexp.MustCompile(`\b\w+\b`)
+	return word.ReplaceAllStringFunc(comment, func(w string) string {
+		return cmp.Or(renamed[w], w)
+	})
+}
+
+/* ------------------------------------------------------------------------- */
+/* Source Tokens                                                             */
+/* ------------------------------------------------------------------------- */
+
+// sourceToken is a token of a .go or .templ file.
+type sourceToken struct {
+	tok    token.Token
+	lit    string
+	offset int
+}
+
+// tokenEdit replaces the text of a token.
+type tokenEdit struct {
+	sourceToken
+	text string
+}
+
+// scanSourceTokens returns the tokens of a .go or .templ file, with the Go scanner. The
+// strings and comments are single tokens, so their content is never taken for an
+// identifier. The scan errors of the templ markup are ignored.
+func scanSourceTokens(content string) []sourceToken {
+	src := []byte(content)
+	file := token.NewFileSet().AddFile("", -1, len(src))
+
+	var s scanner.Scanner
+	s.Init(file, src, func(token.Position, string) {}, scanner.ScanComments)
+
+	var tokens []sourceToken
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return tokens
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue // Inserted at the end of the line, not in the source
+		}
+		switch {
+		case tok == token.COMMENT:
+			lit = commentSource(content, file.Offset(pos)) // The scanner strips the \r of the literal
+		case lit == "":
+			lit = tok.String()
+		}
+		tokens = append(tokens, sourceToken{tok: tok, lit: lit, offset: file.Offset(pos)})
+	}
+}
+
+// commentSource returns the comment starting at offset in content.
+func commentSource(content string, offset int) string {
+	comment := content[offset:]
+	if strings.HasPrefix(comment, "//") {
+		if end := strings.IndexByte(comment, '\n'); end >= 0 {
+			return comment[:end]
+		}
+		return comment
+	}
+	if end := strings.Index(comment[2:], "*/"); end >= 0 {
+		return comment[:end+4]
+	}
+	return comment
+}
+
+// isDeclarationKeyword reports whether t introduces a declared name: a Go declaration or
+// a templ, css or script component.
+func isDeclarationKeyword(t sourceToken) bool {
+	switch t.tok {
+	case token.FUNC, token.VAR, token.CONST, token.TYPE:
+		return true
+	case token.IDENT:
+		return t.lit == "templ" || t.lit == "css" || t.lit == "script"
+	default:
+		return false
+	}
+}
+
+// templBodies reports, for each token of a .templ file, whether it is in the body of a
+// templ, css or script component, where the markup is mixed with Go expressions.
+func templBodies(tokens []sourceToken) []bool {
+	inBody := make([]bool, len(tokens))
+	depth := 0
+	component, body := false, false
+	for i, t := range tokens {
+		if depth == 0 && t.tok == token.IDENT && isDeclarationKeyword(t) && i+1 < len(tokens) && tokens[i+1].tok == token.IDENT {
+			component = true
+		}
+		inBody[i] = body && depth > 0
+
+		switch t.tok {
+		case token.LBRACE:
+			if depth == 0 {
+				body, component = component, false
+			}
+			depth++
+		case token.RBRACE:
+			depth = max(depth-1, 0)
+		}
+	}
+	return inBody
+}
+
+// isTemplExpression reports whether the identifier at index i of a templ component body
+// is Go code rather than markup text: a templ call (@name), a selector, or the operand of
+// a call, a selector or a composite literal.
+func isTemplExpression(tokens []sourceToken, i int) bool {
+	if i > 0 {
+		prev := tokens[i-1]
+		if prev.tok == token.PERIOD || (prev.tok == token.ILLEGAL && prev.lit == "@") {
+			return true
+		}
+	}
+	if i+1 < len(tokens) {
+		switch tokens[i+1].tok {
+		case token.LPAREN, token.PERIOD, token.LBRACE:
+			return true
+		}
+	}
+	return false
+}
+
+// applyTokenEdits returns content with the edited tokens replaced. The edits are sorted
+// by offset.
+func applyTokenEdits(content string, edits []tokenEdit) string {
+	if len(edits) == 0 {
+		return content
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, edit := range edits {
+		sb.WriteString(content[last:edit.offset])
+		sb.WriteString(edit.text)
+		last = edit.offset + len(edit.lit)
+	}
+	sb.WriteString(content[last:])
+	return sb.String()
+}
+
+// repairRenamedGuardMarkers repairs the guard markers of the templ files of the renamed
+// component, so that the next sync can inject the assets.
+func repairRenamedGuardMarkers(log logger.Logger, dir, markerName string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".templ" {
+			return nil
+		}
+
+		result, err := processor.RepairGuardMarkersInFile(path, markerName, false)
+		if err != nil {
+			return err
+		}
+		switch result.Status {
+		case processor.RepairFixed:
+			log.Info("Repaired guard markers").
+				WithAttrs("file", path, "issues", strings.Join(result.Issues, "; "))
+		case processor.RepairUnresolved:
+			log.Warning("Cannot repair guard markers").
+				WithAttrs("file", path, "reason", result.Reason)
+		}
+		return nil
+	})
+}
+
+// findDependencyReferences returns the sorted components whose declared dependencies,
+// or own entry, use the given name.
+func findDependencyReferences(dependencies map[string][]string, name string) []string {
+	var refs []string
+	for component, deps := range dependencies {
+		if gonameprovider.ToGoPackageName(component) == name {
+			refs = append(refs, component)
+			continue
+		}
+		for _, dep := range deps {
+			if gonameprovider.ToGoPackageName(dep) == name {
+				refs = append(refs, component)
+				break
+			}
+		}
+	}
+	slices.Sort(refs)
+	return refs
+}
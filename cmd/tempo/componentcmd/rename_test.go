@@ -0,0 +1,276 @@
+package componentcmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+func setupRenameTest(t *testing.T) (*cli.Command, *app.AppContext) {
+	t.Helper()
+	tempDir := t.TempDir()
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupComponentCommand(cliCtx),
+		},
+	}
+
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to define component templates: %v", err)
+	}
+
+	_, err := testutils.CaptureStdout(func() {
+		args := []string{"tempo", "component", "new", "--name", "button", "--js"}
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Failed to create component: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	return cliApp, cliCtx
+}
+
+func TestComponentCommand_RenameSubCmd(t *testing.T) {
+	cliApp, cliCtx := setupRenameTest(t)
+	cfg := cliCtx.Config
+
+	// An old asset, as left by a previous sync
+	oldAsset := filepath.Join(cfg.App.AssetsDir, "button", "css", "base.css")
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(oldAsset, past, past); err != nil {
+		t.Fatalf("Failed to set asset time: %v", err)
+	}
+
+	// Another component using the renamed one
+	moduleRoot, moduleName, err := cliCtx.ResolveModule()
+	if err != nil {
+		t.Fatalf("Failed to resolve the Go module: %v", err)
+	}
+	importPath, err := generator.ComponentImportPath(moduleRoot, moduleName, cfg.App.GoPackage)
+	if err != nil {
+		t.Fatalf("Failed to resolve the import path: %v", err)
+	}
+	consumer := filepath.Join(cfg.App.GoPackage, "card", "card.templ")
+	testutils.CreateFile(t, consumer, "package card\n\nimport \""+importPath+"/button\"\n\ntempl Card() {\n\t@button.Button()\n}\n")
+
+	output, err := testutils.CaptureStdout(func() {
+		args := []string{"tempo", "component", "rename", "--from", "button", "--to", "btn"}
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"Component has been renamed"})
+
+	if _, err := os.Stat(filepath.Join(cfg.App.GoPackage, "button")); !os.IsNotExist(err) {
+		t.Errorf("Expected old component folder to be removed")
+	}
+
+	content, err := os.ReadFile(filepath.Join(cfg.App.GoPackage, "btn", "btn.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read renamed component: %v", err)
+	}
+	for _, want := range []string{"package btn", "templ Btn()", "@css.BtnCSS()", "/btn/css\""} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected renamed component to contain %q, got:\n%s", want, content)
+		}
+	}
+
+	cssContent, err := os.ReadFile(filepath.Join(cfg.App.GoPackage, "btn", "css", "base.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read renamed css templ: %v", err)
+	}
+	for _, want := range []string{"package css", "var btnCSSHandle", "templ BtnCSS()", "@themes.BtnThemes()", "[tempo] BEGIN"} {
+		if !strings.Contains(string(cssContent), want) {
+			t.Errorf("Expected renamed css templ to contain %q, got:\n%s", want, cssContent)
+		}
+	}
+
+	info, err := os.Stat(filepath.Join(cfg.App.AssetsDir, "btn", "css", "base.css"))
+	if err != nil {
+		t.Fatalf("Expected assets to be moved: %v", err)
+	}
+	if !info.ModTime().After(past) {
+		t.Errorf("Expected moved assets to be touched for the next sync")
+	}
+	if _, err := os.Stat(filepath.Join(cfg.App.AssetsDir, "button")); !os.IsNotExist(err) {
+		t.Errorf("Expected old assets folder to be removed")
+	}
+
+	consumerContent, err := os.ReadFile(consumer)
+	if err != nil {
+		t.Fatalf("Failed to read consumer component: %v", err)
+	}
+	if !strings.Contains(string(consumerContent), importPath+"/btn\"") || !strings.Contains(string(consumerContent), "@btn.Btn()") {
+		t.Errorf("Expected consumer to call the renamed component, got:\n%s", consumerContent)
+	}
+}
+
+func TestComponentCommand_RenameSubCmd_DryRun(t *testing.T) {
+	cliApp, cliCtx := setupRenameTest(t)
+
+	output, err := testutils.CaptureStdout(func() {
+		args := []string{"tempo", "component", "rename", "--from", "button", "--to", "btn", "--dry-run"}
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"Dry Run Mode"})
+
+	if _, err := os.Stat(filepath.Join(cliCtx.Config.App.GoPackage, "button")); err != nil {
+		t.Errorf("Expected component to be left untouched: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cliCtx.Config.App.GoPackage, "btn")); !os.IsNotExist(err) {
+		t.Errorf("Expected no renamed folder in dry-run mode")
+	}
+}
+
+func TestComponentCommand_RenameSubCmd_Errors(t *testing.T) {
+	cliApp, cliCtx := setupRenameTest(t)
+	testutils.CreateFile(t, filepath.Join(cliCtx.Config.App.GoPackage, "card", "card.templ"), "package card\n")
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr string
+	}{
+		{"missing component", "dropdown", "menu", "does not exist"},
+		{"same name", "button", "button", "already has this name"},
+		{"destination exists", "button", "card", "already exists"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := []string{"tempo", "component", "rename", "--from", tt.from, "--to", tt.to}
+			err := cliApp.Run(context.Background(), args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestComponentCommand_RenameSubCmd_WithoutGoModule(t *testing.T) {
+	cliApp, cliCtx := setupRenameTest(t)
+	requireGoModule := false
+	cliCtx.Config.App.RequireGoModule = &requireGoModule
+	cliCtx.Config.App.GoModule = ""
+
+	err := cliApp.Run(context.Background(), []string{"tempo", "component", "rename", "--from", "button", "--to", "btn"})
+	if err == nil || !strings.Contains(err.Error(), "requires a Go module") {
+		t.Errorf("Expected a Go module error, got %v", err)
+	}
+}
+
+func TestRenameIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"Button", "Btn", true},
+		{"ButtonCSS", "BtnCSS", true},
+		{"Button2", "Btn2", true},
+		{"Buttons", "", false},
+		{"MyButton", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := renameIdentifier(tt.name, "Button", "Btn")
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("renameIdentifier(%q) = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRenameInComponent(t *testing.T) {
+	plan := &renamePlan{From: newComponentNames("button"), To: newComponentNames("btn"), ImportPath: "example.com/app/components"}
+	renamed := map[string]string{"Button": "Btn", "ButtonProps": "BtnProps", "buttonJsHandle": "btnJsHandle"}
+
+	input := `package button
+
+import "example.com/app/components/button/css"
+
+// Button renders the button component.
+templ Button(props ButtonProps) {
+	@css.ButtonCSS()
+	@buttonJsHandle.Once() {
+		<button type="button" class="button">Button</button>
+	}
+}
+`
+	want := `package btn
+
+import "example.com/app/components/btn/css"
+
+// Btn renders the button component.
+templ Btn(props BtnProps) {
+	@css.ButtonCSS()
+	@btnJsHandle.Once() {
+		<button type="button" class="button">Button</button>
+	}
+}
+`
+	if got := renameInComponent(input, plan, renamed, false); got != want {
+		t.Errorf("renameInComponent() =\n%s\nwant:\n%s", got, want)
+	}
+
+	markup := "package card\n\ntempl Card() {\n\t<button type=\"button\">button</button>\n}\n"
+	if got := renameInComponent(markup, plan, map[string]string{"button": "btn"}, false); got != markup {
+		t.Errorf("Expected the markup to be left untouched, got:\n%s", got)
+	}
+}
+
+func TestRenameReferences(t *testing.T) {
+	plan := &renamePlan{From: newComponentNames("button"), To: newComponentNames("btn"), ImportPath: "example.com/app/components"}
+	renamed := map[string]string{"Button": "Btn"}
+
+	input := "package card\n\nimport \"example.com/app/components/button\"\n\ntempl Card() {\n\t<button type=\"button\">Buy</button>\n\t@button.Button()\n}\n"
+	want := "package card\n\nimport \"example.com/app/components/btn\"\n\ntempl Card() {\n\t<button type=\"button\">Buy</button>\n\t@btn.Btn()\n}\n"
+	if got := renameReferences(input, plan, renamed); got != want {
+		t.Errorf("renameReferences() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFindDependencyReferences(t *testing.T) {
+	deps := map[string][]string{
+		"dropdown": {"button", "icon"},
+		"button":   {"icon"},
+		"card":     {"icon"},
+	}
+	got := findDependencyReferences(deps, "button")
+	if strings.Join(got, ",") != "button,dropdown" {
+		t.Errorf("Expected [button dropdown], got %v", got)
+	}
+}
@@ -0,0 +1,238 @@
+package componentcmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/resolver"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// componentReference is a line of a Go or templ file referencing a component.
+type componentReference struct {
+	File      string // Path relative to the module root, with forward slashes
+	Line      int
+	Reference string // Imported path or qualified identifier, e.g. button.Button
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupComponentWhereUsedSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "where-used",
+		Usage:                  "List the Go and templ files of the module importing a component and their call sites",
+		UsageText:              "tempo component where-used --name <name> [options]",
+		UseShortOptionHandling: true,
+		Flags:                  getWhereUsedFlags(),
+		Action:                 runComponentWhereUsedSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getWhereUsedFlags defines the CLI flags for the where-used subcommand.
+func getWhereUsedFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "name",
+			Aliases:  []string{"n"},
+			Usage:    "Name of the component to search for",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:    "package",
+			Aliases: []string{"p"},
+			Usage:   "The Go package name where components are generated (default: components)",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runComponentWhereUsedSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Resolve the component folder and its import path
+		name, componentDir, err := resolveComponentFolder(cmd, cmdCtx.Config)
+		if err != nil {
+			return err
+		}
+		exists, err := utils.DirExists(componentDir)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return apperrors.WrapCode(apperrors.CodeComponentNotFound, "component '%s' does not exist in %s", name, filepath.Dir(componentDir))
+		}
+
+		moduleRoot, moduleName, err := cmdCtx.ResolveModule()
+		if err != nil {
+			return err
+		}
+		importPath, err := generator.ComponentImportPath(moduleRoot, moduleName, componentDir)
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Scan the module for references
+		refs, err := findComponentReferences(moduleRoot, componentDir, importPath)
+		if err != nil {
+			return err
+		}
+
+		files := make(map[string]bool)
+		for _, r := range refs {
+			files[r.File] = true
+		}
+		cmdCtx.Summary = map[string]any{"component": name, "references": len(refs), "files": len(files)}
+
+		if len(refs) == 0 {
+			cmdCtx.Logger.Info("No references found: the component is not used in the module").
+				WithAttrs("component", name, "import_path", importPath)
+			return nil
+		}
+
+		if err := writeReferencesTable(os.Stdout, refs); err != nil {
+			return err
+		}
+		cmdCtx.Logger.Success("Component references found").
+			WithAttrs("component", name, "references", len(refs), "files", len(files))
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// resolveComponentFolder returns the package name of the component and its folder.
+func resolveComponentFolder(cmd *cli.Command, cfg *config.Config) (name, componentDir string, err error) {
+	goPackage, err := resolver.ResolveString(cmd.String("package"), cfg.App.GoPackage, "package", config.DefaultGoPackage, nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	name = newComponentNames(cmd.String("name")).Package
+	if name == "" {
+		return "", "", apperrors.Wrap("'--name' must be a valid component name")
+	}
+	return name, filepath.Join(goPackage, name), nil
+}
+
+// findComponentReferences returns the imports of the component, or of its subpackages,
+// and the identifiers qualified with the imported name in the .go and .templ files of
+// the module. The component folder, the files generated by templ, the vendor folder and
+// the hidden folders are skipped.
+func findComponentReferences(moduleRoot, componentDir, importPath string) ([]componentReference, error) {
+	absComponent, err := filepath.Abs(componentDir)
+	if err != nil {
+		return nil, apperrors.Wrap("failed to resolve the component folder", err, componentDir)
+	}
+	importRe := regexp.MustCompile(`^\s*(?:import\s+)?(?:\(\s*)?(\w+|\.)?\s*"(` + regexp.QuoteMeta(importPath) + `(?:/[^"]*)?)"`)
+
+	var refs []componentReference
+	err = filepath.WalkDir(moduleRoot, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != moduleRoot && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor" || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			if abs, err := filepath.Abs(p); err == nil && abs == absComponent {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(p)
+		if (ext != ".go" && ext != ".templ") || strings.HasSuffix(p, "_templ.go") {
+			return nil
+		}
+
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return apperrors.Wrap("failed to read file", err, p)
+		}
+		if !bytes.Contains(content, []byte(`"`+importPath)) {
+			return nil
+		}
+		rel, err := filepath.Rel(moduleRoot, p)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, scanReferences(filepath.ToSlash(rel), content, importRe)...)
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to scan the Go module", err, moduleRoot)
+	}
+	return refs, nil
+}
+
+// scanReferences returns the import lines of content matching importRe and the lines
+// using an identifier qualified with the name of an imported package.
+func scanReferences(file string, content []byte, importRe *regexp.Regexp) []componentReference {
+	var refs []componentReference
+	var qualifiers []*regexp.Regexp
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if m := importRe.FindStringSubmatch(text); m != nil {
+			refs = append(refs, componentReference{File: file, Line: line, Reference: m[2]})
+			alias := m[1]
+			if alias == "" {
+				alias = path.Base(m[2])
+			}
+			if alias != "." && alias != "_" {
+				qualifiers = append(qualifiers, regexp.MustCompile(`\b`+regexp.QuoteMeta(alias)+`\.[A-Za-z_]\w*`))
+			}
+			continue
+		}
+		for _, q := range qualifiers {
+			for _, match := range q.FindAllString(text, -1) {
+				refs = append(refs, componentReference{File: file, Line: line, Reference: match})
+			}
+		}
+	}
+	return refs
+}
+
+// writeReferencesTable writes the references as an aligned table.
+func writeReferencesTable(w io.Writer, refs []componentReference) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nFILE\tLINE\tREFERENCE\n")
+	for _, r := range refs {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", r.File, r.Line, r.Reference)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,73 @@
+package componentcmd
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestComponentCommand_WhereUsedSubCmd(t *testing.T) {
+	cliApp, cliCtx := setupRenameTest(t)
+	cfg := cliCtx.Config
+
+	t.Run("no references", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "where-used", "--name", "button"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"No references found"})
+	})
+
+	importPath := "example.com/myproject/custom-package/button"
+	testutils.CreateFile(t, filepath.Join(cfg.App.GoPackage, "card", "card.templ"),
+		"package card\n\nimport \""+importPath+"\"\n\ntempl Card() {\n\t@button.Button()\n}\n")
+	testutils.CreateFile(t, filepath.Join(cliCtx.CWD, "pages", "home.go"),
+		"package pages\n\nimport (\n\t\"fmt\"\n\n\tbtn \""+importPath+"\"\n)\n\nvar _ = btn.Button\nvar _ = fmt.Sprint\n")
+	testutils.CreateFile(t, filepath.Join(cliCtx.CWD, "pages", "other.go"),
+		"package pages\n\nvar button = struct{ Button int }{}\nvar _ = button.Button\n")
+
+	t.Run("references", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			args := []string{"tempo", "component", "where-used", "--name", "button"}
+			if err := cliApp.Run(context.Background(), args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"FILE", "Component references found"})
+		for _, want := range []string{
+			`custom-package/card/card.templ\s+3\s+` + regexp.QuoteMeta(importPath),
+			`custom-package/card/card.templ\s+6\s+button\.Button`,
+			`pages/home.go\s+6\s+` + regexp.QuoteMeta(importPath),
+			`pages/home.go\s+9\s+btn\.Button`,
+		} {
+			if !regexp.MustCompile(want).MatchString(output) {
+				t.Errorf("Expected output to match %q, got:\n%s", want, output)
+			}
+		}
+		if strings.Contains(output, "other.go") {
+			t.Errorf("Expected files not importing the component to be skipped, got:\n%s", output)
+		}
+		if got := cliCtx.Summary["references"]; got != 4 {
+			t.Errorf("Expected 4 references in the summary, got %v", got)
+		}
+	})
+
+	t.Run("missing component", func(t *testing.T) {
+		args := []string{"tempo", "component", "where-used", "--name", "missing"}
+		if err := cliApp.Run(context.Background(), args); err == nil {
+			t.Errorf("Expected an error for a missing component")
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package configcmd
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupConfigCommand creates the "config" command with its "set" subcommand.
+func SetupConfigCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "config",
+		Usage:     "Manage project and user-level configuration",
+		UsageText: "tempo config [--global] <subcommand> [arguments]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "global",
+				Usage: "Use the user-level config ($XDG_CONFIG_HOME/tempo/config.yaml) instead of the project tempo.yaml",
+			},
+		},
+		Commands: []*cli.Command{
+			setupConfigSetSubCommand(cmdCtx),
+		},
+	}
+}
+
+func setupConfigSetSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:        "set",
+		Usage:       "Set a configuration value",
+		UsageText:   "tempo config [--global] set <key> <value>",
+		Description: "Supported keys: " + strings.Join(settableKeys(), ", "),
+		Action:      runConfigSetSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runConfigSetSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+
+		// Step 1: Validate arguments
+		if cmd.Args().Len() != 2 {
+			return apperrors.Wrap("expected exactly two arguments: <key> <value>")
+		}
+		key, value := cmd.Args().Get(0), cmd.Args().Get(1)
+
+		// Step 2: Resolve the target config file
+		configPath, err := resolveConfigPath(cmdCtx, cmd.Bool("global"))
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Update the config file
+		if err := config.SetValue(configPath, key, value); err != nil {
+			return apperrors.Wrap("failed to update config", err)
+		}
+
+		cmdCtx.Logger.Success("Configuration updated").
+			WithAttrs(
+				"key", key,
+				"value", value,
+				"config_file", configPath,
+			)
+		helpers.ResetLogger(cmdCtx.Logger)
+
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// resolveConfigPath returns the user-level config path when global is set,
+// otherwise the path to the project config file (see AppContext.ConfigPath).
+func resolveConfigPath(cmdCtx *app.AppContext, global bool) (string, error) {
+	if global {
+		return config.GlobalConfigPath()
+	}
+
+	if err := cmdCtx.IsTempoProject(); err != nil {
+		return "", err
+	}
+	return cmdCtx.ConfigPath()
+}
+
+// settableKeys returns the sorted list of keys accepted by "config set".
+func settableKeys() []string {
+	keys := make([]string, 0, len(config.SettableKeys))
+	for key := range config.SettableKeys {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,110 @@
+package configcmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+func newTestApp(cwd string) *cli.Command {
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: config.DefaultConfig(),
+		CWD:    cwd,
+	}
+
+	return &cli.Command{
+		Commands: []*cli.Command{
+			SetupConfigCommand(cliCtx),
+		},
+	}
+}
+
+func TestConfigCommand_SetGlobal(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	cliApp := newTestApp(t.TempDir())
+
+	output, err := testutils.CaptureStdout(func() {
+		args := []string{"tempo", "config", "--global", "set", "processor.workers", "4"}
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, output, []string{"✔ Configuration updated"})
+
+	data, err := os.ReadFile(filepath.Join(configHome, "tempo", "config.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read global config: %v", err)
+	}
+	if !strings.Contains(string(data), "workers: 4") {
+		t.Errorf("Expected global config to contain workers: 4, got:\n%s", data)
+	}
+}
+
+func TestConfigCommand_SetProject(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	configPath := filepath.Join(tempDir, "tempo.yaml")
+	if err := os.WriteFile(configPath, []byte("tempo_root: .tempo-files\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cliApp := newTestApp(tempDir)
+
+	if _, err := testutils.CaptureStdout(func() {
+		args := []string{"tempo", "config", "set", "app.css_layer", "components"}
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	for _, want := range []string{"tempo_root: .tempo-files", "css_layer: components"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected config file to contain %q, got:\n%s", want, data)
+		}
+	}
+}
+
+func TestConfigCommand_SetErrors(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing value", args: []string{"tempo", "config", "--global", "set", "processor.workers"}},
+		{name: "unknown key", args: []string{"tempo", "config", "--global", "set", "app.unknown", "x"}},
+		{name: "not a tempo project", args: []string{"tempo", "config", "set", "app.css_layer", "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cliApp := newTestApp(t.TempDir())
+			if err := cliApp.Run(context.Background(), tt.args); err == nil {
+				t.Errorf("Expected error for args %v, got nil", tt.args)
+			}
+		})
+	}
+}
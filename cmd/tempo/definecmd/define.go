@@ -0,0 +1,25 @@
+package definecmd
+
+import (
+	"context"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/urfave/cli/v3"
+)
+
+// SetupDefineCommand creates the "define" command with its "docs", "test" and "from-go" subcommands.
+func SetupDefineCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "define",
+		Usage:     "Tools for template authors",
+		UsageText: "tempo define <subcommand> [options]",
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Commands: []*cli.Command{
+			setupDefineDocsSubCommand(cmdCtx),
+			setupDefineTestSubCommand(cmdCtx),
+			setupDefineFromGoSubCommand(cmdCtx),
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package definecmd
+
+import (
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+)
+
+func TestSetupDefineCommand(t *testing.T) {
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: config.DefaultConfig(),
+		CWD:    t.TempDir(),
+	}
+
+	command := SetupDefineCommand(cliCtx)
+	if command == nil {
+		t.Fatal("SetupDefineCommand returned nil")
+	}
+
+	if command.Name != "define" {
+		t.Errorf("Expected command name 'define', got '%s'", command.Name)
+	}
+
+	if len(command.Commands) != 3 || command.Commands[0].Name != "docs" || command.Commands[1].Name != "test" || command.Commands[2].Name != "from-go" {
+		t.Errorf("Expected the 'docs', 'test' and 'from-go' subcommands, got %v", command.Commands)
+	}
+}
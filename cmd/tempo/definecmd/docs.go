@@ -0,0 +1,181 @@
+package definecmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/resolver"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+// DefaultDocsFile is the name of the generated reference file, inside the tempo root.
+const DefaultDocsFile = "TEMPLATE_VARIABLES.md"
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupDefineDocsSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "docs",
+		Usage:                  "Generate a reference of the variables available inside templates",
+		UsageText:              "tempo define docs [options]",
+		UseShortOptionHandling: true,
+		Flags:                  getDocsFlags(),
+		Action:                 runDefineDocsSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getDocsFlags defines the CLI flags for the "docs" subcommand.
+func getDocsFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   "Path of the generated file, '-' for stdout (default: <tempo_root>/" + DefaultDocsFile + ")",
+		},
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"f"},
+			Usage:   "Output format: markdown, json (default: markdown)",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runDefineDocsSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Resolve the output format and file
+		format, err := resolver.ResolveString(cmd.String("format"), "", "format", "markdown", []string{"markdown", "json"})
+		if err != nil {
+			return err
+		}
+
+		output := cmd.String("output")
+		if output == "" {
+			output = filepath.Join(cmdCtx.Config.TempoRoot, DefaultDocsFile)
+		}
+
+		// Step 2: Collect the variables and where the templates use them
+		references, err := generator.FindTemplateReferences(cmdCtx.Config.Paths.TemplatesDir, cmdCtx.Config.Templates.Extensions)
+		if err != nil {
+			return err
+		}
+		sample := sampleTemplateData(cmdCtx.Config)
+		// Only a sample value: left empty outside of a Go module
+		_ = helpers.ResolveComponentImportPath(cmdCtx, sample)
+		vars := generator.DescribeTemplateVariables(sample, references)
+
+		// Step 3: Write the reference
+		var content strings.Builder
+		if format == "json" {
+			if err := writeVariablesJSON(&content, vars); err != nil {
+				return err
+			}
+		} else {
+			writeVariablesMarkdown(&content, vars)
+		}
+
+		if output == "-" {
+			_, err := io.WriteString(os.Stdout, content.String())
+			return err
+		}
+
+		if err := utils.WriteStringToFile(output, content.String()); err != nil {
+			return apperrors.Wrap("failed to write template variables reference", err, output)
+		}
+		cmdCtx.Logger.Success("Template variables reference has been generated").
+			WithAttrs("file", output, "variables", len(vars))
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// sampleTemplateData returns the template data of a sample "button" component, with
+// the values of the configuration.
+func sampleTemplateData(cfg *config.Config) *generator.TemplateData {
+	return &generator.TemplateData{
+		TemplatesDir:     cfg.Paths.TemplatesDir,
+		ActionsDir:       cfg.Paths.ActionsDir,
+		GoModule:         cfg.App.GoModule,
+		GoModuleOptional: !cfg.App.RequiresGoModule(),
+		GoPackage:        cfg.App.GoPackage,
+		ComponentName:    "button",
+		VariantName:      "outline",
+		TagName:          "x-button",
+		AssetsDir:        cfg.App.AssetsDir,
+		StylesDir:        cfg.Layout.Styles(),
+		VariantsDir:      cfg.Layout.Variants(),
+		ScriptsDir:       cfg.Layout.Scripts(),
+		WithJs:           cfg.App.JsEnabled(),
+		WithTsDecls:      cfg.App.TsDeclsEnabled(),
+		CssLayer:         cfg.App.CssLayer,
+		GuardMarker:      cfg.Templates.GuardMarker,
+		Watermark:        cfg.Templates.Watermark,
+		UserData:         cfg.Templates.UserData,
+	}
+}
+
+// writeVariablesMarkdown writes the variables as a Markdown table.
+func writeVariablesMarkdown(w io.Writer, vars []generator.TemplateVariable) {
+	fmt.Fprintln(w, "# Template Variables")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Variables available inside the action templates. Sample values come from a")
+	fmt.Fprintln(w, "`button` component generated with the current tempo.yaml. Handlebars templates")
+	fmt.Fprintln(w, "use the same names without the leading dot (e.g. `{{ComponentName}}`).")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Variable | Type | Sample | Description | Used in |")
+	fmt.Fprintln(w, "| --- | --- | --- | --- | --- |")
+	for _, v := range vars {
+		fmt.Fprintf(w, "| `%s` | %s | %s | %s | %s |\n",
+			v.Name, v.Type, markdownCode(v.Sample), v.Description, markdownCell(strings.Join(v.UsedIn, ", ")))
+	}
+}
+
+// writeVariablesJSON writes the variables as an indented JSON array.
+func writeVariablesJSON(w io.Writer, vars []generator.TemplateVariable) error {
+	data, err := json.MarshalIndent(vars, "", "  ")
+	if err != nil {
+		return apperrors.Wrap("failed to marshal template variables", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// markdownCode formats a sample value as inline code, leaving empty values blank.
+func markdownCode(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "`" + markdownCell(value) + "`"
+}
+
+// markdownCell escapes the characters breaking a Markdown table cell.
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", " ")
+}
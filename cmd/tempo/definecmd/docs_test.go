@@ -0,0 +1,123 @@
+package definecmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+// setupDocsProject creates a project with a single template using a built-in field,
+// a defined user_data key and an undefined one.
+func setupDocsProject(t *testing.T) (string, *config.Config) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, func(cfg *config.Config) {
+		cfg.Templates.UserData = map[string]any{"author": "Jane Doe"}
+	})
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	testutils.CreateFile(t,
+		filepath.Join(cfg.Paths.TemplatesDir, "component", "templ", "component.templ.gotxt"),
+		"package {{ .ComponentName }}\n// {{ .UserData.author }} <{{ .UserData.email }}>")
+
+	return tempDir, cfg
+}
+
+func runDocs(t *testing.T, tempDir string, cfg *config.Config, args ...string) string {
+	t.Helper()
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupDefineCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    tempDir,
+			}),
+		},
+	}
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), append([]string{"tempo", "define", "docs"}, args...)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	return output
+}
+
+func TestDefineDocs_Markdown(t *testing.T) {
+	tempDir, cfg := setupDocsProject(t)
+
+	output := runDocs(t, tempDir, cfg)
+	testutils.ValidateCLIOutput(t, output, []string{"Template variables reference has been generated"})
+
+	content, err := os.ReadFile(filepath.Join(cfg.TempoRoot, DefaultDocsFile))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, string(content), []string{
+		"# Template Variables",
+		"| `.ComponentName` | string | `button` |",
+		"component/templ/component.templ.gotxt",
+		"| `.UserData.author` | string | `Jane Doe` |",
+		"| `.UserData.email` | undefined |",
+	})
+}
+
+func TestDefineDocs_JSONToStdout(t *testing.T) {
+	tempDir, cfg := setupDocsProject(t)
+
+	output := runDocs(t, tempDir, cfg, "--format", "json", "--output", "-")
+
+	var vars []generator.TemplateVariable
+	if err := json.Unmarshal([]byte(output), &vars); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+	}
+
+	found := false
+	for _, v := range vars {
+		if v.Name == ".ComponentName" {
+			found = true
+			if len(v.UsedIn) != 1 {
+				t.Errorf("Expected .ComponentName to be used in one template, got %v", v.UsedIn)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected .ComponentName in %v", vars)
+	}
+
+	if _, err := os.Stat(filepath.Join(cfg.TempoRoot, DefaultDocsFile)); !os.IsNotExist(err) {
+		t.Errorf("Expected no file to be written when printing to stdout")
+	}
+}
+
+func TestDefineDocs_CustomOutput(t *testing.T) {
+	tempDir, cfg := setupDocsProject(t)
+	output := filepath.Join(tempDir, "docs", "vars.md")
+
+	runDocs(t, tempDir, cfg, "-o", output)
+
+	if _, err := os.Stat(output); err != nil {
+		t.Errorf("Expected %s to be written: %v", output, err)
+	}
+}
@@ -0,0 +1,181 @@
+package definecmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/cmdrunner"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/pkg/actions"
+	"github.com/urfave/cli/v3"
+)
+
+// definitionsTimeout bounds the build and run of the definitions program.
+const definitionsTimeout = 2 * time.Minute
+
+// runDefinitions runs the definitions program in dir and returns its output. It is a
+// variable to allow testing overrides.
+var runDefinitions = func(cwd, dir string) ([]byte, error) {
+	return cmdrunner.RunCommandStdout(cwd, definitionsTimeout, "go", "run", dir)
+}
+
+// actionFileStatus is the outcome of an actions file.
+type actionFileStatus struct {
+	Path   string `json:"path"`
+	Status string `json:"status"` // "created", "updated" or "unchanged"
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupDefineFromGoSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "from-go",
+		Usage:     "Write the actions files defined by a Go program using the pkg/actions builder",
+		UsageText: "tempo define from-go [--dry-run] <dir>",
+		Description: "Runs the Go program in <dir> with 'go run'. The program declares the action sets with " +
+			"actions.Define and calls actions.Main; each set is written to <actions_dir>/<name>.json.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "List the actions files that would change without writing them",
+			},
+		},
+		Action: runDefineFromGoSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runDefineFromGoSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		if cmd.Args().Len() != 1 {
+			return helpers.RequiredArgError("dir")
+		}
+		dryRun := cmd.Bool("dry-run")
+
+		// Step 1: Run the definitions program
+		output, err := runDefinitions(cmdCtx.CWD, goPackagePath(cmd.Args().First()))
+		if err != nil {
+			return apperrors.Wrap("failed to run the action definitions", err, cmd.Args().First())
+		}
+		sets, err := actions.Decode(bytes.NewReader(output))
+		if err != nil {
+			return err
+		}
+		if len(sets) == 0 {
+			cmdCtx.Logger.Warning("No action sets defined; declare them with actions.Define and call actions.Main")
+			return nil
+		}
+
+		// Step 2: Write the changed actions files
+		results, err := writeActionFiles(cmdCtx.Config.Paths.ActionsDir, sets, dryRun)
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Report the actions files
+		changed := 0
+		for _, result := range results {
+			if result.Status != "unchanged" {
+				changed++
+			}
+		}
+		cmdCtx.Summary = map[string]any{"files": results, "changed": changed, "dry_run": dryRun}
+		if err := writeActionFilesTable(os.Stdout, results, dryRun); err != nil {
+			return err
+		}
+		if dryRun {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes were made.").WithAttrs("files", changed)
+			return nil
+		}
+		cmdCtx.Logger.Success("Actions files are up to date").WithAttrs("changed", changed)
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// goPackagePath returns dir as a path understood by `go run`, which reads the relative
+// paths without a leading "./" as import paths.
+func goPackagePath(dir string) string {
+	if filepath.IsAbs(dir) || strings.HasPrefix(dir, ".") {
+		return dir
+	}
+	return "." + string(filepath.Separator) + dir
+}
+
+// writeActionFiles writes the sets to the actions folder, sorted by name, leaving the
+// unchanged files untouched. Nothing is written in dry-run mode.
+func writeActionFiles(actionsDir string, sets map[string][]actions.Action, dryRun bool) ([]actionFileStatus, error) {
+	names := make([]string, 0, len(sets))
+	for name := range sets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	results := make([]actionFileStatus, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(actionsDir, name+".json")
+		content, err := generator.MarshalActionsJSON(sets[name])
+		if err != nil {
+			return nil, err
+		}
+
+		status := "created"
+		existing, err := os.ReadFile(path)
+		switch {
+		case err == nil && bytes.Equal(existing, content):
+			status = "unchanged"
+		case err == nil:
+			status = "updated"
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, apperrors.Wrap("failed to read actions file", err, path)
+		}
+		results = append(results, actionFileStatus{Path: path, Status: status})
+
+		if dryRun || status == "unchanged" {
+			continue
+		}
+		if err := utils.WriteToFile(path, content); err != nil {
+			return nil, apperrors.Wrap("failed to write actions file", err, path)
+		}
+	}
+	return results, nil
+}
+
+// writeActionFilesTable lists the actions files and their status.
+func writeActionFilesTable(w io.Writer, results []actionFileStatus, dryRun bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nFILE\tSTATUS\n")
+	for _, result := range results {
+		status := result.Status
+		if dryRun && status != "unchanged" {
+			status = "would be " + status
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", result.Path, status)
+	}
+	return tw.Flush()
+}
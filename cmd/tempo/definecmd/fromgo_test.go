@@ -0,0 +1,97 @@
+package definecmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/indaco/tempo/pkg/actions"
+	"github.com/urfave/cli/v3"
+)
+
+func runFromGo(t *testing.T, tempDir string, cfg *config.Config, args ...string) (string, error) {
+	t.Helper()
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupDefineCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    tempDir,
+			}),
+		},
+	}
+
+	var runErr error
+	output, err := testutils.CaptureStdout(func() {
+		runErr = cliApp.Run(context.Background(), append([]string{"tempo", "define", "from-go"}, args...))
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	return output, runErr
+}
+
+func TestDefineFromGo(t *testing.T) {
+	tempDir, cfg := setupTestProject(t)
+
+	var gotDir string
+	original := runDefinitions
+	t.Cleanup(func() { runDefinitions = original })
+	runDefinitions = func(cwd, dir string) ([]byte, error) {
+		gotDir = dir
+		var buf bytes.Buffer
+		err := actions.Encode(&buf,
+			actions.New("component").RenderFile("component/templ/component.templ.gotxt", "{{ .GoPackage }}/{{ .ComponentName }}.templ"),
+		)
+		return buf.Bytes(), err
+	}
+
+	actionFile := filepath.Join(cfg.Paths.ActionsDir, "component.json")
+
+	output, err := runFromGo(t, tempDir, cfg, "--dry-run", "defs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gotDir != "."+string(filepath.Separator)+"defs" {
+		t.Errorf("Expected the definitions to run as a relative package, got %s", gotDir)
+	}
+	if !strings.Contains(output, "would be created") {
+		t.Errorf("Expected the dry-run status, got:\n%s", output)
+	}
+	if _, err := os.Stat(actionFile); !os.IsNotExist(err) {
+		t.Fatalf("Expected no actions file in dry-run mode, got %v", err)
+	}
+
+	if _, err := runFromGo(t, tempDir, cfg, "defs"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(actionFile)
+	if err != nil {
+		t.Fatalf("Failed to read actions file: %v", err)
+	}
+	if !strings.Contains(string(content), `"templateFile": "component/templ/component.templ.gotxt"`) {
+		t.Errorf("Unexpected actions file:\n%s", content)
+	}
+
+	output, err = runFromGo(t, tempDir, cfg, "defs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "unchanged") {
+		t.Errorf("Expected the actions file to be unchanged, got:\n%s", output)
+	}
+}
+
+func TestDefineFromGo_MissingDir(t *testing.T) {
+	tempDir, cfg := setupTestProject(t)
+	if _, err := runFromGo(t, tempDir, cfg); err == nil {
+		t.Fatal("Expected an error without the definitions folder, got nil")
+	}
+}
@@ -0,0 +1,95 @@
+package definecmd
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/urfave/cli/v3"
+)
+
+// DefaultFixturesDir is the name of the folder holding the template fixtures, inside the tempo root.
+const DefaultFixturesDir = "fixtures"
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupDefineTestSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "test",
+		Usage:                  "Render the templates against fixture data and report rendering errors",
+		UsageText:              "tempo define test [options]",
+		UseShortOptionHandling: true,
+		Flags:                  getTestFlags(),
+		Action:                 runDefineTestSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getTestFlags defines the CLI flags for the "test" subcommand.
+func getTestFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "fixtures",
+			Usage: "Folder of the YAML or JSON fixture files (default: <tempo_root>/" + DefaultFixturesDir + ")",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runDefineTestSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Load the fixtures, falling back to the sample data
+		fixturesDir := cmd.String("fixtures")
+		if fixturesDir == "" {
+			fixturesDir = filepath.Join(cmdCtx.Config.TempoRoot, DefaultFixturesDir)
+		}
+
+		sample := sampleTemplateData(cmdCtx.Config)
+		fixtures, err := generator.LoadTemplateFixtures(fixturesDir, sample)
+		if err != nil {
+			return err
+		}
+		if len(fixtures) == 0 {
+			cmdCtx.Logger.Hint("No fixtures found, rendering with the sample data").WithAttrs("fixtures", fixturesDir)
+			fixtures = []generator.TemplateFixture{{Name: "sample", Data: sample}}
+		}
+
+		// Step 2: Render every template against every fixture
+		results, err := generator.RenderTemplates(cmdCtx.Config.Paths.TemplatesDir, cmdCtx.Config.Templates.Extensions, fixtures)
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Report results
+		failed := 0
+		for _, result := range results {
+			if result.Err == nil {
+				continue
+			}
+			failed++
+			cmdCtx.Logger.Error("Rendering failed").
+				WithAttrs("template", result.Template, "fixture", result.Fixture, "error", result.Err.Error())
+		}
+		if failed > 0 {
+			return apperrors.Wrap("%s of %s template render(s) failed", failed, len(results))
+		}
+
+		cmdCtx.Logger.Success("All templates rendered successfully").
+			WithAttrs("renders", len(results), "fixtures", len(fixtures))
+		return nil
+	}
+}
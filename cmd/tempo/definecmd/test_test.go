@@ -0,0 +1,94 @@
+package definecmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+// setupTestProject creates a project with a template using a user_data key that only
+// some fixtures define.
+func setupTestProject(t *testing.T) (string, *config.Config) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+	testutils.CreateFile(t,
+		filepath.Join(cfg.Paths.TemplatesDir, "component", "templ", "component.templ.gotxt"),
+		"package {{ .ComponentName }}\n// {{ .UserData.size.default }}")
+
+	return tempDir, cfg
+}
+
+func runTest(tempDir string, cfg *config.Config, args ...string) (string, error) {
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupDefineCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    tempDir,
+			}),
+		},
+	}
+
+	var runErr error
+	output, err := testutils.CaptureStdout(func() {
+		runErr = cliApp.Run(context.Background(), append([]string{"tempo", "define", "test"}, args...))
+	})
+	if err != nil {
+		return "", err
+	}
+	return output, runErr
+}
+
+func TestDefineTest_Fixtures(t *testing.T) {
+	tempDir, cfg := setupTestProject(t)
+	fixturesDir := filepath.Join(cfg.TempoRoot, DefaultFixturesDir)
+	testutils.CreateFile(t, filepath.Join(fixturesDir, "button.yaml"), "component_name: button\nuser_data:\n  size:\n    default: md\n")
+	testutils.CreateFile(t, filepath.Join(fixturesDir, "input.json"), `{"component_name": "input", "user_data": {"size": {"default": "sm"}}}`)
+
+	output, err := runTest(tempDir, cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"All templates rendered successfully", "renders: 2", "fixtures: 2"})
+}
+
+func TestDefineTest_ReportsFailures(t *testing.T) {
+	tempDir, cfg := setupTestProject(t)
+	fixturesDir := filepath.Join(tempDir, "custom-fixtures")
+	testutils.CreateFile(t, filepath.Join(fixturesDir, "ok.yaml"), "user_data:\n  size:\n    default: md\n")
+	testutils.CreateFile(t, filepath.Join(fixturesDir, "broken.yaml"), "user_data:\n  size: md\n")
+
+	output, err := runTest(tempDir, cfg, "--fixtures", fixturesDir)
+	if err == nil || !utils.ErrorContains(err, "1 of 2 template render(s) failed") {
+		t.Fatalf("Expected a render failure, got %v", err)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"Rendering failed", "component/templ/component.templ.gotxt", "fixture: broken"})
+}
+
+func TestDefineTest_SampleDataWithoutFixtures(t *testing.T) {
+	tempDir, cfg := setupTestProject(t)
+	testutils.CreateFile(t, filepath.Join(cfg.Paths.TemplatesDir, "component", "templ", "component.templ.gotxt"),
+		"package {{ .ComponentName }}")
+
+	output, err := runTest(tempDir, cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"No fixtures found", "All templates rendered successfully"})
+}
@@ -0,0 +1,105 @@
+package explaincmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupExplainCommand creates the "explain" command describing the error codes.
+func SetupExplainCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "explain",
+		Usage:     "Explain an error code: its causes and the commands fixing it (lists all codes without argument)",
+		UsageText: "tempo explain [--json] [error-code]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Print the explanation as JSON",
+			},
+		},
+		Action: runExplainCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runExplainCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		if cmd.Args().Len() > 1 {
+			return apperrors.Wrap("expected at most one argument: <error-code>")
+		}
+
+		if cmd.Args().Len() == 0 {
+			codes := apperrors.Codes()
+			if cmd.Bool("json") {
+				return writeJSON(os.Stdout, codes)
+			}
+			return writeCodesTable(os.Stdout, codes)
+		}
+
+		info, ok := apperrors.LookupCode(cmd.Args().First())
+		if !ok {
+			return apperrors.Wrap("unknown error code %s. Run 'tempo explain' to list them", cmd.Args().First())
+		}
+		if cmd.Bool("json") {
+			return writeJSON(os.Stdout, info)
+		}
+		return writeExplanation(os.Stdout, info)
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return apperrors.Wrap("failed to marshal error codes", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// writeCodesTable writes the codes and their titles as an aligned table.
+func writeCodesTable(w io.Writer, codes []apperrors.CodeInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "CODE\tTITLE\n")
+	for _, info := range codes {
+		fmt.Fprintf(tw, "%s\t%s\n", info.Code, info.Title)
+	}
+	return tw.Flush()
+}
+
+// writeExplanation writes the full explanation of an error code.
+func writeExplanation(w io.Writer, info apperrors.CodeInfo) error {
+	fmt.Fprintf(w, "%s: %s\n\n%s\n", info.Code, info.Title, info.Explanation)
+	if len(info.Causes) > 0 {
+		fmt.Fprintf(w, "\nCommon causes:\n")
+		for _, cause := range info.Causes {
+			fmt.Fprintf(w, "  - %s\n", cause)
+		}
+	}
+	if len(info.Fixes) > 0 {
+		fmt.Fprintf(w, "\nHow to fix:\n")
+		for _, fix := range info.Fixes {
+			fmt.Fprintf(w, "  - %s\n", fix)
+		}
+	}
+	return nil
+}
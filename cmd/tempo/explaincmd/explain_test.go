@@ -0,0 +1,50 @@
+package explaincmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+func TestExplainCommand(t *testing.T) {
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupExplainCommand(&app.AppContext{Logger: logger.NewDefaultLogger()}),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{"list codes", []string{"tempo", "explain"}, []string{"CODE", "TEMPO-E001", "TEMPO-E007"}},
+		{"explain code", []string{"tempo", "explain", "TEMPO-E003"}, []string{"Missing go.mod file", "Common causes:", "How to fix:", "go mod init"}},
+		{"short code", []string{"tempo", "explain", "e003"}, []string{"TEMPO-E003"}},
+		{"json", []string{"tempo", "explain", "--json", "E003"}, []string{`"code": "TEMPO-E003"`, `"fixes"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := testutils.CaptureStdout(func() {
+				if err := cliApp.Run(context.Background(), tt.args); err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			})
+			if err != nil {
+				t.Fatalf("Failed to capture stdout: %v", err)
+			}
+			testutils.ValidateCLIOutput(t, output, tt.expected)
+		})
+	}
+
+	t.Run("unknown code", func(t *testing.T) {
+		if err := cliApp.Run(context.Background(), []string{"tempo", "explain", "TEMPO-E999"}); err == nil {
+			t.Errorf("Expected an error for an unknown code")
+		}
+	})
+}
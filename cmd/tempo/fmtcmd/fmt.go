@@ -0,0 +1,170 @@
+package fmtcmd
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/formatter"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/sarif"
+	"github.com/indaco/tempo/internal/version"
+	"github.com/urfave/cli/v3"
+)
+
+// Output formats of the check results.
+const (
+	FormatText  = "text"
+	FormatSARIF = "sarif"
+)
+
+// formats lists the supported output formats.
+var formats = []string{FormatText, FormatSARIF}
+
+// ruleUnformatted is the SARIF rule of the files that need formatting.
+const ruleUnformatted = "tempo/unformatted-file"
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupFmtCommand creates the "fmt" command for normalizing templates and action files.
+func SetupFmtCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "fmt",
+		Usage:                  "Format action files and templates",
+		UsageText:              "tempo fmt [options]",
+		UseShortOptionHandling: true,
+		Flags:                  getFlags(),
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Action: runFmtCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getFlags defines CLI flags.
+func getFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "check",
+			Usage: "Report files that are not formatted without rewriting them; exits with an error if any are found",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Value: FormatText,
+			Usage: "Output format of the check results: text or sarif (implies --check, for code scanning annotations)",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runFmtCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		format := cmd.String("format")
+		if !slices.Contains(formats, format) {
+			return apperrors.Wrap("invalid value for '--format' %s (expected one of: %s)", format, strings.Join(formats, ", "))
+		}
+		check := cmd.Bool("check") || format == FormatSARIF
+
+		// Step 1: Format action files and templates
+		changed, err := formatFiles(cmdCtx.Config, check)
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Report results
+		if format == FormatSARIF {
+			return writeSARIF(cmdCtx, changed)
+		}
+
+		if len(changed) == 0 {
+			cmdCtx.Logger.Success("All files are formatted")
+			return nil
+		}
+
+		if check {
+			for _, path := range changed {
+				cmdCtx.Logger.Warning("Not formatted").WithAttrs("path", path)
+			}
+			return apperrors.WrapCode(apperrors.CodeUnformattedFiles, "%s file(s) need formatting. Run 'tempo fmt' to fix them", len(changed))
+		}
+
+		for _, path := range changed {
+			cmdCtx.Logger.Info("Formatted").WithAttrs("path", path)
+		}
+		cmdCtx.Logger.Success("Formatting completed")
+
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// Unformatted returns the action files and templates that need formatting, without
+// rewriting them.
+func Unformatted(cfg *config.Config) ([]string, error) {
+	return formatFiles(cfg, true)
+}
+
+// AddSARIFResults adds the files that need formatting to log, at the first line changed
+// by formatting, with paths relative to root.
+func AddSARIFResults(log *sarif.Log, root string, changed []string) error {
+	log.AddRule(ruleUnformatted, "File is not formatted")
+	for _, path := range changed {
+		line, err := formatter.FirstChangedLine(path)
+		if err != nil {
+			return err
+		}
+		log.AddResult(ruleUnformatted, sarif.LevelWarning, "File is not formatted. Run 'tempo fmt' to fix it", root, path, line)
+	}
+	return nil
+}
+
+// formatFiles formats the action files and templates, or only reports them with check,
+// and returns the changed ones.
+func formatFiles(cfg *config.Config, check bool) ([]string, error) {
+	changed, err := formatter.Run(formatter.Options{
+		ActionsDir:   cfg.Paths.ActionsDir,
+		TemplatesDir: cfg.Paths.TemplatesDir,
+		Extensions:   cfg.Templates.Extensions,
+		Check:        check,
+	})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to format files", err)
+	}
+	return changed, nil
+}
+
+// writeSARIF prints the files that need formatting as a SARIF log, at the first line
+// changed by formatting, and fails when there are any.
+func writeSARIF(cmdCtx *app.AppContext, changed []string) error {
+	log := sarif.New("tempo", version.GetVersion(), "https://github.com/indaco/tempo")
+	if err := AddSARIFResults(log, cmdCtx.CWD, changed); err != nil {
+		return err
+	}
+
+	if err := log.Write(os.Stdout); err != nil {
+		return apperrors.Wrap("failed to write SARIF output", err)
+	}
+	if len(changed) > 0 {
+		return apperrors.WrapCode(apperrors.CodeUnformattedFiles, "%s file(s) need formatting", len(changed))
+	}
+	return nil
+}
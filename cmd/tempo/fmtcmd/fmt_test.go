@@ -0,0 +1,121 @@
+package fmtcmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/sarif"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+func TestFmtCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	templatePath := filepath.Join(cfg.Paths.TemplatesDir, "component", "templ", "component.templ.gotxt")
+	if err := os.MkdirAll(filepath.Dir(templatePath), 0755); err != nil {
+		t.Fatalf("Failed to create templates dir: %v", err)
+	}
+	if err := os.WriteFile(templatePath, []byte("templ Button() {   \r\n}"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupFmtCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    tempDir,
+			}),
+		},
+	}
+
+	t.Run("check reports unformatted files", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "fmt", "--check"}); err == nil {
+				t.Errorf("Expected an error in check mode with unformatted files")
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"Not formatted", templatePath})
+	})
+
+	t.Run("sarif reports unformatted files", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "fmt", "--format", "sarif"}); err == nil {
+				t.Errorf("Expected an error with unformatted files")
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		var log sarif.Log
+		if err := json.Unmarshal([]byte(output), &log); err != nil {
+			t.Fatalf("Invalid SARIF output: %v\n%s", err, output)
+		}
+		results := log.Results()
+		if len(results) != 1 {
+			t.Fatalf("Expected 1 result, got %d", len(results))
+		}
+		location := results[0].Locations[0].PhysicalLocation
+		rel, _ := filepath.Rel(tempDir, templatePath)
+		if location.ArtifactLocation.URI != filepath.ToSlash(rel) {
+			t.Errorf("Expected uri %q, got %q", filepath.ToSlash(rel), location.ArtifactLocation.URI)
+		}
+		if location.Region == nil || location.Region.StartLine != 1 {
+			t.Errorf("Expected start line 1, got %+v", location.Region)
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		if err := cliApp.Run(context.Background(), []string{"tempo", "fmt", "--format", "xml"}); err == nil {
+			t.Errorf("Expected an error for an invalid format")
+		}
+	})
+
+	t.Run("fmt rewrites files", func(t *testing.T) {
+		if _, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "fmt"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}); err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		content, err := os.ReadFile(templatePath)
+		if err != nil {
+			t.Fatalf("Failed to read template: %v", err)
+		}
+		if string(content) != "templ Button() {\n}\n" {
+			t.Errorf("Unexpected formatted content: %q", content)
+		}
+	})
+
+	t.Run("check passes once formatted", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "fmt", "--check"}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"All files are formatted"})
+	})
+}
@@ -0,0 +1,177 @@
+package headerscmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/header"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupHeadersCommand creates the "headers" command with its "apply" subcommand.
+func SetupHeadersCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "headers",
+		Usage:     "Manage the license or banner header of the generated files (templates.header)",
+		UsageText: "tempo headers <subcommand> [options]",
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Commands: []*cli.Command{
+			setupHeadersApplySubCommand(cmdCtx),
+		},
+	}
+}
+
+func setupHeadersApplySubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "apply",
+		Usage:     "Prepend the configured header to the existing files lacking it",
+		UsageText: "tempo headers apply [--dry-run] [paths...]",
+		Description: "Walks the given files and folders, by default the go_package and assets_dir folders, " +
+			"and prepends the templates.header header to the files lacking it. The generated *_templ.go " +
+			"files and the file types without a comment syntax are left out.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "List the files that would get the header without making changes",
+			},
+		},
+		Action: runHeadersApplySubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runHeadersApplySubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		cfg := cmdCtx.Config
+		dryRun := cmd.Bool("dry-run")
+
+		// Step 1: Build the header
+		h, err := header.New(cfg.Templates.Header, cfg.Templates.UserData)
+		if err != nil {
+			return err
+		}
+		if h == nil {
+			return apperrors.Wrap("no header configured; set 'templates.header.template' in tempo.yaml")
+		}
+
+		// Step 2: Collect the managed files
+		roots := cmd.Args().Slice()
+		if len(roots) == 0 {
+			roots = []string{cfg.App.GoPackage, cfg.App.AssetsDir}
+		}
+		files, err := collectFiles(roots)
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Prepend the header to the files lacking it
+		policy, err := cfg.FileModes.Policy()
+		if err != nil {
+			return err
+		}
+		var changed []string
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return apperrors.Wrap("failed to read file", err, file)
+			}
+			updated, ok, err := h.Apply(string(content), file)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			changed = append(changed, file)
+			if dryRun {
+				continue
+			}
+			if err := policy.WriteFile(file, []byte(updated), 0); err != nil {
+				return apperrors.Wrap("failed to write file", err, file)
+			}
+		}
+
+		// Step 4: Report the changed files
+		cmdCtx.Summary = map[string]any{"checked": len(files), "changed": changed, "dry_run": dryRun}
+		if len(changed) == 0 {
+			cmdCtx.Logger.Success("All files have the header").WithAttrs("checked", len(files))
+			return nil
+		}
+		if err := writeTable(os.Stdout, changed, dryRun); err != nil {
+			return err
+		}
+		if dryRun {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes were made.").WithAttrs("files", len(changed))
+			return nil
+		}
+		cmdCtx.Logger.Success("Header applied").WithAttrs("files", len(changed))
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// collectFiles returns the files under roots, sorted, leaving out the generated
+// *_templ.go files. Missing roots are ignored.
+func collectFiles(roots []string) ([]string, error) {
+	var files []string
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) && path == root {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || strings.HasSuffix(d.Name(), "_templ.go") {
+				return nil
+			}
+			files = append(files, filepath.Clean(path))
+			return nil
+		})
+		if err != nil {
+			return nil, apperrors.Wrap("failed to walk folder", err, root)
+		}
+	}
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
+
+// writeTable lists the changed files.
+func writeTable(w io.Writer, changed []string, dryRun bool) error {
+	status := "updated"
+	if dryRun {
+		status = "would update"
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nFILE\tSTATUS\n")
+	for _, file := range changed {
+		fmt.Fprintf(tw, "%s\t%s\n", file, status)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,97 @@
+package headerscmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+func TestHeadersCommand_Apply(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	templPath := filepath.Join(cfg.App.GoPackage, "button", "button.templ")
+	generatedPath := filepath.Join(cfg.App.GoPackage, "button", "button_templ.go")
+	cssPath := filepath.Join(cfg.App.AssetsDir, "button", "base.css")
+	testutils.CreateFile(t, templPath, "package button\n")
+	testutils.CreateFile(t, generatedPath, "package button\n")
+	testutils.CreateFile(t, cssPath, ".button {}\n")
+
+	run := func(t *testing.T, header config.Header, args ...string) (string, error) {
+		t.Helper()
+		cfg.Templates.Header = header
+		cliApp := &cli.Command{Commands: []*cli.Command{SetupHeadersCommand(&app.AppContext{
+			Logger: logger.NewDefaultLogger(),
+			Config: cfg,
+			CWD:    tempDir,
+		})}}
+		var runErr error
+		output, err := testutils.CaptureStdout(func() {
+			runErr = cliApp.Run(context.Background(), append([]string{"tempo", "headers", "apply"}, args...))
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		return output, runErr
+	}
+	read := func(t *testing.T, path string) string {
+		t.Helper()
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+	header := config.Header{Template: "Copyright {{ .Author }}", Author: "ACME Inc."}
+
+	t.Run("No header configured", func(t *testing.T) {
+		_, err := run(t, config.Header{})
+		if err == nil || !strings.Contains(err.Error(), "no header configured") {
+			t.Errorf("Expected an error for a missing header, got %v", err)
+		}
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		output, err := run(t, header, "--dry-run")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(output, templPath) || !strings.Contains(output, "would update") {
+			t.Errorf("Expected the output to list %s, got:\n%s", templPath, output)
+		}
+		if got := read(t, templPath); got != "package button\n" {
+			t.Errorf("Expected no changes in dry-run mode, got %q", got)
+		}
+	})
+
+	t.Run("Applies the header once", func(t *testing.T) {
+		for range 2 {
+			if _, err := run(t, header); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		if got := read(t, templPath); got != "// Copyright ACME Inc.\n\npackage button\n" {
+			t.Errorf("Unexpected templ content %q", got)
+		}
+		if got := read(t, cssPath); got != "/* Copyright ACME Inc. */\n\n.button {}\n" {
+			t.Errorf("Unexpected CSS content %q", got)
+		}
+		if got := read(t, generatedPath); got != "package button\n" {
+			t.Errorf("Expected the generated templ file to be left out, got %q", got)
+		}
+	})
+}
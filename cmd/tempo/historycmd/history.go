@@ -0,0 +1,296 @@
+package historycmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/history"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/version"
+	"github.com/urfave/cli/v3"
+)
+
+// DefaultLimit is the number of invocations listed by "tempo history".
+const DefaultLimit = 20
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupHistoryCommand creates the "history" command listing the recorded invocations.
+func SetupHistoryCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "history",
+		Usage:     "Query the local history of the tempo invocations (opt-in with history.enabled)",
+		UsageText: "tempo history [--command sync] [--since 7d] [--limit 20] [--stats] [--json] [--clear]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "command",
+				Aliases: []string{"c"},
+				Usage:   "Only the invocations of the given command (e.g. \"sync\" or \"component new\")",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only the invocations more recent than the given age (e.g. 12h, 7d, 2w)",
+			},
+			&cli.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"n"},
+				Value:   DefaultLimit,
+				Usage:   "Number of invocations listed, latest first (0 for all)",
+			},
+			&cli.BoolFlag{
+				Name:  "stats",
+				Usage: "Show the runs, failures, average and maximum duration per command, slowest first",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Print the invocations or the statistics as JSON",
+			},
+			&cli.BoolFlag{
+				Name:  "clear",
+				Usage: "Remove the history file",
+			},
+		},
+		Commands: []*cli.Command{
+			setupHistoryPruneSubCommand(cmdCtx),
+		},
+		Action: runHistoryCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runHistoryCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		path, err := cmdCtx.Config.History.Path()
+		if err != nil {
+			return err
+		}
+
+		if cmd.Bool("clear") {
+			return clearHistory(cmdCtx, path)
+		}
+
+		var since time.Time
+		if value := cmd.String("since"); value != "" {
+			age, err := utils.ParseDuration(value)
+			if err != nil {
+				return apperrors.Wrap("invalid value for '--since'", err)
+			}
+			since = time.Now().Add(-age)
+		}
+
+		entries, err := history.Load(path)
+		if err != nil {
+			return err
+		}
+		entries = history.Filter(entries, cmd.String("command"), since)
+
+		if len(entries) == 0 && !cmd.Bool("json") {
+			helpers.EnableLoggerIndentation(cmdCtx.Logger)
+			defer helpers.ResetLogger(cmdCtx.Logger)
+
+			if !cmdCtx.Config.History.IsEnabled() {
+				cmdCtx.Logger.Info("No invocations recorded. Enable the history with 'tempo config --global set history.enabled true'")
+				return nil
+			}
+			cmdCtx.Logger.Info("No invocations recorded").WithAttrs("file", path)
+			return nil
+		}
+
+		if cmd.Bool("stats") {
+			stats := history.Summarize(entries)
+			if cmd.Bool("json") {
+				return writeJSON(os.Stdout, stats)
+			}
+			return writeStatsTable(os.Stdout, stats)
+		}
+
+		entries = latest(entries, int(cmd.Int("limit")))
+		if cmd.Bool("json") {
+			return writeJSON(os.Stdout, entries)
+		}
+		return writeEntriesTable(os.Stdout, entries)
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Recording                                                                 */
+/* ------------------------------------------------------------------------- */
+
+// Track records, for every command of root, the deepest command reached by a run.
+// It returns a function reporting that command after the run (root when none ran).
+func Track(root *cli.Command) func() *cli.Command {
+	ran := root
+	var track func(cmd *cli.Command)
+	track = func(cmd *cli.Command) {
+		before := cmd.Before
+		cmd.Before = func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			ran = c
+			if before == nil {
+				return ctx, nil
+			}
+			return before(ctx, c)
+		}
+		for _, sub := range cmd.Commands {
+			track(sub)
+		}
+	}
+	track(root)
+	return func() *cli.Command { return ran }
+}
+
+// Record appends the invocation of cmd to the history file when the history is
+// enabled, pruning the entries beyond the retention. Invocations of "tempo history"
+// and its subcommands are left out. Recording never fails the
+// command: errors are reported as warnings.
+func Record(cmdCtx *app.AppContext, cmd *cli.Command, duration time.Duration, runErr error) {
+	if !cmdCtx.Config.History.IsEnabled() || cmd == nil {
+		return
+	}
+
+	entry := newEntry(cmdCtx, cmd, duration, runErr)
+	if entry.Command == "history" || strings.HasPrefix(entry.Command, "history ") {
+		return
+	}
+
+	path, err := cmdCtx.Config.History.Path()
+	var maxAge time.Duration
+	if err == nil {
+		maxAge, err = cmdCtx.Config.History.Age()
+	}
+	if err == nil {
+		err = history.Append(path, entry, cmdCtx.Config.History.MaxEntries, maxAge)
+	}
+	if err != nil {
+		cmdCtx.Logger.Warning("Failed to record the invocation in the history").WithAttrs("error", err.Error())
+	}
+}
+
+// CommandName returns the name of cmd below the root command, e.g. "component new", or
+// the root name for the root command itself.
+func CommandName(cmd *cli.Command) string {
+	lineage := cmd.Lineage()
+
+	var names []string
+	for i := len(lineage) - 2; i >= 0; i-- {
+		names = append(names, lineage[i].Name)
+	}
+	if len(names) == 0 {
+		return lineage[len(lineage)-1].Name
+	}
+	return strings.Join(names, " ")
+}
+
+// newEntry builds the history entry of the invocation of cmd.
+func newEntry(cmdCtx *app.AppContext, cmd *cli.Command, duration time.Duration, runErr error) history.Entry {
+	var flags []string
+	lineage := cmd.Lineage()
+	for i := len(lineage) - 1; i >= 0; i-- {
+		for _, f := range lineage[i].Flags {
+			if f.IsSet() {
+				flags = append(flags, "--"+f.Names()[0])
+			}
+		}
+	}
+
+	command := CommandName(cmd)
+
+	entry := history.Entry{
+		Time:     time.Now().Add(-duration),
+		Command:  command,
+		Flags:    flags,
+		Dir:      cmdCtx.CWD,
+		Version:  version.GetVersion(),
+		Duration: duration,
+		Result:   history.ResultSuccess,
+	}
+	if runErr != nil {
+		entry.Result = history.ResultError
+		entry.Error = runErr.Error()
+	}
+	return entry
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// clearHistory removes the history file.
+func clearHistory(cmdCtx *app.AppContext, path string) error {
+	helpers.EnableLoggerIndentation(cmdCtx.Logger)
+	defer helpers.ResetLogger(cmdCtx.Logger)
+
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			cmdCtx.Logger.Info("History is already empty")
+			return nil
+		}
+		return apperrors.Wrap("failed to remove history file", err, path)
+	}
+	cmdCtx.Logger.Success("History cleared").WithAttrs("file", path)
+	return nil
+}
+
+// latest returns the last limit entries, latest first (all of them when limit is not positive).
+func latest(entries []history.Entry, limit int) []history.Entry {
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	reversed := make([]history.Entry, len(entries))
+	for i, entry := range entries {
+		reversed[len(entries)-1-i] = entry
+	}
+	return reversed
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return apperrors.Wrap("failed to marshal history", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// writeEntriesTable writes the invocations as an aligned table.
+func writeEntriesTable(w io.Writer, entries []history.Entry) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "TIME\tCOMMAND\tDURATION\tRESULT\tFLAGS\n")
+	for _, entry := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			entry.Time.Local().Format(time.DateTime),
+			entry.Command,
+			entry.Duration.Round(time.Millisecond),
+			entry.Result,
+			strings.Join(entry.Flags, " "),
+		)
+	}
+	return tw.Flush()
+}
+
+// writeStatsTable writes the statistics per command as an aligned table.
+func writeStatsTable(w io.Writer, stats []history.CommandStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "COMMAND\tRUNS\tFAILURES\tAVERAGE\tMAX\n")
+	for _, s := range stats {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\n",
+			s.Command, s.Runs, s.Failures, s.Average.Round(time.Millisecond), s.Max.Round(time.Millisecond))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,193 @@
+package historycmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/history"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+func setupHistoryContext(t *testing.T, enabled bool) *app.AppContext {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cfg.History = config.History{
+		Enabled: &enabled,
+		File:    filepath.Join(tempDir, "history.jsonl"),
+	}
+
+	return &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+}
+
+// runTracked runs args against a CLI with a "component new" command and the
+// history command, recording the invocation like the tempo entry point.
+func runTracked(t *testing.T, cmdCtx *app.AppContext, actionErr error, args ...string) string {
+	t.Helper()
+
+	root := &cli.Command{
+		Name: "tempo",
+		Commands: []*cli.Command{
+			{
+				Name: "component",
+				Commands: []*cli.Command{
+					{
+						Name:  "new",
+						Flags: []cli.Flag{&cli.StringFlag{Name: "name", Aliases: []string{"n"}}, &cli.BoolFlag{Name: "force"}},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return actionErr
+						},
+					},
+				},
+			},
+			SetupHistoryCommand(cmdCtx),
+		},
+	}
+	ranCommand := Track(root)
+
+	output, err := testutils.CaptureStdout(func() {
+		start := time.Now()
+		err := root.Run(context.Background(), append([]string{"tempo"}, args...))
+		Record(cmdCtx, ranCommand(), time.Since(start), err)
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	return output
+}
+
+func TestRecord(t *testing.T) {
+	cmdCtx := setupHistoryContext(t, true)
+
+	runTracked(t, cmdCtx, nil, "component", "new", "-n", "button", "--force")
+	runTracked(t, cmdCtx, errors.New("boom"), "component", "new", "--name", "card")
+	runTracked(t, cmdCtx, nil, "history")
+
+	entries, err := history.Load(cmdCtx.Config.History.File)
+	if err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 recorded invocations, got %+v", entries)
+	}
+
+	first := entries[0]
+	if first.Command != "component new" || first.Result != history.ResultSuccess || first.Dir != cmdCtx.CWD {
+		t.Errorf("Unexpected first entry: %+v", first)
+	}
+	if len(first.Flags) != 2 || first.Flags[0] != "--name" || first.Flags[1] != "--force" {
+		t.Errorf("Expected the flag names only, got %v", first.Flags)
+	}
+	if entries[1].Result != history.ResultError || entries[1].Error != "boom" {
+		t.Errorf("Expected the failure to be recorded, got %+v", entries[1])
+	}
+}
+
+func TestRecord_Disabled(t *testing.T) {
+	cmdCtx := setupHistoryContext(t, false)
+
+	runTracked(t, cmdCtx, nil, "component", "new", "--name", "button")
+
+	if _, err := os.Stat(cmdCtx.Config.History.File); !os.IsNotExist(err) {
+		t.Errorf("Expected no history file when the history is disabled, got %v", err)
+	}
+
+	output := runTracked(t, cmdCtx, nil, "history")
+	testutils.ValidateCLIOutput(t, output, []string{"No invocations recorded", "history.enabled"})
+}
+
+func TestHistoryCommand_Query(t *testing.T) {
+	cmdCtx := setupHistoryContext(t, true)
+	path := cmdCtx.Config.History.File
+
+	now := time.Now()
+	for _, entry := range []history.Entry{
+		{Time: now.Add(-72 * time.Hour), Command: "sync", Duration: 3 * time.Second, Result: history.ResultSuccess},
+		{Time: now.Add(-time.Hour), Command: "sync", Duration: time.Second, Result: history.ResultError},
+		{Time: now, Command: "fmt", Duration: 10 * time.Millisecond, Result: history.ResultSuccess, Flags: []string{"--check"}},
+	} {
+		if err := history.Append(path, entry, 0, 0); err != nil {
+			t.Fatalf("Failed to append history entry: %v", err)
+		}
+	}
+
+	output := runTracked(t, cmdCtx, nil, "history", "--limit", "2")
+	testutils.ValidateCLIOutput(t, output, []string{"COMMAND", "fmt", "--check", "error"})
+
+	output = runTracked(t, cmdCtx, nil, "history", "--command", "sync", "--since", "1d", "--json")
+	var entries []history.Entry
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		t.Fatalf("Failed to parse JSON output %q: %v", output, err)
+	}
+	if len(entries) != 1 || entries[0].Result != history.ResultError {
+		t.Errorf("Expected the recent sync invocation only, got %+v", entries)
+	}
+
+	output = runTracked(t, cmdCtx, nil, "history", "--stats", "--json")
+	var stats []history.CommandStats
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		t.Fatalf("Failed to parse JSON output %q: %v", output, err)
+	}
+	if len(stats) != 2 || stats[0].Command != "sync" || stats[0].Runs != 2 || stats[0].Failures != 1 {
+		t.Errorf("Unexpected statistics: %+v", stats)
+	}
+
+	output = runTracked(t, cmdCtx, nil, "history", "--clear")
+	testutils.ValidateCLIOutput(t, output, []string{"History cleared"})
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the history file to be removed, got %v", err)
+	}
+}
+
+func TestHistoryCommand_Prune(t *testing.T) {
+	cmdCtx := setupHistoryContext(t, true)
+	path := cmdCtx.Config.History.File
+
+	now := time.Now()
+	for _, entry := range []history.Entry{
+		{Time: now.Add(-60 * 24 * time.Hour), Command: "sync", Result: history.ResultSuccess},
+		{Time: now.Add(-45 * 24 * time.Hour), Command: "fmt", Result: history.ResultError},
+		{Time: now.Add(-time.Hour), Command: "sync", Result: history.ResultError},
+	} {
+		if err := history.Append(path, entry, 0, 0); err != nil {
+			t.Fatalf("Failed to append history entry: %v", err)
+		}
+	}
+
+	output := runTracked(t, cmdCtx, nil, "history", "prune", "--age", "30d", "--dry-run")
+	testutils.ValidateCLIOutput(t, output, []string{"Dry Run Mode", "STORE", "would be pruned"})
+	if entries, _ := history.Load(path); len(entries) != 3 {
+		t.Errorf("Expected the dry run to keep the history, got %d entries", len(entries))
+	}
+
+	output = runTracked(t, cmdCtx, nil, "history", "prune", "--age", "30d")
+	testutils.ValidateCLIOutput(t, output, []string{"History and journal pruned"})
+	entries, err := history.Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Result != history.ResultSuccess || entries[1].Command != "sync" {
+		t.Errorf("Expected the latest successful and the recent invocations to be kept, got %+v", entries)
+	}
+	if got := cmdCtx.Summary["history_removed"]; got != 1 {
+		t.Errorf("Expected 1 removed entry in the summary, got %v", got)
+	}
+
+	if err := SetupHistoryCommand(cmdCtx).Run(context.Background(), []string{"history", "prune", "--age", "soon"}); err == nil {
+		t.Errorf("Expected an error for an invalid age")
+	}
+}
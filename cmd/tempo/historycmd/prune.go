@@ -0,0 +1,170 @@
+package historycmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/history"
+	"github.com/indaco/tempo/internal/journal"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// pruneResult is the outcome of the pruning of a store.
+type pruneResult struct {
+	Store   string
+	File    string
+	Kept    int
+	Removed int
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupHistoryPruneSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "prune",
+		Usage:     "Remove the old entries of the invocation history and of the generation journal, keeping the latest successful state",
+		UsageText: "tempo history prune [--age 30d] [--max-entries 100] [--dry-run]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "age",
+				Usage: "Remove the entries older than the given age, e.g. 30d (default: history.max_age and journal.max_age)",
+			},
+			&cli.IntFlag{
+				Name:  "max-entries",
+				Usage: "Number of entries kept in each store (default: history.max_entries and journal.max_entries)",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Preview the removed entries without making changes",
+			},
+		},
+		Action: runHistoryPruneSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runHistoryPruneSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Resolve the retention of each store
+		historyAge, err := cmdCtx.Config.History.Age()
+		if err != nil {
+			return err
+		}
+		retention, err := journal.RetentionFromConfig(cmdCtx.Config.Journal)
+		if err != nil {
+			return err
+		}
+		historyMax := cmdCtx.Config.History.MaxEntries
+		if value := cmd.String("age"); value != "" {
+			age, err := utils.ParseDuration(value)
+			if err != nil {
+				return apperrors.Wrap("invalid value for '--age'", err)
+			}
+			if age <= 0 {
+				return apperrors.Wrap("invalid value for '--age': must be a positive duration such as 30d")
+			}
+			historyAge, retention.MaxAge = age, age
+		}
+		if cmd.IsSet("max-entries") {
+			maxEntries := int(cmd.Int("max-entries"))
+			if maxEntries <= 0 {
+				return apperrors.Wrap("invalid value for '--max-entries': must be a positive integer")
+			}
+			historyMax, retention.MaxEntries = maxEntries, maxEntries
+		}
+
+		dryRun := cmd.Bool("dry-run")
+		if dryRun {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.")
+		}
+
+		// Step 2: Prune the invocation history
+		historyResult, err := pruneHistory(cmdCtx, historyMax, historyAge, dryRun)
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Prune the generation journal of the project
+		kept, removed, err := journal.New(cmdCtx.Config.TempoRoot, retention).PruneFile(dryRun)
+		if err != nil {
+			return err
+		}
+		journalResult := pruneResult{
+			Store:   "journal",
+			File:    filepath.Join(cmdCtx.Config.TempoRoot, journal.Dir, journal.EntriesFile),
+			Kept:    len(kept),
+			Removed: len(removed),
+		}
+
+		// Step 4: Summarize the pruning
+		results := []pruneResult{historyResult, journalResult}
+		if err := writePruneResults(os.Stdout, results); err != nil {
+			return err
+		}
+		total := historyResult.Removed + journalResult.Removed
+		cmdCtx.Summary = map[string]any{"history_removed": historyResult.Removed, "journal_removed": journalResult.Removed}
+
+		message := "History and journal pruned"
+		if dryRun {
+			message = "History and journal would be pruned"
+		}
+		cmdCtx.Logger.Success(message).WithAttrs("removed", total)
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// pruneHistory removes the entries of the history file beyond maxEntries or older
+// than maxAge, keeping the latest successful invocation (see history.Prune).
+func pruneHistory(cmdCtx *app.AppContext, maxEntries int, maxAge time.Duration, dryRun bool) (pruneResult, error) {
+	path, err := cmdCtx.Config.History.Path()
+	if err != nil {
+		return pruneResult{}, err
+	}
+	entries, err := history.Load(path)
+	if err != nil {
+		return pruneResult{}, err
+	}
+
+	kept, removed := history.Prune(entries, maxEntries, maxAge, time.Now())
+	if !dryRun && removed > 0 {
+		if err := history.Save(path, kept); err != nil {
+			return pruneResult{}, err
+		}
+	}
+	return pruneResult{Store: "history", File: path, Kept: len(kept), Removed: removed}, nil
+}
+
+// writePruneResults writes the outcome of the pruning as an aligned table.
+func writePruneResults(w io.Writer, results []pruneResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nSTORE\tKEPT\tREMOVED\tFILE\n")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", r.Store, r.Kept, r.Removed, r.File)
+	}
+	return tw.Flush()
+}
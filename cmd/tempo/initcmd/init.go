@@ -2,18 +2,22 @@ package initcmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/indaco/tempo/internal/app"
 	"github.com/indaco/tempo/internal/apperrors"
 	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
 	"github.com/indaco/tempo/internal/helpers"
 	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/pkg/tempo"
 	"github.com/urfave/cli/v3"
 )
 
@@ -27,7 +31,7 @@ func SetupInitCommand(cmdCtx *app.AppContext) *cli.Command {
 	return &cli.Command{
 		Name:                   "init",
 		Usage:                  "Initialize a Tempo project",
-		UsageText:              "tempo init [options]",
+		UsageText:              "tempo init [--from tempo.yaml] [--go-package components] [--with-js] [options]",
 		UseShortOptionHandling: true,
 		Flags:                  getFlags(),
 		Action:                 runInitCommand(cmdCtx),
@@ -45,9 +49,80 @@ func getFlags() []cli.Flag {
 			Name:  "base-folder",
 			Usage: "Specify the base folder for Tempo files (default: current directory)",
 		},
+		&cli.BoolFlag{
+			Name:  "demo",
+			Usage: "Scaffold a runnable example: a component, a variant, their assets and a main package",
+		},
+		&cli.StringFlag{
+			Name:  "from",
+			Usage: "Start from an existing tempo.yaml, keeping its content and comments; the other flags override its values",
+		},
+		&cli.StringFlag{
+			Name:  "go-module",
+			Usage: "The name of the Go module (default: read from go.mod)",
+		},
+		&cli.StringFlag{
+			Name:  "go-package",
+			Usage: "The Go package where components will be generated (default: " + config.DefaultGoPackage + ")",
+		},
+		&cli.StringFlag{
+			Name:  "assets-dir",
+			Usage: "The directory where asset files will be generated (default: " + config.DefaultAssetsDir + ")",
+		},
+		&cli.StringFlag{
+			Name:  "css-layer",
+			Usage: "The CSS layer associated with component styles",
+		},
+		&cli.BoolFlag{
+			Name:  "with-js",
+			Usage: "Whether JavaScript is required for the components",
+		},
+		&cli.BoolFlag{
+			Name:  "with-ts-decls",
+			Usage: "Generate a TypeScript declaration stub (.d.ts) next to the component JS assets",
+		},
+		&cli.BoolFlag{
+			Name:  "require-go-module",
+			Value: true,
+			Usage: "Whether commands must run inside a Go module (false for asset-only projects)",
+		},
+		&cli.IntFlag{
+			Name:  "workers",
+			Usage: "Number of concurrent workers used by sync (default: numCPUs * 2)",
+		},
+		&cli.StringFlag{
+			Name:  "summary-format",
+			Usage: "Summary format of sync: " + strings.Join(summaryFormats, ", ") + " (default: " + config.DefaultSummaryFormat + ")",
+		},
+		&cli.StringFlag{
+			Name:  "guard-marker",
+			Usage: "The marker delimiting the auto-generated sections (default: " + config.DefaultGuardMarkText + ")",
+		},
+		&cli.StringFlag{
+			Name:  "watermark",
+			Usage: "Template of the comment added to generated files and synced assets",
+		},
 	}
 }
 
+// summaryFormats lists the values accepted by --summary-format.
+var summaryFormats = []string{"compact", "long", "json", "none"}
+
+// configFlags maps the flags setting a configuration value to their config key.
+var configFlags = []struct{ flag, key string }{
+	{"go-module", "app.go_module"},
+	{"go-package", "app.go_package"},
+	{"assets-dir", "app.assets_dir"},
+	{"css-layer", "app.css_layer"},
+	{"with-js", "app.with_js"},
+	{"with-ts-decls", "app.with_ts_decls"},
+	{"require-go-module", "app.require_go_module"},
+	{"workers", "processor.workers"},
+	{"summary-format", "processor.summary_format"},
+	{"guard-marker", "templates.guard_marker"},
+	{"watermark", "templates.watermark"},
+}
+
 /* ------------------------------------------------------------------------- */
 /* Command Runner                                                            */
 /* ------------------------------------------------------------------------- */
@@ -58,13 +133,23 @@ func runInitCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.C
 
 		helpers.EnableLoggerIndentation(cmdCtx.Logger)
 
-		// Step 1: resolve base folder for tempo files & config
+		// Step 1: validate the configuration flags and resolve base folder for tempo files & config
+		if err := validateConfigFlags(cmd); err != nil {
+			return err
+		}
 		userBaseFolder := cmd.String("base-folder")
 		tempoRoot := filepath.Join(userBaseFolder, cmdCtx.Config.TempoRoot)
 		tempoConfigPath := filepath.Join(userBaseFolder, configFileName)
+		if cmdCtx.ConfigFile != "" {
+			tempoConfigPath = cmdCtx.ConfigFile
+		}
 
-		// Step 2: ensure configuration file does not already exist
-		if err := validateInitPrerequisites(cmdCtx.CWD, tempoConfigPath); err != nil {
+		// Step 2: ensure a Go module is found and configuration file does not already exist
+		if err := validateInitPrerequisites(cmdCtx.CWD, cmdCtx.ModuleRoot, tempoConfigPath); err != nil {
+			return err
+		}
+		_, moduleName, err := cmdCtx.ResolveModule()
+		if err != nil {
 			return err
 		}
 
@@ -73,15 +158,28 @@ func runInitCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.C
 
 		// Step 4: Generate and write the configuration file
 		cmdCtx.Logger.Info("Generating", tempoConfigPath)
-		cfg, err := prepareConfig(cmdCtx.CWD, tempoRoot, templatesDir, actionsDir)
-		if err != nil {
-			return apperrors.Wrap("Failed to prepare the configuration file", err)
+		var cfg *config.Config
+		if from := cmd.String("from"); from != "" {
+			if cfg, err = initFromConfigFile(cmd, from, tempoConfigPath); err != nil {
+				return err
+			}
+			tempoRoot = filepath.Join(userBaseFolder, cfg.TempoRoot)
+		} else {
+			cfg = prepareConfig(moduleName, tempoRoot, templatesDir, actionsDir)
+			applyConfigFlags(cmd, cfg)
+			if err := writeConfigFile(tempoConfigPath, cfg); err != nil {
+				return apperrors.Wrap("Failed to write the configuration file", err, tempoConfigPath)
+			}
 		}
-		if err := writeConfigFile(tempoConfigPath, cfg); err != nil {
-			return apperrors.Wrap("Failed to write the configuration file", err, tempoConfigPath)
+
+		// Step 5: Scaffold the demo project, if requested
+		if cmd.Bool("demo") {
+			if err := scaffoldDemo(ctx, cmdCtx, cfg, tempoRoot); err != nil {
+				return err
+			}
 		}
 
-		// Step 5: Log the successful initialization
+		// Step 6: Log the successful initialization
 		cmdCtx.Logger.Success("Done!", "Customize it to match your project needs.")
 		helpers.ResetLogger(cmdCtx.Logger)
 
@@ -95,13 +193,14 @@ func runInitCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.C
 
 // validateInitPrerequisites ensures all the prerequisites for the init command are satisfied.
 //
-// - A valid go.mod file must be present.
+// - A valid go.mod file must be found in moduleRoot (when set), walking up from the
+// working dir, or through a go.work workspace.
 // - Configuration file does not already exist.
-func validateInitPrerequisites(workingDir, configFilePath string) error {
-	goModPath := filepath.Join(workingDir, "go.mod")
-	if _, err := os.Stat(goModPath); os.IsNotExist(err) {
-		return apperrors.Wrap("missing go.mod file. Run 'go mod init' to create one")
-	} else if err != nil {
+func validateInitPrerequisites(workingDir, moduleRoot, configFilePath string) error {
+	if _, err := utils.ResolveModuleRoot(workingDir, moduleRoot); err != nil {
+		if errors.Is(err, utils.ErrGoModNotFound) {
+			return apperrors.WrapCode(apperrors.CodeGoModMissing, "missing go.mod file. Run 'go mod init' to create one")
+		}
 		return apperrors.Wrap("error checking go.mod file", err)
 	}
 
@@ -118,7 +217,7 @@ func validateInitPrerequisites(workingDir, configFilePath string) error {
 		if err := file.Close(); err != nil {
 			return apperrors.Wrap("Failed to close the configuration file", err)
 		}
-		return apperrors.Wrap("Configuration file already exists", configFilePath)
+		return apperrors.WrapCode(apperrors.CodeConfigExists, "Configuration file already exists", configFilePath)
 	}
 	return nil
 }
@@ -127,12 +226,141 @@ func validateInitPrerequisites(workingDir, configFilePath string) error {
 /* Helper Functions                                                          */
 /* ------------------------------------------------------------------------- */
 
-// prepareConfig creates a new Config instance with the provided base folder, templates folder, and actions folder.
-func prepareConfig(workingDir, tempoRoot, templatesDir, actionsDir string) (*config.Config, error) {
-	moduleName, err := utils.GetModuleName(workingDir)
+// scaffoldDemo generates the demo project with the new configuration and syncs its
+// assets into the templ files.
+func scaffoldDemo(ctx context.Context, cmdCtx *app.AppContext, cfg *config.Config, tempoRoot string) error {
+	templatesDir, actionsDir := config.DerivedFolderPaths(tempoRoot)
+	data := &generator.TemplateData{
+		TemplatesDir:       templatesDir,
+		ActionsDir:         actionsDir,
+		GoModule:           cfg.App.GoModule,
+		GoPackage:          cfg.App.GoPackage,
+		AssetsDir:          cfg.App.AssetsDir,
+		StylesDir:          cfg.Layout.Styles(),
+		VariantsDir:        cfg.Layout.Variants(),
+		ScriptsDir:         cfg.Layout.Scripts(),
+		WithJs:             cfg.App.JsEnabled(),
+		WithTsDecls:        cfg.App.TsDeclsEnabled(),
+		CssLayer:           cfg.App.CssLayer,
+		GuardMarker:        cfg.Templates.GuardMarker,
+		Header:             cfg.Templates.Header,
+		FileModes:          cfg.FileModes,
+		TemplateExtensions: cfg.Templates.Extensions,
+	}
+	demoData := *data
+	demoData.ComponentName = generator.DemoComponentName
+	if err := helpers.ResolveComponentImportPath(cmdCtx, &demoData); err != nil {
+		return err
+	}
+	data.ComponentImportPath = demoData.ComponentImportPath
+
+	if err := generator.ScaffoldDemo(ctx, cmdCtx.Logger, data); err != nil {
+		return err
+	}
+
+	result, err := tempo.Sync(ctx, cfg, tempo.SyncOptions{})
 	if err != nil {
-		return nil, err
+		return apperrors.Wrap("failed to sync the demo assets", err)
+	}
+	if len(result.Failed) > 0 {
+		return apperrors.Wrap("failed to sync %s demo asset(s)", len(result.Failed))
+	}
+	cmdCtx.Logger.Success("Demo assets have been synced").WithAttrs("processed", len(result.Processed))
+
+	cmdCtx.Logger.Blank()
+	cmdCtx.Logger.Hint("Run the demo with:\n  go get github.com/a-h/templ\n  templ generate\n  go run ./" + generator.DemoDir)
+	return nil
+}
+
+// validateConfigFlags checks the values of the configuration flags before anything
+// is written.
+func validateConfigFlags(cmd *cli.Command) error {
+	if cmd.IsSet("workers") && cmd.Int("workers") <= 0 {
+		return apperrors.Wrap("invalid value for '--workers': must be a positive integer")
+	}
+	if cmd.IsSet("summary-format") && !slices.Contains(summaryFormats, cmd.String("summary-format")) {
+		return apperrors.Wrap("invalid value for '--summary-format': %s (expected one of: %s)",
+			cmd.String("summary-format"), strings.Join(summaryFormats, ", "))
+	}
+	for _, name := range []string{"go-package", "assets-dir", "guard-marker"} {
+		if cmd.IsSet(name) && strings.TrimSpace(cmd.String(name)) == "" {
+			return apperrors.Wrap("invalid value for '--%s': must not be empty", name)
+		}
+	}
+	return nil
+}
+
+// applyConfigFlags sets the configuration values given by the flags on cfg.
+func applyConfigFlags(cmd *cli.Command, cfg *config.Config) {
+	if cmd.IsSet("go-module") {
+		cfg.App.GoModule = cmd.String("go-module")
+	}
+	if cmd.IsSet("go-package") {
+		cfg.App.GoPackage = cmd.String("go-package")
+	}
+	if cmd.IsSet("assets-dir") {
+		cfg.App.AssetsDir = cmd.String("assets-dir")
+	}
+	if cmd.IsSet("css-layer") {
+		cfg.App.CssLayer = cmd.String("css-layer")
+	}
+	if cmd.IsSet("with-js") {
+		withJs := cmd.Bool("with-js")
+		cfg.App.WithJs = &withJs
 	}
+	if cmd.IsSet("with-ts-decls") {
+		withTsDecls := cmd.Bool("with-ts-decls")
+		cfg.App.WithTsDecls = &withTsDecls
+	}
+	if cmd.IsSet("require-go-module") {
+		requireGoModule := cmd.Bool("require-go-module")
+		cfg.App.RequireGoModule = &requireGoModule
+	}
+	if cmd.IsSet("workers") {
+		cfg.Processor.Workers = int(cmd.Int("workers"))
+	}
+	if cmd.IsSet("summary-format") {
+		cfg.Processor.SummaryFormat = cmd.String("summary-format")
+	}
+	if cmd.IsSet("guard-marker") {
+		cfg.Templates.GuardMarker = cmd.String("guard-marker")
+	}
+	if cmd.IsSet("watermark") {
+		cfg.Templates.Watermark = cmd.String("watermark")
+	}
+}
+
+// initFromConfigFile copies the configuration file at from to configPath, then sets
+// the values given by the flags in the copy. Its content and comments are kept.
+func initFromConfigFile(cmd *cli.Command, from, configPath string) (*config.Config, error) {
+	// Parse the source first, so that an invalid file is not copied
+	if _, err := config.LoadConfigFile(from); err != nil {
+		return nil, apperrors.WrapCode(apperrors.CodeConfigInvalid, "Invalid configuration file", err, from)
+	}
+
+	content, err := os.ReadFile(from)
+	if err != nil {
+		return nil, apperrors.Wrap("Failed to read the configuration file", err, from)
+	}
+	if err := utils.WriteToFile(configPath, content); err != nil {
+		return nil, apperrors.Wrap("Failed to write the configuration file", err, configPath)
+	}
+
+	for _, f := range configFlags {
+		if !cmd.IsSet(f.flag) {
+			continue
+		}
+		if err := config.SetValue(configPath, f.key, fmt.Sprint(cmd.Value(f.flag))); err != nil {
+			return nil, apperrors.Wrap("Failed to set '--%s' in the configuration file", err, f.flag)
+		}
+	}
+
+	return config.LoadConfigFile(configPath)
+}
+
+// prepareConfig creates a new Config instance with the provided base folder, templates folder, and actions folder.
+// moduleName is the path of the Go module, as read from go.mod.
+func prepareConfig(moduleName, tempoRoot, templatesDir, actionsDir string) *config.Config {
 	return &config.Config{
 		TempoRoot: path.Base(tempoRoot),
 		App: config.App{
@@ -152,7 +380,7 @@ func prepareConfig(workingDir, tempoRoot, templatesDir, actionsDir string) (*con
 			Extensions:  config.DefaultTemplateExtensions,
 			GuardMarker: config.DefaultGuardMarkText,
 		},
-	}, nil
+	}
 }
 
 // writeConfigFile writes the configuration to a YAML file with proper formatting and comments.
@@ -164,6 +392,9 @@ func writeConfigFile(filePath string, cfg *config.Config) error {
 	sb.WriteString("# Documentation & source code: https://github.com/indaco/tempo\n\n")
 	sb.WriteString("# The root folder for tempo files\n")
 	fmt.Fprintf(&sb, "tempo_root: %s\n\n", cfg.TempoRoot)
+	sb.WriteString("# Answer yes to the confirmations, as with --yes. Existing files are still\n")
+	sb.WriteString("# only overwritten with --force.\n")
+	sb.WriteString("# assume_yes: true\n\n")
 
 	// Write app-specific configuration
 	sb.WriteString("app:\n")
@@ -174,22 +405,122 @@ func writeConfigFile(filePath string, cfg *config.Config) error {
 	sb.WriteString("  # The directory where asset files (CSS, JS) will be generated.\n")
 	fmt.Fprintf(&sb, "  assets_dir: %s\n\n", cfg.App.AssetsDir)
 	sb.WriteString("  # Indicates whether JavaScript is required for the component.\n")
-	fmt.Fprintf(&sb, "  # with_js: %s\n\n", strconv.FormatBool(cfg.App.WithJs))
+	formatSetting(&sb, "with_js", strconv.FormatBool(cfg.App.JsEnabled()), cfg.App.WithJs != nil)
+	sb.WriteString("  # Generate a TypeScript declaration stub (.d.ts) next to the component JS asset.\n")
+	formatSetting(&sb, "with_ts_decls", strconv.FormatBool(cfg.App.TsDeclsEnabled()), cfg.App.WithTsDecls != nil)
 	sb.WriteString("  # The name of the CSS layer to associate with component styles.\n")
-	fmt.Fprintf(&sb, "  # css_layer: %s\n\n", cfg.App.CssLayer)
+	formatSetting(&sb, "css_layer", cfg.App.CssLayer, cfg.App.CssLayer != "")
+	sb.WriteString("  # Set to false for asset-only projects without a go.mod (same as --no-gomod-check).\n")
+	requiresGoModule := cfg.App.RequiresGoModule()
+	formatSetting(&sb, "require_go_module", strconv.FormatBool(requiresGoModule), !requiresGoModule)
+
+	// Add layout section
+	formatLayout(&sb)
 
 	// Write processor configuration
-	sb.WriteString("# processor:\n")
+	customWorkers := cfg.Processor.Workers != config.DefaultNumWorkers
+	customSummary := cfg.Processor.SummaryFormat != config.DefaultSummaryFormat
+	formatSection(&sb, "processor", customWorkers || customSummary)
 	sb.WriteString("  # Number of concurrent workers (numCPUs * 2).\n")
-	fmt.Fprintf(&sb, "  # workers: %d\n\n", cfg.Processor.Workers)
+	formatSetting(&sb, "workers", strconv.Itoa(cfg.Processor.Workers), customWorkers)
 	sb.WriteString("  # Summary format: compact, long, json, none.\n")
-	fmt.Fprintf(&sb, "  # summary_format: %s\n\n", cfg.Processor.SummaryFormat)
+	formatSetting(&sb, "summary_format", cfg.Processor.SummaryFormat, customSummary)
+	sb.WriteString("  # Vendor prefixes added to CSS files on sync, for the target browsers.\n")
+	sb.WriteString("  # Without command, the built-in implementation is used.\n")
+	sb.WriteString("  # autoprefixer:\n")
+	sb.WriteString("    # enabled: true\n")
+	sb.WriteString("    # browsers: [\"safari 13\", \"firefox 78\"]\n")
+	sb.WriteString("    # command: npx\n")
+	sb.WriteString("    # args: [\"postcss\", \"--use\", \"autoprefixer\"]\n\n")
+	sb.WriteString("  # Class names of the CSS files scoped to their component, as CSS modules do.\n")
+	sb.WriteString("  # The scoped names are written to a Go map in <file>_classes.go next to the .templ file.\n")
+	sb.WriteString("  # Styles: hash (<class>_<hash>), prefix (<component>__<class>).\n")
+	sb.WriteString("  # scoped_css:\n")
+	sb.WriteString("    # enabled: true\n")
+	sb.WriteString("    # style: hash\n\n")
+	sb.WriteString("  # Limits on the CSS/JS injected into .templ files; files breaking them fail to sync.\n")
+	sb.WriteString("  # guards:\n")
+	sb.WriteString("    # max_css_size: 100KB\n")
+	sb.WriteString("    # max_js_size: 100KB\n")
+	sb.WriteString("    # forbidden_patterns: ['@import\\s+url\\(\\s*.?http']\n\n")
+	sb.WriteString("  # Limits on the total size of the CSS/JS files of each component, checked on sync.\n")
+	sb.WriteString("  # on_exceed: warn (default) or error; 'tempo sync --check' fails on any exceeded budget.\n")
+	sb.WriteString("  # budgets:\n")
+	sb.WriteString("    # css: 50KB\n")
+	sb.WriteString("    # js: 100KB\n")
+	sb.WriteString("    # on_exceed: warn\n\n")
+	sb.WriteString("  # Symbolic links in the assets folder: follow, skip, error.\n")
+	sb.WriteString("  # symlinks: follow\n\n")
+	sb.WriteString("  # Output folders of the assets matching a glob (relative to assets_dir), instead of go_package.\n")
+	sb.WriteString("  # Files keep their path below the glob prefix; the first matching rule wins.\n")
+	sb.WriteString("  # Available data: .OutputDir, .Component\n")
+	sb.WriteString("  # outputs:\n")
+	sb.WriteString("    # - input: admin/**\n")
+	sb.WriteString("      # output: internal/admin/ui\n")
+	sb.WriteString("  # file and region inject into a named guard region ([tempo:base]) of a single .templ file.\n")
+	sb.WriteString("    # - input: button/css/base.css\n")
+	sb.WriteString("      # output: \"{{ .OutputDir }}/button\"\n")
+	sb.WriteString("      # file: button\n")
+	sb.WriteString("      # region: base\n\n")
+	sb.WriteString("  # Retries of the files failing with transient file system errors (e.g. EBUSY, ETIMEDOUT).\n")
+	sb.WriteString("  # The backoff doubles after each retry, up to max_backoff.\n")
+	sb.WriteString("  # retry:\n")
+	sb.WriteString("    # max_retries: 3\n")
+	sb.WriteString("    # backoff: 100ms\n")
+	sb.WriteString("    # max_backoff: 5s\n\n")
+	sb.WriteString("  # Limit on the disk IO of sync, in bytes (e.g. 5MB/s) or file operations (e.g. 200ops/s) per second.\n")
+	sb.WriteString("  # io_throttle: 5MB/s\n\n")
+	sb.WriteString("  # JS injected into the script blocks of .templ files. escape (default: true) escapes\n")
+	sb.WriteString("  # '</script', '<!--' and '{{' without changing the code; wrap_iife isolates its scope.\n")
+	sb.WriteString("  # js_injection:\n")
+	sb.WriteString("    # escape: true\n")
+	sb.WriteString("    # wrap_iife: false\n\n")
+	sb.WriteString("  # File types synced besides CSS and JS, into the guarded block of their .templ file.\n")
+	sb.WriteString("  # mode: inject (default) or go_var (a Go string variable, named with variable).\n")
+	sb.WriteString("  # escape: none, html, json (default for .json) or templ (default for .svg).\n")
+	sb.WriteString("  # languages:\n")
+	sb.WriteString("    # - extension: .json\n")
+	sb.WriteString("    # - extension: .svg\n")
+	sb.WriteString("      # mode: go_var\n\n")
+	sb.WriteString("  # Already built assets left out of sync and reported as skipped: the files matching\n")
+	sb.WriteString("  # patterns (default: *.min.css, *.min.js) and, with sniff (default: true), the files\n")
+	sb.WriteString("  # detected as minified or binary by content.\n")
+	sb.WriteString("  # skip_inputs:\n")
+	sb.WriteString("    # enabled: true\n")
+	sb.WriteString("    # patterns: ['*.min.css', '*.min.js', 'vendor/**']\n")
+	sb.WriteString("    # sniff: true\n\n")
 
 	// Write templates configuration
-	sb.WriteString("# templates:\n")
+	customMarker := cfg.Templates.GuardMarker != config.DefaultGuardMarkText
+	customWatermark := cfg.Templates.Watermark != ""
+	formatSection(&sb, "templates", customMarker || customWatermark || len(cfg.Templates.UserData) > 0)
 	sb.WriteString("  # A placeholder in template files indicating auto-generated sections.\n")
-	fmt.Fprintf(&sb, "  # guard_marker: %s\n\n", cfg.Templates.GuardMarker)
-	sb.WriteString("  # File extensions used for template files.\n")
+	formatSetting(&sb, "guard_marker", cfg.Templates.GuardMarker, customMarker)
+	sb.WriteString("  # Template for the comment added to generated files and synced assets.\n")
+	sb.WriteString("  # Available data: .ComponentName, .Date, .TempoVersion, .UserData\n")
+	if customWatermark {
+		formatSetting(&sb, "watermark", strconv.Quote(cfg.Templates.Watermark), true)
+	} else {
+		sb.WriteString("  # watermark: \"Generated by tempo v{{ .TempoVersion }} on {{ .Date }}\"\n\n")
+	}
+	sb.WriteString("  # License or banner header added by the \"header\" actions and 'tempo headers apply'\n")
+	sb.WriteString("  # to the files lacking it. Available data: .Year, .Author, .UserData\n")
+	sb.WriteString("  # header:\n")
+	sb.WriteString("    # template: \"Copyright {{ .Year }} {{ .Author }}. SPDX-License-Identifier: MIT\"\n")
+	sb.WriteString("    # author: \"ACME Inc.\"\n\n")
+	sb.WriteString("  # Cache the rendered templates in the render-cache folder, keyed by template and data.\n")
+	sb.WriteString("  # Only for templates whose output depends on their data alone.\n")
+	sb.WriteString("  # render_cache: true\n\n")
+	sb.WriteString("  # Look up the templates missing from templates_dir in the user templates folder\n")
+	sb.WriteString("  # (~/.config/tempo/templates), then in the built-in ones. define copies from the same layers.\n")
+	sb.WriteString("  # layered: true\n\n")
+	sb.WriteString("  # Check the generated .templ files for accessibility issues (e.g. <img> without alt).\n")
+	sb.WriteString("  # a11y_audit: true\n\n")
+	sb.WriteString("  # Record a checksum of the synced content to detect the guard regions edited by hand:\n")
+	sb.WriteString("  # warn overwrites them with a warning, refuse leaves them untouched (see --overwrite-manual).\n")
+	sb.WriteString("  # manual_edits: warn\n\n")
+	sb.WriteString("  # File extensions used for template files, removed from the rendered file names.\n")
+	sb.WriteString("  # Without .gotxt, the built-in templates are copied with the first one (e.g. .tmpl).\n")
 	sb.WriteString("  # extensions:\n")
 
 	extensions := cfg.Templates.Extensions
@@ -207,6 +538,26 @@ func writeConfigFile(filePath string, cfg *config.Config) error {
 	// Add function providers section
 	formatFunctionProviders(&sb, cfg.Templates.FunctionProviders)
 
+	// Add file modes section
+	formatFileModes(&sb)
+
+	// Add history section
+	formatHistory(&sb)
+	formatJournal(&sb)
+
+	// Add build section
+	formatBuild(&sb)
+	formatJS(&sb)
+	formatCI(&sb)
+
+	// Add plugins section
+	formatPlugins(&sb)
+	formatEntities(&sb)
+
+	// Add notifications section
+	formatNotifications(&sb)
+	formatDisplay(&sb)
+
 	// Write the final content to the file
 	return utils.WriteStringToFile(filePath, sb.String())
 }
@@ -215,6 +566,26 @@ func writeConfigFile(filePath string, cfg *config.Config) error {
 /* Utility Helpers                                                           */
 /* ------------------------------------------------------------------------- */
 
+// formatSection appends the header of a top-level section, commented out unless it
+// holds active settings.
+func formatSection(sb *strings.Builder, name string, active bool) {
+	if active {
+		fmt.Fprintf(sb, "%s:\n", name)
+		return
+	}
+	fmt.Fprintf(sb, "# %s:\n", name)
+}
+
+// formatSetting appends a setting of a section, commented out unless active, e.g. when
+// its value differs from the default.
+func formatSetting(sb *strings.Builder, key, value string, active bool) {
+	if active {
+		fmt.Fprintf(sb, "  %s: %s\n\n", key, value)
+		return
+	}
+	fmt.Fprintf(sb, "  # %s: %s\n\n", key, value)
+}
+
 // formatUserData appends the user_data section to the YAML config.
 func formatUserData(sb *strings.Builder, userData map[string]any) {
 	sb.WriteString("\n  # User-defined variables for template processing.\n")
@@ -268,3 +639,126 @@ func formatFunctionProviders(sb *strings.Builder, providers []config.TemplateFun
 		}
 	}
 }
+
+// formatLayout appends the commented layout section to the YAML config.
+func formatLayout(sb *strings.Builder) {
+	sb.WriteString("\n# Folders of the CSS, variant and JS files in each component folder, for both the\n")
+	sb.WriteString("# assets and the Go package. Used by the components and variants defined afterwards.\n")
+	sb.WriteString("# layout:\n")
+	fmt.Fprintf(sb, "  # styles_dir: %s\n", config.DefaultStylesDir)
+	fmt.Fprintf(sb, "  # variants_dir: %s\n", config.DefaultVariantsDir)
+	fmt.Fprintf(sb, "  # scripts_dir: %s\n", config.DefaultScriptsDir)
+}
+
+// formatFileModes appends the commented file_modes section to the YAML config.
+func formatFileModes(sb *strings.Builder) {
+	sb.WriteString("\n# Permissions of the generated and synced files, narrowed by the umask.\n")
+	sb.WriteString("# file_modes:\n")
+	sb.WriteString("  # default: \"0644\"\n")
+	sb.WriteString("  # by_extension:\n")
+	sb.WriteString("    # .templ: \"0664\"\n")
+	sb.WriteString("  # Keep the mode and owner of overwritten files.\n")
+	sb.WriteString("  # preserve_existing: true\n")
+}
+
+// formatHistory appends the commented history section to the YAML config.
+func formatHistory(sb *strings.Builder) {
+	sb.WriteString("\n# Local record of the tempo invocations, queried with 'tempo history'. Opt-in, never sent anywhere.\n")
+	sb.WriteString("# history:\n")
+	sb.WriteString("  # enabled: true\n")
+	sb.WriteString("  # Defaults to history.jsonl next to the user-level config.\n")
+	sb.WriteString("  # file: .tempo-files/history.jsonl\n")
+	sb.WriteString("  # max_entries: 1000\n")
+	sb.WriteString("  # Entries older than this are dropped; the latest successful invocation is always kept.\n")
+	sb.WriteString("  # max_age: 90d\n")
+}
+
+// formatJournal appends the commented journal section to the YAML config.
+func formatJournal(sb *strings.Builder) {
+	sb.WriteString("\n# Retention of the generation journal replayed by 'tempo component regenerate'.\n")
+	sb.WriteString("# Pruned on each generation and with 'tempo history prune'; the latest generation of each component is kept.\n")
+	sb.WriteString("# journal:\n")
+	sb.WriteString("  # max_entries: 200\n")
+	sb.WriteString("  # max_age: 30d\n")
+}
+
+// formatBuild appends the commented build section to the YAML config.
+func formatBuild(sb *strings.Builder) {
+	sb.WriteString("\n# Steps of 'tempo build', run after the sync.\n")
+	sb.WriteString("# build:\n")
+	sb.WriteString("  # Command running templ generate. Defaults to templ.\n")
+	sb.WriteString("  # templ: go tool templ\n")
+	sb.WriteString("  # Run 'go build ./...' after templ generate.\n")
+	sb.WriteString("  # go_build: true\n")
+}
+
+// formatJS appends the commented js section to the YAML config.
+func formatJS(sb *strings.Builder) {
+	sb.WriteString("\n# Package manager run by 'tempo js install|build' in the JS folders of the components.\n")
+	sb.WriteString("# js:\n")
+	sb.WriteString("  # Command running the package manager. Defaults to npm.\n")
+	sb.WriteString("  # package_manager: pnpm\n")
+}
+
+// formatCI appends the commented ci section to the YAML config.
+func formatCI(sb *strings.Builder) {
+	sb.WriteString("\n# Checks chained by 'tempo ci', with one report and a failing exit status.\n")
+	sb.WriteString("# ci:\n")
+	sb.WriteString("  # Checks run in order. Defaults to all of them.\n")
+	sb.WriteString("  # steps: [config, doctor, fmt, sync]\n")
+	sb.WriteString("  # Stop at the first failing check.\n")
+	sb.WriteString("  # fail_fast: true\n")
+	sb.WriteString("  # JSON report of the checks.\n")
+	sb.WriteString("  # report: tempo-ci.json\n")
+}
+
+// formatPlugins appends a commented plugins example to the YAML config.
+func formatPlugins(sb *strings.Builder) {
+	sb.WriteString("\n# External executables extending tempo (JSON over stdin/stdout).\n")
+	sb.WriteString("# plugins:\n")
+	sb.WriteString("  # Sync transformer applied to CSS files before injection.\n")
+	sb.WriteString("  # - name: postcss\n")
+	sb.WriteString("  #   kind: transformer\n")
+	sb.WriteString("  #   command: ./bin/tempo-postcss\n")
+	sb.WriteString("  #   extensions: [\".css\"]\n")
+	sb.WriteString("  #\n")
+	sb.WriteString("  # Action type usable in actions files with \"type\": \"license\".\n")
+	sb.WriteString("  # - name: license\n")
+	sb.WriteString("  #   kind: action\n")
+	sb.WriteString("  #   command: ./bin/tempo-license\n")
+}
+
+// formatEntities appends a commented entities example to the YAML config.
+func formatEntities(sb *strings.Builder) {
+	sb.WriteString("\n# Entity types generated with 'tempo new <name>', from the templates in\n")
+	sb.WriteString("# <tempo_root>/templates/<templates> and the actions in <tempo_root>/actions/<actions>.\n")
+	sb.WriteString("# entities:\n")
+	sb.WriteString("  # - name: page\n")
+	sb.WriteString("  #   templates: page\n")
+	sb.WriteString("  #   actions: page.json\n")
+	sb.WriteString("  #   flags: # Available as .Flags.<name>\n")
+	sb.WriteString("  #     - name: title\n")
+	sb.WriteString("  #       type: string\n")
+	sb.WriteString("  #       required: true\n")
+}
+
+// formatNotifications appends the commented notifications section to the YAML config.
+func formatNotifications(sb *strings.Builder) {
+	sb.WriteString("\n# Outcome of the runs sent as JSON on completion, e.g. to a chat bot.\n")
+	sb.WriteString("# notifications:\n")
+	sb.WriteString("  # webhook: https://example.com/hooks/tempo\n")
+	sb.WriteString("  # headers:\n")
+	sb.WriteString("  #   Authorization: Bearer ${TEMPO_WEBHOOK_TOKEN}\n")
+	sb.WriteString("  # socket: /tmp/tempo.sock\n")
+	sb.WriteString("  # Defaults to sync and the commands generating files.\n")
+	sb.WriteString("  # commands: [sync, component new]\n")
+	sb.WriteString("  # timeout: 5s\n")
+}
+
+// formatDisplay appends the commented display section to the YAML config.
+func formatDisplay(sb *strings.Builder) {
+	sb.WriteString("\n# How the output is printed.\n")
+	sb.WriteString("# display:\n")
+	sb.WriteString("  # Print the paths relative to the project root, e.g. in CI logs.\n")
+	sb.WriteString("  # relative_paths: true\n")
+}
@@ -2,6 +2,7 @@ package initcmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -184,17 +185,7 @@ func TestInitCommand_FailsOnUnwritableConfigFile(t *testing.T) {
 }
 
 func TestPrepareConfig_DefaultExtensions(t *testing.T) {
-	tempDir := t.TempDir()
-
-	// Create go.mod inside tempDir (the correct working directory)
-	if err := testutils.CreateModFile(tempDir); err != nil {
-		t.Fatalf("Failed to create go.mod file: %v", err)
-	}
-
-	cfg, err := prepareConfig(tempDir, "tempo-root", "templates", "actions")
-	if err != nil {
-		t.Fatalf("Failed to get config: %v", err)
-	}
+	cfg := prepareConfig("example.com/myproject", "tempo-root", "templates", "actions")
 
 	if len(cfg.Templates.Extensions) == 0 {
 		t.Fatalf("Expected extensions to have a default value, got empty slice")
@@ -326,26 +317,16 @@ func TestWriteConfigFile_WithUserData(t *testing.T) {
 
 func TestWriteConfigFile_WithFunctionProviders(t *testing.T) {
 	tempDir := t.TempDir()
-
-	// Create go.mod inside tempDir (the correct working directory)
-	if err := testutils.CreateModFile(tempDir); err != nil {
-		t.Fatalf("Failed to create go.mod file: %v", err)
-	}
-
 	configFile := filepath.Join(tempDir, "tempo.yaml")
 
-	cfg, err := prepareConfig(tempDir, "tempo-root", "templates", "actions")
-	if err != nil {
-		t.Fatalf("Failed to get config: %v", err)
-	}
+	cfg := prepareConfig("example.com/myproject", "tempo-root", "templates", "actions")
 
 	cfg.Templates.FunctionProviders = []config.TemplateFuncProvider{
 		{Name: "default", Type: "path", Value: "./providers/default"},
 		{Name: "custom", Type: "url", Value: "https://github.com/user/custom-provider.git"},
 	}
 
-	err = writeConfigFile(configFile, cfg)
-	if err != nil {
+	if err := writeConfigFile(configFile, cfg); err != nil {
 		t.Fatalf("Failed to write config file: %v", err)
 	}
 
@@ -465,7 +446,7 @@ func TestValidateInitPrerequisites_FailsOnGoModStatError(t *testing.T) {
 	}()
 
 	// Step 4: Run validation
-	err := validateInitPrerequisites(restrictedDir, filepath.Join(tempDir, "tempo.yaml"))
+	err := validateInitPrerequisites(restrictedDir, "", filepath.Join(tempDir, "tempo.yaml"))
 
 	// Step 5: Ensure we get the expected "error checking go.mod file" error
 	if err == nil {
@@ -477,3 +458,255 @@ func TestValidateInitPrerequisites_FailsOnGoModStatError(t *testing.T) {
 		t.Errorf("Unexpected error message. Got: %s, Want substring: %s", err.Error(), expectedErr)
 	}
 }
+
+func TestInitCommand_ModuleRoot(t *testing.T) {
+	repo := t.TempDir()
+	for _, module := range []string{"api", "web"} {
+		if err := os.MkdirAll(filepath.Join(repo, module), 0755); err != nil {
+			t.Fatalf("Failed to create module dir: %v", err)
+		}
+		goMod := fmt.Sprintf("module example.com/%s\n\ngo 1.23\n", module)
+		if err := os.WriteFile(filepath.Join(repo, module, "go.mod"), []byte(goMod), 0644); err != nil {
+			t.Fatalf("Failed to create go.mod: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(repo, "go.work"), []byte("go 1.23\n\nuse (\n\t./api\n\t./web\n)\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.work: %v", err)
+	}
+
+	runInit := func(cwd, moduleRoot string) error {
+		cliApp := &cli.Command{
+			Commands: []*cli.Command{
+				SetupInitCommand(&app.AppContext{
+					Logger:     logger.NewDefaultLogger(),
+					Config:     config.DefaultConfig(),
+					CWD:        cwd,
+					ModuleRoot: moduleRoot,
+				}),
+			},
+		}
+		var runErr error
+		if _, err := testutils.CaptureStdout(func() {
+			runErr = cliApp.Run(context.Background(), []string{"tempo", "init", "--base-folder", cwd})
+		}); err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		return runErr
+	}
+
+	readConfig := func(path string) string {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read config: %v", err)
+		}
+		return string(content)
+	}
+
+	t.Run("subdirectory of a module", func(t *testing.T) {
+		subDir := filepath.Join(repo, "web", "ui")
+		if err := os.MkdirAll(subDir, 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := validateInitPrerequisites(subDir, "", filepath.Join(subDir, "tempo.yaml")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		cmdCtx := &app.AppContext{CWD: subDir}
+		_, moduleName, err := cmdCtx.ResolveModule()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		cfg := prepareConfig(moduleName, "tempo-root", "templates", "actions")
+		if cfg.App.GoModule != "example.com/web" {
+			t.Errorf("Expected module %q, got %q", "example.com/web", cfg.App.GoModule)
+		}
+	})
+
+	t.Run("workspace root requires --module-root", func(t *testing.T) {
+		err := validateInitPrerequisites(repo, "", filepath.Join(repo, "tempo.yaml"))
+		if err == nil || !errors.Is(err, utils.ErrAmbiguousWorkspace) {
+			t.Fatalf("Expected ErrAmbiguousWorkspace, got %v", err)
+		}
+	})
+
+	t.Run("workspace root with --module-root", func(t *testing.T) {
+		if err := runInit(repo, "api"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if content := readConfig(filepath.Join(repo, "tempo.yaml")); !strings.Contains(content, "go_module: example.com/api") {
+			t.Errorf("Expected go_module example.com/api in config, got:\n%s", content)
+		}
+	})
+}
+
+func TestInitCommand_Demo(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupInitCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: config.DefaultConfig(),
+				CWD:    tempDir,
+			}),
+		},
+	}
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), []string{"tempo", "init", "--demo"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, output, []string{
+		"Demo project has been created",
+		"Demo assets have been synced",
+		"go run ./cmd/tempo-demo",
+	})
+
+	testutils.ValidateGeneratedFiles(t, []string{
+		"tempo.yaml",
+		filepath.Join(".tempo-files", "actions", "component.json"),
+		filepath.Join(".tempo-files", "actions", "variant.json"),
+		filepath.Join(".tempo-files", "templates", "demo", "main.go.gotxt"),
+		filepath.Join("components", "button", "button.templ"),
+		filepath.Join("components", "button", "css", "variants", "outline.templ"),
+		filepath.Join("assets", "button", "css", "base.css"),
+		filepath.Join("cmd", "tempo-demo", "main.go"),
+		filepath.Join("cmd", "tempo-demo", "page.templ"),
+	})
+
+	page, err := os.ReadFile(filepath.Join("cmd", "tempo-demo", "page.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read page: %v", err)
+	}
+	if !strings.Contains(string(page), "@button.Button()") {
+		t.Errorf("Expected the page to use the demo component, got:\n%s", page)
+	}
+
+	baseTempl, err := os.ReadFile(filepath.Join("components", "button", "css", "base.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read base.templ: %v", err)
+	}
+	if !strings.Contains(string(baseTempl), ":root") {
+		t.Errorf("Expected the CSS to be synced into base.templ, got:\n%s", baseTempl)
+	}
+}
+
+func TestInitCommand_ConfigFlags(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	newCLI := func() *cli.Command {
+		cliCtx := &app.AppContext{
+			Logger: logger.NewDefaultLogger(),
+			Config: config.DefaultConfig(),
+			CWD:    tempDir,
+		}
+		return &cli.Command{Commands: []*cli.Command{SetupInitCommand(cliCtx)}}
+	}
+
+	run := func(args ...string) error {
+		var runErr error
+		if _, err := testutils.CaptureStdout(func() {
+			runErr = newCLI().Run(context.Background(), append([]string{"tempo", "init"}, args...))
+		}); err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		return runErr
+	}
+
+	t.Run("Generated config", func(t *testing.T) {
+		baseFolder := filepath.Join(tempDir, "generated")
+		err := run("--base-folder", baseFolder,
+			"--go-module", "example.com/ui",
+			"--go-package", "web/components",
+			"--assets-dir", "web/assets",
+			"--css-layer", "components",
+			"--with-js",
+			"--require-go-module=false",
+			"--workers", "3",
+			"--summary-format", "json",
+			"--guard-marker", "ui",
+			"--watermark", "Generated by tempo on {{ .Date }}",
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		cfg, err := config.LoadConfigFile(filepath.Join(baseFolder, "tempo.yaml"))
+		if err != nil {
+			t.Fatalf("Failed to load the generated config: %v", err)
+		}
+		if cfg.App.GoModule != "example.com/ui" || !strings.HasSuffix(cfg.App.GoPackage, "components") ||
+			!strings.HasSuffix(cfg.App.AssetsDir, "assets") || cfg.App.CssLayer != "components" || !cfg.App.JsEnabled() ||
+			cfg.App.TsDeclsEnabled() || cfg.App.RequiresGoModule() {
+			t.Errorf("Unexpected app config: %+v", cfg.App)
+		}
+		if cfg.Processor.Workers != 3 || cfg.Processor.SummaryFormat != "json" {
+			t.Errorf("Unexpected processor config: %+v", cfg.Processor)
+		}
+		if cfg.Templates.GuardMarker != "ui" || cfg.Templates.Watermark != "Generated by tempo on {{ .Date }}" {
+			t.Errorf("Unexpected templates config: guard_marker=%q watermark=%q", cfg.Templates.GuardMarker, cfg.Templates.Watermark)
+		}
+	})
+
+	t.Run("From existing config", func(t *testing.T) {
+		source := filepath.Join(tempDir, "source.yaml")
+		testutils.CreateFile(t, source, `# Shared settings
+tempo_root: .tempo-files
+app:
+  go_module: example.com/shared
+  go_package: components
+  with_js: true
+processor:
+  workers: 2
+`)
+
+		baseFolder := filepath.Join(tempDir, "from")
+		if err := run("--base-folder", baseFolder, "--from", source, "--workers", "6", "--css-layer", "ui"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		configPath := filepath.Join(baseFolder, "tempo.yaml")
+		content, err := os.ReadFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read the config: %v", err)
+		}
+		if !strings.Contains(string(content), "# Shared settings") {
+			t.Errorf("Expected the comments of the source config to be kept, got:\n%s", content)
+		}
+
+		cfg, err := config.LoadConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to load the config: %v", err)
+		}
+		if cfg.App.GoModule != "example.com/shared" || !cfg.App.JsEnabled() || cfg.App.CssLayer != "ui" || cfg.Processor.Workers != 6 {
+			t.Errorf("Unexpected config: app=%+v workers=%d", cfg.App, cfg.Processor.Workers)
+		}
+	})
+
+	t.Run("Invalid values", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"--workers", "0"},
+			{"--summary-format", "xml"},
+			{"--go-package", " "},
+			{"--from", filepath.Join(tempDir, "missing.yaml")},
+		} {
+			baseFolder := filepath.Join(tempDir, "invalid")
+			if err := run(append([]string{"--base-folder", baseFolder}, args...)...); err == nil {
+				t.Errorf("Expected an error for %v", args)
+			}
+			_ = os.RemoveAll(baseFolder)
+		}
+	})
+}
@@ -0,0 +1,319 @@
+package jscmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/cmdrunner"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+// PackageFile is the manifest of a JS package.
+const PackageFile = "package.json"
+
+// Statuses of a JS package run.
+const (
+	packageOK      = "ok"
+	packageFailed  = "failed"
+	packageSkipped = "skipped"
+)
+
+// runExternal runs an external command in dir, streaming its output. Tests replace it.
+var runExternal cmdrunner.Runner = cmdrunner.RunCommandContext
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// packageJSON holds the fields of a package.json file read and scaffolded by tempo.
+type packageJSON struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version,omitempty"`
+	Private bool              `json:"private,omitempty"`
+	Type    string            `json:"type,omitempty"`
+	Scripts map[string]string `json:"scripts"`
+}
+
+// packageResult is the outcome of the package manager run in a JS package.
+type packageResult struct {
+	Dir      string
+	Status   string
+	Detail   string
+	Duration time.Duration
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupJSCommand creates the "js" command running the package manager in the JS
+// folders of the components.
+func SetupJSCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "js",
+		Usage:     "Scaffold and run the package.json files of the component JS folders with the configured package manager",
+		UsageText: "tempo js <subcommand> [options]",
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Commands: []*cli.Command{
+			setupJSInitSubCommand(cmdCtx),
+			setupJSRunSubCommand(cmdCtx, "install", "Install the dependencies of the JS packages"),
+			setupJSRunSubCommand(cmdCtx, "build", "Run the build script of the JS packages"),
+		},
+	}
+}
+
+func setupJSInitSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "init",
+		Usage:     "Scaffold a package.json file in the JS folder of a component",
+		UsageText: "tempo js init --name <name> [--force]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Aliases:  []string{"n"},
+				Usage:    "Name of the component",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Overwrite an existing package.json file",
+			},
+		},
+		Action: runJSInitSubCommand(cmdCtx),
+	}
+}
+
+func setupJSRunSubCommand(cmdCtx *app.AppContext, name, usage string) *cli.Command {
+	return &cli.Command{
+		Name:      name,
+		Usage:     usage + " in each component JS folder holding a package.json file",
+		UsageText: "tempo js " + name + " [--name <name>] [--package-manager <command>]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "name",
+				Aliases: []string{"n"},
+				Usage:   "Only run in the JS packages of this component",
+			},
+			&cli.StringFlag{
+				Name:  "package-manager",
+				Usage: "Command running the package manager, e.g. pnpm (default: js.package_manager or npm)",
+			},
+		},
+		Action: runJSRunSubCommand(cmdCtx, name),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runners                                                           */
+/* ------------------------------------------------------------------------- */
+
+func runJSInitSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		name := gonameprovider.ToGoPackageName(cmd.String("name"))
+		if name == "" {
+			return apperrors.Wrap("'--name' must be a valid component name")
+		}
+		if exists, err := utils.DirExists(filepath.Join(cmdCtx.Config.App.GoPackage, name)); err != nil {
+			return err
+		} else if !exists {
+			return apperrors.WrapCode(apperrors.CodeComponentNotFound, "component '%s' does not exist in %s", name, cmdCtx.Config.App.GoPackage)
+		}
+
+		path := filepath.Join(cmdCtx.Config.App.AssetsDir, name, filepath.FromSlash(cmdCtx.Config.Layout.Scripts()), PackageFile)
+		if exists, err := utils.FileExists(path); err != nil {
+			return err
+		} else if exists && !cmd.Bool("force") {
+			return apperrors.Wrap("%s already exists. Use '--force' to overwrite it", path)
+		}
+
+		pkg := packageJSON{
+			Name:    strings.ReplaceAll(name, "_", "-"),
+			Version: "0.0.0",
+			Private: true,
+			Type:    "module",
+			Scripts: map[string]string{},
+		}
+		if err := utils.WriteJSONToFile(path, pkg); err != nil {
+			return apperrors.Wrap("failed to write package file", err, path)
+		}
+
+		cmdCtx.Logger.Success("JS package created. Add a 'build' script to run it with 'tempo js build'").
+			WithAttrs("component", name, "path", path)
+		return nil
+	}
+}
+
+func runJSRunSubCommand(cmdCtx *app.AppContext, action string) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Find the JS packages
+		root := cmdCtx.Config.App.AssetsDir
+		if name := cmd.String("name"); name != "" {
+			root = filepath.Join(root, gonameprovider.ToGoPackageName(name))
+		}
+		dirs, err := findPackages(root)
+		if err != nil {
+			return err
+		}
+		if len(dirs) == 0 {
+			cmdCtx.Logger.Info("No JS packages found. Run 'tempo js init --name <component>' to create one").
+				WithAttrs("assets_dir", root)
+			return nil
+		}
+
+		// Step 2: Run the package manager in each package
+		pm := cmdCtx.Config.JS.PackageManagerCommand()
+		if value := strings.Fields(cmd.String("package-manager")); len(value) > 0 {
+			pm = value
+		}
+		results := runPackages(ctx, dirs, pm, action)
+
+		// Step 3: Summarize the runs
+		if err := writeResults(os.Stdout, cmdCtx.Config, results); err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, r := range results {
+			if r.Status == packageFailed {
+				failed++
+			}
+		}
+		cmdCtx.Summary = map[string]any{"action": action, "packages": len(results), "failed": failed}
+		if failed > 0 {
+			return apperrors.Wrap("'%s' failed in %s of %s JS packages", action, failed, len(results))
+		}
+		cmdCtx.Logger.Success("JS packages completed").WithAttrs("action", action, "packages", len(results))
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// findPackages returns the folders of root holding a package.json file. The
+// node_modules and hidden folders are skipped, as is a missing root.
+func findPackages(root string) ([]string, error) {
+	exists, err := utils.DirExists(root)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	var dirs []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (d.Name() == "node_modules" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == PackageFile {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to scan folder", err, root)
+	}
+	return dirs, nil
+}
+
+// runPackages runs the package manager action in every package folder, going on after
+// a failure, and returns the outcome of each run. A build is skipped in the packages
+// without build script.
+func runPackages(ctx context.Context, dirs []string, pm []string, action string) []packageResult {
+	args := []string{"install"}
+	if action == "build" {
+		args = []string{"run", "build"}
+	}
+
+	results := make([]packageResult, 0, len(dirs))
+	for _, dir := range dirs {
+		result := packageResult{Dir: dir, Status: packageOK}
+		if action == "build" {
+			ok, err := hasScript(dir, "build")
+			if err != nil {
+				result.Status, result.Detail = packageFailed, err.Error()
+			} else if !ok {
+				result.Status, result.Detail = packageSkipped, "no build script"
+			}
+			if result.Status != packageOK {
+				results = append(results, result)
+				continue
+			}
+		}
+
+		start := time.Now()
+		err := runExternal(ctx, dir, append(append([]string{}, pm...), args...))
+		result.Duration = time.Since(start)
+		if err != nil {
+			if errors.Is(err, exec.ErrNotFound) {
+				err = apperrors.Wrap("command not found. Install it or set 'js.package_manager' in tempo.yaml", err)
+			}
+			result.Status, result.Detail = packageFailed, err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// hasScript reports whether the package.json file of dir defines the script name.
+func hasScript(dir, name string) (bool, error) {
+	path := filepath.Join(dir, PackageFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, apperrors.Wrap("failed to read package file", err, path)
+	}
+	var pkg packageJSON
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return false, apperrors.Wrap("invalid package file", err, path)
+	}
+	return pkg.Scripts[name] != "", nil
+}
+
+// writeResults writes the outcome of the package runs as an aligned table, with the
+// package folders relative to the assets folder.
+func writeResults(w io.Writer, cfg *config.Config, results []packageResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nPACKAGE\tSTATUS\tDURATION\tDETAIL\n")
+	for _, r := range results {
+		dir := r.Dir
+		if rel, err := filepath.Rel(cfg.App.AssetsDir, r.Dir); err == nil {
+			dir = filepath.ToSlash(rel)
+		}
+		duration := "-"
+		if r.Status != packageSkipped {
+			duration = r.Duration.Round(time.Millisecond).String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", dir, r.Status, duration, r.Detail)
+	}
+	return tw.Flush()
+}
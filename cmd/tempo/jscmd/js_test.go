@@ -0,0 +1,149 @@
+package jscmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+// setupJSProject creates a project with the given components and returns its config.
+func setupJSProject(t *testing.T, components ...string) *config.Config {
+	t.Helper()
+	tempDir := t.TempDir()
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+	for _, name := range components {
+		if err := os.MkdirAll(filepath.Join(cfg.App.GoPackage, name), 0755); err != nil {
+			t.Fatalf("Failed to create component dir: %v", err)
+		}
+	}
+	return cfg
+}
+
+// componentLabel labels the external commands with the component of the JS folder
+// they run in.
+func componentLabel(dir string) string {
+	return filepath.Base(filepath.Dir(dir))
+}
+
+func newJSApp(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Commands: []*cli.Command{
+			SetupJSCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    filepath.Dir(cfg.TempoRoot),
+			}),
+		},
+	}
+}
+
+func TestJSCommand_Init(t *testing.T) {
+	cfg := setupJSProject(t, "date_picker")
+	cliApp := newJSApp(cfg)
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), []string{"tempo", "js", "init", "--name", "date-picker"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"JS package created"})
+
+	data, err := os.ReadFile(filepath.Join(cfg.App.AssetsDir, "date_picker", "js", PackageFile))
+	if err != nil {
+		t.Fatalf("Failed to read package file: %v", err)
+	}
+	var pkg packageJSON
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		t.Fatalf("Invalid package file: %v", err)
+	}
+	if pkg.Name != "date-picker" || !pkg.Private {
+		t.Errorf("Unexpected package file: %+v", pkg)
+	}
+
+	if err := cliApp.Run(context.Background(), []string{"tempo", "js", "init", "--name", "date-picker"}); err == nil {
+		t.Errorf("Expected an error for an existing package file")
+	}
+	if err := cliApp.Run(context.Background(), []string{"tempo", "js", "init", "--name", "missing"}); err == nil {
+		t.Errorf("Expected an error for a missing component")
+	}
+}
+
+func TestJSCommand_Run(t *testing.T) {
+	cfg := setupJSProject(t, "button", "card", "modal")
+	cfg.JS.PackageManager = "pnpm"
+	testutils.CreateFile(t, filepath.Join(cfg.App.AssetsDir, "button", "js", PackageFile), `{"name":"button","scripts":{"build":"tsc"}}`)
+	testutils.CreateFile(t, filepath.Join(cfg.App.AssetsDir, "card", "js", PackageFile), `{"name":"card","scripts":{}}`)
+	testutils.CreateFile(t, filepath.Join(cfg.App.AssetsDir, "modal", "js", PackageFile), `{"name":"modal","scripts":{"build":"tsc"}}`)
+	testutils.CreateFile(t, filepath.Join(cfg.App.AssetsDir, "modal", "js", "node_modules", "dep", PackageFile), `{"name":"dep"}`)
+
+	t.Run("install", func(t *testing.T) {
+		calls := testutils.StubRunner(t, &runExternal, componentLabel, nil)
+		output, err := testutils.CaptureStdout(func() {
+			if err := newJSApp(cfg).Run(context.Background(), []string{"tempo", "js", "install"}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		want := []string{"button: pnpm install", "card: pnpm install", "modal: pnpm install"}
+		if !slices.Equal(*calls, want) {
+			t.Errorf("Unexpected calls: got %v, want %v", *calls, want)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"PACKAGE", "button/js", "JS packages completed"})
+	})
+
+	t.Run("build with failure", func(t *testing.T) {
+		calls := testutils.StubRunner(t, &runExternal, componentLabel, map[string]error{"button: npm run build": errors.New("exit status 1")})
+		var runErr error
+		output, err := testutils.CaptureStdout(func() {
+			runErr = newJSApp(cfg).Run(context.Background(), []string{"tempo", "js", "build", "--package-manager", "npm"})
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		if runErr == nil {
+			t.Errorf("Expected an error for a failed package")
+		}
+		want := []string{"button: npm run build", "modal: npm run build"}
+		if !slices.Equal(*calls, want) {
+			t.Errorf("Expected the build to go on after a failure and skip card, got %v", *calls)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"failed", "no build script"})
+	})
+
+	t.Run("single component", func(t *testing.T) {
+		calls := testutils.StubRunner(t, &runExternal, componentLabel, nil)
+		_, err := testutils.CaptureStdout(func() {
+			if err := newJSApp(cfg).Run(context.Background(), []string{"tempo", "js", "install", "--name", "card"}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		if want := []string{"card: pnpm install"}; !slices.Equal(*calls, want) {
+			t.Errorf("Unexpected calls: got %v, want %v", *calls, want)
+		}
+	})
+}
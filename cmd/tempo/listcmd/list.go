@@ -0,0 +1,172 @@
+package listcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/dependency"
+	"github.com/indaco/tempo/internal/deprecation"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/tags"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupListCommand creates the "list" command for listing components and their dependencies.
+func SetupListCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "list",
+		Usage:                  "List the generated components and their declared dependencies",
+		UsageText:              "tempo list [options]",
+		UseShortOptionHandling: true,
+		Flags:                  getFlags(),
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Action: runListCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getFlags defines CLI flags.
+func getFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "tree",
+			Usage: "Show the component dependency graph as a tree",
+		},
+		helpers.TagFlag(),
+		helpers.ExcludeTagFlag(),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runListCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Collect the generated components
+		filter, err := helpers.NewTagFilter(cmd, cmdCtx.Config)
+		if err != nil {
+			return err
+		}
+		existing, err := listComponentDirs(cmdCtx.Config.App.GoPackage)
+		if err != nil {
+			return apperrors.Wrap("failed to list components", err, cmdCtx.Config.App.GoPackage)
+		}
+
+		// Step 2: Build the dependency graph, including components without dependencies
+		graph := dependency.NewGraph(cmdCtx.Config.Components.Dependencies, gonameprovider.ToGoPackageName)
+		for _, name := range existing {
+			if _, ok := graph[name]; !ok {
+				graph[name] = nil
+			}
+		}
+
+		if len(graph) == 0 {
+			cmdCtx.Logger.Info("No components found").WithAttrs("go_package", cmdCtx.Config.App.GoPackage)
+			return nil
+		}
+
+		deprecated, err := deprecation.Load(deprecation.ManifestPath(cmdCtx.Config.TempoRoot))
+		if err != nil {
+			return err
+		}
+
+		componentTags := tags.ByComponent(cmdCtx.Config.Components.Tags, gonameprovider.ToGoPackageName)
+		label := func(name string) string {
+			text := name
+			if names := componentTags[name]; len(names) > 0 {
+				text += " [" + strings.Join(names, ", ") + "]"
+			}
+			if !slices.Contains(existing, name) {
+				text += " (missing)"
+			}
+			if status := deprecated.Status(name); status != "" {
+				text += " (" + status + ")"
+			}
+			return text
+		}
+
+		// Step 3: Print the components selected by the tag filters
+		nodes := slices.DeleteFunc(graph.Nodes(), func(name string) bool { return !filter.Match(name) })
+		if len(nodes) == 0 {
+			cmdCtx.Logger.Info("No components match the tag filters")
+			return nil
+		}
+		if !cmd.Bool("tree") {
+			for _, name := range nodes {
+				line := label(name)
+				if deps := graph[name]; len(deps) > 0 {
+					line += " -> " + strings.Join(deps, ", ")
+				}
+				fmt.Println(line)
+			}
+			return nil
+		}
+
+		roots := graph.Roots()
+		if filter != nil {
+			roots = selectedRoots(graph, nodes)
+		}
+		return graph.WriteTree(os.Stdout, roots, label)
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// selectedRoots returns the selected components no other selected component depends on,
+// so that each one is printed once at the top of a tree, or all of them when they only
+// depend on each other.
+func selectedRoots(graph dependency.Graph, selected []string) []string {
+	dependedOn := map[string]bool{}
+	for _, name := range selected {
+		for _, dep := range graph[name] {
+			dependedOn[dep] = true
+		}
+	}
+	roots := slices.DeleteFunc(slices.Clone(selected), func(name string) bool { return dependedOn[name] })
+	if len(roots) == 0 {
+		return selected
+	}
+	return roots
+}
+
+// listComponentDirs returns the sorted names of the component folders in the Go package.
+func listComponentDirs(goPackage string) ([]string, error) {
+	entries, err := os.ReadDir(goPackage)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
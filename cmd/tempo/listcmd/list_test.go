@@ -0,0 +1,170 @@
+package listcmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/deprecation"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+func setupListApp(t *testing.T, deps map[string][]string, components ...string) (*cli.Command, *config.Config) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, func(cfg *config.Config) {
+		cfg.Components.Dependencies = deps
+	})
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	for _, name := range components {
+		if err := os.MkdirAll(filepath.Join(cfg.App.GoPackage, name), 0755); err != nil {
+			t.Fatalf("Failed to create component dir: %v", err)
+		}
+	}
+
+	return &cli.Command{
+		Commands: []*cli.Command{
+			SetupListCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    tempDir,
+			}),
+		},
+	}, cfg
+}
+
+func TestListCommand(t *testing.T) {
+	deps := map[string][]string{"dropdown": {"button", "menu"}}
+
+	t.Run("flat list", func(t *testing.T) {
+		cliApp, _ := setupListApp(t, deps, "button", "card", "dropdown")
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "list"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		testutils.ValidateCLIOutput(t, output, []string{
+			"button\n",
+			"card\n",
+			"dropdown -> button, menu\n",
+			"menu (missing)\n",
+		})
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		cliApp, _ := setupListApp(t, deps, "button", "card", "dropdown")
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "list", "--tree"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		expected := "card\ndropdown\n├── button\n└── menu (missing)\n"
+		if output != expected {
+			t.Errorf("Unexpected tree output:\n%s\nexpected:\n%s", output, expected)
+		}
+	})
+
+	t.Run("deprecated", func(t *testing.T) {
+		cliApp, cfg := setupListApp(t, deps, "button", "card", "dropdown")
+		manifest := deprecation.Manifest{
+			"card": {Replacement: "panel", Since: "2026-10-16"},
+			"menu": {Since: "2026-10-16"},
+		}
+		if err := deprecation.Save(deprecation.ManifestPath(cfg.TempoRoot), manifest); err != nil {
+			t.Fatalf("Failed to write deprecation manifest: %v", err)
+		}
+
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "list"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		testutils.ValidateCLIOutput(t, output, []string{
+			"button\n",
+			"card (deprecated, use panel)\n",
+			"menu (missing) (deprecated)\n",
+		})
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		cliApp, cfg := setupListApp(t, deps, "button", "card", "dropdown")
+		cfg.Components.Tags = map[string][]string{
+			"button":   {"forms"},
+			"dropdown": {"forms", "experimental"},
+		}
+
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "list", "--tag", "forms", "--exclude-tag", "experimental"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		if expected := "button [forms]\n"; output != expected {
+			t.Errorf("Unexpected output:\n%s\nexpected:\n%s", output, expected)
+		}
+	})
+
+	t.Run("tags tree", func(t *testing.T) {
+		cliApp, cfg := setupListApp(t, deps, "button", "card", "dropdown")
+		cfg.Components.Tags = map[string][]string{
+			"button":   {"forms"},
+			"dropdown": {"forms"},
+		}
+
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "list", "--tree", "--tag", "forms"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		expected := "dropdown [forms]\n├── button [forms]\n└── menu (missing)\n"
+		if output != expected {
+			t.Errorf("Unexpected tree output:\n%s\nexpected:\n%s", output, expected)
+		}
+	})
+
+	t.Run("no components", func(t *testing.T) {
+		cliApp, _ := setupListApp(t, nil)
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "list"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		testutils.ValidateCLIOutput(t, output, []string{"No components found"})
+	})
+}
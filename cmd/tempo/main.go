@@ -5,16 +5,40 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/indaco/tempo/cmd/tempo/buildcmd"
+	"github.com/indaco/tempo/cmd/tempo/cachecmd"
+	"github.com/indaco/tempo/cmd/tempo/cicmd"
 	"github.com/indaco/tempo/cmd/tempo/componentcmd"
+	"github.com/indaco/tempo/cmd/tempo/configcmd"
+	"github.com/indaco/tempo/cmd/tempo/definecmd"
+	"github.com/indaco/tempo/cmd/tempo/explaincmd"
+	"github.com/indaco/tempo/cmd/tempo/fmtcmd"
+	"github.com/indaco/tempo/cmd/tempo/headerscmd"
+	"github.com/indaco/tempo/cmd/tempo/historycmd"
 	"github.com/indaco/tempo/cmd/tempo/initcmd"
+	"github.com/indaco/tempo/cmd/tempo/jscmd"
+	"github.com/indaco/tempo/cmd/tempo/listcmd"
+	"github.com/indaco/tempo/cmd/tempo/migratecmd"
+	"github.com/indaco/tempo/cmd/tempo/newcmd"
+	"github.com/indaco/tempo/cmd/tempo/prunecmd"
 	"github.com/indaco/tempo/cmd/tempo/registercmd"
+	"github.com/indaco/tempo/cmd/tempo/schemacmd"
+	"github.com/indaco/tempo/cmd/tempo/statscmd"
+	"github.com/indaco/tempo/cmd/tempo/statuscmd"
 	"github.com/indaco/tempo/cmd/tempo/synccmd"
 	"github.com/indaco/tempo/cmd/tempo/variantcmd"
+	"github.com/indaco/tempo/cmd/tempo/versioncmd"
+	"github.com/indaco/tempo/cmd/tempo/webcomponentcmd"
 	"github.com/indaco/tempo/internal/app"
 	apperrors "github.com/indaco/tempo/internal/apperrors"
 	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/jsonoutput"
 	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/notify"
+	"github.com/indaco/tempo/internal/plugin"
 	"github.com/indaco/tempo/internal/utils"
 	"github.com/indaco/tempo/internal/version"
 	"github.com/urfave/cli/v3"
@@ -28,6 +52,12 @@ const (
 	email       = "github@mircoveltri.me"
 )
 
+// cwdEnv is the environment variable setting the working directory, like --cwd.
+const cwdEnv = "TEMPO_CWD"
+
+// configEnv is the environment variable setting the project config file, like --config.
+const configEnv = "TEMPO_CONFIG"
+
 // main is the CLI application's entry point.
 func main() {
 	if err := runCLI(os.Args); err != nil {
@@ -38,26 +68,147 @@ func main() {
 
 // runCLI sets up and runs the CLI application, returning any errors encountered during execution.
 func runCLI(args []string) error {
+	// Move into the directory set with --cwd or TEMPO_CWD, if any.
+	if err := changeWorkingDir(args); err != nil {
+		return err
+	}
+
 	// Get current working directory.
 	cwd := utils.GetCWD()
 
-	// Load configuration.
-	cfg, err := config.LoadConfig()
+	// Load configuration, from the file set with --config or TEMPO_CONFIG, if any.
+	configFile := configFilePath(args)
+	cfg, err := config.LoadConfigFrom(configFile)
 	if err != nil {
-		return apperrors.Wrap("error loading config", err)
+		return apperrors.WrapCode(apperrors.CodeConfigInvalid, "error loading config", err)
+	}
+
+	// Register action plugins.
+	if err := plugin.RegisterActions(cfg.Plugins); err != nil {
+		return apperrors.WrapCode(apperrors.CodePluginLoad, "error loading plugins", err)
 	}
 
-	// Initialize CLI context.
+	// Initialize CLI context. The module names are kept in memory only without a user cache folder.
+	moduleNamesFile, _ := utils.DefaultModuleNameCachePath()
 	cliCtx := &app.AppContext{
-		Logger: logger.NewDefaultLogger(),
-		Config: cfg,
-		CWD:    cwd,
+		Logger:      logger.NewDefaultLogger(),
+		Config:      cfg,
+		ConfigFile:  configFile,
+		CWD:         cwd,
+		ModuleNames: utils.NewModuleNameCache(moduleNamesFile),
 	}
 
 	appCmd := newCLI(cliCtx)
+	ranCommand := historycmd.Track(appCmd)
+	session := trackJSONOutput(appCmd, cliCtx)
+
+	// Run application, recording the invocation when the history is enabled
+	// and notifying its outcome when notifications are configured.
+	start := time.Now()
+	err = appCmd.Run(context.Background(), args)
+	duration := time.Since(start)
+	historycmd.Record(cliCtx, ranCommand(), duration, err)
+	notifyRun(cliCtx, ranCommand(), duration, err)
+	if s := session(); s != nil {
+		return printJSONOutput(s, ranCommand(), cliCtx.Summary, duration, err)
+	}
+	return err
+}
+
+// changeWorkingDir moves into the directory set with --cwd, or else TEMPO_CWD, before
+// the config is loaded, so that the config file, go.mod and the project paths are all
+// resolved from it. The flag is read ahead of the CLI parsing for this reason.
+func changeWorkingDir(args []string) error {
+	dir, ok := globalFlag(args, "cwd")
+	if !ok {
+		dir = os.Getenv(cwdEnv)
+	}
+	if dir == "" {
+		return nil
+	}
+	if err := os.Chdir(dir); err != nil {
+		return apperrors.Wrap("invalid value for '--cwd'", err, dir)
+	}
+	return nil
+}
+
+// configFilePath returns the project config file set with --config, or else
+// TEMPO_CONFIG, and an empty string when none is set. Like --cwd, the flag is read
+// ahead of the CLI parsing, as the config is loaded first.
+func configFilePath(args []string) string {
+	if path, ok := globalFlag(args, "config"); ok {
+		return path
+	}
+	return os.Getenv(configEnv)
+}
 
-	// Run application.
-	return appCmd.Run(context.Background(), args)
+// globalFlag returns the value of the global flag name in args, and false when it is
+// not set. Arguments after "--" are not flags.
+func globalFlag(args []string, name string) (string, bool) {
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return "", false
+		case arg == "--"+name || arg == "-"+name:
+			if i+1 < len(args) {
+				return args[i+1], true
+			}
+			return "", false
+		case strings.HasPrefix(arg, "--"+name+"="), strings.HasPrefix(arg, "-"+name+"="):
+			_, value, _ := strings.Cut(arg, "=")
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// trackJSONOutput wraps the Before of the root command to start collecting the outcome
+// of the run with --output-format json. The returned function gives the started session, or nil.
+func trackJSONOutput(root *cli.Command, cliCtx *app.AppContext) func() *jsonoutput.Session {
+	var session *jsonoutput.Session
+	before := root.Before
+	root.Before = func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+		ctx, err := before(ctx, cmd)
+		if err != nil || cmd.String("output-format") != jsonoutput.FormatJSON {
+			return ctx, err
+		}
+		ctx, session, err = jsonoutput.Start(ctx, cliCtx.Logger)
+		return ctx, err
+	}
+	return func() *jsonoutput.Session { return session }
+}
+
+// printJSONOutput prints the result of the run of cmd collected by session. The run
+// error is kept, so that the exit status reflects it.
+func printJSONOutput(session *jsonoutput.Session, cmd *cli.Command, summary map[string]any, duration time.Duration, runErr error) error {
+	command := appName
+	if cmd != nil {
+		command = historycmd.CommandName(cmd)
+	}
+	res := session.Finish(command, duration, summary, runErr)
+	if err := jsonoutput.Write(os.Stdout, res); err != nil {
+		return err
+	}
+	return runErr
+}
+
+// notifyRun sends the outcome of the run of cmd when the notifications are enabled for
+// it. Delivery never fails the command: errors are reported as warnings.
+func notifyRun(cliCtx *app.AppContext, cmd *cli.Command, duration time.Duration, runErr error) {
+	n := cliCtx.Config.Notifications
+	if !n.IsEnabled() || cmd == nil {
+		return
+	}
+	command := historycmd.CommandName(cmd)
+	if !n.Notifies(command) {
+		return
+	}
+
+	payload := notify.NewPayload(command, cliCtx.CWD, version.GetVersion(), duration, cliCtx.Summary, runErr)
+	if err := notify.Send(context.Background(), n, payload); err != nil {
+		cliCtx.Logger.Warning("Failed to send the run notification").WithAttrs("error", err.Error())
+	}
 }
 
 // newCLI creates and returns the root CLI command and its subcommands.
@@ -68,12 +219,122 @@ func newCLI(cliCtx *app.AppContext) *cli.Command {
 		Usage:       usage,
 		UsageText:   "tempo <subcommand> [options] [arguments]",
 		Description: description,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "cwd",
+				Usage:   "Run as if tempo was started in this directory: the config file, go.mod and the project paths are resolved from it",
+				Sources: cli.EnvVars(cwdEnv),
+			},
+			&cli.StringFlag{
+				Name:    "config",
+				Usage:   "Project config file to use instead of the tempo.yaml or tempo.yml file of the working directory",
+				Sources: cli.EnvVars(configEnv),
+			},
+			&cli.StringFlag{
+				Name:  "module-root",
+				Usage: "Directory of the Go module to work with (default: nearest go.mod or the single module of go.work)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-watermark",
+				Usage: "Do not add the configured watermark to generated and synced files",
+			},
+			&cli.BoolFlag{
+				Name:  "no-gomod-check",
+				Usage: "Do not require a Go module (same as app.require_go_module: false)",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-checks",
+				Usage: "Warn about missing folders instead of failing (same as strict: false). The config file is still required",
+			},
+			&cli.StringFlag{
+				Name:  "color",
+				Value: logger.ColorAuto,
+				Usage: "Colored output: auto (terminal only, honoring NO_COLOR and FORCE_COLOR), always, never",
+			},
+			&cli.BoolFlag{
+				Name:  "no-emoji",
+				Usage: "Print plain text tags (e.g. [ok], [warn]) instead of icons, for logs rendering them badly",
+			},
+			&cli.StringFlag{
+				Name:  "output-format",
+				Value: jsonoutput.FormatText,
+				Usage: "Output format: text, or json to print a structured result (created files, warnings, errors) instead of human text. Set it before the command",
+				// Not inherited, so that it is rejected after the command instead of ignored
+				Local: true,
+			},
+		},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			if err := logger.SetColorMode(cmd.String("color")); err != nil {
+				return ctx, apperrors.Wrap("invalid value for '--color'", err)
+			}
+			logger.SetEmoji(!cmd.Bool("no-emoji"))
+			if cliCtx.Config.Display.RelativePaths {
+				logger.SetPathRoot(cliCtx.CWD)
+			}
+			if err := jsonoutput.ValidateFormat(cmd.String("output-format")); err != nil {
+				return ctx, apperrors.Wrap("invalid value for '--output-format'", err)
+			}
+			if cmd.String("output-format") == jsonoutput.FormatJSON {
+				_ = logger.SetColorMode(logger.ColorNever)
+			}
+			if cmd.Bool("no-watermark") {
+				cliCtx.Config.Templates.Watermark = ""
+			}
+			if cmd.Bool("no-gomod-check") {
+				requireGoModule := false
+				cliCtx.Config.App.RequireGoModule = &requireGoModule
+			}
+			if cmd.Bool("skip-checks") {
+				strict := false
+				cliCtx.Config.Strict = &strict
+			}
+			return ctx, resolveModuleSettings(cliCtx, cmd.String("module-root"))
+		},
 		Commands: []*cli.Command{
 			initcmd.SetupInitCommand(cliCtx),
 			componentcmd.SetupComponentCommand(cliCtx),
 			variantcmd.SetupVariantCommand(cliCtx),
+			webcomponentcmd.SetupWebComponentCommand(cliCtx),
+			newcmd.SetupNewCommand(cliCtx),
 			registercmd.SetupRegisterCommand(cliCtx),
 			synccmd.SetupSyncCommand(cliCtx),
+			buildcmd.SetupBuildCommand(cliCtx),
+			cicmd.SetupCICommand(cliCtx),
+			jscmd.SetupJSCommand(cliCtx),
+			fmtcmd.SetupFmtCommand(cliCtx),
+			headerscmd.SetupHeadersCommand(cliCtx),
+			definecmd.SetupDefineCommand(cliCtx),
+			listcmd.SetupListCommand(cliCtx),
+			statscmd.SetupStatsCommand(cliCtx),
+			statuscmd.SetupStatusCommand(cliCtx),
+			cachecmd.SetupCacheCommand(cliCtx),
+			historycmd.SetupHistoryCommand(cliCtx),
+			configcmd.SetupConfigCommand(cliCtx),
+			schemacmd.SetupSchemaCommand(cliCtx),
+			migratecmd.SetupMigrateCommand(cliCtx),
+			explaincmd.SetupExplainCommand(cliCtx),
+			versioncmd.SetupVersionCommand(cliCtx),
+			prunecmd.SetupPruneCommand(cliCtx),
 		},
 	}
 }
+
+// resolveModuleSettings stores the --module-root override and, when the config does not
+// set app.go_module, fills it from the resolved Go module. Detection errors are ignored
+// here: commands requiring a module report them on their own.
+func resolveModuleSettings(cliCtx *app.AppContext, moduleRoot string) error {
+	cliCtx.ModuleRoot = moduleRoot
+	if moduleRoot != "" {
+		if _, err := utils.ResolveModuleRoot(cliCtx.CWD, moduleRoot); err != nil {
+			return err
+		}
+	}
+
+	if cliCtx.Config.App.GoModule != "" {
+		return nil
+	}
+	if _, name, err := cliCtx.ResolveModule(); err == nil {
+		cliCtx.Config.App.GoModule = name
+	}
+	return nil
+}
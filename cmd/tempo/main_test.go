@@ -2,18 +2,24 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/fatih/color"
 	"github.com/indaco/tempo/internal/app"
 	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/jsonoutput"
 	"github.com/indaco/tempo/internal/logger"
 	"github.com/indaco/tempo/internal/testutils"
 	"github.com/indaco/tempo/internal/utils"
 	"github.com/indaco/tempo/internal/version"
+	"github.com/urfave/cli/v3"
 )
 
 func TestNewCLIFields(t *testing.T) {
@@ -46,7 +52,7 @@ func TestNewCLIFields(t *testing.T) {
 	}
 
 	// Verify that the expected subcommands are present.
-	expectedSubcommands := []string{"init", "component", "variant", "register", "sync"}
+	expectedSubcommands := []string{"init", "component", "variant", "webcomponent", "new", "register", "sync", "build", "ci", "js", "fmt", "headers", "list", "stats", "status", "cache", "history", "config", "define", "schema", "migrate", "explain", "version", "prune"}
 	if len(cmd.Commands) != len(expectedSubcommands) {
 		t.Errorf("expected %d subcommands, got %d", len(expectedSubcommands), len(cmd.Commands))
 	}
@@ -178,6 +184,220 @@ func TestRunApp_InitAutoGen(t *testing.T) {
 	testutils.ValidateCLIOutput(t, output, []string{"Generating", "Done!"})
 }
 
+// TestRunApp_JSONOutput verifies that "--output-format json" prints a single structured result.
+func TestRunApp_JSONOutput(t *testing.T) {
+	t.Cleanup(func() { color.NoColor = true })
+
+	tempDir, origDir := setupTempDir(t)
+	defer restoreWorkingDir(t, origDir)
+
+	var runErr error
+	output, err := testutils.CaptureStdout(func() {
+		runErr = runCLI([]string{"tempo", "--output-format", "json", "init", "--base-folder", tempDir})
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture output: %v", err)
+	}
+	if runErr != nil {
+		t.Fatalf("Unexpected error: %v", runErr)
+	}
+
+	var res jsonoutput.Result
+	if err := json.Unmarshal([]byte(output), &res); err != nil {
+		t.Fatalf("Expected a single JSON document, got %q: %v", output, err)
+	}
+	if res.Command != "init" || res.Status != jsonoutput.StatusSuccess {
+		t.Errorf("Expected a successful init, got command %q and status %q", res.Command, res.Status)
+	}
+	if len(res.Messages) == 0 || len(res.Errors) != 0 {
+		t.Errorf("Expected the log entries without errors, got %+v", res)
+	}
+}
+
+// TestRunApp_JSONOutputError verifies that a failing run reports its error in the result.
+func TestRunApp_JSONOutputError(t *testing.T) {
+	t.Cleanup(func() { color.NoColor = true })
+
+	_, origDir := setupTempDir(t)
+	defer restoreWorkingDir(t, origDir)
+
+	var runErr error
+	output, err := testutils.CaptureStdout(func() {
+		runErr = runCLI([]string{"tempo", "--output-format", "json", "component", "new", "--name", "button"})
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture output: %v", err)
+	}
+	if runErr == nil {
+		t.Fatal("Expected an error without a tempo project")
+	}
+
+	var res jsonoutput.Result
+	if err := json.Unmarshal([]byte(output), &res); err != nil {
+		t.Fatalf("Expected a single JSON document, got %q: %v", output, err)
+	}
+	if res.Command != "component" || res.Status != jsonoutput.StatusError || res.Error != runErr.Error() {
+		t.Errorf("Unexpected result: %+v", res)
+	}
+	if len(res.Errors) == 0 || res.Files == nil {
+		t.Errorf("Expected the error and an empty file list, got %+v", res)
+	}
+}
+
+// TestNewCLI_OutputFormatBeforeCommand verifies that --output-format is rejected after
+// the command, where it would be ignored or taken for the --output flag of the command.
+func TestNewCLI_OutputFormatBeforeCommand(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.App.GoModule = "example.com/myproject"
+	cliCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: t.TempDir()}
+
+	cmd := newCLI(cliCtx)
+	cmd.Commands = append(cmd.Commands, &cli.Command{
+		Name:   "noop",
+		Action: func(ctx context.Context, cmd *cli.Command) error { return nil },
+	})
+
+	err := cmd.Run(context.Background(), []string{"tempo", "noop", "--output-format", "json"})
+	if err == nil || !strings.Contains(err.Error(), "output-format") {
+		t.Errorf("Expected --output-format to be rejected after the command, got %v", err)
+	}
+}
+
+func TestNewCLI_NoWatermark(t *testing.T) {
+	for _, tt := range []struct {
+		args     []string
+		expected string
+	}{
+		{args: []string{"tempo", "noop"}, expected: "Generated by tempo"},
+		{args: []string{"tempo", "--no-watermark", "noop"}, expected: ""},
+	} {
+		cfg := config.DefaultConfig()
+		cfg.App.GoModule = "example.com/myproject"
+		cfg.Templates.Watermark = "Generated by tempo"
+		cliCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: t.TempDir()}
+
+		cmd := newCLI(cliCtx)
+		cmd.Commands = append(cmd.Commands, &cli.Command{
+			Name:   "noop",
+			Action: func(ctx context.Context, cmd *cli.Command) error { return nil },
+		})
+
+		if err := cmd.Run(context.Background(), tt.args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Templates.Watermark != tt.expected {
+			t.Errorf("args %v: expected watermark %q, got %q", tt.args, tt.expected, cfg.Templates.Watermark)
+		}
+	}
+}
+
+func TestNewCLI_NoGoModCheck(t *testing.T) {
+	for _, tt := range []struct {
+		args     []string
+		expected bool
+	}{
+		{args: []string{"tempo", "noop"}, expected: true},
+		{args: []string{"tempo", "--no-gomod-check", "noop"}, expected: false},
+	} {
+		cfg := config.DefaultConfig()
+		cfg.App.GoModule = "example.com/myproject"
+		cliCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: t.TempDir()}
+
+		cmd := newCLI(cliCtx)
+		cmd.Commands = append(cmd.Commands, &cli.Command{
+			Name:   "noop",
+			Action: func(ctx context.Context, cmd *cli.Command) error { return nil },
+		})
+
+		if err := cmd.Run(context.Background(), tt.args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.App.RequiresGoModule() != tt.expected {
+			t.Errorf("args %v: expected RequiresGoModule %v, got %v", tt.args, tt.expected, cfg.App.RequiresGoModule())
+		}
+	}
+}
+
+func TestNewCLI_ColorAndEmoji(t *testing.T) {
+	t.Cleanup(func() {
+		color.NoColor = true
+		logger.SetEmoji(true)
+	})
+
+	for _, tt := range []struct {
+		args        []string
+		wantNoColor bool
+		wantIcon    string
+	}{
+		{args: []string{"tempo", "--color", "always", "noop"}, wantNoColor: false, wantIcon: "✔"},
+		{args: []string{"tempo", "--color", "never", "--no-emoji", "noop"}, wantNoColor: true, wantIcon: "[ok]"},
+	} {
+		cfg := config.DefaultConfig()
+		cfg.App.GoModule = "example.com/myproject"
+		cliCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: t.TempDir()}
+
+		cmd := newCLI(cliCtx)
+		cmd.Commands = append(cmd.Commands, &cli.Command{
+			Name:   "noop",
+			Action: func(ctx context.Context, cmd *cli.Command) error { return nil },
+		})
+
+		if err := cmd.Run(context.Background(), tt.args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if color.NoColor != tt.wantNoColor {
+			t.Errorf("args %v: expected NoColor %v, got %v", tt.args, tt.wantNoColor, color.NoColor)
+		}
+		if icon := logger.Icon("success"); icon != tt.wantIcon {
+			t.Errorf("args %v: expected icon %q, got %q", tt.args, tt.wantIcon, icon)
+		}
+	}
+
+	cliCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: config.DefaultConfig(), CWD: t.TempDir()}
+	if err := newCLI(cliCtx).Run(context.Background(), []string{"tempo", "--color", "sometimes"}); err == nil {
+		t.Error("Expected an error for an invalid color mode")
+	}
+}
+
+func TestResolveModuleSettings(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	t.Run("fills go_module when not configured", func(t *testing.T) {
+		cliCtx := &app.AppContext{Config: config.DefaultConfig(), CWD: t.TempDir()}
+		if err := resolveModuleSettings(cliCtx, tempDir); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cliCtx.ModuleRoot != tempDir {
+			t.Errorf("Expected module root %q, got %q", tempDir, cliCtx.ModuleRoot)
+		}
+		if cliCtx.Config.App.GoModule != "example.com/myproject" {
+			t.Errorf("Expected go_module %q, got %q", "example.com/myproject", cliCtx.Config.App.GoModule)
+		}
+	})
+
+	t.Run("keeps configured go_module", func(t *testing.T) {
+		cfg := config.DefaultConfig()
+		cfg.App.GoModule = "example.com/configured"
+		cliCtx := &app.AppContext{Config: cfg, CWD: tempDir}
+		if err := resolveModuleSettings(cliCtx, ""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.App.GoModule != "example.com/configured" {
+			t.Errorf("Expected configured go_module to be kept, got %q", cfg.App.GoModule)
+		}
+	})
+
+	t.Run("invalid module root", func(t *testing.T) {
+		cliCtx := &app.AppContext{Config: config.DefaultConfig(), CWD: tempDir}
+		if err := resolveModuleSettings(cliCtx, filepath.Join(tempDir, "missing")); err == nil {
+			t.Errorf("Expected an error for a module root without go.mod")
+		}
+	})
+}
+
 /* ------------------------------------------------------------------------- */
 /* ERROR CASES                                                               */
 /* ------------------------------------------------------------------------- */
@@ -217,6 +437,96 @@ func TestRunCLI_LoadConfigError(t *testing.T) {
 	}
 }
 
+func TestGlobalFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		found    bool
+	}{
+		{name: "Not set", args: []string{"tempo", "sync"}},
+		{name: "Separate value", args: []string{"tempo", "--cwd", "web", "sync"}, expected: "web", found: true},
+		{name: "Inline value", args: []string{"tempo", "sync", "--cwd=web"}, expected: "web", found: true},
+		{name: "Missing value", args: []string{"tempo", "sync", "--cwd"}},
+		{name: "After the separator", args: []string{"tempo", "sync", "--", "--cwd", "web"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := globalFlag(tt.args, "cwd")
+			if got != tt.expected || found != tt.found {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.expected, tt.found, got, found)
+			}
+		})
+	}
+}
+
+func TestConfigFilePath(t *testing.T) {
+	t.Setenv(configEnv, "")
+	if got := configFilePath([]string{"tempo", "--config", "ci/tempo.yaml", "sync"}); got != "ci/tempo.yaml" {
+		t.Errorf("Expected the --config value, got %q", got)
+	}
+	if got := configFilePath([]string{"tempo", "sync"}); got != "" {
+		t.Errorf("Expected no config file, got %q", got)
+	}
+
+	t.Setenv(configEnv, "env.yaml")
+	if got := configFilePath([]string{"tempo", "sync"}); got != "env.yaml" {
+		t.Errorf("Expected the %s value, got %q", configEnv, got)
+	}
+	if got := configFilePath([]string{"tempo", "--config=flag.yaml", "sync"}); got != "flag.yaml" {
+		t.Errorf("Expected the flag to take precedence, got %q", got)
+	}
+}
+
+func TestRunCLI_Cwd(t *testing.T) {
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(origDir); err != nil {
+			t.Fatalf("failed to restore working directory: %v", err)
+		}
+	})
+
+	t.Run("Flag", func(t *testing.T) {
+		projectDir, err := filepath.EvalSymlinks(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv(cwdEnv, "")
+		if err := runCLI([]string{"tempo", "--cwd", projectDir, "--version"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cwd, _ := os.Getwd(); cwd != projectDir {
+			t.Errorf("Expected the working directory %s, got %s", projectDir, cwd)
+		}
+	})
+
+	t.Run("Environment", func(t *testing.T) {
+		projectDir, err := filepath.EvalSymlinks(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv(cwdEnv, projectDir)
+		if err := runCLI([]string{"tempo", "--version"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cwd, _ := os.Getwd(); cwd != projectDir {
+			t.Errorf("Expected the working directory %s, got %s", projectDir, cwd)
+		}
+	})
+
+	t.Run("Missing directory", func(t *testing.T) {
+		t.Setenv(cwdEnv, "")
+		err := runCLI([]string{"tempo", "--cwd", filepath.Join(t.TempDir(), "missing"), "--version"})
+		if err == nil || !strings.Contains(err.Error(), "--cwd") {
+			t.Errorf("Expected an invalid --cwd error, got %v", err)
+		}
+	})
+}
+
 /* ------------------------------------------------------------------------- */
 /* HELPERS                                                                   */
 /* ------------------------------------------------------------------------- */
@@ -0,0 +1,352 @@
+package migratecmd
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/layout"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/textprovider"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+// skippedDirs are the folders never searched for imports to rewrite.
+var skippedDirs = []string{".git", "node_modules", "vendor"}
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// pathsPlan describes the moves applied by the "migrate paths" subcommand.
+type pathsPlan struct {
+	From      layout.Layout
+	To        layout.Layout
+	OldImport string // Import path of the old components folder (empty without Go module)
+	NewImport string // Import path of the new components folder (empty without Go module)
+}
+
+// folderMove is a folder moved to the new layout.
+type folderMove struct {
+	From string
+	To   string
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupMigrateCommand creates the "migrate" command with its "paths" subcommand.
+func SetupMigrateCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "migrate",
+		Usage:     "Migrate the project files after a change of the configuration",
+		UsageText: "tempo migrate <subcommand> [options]",
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Commands: []*cli.Command{
+			setupMigratePathsSubCommand(cmdCtx),
+		},
+	}
+}
+
+func setupMigratePathsSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "paths",
+		Usage:     "Move the assets and components to the folders set by app.assets_dir and app.go_package",
+		UsageText: "tempo migrate paths [--from-assets <dir>] [--from-package <dir>] [--dry-run]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "from-assets",
+				Usage: "Previous assets folder (default: the one recorded by the last sync)",
+			},
+			&cli.StringFlag{
+				Name:  "from-package",
+				Usage: "Previous components folder (default: the one recorded by the last sync)",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Preview the moves without making changes",
+			},
+		},
+		Action: runMigratePathsSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runMigratePathsSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		manifest := layout.ManifestPath(cmdCtx.Config.TempoRoot)
+
+		// Step 1: Detect the previous layout and build the plan
+		plan, err := createPathsPlan(cmd, cmdCtx.Config, manifest)
+		if err != nil {
+			return err
+		}
+
+		if plan.From == plan.To {
+			cmdCtx.Logger.Success("Project layout is up to date").
+				WithAttrs("assets_dir", plan.To.AssetsDir, "go_package", plan.To.GoPackage)
+			return nil
+		}
+
+		// Step 2: Check the previous folders exist and the new ones are free
+		moves, err := pendingMoves(plan)
+		if err != nil {
+			return err
+		}
+
+		if cmd.Bool("dry-run") {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.")
+			for _, move := range moves {
+				cmdCtx.Logger.Info("Would move").WithAttrs("from", move.From, "to", move.To)
+			}
+			if plan.OldImport != plan.NewImport {
+				cmdCtx.Logger.Info("Would rewrite imports").WithAttrs("from", plan.OldImport, "to", plan.NewImport)
+			}
+			return nil
+		}
+
+		// Step 3: Move the folders
+		for _, move := range moves {
+			if err := moveDir(move.From, move.To); err != nil {
+				return err
+			}
+			cmdCtx.Logger.Info("Moved").WithAttrs("from", move.From, "to", move.To)
+		}
+
+		// Step 4: Rewrite the import paths of the moved components
+		updated, err := rewriteImports(cmdCtx.CWD, plan)
+		if err != nil {
+			return err
+		}
+
+		// Step 5: Repair the guard markers of the moved templ files
+		if err := repairGuardMarkers(cmdCtx.Logger, plan.To.GoPackage, cmdCtx.Config.Templates.GuardMarker); err != nil {
+			return err
+		}
+
+		// Step 6: Record the new layout
+		if err := layout.Save(manifest, plan.To); err != nil {
+			return err
+		}
+
+		cmdCtx.Logger.Success("Project layout migrated. Run 'tempo sync' to refresh the components").
+			WithAttrs(
+				"assets_dir", plan.From.AssetsDir+" -> "+plan.To.AssetsDir,
+				"go_package", plan.From.GoPackage+" -> "+plan.To.GoPackage,
+				"updated_files", len(updated),
+			)
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// createPathsPlan resolves the previous layout, from the flags then the manifest, and the
+// new layout from the configuration.
+func createPathsPlan(cmd *cli.Command, cfg *config.Config, manifest string) (*pathsPlan, error) {
+	to := layout.Layout{
+		AssetsDir: filepath.Clean(cfg.App.AssetsDir),
+		GoPackage: filepath.Clean(cfg.App.GoPackage),
+	}
+
+	from, recorded, err := layout.Load(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if !recorded {
+		from = to
+	}
+	if value := cmd.String("from-assets"); value != "" {
+		from.AssetsDir = filepath.Clean(value)
+	}
+	if value := cmd.String("from-package"); value != "" {
+		from.GoPackage = filepath.Clean(value)
+	}
+	if !recorded && !cmd.IsSet("from-assets") && !cmd.IsSet("from-package") {
+		return nil, apperrors.Wrap("cannot detect the previous layout: no sync recorded it in %s. Pass '--from-assets' and/or '--from-package'", manifest)
+	}
+
+	plan := &pathsPlan{From: from, To: to}
+	if cfg.App.GoModule != "" {
+		module := strings.TrimSuffix(cfg.App.GoModule, "/")
+		plan.OldImport = module + "/" + importPath(from.GoPackage)
+		plan.NewImport = module + "/" + importPath(to.GoPackage)
+	}
+	return plan, nil
+}
+
+// importPath returns the import path of a components folder relative to the module root.
+func importPath(goPackage string) string {
+	return gonameprovider.ToGoPackageName(textprovider.NormalizePath(goPackage))
+}
+
+// pendingMoves returns the folders to move, from the previous to the new layout. A
+// previous folder already moved is ignored; a new folder already in use is an error.
+func pendingMoves(plan *pathsPlan) ([]folderMove, error) {
+	var moves []folderMove
+	candidates := []folderMove{
+		{From: plan.From.AssetsDir, To: plan.To.AssetsDir},
+		{From: plan.From.GoPackage, To: plan.To.GoPackage},
+	}
+
+	for _, candidate := range candidates {
+		src, dest := candidate.From, candidate.To
+		if src == dest {
+			continue
+		}
+		if isWithin(dest, src) {
+			return nil, apperrors.Wrap("cannot move %s into its own subfolder %s", src, dest)
+		}
+
+		exists, err := utils.DirExists(src)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+
+		if exists, _, err := utils.FileOrDirExists(dest); err != nil {
+			return nil, err
+		} else if exists && !isEmptyDir(dest) {
+			return nil, apperrors.Wrap("cannot move %s: %s already exists and is not empty", src, dest)
+		}
+		moves = append(moves, candidate)
+	}
+	return moves, nil
+}
+
+// moveDir moves the folder src to dest, replacing an empty dest, and refreshes the
+// modification time of its files so that the next sync processes them again.
+func moveDir(src, dest string) error {
+	if isEmptyDir(dest) {
+		if err := os.Remove(dest); err != nil {
+			return apperrors.Wrap("failed to replace empty folder", err, dest)
+		}
+	}
+	if err := utils.EnsureDirExists(filepath.Dir(dest)); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dest); err != nil {
+		return apperrors.Wrap("failed to move folder", err, src)
+	}
+
+	now := time.Now()
+	return filepath.WalkDir(dest, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, now, now)
+	})
+}
+
+// rewriteImports replaces the import paths of the previous components folder with the
+// new one in the .go and .templ files of root. It returns the updated files.
+func rewriteImports(root string, plan *pathsPlan) ([]string, error) {
+	if plan.OldImport == plan.NewImport || plan.OldImport == "" {
+		return nil, nil
+	}
+
+	re := regexp.MustCompile(`"` + regexp.QuoteMeta(plan.OldImport) + `(/[^"]*)?"`)
+	var updated []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && slices.Contains(skippedDirs, d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if (ext != ".templ" && ext != ".go") || strings.HasSuffix(path, "_templ.go") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return apperrors.Wrap("failed to read file", err, path)
+		}
+		rewritten := re.ReplaceAllString(string(content), `"`+plan.NewImport+`${1}"`)
+		if rewritten == string(content) {
+			return nil
+		}
+		if err := utils.WriteStringToFile(path, rewritten); err != nil {
+			return apperrors.Wrap("failed to write file", err, path)
+		}
+		updated = append(updated, path)
+		return nil
+	})
+	if err != nil {
+		return updated, apperrors.Wrap("failed to rewrite imports", err, root)
+	}
+	return updated, nil
+}
+
+// repairGuardMarkers repairs the guard markers of the templ files in dir, so that the
+// next sync can inject the assets.
+func repairGuardMarkers(log logger.Logger, dir, markerName string) error {
+	exists, err := utils.DirExists(dir)
+	if err != nil || !exists {
+		return err
+	}
+
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".templ" {
+			return nil
+		}
+
+		result, err := processor.RepairGuardMarkersInFile(path, markerName, false)
+		if err != nil {
+			return err
+		}
+		switch result.Status {
+		case processor.RepairFixed:
+			log.Info("Repaired guard markers").
+				WithAttrs("file", path, "issues", strings.Join(result.Issues, "; "))
+		case processor.RepairUnresolved:
+			log.Warning("Cannot repair guard markers").
+				WithAttrs("file", path, "reason", result.Reason)
+		}
+		return nil
+	})
+}
+
+// isWithin reports whether path is a subfolder of dir.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && !strings.HasPrefix(rel, "..")
+}
+
+// isEmptyDir reports whether path is an empty folder.
+func isEmptyDir(path string) bool {
+	entries, err := os.ReadDir(path)
+	return err == nil && len(entries) == 0
+}
@@ -0,0 +1,161 @@
+package migratecmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/layout"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+// setupMigrateProject creates a project whose files follow the old layout while the
+// config uses the new one, and returns its config.
+func setupMigrateProject(t *testing.T) *config.Config {
+	t.Helper()
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, func(cfg *config.Config) {
+		cfg.App.GoModule = "example.com/app"
+		cfg.App.AssetsDir = "web/assets"
+		cfg.App.GoPackage = "internal/ui"
+	})
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	files := map[string]string{
+		"assets/button/button.css": ".btn { color: red; }",
+		"components/button/button.templ": "package button\n\n" +
+			"/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n" +
+			"/* [tempo] END */\n",
+		"components/card/card.templ": "package card\n\nimport \"example.com/app/components/button\"\n",
+		"main.go":                    "package main\n\nimport _ \"example.com/app/components/card\"\n",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create folder: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	return cfg
+}
+
+func newMigrateApp(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Commands: []*cli.Command{
+			SetupMigrateCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    ".",
+			}),
+		},
+	}
+}
+
+func TestMigratePaths(t *testing.T) {
+	cfg := setupMigrateProject(t)
+	manifest := layout.ManifestPath(cfg.TempoRoot)
+	if err := layout.Save(manifest, layout.Layout{AssetsDir: "assets", GoPackage: "components"}); err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+	cliApp := newMigrateApp(cfg)
+
+	t.Run("dry run", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "migrate", "paths", "--dry-run"}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"Would move", "web/assets", "Would rewrite imports"})
+		if _, err := os.Stat("assets"); err != nil {
+			t.Errorf("Dry run must not move files: %v", err)
+		}
+	})
+
+	t.Run("migrate", func(t *testing.T) {
+		if _, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "migrate", "paths"}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}); err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		for _, path := range []string{"web/assets/button/button.css", "internal/ui/button/button.templ", "internal/ui/card/card.templ"} {
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("Expected %s: %v", path, err)
+			}
+		}
+		for _, path := range []string{"assets", "components"} {
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("Expected %s to be moved", path)
+			}
+		}
+
+		card, _ := os.ReadFile("internal/ui/card/card.templ")
+		if !strings.Contains(string(card), `"example.com/app/internal/ui/button"`) {
+			t.Errorf("Expected rewritten import in card.templ, got:\n%s", card)
+		}
+		main, _ := os.ReadFile("main.go")
+		if !strings.Contains(string(main), `"example.com/app/internal/ui/card"`) {
+			t.Errorf("Expected rewritten import in main.go, got:\n%s", main)
+		}
+
+		recorded, ok, err := layout.Load(manifest)
+		if err != nil || !ok || recorded != (layout.Layout{AssetsDir: "web/assets", GoPackage: "internal/ui"}) {
+			t.Errorf("Expected the new layout in the manifest, got %+v (ok=%v, err=%v)", recorded, ok, err)
+		}
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "migrate", "paths"}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"Project layout is up to date"})
+	})
+}
+
+func TestMigratePaths_FromFlags(t *testing.T) {
+	cfg := setupMigrateProject(t)
+	cliApp := newMigrateApp(cfg)
+
+	if err := cliApp.Run(context.Background(), []string{"tempo", "migrate", "paths"}); err == nil {
+		t.Fatal("Expected an error without manifest nor flags")
+	}
+
+	if _, err := testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), []string{"tempo", "migrate", "paths", "--from-assets", "assets"}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	if _, err := os.Stat("web/assets/button/button.css"); err != nil {
+		t.Errorf("Expected moved assets: %v", err)
+	}
+	if _, err := os.Stat("components/button/button.templ"); err != nil {
+		t.Errorf("Components must stay in place without '--from-package': %v", err)
+	}
+}
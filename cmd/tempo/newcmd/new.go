@@ -0,0 +1,266 @@
+package newcmd
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/rendercache"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+// entityNameRe matches the valid names of entity types and their flags.
+var entityNameRe = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// reservedFlags are the flags of every entity subcommand.
+var reservedFlags = []string{"name", "n", "force", "dry-run", "help", "h"}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupNewCommand creates the "new" command with a subcommand per entity type declared
+// in the entities config.
+func SetupNewCommand(cmdCtx *app.AppContext) *cli.Command {
+	commands := make([]*cli.Command, 0, len(cmdCtx.Config.Entities))
+	for _, entity := range cmdCtx.Config.Entities {
+		commands = append(commands, setupEntitySubCommand(cmdCtx, entity))
+	}
+
+	return &cli.Command{
+		Name:      "new",
+		Usage:     "Generate an instance of an entity type declared in the entities config (e.g. layout, page)",
+		UsageText: "tempo new <entity> [options] [name]",
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			if err := cmdCtx.CheckTempoProject(cmdCtx.Config.App.RequiresGoModule()); err != nil {
+				return ctx, err
+			}
+			return ctx, validateEntities(cmdCtx.Config.Entities)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if len(cmdCtx.Config.Entities) == 0 {
+				return apperrors.Wrap("no entity types declared. Add them to the 'entities' section of the config file")
+			}
+			return cli.ShowSubcommandHelp(cmd)
+		},
+		Commands: commands,
+	}
+}
+
+func setupEntitySubCommand(cmdCtx *app.AppContext, entity config.Entity) *cli.Command {
+	usage := entity.Usage
+	if usage == "" {
+		usage = "Generate a " + entity.Name + " from its templates"
+	}
+	return &cli.Command{
+		Name:                   entity.Name,
+		Usage:                  usage,
+		UsageText:              "tempo new " + entity.Name + " [options] [name]",
+		UseShortOptionHandling: true,
+		Flags:                  getEntityFlags(entity),
+		Before:                 validateEntityPrerequisites(cmdCtx, entity),
+		Action:                 runEntitySubCommand(cmdCtx, entity),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getEntityFlags returns the common flags followed by the flags declared by the entity.
+func getEntityFlags(entity config.Entity) []cli.Flag {
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:    "name",
+			Aliases: []string{"n"},
+			Usage:   "Name of the " + entity.Name,
+		},
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Force overwriting if already exists",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Preview actions without making changes",
+		},
+	}
+
+	for _, f := range entity.Flags {
+		switch f.FlagType() {
+		case config.EntityFlagBool:
+			value, _ := strconv.ParseBool(f.Default)
+			flags = append(flags, &cli.BoolFlag{Name: f.Name, Usage: f.Usage, Value: value, Required: f.Required})
+		case config.EntityFlagInt:
+			value, _ := strconv.Atoi(f.Default)
+			flags = append(flags, &cli.IntFlag{Name: f.Name, Usage: f.Usage, Value: value, Required: f.Required})
+		default:
+			flags = append(flags, &cli.StringFlag{Name: f.Name, Usage: f.Usage, Value: f.Default, Required: f.Required})
+		}
+	}
+	return flags
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runEntitySubCommand(cmdCtx *app.AppContext, entity config.Entity) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Resolve the name of the entity to create
+		args, err := helpers.ResolvePositionalArgs(cmd, "name")
+		if err != nil {
+			return err
+		}
+		if args[0] == "" {
+			return helpers.RequiredArgError("name")
+		}
+
+		// Step 2: Create template data
+		if err := cmdCtx.Config.Layout.Validate(); err != nil {
+			return err
+		}
+		data := createTemplateData(cmd, cmdCtx.Config, entity, args[0])
+		if err := helpers.ResolveComponentImportPath(cmdCtx, data); err != nil {
+			return err
+		}
+		if data.DryRun {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.\n")
+			return nil
+		}
+
+		// Step 3: Retrieve and process the entity actions
+		actionsFile := filepath.Join(data.ActionsDir, entity.ActionsFile())
+		if err := generator.ProcessEntityActions(ctx, cmdCtx.Logger, actionsFile, data, cmdCtx.Config); err != nil {
+			return apperrors.Wrap("failed to process actions for %s", err, entity.Name, data.ComponentName)
+		}
+
+		cmdCtx.Logger.Success("Entity files have been created").
+			WithAttrs("entity", entity.Name, "name", data.ComponentName)
+		cmdCtx.Summary = map[string]any{"entity": entity.Name, "name": data.ComponentName}
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Prerequisites Validation                                                  */
+/* ------------------------------------------------------------------------- */
+
+// validateEntityPrerequisites checks the templates folder and the actions file of the
+// entity exist.
+func validateEntityPrerequisites(cmdCtx *app.AppContext, entity config.Entity) func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	return func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+		cfg := cmdCtx.Config
+		_, err := helpers.CheckPrerequisiteFolders(
+			cfg,
+			cmdCtx.Logger,
+			map[string]string{"templates_directory": filepath.Join(cfg.Paths.TemplatesDir, entity.TemplatesFolder())},
+			"Write the templates of the '"+entity.Name+"' entity before creating one.",
+			[]string{"tempo new " + entity.Name + " -h"},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		actionsFile := filepath.Join(cfg.Paths.ActionsDir, entity.ActionsFile())
+		exists, err := utils.FileExistsFunc(actionsFile)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, apperrors.WrapCode(apperrors.CodeTemplatesNotFound, "cannot find the actions file of the '%s' entity: %s", entity.Name, actionsFile)
+		}
+		return ctx, nil
+	}
+}
+
+// validateEntities checks the declared entity types have valid and unique names, and
+// flags of a known type that do not shadow the common flags.
+func validateEntities(entities []config.Entity) error {
+	seen := make(map[string]bool, len(entities))
+	for _, entity := range entities {
+		if !entityNameRe.MatchString(entity.Name) {
+			return apperrors.WrapCode(apperrors.CodeConfigInvalid, "invalid entity name %q: use lowercase letters, digits and dashes", entity.Name)
+		}
+		if seen[entity.Name] {
+			return apperrors.WrapCode(apperrors.CodeConfigInvalid, "entity '%s' is declared twice", entity.Name)
+		}
+		seen[entity.Name] = true
+
+		flags := make(map[string]bool, len(entity.Flags))
+		for _, f := range entity.Flags {
+			switch {
+			case !entityNameRe.MatchString(f.Name):
+				return apperrors.WrapCode(apperrors.CodeConfigInvalid, "entity '%s': invalid flag name %q", entity.Name, f.Name)
+			case slices.Contains(reservedFlags, f.Name) || flags[f.Name]:
+				return apperrors.WrapCode(apperrors.CodeConfigInvalid, "entity '%s': flag '%s' is already defined", entity.Name, f.Name)
+			case !slices.Contains([]string{config.EntityFlagString, config.EntityFlagBool, config.EntityFlagInt}, f.FlagType()):
+				return apperrors.WrapCode(apperrors.CodeConfigInvalid, "entity '%s': flag '%s' has unknown type %q (string, bool or int)", entity.Name, f.Name, f.Type)
+			}
+			flags[f.Name] = true
+		}
+	}
+	return nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// createTemplateData returns the template data of the entity named name, with the values
+// of its flags.
+func createTemplateData(cmd *cli.Command, cfg *config.Config, entity config.Entity, name string) *generator.TemplateData {
+	templatesDir, actionsDir := config.DerivedFolderPaths(cfg.TempoRoot)
+
+	flags := make(map[string]any, len(entity.Flags))
+	for _, f := range entity.Flags {
+		switch f.FlagType() {
+		case config.EntityFlagBool:
+			flags[f.Name] = cmd.Bool(f.Name)
+		case config.EntityFlagInt:
+			flags[f.Name] = cmd.Int(f.Name)
+		default:
+			flags[f.Name] = cmd.String(f.Name)
+		}
+	}
+
+	data := &generator.TemplateData{
+		TemplatesDir:       templatesDir,
+		ActionsDir:         actionsDir,
+		GoModule:           cfg.App.GoModule,
+		GoModuleOptional:   !cfg.App.RequiresGoModule(),
+		GoPackage:          cfg.App.GoPackage,
+		ComponentName:      gonameprovider.ToGoPackageName(name),
+		AssetsDir:          cfg.App.AssetsDir,
+		StylesDir:          cfg.Layout.Styles(),
+		VariantsDir:        cfg.Layout.Variants(),
+		ScriptsDir:         cfg.Layout.Scripts(),
+		WithJs:             cfg.App.JsEnabled(),
+		WithTsDecls:        cfg.App.TsDeclsEnabled(),
+		CssLayer:           cfg.App.CssLayer,
+		GuardMarker:        cfg.Templates.GuardMarker,
+		Watermark:          cfg.Templates.Watermark,
+		Force:              cmd.Bool("force"),
+		DryRun:             cmd.Bool("dry-run"),
+		UserData:           cfg.Templates.UserData,
+		Header:             cfg.Templates.Header,
+		FileModes:          cfg.FileModes,
+		TemplateExtensions: cfg.Templates.Extensions,
+		Entity:             entity.Name,
+		Flags:              flags,
+		RenderCache:        rendercache.Open(cfg.TempoRoot, cfg.Templates.RenderCacheEnabled(), cfg.Templates.FunctionProviders),
+	}
+	data.SetTemplateLayers(cfg.Templates.LayeredEnabled())
+	return data
+}
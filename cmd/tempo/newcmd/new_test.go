@@ -0,0 +1,151 @@
+package newcmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+// setupEntityProject creates a project declaring a "page" entity type, with its template
+// and actions file.
+func setupEntityProject(t *testing.T) (string, *config.Config) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cfg.Entities = []config.Entity{{
+		Name: "page",
+		Flags: []config.EntityFlag{
+			{Name: "title", Required: true},
+			{Name: "draft", Type: config.EntityFlagBool},
+			{Name: "order", Type: config.EntityFlagInt, Default: "10"},
+		},
+	}}
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	testutils.CreateFile(t, filepath.Join(cfg.Paths.TemplatesDir, "page", "page.templ.gotxt"),
+		"// {{ .Entity }} {{ .ComponentName }}: {{ .Flags.title }} draft={{ .Flags.draft }} order={{ .Flags.order }}\n")
+	testutils.CreateFile(t, filepath.Join(cfg.Paths.ActionsDir, "page.json"),
+		`[{"item": "file", "templateFile": "page/page.templ.gotxt", "path": "{{ .GoPackage }}/pages/{{ .ComponentName }}.templ"}]`)
+
+	return tempDir, cfg
+}
+
+func newCLIApp(tempDir string, cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Commands: []*cli.Command{
+			SetupNewCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    tempDir,
+			}),
+		},
+	}
+}
+
+func TestNewCommand_Entity(t *testing.T) {
+	tempDir, cfg := setupEntityProject(t)
+	cliApp := newCLIApp(tempDir, cfg)
+
+	output, err := testutils.CaptureStdout(func() {
+		args := []string{"tempo", "new", "page", "--title", "About us", "--draft", "about"}
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"Entity files have been created", "page"})
+
+	content, err := os.ReadFile(filepath.Join(cfg.App.GoPackage, "pages", "about.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	if want := "// page about: About us draft=true order=10"; !strings.Contains(string(content), want) {
+		t.Errorf("Expected %q in the generated file, got:\n%s", want, content)
+	}
+}
+
+func TestNewCommand_Entity_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		setup    func(cfg *config.Config)
+		expected string
+	}{
+		{
+			name:     "Missing name",
+			args:     []string{"page", "--title", "About"},
+			expected: `Required flag "name" not set`,
+		},
+		{
+			name:     "Missing required flag",
+			args:     []string{"page", "about"},
+			expected: `"title" not set`,
+		},
+		{
+			name: "Missing actions file",
+			args: []string{"page", "--title", "About", "about"},
+			setup: func(cfg *config.Config) {
+				cfg.Entities[0].Actions = "missing.json"
+			},
+			expected: "cannot find the actions file",
+		},
+		{
+			name: "Flag shadowing a common flag",
+			args: []string{"page", "about"},
+			setup: func(cfg *config.Config) {
+				cfg.Entities[0].Flags = append(cfg.Entities[0].Flags, config.EntityFlag{Name: "force"})
+			},
+			expected: "flag 'force' is already defined",
+		},
+		{
+			name: "Unknown flag type",
+			args: []string{"page", "about"},
+			setup: func(cfg *config.Config) {
+				cfg.Entities[0].Flags[0].Type = "float"
+			},
+			expected: "unknown type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir, cfg := setupEntityProject(t)
+			if tt.setup != nil {
+				tt.setup(cfg)
+			}
+			cliApp := newCLIApp(tempDir, cfg)
+
+			err := cliApp.Run(context.Background(), append([]string{"tempo", "new"}, tt.args...))
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected an error containing %q, got: %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestNewCommand_NoEntities(t *testing.T) {
+	tempDir, cfg := setupEntityProject(t)
+	cfg.Entities = nil
+
+	err := newCLIApp(tempDir, cfg).Run(context.Background(), []string{"tempo", "new"})
+	if err == nil || !strings.Contains(err.Error(), "no entity types declared") {
+		t.Errorf("Expected the missing entities error, got: %v", err)
+	}
+}
@@ -0,0 +1,274 @@
+package prunecmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/layout"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/worker"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Kinds of orphaned files.
+const (
+	orphanAssetDir  = "asset-dir"  // Asset folder whose files have no .templ file
+	orphanAsset     = "asset"      // Asset file with no .templ file
+	orphanTemplFile = "templ-file" // .templ file with guard markers and no source asset
+)
+
+// orphan is a file or folder left behind by a deleted component or variant.
+type orphan struct {
+	Kind   string
+	Path   string
+	Reason string
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupPruneCommand creates the "prune" command removing orphaned assets and templ files.
+func SetupPruneCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "prune",
+		Usage:     "List the assets with no .templ file and the .templ files with no source asset, and delete them with --apply",
+		UsageText: "tempo prune [--apply]",
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "apply",
+				Usage: "Delete the orphaned files instead of listing them",
+			},
+		},
+		Action: runPruneCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runPruneCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Check the files follow the configured layout
+		if err := checkLayout(cmdCtx.Config); err != nil {
+			return err
+		}
+
+		// Step 2: Cross-reference the assets and the templ files
+		orphans, err := findOrphans(cmdCtx.Config)
+		if err != nil {
+			return err
+		}
+		if len(orphans) == 0 {
+			cmdCtx.Logger.Success("No orphaned files found").
+				WithAttrs("assets_dir", cmdCtx.Config.App.AssetsDir, "go_package", cmdCtx.Config.App.GoPackage)
+			return nil
+		}
+
+		if err := writeOrphansTable(os.Stdout, orphans); err != nil {
+			return err
+		}
+
+		if !cmd.Bool("apply") {
+			cmdCtx.Logger.Info("Run 'tempo prune --apply' to delete them").
+				WithAttrs("orphans", len(orphans))
+			return nil
+		}
+
+		// Step 3: Delete the orphans
+		for _, o := range orphans {
+			if err := removeOrphan(o); err != nil {
+				return err
+			}
+		}
+		cmdCtx.Logger.Success("Orphaned files deleted").WithAttrs("deleted", len(orphans))
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// checkLayout returns an error when the layout recorded by the last sync differs from
+// the configured one: every file would look orphaned until they are moved.
+func checkLayout(cfg *config.Config) error {
+	recorded, ok, err := layout.Load(layout.ManifestPath(cfg.TempoRoot))
+	if err != nil || !ok {
+		return err
+	}
+	current := layout.Layout{
+		AssetsDir: filepath.Clean(cfg.App.AssetsDir),
+		GoPackage: filepath.Clean(cfg.App.GoPackage),
+	}
+	if recorded != current {
+		return apperrors.Wrap("the project layout changed since the last sync (assets_dir: %s, go_package: %s). Run 'tempo migrate paths' first",
+			recorded.AssetsDir, recorded.GoPackage)
+	}
+	return nil
+}
+
+// findOrphans returns the asset files whose .templ file is missing, grouped by asset
+// folder when none of its files has one, and the .templ files holding guard markers
+// whose source asset is missing.
+func findOrphans(cfg *config.Config) ([]orphan, error) {
+	rules, err := worker.ConfigOutputRules(cfg.Processor.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := worker.ConfigLanguages(cfg.Processor.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	assetsDir, goPackage := cfg.App.AssetsDir, cfg.App.GoPackage
+	expected := make(map[string]bool)
+	var orphans []orphan
+
+	// Assets, grouped by their top-level folder
+	folders := make(map[string][]string)
+	live := make(map[string]bool)
+	var folderOrder []string
+	err = walkFiles(assetsDir, func(path string) error {
+		if !languages.Supports(path) {
+			return nil
+		}
+		folder := topLevelDir(assetsDir, path)
+		output := filepath.Clean(worker.OutputPath(path, assetsDir, goPackage, rules))
+		expected[output] = true
+		if exists, err := utils.FileExists(output); err != nil || exists {
+			live[folder] = true
+			return err
+		}
+
+		if _, ok := folders[folder]; !ok {
+			folderOrder = append(folderOrder, folder)
+		}
+		folders[folder] = append(folders[folder], path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, folder := range folderOrder {
+		files := folders[folder]
+		if folder != "" && !live[folder] {
+			orphans = append(orphans, orphan{Kind: orphanAssetDir, Path: folder, Reason: "no .templ file for its assets"})
+			continue
+		}
+		for _, path := range files {
+			output := filepath.Clean(worker.OutputPath(path, assetsDir, goPackage, rules))
+			orphans = append(orphans, orphan{Kind: orphanAsset, Path: path, Reason: "missing " + output})
+		}
+	}
+
+	// Templ files holding guard markers
+	err = walkFiles(goPackage, func(path string) error {
+		if filepath.Ext(path) != ".templ" || expected[filepath.Clean(path)] {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return apperrors.Wrap("failed to read file", err, path)
+		}
+		if _, _, ok := processor.GuardRegion(string(content), cfg.Templates.GuardMarker); ok {
+			orphans = append(orphans, orphan{Kind: orphanTemplFile, Path: path, Reason: "no source asset"})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orphans, nil
+}
+
+// walkFiles calls fn for every file of dir. A missing dir has no files.
+func walkFiles(dir string, fn func(path string) error) error {
+	exists, err := utils.DirExists(dir)
+	if err != nil || !exists {
+		return err
+	}
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		return fn(path)
+	})
+	if err != nil {
+		return apperrors.Wrap("failed to scan folder", err, dir)
+	}
+	return nil
+}
+
+// topLevelDir returns the folder of root holding path, or "" for a file of root itself.
+func topLevelDir(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return filepath.Join(root, parts[0])
+}
+
+// removeOrphan deletes an orphan, with the Go file generated by templ for a .templ file.
+func removeOrphan(o orphan) error {
+	if o.Kind == orphanAssetDir {
+		if err := os.RemoveAll(o.Path); err != nil {
+			return apperrors.Wrap("failed to delete folder", err, o.Path)
+		}
+		return nil
+	}
+
+	paths := []string{o.Path}
+	if o.Kind == orphanTemplFile {
+		paths = append(paths, strings.TrimSuffix(o.Path, ".templ")+"_templ.go")
+	}
+	for _, path := range paths {
+		if err := utils.RemoveIfExists(path); err != nil {
+			return apperrors.Wrap("failed to delete file", err, path)
+		}
+	}
+	return nil
+}
+
+// writeOrphansTable writes the orphans as an aligned table, sorted by path.
+func writeOrphansTable(w io.Writer, orphans []orphan) error {
+	sorted := slices.Clone(orphans)
+	slices.SortFunc(sorted, func(a, b orphan) int { return strings.Compare(a.Path, b.Path) })
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "KIND\tPATH\tREASON\n")
+	for _, o := range sorted {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", o.Kind, o.Path, o.Reason)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,204 @@
+package prunecmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/layout"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+const guardedTempl = "package button\n\n" +
+	"/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n" +
+	"/* [tempo] END */\n"
+
+// setupPruneProject creates a project with a live component, a deleted component whose
+// assets were left behind and a variant whose source CSS was deleted.
+func setupPruneProject(t *testing.T) *config.Config {
+	t.Helper()
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, func(cfg *config.Config) {
+		cfg.App.AssetsDir = "assets"
+		cfg.App.GoPackage = "components"
+	})
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	files := map[string]string{
+		"assets/button/css/base.css":                 ".btn { color: red; }",
+		"assets/button/css/outline.css":              ".btn { border: 1px; }",
+		"assets/card/css/base.css":                   ".card { color: blue; }",
+		"assets/card/js/script.d.ts":                 "export {};",
+		"components/button/button.templ":             "package button\n",
+		"components/button/css/base.templ":           guardedTempl,
+		"components/button/css/outline.templ":        guardedTempl,
+		"components/button/css/ghost.templ":          guardedTempl,
+		"components/button/css/ghost_templ.go":       "package button\n",
+		"components/button/css/handwritten.templ":    "package button\n",
+		"components/button/css/outline_templ.go":     "package button\n",
+		"components/button/css/base_templ.go":        "package button\n",
+		"components/button/css/handwritten_templ.go": "package button\n",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create folder: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	return cfg
+}
+
+func newPruneApp(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Commands: []*cli.Command{
+			SetupPruneCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    ".",
+			}),
+		},
+	}
+}
+
+func TestPruneCommand(t *testing.T) {
+	cfg := setupPruneProject(t)
+	cliApp := newPruneApp(cfg)
+
+	t.Run("lists orphans", func(t *testing.T) {
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "prune"}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		for _, want := range []string{
+			"asset-dir   assets/card",
+			"templ-file  components/button/css/ghost.templ",
+			"Run 'tempo prune --apply'",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+			}
+		}
+		for _, unwanted := range []string{"outline", "handwritten", "base.templ"} {
+			if strings.Contains(output, unwanted) {
+				t.Errorf("Expected output not to contain %q, got:\n%s", unwanted, output)
+			}
+		}
+		if _, err := os.Stat("assets/card"); err != nil {
+			t.Errorf("Expected orphans to be kept without --apply: %v", err)
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		if _, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "prune", "--apply"}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}); err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		for _, path := range []string{"assets/card", "components/button/css/ghost.templ", "components/button/css/ghost_templ.go"} {
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("Expected %s to be deleted", path)
+			}
+		}
+		for _, path := range []string{"assets/button/css/outline.css", "components/button/css/outline.templ", "components/button/css/handwritten.templ"} {
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("Expected %s to be kept: %v", path, err)
+			}
+		}
+	})
+}
+
+func TestPruneCommand_OrphanedAssetFile(t *testing.T) {
+	cfg := setupPruneProject(t)
+	if err := os.Remove("components/button/css/outline.templ"); err != nil {
+		t.Fatalf("Failed to remove templ file: %v", err)
+	}
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := newPruneApp(cfg).Run(context.Background(), []string{"tempo", "prune"}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	if !strings.Contains(output, "assets/button/css/outline.css") {
+		t.Errorf("Expected the asset with no .templ file to be listed, got:\n%s", output)
+	}
+	if strings.Contains(output, "assets/button ") {
+		t.Errorf("Expected the live asset folder to be kept, got:\n%s", output)
+	}
+}
+
+func TestPruneCommand_LayoutChanged(t *testing.T) {
+	cfg := setupPruneProject(t)
+	manifest := layout.ManifestPath(cfg.TempoRoot)
+	if err := layout.Save(manifest, layout.Layout{AssetsDir: "web/assets", GoPackage: "components"}); err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+
+	err := newPruneApp(cfg).Run(context.Background(), []string{"tempo", "prune", "--apply"})
+	if err == nil || !strings.Contains(err.Error(), "tempo migrate paths") {
+		t.Fatalf("Expected layout error, got: %v", err)
+	}
+	if _, err := os.Stat("assets/card"); err != nil {
+		t.Errorf("Expected no file to be deleted: %v", err)
+	}
+}
+
+func TestPruneCommand_AssetLanguages(t *testing.T) {
+	cfg := setupPruneProject(t)
+	cfg.Processor.Languages = []config.Language{{Extension: ".svg"}}
+	testutils.CreateFile(t, "assets/button/icons/icon.svg", `<svg><path d="M0 0"/></svg>`)
+	testutils.CreateFile(t, "components/button/icons/icon.templ", guardedTempl)
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := newPruneApp(cfg).Run(context.Background(), []string{"tempo", "prune"}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	if strings.Contains(output, "icon") {
+		t.Errorf("Expected the output of the synced language to be kept, got:\n%s", output)
+	}
+
+	// Without a .templ file, the asset of the language is an orphan
+	if err := os.Remove("components/button/icons/icon.templ"); err != nil {
+		t.Fatalf("Failed to remove templ file: %v", err)
+	}
+	output, err = testutils.CaptureStdout(func() {
+		if err := newPruneApp(cfg).Run(context.Background(), []string{"tempo", "prune"}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	if !strings.Contains(output, "assets/button/icons/icon.svg") {
+		t.Errorf("Expected the asset with no .templ file to be listed, got:\n%s", output)
+	}
+}
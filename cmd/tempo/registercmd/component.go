@@ -0,0 +1,295 @@
+package registercmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+// Statuses of a registered component folder.
+const (
+	componentRegistered = "registered"
+	componentSkipped    = "skipped"
+)
+
+// templateBraces escapes the template delimiters of the extracted files, so that they
+// are rendered as is.
+var templateBraces = strings.NewReplacer("{{", `{{ "{{" }}`, "}}", `{{ "}}" }}`)
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// componentResult is the outcome of the registration of a component folder.
+type componentResult struct {
+	Name   string
+	Status string
+	Detail string
+}
+
+// componentFile is a file of a registered component and its destination.
+type componentFile struct {
+	Src     string
+	Rel     string // Path relative to the component folder, with forward slashes
+	IsAsset bool   // CSS or JS file, copied to the assets folder
+}
+
+/* ------------------------------------------------------------------------- */
+/* Register Component Subcommand                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupRegisterComponentSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "component",
+		Usage:     "Register existing component folders: copy their .templ files to the Go package and their CSS and JS files to the assets folder",
+		UsageText: "tempo register component --from-dir <dir> [--extract-templates] [--force] [--dry-run]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "from-dir",
+				Usage:    "Folder holding one subfolder per component to register",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "extract-templates",
+				Usage: "Also extract the files of each component as templates, with an actions file, to generate similar components",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Register the components already present in the Go package, overwriting their files",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Preview the registration without making changes",
+			},
+		},
+		Action: runRegisterComponentSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runRegisterComponentSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: List the component folders
+		fromDir := cmd.String("from-dir")
+		entries, err := os.ReadDir(fromDir)
+		if err != nil {
+			return apperrors.Wrap("failed to read the components folder", err, fromDir)
+		}
+
+		dryRun := cmd.Bool("dry-run")
+		if dryRun {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.")
+		}
+
+		// Step 2: Register each component folder
+		var results []componentResult
+		registered := 0
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			result, err := registerComponent(cmdCtx.Config, filepath.Join(fromDir, entry.Name()), cmd.Bool("extract-templates"), cmd.Bool("force"), dryRun)
+			if err != nil {
+				return err
+			}
+			if result.Status == componentRegistered {
+				registered++
+			}
+			results = append(results, result)
+		}
+
+		if len(results) == 0 {
+			cmdCtx.Logger.Info("No component folders found").WithAttrs("from_dir", fromDir)
+			return nil
+		}
+
+		// Step 3: Summarize the registration
+		if err := writeComponentResults(os.Stdout, results); err != nil {
+			return err
+		}
+		cmdCtx.Logger.Success("Components registered. Run 'tempo sync' to inject their assets").
+			WithAttrs("registered", registered, "skipped", len(results)-registered)
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// registerComponent copies the files of the component folder src to the configured
+// layout and, with extract, extracts them as templates. A folder without .templ file or
+// already registered, unless force is set, is skipped.
+func registerComponent(cfg *config.Config, src string, extract, force, dryRun bool) (componentResult, error) {
+	name := gonameprovider.ToGoPackageName(filepath.Base(src))
+	result := componentResult{Name: name, Status: componentSkipped}
+	if name == "" {
+		result.Name, result.Detail = filepath.Base(src), "not a valid component name"
+		return result, nil
+	}
+
+	files, err := listComponentFiles(src)
+	if err != nil {
+		return result, err
+	}
+	if !slices.ContainsFunc(files, func(f componentFile) bool { return filepath.Ext(f.Rel) == ".templ" }) {
+		result.Detail = "no .templ file"
+		return result, nil
+	}
+
+	componentDir := filepath.Join(cfg.App.GoPackage, name)
+	assetsDir := filepath.Join(cfg.App.AssetsDir, name)
+	if exists, err := utils.DirExists(componentDir); err != nil {
+		return result, err
+	} else if exists && !force {
+		result.Detail = "already registered in " + componentDir
+		return result, nil
+	}
+
+	result.Status = componentRegistered
+	result.Detail = fmt.Sprintf("%d file(s) -> %s", len(files), componentDir)
+	if dryRun {
+		return result, nil
+	}
+
+	for _, f := range files {
+		dest := filepath.Join(componentDir, filepath.FromSlash(f.Rel))
+		if f.IsAsset {
+			dest = filepath.Join(assetsDir, filepath.FromSlash(f.Rel))
+		}
+		if err := copyFile(f.Src, dest); err != nil {
+			return result, err
+		}
+	}
+
+	if extract {
+		if err := extractTemplates(cfg, name, files); err != nil {
+			return result, err
+		}
+		result.Detail += ", templates in " + filepath.Join(cfg.Paths.TemplatesDir, name)
+	}
+	return result, nil
+}
+
+// listComponentFiles returns the files of the component folder src, sorted by path.
+func listComponentFiles(src string) ([]componentFile, error) {
+	var files []componentFile
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != src && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, componentFile{
+			Src:     path,
+			Rel:     filepath.ToSlash(rel),
+			IsAsset: processor.GetLoader(filepath.Ext(path)) != api.LoaderNone,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to scan component folder", err, src)
+	}
+	return files, nil
+}
+
+// copyFile copies the file src to dest, creating its folder.
+func copyFile(src, dest string) error {
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return apperrors.Wrap("failed to read file", err, src)
+	}
+	if err := utils.WriteToFile(dest, content); err != nil {
+		return apperrors.Wrap("failed to write file", err, dest)
+	}
+	return nil
+}
+
+// extractTemplates writes the files of the component name as templates in the templates
+// folder, with the actions file rendering them for another component name. The package
+// clause and the exported identifiers named after the component are templated.
+func extractTemplates(cfg *config.Config, name string, files []componentFile) error {
+	pkgDecl := regexp.MustCompile(`(?m)^package ` + regexp.QuoteMeta(name) + `\s*$`)
+	exported := gonameprovider.ToGoExportedName(name)
+	identifier := regexp.MustCompile(`\b` + regexp.QuoteMeta(exported) + `([A-Z0-9_]\w*)?\b`)
+
+	actions := make(generator.JSONActionList, 0, len(files))
+	for _, f := range files {
+		content, err := os.ReadFile(f.Src)
+		if err != nil {
+			return apperrors.Wrap("failed to read file", err, f.Src)
+		}
+		tmpl := templateBraces.Replace(string(content))
+		if !f.IsAsset {
+			tmpl = pkgDecl.ReplaceAllString(tmpl, "package {{ .ComponentName | goPackageName }}")
+			tmpl = identifier.ReplaceAllString(tmpl, "{{ .ComponentName | goExportedName }}${1}")
+		}
+
+		templateFile := generator.TemplateFileName(name+"/"+f.Rel+generator.BuiltInTemplateExtension, cfg.Templates.Extensions)
+		if err := utils.WriteStringToFile(filepath.Join(cfg.Paths.TemplatesDir, filepath.FromSlash(templateFile)), tmpl); err != nil {
+			return apperrors.Wrap("failed to write template", err, templateFile)
+		}
+
+		root := "{{ .GoPackage }}"
+		if f.IsAsset {
+			root = "{{ .AssetsDir }}"
+		}
+		actions = append(actions, generator.JSONAction{
+			Item:         "file",
+			TemplateFile: templateFile,
+			Path:         root + "/{{ .ComponentName | goPackageName }}/" + f.Rel,
+		})
+	}
+
+	content, err := generator.MarshalActionsJSON(actions)
+	if err != nil {
+		return err
+	}
+	actionsFile := filepath.Join(cfg.Paths.ActionsDir, name+".json")
+	if err := utils.WriteToFile(actionsFile, content); err != nil {
+		return apperrors.Wrap("failed to write actions file", err, actionsFile)
+	}
+	return nil
+}
+
+// writeComponentResults writes the outcome of the registration as an aligned table.
+func writeComponentResults(w io.Writer, results []componentResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nCOMPONENT\tSTATUS\tDETAIL\n")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Name, r.Status, r.Detail)
+	}
+	return tw.Flush()
+}
@@ -25,10 +25,11 @@ func SetupRegisterCommand(cmdCtx *app.AppContext) *cli.Command {
 		Usage:     "Register is used to extend tempo.",
 		UsageText: "tempo register <subcommand> [options] [arguments]",
 		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
-			return ctx, app.IsTempoProject(cmdCtx.CWD)
+			return ctx, cmdCtx.IsTempoProject()
 		},
 		Commands: []*cli.Command{
 			setupRegisterFunctionsSubCommand(cmdCtx, getFlags()),
+			setupRegisterComponentSubCommand(cmdCtx),
 		},
 	}
 }
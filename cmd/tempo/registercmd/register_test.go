@@ -262,3 +262,98 @@ var Provider templatefuncs.TemplateFuncProvider = &MockProvider{}
 		t.Errorf("Expected function 'localFunc' from local provider to be registered, but it was not found")
 	}
 }
+
+// TestRegisterCommand_Component tests registering a folder of existing components.
+func TestRegisterCommand_Component(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := setupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	appCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+
+	fromDir := filepath.Join(tempDir, "legacy")
+	testutils.CreateFile(t, filepath.Join(fromDir, "button", "button.templ"), "package button\n\ntempl Button() {\n\t<button>{ \"ok\" }</button>\n}\n")
+	testutils.CreateFile(t, filepath.Join(fromDir, "button", "css", "base.css"), ".btn { color: red; }\n")
+	testutils.CreateFile(t, filepath.Join(fromDir, "notes", "README.md"), "No templ file here\n")
+	testutils.CreateFile(t, filepath.Join(fromDir, "card", "card.templ"), "package card\n")
+	testutils.CreateFile(t, filepath.Join(cfg.App.GoPackage, "card", "card.templ"), "package card\n")
+
+	cmd := SetupRegisterCommand(appCtx)
+	output, err := testutils.CaptureStdout(func() {
+		if err := cmd.Run(context.Background(), []string{"register", "component", "--from-dir", fromDir, "--extract-templates"}); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	for _, expected := range []string{"button", "registered", "no .templ file", "already registered", "Components registered"} {
+		if !utils.ContainsSubstring(output, expected) {
+			t.Errorf("Expected output to contain %q, got: %s", expected, output)
+		}
+	}
+
+	// Files are copied to the configured layout
+	for _, path := range []string{
+		filepath.Join(cfg.App.GoPackage, "button", "button.templ"),
+		filepath.Join(cfg.App.AssetsDir, "button", "css", "base.css"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s to be registered: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cfg.App.GoPackage, "notes")); !os.IsNotExist(err) {
+		t.Errorf("Expected the folder without .templ file to be skipped")
+	}
+
+	// Templates are extracted with an actions file
+	tmpl, err := os.ReadFile(filepath.Join(cfg.Paths.TemplatesDir, "button", "button.templ.gotxt"))
+	if err != nil {
+		t.Fatalf("Expected the extracted template: %v", err)
+	}
+	for _, expected := range []string{"package {{ .ComponentName | goPackageName }}", "templ {{ .ComponentName | goExportedName }}()", "<button>"} {
+		if !utils.ContainsSubstring(string(tmpl), expected) {
+			t.Errorf("Expected template to contain %q, got: %s", expected, tmpl)
+		}
+	}
+	actions, err := os.ReadFile(filepath.Join(cfg.Paths.ActionsDir, "button.json"))
+	if err != nil {
+		t.Fatalf("Expected the actions file: %v", err)
+	}
+	if !utils.ContainsSubstring(string(actions), "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/css/base.css") {
+		t.Errorf("Expected the CSS file to be rendered in the assets folder, got: %s", actions)
+	}
+}
+
+// TestRegisterCommand_Component_DryRun tests that a dry run writes nothing.
+func TestRegisterCommand_Component_DryRun(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := setupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	appCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}
+
+	fromDir := filepath.Join(tempDir, "legacy")
+	testutils.CreateFile(t, filepath.Join(fromDir, "button", "button.templ"), "package button\n")
+
+	cmd := SetupRegisterCommand(appCtx)
+	if err := cmd.Run(context.Background(), []string{"register", "component", "--from-dir", fromDir, "--dry-run"}); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.App.GoPackage, "button")); !os.IsNotExist(err) {
+		t.Errorf("Expected no files to be written in dry-run mode")
+	}
+}
@@ -0,0 +1,99 @@
+package schemacmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/schema"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupSchemaCommand creates the "schema" command with its "actions" and "config" subcommands.
+func SetupSchemaCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "schema",
+		Usage:     "Export JSON Schema documents for editor validation and completion",
+		UsageText: "tempo schema <subcommand> [options]",
+		Commands: []*cli.Command{
+			setupSchemaSubCommand(cmdCtx, "actions", "Export the JSON Schema of the actions files",
+				ActionsSchema),
+			setupSchemaSubCommand(cmdCtx, "config", "Export the JSON Schema of tempo.yaml",
+				ConfigSchema),
+		},
+	}
+}
+
+func setupSchemaSubCommand(cmdCtx *app.AppContext, name, usage string, build func() *schema.Schema) *cli.Command {
+	return &cli.Command{
+		Name:                   name,
+		Usage:                  usage,
+		UsageText:              fmt.Sprintf("tempo schema %s [options]", name),
+		UseShortOptionHandling: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Path of the generated file (default: stdout)",
+			},
+		},
+		Action: runSchemaSubCommand(cmdCtx, build),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runSchemaSubCommand(cmdCtx *app.AppContext, build func() *schema.Schema) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Generate the schema
+		data, err := json.MarshalIndent(build(), "", "  ")
+		if err != nil {
+			return apperrors.Wrap("failed to marshal schema", err)
+		}
+		data = append(data, '\n')
+
+		// Step 2: Write it to stdout or to the output file
+		output := cmd.String("output")
+		if output == "" {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+
+		if err := utils.WriteToFile(output, data); err != nil {
+			return apperrors.Wrap("failed to write schema", err, output)
+		}
+		cmdCtx.Logger.Success("JSON Schema has been generated").WithAttrs("file", output)
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// ActionsSchema returns the JSON Schema of the actions files, generated from generator.JSONAction.
+func ActionsSchema() *schema.Schema {
+	return schema.Generate("tempo actions file", reflect.TypeFor[[]generator.JSONAction](), "json")
+}
+
+// ConfigSchema returns the JSON Schema of tempo.yaml, generated from config.Config.
+func ConfigSchema() *schema.Schema {
+	return schema.Generate("tempo configuration file", reflect.TypeFor[config.Config](), "yaml")
+}
@@ -0,0 +1,87 @@
+package schemacmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/schema"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+func newSchemaApp() *cli.Command {
+	return &cli.Command{
+		Commands: []*cli.Command{
+			SetupSchemaCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: config.DefaultConfig(),
+			}),
+		},
+	}
+}
+
+func TestSchemaCommand_Actions(t *testing.T) {
+	output, err := testutils.CaptureStdout(func() {
+		if err := newSchemaApp().Run(context.Background(), []string{"tempo", "schema", "actions"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	var s schema.Schema
+	if err := json.Unmarshal([]byte(output), &s); err != nil {
+		t.Fatalf("Failed to parse schema: %v\n%s", err, output)
+	}
+	if s.Type != "array" || s.Items == nil {
+		t.Fatalf("Expected an array of actions, got %+v", s)
+	}
+	for _, name := range []string{"type", "item", "templateFile", "path", "source", "destination", "engine"} {
+		if _, ok := s.Items.Properties[name]; !ok {
+			t.Errorf("Expected action property %q", name)
+		}
+	}
+	if len(s.Items.Required) != 1 || s.Items.Required[0] != "item" {
+		t.Errorf("Expected item to be required, got %v", s.Items.Required)
+	}
+}
+
+func TestSchemaCommand_ConfigToFile(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "schemas", "tempo.schema.json")
+
+	if _, err := testutils.CaptureStdout(func() {
+		if err := newSchemaApp().Run(context.Background(), []string{"tempo", "schema", "config", "-o", output}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	var s schema.Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+
+	for _, name := range []string{"tempo_root", "app", "processor", "templates", "components", "plugins"} {
+		if _, ok := s.Properties[name]; !ok {
+			t.Errorf("Expected config property %q", name)
+		}
+	}
+	if _, ok := s.Properties["Paths"]; ok {
+		t.Errorf("Expected fields excluded from YAML to be skipped")
+	}
+	if app := s.Properties["app"]; app == nil || app.Properties["require_go_module"] == nil {
+		t.Errorf("Expected app.require_go_module in the schema")
+	}
+}
@@ -0,0 +1,273 @@
+package statscmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/resolver"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/worker"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// AssetInfo describes a CSS or JS asset managed by tempo.
+type AssetInfo struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// ProjectStats holds the project insights reported by the stats command.
+type ProjectStats struct {
+	Components    int         `json:"components"`
+	Variants      int         `json:"variants"`
+	CSSFiles      int         `json:"css_files"`
+	CSSBytes      int64       `json:"css_bytes"`
+	JSFiles       int         `json:"js_files"`
+	JSBytes       int64       `json:"js_bytes"`
+	LargestAssets []AssetInfo `json:"largest_assets"`
+	LastSync      *time.Time  `json:"last_sync,omitempty"`
+	CacheHits     int         `json:"cache_hits"`     // Assets unchanged since the last sync
+	CacheHitRate  float64     `json:"cache_hit_rate"` // CacheHits over the number of assets (0-1)
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupStatsCommand creates the "stats" command reporting project insights.
+func SetupStatsCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "stats",
+		Usage:                  "Show statistics about components, variants and managed assets",
+		UsageText:              "tempo stats [options]",
+		UseShortOptionHandling: true,
+		Flags:                  getFlags(),
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Action: runStatsCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getFlags defines CLI flags.
+func getFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"f"},
+			Usage:   "Output format: table, json (default: table)",
+		},
+		&cli.IntFlag{
+			Name:  "top",
+			Value: 5,
+			Usage: "Number of largest assets to report",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runStatsCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		// Step 1: Resolve the output format
+		format, err := resolver.ResolveString(cmd.String("format"), "", "format", "table", []string{"table", "json"})
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Collect the statistics
+		stats, err := collectStats(cmdCtx.Config.App.GoPackage, cmdCtx.Config.App.AssetsDir, cmdCtx.Config.Layout.Variants(), cmdCtx.CWD, int(cmd.Int("top")))
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Print the report
+		if format == "json" {
+			return writeJSON(os.Stdout, stats)
+		}
+		return writeTable(os.Stdout, stats)
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// collectStats gathers the statistics for the components in goPackage, with their
+// variants in variantsDir, and the assets in assetsDir. The last sync time is read from the sync cache file in workingDir.
+func collectStats(goPackage, assetsDir, variantsDir, workingDir string, top int) (*ProjectStats, error) {
+	stats := &ProjectStats{LargestAssets: []AssetInfo{}}
+
+	components, err := listDirs(goPackage)
+	if err != nil {
+		return nil, apperrors.Wrap("failed to list components", err, goPackage)
+	}
+	stats.Components = len(components)
+
+	for _, name := range components {
+		variants, err := countFiles(filepath.Join(goPackage, name, filepath.FromSlash(variantsDir)), ".templ")
+		if err != nil {
+			return nil, apperrors.Wrap("failed to count variants", err, name)
+		}
+		stats.Variants += variants
+	}
+
+	lastSync, synced := worker.LastRunTime(workingDir)
+	if synced {
+		stats.LastSync = &lastSync
+	}
+
+	var assets []AssetInfo
+	err = filepath.WalkDir(assetsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == assetsDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if d.IsDir() || (ext != ".css" && ext != ".js") {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch ext {
+		case ".css":
+			stats.CSSFiles++
+			stats.CSSBytes += info.Size()
+		case ".js":
+			stats.JSFiles++
+			stats.JSBytes += info.Size()
+		}
+		if synced && info.ModTime().Unix() < lastSync.Unix() {
+			stats.CacheHits++
+		}
+
+		assets = append(assets, AssetInfo{Path: path, Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to scan assets", err, assetsDir)
+	}
+
+	if len(assets) > 0 {
+		stats.CacheHitRate = float64(stats.CacheHits) / float64(len(assets))
+	}
+
+	slices.SortStableFunc(assets, func(a, b AssetInfo) int {
+		if c := cmp.Compare(b.Size, a.Size); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+	if top >= 0 && len(assets) > top {
+		assets = assets[:top]
+	}
+	stats.LargestAssets = append(stats.LargestAssets, assets...)
+
+	return stats, nil
+}
+
+// listDirs returns the sorted names of the non-hidden folders in dir.
+func listDirs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// countFiles counts the files with the given extension in dir.
+func countFiles(dir, ext string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	count := 0
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ext {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// writeJSON writes the statistics as indented JSON.
+func writeJSON(w io.Writer, stats *ProjectStats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return apperrors.Wrap("failed to marshal stats", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// writeTable writes the statistics as an aligned table.
+func writeTable(w io.Writer, stats *ProjectStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Components\t%d\n", stats.Components)
+	fmt.Fprintf(tw, "Variants\t%d\n", stats.Variants)
+	fmt.Fprintf(tw, "CSS\t%s (%d files)\n", utils.FormatBytes(stats.CSSBytes), stats.CSSFiles)
+	fmt.Fprintf(tw, "JS\t%s (%d files)\n", utils.FormatBytes(stats.JSBytes), stats.JSFiles)
+
+	if stats.LastSync != nil {
+		fmt.Fprintf(tw, "Last sync\t%s\n", stats.LastSync.Format(time.DateTime))
+		fmt.Fprintf(tw, "Cache hit rate\t%.1f%% (%d of %d assets unchanged)\n",
+			stats.CacheHitRate*100, stats.CacheHits, stats.CSSFiles+stats.JSFiles)
+	} else {
+		fmt.Fprintf(tw, "Last sync\tnever\n")
+		fmt.Fprintf(tw, "Cache hit rate\tn/a\n")
+	}
+
+	if len(stats.LargestAssets) > 0 {
+		fmt.Fprintf(tw, "\nLargest assets\t\n")
+		for _, asset := range stats.LargestAssets {
+			fmt.Fprintf(tw, "  %s\t%s\n", asset.Path, utils.FormatBytes(asset.Size))
+		}
+	}
+
+	return tw.Flush()
+}
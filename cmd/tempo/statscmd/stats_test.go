@@ -0,0 +1,167 @@
+package statscmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/indaco/tempo/internal/worker"
+	"github.com/urfave/cli/v3"
+)
+
+// setupStatsProject creates a project with two components, one variant and three assets.
+// When synced is true, the last sync happened after base.css and before script.js was modified.
+func setupStatsProject(t *testing.T, synced bool) (string, *config.Config) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	files := map[string]string{
+		filepath.Join(cfg.App.GoPackage, "button", "button.templ"):                     "package button",
+		filepath.Join(cfg.App.GoPackage, "button", "css", "variants", "outline.templ"): "package css",
+		filepath.Join(cfg.App.GoPackage, "card", "card.templ"):                         "package card",
+		filepath.Join(cfg.App.AssetsDir, "button", "css", "base.css"):                  strings.Repeat("a", 2048),
+		filepath.Join(cfg.App.AssetsDir, "button", "css", "variants", "outline.css"):   "b",
+		filepath.Join(cfg.App.AssetsDir, "card", "js", "script.js"):                    strings.Repeat("c", 100),
+		filepath.Join(cfg.App.AssetsDir, "card", "README.md"):                          "ignored",
+	}
+	for path, content := range files {
+		testutils.CreateFile(t, path, content)
+	}
+
+	if synced {
+		past := time.Now().Add(-time.Hour)
+		for _, path := range []string{
+			filepath.Join(cfg.App.AssetsDir, "button", "css", "base.css"),
+			filepath.Join(cfg.App.AssetsDir, "button", "css", "variants", "outline.css"),
+		} {
+			if err := os.Chtimes(path, past, past); err != nil {
+				t.Fatalf("Failed to change file times: %v", err)
+			}
+		}
+		lastRun := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+		testutils.CreateFile(t, filepath.Join(tempDir, worker.LastRunFile), lastRun)
+	}
+
+	return tempDir, cfg
+}
+
+func runStats(t *testing.T, tempDir string, cfg *config.Config, args ...string) string {
+	t.Helper()
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupStatsCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    tempDir,
+			}),
+		},
+	}
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), append([]string{"tempo", "stats"}, args...)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	return output
+}
+
+func TestStatsCommand_JSON(t *testing.T) {
+	tempDir, cfg := setupStatsProject(t, true)
+
+	output := runStats(t, tempDir, cfg, "--format", "json", "--top", "2")
+
+	var stats ProjectStats
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+	}
+
+	if stats.Components != 2 || stats.Variants != 1 {
+		t.Errorf("Expected 2 components and 1 variant, got %d and %d", stats.Components, stats.Variants)
+	}
+	if stats.CSSFiles != 2 || stats.CSSBytes != 2049 {
+		t.Errorf("Expected 2 CSS files (2049 bytes), got %d (%d bytes)", stats.CSSFiles, stats.CSSBytes)
+	}
+	if stats.JSFiles != 1 || stats.JSBytes != 100 {
+		t.Errorf("Expected 1 JS file (100 bytes), got %d (%d bytes)", stats.JSFiles, stats.JSBytes)
+	}
+	if len(stats.LargestAssets) != 2 || filepath.Base(stats.LargestAssets[0].Path) != "base.css" ||
+		filepath.Base(stats.LargestAssets[1].Path) != "script.js" {
+		t.Errorf("Unexpected largest assets: %+v", stats.LargestAssets)
+	}
+	if stats.LastSync == nil {
+		t.Fatalf("Expected a last sync time")
+	}
+	if stats.CacheHits != 2 {
+		t.Errorf("Expected 2 cache hits, got %d", stats.CacheHits)
+	}
+	if rate := stats.CacheHitRate; rate < 0.66 || rate > 0.67 {
+		t.Errorf("Expected a cache hit rate of 2/3, got %f", rate)
+	}
+}
+
+func TestStatsCommand_Table(t *testing.T) {
+	tempDir, cfg := setupStatsProject(t, false)
+
+	output := runStats(t, tempDir, cfg)
+
+	testutils.ValidateCLIOutput(t, output, []string{
+		"Components", "2",
+		"Variants", "1",
+		"2.0 KB (2 files)",
+		"100 B (1 files)",
+		"Last sync", "never",
+		"Cache hit rate", "n/a",
+		"Largest assets",
+	})
+}
+
+func TestStatsCommand_EmptyProject(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	output := runStats(t, tempDir, cfg, "--format", "json")
+
+	var stats ProjectStats
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+	}
+	if stats.Components != 0 || stats.CSSFiles != 0 || len(stats.LargestAssets) != 0 || stats.LastSync != nil {
+		t.Errorf("Expected empty stats, got %+v", stats)
+	}
+}
+
+func TestStatsCommand_InvalidFormatFallsBackToTable(t *testing.T) {
+	tempDir, cfg := setupStatsProject(t, false)
+
+	output := runStats(t, tempDir, cfg, "--format", "xml")
+
+	testutils.ValidateCLIOutput(t, output, []string{"Components", "Largest assets"})
+}
@@ -0,0 +1,319 @@
+package statuscmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/sarif"
+	"github.com/indaco/tempo/internal/version"
+	"github.com/indaco/tempo/internal/worker"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Statuses of the files out of sync.
+const (
+	statusStale          = "stale"           // Asset modified after its .templ file
+	statusMissingTempl   = "missing-templ"   // Asset with no .templ file
+	statusMissingMarkers = "missing-markers" // .templ file without guard markers
+)
+
+// Output formats of the status.
+const (
+	FormatText  = "text"
+	FormatSARIF = "sarif"
+)
+
+// formats lists the supported output formats.
+var formats = []string{FormatText, FormatSARIF}
+
+// sarifRule is the SARIF rule of a status, along with the file it is reported on.
+type sarifRule struct {
+	id, description, message string
+	onAsset                  bool // Reported on the asset instead of its .templ file
+}
+
+// sarifStatuses lists the statuses in the order their SARIF rule is declared.
+var sarifStatuses = []string{statusStale, statusMissingTempl, statusMissingMarkers}
+
+// sarifRules maps the statuses to their SARIF rule. The messages are formatted with the
+// path of the other file.
+var sarifRules = map[string]sarifRule{
+	statusStale: {
+		id: "tempo/stale-output", description: "Asset modified after its .templ file",
+		message: "%s was modified after this file. Run 'tempo sync' to update it",
+	},
+	statusMissingTempl: {
+		id: "tempo/missing-output", description: "Asset with no .templ file",
+		message: "No .templ file for this asset, expected %s", onAsset: true,
+	},
+	statusMissingMarkers: {
+		id: "tempo/missing-guard-markers", description: ".templ file without guard markers",
+		message: "No guard markers for %s. Run 'tempo sync --repair' to add them",
+	},
+}
+
+// FileStatus is an asset whose .templ file is out of sync.
+type FileStatus struct {
+	Status    string `json:"status"`
+	Component string `json:"component,omitempty"`
+	Asset     string `json:"asset"`
+	Templ     string `json:"templ"`
+}
+
+// ProjectStatus holds the outcome of the status command.
+type ProjectStatus struct {
+	Assets     int          `json:"assets"`      // Number of synced assets checked
+	Files      []FileStatus `json:"files"`       // Assets out of sync, sorted by path
+	Components []string     `json:"components"`  // Components with an asset out of sync, sorted
+	TemplFiles int          `json:"templ_files"` // Number of distinct .templ files checked
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupStatusCommand creates the "status" command listing the outputs out of sync.
+func SetupStatusCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "status",
+		Usage:     "Show the assets newer than their .templ file, the .templ files missing guard markers and the components out of sync",
+		UsageText: "tempo status [--exit-code] [--format text|sarif]",
+		Description: "Compares the modification times of the assets and of their .templ files, and checks the guard " +
+			"markers, without processing or writing anything. Run 'tempo sync' to bring the components up to date.",
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "exit-code",
+				Usage: "Fail when some components are out of sync, e.g. in pre-commit hooks",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: FormatText,
+				Usage: "Output format: text or sarif (implies --exit-code, for code scanning annotations)",
+			},
+		},
+		Action: runStatusCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runStatusCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		format := cmd.String("format")
+		if !slices.Contains(formats, format) {
+			return apperrors.Wrap("invalid value for '--format' %s (expected one of: %s)", format, strings.Join(formats, ", "))
+		}
+
+		// Step 1: Compare the assets with their .templ files
+		status, err := CollectStatus(cmdCtx.Config)
+		if err != nil {
+			return err
+		}
+		cmdCtx.Summary = map[string]any{
+			"assets":      status.Assets,
+			"templ_files": status.TemplFiles,
+			"files":       status.Files,
+			"components":  status.Components,
+		}
+
+		if format == FormatSARIF {
+			return writeSARIF(cmdCtx, status)
+		}
+
+		if len(status.Files) == 0 {
+			cmdCtx.Logger.Success("All components are in sync").
+				WithAttrs("assets", status.Assets, "templ_files", status.TemplFiles)
+			return nil
+		}
+
+		// Step 2: Report the files out of sync
+		if err := writeStatusTable(os.Stdout, status); err != nil {
+			return err
+		}
+
+		if cmd.Bool("exit-code") {
+			return apperrors.WrapCode(apperrors.CodeOutOfSync, "%s component(s) out of sync", len(status.Components))
+		}
+		cmdCtx.Logger.Info("Run 'tempo sync' to update them").
+			WithAttrs("components", len(status.Components), "files", len(status.Files))
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// CollectStatus checks every synced asset, leaving out the prebuilt ones, against its
+// .templ file: the file must exist, hold the guard markers and be modified after the asset.
+func CollectStatus(cfg *config.Config) (*ProjectStatus, error) {
+	rules, err := worker.ConfigOutputRules(cfg.Processor.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := worker.ConfigLanguages(cfg.Processor.Languages)
+	if err != nil {
+		return nil, err
+	}
+	skipper, err := worker.ConfigInputSkipper(cfg.Processor.SkipInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	assetsDir, goPackage := cfg.App.AssetsDir, cfg.App.GoPackage
+	status := &ProjectStatus{Files: []FileStatus{}, Components: []string{}}
+
+	// A .templ file is read once, for all its assets
+	contents := make(map[string]string)
+	err = worker.WalkInputDir(assetsDir, cfg.Processor.Symlinks, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == assetsDir && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if errors.Is(err, worker.ErrSymlinkSkipped) || errors.Is(err, worker.ErrSymlinkNotAllowed) ||
+				errors.Is(err, worker.ErrSymlinkCycle) || errors.Is(err, worker.ErrSymlinkDuplicate) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !languages.Supports(path) {
+			return nil
+		}
+		if reason, err := skipper.SkipReason(path, assetsDir, nil, languages); err != nil || reason != "" {
+			return err
+		}
+		status.Assets++
+
+		templ, region := worker.OutputTarget(path, assetsDir, goPackage, rules)
+		templ = filepath.Clean(templ)
+		result := FileStatus{Component: worker.ComponentName(assetsDir, path), Asset: path, Templ: templ}
+
+		templInfo, err := os.Stat(templ)
+		if errors.Is(err, fs.ErrNotExist) {
+			result.Status = statusMissingTempl
+			status.Files = append(status.Files, result)
+			return nil
+		}
+		if err != nil {
+			return apperrors.Wrap("failed to read file", err, templ)
+		}
+
+		content, read := contents[templ]
+		if !read {
+			data, err := os.ReadFile(templ)
+			if err != nil {
+				return apperrors.Wrap("failed to read file", err, templ)
+			}
+			content = string(data)
+			contents[templ] = content
+		}
+		_, _, hasMarkers := processor.GuardRegion(content, processor.RegionMarker(cfg.Templates.GuardMarker, region))
+
+		assetInfo, err := d.Info()
+		if err != nil {
+			return apperrors.Wrap("failed to read file", err, path)
+		}
+
+		switch {
+		case !hasMarkers:
+			result.Status = statusMissingMarkers
+		case assetInfo.ModTime().After(templInfo.ModTime()):
+			result.Status = statusStale
+		default:
+			return nil
+		}
+		status.Files = append(status.Files, result)
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to scan assets", err, assetsDir)
+	}
+	status.TemplFiles = len(contents)
+
+	slices.SortFunc(status.Files, func(a, b FileStatus) int { return strings.Compare(a.Asset, b.Asset) })
+	for _, f := range status.Files {
+		if f.Component != "" && !slices.Contains(status.Components, f.Component) {
+			status.Components = append(status.Components, f.Component)
+		}
+	}
+	slices.Sort(status.Components)
+	return status, nil
+}
+
+// writeStatusTable writes the files out of sync as an aligned table, followed by the
+// components to sync.
+func writeStatusTable(w io.Writer, status *ProjectStatus) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nSTATUS\tASSET\tTEMPL FILE\n")
+	for _, f := range status.Files {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", f.Status, f.Asset, f.Templ)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(status.Components) > 0 {
+		_, err := fmt.Fprintf(w, "\nComponents out of sync: %s\n", strings.Join(status.Components, ", "))
+		return err
+	}
+	return nil
+}
+
+// AddSARIFResults adds the files out of sync of status to log, with paths relative to
+// root: the stale .templ files, the assets with no .templ file and the .templ files
+// without guard markers.
+func AddSARIFResults(log *sarif.Log, root string, status *ProjectStatus) {
+	for _, s := range sarifStatuses {
+		log.AddRule(sarifRules[s].id, sarifRules[s].description)
+	}
+	for _, f := range status.Files {
+		rule, ok := sarifRules[f.Status]
+		if !ok {
+			continue
+		}
+		path, other := f.Templ, f.Asset
+		if rule.onAsset {
+			path, other = f.Asset, f.Templ
+		}
+		log.AddResult(rule.id, sarif.LevelWarning, fmt.Sprintf(rule.message, other), root, path, 0)
+	}
+}
+
+// writeSARIF prints the files out of sync as a SARIF log and fails when there are any.
+func writeSARIF(cmdCtx *app.AppContext, status *ProjectStatus) error {
+	log := sarif.New("tempo", version.GetVersion(), "https://github.com/indaco/tempo")
+	AddSARIFResults(log, cmdCtx.CWD, status)
+
+	if err := log.Write(os.Stdout); err != nil {
+		return apperrors.Wrap("failed to write SARIF output", err)
+	}
+	if len(status.Files) > 0 {
+		return apperrors.WrapCode(apperrors.CodeOutOfSync, "%s asset(s) out of sync", len(status.Files))
+	}
+	return nil
+}
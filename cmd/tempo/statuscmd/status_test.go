@@ -0,0 +1,194 @@
+package statuscmd
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/sarif"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+const guardedTempl = "package button\n\n" +
+	"/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n" +
+	"/* [tempo] END */\n"
+
+// newStatusProject creates an empty project in a temporary working directory.
+func newStatusProject(t *testing.T) *config.Config {
+	t.Helper()
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, func(cfg *config.Config) {
+		cfg.App.AssetsDir = "assets"
+		cfg.App.GoPackage = "components"
+	})
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+	return cfg
+}
+
+// setupStatusProject creates a project with a synced asset, an asset modified after its
+// .templ file, an asset with no .templ file and a .templ file without guard markers.
+func setupStatusProject(t *testing.T) *config.Config {
+	t.Helper()
+	cfg := newStatusProject(t)
+
+	files := map[string]string{
+		"assets/button/css/base.css":          ".btn { color: red; }",
+		"assets/button/css/outline.css":       ".btn { border: 1px; }",
+		"assets/card/css/base.css":            ".card { color: blue; }",
+		"assets/card/js/script.js":            "console.log(1);",
+		"assets/card/README.md":               "ignored",
+		"components/button/css/base.templ":    guardedTempl,
+		"components/button/css/outline.templ": guardedTempl,
+		"components/card/css/base.templ":      "package card\n",
+	}
+	for path, content := range files {
+		testutils.CreateFile(t, path, content)
+	}
+
+	// Only outline.css was modified after its .templ file
+	past := time.Now().Add(-time.Hour)
+	for _, path := range []string{"assets/button/css/base.css", "assets/card/css/base.css", "components/button/css/outline.templ"} {
+		if err := os.Chtimes(path, past, past); err != nil {
+			t.Fatalf("Failed to change file times: %v", err)
+		}
+	}
+	return cfg
+}
+
+func runStatus(t *testing.T, cfg *config.Config, args ...string) (string, error) {
+	t.Helper()
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupStatusCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    ".",
+			}),
+		},
+	}
+
+	var runErr error
+	output, err := testutils.CaptureStdout(func() {
+		runErr = cliApp.Run(context.Background(), append([]string{"tempo", "status"}, args...))
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	return output, runErr
+}
+
+func TestStatusCommand(t *testing.T) {
+	cfg := setupStatusProject(t)
+
+	t.Run("lists the files out of sync", func(t *testing.T) {
+		output, err := runStatus(t, cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, want := range []string{
+			"stale            assets/button/css/outline.css",
+			"missing-markers  assets/card/css/base.css",
+			"missing-templ    assets/card/js/script.js",
+			"Components out of sync: button, card",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in output, got:\n%s", want, output)
+			}
+		}
+		for _, unwanted := range []string{"assets/button/css/base.css", "README.md"} {
+			if strings.Contains(output, unwanted) {
+				t.Errorf("Did not expect %q in output, got:\n%s", unwanted, output)
+			}
+		}
+	})
+
+	t.Run("leaves the files unchanged", func(t *testing.T) {
+		content, err := os.ReadFile("components/card/css/base.templ")
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+		if string(content) != "package card\n" {
+			t.Errorf("Expected the .templ file to be unchanged, got:\n%s", content)
+		}
+	})
+
+	t.Run("sarif annotates the files out of sync", func(t *testing.T) {
+		output, err := runStatus(t, cfg, "--format", "sarif")
+		if code := apperrors.CodeOf(err); code != apperrors.CodeOutOfSync {
+			t.Errorf("Expected code %s, got %q (err: %v)", apperrors.CodeOutOfSync, code, err)
+		}
+
+		var log sarif.Log
+		if err := json.Unmarshal([]byte(output), &log); err != nil {
+			t.Fatalf("Invalid SARIF output: %v\n%s", err, output)
+		}
+		got := map[string]string{}
+		for _, result := range log.Results() {
+			got[result.Locations[0].PhysicalLocation.ArtifactLocation.URI] = result.RuleID
+		}
+		expected := map[string]string{
+			"components/button/css/outline.templ": "tempo/stale-output",
+			"components/card/css/base.templ":      "tempo/missing-guard-markers",
+			"assets/card/js/script.js":            "tempo/missing-output",
+		}
+		if !maps.Equal(got, expected) {
+			t.Errorf("Expected the results %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		if _, err := runStatus(t, cfg, "--format", "xml"); err == nil {
+			t.Error("Expected an error for an invalid format")
+		}
+	})
+
+	t.Run("fails with --exit-code", func(t *testing.T) {
+		_, err := runStatus(t, cfg, "--exit-code")
+		if err == nil {
+			t.Fatal("Expected an error, got nil")
+		}
+		if code := apperrors.CodeOf(err); code != apperrors.CodeOutOfSync {
+			t.Errorf("Expected code %s, got %q", apperrors.CodeOutOfSync, code)
+		}
+	})
+}
+
+func TestStatusCommand_InSync(t *testing.T) {
+	cfg := newStatusProject(t)
+	testutils.CreateFile(t, "assets/button/css/base.css", ".btn { color: red; }")
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes("assets/button/css/base.css", past, past); err != nil {
+		t.Fatalf("Failed to change file times: %v", err)
+	}
+	testutils.CreateFile(t, "components/button/css/base.templ", guardedTempl)
+
+	status, err := CollectStatus(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status.Assets != 1 || status.TemplFiles != 1 || len(status.Files) != 0 || len(status.Components) != 0 {
+		t.Errorf("Expected one asset in sync, got %+v", status)
+	}
+
+	if _, err := runStatus(t, cfg, "--exit-code"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
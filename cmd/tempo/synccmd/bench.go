@@ -0,0 +1,100 @@
+package synccmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/worker"
+	"github.com/urfave/cli/v3"
+)
+
+// benchReport is the JSON output of '--bench' with the json summary format.
+type benchReport struct {
+	worker.BenchResult
+	FilesPerSecond float64       `json:"files_per_second"`
+	BytesPerSecond float64       `json:"bytes_per_second"`
+	Budget         time.Duration `json:"budget,omitempty"`
+}
+
+// runBenchSync runs the worker pool on a synthetic asset tree, created in a temporary
+// folder removed afterwards, and reports its throughput. The project files are left
+// untouched. It fails when the run exceeds the '--bench-budget' duration.
+func runBenchSync(ctx context.Context, log logger.Logger, cmd *cli.Command, opts worker.WorkerPoolOptions, format worker.SummaryFormat, w io.Writer) error {
+	benchOpts := worker.BenchOptions{
+		Files:      int(cmd.Int("bench-files")),
+		Workers:    opts.NumWorkers,
+		Production: opts.IsProduction,
+		MarkerName: opts.MarkerName,
+	}
+	if benchOpts.Files < 0 {
+		return apperrors.Wrap("invalid value for '--bench-files': must be a positive integer")
+	}
+	if value := cmd.String("bench-size"); value != "" {
+		size, err := utils.ParseBytes(value)
+		if err != nil {
+			return apperrors.Wrap("invalid value for '--bench-size'", err)
+		}
+		benchOpts.FileSize = size
+	}
+	var budget time.Duration
+	if value := cmd.String("bench-budget"); value != "" {
+		var err error
+		if budget, err = utils.ParseDuration(value); err != nil {
+			return apperrors.Wrap("invalid value for '--bench-budget'", err)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "tempo-bench-")
+	if err != nil {
+		return apperrors.Wrap("failed to create the benchmark folder", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if format != worker.FormatJSON {
+		log.Info("Running benchmark...")
+	}
+	result, err := worker.RunBench(ctx, dir, benchOpts)
+	if err != nil {
+		return apperrors.Wrap("benchmark failed", err)
+	}
+
+	report := benchReport{
+		BenchResult:    result,
+		FilesPerSecond: result.FilesPerSecond(),
+		BytesPerSecond: result.BytesPerSecond(),
+		Budget:         budget,
+	}
+	if format == worker.FormatJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return apperrors.Wrap("failed to marshal the benchmark report", err)
+		}
+		if _, err := fmt.Fprintln(w, string(data)); err != nil {
+			return err
+		}
+	} else {
+		log.Success("Benchmark completed").WithAttrs(
+			"files", result.Files,
+			"size", utils.FormatBytes(result.Bytes),
+			"workers", result.Workers,
+			"duration", result.Duration.Round(time.Millisecond).String(),
+			"throughput", fmt.Sprintf("%.0f files/s, %s/s", report.FilesPerSecond, utils.FormatBytes(int64(report.BytesPerSecond))),
+		)
+	}
+
+	if result.Failed > 0 {
+		return apperrors.Wrap("benchmark: %s file(s) failed to process", result.Failed)
+	}
+	if budget > 0 && result.Duration > budget {
+		return apperrors.Wrap("benchmark exceeded the performance budget: %s > %s",
+			result.Duration.Round(time.Millisecond).String(), budget.String())
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package synccmd
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+func TestSyncCommand_Bench(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupSyncCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    tempDir,
+			}),
+		},
+	}
+
+	run := func(args ...string) (string, error) {
+		var runErr error
+		output, err := testutils.CaptureStdout(func() {
+			runErr = cliApp.Run(context.Background(), append([]string{"tempo", "sync", "--bench", "--bench-files", "20", "--bench-size", "1KB"}, args...))
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		return output, runErr
+	}
+
+	t.Run("Report", func(t *testing.T) {
+		output, err := run()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"Benchmark completed", "files: 20", "files/s"})
+	})
+
+	t.Run("JSON report", func(t *testing.T) {
+		output, err := run("--summary", "json")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var report benchReport
+		if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &report); err != nil {
+			t.Fatalf("Expected a JSON report, got %q: %v", output, err)
+		}
+		if report.Files != 20 || report.Bytes < 20*1024 || report.FilesPerSecond <= 0 {
+			t.Errorf("Unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("Budget exceeded", func(t *testing.T) {
+		_, err := run("--bench-budget", "1ns")
+		if err == nil || !strings.Contains(err.Error(), "performance budget") {
+			t.Errorf("Expected a performance budget error, got %v", err)
+		}
+	})
+
+	t.Run("Invalid size", func(t *testing.T) {
+		if _, err := run("--bench-size", "big"); err == nil {
+			t.Error("Expected an error for an invalid size")
+		}
+	})
+}
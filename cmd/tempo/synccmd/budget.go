@@ -0,0 +1,60 @@
+package synccmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/worker"
+)
+
+// checkBudgets reports the components of the input folder exceeding the asset budget,
+// if any is set. Exceeded budgets fail when strict is set (the --check mode) or the
+// budget requires it, and are logged as a warning otherwise.
+func checkBudgets(cmdCtx *app.AppContext, opts worker.WorkerPoolOptions, budget *worker.AssetBudget, strict bool, w io.Writer) error {
+	if budget == nil {
+		if strict {
+			cmdCtx.Logger.Info("No asset budget set: add processor.budgets to the config file")
+		}
+		return nil
+	}
+
+	violations, err := budget.Check(opts)
+	if err != nil {
+		return apperrors.Wrap("failed to check the asset budgets", err)
+	}
+	if cmdCtx.Summary == nil {
+		cmdCtx.Summary = make(map[string]any)
+	}
+	cmdCtx.Summary["budget_violations"] = len(violations)
+
+	if len(violations) == 0 {
+		if strict {
+			cmdCtx.Logger.Success("All components are within their asset budgets")
+		}
+		return nil
+	}
+
+	if err := writeBudgetViolations(w, violations); err != nil {
+		return err
+	}
+	if strict || budget.Fail {
+		return apperrors.WrapCode(apperrors.CodeBudgetExceeded, "%s component asset budget(s) exceeded", len(violations))
+	}
+	cmdCtx.Logger.Warning("Some components exceed their asset budget (processor.budgets)").
+		WithAttrs("violations", len(violations))
+	return nil
+}
+
+// writeBudgetViolations writes the exceeded budgets as an aligned table.
+func writeBudgetViolations(w io.Writer, violations []worker.BudgetViolation) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nCOMPONENT\tKIND\tSIZE\tBUDGET\n")
+	for _, v := range violations {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", v.Component, v.Kind, utils.FormatBytes(v.Size), utils.FormatBytes(v.Limit))
+	}
+	return tw.Flush()
+}
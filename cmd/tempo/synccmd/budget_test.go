@@ -0,0 +1,113 @@
+package synccmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+func TestSyncCommand_Budgets(t *testing.T) {
+	templContent := "/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo] END */"
+
+	setup := func(t *testing.T, budgets config.Budgets) (*cli.Command, *app.AppContext, string) {
+		t.Helper()
+		tempDir := t.TempDir()
+		if err := testutils.CreateModFile(tempDir); err != nil {
+			t.Fatalf("Failed to create go.mod file: %v", err)
+		}
+		cfg := testutils.SetupConfig(tempDir, func(cfg *config.Config) {
+			cfg.Processor.Budgets = budgets
+		})
+		if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+			t.Fatalf("Failed to create mock config file: %v", err)
+		}
+		_ = os.MkdirAll(filepath.Join(cfg.Paths.TemplatesDir, "component"), 0755)
+		_ = os.MkdirAll(filepath.Join(cfg.Paths.TemplatesDir, "component-variant"), 0755)
+		_ = os.MkdirAll(cfg.Paths.ActionsDir, 0755)
+
+		testutils.CreateFile(t, filepath.Join(cfg.App.AssetsDir, "big", "base.css"), strings.Repeat(".a{color:red}\n", 100))
+		testutils.CreateFile(t, filepath.Join(cfg.App.AssetsDir, "small", "base.css"), ".b{color:red}")
+		templPath := filepath.Join(cfg.App.GoPackage, "big", "base.templ")
+		testutils.CreateFile(t, templPath, templContent)
+		testutils.CreateFile(t, filepath.Join(cfg.App.GoPackage, "small", "base.templ"), templContent)
+
+		cliCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}
+		return &cli.Command{Commands: []*cli.Command{SetupSyncCommand(cliCtx)}}, cliCtx, templPath
+	}
+
+	t.Run("check mode", func(t *testing.T) {
+		cliApp, _, templPath := setup(t, config.Budgets{CSS: "1KB"})
+		var runErr error
+		output, err := testutils.CaptureStdout(func() {
+			runErr = cliApp.Run(context.Background(), []string{"tempo", "sync", "--check"})
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		if apperrors.CodeOf(runErr) != apperrors.CodeBudgetExceeded {
+			t.Errorf("Expected a budget error, got %v", runErr)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"COMPONENT", "big", "1.0 KB"})
+		if strings.Contains(output, "small") {
+			t.Errorf("Expected the component within budget to be left out, got:\n%s", output)
+		}
+		if content, _ := os.ReadFile(templPath); string(content) != templContent {
+			t.Errorf("Expected check mode not to sync, got %q", content)
+		}
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		cliApp, cliCtx, templPath := setup(t, config.Budgets{CSS: "1KB"})
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "sync"}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"exceed their asset budget"})
+		if cliCtx.Summary["budget_violations"] != 1 {
+			t.Errorf("Expected 1 budget violation in the summary, got %v", cliCtx.Summary["budget_violations"])
+		}
+		if content, _ := os.ReadFile(templPath); string(content) == templContent {
+			t.Errorf("Expected the sync to go on despite the warning")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		cliApp, _, _ := setup(t, config.Budgets{CSS: "1KB", OnExceed: "error"})
+		var runErr error
+		_, err := testutils.CaptureStdout(func() {
+			runErr = cliApp.Run(context.Background(), []string{"tempo", "sync"})
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		if apperrors.CodeOf(runErr) != apperrors.CodeBudgetExceeded {
+			t.Errorf("Expected a budget error, got %v", runErr)
+		}
+	})
+
+	t.Run("within budget", func(t *testing.T) {
+		cliApp, _, _ := setup(t, config.Budgets{CSS: "10KB"})
+		output, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "sync", "--check"}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"within their asset budgets"})
+	})
+}
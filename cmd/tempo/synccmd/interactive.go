@@ -0,0 +1,219 @@
+package synccmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/progress"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/worker"
+	"github.com/urfave/cli/v3"
+)
+
+// interactiveTerminal reports whether the answers can be prompted for: stdin is a
+// terminal and the command does not run in CI. Tests replace it.
+var interactiveTerminal = func() bool {
+	if _, ci := os.LookupEnv("CI"); ci {
+		return false
+	}
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// promptInput is where the answers are read from. Tests replace it.
+var promptInput io.Reader = os.Stdin
+
+// conflictPromptHelp describes the answers to the prompt of an edited guard region.
+const conflictPromptHelp = `k - keep the edited region, skipping the file
+o - overwrite the region with the source asset
+d - show the changes the overwrite would make
+a - overwrite this region and all the remaining ones
+q - keep this region and all the remaining ones
+? - print help
+`
+
+// conflictResolver asks, file by file, how to resolve the guard regions edited by hand
+// since the last sync, once the worker pool refused to overwrite them.
+type conflictResolver struct {
+	in       *bufio.Reader
+	out      io.Writer
+	log      logger.Logger
+	checksum *processor.GuardChecksum
+}
+
+// newConflictResolver returns the resolver of the manual edits for '--interactive',
+// forcing the refuse policy so that the worker pool leaves the edited files untouched.
+// It returns nil when the flag is not set, when the edits are not detected or always
+// overwritten, and with '--yes' or outside an interactive terminal, where
+// templates.manual_edits applies as is.
+func newConflictResolver(cmd *cli.Command, yes bool, log logger.Logger, checksum *processor.GuardChecksum, out io.Writer) *conflictResolver {
+	if !cmd.Bool("interactive") {
+		return nil
+	}
+	switch {
+	case checksum == nil:
+		log.Warning("'--interactive' requires templates.manual_edits to detect the guard regions edited by hand")
+		return nil
+	case checksum.Overwrite:
+		return nil
+	case yes:
+		log.Info("'--yes' set: the guard regions edited by hand follow templates.manual_edits").
+			WithAttrs("policy", checksum.Policy)
+		return nil
+	case !interactiveTerminal():
+		log.Info("Not an interactive terminal: the guard regions edited by hand follow templates.manual_edits").
+			WithAttrs("policy", checksum.Policy)
+		return nil
+	}
+
+	checksum.Policy = processor.ManualEditsRefuse
+	return &conflictResolver{in: bufio.NewReader(promptInput), out: out, log: log, checksum: checksum}
+}
+
+// Resolve prompts for each file whose processing failed on a guard region edited by
+// hand. The overwritten files are processed again and removed from the errors, the
+// kept ones are moved to the skipped files. Files with other errors are left as is.
+func (r *conflictResolver) Resolve(manager *worker.WorkerPoolManager, jobs []worker.Job, errs, skipped []worker.ProcessingError) ([]worker.ProcessingError, []worker.ProcessingError) {
+	outputs := make(map[string]worker.Job, len(jobs))
+	for _, job := range jobs {
+		outputs[job.InputPath] = job
+	}
+
+	conflicts := 0
+	for _, e := range errs {
+		if _, ok := outputs[e.Source]; ok && e.Code == apperrors.CodeManualEdits {
+			conflicts++
+		}
+	}
+	if conflicts == 0 {
+		return errs, skipped
+	}
+	r.log.Info(fmt.Sprintf("%d guard region(s) edited by hand since the last sync", conflicts))
+
+	// The workers are done: the regions are now overwritten on demand
+	r.checksum.Overwrite = true
+	defer func() { r.checksum.Overwrite = false }()
+
+	// The workers report the errors in any order: prompt in the order of the files
+	slices.SortStableFunc(errs, func(a, b worker.ProcessingError) int { return strings.Compare(a.Source, b.Source) })
+
+	var remaining []worker.ProcessingError
+	all := ""
+	for _, e := range errs {
+		job, ok := outputs[e.Source]
+		if !ok || e.Code != apperrors.CodeManualEdits {
+			remaining = append(remaining, e)
+			continue
+		}
+		dest := job.OutputPath
+
+		answer := all
+		if answer == "" {
+			answer = r.ask(manager, job)
+			switch answer {
+			case "a":
+				answer, all = "o", "o"
+			case "q":
+				answer, all = "k", "k"
+			}
+		}
+
+		manager.Metrics.ErrorsEncountered--
+		if answer == "o" {
+			if err := manager.Factory.GetProcessor(e.Source).Process(e.Source, dest, job.Marker(manager.MarkerName)); err != nil {
+				manager.Metrics.ErrorsEncountered++
+				remaining = append(remaining, worker.FormatError(e.Source, err))
+				continue
+			}
+			manager.Metrics.RecordProcessedFile(e.Source)
+			manager.Progress.Emit(progress.Record{Event: progress.EventDone, File: e.Source, Dest: dest})
+			r.log.Success("Overwritten the guard region edited by hand").WithAttrs("file", dest)
+			continue
+		}
+		skip := worker.ProcessingError{
+			Source:     e.Source,
+			Dest:       dest,
+			Reason:     "Guard region edited by hand, kept interactively",
+			SkipType:   worker.SkipManualEdits,
+			ReasonCode: worker.SkipManualEdits.Reason(),
+		}
+		skipped = append(skipped, skip)
+		manager.Progress.Emit(progress.Record{Event: progress.EventSkipped, File: skip.Source, Dest: skip.Dest, Reason: skip.Reason})
+	}
+	return remaining, skipped
+}
+
+// ask prompts for the resolution of the edited guard region of the job output until a
+// valid answer is given, and returns it: "k", "o", "a" or "q". The end of the input
+// keeps the remaining regions.
+func (r *conflictResolver) ask(manager *worker.WorkerPoolManager, job worker.Job) string {
+	dest := job.OutputPath
+	for {
+		fmt.Fprintf(r.out, "\nThe guard region of %s was edited by hand.\nOverwrite it with %s [k,o,d,a,q,?]? ", job.Target(), job.InputPath)
+		line, err := r.in.ReadString('\n')
+		answer := strings.TrimSpace(line)
+		if answer == "" && err != nil {
+			fmt.Fprintln(r.out)
+			return "q"
+		}
+
+		switch answer {
+		case "k", "o", "a", "q":
+			return answer
+		case "d":
+			diff, err := previewOverwrite(manager, job)
+			if err != nil {
+				r.log.Error("Failed to preview the overwrite").WithAttrs("file", dest, "error", err.Error())
+				continue
+			}
+			fmt.Fprint(r.out, diffOrNote(diff))
+		default:
+			fmt.Fprint(r.out, conflictPromptHelp)
+		}
+	}
+}
+
+// previewOverwrite returns the unified diff of the changes made to the job output by
+// the overwrite of its guard region, processing a temporary copy of the output.
+func previewOverwrite(manager *worker.WorkerPoolManager, job worker.Job) (string, error) {
+	source, dest := job.InputPath, job.OutputPath
+	current, err := os.ReadFile(dest)
+	if err != nil {
+		return "", apperrors.Wrap("failed to read the templ file", err, dest)
+	}
+
+	tempDir, err := os.MkdirTemp("", "tempo-interactive-*")
+	if err != nil {
+		return "", apperrors.Wrap("failed to create a temporary folder", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	preview := filepath.Join(tempDir, filepath.Base(dest))
+	if err := os.WriteFile(preview, current, 0o644); err != nil {
+		return "", apperrors.Wrap("failed to copy the templ file", err, dest)
+	}
+	if err := manager.Factory.GetProcessor(source).Process(source, preview, job.Marker(manager.MarkerName)); err != nil {
+		return "", err
+	}
+	updated, err := os.ReadFile(preview)
+	if err != nil {
+		return "", apperrors.Wrap("failed to read the templ file", err, preview)
+	}
+	return utils.UnifiedDiff(dest, dest, string(current), string(updated)), nil
+}
+
+// diffOrNote returns diff, or a note when the overwrite changes nothing.
+func diffOrNote(diff string) string {
+	if diff == "" {
+		return "No changes: the region already matches the source asset.\n"
+	}
+	return diff
+}
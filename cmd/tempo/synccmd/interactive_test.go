@@ -0,0 +1,150 @@
+package synccmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+func TestSyncCommand_Interactive(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cfg.Templates.ManualEdits = processor.ManualEditsRefuse
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	startMarker, endMarker := processor.GuardMarkers(cfg.Templates.GuardMarker)
+	names := []string{"button", "card"}
+	for _, name := range names {
+		testutils.CreateFile(t, filepath.Join(cfg.App.AssetsDir, name, "base.css"), "."+name+" { color: green; }")
+		testutils.CreateFile(t, filepath.Join(cfg.App.GoPackage, name, "base.templ"), startMarker+"\n"+endMarker+"\n")
+	}
+
+	origTerminal, origInput := interactiveTerminal, promptInput
+	t.Cleanup(func() { interactiveTerminal, promptInput = origTerminal, origInput })
+
+	run := func(t *testing.T, answers string, args ...string) (string, error) {
+		t.Helper()
+		promptInput = strings.NewReader(answers)
+		cliApp := &cli.Command{Commands: []*cli.Command{SetupSyncCommand(&app.AppContext{
+			Logger: logger.NewDefaultLogger(),
+			Config: cfg,
+			CWD:    tempDir,
+		})}}
+		var runErr error
+		output, err := testutils.CaptureStdout(func() {
+			runErr = cliApp.Run(context.Background(), append([]string{"tempo", "sync", "--force"}, args...))
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		return output, runErr
+	}
+	edit := func(t *testing.T) {
+		t.Helper()
+		for _, name := range names {
+			path := filepath.Join(cfg.App.GoPackage, name, "base.templ")
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			edited := strings.Replace(string(content), "color: green", "color: red", 1)
+			if err := os.WriteFile(path, []byte(edited), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	read := func(t *testing.T, name string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(cfg.App.GoPackage, name, "base.templ"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	// The first sync records the checksums of the regions
+	if _, err := run(t, ""); err != nil {
+		t.Fatalf("Unexpected error on the first sync: %v", err)
+	}
+
+	t.Run("keeps and overwrites per file, with a diff", func(t *testing.T) {
+		interactiveTerminal = func() bool { return true }
+		edit(t)
+
+		// The files are processed in order: button is kept, card is overwritten
+		output, err := run(t, "x\nk\nd\no\n", "--interactive")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, want := range []string{"[k,o,d,a,q,?]", "o - overwrite the region", "-.card { color: red; }", "+.card { color: green; }"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", want, output)
+			}
+		}
+		if !strings.Contains(read(t, "button"), "color: red") {
+			t.Error("Expected the kept region of button to be left untouched")
+		}
+		if !strings.Contains(read(t, "card"), "color: green") {
+			t.Error("Expected the region of card to be overwritten")
+		}
+	})
+
+	t.Run("overwrites all the remaining regions", func(t *testing.T) {
+		interactiveTerminal = func() bool { return true }
+		edit(t)
+
+		if _, err := run(t, "a\n", "--interactive"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, name := range names {
+			if !strings.Contains(read(t, name), "color: green") {
+				t.Errorf("Expected the region of %s to be overwritten", name)
+			}
+		}
+	})
+
+	t.Run("falls back to the policy outside a terminal", func(t *testing.T) {
+		interactiveTerminal = func() bool { return false }
+		edit(t)
+
+		output, err := run(t, "a\n", "--interactive")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if strings.Contains(output, "[k,o,d,a,q,?]") {
+			t.Errorf("Expected no prompt outside a terminal, got:\n%s", output)
+		}
+		if !strings.Contains(read(t, "card"), "color: red") {
+			t.Error("Expected the refuse policy to leave the edited region untouched")
+		}
+	})
+
+	t.Run("falls back to the policy with --yes", func(t *testing.T) {
+		interactiveTerminal = func() bool { return true }
+		edit(t)
+
+		output, err := run(t, "a\n", "--interactive", "--yes")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if strings.Contains(output, "[k,o,d,a,q,?]") {
+			t.Errorf("Expected no prompt with --yes, got:\n%s", output)
+		}
+		if !strings.Contains(read(t, "card"), "color: red") {
+			t.Error("Expected the refuse policy to leave the edited region untouched")
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package synccmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/processor"
+)
+
+// repairGuardMarkers scans the .templ files in outputDir and repairs missing,
+// duplicated or misordered guard markers. It returns the repaired files and the
+// files that could not be repaired.
+func repairGuardMarkers(log logger.Logger, outputDir, markerName string) ([]string, []string, error) {
+	var repaired, unresolved []string
+
+	err := filepath.WalkDir(outputDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".templ" {
+			return nil
+		}
+
+		result, err := processor.RepairGuardMarkersInFile(path, markerName, false)
+		if err != nil {
+			return err
+		}
+
+		switch result.Status {
+		case processor.RepairFixed:
+			repaired = append(repaired, path)
+			log.Info("Repaired guard markers").
+				WithAttrs(
+					"file", path,
+					"issues", strings.Join(result.Issues, "; "),
+				)
+		case processor.RepairUnresolved:
+			unresolved = append(unresolved, path)
+			log.Warning("Cannot repair guard markers").
+				WithAttrs(
+					"file", path,
+					"issues", strings.Join(result.Issues, "; "),
+					"reason", result.Reason,
+				)
+		}
+		return nil
+	})
+	if err != nil {
+		return repaired, unresolved, apperrors.Wrap("failed to repair guard markers", err, outputDir)
+	}
+
+	return repaired, unresolved, nil
+}
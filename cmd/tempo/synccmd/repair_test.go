@@ -0,0 +1,103 @@
+package synccmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	repairBegin = "/* [tempo] BEGIN - Do not edit! This section is auto-generated. */"
+	repairEnd   = "/* [tempo] END */"
+)
+
+func TestRepairGuardMarkers(t *testing.T) {
+	outputDir := t.TempDir()
+
+	files := map[string]string{
+		"button/button.templ":  "<style>\n" + repairBegin + "\n</style>",
+		"card/card.templ":      "templ Card() {\n" + repairEnd + "\n}",
+		"valid/valid.templ":    "<style>\n" + repairBegin + "\n" + repairEnd + "\n</style>",
+		"button/notes.txt":     "<style>\n" + repairBegin + "\n</style>",
+		"nomarkers/none.templ": "templ None() {}",
+	}
+	for name, content := range files {
+		testutils.CreateFile(t, filepath.Join(outputDir, name), content)
+	}
+
+	mockLogger := &testutils.MockLogger{}
+	repaired, unresolved, err := repairGuardMarkers(mockLogger, outputDir, "tempo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(repaired) != 1 || !strings.HasSuffix(repaired[0], filepath.Join("button", "button.templ")) {
+		t.Errorf("Expected button.templ to be repaired, got %v", repaired)
+	}
+	if len(unresolved) != 1 || !strings.HasSuffix(unresolved[0], filepath.Join("card", "card.templ")) {
+		t.Errorf("Expected card.templ to be unresolved, got %v", unresolved)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "button", "button.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read repaired file: %v", err)
+	}
+	if string(content) != "<style>\n"+repairBegin+"\n"+repairEnd+"\n</style>" {
+		t.Errorf("Unexpected repaired content:\n%s", string(content))
+	}
+
+	notes, _ := os.ReadFile(filepath.Join(outputDir, "button", "notes.txt"))
+	if string(notes) != files["button/notes.txt"] {
+		t.Errorf("Expected non-templ files to be left untouched")
+	}
+
+	logs := strings.Join(mockLogger.Logs, "\n")
+	if !strings.Contains(logs, "Repaired guard markers") || !strings.Contains(logs, "Cannot repair guard markers") {
+		t.Errorf("Expected repair logs, got:\n%s", logs)
+	}
+}
+
+func TestSyncCommand_Repair(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	testutils.CreateFile(t, filepath.Join(cfg.App.AssetsDir, "button.css"), ".button { color: red; }")
+	templPath := filepath.Join(cfg.App.GoPackage, "button.templ")
+	testutils.CreateFile(t, templPath, "templ Button() {\n<style>\n"+repairBegin+"\n</style>\n}")
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupSyncCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    tempDir,
+			}),
+		},
+	}
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), []string{"tempo", "sync", "--repair", "--summary", "none"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, output, []string{"Repaired guard markers", "Guard markers repaired in 1 file(s)"})
+	testutils.VerifyFileContent(t, templPath, ".button { color: red; }")
+}
@@ -0,0 +1,49 @@
+package synccmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/worker"
+)
+
+// DefaultStdinFilepath is the asset path assumed for the content read with '--stdin'.
+const DefaultStdinFilepath = "stdin.css"
+
+// runStdinSync processes the asset read from r as the worker pool would process the file
+// at assetPath, and writes the result to w: templPath with the asset injected between its
+// guard markers, or only the guarded block when templPath is empty. No file is written
+// and the sync cache is left untouched.
+func runStdinSync(opts worker.WorkerPoolOptions, assetPath, templPath string, r io.Reader, w io.Writer) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return apperrors.Wrap("failed to read the asset from stdin", err)
+	}
+
+	var templContent []byte
+	if templPath != "" {
+		templContent, err = os.ReadFile(templPath)
+		if err != nil {
+			return apperrors.Wrap("failed to read the templ file", err, templPath)
+		}
+	}
+
+	factory := &processor.ProcessorFactory{
+		Production:   opts.IsProduction,
+		Watermark:    opts.Watermark,
+		InputDir:     opts.InputDir,
+		Transformers: opts.Transformers,
+		Guards:       opts.Guards,
+		JS:           opts.JSInjector,
+	}
+	result, err := factory.ProcessContent(assetPath, string(content), string(templContent), opts.MarkerName)
+	if err != nil {
+		return apperrors.Wrap("failed to process the asset read from stdin", err, assetPath)
+	}
+
+	_, err = fmt.Fprint(w, result)
+	return err
+}
@@ -0,0 +1,78 @@
+package synccmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/indaco/tempo/internal/watermark"
+	"github.com/indaco/tempo/internal/worker"
+)
+
+func TestRunStdinSync(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "assets")
+	startMarker, endMarker := processor.GuardMarkers("build")
+
+	wm, err := watermark.New("Generated for {{ .ComponentName }}", nil)
+	if err != nil {
+		t.Fatalf("Failed to create watermark: %v", err)
+	}
+	opts, err := worker.NewWorkerPoolOptions(context.Background(), inputDir, filepath.Join(tempDir, "components"),
+		worker.WithMarkerName("build"),
+		worker.WithWatermark(wm),
+		worker.WithProduction(true),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create worker pool options: %v", err)
+	}
+	assetPath := filepath.Join(inputDir, "button", "css", "base.css")
+
+	t.Run("Block only", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := runStdinSync(opts, assetPath, "", strings.NewReader(".btn {\n  color: red;\n}"), &out); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		result := out.String()
+		if !strings.HasPrefix(result, startMarker+"\n") || !strings.HasSuffix(result, endMarker+"\n") {
+			t.Errorf("Expected the block to be delimited by the guard markers, got:\n%s", result)
+		}
+		testutils.ValidateCLIOutput(t, result, []string{"Generated for button", ".btn{color:red}"})
+	})
+
+	t.Run("Templ file", func(t *testing.T) {
+		templPath := filepath.Join(tempDir, "components", "button", "button.templ")
+		templContent := "templ Button() {\n<style>\n" + startMarker + "\n" + endMarker + "\n</style>\n}\n"
+		testutils.CreateFile(t, templPath, templContent)
+
+		var out bytes.Buffer
+		if err := runStdinSync(opts, assetPath, templPath, strings.NewReader(".btn { color: red; }"), &out); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		result := out.String()
+		if !strings.HasPrefix(result, "templ Button() {\n<style>\n") || !strings.Contains(result, ".btn{color:red}") {
+			t.Errorf("Expected the asset injected into the templ content, got:\n%s", result)
+		}
+		if content, err := os.ReadFile(templPath); err != nil || string(content) != templContent {
+			t.Errorf("Expected the templ file to be left untouched, got %q (%v)", content, err)
+		}
+	})
+
+	t.Run("Missing templ file", func(t *testing.T) {
+		var out bytes.Buffer
+		err := runStdinSync(opts, assetPath, filepath.Join(tempDir, "missing.templ"), strings.NewReader(".btn{}"), &out)
+		if err == nil {
+			t.Fatal("Expected an error for a missing templ file")
+		}
+		if out.Len() != 0 {
+			t.Errorf("Expected no output on error, got %q", out.String())
+		}
+	})
+}
@@ -1,24 +1,38 @@
 package synccmd
 
 import (
+	"archive/zip"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
 	"github.com/indaco/tempo/internal/app"
 	"github.com/indaco/tempo/internal/apperrors"
 	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/git"
 	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/layout"
 	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/progress"
 	"github.com/indaco/tempo/internal/resolver"
-	"github.com/indaco/tempo/internal/utils"
 	"github.com/indaco/tempo/internal/worker"
 	"github.com/urfave/cli/v3"
 	"golang.org/x/sync/errgroup"
 )
 
+// progressOutput is where the progress events of '--progress-format' are written.
+// Tests replace it.
+var progressOutput io.Writer = os.Stderr
+
 /* ------------------------------------------------------------------------- */
 /* Command Setup                                                             */
 /* ------------------------------------------------------------------------- */
@@ -32,7 +46,7 @@ func SetupSyncCommand(cmdCtx *app.AppContext) *cli.Command {
 		UseShortOptionHandling: true,
 		Flags:                  getFlags(),
 		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
-			return ctx, app.IsTempoProject(cmdCtx.CWD)
+			return ctx, cmdCtx.CheckTempoProject(cmdCtx.Config.App.RequiresGoModule())
 		},
 		Action: runSyncCommand(cmdCtx),
 	}
@@ -50,10 +64,14 @@ func getFlags() []cli.Flag {
 			Aliases: []string{"i"},
 			Usage:   "The directory containing asset files (e.g., CSS, JS) to be processed (default: assets)",
 		},
+		&cli.StringFlag{
+			Name:  "input-zip",
+			Usage: "Read the asset files from a zip bundle instead of the input directory: the bundle root stands for the input directory",
+		},
 		&cli.StringFlag{
 			Name:    "output",
-			Aliases: []string{"o"},
-			Usage:   "The directory containing the .templ component files where assets will be injected (default: components)",
+			Aliases: []string{"o", "output-dir"},
+			Usage:   "The directory containing the .templ component files where assets will be injected (default: components). Assets matching processor.outputs go to their rule folder",
 		},
 		&cli.StringFlag{
 			Name:    "exclude",
@@ -80,6 +98,18 @@ func getFlags() []cli.Flag {
 			Aliases: []string{"s"},
 			Usage:   "Summary format: compact, long, json, none (default: compact)",
 		},
+		&cli.BoolFlag{
+			Name:  "repair",
+			Usage: "Repair missing or duplicated guard markers in .templ files before processing",
+		},
+		&cli.IntFlag{
+			Name:  "retries",
+			Usage: "Retries of the files failing with transient file system errors, e.g. EBUSY (default: processor.retry.max_retries)",
+		},
+		&cli.BoolFlag{
+			Name:  "fail-fast",
+			Usage: "Stop processing on the first file that fails (default: process all files and report failures)",
+		},
 		&cli.BoolFlag{
 			Name:  "verbose",
 			Usage: "Show detailed information in the summary report",
@@ -88,11 +118,101 @@ func getFlags() []cli.Flag {
 			Name:  "track-time",
 			Usage: "Display execution time per processed file.",
 		},
+		&cli.BoolFlag{
+			Name:  "check",
+			Usage: "Only check the asset budgets set in processor.budgets, without syncing, and fail when a component exceeds one",
+		},
+		&cli.StringFlag{
+			Name:  "io-throttle",
+			Usage: "Limit the disk IO, in bytes (e.g. 5MB/s) or file operations (e.g. 200ops/s) per second, on shared CI runners (default: processor.io_throttle)",
+		},
+		&cli.StringFlag{
+			Name:  "symlinks",
+			Usage: "Symbolic links in the input folder: follow, skip, error (default: follow)",
+		},
+		&cli.StringFlag{
+			Name:  "marker",
+			Usage: "Name of the guard markers delimiting the injected content (default: templates.guard_marker)",
+		},
+		&cli.BoolFlag{
+			Name:  "stdin",
+			Usage: "Process a single asset read from stdin and write the injected templ content to stdout, without scanning the input folder",
+		},
+		&cli.StringFlag{
+			Name:  "stdin-filepath",
+			Value: DefaultStdinFilepath,
+			Usage: "Path of the asset read with --stdin, selecting its type and component name",
+		},
+		&cli.StringFlag{
+			Name:  "templ",
+			Usage: "With --stdin, .templ file the asset is injected into (left untouched); without it, only the guarded block is written",
+		},
+		&cli.BoolFlag{
+			Name:  "bench",
+			Usage: "Benchmark the worker pool on a synthetic asset tree in a temporary folder and report its throughput, without touching the project files",
+		},
+		&cli.IntFlag{
+			Name:  "bench-files",
+			Value: worker.DefaultBenchFiles,
+			Usage: "Number of synthetic asset files processed by --bench",
+		},
+		&cli.StringFlag{
+			Name:  "bench-size",
+			Usage: "Size of each synthetic asset file of --bench, e.g. 16KB (default: 4KB)",
+		},
+		&cli.StringFlag{
+			Name:  "bench-budget",
+			Usage: "Performance budget of --bench: fail when the run takes longer than the given duration (e.g. 500ms)",
+		},
 		&cli.StringFlag{
 			Name:    "report-file",
 			Aliases: []string{"rf"},
 			Usage:   "Export summary to a JSON file",
 		},
+		&cli.StringFlag{
+			Name:  "source-map",
+			Usage: "Export a JSON map from each injected .templ region to its source asset file and lines",
+		},
+		&cli.BoolFlag{
+			Name:  "overwrite-manual",
+			Usage: "Overwrite the guard regions edited by hand since the last sync (see templates.manual_edits)",
+		},
+		&cli.BoolFlag{
+			Name:  "interactive",
+			Usage: "Ask whether to keep or overwrite each guard region edited by hand, with a diff on demand; outside a terminal or in CI, templates.manual_edits applies",
+		},
+		helpers.YesFlag(),
+		&cli.StringFlag{
+			Name:  "progress-format",
+			Usage: "Stream one progress event per file (queued, started, done, skipped, error) to stderr: none, ndjson (default: none)",
+		},
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "Sync only the files changed since a git ref, e.g. origin/main, instead of the files modified since the last run",
+		},
+		&cli.BoolFlag{
+			Name:  "compare-last",
+			Usage: "Compare the summary with the last run: newly failing or skipped files and time regression",
+		},
+		helpers.TagFlag(),
+		helpers.ExcludeTagFlag(),
+		&cli.StringSliceFlag{
+			Name:  "fail-on-skip",
+			Usage: "Fail when files are skipped for one of the given reasons: excluded, unchanged, minified, ignored, missing-output, conflict, queue-full",
+		},
+		&cli.BoolFlag{
+			Name:  "git-add",
+			Usage: "Stage the files created or modified by the command",
+		},
+		&cli.BoolFlag{
+			Name:  "git-commit",
+			Usage: "Stage and commit the files created or modified by the command",
+		},
+		&cli.StringFlag{
+			Name:    "message",
+			Aliases: []string{"m"},
+			Usage:   "Commit message used with --git-commit (default: \"" + helpers.DefaultGitCommitMessage + "\")",
+		},
 	}
 }
 
@@ -110,17 +230,110 @@ func runSyncCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.C
 			return err
 		}
 
+		// Single-file mode: nothing else is read or written
+		if cmd.Bool("stdin") {
+			return runStdinSync(opts, cmd.String("stdin-filepath"), cmd.String("templ"), os.Stdin, os.Stdout)
+		}
+
+		// Benchmark mode: the pipeline runs on a synthetic asset tree
+		if cmd.Bool("bench") {
+			defer helpers.ResetLogger(cmdCtx.Logger)
+			return runBenchSync(ctx, cmdCtx.Logger, cmd, opts, summaryOpts.Format, os.Stdout)
+		}
+
+		// Zip bundle mode: the assets are read from the bundle, mapped to the input folder
+		inputDir := opts.InputDir
+		if bundlePath := cmd.String("input-zip"); bundlePath != "" {
+			bundle, err := zip.OpenReader(bundlePath)
+			if err != nil {
+				return apperrors.Wrap("failed to open the input zip bundle", err, bundlePath)
+			}
+			defer bundle.Close()
+			opts.InputFS = bundle
+			inputDir = ""
+		}
+
+		// Git mode: only the files changed since the ref are processed
+		if ref := cmd.String("since"); ref != "" {
+			if opts.InputFS != nil {
+				return apperrors.Wrap("'--since' cannot be used with '--input-zip'")
+			}
+			changed, err := git.ChangedSince(cmdCtx.CWD, ref)
+			if err != nil {
+				return err
+			}
+			worker.WithChangedFiles(changed)(&opts)
+		}
+
 		// Step 2: Check prerequisites
-		if err := validateSyncPrerequisites(opts.InputDir, opts.OutputDir); err != nil {
+		missingFolders, err := validateSyncPrerequisites(cmdCtx.Config, cmdCtx.Logger, inputDir, opts.OutputDir)
+		if err != nil {
+			return err
+		}
+		if _, missing := missingFolders["input_dir"]; missing {
+			cmdCtx.Logger.Info("Nothing to sync: the input folder is missing").WithAttrs("input_dir", inputDir)
+			helpers.ResetLogger(cmdCtx.Logger)
+			return nil
+		}
+
+		budgets := cmdCtx.Config.Processor.Budgets
+		budget, err := worker.ParseAssetBudget(budgets.CSS, budgets.JS, budgets.OnExceed)
+		if err != nil {
+			return err
+		}
+
+		// Check mode: only the asset budgets are checked
+		if cmd.Bool("check") {
+			defer helpers.ResetLogger(cmdCtx.Logger)
+			return checkBudgets(cmdCtx, opts, budget, true, os.Stdout)
+		}
+
+		// Step 3: Repair guard markers if requested
+		// Repaired files have empty markers, so force processing of their inputs to refill them
+		if cmd.Bool("repair") {
+			repaired, unresolved, err := repairGuardMarkers(cmdCtx.Logger, opts.OutputDir, cmdCtx.Config.Templates.GuardMarker)
+			if err != nil {
+				return err
+			}
+			if len(unresolved) > 0 {
+				cmdCtx.Logger.Warning(fmt.Sprintf("%d file(s) need a manual fix of the guard markers", len(unresolved)))
+			}
+			if len(repaired) > 0 {
+				cmdCtx.Logger.Success(fmt.Sprintf("Guard markers repaired in %d file(s)", len(repaired)))
+				worker.WithForcedOutputs(repaired)(&opts)
+			}
+		}
+
+		// Step 4: Run file processing, tracking the changed files for git
+		snapshot, err := helpers.StartGitTracking(cmdCtx.CWD, cmd.Bool("git-add"), cmd.Bool("git-commit"))
+		if err != nil {
 			return err
 		}
 
-		// Step 3: Run file processing
+		conflicts := newConflictResolver(cmd, helpers.AssumeYes(cmd, cmdCtx.Config), cmdCtx.Logger, opts.Checksum, os.Stdout)
 		cmdCtx.Logger.Info("Processing files...")
-		if err := runWorkerPool(cmdCtx, opts, summaryOpts); err != nil {
+		if err := runWorkerPool(cmdCtx, opts, summaryOpts, cmd.String("source-map"), conflicts); err != nil {
 			return apperrors.Wrap("failed processing files", err)
 		}
 		cmdCtx.Logger.Success("Processing completed successfully without errors.")
+
+		// Record the layout, so that 'tempo migrate paths' can move the files if it changes
+		if opts.InputFS == nil {
+			manifest := layout.ManifestPath(cmdCtx.Config.TempoRoot)
+			if err := layout.Save(manifest, layout.Layout{AssetsDir: opts.InputDir, GoPackage: opts.OutputDir}); err != nil {
+				cmdCtx.Logger.Warning("Failed to record the project layout").WithAttrs("error", err.Error())
+			}
+		}
+
+		// Step 5: Check the asset budgets of the components
+		if err := checkBudgets(cmdCtx, opts, budget, false, os.Stdout); err != nil {
+			return err
+		}
+
+		// Step 6: Stage or commit the changed files
+		if err := helpers.FinishGitTracking(snapshot, cmd.Bool("git-commit"), cmd.String("message"), cmdCtx.Logger); err != nil {
+			return err
+		}
 		helpers.ResetLogger(cmdCtx.Logger)
 
 		return nil
@@ -132,28 +345,20 @@ func runSyncCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.C
 /* ------------------------------------------------------------------------- */
 
 // validateSyncPrerequisites checks prerequisites for the "run" command, including:
-// - Existence of the input folder
+// - Existence of the input folder, unless empty when the assets come from a zip bundle
 // - Existence of the output folder
-func validateSyncPrerequisites(inputDir, outputDir string) error {
+//
+// When strict mode is disabled, the missing folders are returned with a warning instead
+// of an error.
+func validateSyncPrerequisites(cfg *config.Config, log logger.Logger, inputDir, outputDir string) (map[string]string, error) {
 	foldersToCheck := map[string]string{
-		"input_dir":  inputDir,
 		"output_dir": outputDir,
 	}
-
-	missingFolders, err := utils.CheckMissingFolders(foldersToCheck)
-	if err != nil {
-		return err
-	}
-
-	if len(missingFolders) > 0 {
-		return helpers.BuildMissingFoldersError(
-			missingFolders,
-			"",
-			[]string{},
-		)
+	if inputDir != "" {
+		foldersToCheck["input_dir"] = inputDir
 	}
 
-	return nil
+	return helpers.CheckPrerequisiteFolders(cfg, log, foldersToCheck, "", []string{})
 }
 
 /* ------------------------------------------------------------------------- */
@@ -165,9 +370,11 @@ func runWorkerPool(
 	cmdCtx *app.AppContext,
 	opts worker.WorkerPoolOptions,
 	summaryOpts *worker.SummaryOptions,
+	sourceMapFile string,
+	conflicts *conflictResolver,
 ) error {
-	cacheFile := filepath.Join(cmdCtx.CWD, ".tempo-lastrun")
-	lastRunTimestamp := getLastRunTimestamp(cacheFile)
+	cacheFile := filepath.Join(cmdCtx.CWD, worker.LastRunFile)
+	lastRunTimestamp := worker.ReadLastRunTimestamp(cacheFile)
 
 	// Initialize worker pool manager
 	manager := worker.NewWorkerPoolManager(opts)
@@ -192,6 +399,8 @@ func runWorkerPool(
 			skippedFiles = append(skippedFiles, skip)
 			skipMu.Unlock()
 
+			manager.Progress.Emit(progress.Record{Event: progress.EventSkipped, File: skip.Source, Dest: skip.Dest, Reason: skip.Reason})
+
 			manager.Metrics.IncrementSkippedFile()
 		}
 		return nil
@@ -203,23 +412,24 @@ func runWorkerPool(
 			errMu.Lock()
 			collectedErrors = append(collectedErrors, err)
 			errMu.Unlock()
+
+			manager.Progress.Emit(progress.Record{Event: progress.EventError, File: err.Source, Dest: err.Dest, Error: err.Message})
 		}
 		return nil
 	})
 
 	// Queue files for processing before closing job channel & starting workers
-	if err := queueFilesForProcessing(cmdCtx.Logger, opts, manager, lastRunTimestamp); err != nil {
+	jobs, err := queueFilesForProcessing(cmdCtx.Logger, opts, manager, lastRunTimestamp)
+	if err != nil {
 		return apperrors.Wrap("Failed to queue files", err)
 	}
 
 	// Close job channel before starting workers
 	close(manager.JobChan)
 
-	// Start workers
-	err := manager.StartWorkers(opts.Context, opts.NumWorkers, opts.IsTrackExecutionTime)
-	if err != nil {
-		return apperrors.Wrap("error starting the WorkerPoolManager", err)
-	}
+	// Start workers. With fail-fast, the first processing error stops the pool;
+	// the summary below still reports the partial results collected so far.
+	workersErr := manager.StartWorkers(opts.Context, opts.NumWorkers, opts.IsTrackExecutionTime)
 
 	// Close channels after all workers finish
 	close(manager.ErrorsChan)
@@ -230,19 +440,52 @@ func runWorkerPool(
 		return apperrors.Wrap("Failed while collecting skipped/errors", err)
 	}
 
+	// Prompt for the guard regions edited by hand, refused by the workers
+	if conflicts != nil {
+		collectedErrors, skippedFiles = conflicts.Resolve(manager, jobs, collectedErrors, skippedFiles)
+	}
+
 	// Use the stored skipped files
 	manager.Metrics.SkippedFiles = len(skippedFiles)
 
-	if err := saveLastRunTimestamp(cacheFile); err != nil {
-		return apperrors.Wrap("Failed to update last run timestamp", err)
+	// Keep the last run timestamp untouched on abort so unprocessed files are picked up next time
+	if workersErr == nil {
+		if err := worker.SaveLastRunTimestamp(cacheFile); err != nil {
+			return apperrors.Wrap("Failed to update last run timestamp", err)
+		}
 	}
 
 	// Handle Summary
+	run := manager.Metrics.RunSummary(collectedErrors, skippedFiles)
+	skippedByReason := worker.CountSkipReasons(skippedFiles)
+	cmdCtx.Summary = map[string]any{
+		"files_processed":   run.FilesProcessed,
+		"failed":            len(run.Failed),
+		"skipped":           len(run.Skipped),
+		"skipped_by_reason": skippedByReason,
+		"elapsed_ms":        run.Elapsed.Milliseconds(),
+	}
 	if err := handleSummary(cmdCtx.Logger, manager, collectedErrors, skippedFiles, summaryOpts); err != nil {
 		return err
 	}
 
-	return nil
+	// Compare with the last run and persist the summary for the next one
+	if err := handleRunHistory(cmdCtx.Logger, manager, collectedErrors, skippedFiles, summaryOpts); err != nil {
+		return err
+	}
+
+	// Export the source map, including the files unchanged since the last run
+	if sourceMapFile != "" {
+		if err := handleSourceMap(cmdCtx.Logger, jobs, opts.MarkerName, sourceMapFile, manager.Input); err != nil {
+			return err
+		}
+	}
+
+	if workersErr != nil {
+		return apperrors.Wrap("worker pool stopped before processing all files", workersErr)
+	}
+
+	return checkSkipReasons(skippedByReason, summaryOpts.FailOnSkip)
 }
 
 /* ------------------------------------------------------------------------- */
@@ -250,17 +493,42 @@ func runWorkerPool(
 /* ------------------------------------------------------------------------- */
 
 // queueFilesForProcessing walks through the input directory and enqueues jobs.
+// Input files targeting the same output file are skipped as conflicts, so that
+// workers never write the same .templ file concurrently.
+// It returns all the candidate jobs, whether they have been enqueued or skipped.
 func queueFilesForProcessing(
 	log logger.Logger,
 	opts worker.WorkerPoolOptions,
 	manager *worker.WorkerPoolManager,
 	lastRunTimestamp int64,
-) error {
-	return filepath.WalkDir(opts.InputDir, func(source string, d os.DirEntry, err error) error {
+) ([]worker.Job, error) {
+	// Step 1: Collect the candidate jobs
+	var candidates []worker.Job
+	walk := func(fn fs.WalkDirFunc) error {
+		if opts.InputFS != nil {
+			return worker.WalkInputFS(opts.InputFS, opts.InputDir, fn)
+		}
+		return worker.WalkInputDir(opts.InputDir, opts.SymlinkPolicy, fn)
+	}
+	err := walk(func(source string, d os.DirEntry, err error) error {
+		if reason, ok := symlinkSkipReason(err); ok {
+			handleSkip(log, manager.SkippedChan, worker.SkippedFile{
+				Source:    source,
+				InputDir:  opts.InputDir,
+				OutputDir: opts.OutputDir,
+				Reason:    reason,
+				SkipType:  worker.SkipSymlink,
+			})
+			return nil
+		}
 		if err != nil {
 			handleError(log, manager, source, err)
 			return nil
 		}
+		// Dependencies installed by 'tempo js install' are not component assets
+		if d.IsDir() && d.Name() == "node_modules" {
+			return filepath.SkipDir
+		}
 
 		absPath, err := filepath.Abs(source)
 		if err != nil {
@@ -280,21 +548,73 @@ func queueFilesForProcessing(
 			return nil
 		}
 
-		outputFilePath := utils.RebasePathToOutput(source, opts.InputDir, opts.OutputDir)
-		if !d.IsDir() && shouldProcessFile(log, source, outputFilePath, opts, lastRunTimestamp, manager) {
-			if !enqueueJob(manager, source, outputFilePath) {
+		// The components left out by the tag filters are not synced at all
+		if !d.IsDir() && !opts.Tags.Match(worker.ComponentName(opts.InputDir, source)) {
+			return nil
+		}
+
+		if !d.IsDir() {
+			outputFilePath, region := worker.OutputTarget(source, opts.InputDir, opts.OutputDir, opts.OutputRules)
+			reason, err := opts.InputSkipper.SkipReason(source, opts.InputDir, manager.Input, opts.Languages)
+			if err != nil {
+				handleError(log, manager, source, err)
+				return nil
+			}
+			if reason != "" {
 				handleSkip(log, manager.SkippedChan, worker.SkippedFile{
 					Source:    source,
 					Dest:      outputFilePath,
 					InputDir:  opts.InputDir,
 					OutputDir: opts.OutputDir,
-					Reason:    "Job queue is full. Increase workers.",
-					SkipType:  worker.SkipQueueFull,
+					Reason:    reason,
+					SkipType:  worker.SkipPrebuilt,
 				})
+				return nil
 			}
+			candidates = append(candidates, worker.Job{InputPath: source, OutputPath: outputFilePath, Region: region})
 		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Detect input files sharing the same output file
+	conflicts := worker.FindOutputConflicts(candidates, opts.Languages)
+	for _, output := range slices.Sorted(maps.Keys(conflicts)) {
+		log.Warning("Output file matched by several input files").
+			WithAttrs("output", output, "inputs", strings.Join(conflicts[output], ", "))
+	}
+
+	// Step 3: Enqueue the jobs
+	for _, job := range candidates {
+		if inputs, ok := conflicts[job.Target()]; ok && slices.Contains(inputs, job.InputPath) {
+			handleSkip(log, manager.SkippedChan, worker.SkippedFile{
+				Source:    job.InputPath,
+				Dest:      job.OutputPath,
+				InputDir:  opts.InputDir,
+				OutputDir: opts.OutputDir,
+				Reason:    fmt.Sprintf("Output file also matched by: %s", strings.Join(otherInputs(inputs, job.InputPath), ", ")),
+				SkipType:  worker.SkipOutputConflict,
+			})
+			continue
+		}
+
+		if shouldProcessFile(log, job.InputPath, job.OutputPath, opts, lastRunTimestamp, manager) {
+			if !enqueueJob(manager, job) {
+				handleSkip(log, manager.SkippedChan, worker.SkippedFile{
+					Source:    job.InputPath,
+					Dest:      job.OutputPath,
+					InputDir:  opts.InputDir,
+					OutputDir: opts.OutputDir,
+					Reason:    "Job queue is full. Increase workers.",
+					SkipType:  worker.SkipQueueFull,
+				})
+			}
+		}
+	}
+
+	return candidates, nil
 }
 
 /* ------------------------------------------------------------------------- */
@@ -332,21 +652,63 @@ func resolveSyncFlags(
 	isProd := cmd.Bool("prod")
 	isForce := cmd.Bool("force")
 	isTrackExecutionTime := cmd.Bool("track-time")
+	isFailFast := cmd.Bool("fail-fast")
 
 	numWorkers, err := resolver.ResolveInt(cmd.String("workers"), cmdCtx.Config.Processor.Workers, "workers")
 	if err != nil {
 		return worker.WorkerPoolOptions{}, nil, err
 	}
 
+	symlinkPolicy, err := resolver.ResolveString(
+		cmd.String("symlinks"),
+		cmdCtx.Config.Processor.Symlinks,
+		"symlinks",
+		worker.DefaultSymlinkPolicy,
+		worker.SymlinkPolicies,
+	)
+	if err != nil {
+		return worker.WorkerPoolOptions{}, nil, err
+	}
+
+	var retries *int
+	if cmd.IsSet("retries") {
+		n := int(cmd.Int("retries"))
+		retries = &n
+	}
+
+	log := cmdCtx.Logger
+	configOpts, err := worker.ConfigOptions(ctx, cmdCtx.Config, inputDir, worker.ConfigOverrides{
+		Marker:               cmd.String("marker"),
+		Symlinks:             symlinkPolicy,
+		IOThrottle:           cmd.String("io-throttle"),
+		Retries:              retries,
+		IncludeTags:          cmd.StringSlice("tag"),
+		ExcludeTags:          cmd.StringSlice("exclude-tag"),
+		OverwriteManualEdits: cmd.Bool("overwrite-manual"),
+		OnManualEdit: func(outputFilePath string) {
+			log.Warning("Overwriting a guard region edited by hand since the last sync").
+				WithAttrs("file", outputFilePath)
+		},
+	})
+	if err != nil {
+		return worker.WorkerPoolOptions{}, nil, err
+	}
+
+	reporter, err := progress.New(cmd.String("progress-format"), progressOutput)
+	if err != nil {
+		return worker.WorkerPoolOptions{}, nil, err
+	}
+
 	// Worker pool options
-	opts, err := worker.NewWorkerPoolOptions(ctx, inputDir, outputDir,
+	opts, err := worker.NewWorkerPoolOptions(ctx, inputDir, outputDir, append(configOpts,
 		worker.WithExcludeDir(excludeDir),
-		worker.WithMarkerName(cmdCtx.Config.Templates.GuardMarker),
+		worker.WithProgress(reporter),
 		worker.WithNumWorkers(numWorkers),
 		worker.WithProduction(isProd),
 		worker.WithForce(isForce),
+		worker.WithFailFast(isFailFast),
 		worker.WithTrackExecutionTime(isTrackExecutionTime),
-	)
+	)...)
 	if err != nil {
 		return worker.WorkerPoolOptions{}, nil, apperrors.Wrap("invalid worker pool options", err)
 	}
@@ -365,11 +727,20 @@ func resolveSyncFlags(
 
 	reportFile := cmd.String("report-file")
 	isVerboseSummary := cmd.Bool("verbose")
+	isCompareLast := cmd.Bool("compare-last")
+
+	failOnSkip, err := worker.ParseSkipReasons(cmd.StringSlice("fail-on-skip"))
+	if err != nil {
+		return worker.WorkerPoolOptions{}, nil, err
+	}
 
 	summaryOpts := &worker.SummaryOptions{
-		Format:     worker.SummaryFormat(summaryFormat),
-		ReportFile: reportFile,
-		IsVerbose:  isVerboseSummary,
+		Format:      worker.SummaryFormat(summaryFormat),
+		ReportFile:  reportFile,
+		IsVerbose:   isVerboseSummary,
+		CompareLast: isCompareLast,
+		HistoryFile: filepath.Join(cmdCtx.Config.TempoRoot, worker.LastSummaryFile),
+		FailOnSkip:  failOnSkip,
 	}
 
 	return opts, summaryOpts, nil
@@ -406,6 +777,57 @@ func handleSummary(
 	return nil
 }
 
+// handleRunHistory prints the changes since the run saved in the history file when
+// requested, then saves the current run in its place.
+func handleRunHistory(
+	logger logger.Logger,
+	manager *worker.WorkerPoolManager,
+	processingErrors []worker.ProcessingError,
+	skippedFiles []worker.ProcessingError,
+	summaryOpts *worker.SummaryOptions,
+) error {
+	historyFile := summaryOpts.HistoryFile
+	if historyFile == "" {
+		return nil
+	}
+
+	current := manager.Metrics.RunSummary(processingErrors, skippedFiles)
+
+	if summaryOpts.CompareLast {
+		previous, found, err := worker.LoadRunSummary(historyFile)
+		switch {
+		case err != nil:
+			logger.Warning("Cannot read the last sync summary, skipping comparison").
+				WithAttrs("file", historyFile, "error", err.Error())
+		case !found:
+			logger.Info("No previous sync summary to compare with. It will be available after this run.")
+		default:
+			logger.Default(worker.CompareRunSummaries(previous, current).String())
+		}
+	}
+
+	if err := worker.SaveRunSummary(historyFile, current); err != nil {
+		return apperrors.Wrap("Failed to save the sync summary", err, historyFile)
+	}
+	return nil
+}
+
+// handleSourceMap builds the source map for the jobs and writes it to sourceMapFile.
+func handleSourceMap(logger logger.Logger, jobs []worker.Job, markerName, sourceMapFile string, input *processor.InputFS) error {
+	sourceMap, err := worker.BuildSourceMap(jobs, markerName, input)
+	if err != nil {
+		return apperrors.Wrap("Failed to build the source map", err)
+	}
+
+	if err := worker.SaveSourceMap(sourceMapFile, sourceMap); err != nil {
+		return apperrors.Wrap("Failed to export the source map", err, sourceMapFile)
+	}
+
+	logger.Info(fmt.Sprintf("Source map exported with %d entries", len(sourceMap.Entries))).
+		WithAttrs("file", sourceMapFile)
+	return nil
+}
+
 // handleError sends errors to the error channel.
 // With large buffer sizes (numWorkers * 100), blocking is unlikely.
 // Uses non-blocking send as a safety fallback; logs a warning if the buffer is full.
@@ -432,6 +854,36 @@ func handleSkip(log logger.Logger, ch chan<- worker.ProcessingError, skipped wor
 	}
 }
 
+// symlinkSkipReason returns the skip reason of a symbolic link left out by the walk
+// of the input folder, and false for any other error.
+func symlinkSkipReason(err error) (string, bool) {
+	switch {
+	case errors.Is(err, worker.ErrSymlinkSkipped):
+		return "Symbolic link skipped by the symlink policy", true
+	case errors.Is(err, worker.ErrSymlinkCycle):
+		return "Symbolic link cycle: the target contains the linking folder", true
+	case errors.Is(err, worker.ErrSymlinkDuplicate):
+		return "Symbolic link target already in the input folder", true
+	default:
+		return "", false
+	}
+}
+
+// checkSkipReasons returns an error listing the files skipped for one of the failing
+// reasons of '--fail-on-skip'.
+func checkSkipReasons(counts map[worker.SkipReason]int, failOn []worker.SkipReason) error {
+	var found []string
+	for i, reason := range failOn {
+		if counts[reason] > 0 && !slices.Contains(failOn[:i], reason) {
+			found = append(found, fmt.Sprintf("%s: %d", reason, counts[reason]))
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	return apperrors.WrapCode(apperrors.CodeSkippedFiles, "files skipped for a failing reason (%s)", strings.Join(found, ", "))
+}
+
 // shouldExcludeDir checks if the current path should be excluded.
 func shouldExcludeDir(excludeDir, absPath string) bool {
 	return excludeDir != "" && strings.HasPrefix(absPath, excludeDir)
@@ -451,7 +903,15 @@ func shouldProcessFile(log logger.Logger, source, dest string, opts worker.Worke
 		return false
 	}
 
-	lastModified, err := getFileLastModifiedTime(source)
+	if isForcedOutput(dest, opts) {
+		return true
+	}
+
+	if opts.ChangedFiles != nil {
+		return isChangedFile(log, source, dest, opts, manager)
+	}
+
+	lastModified, err := getFileLastModifiedTime(manager.Input, source)
 	if err != nil {
 		handleError(log, manager, source, err)
 		return false
@@ -471,10 +931,65 @@ func shouldProcessFile(log logger.Logger, source, dest string, opts worker.Worke
 	return true
 }
 
+// getFileLastModifiedTime retrieves the last modified timestamp of a given input file,
+// read from input (nil for the OS file system).
+func getFileLastModifiedTime(input *processor.InputFS, filePath string) (int64, error) {
+	info, err := input.Stat(filePath)
+	if err != nil {
+		return 0, err
+	}
+	return info.ModTime().Unix(), nil
+}
+
+// isChangedFile decides whether the file should be processed in git mode, where the
+// files unchanged since the git ref are skipped.
+func isChangedFile(log logger.Logger, source, dest string, opts worker.WorkerPoolOptions, manager *worker.WorkerPoolManager) bool {
+	absPath, err := filepath.Abs(source)
+	if err != nil {
+		handleError(log, manager, source, err)
+		return false
+	}
+
+	if !opts.IsProduction && !opts.IsForce && !opts.ChangedFiles[absPath] {
+		handleSkip(log, manager.SkippedChan, worker.SkippedFile{
+			Source:    source,
+			Dest:      dest,
+			InputDir:  opts.InputDir,
+			OutputDir: opts.OutputDir,
+			Reason:    "File unchanged since the git ref",
+			SkipType:  worker.SkipUnchangedFile,
+		})
+		return false
+	}
+	return true
+}
+
+// isForcedOutput reports whether dest is an output file processed regardless of the
+// modification times, e.g. after a guard marker repair.
+func isForcedOutput(dest string, opts worker.WorkerPoolOptions) bool {
+	if len(opts.ForcedOutputs) == 0 {
+		return false
+	}
+	absPath, err := filepath.Abs(dest)
+	return err == nil && opts.ForcedOutputs[absPath]
+}
+
+// otherInputs returns the inputs other than the given one.
+func otherInputs(inputs []string, input string) []string {
+	others := make([]string, 0, len(inputs)-1)
+	for _, in := range inputs {
+		if in != input {
+			others = append(others, in)
+		}
+	}
+	return others
+}
+
 // enqueueJob attempts to enqueue a job and returns success status.
-func enqueueJob(manager *worker.WorkerPoolManager, inputPath, outputPath string) bool {
+func enqueueJob(manager *worker.WorkerPoolManager, job worker.Job) bool {
 	select {
-	case manager.JobChan <- worker.Job{InputPath: inputPath, OutputPath: outputPath}:
+	case manager.JobChan <- job:
+		manager.Progress.Emit(progress.Record{Event: progress.EventQueued, File: job.InputPath, Dest: job.OutputPath})
 		return true
 	default:
 		return false
@@ -1,19 +1,27 @@
 package synccmd
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
 	"github.com/indaco/tempo/internal/config"
 	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/progress"
+	"github.com/indaco/tempo/internal/tags"
 	"github.com/indaco/tempo/internal/testutils"
 	"github.com/indaco/tempo/internal/utils"
 	"github.com/indaco/tempo/internal/worker"
@@ -21,7 +29,7 @@ import (
 )
 
 func TestSyncCommand(t *testing.T) {
-	tempDir := os.TempDir()
+	tempDir := t.TempDir()
 	inputDir := filepath.Join(tempDir, "input")
 	outputDir := filepath.Join(tempDir, "output")
 
@@ -176,7 +184,7 @@ func TestSyncWorkerPool_BasicExecution(t *testing.T) {
 	}
 
 	// Run worker pool
-	err := runWorkerPool(cmdCtx, opts, &worker.SummaryOptions{})
+	err := runWorkerPool(cmdCtx, opts, &worker.SummaryOptions{}, "", nil)
 	if err != nil {
 		t.Fatalf("Worker pool execution failed: %v", err)
 	}
@@ -226,7 +234,7 @@ func TestSyncWorkerPool_SummaryAsJSON(t *testing.T) {
 
 	// Capture JSON output
 	output, err := testutils.CaptureStdout(func() {
-		_ = runWorkerPool(cmdCtx, opts, &worker.SummaryOptions{Format: "json"})
+		_ = runWorkerPool(cmdCtx, opts, &worker.SummaryOptions{Format: "json"}, "", nil)
 	})
 	if err != nil {
 		t.Fatalf("Failed to capture stdout: %v", err)
@@ -249,6 +257,82 @@ func TestSyncWorkerPool_SummaryAsJSON(t *testing.T) {
 	t.Log("[DEBUG] JSON summary output validated successfully")
 }
 
+func TestSyncWorkerPool_SkipsPrebuiltFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	outputDir := filepath.Join(tempDir, "output")
+
+	cmdCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		CWD:    tempDir,
+	}
+
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "button.css"), ".btn {\n  color: black;\n}\n")
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "vendor.min.css"), ".a{color:red}")
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "bundle.js"), strings.Repeat("var a=1;", 100))
+	testutils.CreateFile(t, filepath.Join(outputDir, "button", "button.templ"), "/* [tempo] BEGIN */\n/* [tempo] END */")
+
+	skipper, err := worker.ConfigInputSkipper(config.SkipInputs{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := worker.WorkerPoolOptions{
+		Context:      ctx,
+		InputDir:     inputDir,
+		OutputDir:    outputDir,
+		MarkerName:   "tempo",
+		InputSkipper: skipper,
+		NumWorkers:   2,
+		IsProduction: true,
+	}
+
+	output, err := testutils.CaptureStdout(func() {
+		_ = runWorkerPool(cmdCtx, opts, &worker.SummaryOptions{Format: "json"}, "", nil)
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	var summary struct {
+		SkippedFiles map[string][]worker.ProcessingError `json:"skipped_files"`
+	}
+	if err := json.Unmarshal([]byte(output), &summary); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+
+	reasons := make(map[string]string)
+	for _, f := range summary.SkippedFiles["prebuilt_file"] {
+		reasons[filepath.Base(f.Source)] = f.Reason
+	}
+	expected := map[string]string{
+		"vendor.min.css": "Matches the skip_inputs pattern *.min.css",
+		"bundle.js":      "Minified content",
+	}
+	if !reflect.DeepEqual(reasons, expected) {
+		t.Errorf("Expected prebuilt files %v, got %v", expected, reasons)
+	}
+}
+
+func TestCheckSkipReasons(t *testing.T) {
+	counts := map[worker.SkipReason]int{worker.ReasonUnchanged: 4, worker.ReasonMissingOutput: 2}
+
+	if err := checkSkipReasons(counts, []worker.SkipReason{worker.ReasonConflict, worker.ReasonMinified}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	err := checkSkipReasons(counts, []worker.SkipReason{worker.ReasonMissingOutput, worker.ReasonUnchanged, worker.ReasonMissingOutput})
+	if apperrors.CodeOf(err) != apperrors.CodeSkippedFiles {
+		t.Fatalf("Expected a skipped files error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "missing-output: 2, unchanged: 4") {
+		t.Errorf("Expected the failing reasons in the error, got %v", err)
+	}
+}
+
 func TestSyncWorkerPool_SummaryToJSONFile(t *testing.T) {
 	t.Log("[DEBUG] Starting TestRunWorkerPool_SummaryToJSONFile")
 
@@ -282,7 +366,7 @@ func TestSyncWorkerPool_SummaryToJSONFile(t *testing.T) {
 	}
 
 	// Run worker pool with JSON file output
-	err := runWorkerPool(cmdCtx, opts, &worker.SummaryOptions{Format: "json", ReportFile: summaryFile})
+	err := runWorkerPool(cmdCtx, opts, &worker.SummaryOptions{Format: "json", ReportFile: summaryFile}, "", nil)
 	if err != nil {
 		t.Fatalf("Worker pool execution failed: %v", err)
 	}
@@ -314,6 +398,60 @@ func TestSyncWorkerPool_SummaryToJSONFile(t *testing.T) {
 	t.Log("[DEBUG] JSON summary file validated successfully")
 }
 
+func TestSyncWorkerPool_SourceMap(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	outputDir := filepath.Join(tempDir, "output")
+	sourceMapFile := filepath.Join(tempDir, "sourcemap.json")
+
+	cmdCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		CWD:    tempDir,
+	}
+
+	begin, end := processor.GuardMarkers("tempo")
+	testutils.CreateFile(t, filepath.Join(inputDir, "button.css"), ".a { color: red; }\n.b { color: blue; }\n")
+	testutils.CreateFile(t, filepath.Join(outputDir, "button.templ"), "<style>\n"+begin+"\n"+end+"\n</style>")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := worker.WorkerPoolOptions{
+		Context:    ctx,
+		InputDir:   inputDir,
+		OutputDir:  outputDir,
+		MarkerName: "tempo",
+		NumWorkers: 2,
+	}
+
+	if err := runWorkerPool(cmdCtx, opts, &worker.SummaryOptions{Format: worker.FormatNone}, sourceMapFile, nil); err != nil {
+		t.Fatalf("Worker pool execution failed: %v", err)
+	}
+
+	data, err := os.ReadFile(sourceMapFile)
+	if err != nil {
+		t.Fatalf("Expected source map file: %v", err)
+	}
+	var sourceMap worker.SourceMap
+	if err := json.Unmarshal(data, &sourceMap); err != nil {
+		t.Fatalf("Invalid source map: %v", err)
+	}
+
+	if len(sourceMap.Entries) != 1 {
+		t.Fatalf("Expected 1 source map entry, got %+v", sourceMap.Entries)
+	}
+	entry := sourceMap.Entries[0]
+	if entry.Source != filepath.ToSlash(filepath.Join(inputDir, "button.css")) {
+		t.Errorf("Unexpected source: %s", entry.Source)
+	}
+	if entry.Region.Start != 3 || entry.Region.End < entry.Region.Start {
+		t.Errorf("Expected a non-empty region starting at line 3, got %+v", entry.Region)
+	}
+	if entry.SourceLines != (worker.LineRange{Start: 1, End: 2}) {
+		t.Errorf("Expected source lines 1-2, got %+v", entry.SourceLines)
+	}
+}
+
 func TestWorkerErrorHandling(t *testing.T) {
 	// Step 1: Create a channel to simulate errors
 	errorsChan := make(chan worker.ProcessingError, 3)
@@ -402,7 +540,7 @@ func TestValidateSyncPrerequisites(t *testing.T) {
 			}
 		}
 
-		err := validateSyncPrerequisites(inputDir, outputDir)
+		_, err := validateSyncPrerequisites(cfg, cmdCtx.Logger, inputDir, outputDir)
 		if err != nil {
 			t.Errorf("expected no error, but got: %v", err)
 		}
@@ -417,13 +555,27 @@ func TestValidateSyncPrerequisites(t *testing.T) {
 			t.Fatalf("failed to remove directory %s: %v", missingDir, err)
 		}
 
-		err := validateSyncPrerequisites(missingDir, outputDir)
+		_, err := validateSyncPrerequisites(cfg, cmdCtx.Logger, missingDir, outputDir)
 		if err == nil {
 			t.Errorf("expected an error due to missing folders, but got nil")
 		} else if !utils.ContainsSubstring(err.Error(), "Missing folders:") {
 			t.Errorf("expected error message to mention missing folders, but got: %v", err)
 		}
 	})
+
+	t.Run("Strict Mode Disabled", func(t *testing.T) {
+		strict := false
+		cfg.Strict = &strict
+		defer func() { cfg.Strict = nil }()
+
+		missing, err := validateSyncPrerequisites(cfg, cmdCtx.Logger, cmdCtx.Config.App.AssetsDir, cmdCtx.Config.App.GoPackage)
+		if err != nil {
+			t.Errorf("expected the missing folders to be warned about, but got: %v", err)
+		}
+		if _, ok := missing["input_dir"]; !ok {
+			t.Errorf("expected the missing input folder to be returned, got: %v", missing)
+		}
+	})
 }
 
 func TestResolveSyncFlags(t *testing.T) {
@@ -555,6 +707,72 @@ func TestResolveSyncFlags(t *testing.T) {
 	}
 }
 
+func TestResolveSyncFlags_Autoprefixer(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name         string
+		autoprefixer config.Autoprefixer
+		expected     []string
+		expectError  bool
+	}{
+		{
+			name:     "Disabled",
+			expected: nil,
+		},
+		{
+			name:         "Enabled",
+			autoprefixer: config.Autoprefixer{Enabled: true, Browsers: []string{"safari 13"}},
+			expected:     []string{processor.AutoprefixerName},
+		},
+		{
+			name:         "Invalid browsers",
+			autoprefixer: config.Autoprefixer{Enabled: true, Browsers: []string{"defaults"}},
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.App.AssetsDir = filepath.Join(tempDir, "assets")
+			cfg.App.GoPackage = filepath.Join(tempDir, "package")
+			cfg.Processor.Autoprefixer = tt.autoprefixer
+
+			cmdCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}
+
+			cliApp := &cli.Command{
+				Flags: getFlags(),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					opts, _, err := resolveSyncFlags(ctx, cmd, cmdCtx)
+					if tt.expectError {
+						if err == nil {
+							t.Errorf("expected error but got nil")
+						}
+						return nil
+					}
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+
+					var names []string
+					for _, transformer := range opts.Transformers {
+						names = append(names, transformer.Name)
+					}
+					if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+						t.Errorf("expected transformers %v, got %v", tt.expected, names)
+					}
+					return nil
+				},
+			}
+
+			if err := cliApp.Run(context.Background(), []string{"cmd"}); err != nil {
+				t.Fatalf("failed to run CLI app: %v", err)
+			}
+		})
+	}
+}
+
 func TestQueueFilesForProcessing_NonDirectory(t *testing.T) {
 	tempDir := t.TempDir()
 	// Create a file instead of a directory to use as InputDir.
@@ -571,7 +789,7 @@ func TestQueueFilesForProcessing_NonDirectory(t *testing.T) {
 	}
 	manager := worker.NewWorkerPoolManager(opts)
 	mockLog := &testutils.MockLogger{}
-	err := queueFilesForProcessing(mockLog, opts, manager, 0)
+	_, err := queueFilesForProcessing(mockLog, opts, manager, 0)
 	// Expect no error.
 	if err != nil {
 		t.Errorf("expected nil error when inputDir is not a directory, got: %v", err)
@@ -617,7 +835,7 @@ func TestQueueFilesForProcessing_ExcludedFiles(t *testing.T) {
 	}
 
 	mockLog := &testutils.MockLogger{}
-	err := queueFilesForProcessing(mockLog, opts, manager, 0)
+	_, err := queueFilesForProcessing(mockLog, opts, manager, 0)
 	if err != nil {
 		t.Errorf("expected nil error when processing inputDir, got: %v", err)
 	}
@@ -644,6 +862,58 @@ func TestQueueFilesForProcessing_ExcludedFiles(t *testing.T) {
 	}
 }
 
+func TestQueueFilesForProcessing_NodeModules(t *testing.T) {
+	tempDir := t.TempDir()
+	testutils.CreateFile(t, filepath.Join(tempDir, "button", "js", "script.js"), "export {};")
+	testutils.CreateFile(t, filepath.Join(tempDir, "button", "js", "node_modules", "dep", "index.js"), "export {};")
+
+	opts := worker.WorkerPoolOptions{
+		InputDir:   tempDir,
+		OutputDir:  t.TempDir(),
+		NumWorkers: 2,
+	}
+	manager := worker.NewWorkerPoolManager(opts)
+
+	candidates, err := queueFilesForProcessing(&testutils.MockLogger{}, opts, manager, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(candidates) != 1 || filepath.Base(candidates[0].InputPath) != "script.js" {
+		t.Errorf("Expected only the component script to be queued, got %+v", candidates)
+	}
+}
+
+func TestQueueFilesForProcessing_Tags(t *testing.T) {
+	tempDir := t.TempDir()
+	testutils.CreateFile(t, filepath.Join(tempDir, "button", "css", "base.css"), ".btn {}")
+	testutils.CreateFile(t, filepath.Join(tempDir, "text_input", "css", "base.css"), ".input {}")
+	testutils.CreateFile(t, filepath.Join(tempDir, "card", "css", "base.css"), ".card {}")
+	testutils.CreateFile(t, filepath.Join(tempDir, "global.css"), "body {}")
+
+	filter, err := tags.NewFilter(map[string][]string{
+		"button":     {"forms"},
+		"text_input": {"forms", "experimental"},
+	}, []string{"forms"}, []string{"experimental"}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	opts := worker.WorkerPoolOptions{
+		InputDir:   tempDir,
+		OutputDir:  t.TempDir(),
+		NumWorkers: 2,
+		Tags:       filter,
+	}
+	manager := worker.NewWorkerPoolManager(opts)
+
+	candidates, err := queueFilesForProcessing(&testutils.MockLogger{}, opts, manager, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(candidates) != 1 || worker.ComponentName(tempDir, candidates[0].InputPath) != "button" {
+		t.Errorf("Expected only the button CSS to be queued, got %+v", candidates)
+	}
+}
+
 func TestShouldProcessFile(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -651,6 +921,7 @@ func TestShouldProcessFile(t *testing.T) {
 	excludedFile := filepath.Join(tempDir, ".DS_Store")
 	oldFile := filepath.Join(tempDir, "old.js")
 	newFile := filepath.Join(tempDir, "new.js")
+	repairedOutput := filepath.Join(tempDir, "old.templ")
 
 	// Write dummy content to files
 	if err := os.WriteFile(excludedFile, []byte("content"), 0644); err != nil {
@@ -725,6 +996,40 @@ func TestShouldProcessFile(t *testing.T) {
 			expectedResult: true,
 			expectedSkip:   false,
 		},
+		{
+			name:           "Old file with a repaired output",
+			source:         oldFile,
+			dest:           repairedOutput,
+			opts:           worker.WorkerPoolOptions{ForcedOutputs: map[string]bool{repairedOutput: true}, NumWorkers: 1},
+			lastRun:        newTimestamp,
+			expectedResult: true,
+			expectedSkip:   false,
+		},
+		{
+			name:           "Old file with another repaired output",
+			source:         oldFile,
+			dest:           filepath.Join(tempDir, "other.templ"),
+			opts:           worker.WorkerPoolOptions{ForcedOutputs: map[string]bool{repairedOutput: true}, NumWorkers: 1},
+			lastRun:        newTimestamp,
+			expectedResult: false,
+			expectedSkip:   true,
+		},
+		{
+			name:           "Old file changed since the git ref",
+			source:         oldFile,
+			opts:           worker.WorkerPoolOptions{ChangedFiles: map[string]bool{oldFile: true}, NumWorkers: 1},
+			lastRun:        newTimestamp,
+			expectedResult: true,
+			expectedSkip:   false,
+		},
+		{
+			name:           "New file unchanged since the git ref",
+			source:         newFile,
+			opts:           worker.WorkerPoolOptions{ChangedFiles: map[string]bool{oldFile: true}, NumWorkers: 1},
+			lastRun:        oldTimestamp,
+			expectedResult: false,
+			expectedSkip:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -765,12 +1070,12 @@ func TestEnqueueJob(t *testing.T) {
 		JobChan: make(chan worker.Job, 1),
 	}
 	// Should succeed initially.
-	ok := enqueueJob(manager, "input", "output")
+	ok := enqueueJob(manager, worker.Job{InputPath: "input", OutputPath: "output"})
 	if !ok {
 		t.Errorf("expected enqueueJob to succeed, but it failed")
 	}
 	// Now the channel is full, so enqueueJob should return false.
-	ok = enqueueJob(manager, "input", "output")
+	ok = enqueueJob(manager, worker.Job{InputPath: "input", OutputPath: "output"})
 	if ok {
 		t.Errorf("expected enqueueJob to fail when channel is full, but it succeeded")
 	}
@@ -860,11 +1165,51 @@ func setupTestFiles(t *testing.T, inputDir string) {
 	time.Sleep(2 * time.Second)
 }
 
+func TestQueueFilesForProcessing_OutputConflicts(t *testing.T) {
+	inputDir := t.TempDir()
+	outputDir := t.TempDir()
+
+	for _, name := range []string{"base.css", "base.js", "card.css", "card.md"} {
+		testutils.CreateFile(t, filepath.Join(inputDir, name), "content")
+	}
+
+	opts := worker.WorkerPoolOptions{
+		InputDir:   inputDir,
+		OutputDir:  outputDir,
+		NumWorkers: 2,
+	}
+	mockLog := &testutils.MockLogger{}
+
+	jobs, skipped := verifyFileProcessingWithLogger(t, mockLog, opts, 0, 2, 2)
+
+	for _, job := range jobs {
+		if name := filepath.Base(job.InputPath); name != "card.css" && name != "card.md" {
+			t.Errorf("Unexpected job for conflicting file %s", job.InputPath)
+		}
+	}
+
+	for _, skip := range skipped {
+		if skip.SkipType != worker.SkipOutputConflict {
+			t.Errorf("Expected skip type %q for %s, got %q", worker.SkipOutputConflict, skip.Source, skip.SkipType)
+		}
+		if !strings.Contains(skip.Reason, "Output file also matched by") {
+			t.Errorf("Unexpected skip reason: %s", skip.Reason)
+		}
+	}
+	if !strings.Contains(skipped[0].Reason, "base.js") {
+		t.Errorf("Expected base.css to report base.js as conflicting input, got: %s", skipped[0].Reason)
+	}
+
+	if !strings.Contains(strings.Join(mockLog.Logs, "\n"), "Output file matched by several input files") {
+		t.Errorf("Expected a conflict warning, got logs: %v", mockLog.Logs)
+	}
+}
+
 // recordLastRun saves the last run timestamp
 func recordLastRun(t *testing.T, cacheFile string) int64 {
 	t.Helper()
 	lastRunTimestamp := time.Now().Unix()
-	if err := saveLastRunTimestamp(cacheFile); err != nil {
+	if err := worker.SaveLastRunTimestamp(cacheFile); err != nil {
 		t.Fatalf("Failed to save last run timestamp: %v", err)
 	}
 	return lastRunTimestamp
@@ -879,6 +1224,72 @@ func verifyFileProcessing(
 	expectedSkipped int,
 ) ([]worker.Job, []worker.ProcessingError) {
 	t.Helper()
+	return verifyFileProcessingWithLogger(t, &testutils.MockLogger{}, wpOpts, lastRunTimestamp, expectedJobs, expectedSkipped)
+}
+
+func TestSyncWorkerPool_CompareLast(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	outputDir := filepath.Join(tempDir, "output")
+	historyFile := filepath.Join(tempDir, ".tempo-files", worker.LastSummaryFile)
+
+	cmdCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		CWD:    tempDir,
+	}
+
+	testutils.CreateFile(t, filepath.Join(inputDir, "file1.css"), "body { color: black; }")
+	testutils.CreateFile(t, filepath.Join(outputDir, "file1.css.templ"), "/* [tempo] BEGIN */\n/* [tempo] END */")
+
+	opts := worker.WorkerPoolOptions{
+		Context:    context.Background(),
+		InputDir:   inputDir,
+		OutputDir:  outputDir,
+		MarkerName: "tempo",
+		NumWorkers: 1,
+		IsForce:    true,
+	}
+	summaryOpts := &worker.SummaryOptions{Format: worker.FormatNone, CompareLast: true, HistoryFile: historyFile}
+
+	// First run: nothing to compare with, the summary is saved
+	output, err := testutils.CaptureStdout(func() {
+		if err := runWorkerPool(cmdCtx, opts, summaryOpts, "", nil); err != nil {
+			t.Fatalf("Worker pool execution failed: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"No previous sync summary to compare with"})
+
+	if _, found, err := worker.LoadRunSummary(historyFile); err != nil || !found {
+		t.Fatalf("Expected run summary to be saved, got found=%v err=%v", found, err)
+	}
+
+	// Second run: a new input file has no matching templ file
+	testutils.CreateFile(t, filepath.Join(inputDir, "file2.css"), "p { color: red; }")
+
+	output, err = testutils.CaptureStdout(func() {
+		if err := runWorkerPool(cmdCtx, opts, summaryOpts, "", nil); err != nil {
+			t.Fatalf("Worker pool execution failed: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	testutils.ValidateCLIOutput(t, output, []string{"Compared to Last Run", "Newly Skipped (1)", "file2.css"})
+}
+
+// verifyFileProcessingWithLogger is verifyFileProcessing with a custom logger.
+func verifyFileProcessingWithLogger(
+	t *testing.T,
+	mockLog *testutils.MockLogger,
+	wpOpts worker.WorkerPoolOptions,
+	lastRunTimestamp int64,
+	expectedJobs int,
+	expectedSkipped int,
+) ([]worker.Job, []worker.ProcessingError) {
+	t.Helper()
 
 	// Setup channels
 	jobChan := make(chan worker.Job, 10)
@@ -891,10 +1302,8 @@ func verifyFileProcessing(
 		SkippedChan: skippedChan,
 	}
 
-	mockLog := &testutils.MockLogger{}
-
 	// Run function under test
-	err := queueFilesForProcessing(mockLog, wpOpts, manager, lastRunTimestamp)
+	_, err := queueFilesForProcessing(mockLog, wpOpts, manager, lastRunTimestamp)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -956,3 +1365,515 @@ func formatFlagValue(value any) string {
 		return ""
 	}
 }
+
+func TestSyncWorkerPool_PartialResults(t *testing.T) {
+	tests := []struct {
+		name              string
+		failFast          bool
+		expectErr         bool
+		expectedSucceeded int
+	}{
+		{name: "drain all files by default", failFast: false, expectErr: false, expectedSucceeded: 1},
+		{name: "stop on first failure with fail-fast", failFast: true, expectErr: true, expectedSucceeded: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			inputDir := filepath.Join(tempDir, "input")
+			outputDir := filepath.Join(tempDir, "output")
+
+			// "a.templ" lacks the BEGIN guard marker so processing "a.css" fails
+			testutils.CreateFile(t, filepath.Join(inputDir, "a.css"), "body { color: red; }")
+			testutils.CreateFile(t, filepath.Join(outputDir, "a.templ"), "/* [tempo] END */")
+			testutils.CreateFile(t, filepath.Join(inputDir, "b.css"), "body { color: black; }")
+			testutils.CreateFile(t, filepath.Join(outputDir, "b.templ"),
+				"/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo] END */")
+
+			cmdCtx := &app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				CWD:    tempDir,
+			}
+
+			opts := worker.WorkerPoolOptions{
+				Context:    context.Background(),
+				InputDir:   inputDir,
+				OutputDir:  outputDir,
+				MarkerName: "tempo",
+				NumWorkers: 1,
+				IsForce:    true,
+				IsFailFast: tt.failFast,
+			}
+
+			var runErr error
+			output, err := testutils.CaptureStdout(func() {
+				runErr = runWorkerPool(cmdCtx, opts, &worker.SummaryOptions{Format: "json"}, "", nil)
+			})
+			if err != nil {
+				t.Fatalf("Failed to capture stdout: %v", err)
+			}
+			if tt.expectErr != (runErr != nil) {
+				t.Fatalf("runWorkerPool() error = %v, expectErr %v", runErr, tt.expectErr)
+			}
+
+			var summary struct {
+				Succeeded []string                 `json:"succeeded"`
+				Errors    []worker.ProcessingError `json:"errors"`
+			}
+			if err := json.Unmarshal([]byte(output), &summary); err != nil {
+				t.Fatalf("Summary must be printed even on failure, got %q: %v", output, err)
+			}
+			if len(summary.Errors) != 1 {
+				t.Errorf("Expected 1 failed file, got %v", summary.Errors)
+			}
+			if len(summary.Succeeded) != tt.expectedSucceeded {
+				t.Errorf("Expected %d succeeded files, got %v", tt.expectedSucceeded, summary.Succeeded)
+			}
+		})
+	}
+}
+
+func TestSyncWorkerPool_Progress(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	outputDir := filepath.Join(tempDir, "output")
+
+	// "a.css" fails on the missing BEGIN marker, "b.css" is processed, "c.css" has no templ file
+	testutils.CreateFile(t, filepath.Join(inputDir, "a.css"), "body { color: red; }")
+	testutils.CreateFile(t, filepath.Join(outputDir, "a.templ"), "/* [tempo] END */")
+	testutils.CreateFile(t, filepath.Join(inputDir, "b.css"), "body { color: black; }")
+	testutils.CreateFile(t, filepath.Join(outputDir, "b.templ"),
+		"/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo] END */")
+	testutils.CreateFile(t, filepath.Join(inputDir, "c.css"), "body { color: blue; }")
+
+	var events bytes.Buffer
+	reporter, err := progress.New(progress.FormatNDJSON, &events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := worker.WorkerPoolOptions{
+		Context:    context.Background(),
+		InputDir:   inputDir,
+		OutputDir:  outputDir,
+		MarkerName: "tempo",
+		NumWorkers: 2,
+		IsForce:    true,
+		Progress:   reporter,
+	}
+	cmdCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), CWD: tempDir}
+	if _, err := testutils.CaptureStdout(func() {
+		_ = runWorkerPool(cmdCtx, opts, &worker.SummaryOptions{Format: "none"}, "", nil)
+	}); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	got := map[string][]progress.Event{}
+	for line := range strings.Lines(events.String()) {
+		var rec progress.Record
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("Invalid progress event %q: %v", line, err)
+		}
+		if rec.Time.IsZero() {
+			t.Errorf("Expected the event %q to be timestamped", line)
+		}
+		got[filepath.Base(rec.File)] = append(got[filepath.Base(rec.File)], rec.Event)
+	}
+
+	want := map[string][]progress.Event{
+		"a.css": {progress.EventQueued, progress.EventStarted, progress.EventError},
+		"b.css": {progress.EventQueued, progress.EventStarted, progress.EventDone},
+		"c.css": {progress.EventQueued, progress.EventSkipped},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the events %v, got %v", want, got)
+	}
+}
+
+func TestSyncWorkerPool_Guards(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	outputDir := filepath.Join(tempDir, "output")
+	templContent := "/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo] END */"
+
+	testutils.CreateFile(t, filepath.Join(inputDir, "vendor.css"), strings.Repeat(".a{color:red}", 100))
+	testutils.CreateFile(t, filepath.Join(outputDir, "vendor.templ"), templContent)
+	testutils.CreateFile(t, filepath.Join(inputDir, "remote.css"), "@import url(https://example.com/x.css);")
+	testutils.CreateFile(t, filepath.Join(outputDir, "remote.templ"), templContent)
+	testutils.CreateFile(t, filepath.Join(inputDir, "ok.css"), "body { color: black; }")
+	testutils.CreateFile(t, filepath.Join(outputDir, "ok.templ"), templContent)
+
+	guards, err := processor.NewInjectionGuards(processor.GuardOptions{
+		MaxCSSSize:        "1KB",
+		ForbiddenPatterns: []string{`@import\s+url\(\s*.?http`},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	opts := worker.WorkerPoolOptions{
+		Context:    context.Background(),
+		InputDir:   inputDir,
+		OutputDir:  outputDir,
+		MarkerName: "tempo",
+		Guards:     guards,
+		NumWorkers: 1,
+		IsForce:    true,
+	}
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := runWorkerPool(&app.AppContext{Logger: logger.NewDefaultLogger(), CWD: tempDir}, opts, &worker.SummaryOptions{Format: "json"}, "", nil); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	var summary struct {
+		Succeeded []string                 `json:"succeeded"`
+		Errors    []worker.ProcessingError `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(output), &summary); err != nil {
+		t.Fatalf("Failed to parse summary %q: %v", output, err)
+	}
+	if len(summary.Succeeded) != 1 || len(summary.Errors) != 2 {
+		t.Fatalf("Expected 1 succeeded and 2 failed files, got %+v", summary)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "vendor.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read templ file: %v", err)
+	}
+	if string(content) != templContent {
+		t.Errorf("Expected the guarded templ file to be left unchanged, got %q", content)
+	}
+}
+
+func TestSyncWorkerPool_Symlinks(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	outputDir := filepath.Join(tempDir, "output")
+	templContent := "/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo] END */"
+
+	testutils.CreateFile(t, filepath.Join(tempDir, "design-tokens", "colors.css"), ":root { --primary: blue; }")
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "base.css"), ".btn { color: black; }")
+	testutils.CreateFile(t, filepath.Join(outputDir, "tokens", "colors.templ"), templContent)
+	testutils.CreateFile(t, filepath.Join(outputDir, "button", "base.templ"), templContent)
+	if err := os.Symlink(filepath.Join(tempDir, "design-tokens"), filepath.Join(inputDir, "tokens")); err != nil {
+		t.Skipf("Symbolic links not supported: %v", err)
+	}
+	if err := os.Symlink(inputDir, filepath.Join(inputDir, "button", "loop")); err != nil {
+		t.Fatalf("Failed to create link: %v", err)
+	}
+
+	for _, tt := range []struct {
+		policy    string
+		succeeded int
+	}{
+		{policy: worker.SymlinkFollow, succeeded: 2},
+		{policy: worker.SymlinkSkip, succeeded: 1},
+	} {
+		t.Run(tt.policy, func(t *testing.T) {
+			opts := worker.WorkerPoolOptions{
+				Context:       context.Background(),
+				InputDir:      inputDir,
+				OutputDir:     outputDir,
+				MarkerName:    "tempo",
+				SymlinkPolicy: tt.policy,
+				NumWorkers:    1,
+				IsForce:       true,
+			}
+
+			output, err := testutils.CaptureStdout(func() {
+				if err := runWorkerPool(&app.AppContext{Logger: logger.NewDefaultLogger(), CWD: tempDir}, opts, &worker.SummaryOptions{Format: "json"}, "", nil); err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			})
+			if err != nil {
+				t.Fatalf("Failed to capture stdout: %v", err)
+			}
+
+			var summary struct {
+				Succeeded    []string                            `json:"succeeded"`
+				SkippedFiles map[string][]worker.ProcessingError `json:"skipped_files"`
+			}
+			if err := json.Unmarshal([]byte(output), &summary); err != nil {
+				t.Fatalf("Failed to parse summary %q: %v", output, err)
+			}
+			if len(summary.Succeeded) != tt.succeeded {
+				t.Errorf("Expected %d succeeded files, got %v", tt.succeeded, summary.Succeeded)
+			}
+			if len(summary.SkippedFiles[string(worker.SkipSymlink)]) == 0 {
+				t.Errorf("Expected skipped symbolic links, got %+v", summary.SkippedFiles)
+			}
+		})
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "tokens", "colors.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read templ file: %v", err)
+	}
+	if !strings.Contains(string(content), "--primary") {
+		t.Errorf("Expected the linked asset to be injected at the link location, got %q", content)
+	}
+}
+
+func TestSyncWorkerPool_OutputRules(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	outputDir := filepath.Join(tempDir, "output")
+	adminDir := filepath.Join(tempDir, "admin")
+	templContent := "/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo] END */"
+
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "base.css"), ".btn { color: black; }")
+	testutils.CreateFile(t, filepath.Join(inputDir, "admin", "table", "base.css"), ".table { color: red; }")
+	testutils.CreateFile(t, filepath.Join(outputDir, "button", "base.templ"), templContent)
+	testutils.CreateFile(t, filepath.Join(adminDir, "table", "base.templ"), templContent)
+
+	rule, err := worker.NewOutputRule("admin/**", adminDir)
+	if err != nil {
+		t.Fatalf("Failed to create output rule: %v", err)
+	}
+
+	opts := worker.WorkerPoolOptions{
+		Context:     context.Background(),
+		InputDir:    inputDir,
+		OutputDir:   outputDir,
+		OutputRules: []worker.OutputRule{rule},
+		MarkerName:  "tempo",
+		NumWorkers:  1,
+		IsForce:     true,
+	}
+
+	if _, err := testutils.CaptureStdout(func() {
+		if err := runWorkerPool(&app.AppContext{Logger: logger.NewDefaultLogger(), CWD: tempDir}, opts, &worker.SummaryOptions{Format: "none"}, "", nil); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	for path, want := range map[string]string{
+		filepath.Join(outputDir, "button", "base.templ"): ".btn",
+		filepath.Join(adminDir, "table", "base.templ"):   ".table",
+	} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read templ file: %v", err)
+		}
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected %s to contain %q, got %q", path, want, content)
+		}
+	}
+}
+
+func TestSyncWorkerPool_OutputRegions(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	outputDir := filepath.Join(tempDir, "output")
+	templPath := filepath.Join(outputDir, "button", "button.templ")
+
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "css", "base.css"), ".btn { color: black; }")
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "css", "variants.css"), ".btn-primary { color: blue; }")
+	testutils.CreateFile(t, templPath, "package button\n\n"+
+		"/* [tempo:base] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo:base] END */\n\n"+
+		"/* [tempo:variants] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo:variants] END */\n")
+
+	cfgRules := []config.OutputRule{
+		{Input: "button/css/base.css", Output: "{{ .OutputDir }}/button", File: "button", Region: "base"},
+		{Input: "button/css/variants.css", Output: "{{ .OutputDir }}/button", File: "button", Region: "variants"},
+	}
+	rules, err := worker.ConfigOutputRules(cfgRules)
+	if err != nil {
+		t.Fatalf("Failed to create output rules: %v", err)
+	}
+
+	opts := worker.WorkerPoolOptions{
+		Context:     context.Background(),
+		InputDir:    inputDir,
+		OutputDir:   outputDir,
+		OutputRules: rules,
+		MarkerName:  "tempo",
+		NumWorkers:  2,
+		IsForce:     true,
+	}
+
+	if _, err := testutils.CaptureStdout(func() {
+		if err := runWorkerPool(&app.AppContext{Logger: logger.NewDefaultLogger(), CWD: tempDir}, opts, &worker.SummaryOptions{Format: "none"}, "", nil); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	content, err := os.ReadFile(templPath)
+	if err != nil {
+		t.Fatalf("Failed to read templ file: %v", err)
+	}
+	lines := strings.Split(string(content), "\n")
+	for marker, want := range map[string]string{"tempo:base": ".btn { color: black; }", "tempo:variants": ".btn-primary { color: blue; }"} {
+		start, end, ok := processor.GuardRegion(string(content), marker)
+		if !ok || end < start {
+			t.Fatalf("Expected the %s region to be filled, got %q", marker, content)
+		}
+		region := strings.Join(lines[start-1:end], "\n")
+		if !strings.Contains(region, want) {
+			t.Errorf("Expected the %s region to contain %q, got %q", marker, want, region)
+		}
+	}
+}
+
+func TestSyncWorkerPool_AssetLanguages(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	outputDir := filepath.Join(tempDir, "output")
+	templContent := "/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo] END */"
+
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "tokens.json"), `{"label": "</script>"}`)
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "icon.svg"), `<svg><path d="M0 0"/></svg>`)
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "notes.txt"), "ignored")
+	testutils.CreateFile(t, filepath.Join(outputDir, "button", "tokens.templ"), templContent)
+	testutils.CreateFile(t, filepath.Join(outputDir, "button", "icon.templ"), templContent)
+
+	languages, err := worker.ConfigLanguages([]config.Language{
+		{Extension: ".json"},
+		{Extension: "svg", Mode: processor.LanguageGoVar},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	opts := worker.WorkerPoolOptions{
+		Context:    context.Background(),
+		InputDir:   inputDir,
+		OutputDir:  outputDir,
+		MarkerName: "tempo",
+		Languages:  languages,
+		NumWorkers: 1,
+		IsForce:    true,
+	}
+
+	if _, err := testutils.CaptureStdout(func() {
+		if err := runWorkerPool(&app.AppContext{Logger: logger.NewDefaultLogger(), CWD: tempDir}, opts, &worker.SummaryOptions{Format: "none"}, "", nil); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	for path, want := range map[string]string{
+		filepath.Join(outputDir, "button", "tokens.templ"): `{"label": "\u003c/script\u003e"}`,
+		filepath.Join(outputDir, "button", "icon.templ"):   "var iconSVG = `<svg><path d=\"M0 0\"/></svg>`",
+	} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read templ file: %v", err)
+		}
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected %s to contain %q, got %q", path, want, content)
+		}
+	}
+
+	if _, err := worker.ConfigLanguages([]config.Language{{Extension: ".css"}}); err == nil {
+		t.Error("Expected an error for a natively synced extension")
+	}
+}
+
+func TestSyncCommand_InputZip(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	// The assets folder is missing: the assets only live in the bundle
+	templPath := filepath.Join(cfg.App.GoPackage, "button", "base.templ")
+	startMarker, endMarker := processor.GuardMarkers(cfg.Templates.GuardMarker)
+	testutils.CreateFile(t, templPath, startMarker+"\n"+endMarker+"\n")
+
+	bundlePath := filepath.Join(tempDir, "bundle.zip")
+	bundle, err := os.Create(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(bundle)
+	w, err := zw.Create("button/base.css")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(".btn { color: green; }")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := bundle.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cliApp := &cli.Command{Commands: []*cli.Command{SetupSyncCommand(&app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	})}}
+
+	t.Run("injects the bundled assets", func(t *testing.T) {
+		if _, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), []string{"tempo", "sync", "--input-zip", bundlePath, "--force"}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}); err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+
+		content, err := os.ReadFile(templPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), ".btn { color: green; }") {
+			t.Errorf("Expected the bundled CSS to be injected, got:\n%s", content)
+		}
+	})
+
+	t.Run("missing bundle", func(t *testing.T) {
+		err := cliApp.Run(context.Background(), []string{"tempo", "sync", "--input-zip", filepath.Join(tempDir, "missing.zip")})
+		if err == nil || !strings.Contains(err.Error(), "failed to open the input zip bundle") {
+			t.Errorf("Expected bundle error, got: %v", err)
+		}
+	})
+}
+
+/* ------------------------------------------------------------------------- */
+/* Test getFileLastModifiedTime                                              */
+/* ------------------------------------------------------------------------- */
+
+func TestGetFileLastModifiedTime(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "testfile.txt")
+
+	// Create test file
+	err := os.WriteFile(testFile, []byte("test content"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	// Get last modified time
+	ts, err := getFileLastModifiedTime(nil, testFile)
+	if err != nil {
+		t.Fatalf("Failed to get last modified time: %v", err)
+	}
+
+	if ts <= 0 {
+		t.Errorf("Expected a valid last modified timestamp, got %d", ts)
+	}
+
+	// Case 1: Non-existent file should return an error
+	nonExistentFile := filepath.Join(tempDir, "nonexistent.txt")
+	_, err = getFileLastModifiedTime(nil, nonExistentFile)
+	if err == nil {
+		t.Errorf("Expected an error for non-existent file, but got none")
+	}
+}
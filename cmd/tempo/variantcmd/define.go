@@ -3,6 +3,7 @@ package variantcmd
 import (
 	"context"
 	"path/filepath"
+	"strings"
 
 	"github.com/indaco/tempo/internal/app"
 	"github.com/indaco/tempo/internal/apperrors"
@@ -10,6 +11,7 @@ import (
 	"github.com/indaco/tempo/internal/generator"
 	"github.com/indaco/tempo/internal/helpers"
 	"github.com/indaco/tempo/internal/resolver"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
 	"github.com/indaco/tempo/internal/utils"
 	"github.com/urfave/cli/v3"
 )
@@ -25,16 +27,25 @@ func setupVariantDefineSubCommand(cmdCtx *app.AppContext) *cli.Command {
 		Flags:                  flags,
 		Before:                 validateVariantDefinePrerequisites(cmdCtx.Config),
 		Action:                 runVariantDefineSubCommand(*cmdCtx),
+
+		Description: "Copies the variant templates into the templates folder. With '--component', they are " +
+			"copied into component-variant/<component>/ and preferred by 'tempo variant new' for that component.",
 	}
 }
 
 // getDefineFlags generates the core CLI flags shared across subcommands.
 func getDefineFlags() []cli.Flag {
 	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "component",
+			Aliases: []string{"c"},
+			Usage:   "Define variant templates specific to the given component",
+		},
 		&cli.BoolFlag{
 			Name:  "force",
 			Usage: "Force overwriting if already exists",
 		},
+		helpers.YesFlag(),
 		&cli.BoolFlag{
 			Name:  "dry-run",
 			Usage: "Preview actions without making changes",
@@ -57,7 +68,7 @@ func validateVariantDefinePrerequisites(cfg *config.Config) func(ctx context.Con
 			return nil, apperrors.Wrap("Failed to check component templates folder", err)
 		}
 		if !exists {
-			return nil, apperrors.Wrap("Templates for component not found. Run 'tempo component define' first.")
+			return nil, apperrors.WrapCode(apperrors.CodeTemplatesNotFound, "Templates for component not found. Run 'tempo component define' first.")
 		}
 		return ctx, nil
 	}
@@ -81,14 +92,18 @@ func runVariantDefineSubCommand(cmdCtx app.AppContext) func(ctx context.Context,
 			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.")
 		}
 
-		// Step 2: Check if templates folder for component already exists
+		// Step 2: Check if templates folder for variant already exists
 		// Display a warning and stop if `--force` is not set
-		outputPath := filepath.Join(data.TemplatesDir, "component-variant")
+		component := gonameprovider.ToGoPackageName(cmd.String("component"))
+		outputPath := filepath.Join(data.TemplatesDir, generator.VariantTemplatesDir)
+		if component != "" {
+			outputPath = filepath.Join(data.TemplatesDir, filepath.FromSlash(generator.ComponentVariantTemplatesDir(component)))
+		}
 		exists, err := utils.DirExists(outputPath)
 		if err != nil {
 			return err
 		} else if exists {
-			helpers.CheckEntityForDefine("variant", outputPath, data.Force, cmdCtx.Logger)
+			helpers.CheckEntityForDefine("variant", outputPath, data.Force, helpers.AssumeYes(cmd, cmdCtx.Config), cmdCtx.Logger)
 
 			if !data.Force {
 				return nil
@@ -100,6 +115,11 @@ func runVariantDefineSubCommand(cmdCtx app.AppContext) func(ctx context.Context,
 		if err != nil {
 			return apperrors.Wrap("Failed to build variant actions", err)
 		}
+		entityType := "variant"
+		if component != "" {
+			builtInActions = generator.ScopeVariantActions(builtInActions, component)
+			entityType = strings.TrimSuffix(generator.VariantActionFileName(component), ".json")
+		}
 
 		// Step 4: Process actions
 		if err := generator.ProcessActions(ctx, cmdCtx.Logger, builtInActions, data); err != nil {
@@ -111,7 +131,7 @@ func runVariantDefineSubCommand(cmdCtx app.AppContext) func(ctx context.Context,
 			helpers.LogSuccessMessages("variant", cmdCtx.Config, cmdCtx.Logger)
 
 			// Step 6: Generate JSON action file
-			if err := generator.GenerateActionFile("variant", data, builtInActions, cmdCtx.Logger); err != nil {
+			if err := generator.GenerateActionFile(entityType, data, builtInActions, cmdCtx.Logger); err != nil {
 				return err
 			}
 		}
@@ -129,16 +149,19 @@ func runVariantDefineSubCommand(cmdCtx app.AppContext) func(ctx context.Context,
 // by resolving configuration values and CLI flags.
 func createTemplateData(cmd *cli.Command, cfg *config.Config) (*generator.TemplateData, error) {
 	TemplatesDir, ActionsDir := config.DerivedFolderPaths(cfg.TempoRoot)
-	isWithJs := resolver.ResolveBool(cmd.Bool("js"), cfg.App.WithJs)
+	isWithJs := resolver.ResolveBool(cmd.Bool("js"), cfg.App.JsEnabled())
 	isForce := cmd.Bool("force")
 	isDryRun := cmd.Bool("dry-run")
 
 	// Initialize common fields
-	return &generator.TemplateData{
-		TemplatesDir: TemplatesDir,
-		ActionsDir:   ActionsDir,
-		WithJs:       isWithJs,
-		Force:        isForce,
-		DryRun:       isDryRun,
-	}, nil
+	data := &generator.TemplateData{
+		TemplatesDir:       TemplatesDir,
+		ActionsDir:         ActionsDir,
+		WithJs:             isWithJs,
+		Force:              isForce,
+		DryRun:             isDryRun,
+		TemplateExtensions: cfg.Templates.Extensions,
+	}
+	data.SetTemplateLayers(cfg.Templates.LayeredEnabled())
+	return data, nil
 }
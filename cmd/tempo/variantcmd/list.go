@@ -0,0 +1,212 @@
+package variantcmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/resolver"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Types                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// VariantInfo describes a component variant and which of its sides exist.
+type VariantInfo struct {
+	Name     string `json:"name"`
+	HasTempl bool   `json:"templ"`
+	HasCSS   bool   `json:"css"`
+}
+
+// Complete reports whether both the .templ and the .css files of the variant exist.
+func (v VariantInfo) Complete() bool {
+	return v.HasTempl && v.HasCSS
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// setupVariantListSubCommand creates the "list" subcommand for listing the variants of a component.
+func setupVariantListSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "list",
+		Usage:                  "List the variants of a component and their templ/CSS files",
+		UsageText:              "tempo variant list --component <name> [options]",
+		UseShortOptionHandling: true,
+		Flags:                  getListFlags(),
+		Action:                 runVariantListSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getListFlags defines the CLI flags for the "list" subcommand.
+func getListFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "component",
+			Aliases:  []string{"c"},
+			Usage:    "Name of the component whose variants are listed",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"f"},
+			Usage:   "Output format: table, json (default: table)",
+		},
+		&cli.BoolFlag{
+			Name:  "missing-assets",
+			Usage: "Only show variants missing either the .templ or the .css file",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runVariantListSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		// Step 1: Resolve the output format
+		format, err := resolver.ResolveString(cmd.String("format"), "", "format", "table", []string{"table", "json"})
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Ensure the component exists
+		componentName := gonameprovider.ToGoPackageName(cmd.String("component"))
+		componentFolderPath := filepath.Join(cmdCtx.Config.App.GoPackage, componentName)
+		if exists, err := utils.DirExists(componentFolderPath); err != nil {
+			return apperrors.Wrap("Error checking component folder", err, componentName)
+		} else if !exists {
+			return apperrors.WrapCode(apperrors.CodeComponentNotFound, "Cannot list variants: Component does not exist", componentName)
+		}
+
+		// Step 3: Collect the variants
+		variants, err := collectVariants(cmdCtx.Config.App.GoPackage, cmdCtx.Config.App.AssetsDir, cmdCtx.Config.Layout.Variants(), componentName)
+		if err != nil {
+			return apperrors.Wrap("failed to list variants", err, componentName)
+		}
+
+		if cmd.Bool("missing-assets") {
+			variants = slices.DeleteFunc(variants, VariantInfo.Complete)
+		}
+
+		// Step 4: Print the variants
+		if format == "json" {
+			return writeVariantsJSON(os.Stdout, variants)
+		}
+		return writeVariantsTable(os.Stdout, variants)
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// collectVariants returns the variants of the component sorted by name, merging the
+// .templ files in the Go package with the .css files in the assets folder, both in the
+// variantsDir folder of the component.
+func collectVariants(goPackage, assetsDir, variantsDir, componentName string) ([]VariantInfo, error) {
+	variantsDir = filepath.FromSlash(variantsDir)
+	templNames, err := listFileNames(filepath.Join(goPackage, componentName, variantsDir), ".templ")
+	if err != nil {
+		return nil, err
+	}
+	cssNames, err := listFileNames(filepath.Join(assetsDir, componentName, variantsDir), ".css")
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*VariantInfo)
+	get := func(name string) *VariantInfo {
+		if v, ok := byName[name]; ok {
+			return v
+		}
+		v := &VariantInfo{Name: name}
+		byName[name] = v
+		return v
+	}
+	for _, name := range templNames {
+		get(name).HasTempl = true
+	}
+	for _, name := range cssNames {
+		get(name).HasCSS = true
+	}
+
+	variants := make([]VariantInfo, 0, len(byName))
+	for _, v := range byName {
+		variants = append(variants, *v)
+	}
+	slices.SortFunc(variants, func(a, b VariantInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return variants, nil
+}
+
+// listFileNames returns the names, without extension, of the files with the given extension in dir.
+func listFileNames(dir, ext string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ext {
+			names = append(names, strings.TrimSuffix(entry.Name(), ext))
+		}
+	}
+	return names, nil
+}
+
+// writeVariantsJSON writes the variants as an indented JSON array.
+func writeVariantsJSON(w io.Writer, variants []VariantInfo) error {
+	data, err := json.MarshalIndent(variants, "", "  ")
+	if err != nil {
+		return apperrors.Wrap("failed to marshal variants", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// writeVariantsTable writes the variants as an aligned table.
+func writeVariantsTable(w io.Writer, variants []VariantInfo) error {
+	if len(variants) == 0 {
+		_, err := fmt.Fprintln(w, "No variants found")
+		return err
+	}
+
+	mark := func(ok bool) string {
+		if ok {
+			return "yes"
+		}
+		return "missing"
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "VARIANT\tTEMPL\tCSS\n")
+	for _, v := range variants {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", v.Name, mark(v.HasTempl), mark(v.HasCSS))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,141 @@
+package variantcmd
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+// setupVariantListProject creates a project with a "button" component and three variants:
+// "outline" (complete), "ghost" (missing the CSS) and "solid" (missing the templ).
+func setupVariantListProject(t *testing.T) (string, *config.Config) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	files := map[string]string{
+		filepath.Join(cfg.App.GoPackage, "button", "button.templ"):                     "package button",
+		filepath.Join(cfg.App.GoPackage, "button", "css", "variants", "outline.templ"): "package css",
+		filepath.Join(cfg.App.GoPackage, "button", "css", "variants", "ghost.templ"):   "package css",
+		filepath.Join(cfg.App.AssetsDir, "button", "css", "variants", "outline.css"):   ".outline {}",
+		filepath.Join(cfg.App.AssetsDir, "button", "css", "variants", "solid.css"):     ".solid {}",
+	}
+	for path, content := range files {
+		testutils.CreateFile(t, path, content)
+	}
+
+	return tempDir, cfg
+}
+
+func runVariantList(t *testing.T, tempDir string, cfg *config.Config, args ...string) (string, error) {
+	t.Helper()
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupVariantCommand(&app.AppContext{
+				Logger: logger.NewDefaultLogger(),
+				Config: cfg,
+				CWD:    tempDir,
+			}),
+		},
+	}
+
+	var runErr error
+	output, err := testutils.CaptureStdout(func() {
+		runErr = cliApp.Run(context.Background(), append([]string{"tempo", "variant", "list"}, args...))
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	return output, runErr
+}
+
+func TestVariantListCommand_JSON(t *testing.T) {
+	tempDir, cfg := setupVariantListProject(t)
+
+	output, err := runVariantList(t, tempDir, cfg, "--component", "button", "--format", "json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var variants []VariantInfo
+	if err := json.Unmarshal([]byte(output), &variants); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+	}
+
+	expected := []VariantInfo{
+		{Name: "ghost", HasTempl: true, HasCSS: false},
+		{Name: "outline", HasTempl: true, HasCSS: true},
+		{Name: "solid", HasTempl: false, HasCSS: true},
+	}
+	if len(variants) != len(expected) {
+		t.Fatalf("Expected %d variants, got %+v", len(expected), variants)
+	}
+	for i, v := range expected {
+		if variants[i] != v {
+			t.Errorf("Expected variant %+v, got %+v", v, variants[i])
+		}
+	}
+}
+
+func TestVariantListCommand_Table(t *testing.T) {
+	tempDir, cfg := setupVariantListProject(t)
+
+	output, err := runVariantList(t, tempDir, cfg, "-c", "button")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, output, []string{"VARIANT", "TEMPL", "CSS", "ghost", "outline", "solid", "missing"})
+}
+
+func TestVariantListCommand_MissingAssets(t *testing.T) {
+	tempDir, cfg := setupVariantListProject(t)
+
+	output, err := runVariantList(t, tempDir, cfg, "-c", "button", "--missing-assets")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, output, []string{"ghost", "solid"})
+	if strings.Contains(output, "outline") {
+		t.Errorf("Expected complete variant 'outline' to be filtered out, got:\n%s", output)
+	}
+}
+
+func TestVariantListCommand_NoVariants(t *testing.T) {
+	tempDir, cfg := setupVariantListProject(t)
+	testutils.CreateFile(t, filepath.Join(cfg.App.GoPackage, "card", "card.templ"), "package card")
+
+	output, err := runVariantList(t, tempDir, cfg, "-c", "card")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, output, []string{"No variants found"})
+}
+
+func TestVariantListCommand_ComponentNotFound(t *testing.T) {
+	tempDir, cfg := setupVariantListProject(t)
+
+	_, err := runVariantList(t, tempDir, cfg, "-c", "missing")
+	if err == nil || !strings.Contains(err.Error(), "Component does not exist") {
+		t.Fatalf("Expected a missing component error, got %v", err)
+	}
+}
@@ -3,13 +3,17 @@ package variantcmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/indaco/tempo/internal/app"
 	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/codegen"
 	"github.com/indaco/tempo/internal/config"
 	"github.com/indaco/tempo/internal/generator"
 	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/rendercache"
 	"github.com/indaco/tempo/internal/resolver"
 	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
 	"github.com/indaco/tempo/internal/utils"
@@ -22,11 +26,14 @@ func setupVariantNewSubCommand(cmdCtx *app.AppContext) *cli.Command {
 	return &cli.Command{
 		Name:                   "new",
 		Usage:                  "Generate a variant instance from a template",
-		UsageText:              "tempo variant new [options]",
+		UsageText:              "tempo variant new [options] [component] [name]",
 		UseShortOptionHandling: true,
 		Flags:                  flags,
-		Before:                 validateVariantNewPrerequisites(cmdCtx.Config),
+		Before:                 validateVariantNewPrerequisites(cmdCtx.Config, cmdCtx.Logger),
 		Action:                 runVariantNewSubCommand(cmdCtx),
+
+		// --data values may contain commas
+		DisableSliceFlagSeparator: true,
 	}
 }
 
@@ -48,25 +55,61 @@ func getNewFlags() []cli.Flag {
 			Usage:   "The directory where asset files (e.g., CSS, JS) will be generated (default: assets)",
 		},
 		&cli.StringFlag{
-			Name:     "name",
-			Aliases:  []string{"n"},
-			Usage:    "The name of the variant being generated",
-			Required: true,
+			Name:    "name",
+			Aliases: []string{"n"},
+			Usage:   "The name of the variant being generated (or the second argument)",
 		},
 		&cli.StringFlag{
-			Name:     "component",
-			Aliases:  []string{"c"},
-			Usage:    "Name of the component or entity",
-			Required: true,
+			Name:    "component",
+			Aliases: []string{"c"},
+			Usage:   "Name of the component or entity (or the first argument)",
 		},
 		&cli.BoolFlag{
 			Name:  "force",
 			Usage: "Force overwriting if already exists",
 		},
+		helpers.YesFlag(),
+		&cli.BoolFlag{
+			Name:  "no-diff",
+			Usage: "Skip the diff of the changed files with '--force'",
+		},
 		&cli.BoolFlag{
 			Name:  "dry-run",
 			Usage: "Preview actions without making changes",
 		},
+		&cli.StringSliceFlag{
+			Name:  "data",
+			Usage: "Set a user data value (key=value, dotted keys for nested values); repeatable, overrides --data-file",
+		},
+		&cli.StringFlag{
+			Name:  "data-file",
+			Usage: "YAML file of user data deep-merged over the templates.user_data config and the .tempo-data.yaml file",
+		},
+		&cli.StringFlag{
+			Name:  "print-data",
+			Usage: "Print the data received by the templates (config, flags and user data merged) as yaml or json, and exit without generating",
+		},
+		&cli.BoolFlag{
+			Name:  "trace",
+			Usage: "Log each executed action with its destination, elapsed time and rendered bytes",
+		},
+		&cli.StringFlag{
+			Name:  "trace-file",
+			Usage: "Export the action trace to a JSON file",
+		},
+		&cli.BoolFlag{
+			Name:  "git-add",
+			Usage: "Stage the files created or modified by the command",
+		},
+		&cli.BoolFlag{
+			Name:  "git-commit",
+			Usage: "Stage and commit the files created or modified by the command",
+		},
+		&cli.StringFlag{
+			Name:    "message",
+			Aliases: []string{"m"},
+			Usage:   "Commit message used with --git-commit (default: \"" + helpers.DefaultGitCommitMessage + "\")",
+		},
 	}
 }
 
@@ -78,11 +121,30 @@ func runVariantNewSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, c
 	return func(ctx context.Context, cmd *cli.Command) error {
 		helpers.EnableLoggerIndentation(cmdCtx.Logger)
 
-		// Step 1: Create variant data
-		data, err := createVariantData(cmd, cmdCtx.Config)
+		// Step 1: Create variant data, with the names given as flags or as arguments
+		componentName, variantName, err := resolveVariantNames(cmd)
+		if err != nil {
+			return err
+		}
+		data, err := createVariantData(cmd, cmdCtx.Config, componentName, variantName)
 		if err != nil {
 			return apperrors.Wrap("failed to create variant data", err)
 		}
+		if err := helpers.ResolveComponentImportPath(cmdCtx, data); err != nil {
+			return err
+		}
+
+		// The templates defined for the component are preferred over the generic ones
+		pathToVariantActionsFile, templatesDir, err := resolveVariantTemplates(data)
+		if err != nil {
+			return err
+		}
+
+		// Debug mode: the template data is printed instead of generating the variant
+		if format := cmd.String("print-data"); format != "" {
+			defer cmdCtx.Logger.Reset()
+			return helpers.PrintTemplateData(os.Stdout, data, templatesDir, format)
+		}
 
 		if data.DryRun {
 			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.\n")
@@ -91,13 +153,16 @@ func runVariantNewSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, c
 		}
 
 		// Step 2: Check if "variant define" command has been executed
-		pathToVariantActionsFile := filepath.Join(data.ActionsDir, "variant.json")
 		exists, err := utils.FileExistsFunc(pathToVariantActionsFile)
 		if err != nil {
 			return err
 		}
 		if !exists {
-			return apperrors.Wrap("Cannot find actions folder. Did you run 'tempo variant define' before?")
+			return apperrors.WrapCode(apperrors.CodeTemplatesNotFound, "Cannot find actions folder. Did you run 'tempo variant define' before?")
+		}
+		if templatesDir != generator.VariantTemplatesDir {
+			cmdCtx.Logger.Info("Using the variant templates of the component").
+				WithAttrs("component", data.ComponentName, "templates", templatesDir)
 		}
 
 		// Step 3: Ensure the component folder exists before adding a variant
@@ -110,32 +175,59 @@ func runVariantNewSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, c
 					"variant", data.VariantName,
 					"component", data.ComponentName,
 				)
-			return apperrors.Wrap("Cannot create variant: Component does not exist", data.ComponentName)
+			return apperrors.WrapCode(apperrors.CodeComponentNotFound, "Cannot create variant: Component does not exist", data.ComponentName)
 		}
 
 		// Step 4: Check if the component variant already exists with the same name
 		// Display a warning and stop if `--force` is not set
-		outputPath := filepath.Join(data.GoPackage, data.ComponentName, "css", "variants", data.VariantName+".templ")
+		outputPath := filepath.Join(data.GoPackage, data.ComponentName, filepath.FromSlash(data.VariantsDir), data.VariantName+".templ")
 		if exists, err := utils.FileExistsFunc(outputPath); err != nil {
 			return err
 		} else if exists {
-			helpers.CheckEntityForNew("variant", data.VariantName, outputPath, data.Force, cmdCtx.Logger)
+			helpers.CheckEntityForNew("variant", data.VariantName, outputPath, data.Force, helpers.AssumeYes(cmd, cmdCtx.Config), cmdCtx.Logger)
 
 			if !data.Force {
 				return nil
 			}
+
+			// Review the changes to the existing files before overwriting them
+			process := func(ctx context.Context) error {
+				return generator.ProcessEntityActions(ctx, cmdCtx.Logger, pathToVariantActionsFile, data, cmdCtx.Config)
+			}
+			if err := helpers.ConfirmOverwrite(ctx, data.Force, cmd.Bool("no-diff"), helpers.AssumeYes(cmd, cmdCtx.Config), os.Stdout, process); err != nil {
+				cmdCtx.Logger.Reset()
+				return err
+			}
 		}
 
-		// Step 5: Retrieve and process actions
-		if err := generator.ProcessEntityActions(ctx, cmdCtx.Logger, pathToVariantActionsFile, data, cmdCtx.Config); err != nil {
-			return apperrors.Wrap("failed to process actions for variant", err, data.ComponentName)
+		// Step 5: Retrieve and process actions, tracking the changed files for git
+		snapshot, err := helpers.StartGitTracking(cmdCtx.CWD, cmd.Bool("git-add"), cmd.Bool("git-commit"))
+		if err != nil {
+			return err
+		}
+		traceFile := cmd.String("trace-file")
+		ctx, tracer := helpers.StartActionTrace(ctx, cmd.Bool("trace"), traceFile, cmdCtx.Logger)
+		processErr := generator.ProcessEntityActions(ctx, cmdCtx.Logger, pathToVariantActionsFile, data, cmdCtx.Config)
+		// The trace is exported even when the processing failed, but that failure wins
+		traceErr := helpers.FinishActionTrace(tracer, traceFile, cmdCtx.Logger)
+		if processErr != nil {
+			helpers.WarnTraceNotExported(cmdCtx.Logger, traceErr)
+			return apperrors.Wrap("failed to process actions for variant", processErr, data.ComponentName)
+		}
+		if traceErr != nil {
+			return traceErr
+		}
+
+		// Keep the generated variant names of the component in sync
+		if _, err := codegen.WriteVariantsFile(componentFolderPath, data.VariantsDir); err != nil {
+			return err
 		}
 
 		// Step 6: Log success and asset information
 		if !data.DryRun {
 			// Define paths for components and assets
-			componentPath := filepath.Join(data.GoPackage, data.ComponentName, "css", "variant")
-			assetPath := filepath.Join(data.AssetsDir, data.ComponentName, "css", "variants")
+			componentPath := filepath.Join(data.GoPackage, data.ComponentName, filepath.FromSlash(data.VariantsDir))
+			assetPath := filepath.Join(data.AssetsDir, data.ComponentName, filepath.FromSlash(data.VariantsDir))
 
 			// Log the success message with structured attributes
 			cmdCtx.Logger.Success("Templ component for the variant and asset files (CSS) have been created").
@@ -145,9 +237,15 @@ func runVariantNewSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, c
 					"component_path", componentPath,
 					"asset_path", assetPath,
 				)
+			cmdCtx.Summary = map[string]any{"variant": data.VariantName, "component": data.ComponentName, "asset_path": assetPath}
 
 			cmdCtx.Logger.Blank()
-			cmdCtx.Logger.Hint(fmt.Sprintf("Update %s/css/base.templ to conditionally load the variant's styles.", data.ComponentName))
+			cmdCtx.Logger.Hint(fmt.Sprintf("Update %s/%s/base.templ to conditionally load the variant's styles.", data.ComponentName, data.StylesDir))
+		}
+
+		// Step 7: Stage or commit the changed files
+		if err := helpers.FinishGitTracking(snapshot, cmd.Bool("git-commit"), cmd.String("message"), cmdCtx.Logger); err != nil {
+			return err
 		}
 		cmdCtx.Logger.Reset()
 
@@ -163,26 +261,26 @@ func runVariantNewSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, c
 // - Initialized Tempo project (inherited from the main define command).
 // - Existence of the component templates folder.
 // - Existence of the variant templates folder.
-func validateVariantNewPrerequisites(cfg *config.Config) func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+//
+// Missing folders are only warned about when strict mode is disabled.
+func validateVariantNewPrerequisites(cfg *config.Config, log logger.Logger) func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 	return func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 		foldersToCheck := map[string]string{
 			"component_directory": filepath.Join(cfg.Paths.TemplatesDir, "component"),
 			"variant_directory":   filepath.Join(cfg.Paths.TemplatesDir, "component-variant"),
 		}
 
-		missingFolders, err := utils.CheckMissingFolders(foldersToCheck)
+		_, err := helpers.CheckPrerequisiteFolders(
+			cfg,
+			log,
+			foldersToCheck,
+			"Have you run 'tempo component define' or 'tempo variant define' to set up your components?\nMake sure your templates, actions, and implementations exist before creating a new variant.",
+			[]string{"tempo component -h", "tempo define -h"},
+		)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(missingFolders) > 0 {
-			return nil, helpers.BuildMissingFoldersError(
-				missingFolders,
-				"Have you run 'tempo component define' or 'tempo variant define' to set up your components?\nMake sure your templates, actions, and implementations exist before creating a new variant.",
-				[]string{"tempo component -h", "tempo define -h"},
-			)
-		}
-
 		return ctx, nil
 	}
 }
@@ -191,16 +289,47 @@ func validateVariantNewPrerequisites(cfg *config.Config) func(ctx context.Contex
 /* Helper Functions                                                          */
 /* ------------------------------------------------------------------------- */
 
+// resolveVariantNames returns the component and variant names, given with '--component'
+// and '--name' or as arguments, e.g. `tempo variant new button neon`.
+func resolveVariantNames(cmd *cli.Command) (componentName, variantName string, err error) {
+	args, err := helpers.ResolvePositionalArgs(cmd, "component", "name")
+	if err != nil {
+		return "", "", err
+	}
+	if args[0] == "" {
+		return "", "", helpers.RequiredArgError("component")
+	}
+	if args[1] == "" {
+		return "", "", helpers.RequiredArgError("name")
+	}
+	return args[0], args[1], nil
+}
+
+// resolveVariantTemplates returns the action file and the templates folder of the
+// variants of the component: the ones of `tempo variant define --component` when
+// defined, or else the generic ones.
+func resolveVariantTemplates(data *generator.TemplateData) (actionsFile, templatesDir string, err error) {
+	scopedFile := filepath.Join(data.ActionsDir, generator.VariantActionFileName(data.ComponentName))
+	exists, err := utils.FileExistsFunc(scopedFile)
+	if err != nil {
+		return "", "", err
+	}
+	if exists {
+		return scopedFile, generator.ComponentVariantTemplatesDir(data.ComponentName), nil
+	}
+	return filepath.Join(data.ActionsDir, generator.VariantActionFileName("")), generator.VariantTemplatesDir, nil
+}
+
 // createVariantData initializes TemplateData for a variant.
-func createVariantData(cmd *cli.Command, cfg *config.Config) (*generator.TemplateData, error) {
+func createVariantData(cmd *cli.Command, cfg *config.Config, componentName, variantName string) (*generator.TemplateData, error) {
 	data, err := createBaseTemplateData(cmd, cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	// Add variant-specific fields
-	data.VariantName = cmd.String("name")
-	data.ComponentName = gonameprovider.ToGoPackageName(cmd.String("component"))
+	data.VariantName = variantName
+	data.ComponentName = gonameprovider.ToGoPackageName(componentName)
 	return data, nil
 }
 
@@ -229,22 +358,48 @@ func createBaseTemplateData(cmd *cli.Command, cfg *config.Config) (*generator.Te
 	}
 
 	TemplatesDir, ActionsDir := config.DerivedFolderPaths(cfg.TempoRoot)
-	isWithJs := resolver.ResolveBool(cmd.Bool("js"), cfg.App.WithJs)
+	isWithJs := resolver.ResolveBool(cmd.Bool("js"), cfg.App.JsEnabled())
 	isForce := cmd.Bool("force")
 	isDryRun := cmd.Bool("dry-run")
 
+	// User data: config, then --data-file, then --data
+	cliUserData, err := generator.ResolveCLIUserData(cmd.String("data-file"), cmd.StringSlice("data"))
+	if err != nil {
+		return nil, err
+	}
+	userData := cfg.Templates.UserData
+	if cliUserData != nil {
+		userData = generator.MergeUserData(userData, cliUserData)
+	}
+
 	// Initialize common fields
-	return &generator.TemplateData{
-		TemplatesDir: TemplatesDir,
-		ActionsDir:   ActionsDir,
-		GoModule:     cfg.App.GoModule,
-		GoPackage:    goPackage,
-		AssetsDir:    assetsDir,
-		WithJs:       isWithJs,
-		CssLayer:     cfg.App.CssLayer,
-		GuardMarker:  cfg.Templates.GuardMarker,
-		Force:        isForce,
-		DryRun:       isDryRun,
-		UserData:     cfg.Templates.UserData,
-	}, nil
+	if err := cfg.Layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	data := &generator.TemplateData{
+		TemplatesDir:       TemplatesDir,
+		ActionsDir:         ActionsDir,
+		GoModule:           cfg.App.GoModule,
+		GoModuleOptional:   !cfg.App.RequiresGoModule(),
+		GoPackage:          goPackage,
+		AssetsDir:          assetsDir,
+		StylesDir:          cfg.Layout.Styles(),
+		VariantsDir:        cfg.Layout.Variants(),
+		ScriptsDir:         cfg.Layout.Scripts(),
+		WithJs:             isWithJs,
+		CssLayer:           cfg.App.CssLayer,
+		GuardMarker:        cfg.Templates.GuardMarker,
+		Watermark:          cfg.Templates.Watermark,
+		Force:              isForce,
+		DryRun:             isDryRun,
+		UserData:           userData,
+		CLIUserData:        cliUserData,
+		Header:             cfg.Templates.Header,
+		FileModes:          cfg.FileModes,
+		TemplateExtensions: cfg.Templates.Extensions,
+		RenderCache:        rendercache.Open(cfg.TempoRoot, cfg.Templates.RenderCacheEnabled(), cfg.Templates.FunctionProviders),
+	}
+	data.SetTemplateLayers(cfg.Templates.LayeredEnabled())
+	return data, nil
 }
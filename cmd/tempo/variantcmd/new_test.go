@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -132,6 +133,7 @@ func TestVariantCommand_NewSubCmd_DefaultConfig(t *testing.T) {
 		expectedFiles := []string{
 			filepath.Join(cfg.App.GoPackage, "button", "css", "variants", "neon.templ"),
 			filepath.Join(cfg.App.AssetsDir, "button", "css", "variants", "neon.css"),
+			filepath.Join(cfg.App.GoPackage, "button", "variants_gen.go"),
 		}
 		testutils.ValidateGeneratedFiles(t, expectedFiles)
 	})
@@ -699,7 +701,7 @@ func TestVariantCommand_NewSubCmd_MissingFolders(t *testing.T) {
 		t.Fatalf("Failed to remove variant directory %q: %v", variantDir, err)
 	}
 
-	validate := validateVariantNewPrerequisites(cfg)
+	validate := validateVariantNewPrerequisites(cfg, logger.NewDefaultLogger())
 	_, err := validate(context.Background(), &cli.Command{})
 	if err == nil {
 		t.Fatal("Expected an error due to missing folders, but got nil")
@@ -984,7 +986,7 @@ func TestVariantCommand_NewSubCmd_validateVariantNewPrerequisites(t *testing.T)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			validate := validateVariantNewPrerequisites(cfg)
+			validate := validateVariantNewPrerequisites(cfg, logger.NewDefaultLogger())
 			_, err := validate(context.Background(), &cli.Command{})
 
 			if len(tt.missingFolders) == 0 {
@@ -1081,3 +1083,198 @@ func TestVariantCommand_NewSubCmd_Func_createBaseTemplateData_UserData(t *testin
 		t.Errorf("Expected UserData.config.option1 = 'value1', got: %v", val)
 	}
 }
+
+func TestVariantCommand_NewSubCmd_PositionalArgs(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			componentcmd.SetupComponentCommand(cliCtx),
+			SetupVariantCommand(cliCtx),
+		},
+	}
+
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to define component templates: %v", err)
+	}
+	if _, err := testutils.SetupVariantDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to define variant templates: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedFiles []string
+	}{
+		{
+			name:          "Component name as argument",
+			args:          []string{"tempo", "component", "new", "button"},
+			expectedFiles: []string{filepath.Join(cfg.App.GoPackage, "button", "button.templ")},
+		},
+		{
+			name:          "Component and variant names as arguments",
+			args:          []string{"tempo", "variant", "new", "button", "neon"},
+			expectedFiles: []string{filepath.Join(cfg.App.GoPackage, "button", "css", "variants", "neon.templ")},
+		},
+		{
+			name:          "Variant name as argument with component flag",
+			args:          []string{"tempo", "variant", "new", "--component", "button", "outline"},
+			expectedFiles: []string{filepath.Join(cfg.App.GoPackage, "button", "css", "variants", "outline.templ")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testutils.CaptureStdout(func() {
+				if err := cliApp.Run(context.Background(), tt.args); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			})
+			if err != nil {
+				t.Fatalf("Failed to capture stdout: %v", err)
+			}
+			testutils.ValidateGeneratedFiles(t, tt.expectedFiles)
+		})
+	}
+
+	t.Run("Missing variant name", func(t *testing.T) {
+		err := cliApp.Run(context.Background(), []string{"tempo", "variant", "new", "button"})
+		if err == nil || !strings.Contains(err.Error(), `Required flag "name" not set`) {
+			t.Errorf("Expected missing name error, got %v", err)
+		}
+	})
+}
+
+func TestVariantCommand_NewSubCmd_PrintData(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+	cliCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			componentcmd.SetupComponentCommand(cliCtx),
+			SetupVariantCommand(cliCtx),
+		},
+	}
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to define component templates: %v", err)
+	}
+	if _, err := testutils.SetupVariantDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to define variant templates: %v", err)
+	}
+
+	output, err := testutils.CaptureStdout(func() {
+		args := []string{"tempo", "variant", "new", "button", "neon", "--print-data", "yaml"}
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, output, []string{"component_name: button", "variant_name: neon", "variants_dir: css/variants"})
+	if _, err := os.Stat(filepath.Join(cfg.App.GoPackage, "button")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be generated, got: %v", err)
+	}
+}
+
+func TestVariantCommand_NewSubCmd_ComponentScopedTemplates(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			componentcmd.SetupComponentCommand(cliCtx),
+			SetupVariantCommand(cliCtx),
+		},
+	}
+	run := func(args ...string) {
+		t.Helper()
+		if _, err := testutils.CaptureStdout(func() {
+			if err := cliApp.Run(context.Background(), append([]string{"tempo"}, args...)); err != nil {
+				t.Fatalf("Unexpected error running %v: %v", args, err)
+			}
+		}); err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+	}
+
+	run("component", "define")
+	run("component", "new", "--name", "button")
+	run("component", "new", "--name", "card")
+	run("variant", "define")
+	run("variant", "define", "--component", "button")
+
+	scopedTemplate := filepath.Join(cfg.Paths.TemplatesDir, "component-variant", "button", "name.templ.gotxt")
+	scopedActions := filepath.Join(cfg.Paths.ActionsDir, "variant-button.json")
+	testutils.ValidateGeneratedFiles(t, []string{
+		scopedTemplate,
+		filepath.Join(cfg.Paths.TemplatesDir, "component-variant", "button", "assets", "css", "name.css.gotxt"),
+		scopedActions,
+	})
+
+	actions, err := os.ReadFile(scopedActions)
+	if err != nil {
+		t.Fatalf("Failed to read scoped action file: %v", err)
+	}
+	if !strings.Contains(string(actions), "component-variant/button/name.templ.gotxt") || strings.Contains(string(actions), `"source"`) {
+		t.Errorf("Unexpected scoped action file:\n%s", actions)
+	}
+
+	if err := os.WriteFile(scopedTemplate, []byte("// button variant {{ .VariantName }}\n"), 0644); err != nil {
+		t.Fatalf("Failed to customize scoped template: %v", err)
+	}
+
+	run("variant", "new", "button", "neon")
+	run("variant", "new", "card", "neon")
+
+	button, err := os.ReadFile(filepath.Join(cfg.App.GoPackage, "button", "css", "variants", "neon.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read button variant: %v", err)
+	}
+	if !strings.Contains(string(button), "// button variant neon") {
+		t.Errorf("Expected the button variant from the scoped template, got:\n%s", button)
+	}
+
+	card, err := os.ReadFile(filepath.Join(cfg.App.GoPackage, "card", "css", "variants", "neon.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read card variant: %v", err)
+	}
+	if strings.Contains(string(card), "button variant") {
+		t.Errorf("Expected the card variant from the generic template, got:\n%s", card)
+	}
+}
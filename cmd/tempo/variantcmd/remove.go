@@ -0,0 +1,140 @@
+package variantcmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/codegen"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/resolver"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// setupVariantRemoveSubCommand creates the "remove" subcommand deleting the files of a variant.
+func setupVariantRemoveSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:                   "remove",
+		Usage:                  "Remove the templ and CSS files of a variant and update the generated variant names",
+		UsageText:              "tempo variant remove [options] [component] [name]",
+		UseShortOptionHandling: true,
+		Flags:                  getRemoveFlags(),
+		Action:                 runVariantRemoveSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getRemoveFlags defines the CLI flags for the "remove" subcommand.
+func getRemoveFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "package",
+			Aliases: []string{"p"},
+			Usage:   "The Go package name where components are generated (default: components)",
+		},
+		&cli.StringFlag{
+			Name:    "assets",
+			Aliases: []string{"a"},
+			Usage:   "The directory where asset files (e.g., CSS, JS) are generated (default: assets)",
+		},
+		&cli.StringFlag{
+			Name:    "name",
+			Aliases: []string{"n"},
+			Usage:   "The name of the variant to remove (or the second argument)",
+		},
+		&cli.StringFlag{
+			Name:    "component",
+			Aliases: []string{"c"},
+			Usage:   "Name of the component owning the variant (or the first argument)",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Preview the removed files without making changes",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runVariantRemoveSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+		defer helpers.ResetLogger(cmdCtx.Logger)
+
+		// Step 1: Resolve the component and the variant files
+		componentName, variantName, err := resolveVariantNames(cmd)
+		if err != nil {
+			return err
+		}
+		goPackage, err := resolver.ResolveString(cmd.String("package"), cmdCtx.Config.App.GoPackage, "package", config.DefaultGoPackage, nil)
+		if err != nil {
+			return err
+		}
+		assetsDir, err := resolver.ResolveString(cmd.String("assets"), cmdCtx.Config.App.AssetsDir, "assets folder", config.DefaultAssetsDir, nil)
+		if err != nil {
+			return err
+		}
+
+		componentName = gonameprovider.ToGoPackageName(componentName)
+		fileName := gonameprovider.ToGoUnexportedName(variantName)
+		componentFolderPath := filepath.Join(goPackage, componentName)
+		variantsDir := filepath.FromSlash(cmdCtx.Config.Layout.Variants())
+		files := []string{
+			filepath.Join(componentFolderPath, variantsDir, fileName+".templ"),
+			filepath.Join(assetsDir, componentName, variantsDir, fileName+".css"),
+		}
+
+		// Step 2: Ensure the variant exists
+		var existing []string
+		for _, file := range files {
+			exists, err := utils.FileExistsFunc(file)
+			if err != nil {
+				return err
+			}
+			if exists {
+				existing = append(existing, file)
+			}
+		}
+		if len(existing) == 0 {
+			return apperrors.Wrap("Cannot remove variant: Variant does not exist", variantName, componentName)
+		}
+
+		if cmd.Bool("dry-run") {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.")
+			for _, file := range existing {
+				cmdCtx.Logger.Info("Would remove file").WithAttrs("path", file)
+			}
+			return nil
+		}
+
+		// Step 3: Remove the files and update the generated variant names
+		for _, file := range existing {
+			if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return apperrors.Wrap("failed to remove file", err, file)
+			}
+		}
+		if _, err := codegen.WriteVariantsFile(componentFolderPath, variantsDir); err != nil {
+			return err
+		}
+
+		cmdCtx.Logger.Success("Variant removed").
+			WithAttrs("variant", variantName, "component", componentName, "files", len(existing))
+		cmdCtx.Summary = map[string]any{"variant": variantName, "component": componentName, "removed": existing}
+		return nil
+	}
+}
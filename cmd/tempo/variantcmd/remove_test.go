@@ -0,0 +1,111 @@
+package variantcmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/cmd/tempo/componentcmd"
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+func TestVariantCommand_RemoveSubCmd(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+	cfg := testutils.SetupConfig(tempDir, nil)
+	if err := testutils.WriteConfigToFile(filepath.Join(tempDir, "tempo.yaml"), cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+	cliCtx := &app.AppContext{Logger: logger.NewDefaultLogger(), Config: cfg, CWD: tempDir}
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			componentcmd.SetupComponentCommand(cliCtx),
+			SetupVariantCommand(cliCtx),
+		},
+	}
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		var runErr error
+		output, err := testutils.CaptureStdout(func() {
+			runErr = cliApp.Run(context.Background(), append([]string{"tempo"}, args...))
+		})
+		if err != nil {
+			t.Fatalf("Failed to capture stdout: %v", err)
+		}
+		return output, runErr
+	}
+
+	if _, err := testutils.SetupComponentDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to define component: %v", err)
+	}
+	if _, err := testutils.SetupVariantDefine(cliApp, t); err != nil {
+		t.Fatalf("Failed to define variant: %v", err)
+	}
+	for _, args := range [][]string{
+		{"component", "new", "--name", "button"},
+		{"variant", "new", "button", "neon"},
+		{"variant", "new", "button", "outline"},
+	} {
+		if _, err := run(t, args...); err != nil {
+			t.Fatalf("Unexpected error running %v: %v", args, err)
+		}
+	}
+
+	genFile := filepath.Join(cfg.App.GoPackage, "button", "variants_gen.go")
+	templFile := filepath.Join(cfg.App.GoPackage, "button", "css", "variants", "neon.templ")
+	cssFile := filepath.Join(cfg.App.AssetsDir, "button", "css", "variants", "neon.css")
+
+	t.Run("dry run", func(t *testing.T) {
+		output, err := run(t, "variant", "remove", "--dry-run", "button", "neon")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"Dry Run Mode", "Would remove file"})
+		testutils.ValidateGeneratedFiles(t, []string{templFile, cssFile})
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		output, err := run(t, "variant", "remove", "--component", "button", "--name", "neon")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		testutils.ValidateCLIOutput(t, output, []string{"Variant removed"})
+		for _, file := range []string{templFile, cssFile} {
+			if exists, _ := utils.FileExistsFunc(file); exists {
+				t.Errorf("Expected %s to be removed", file)
+			}
+		}
+
+		content, err := os.ReadFile(genFile)
+		if err != nil {
+			t.Fatalf("Failed to read variants file: %v", err)
+		}
+		if strings.Contains(string(content), "VariantNeon") || !strings.Contains(string(content), "VariantOutline") {
+			t.Errorf("Expected only the outline variant, got:\n%s", content)
+		}
+	})
+
+	t.Run("missing variant", func(t *testing.T) {
+		if _, err := run(t, "variant", "remove", "button", "neon"); err == nil {
+			t.Errorf("Expected an error for a missing variant")
+		}
+	})
+
+	t.Run("last variant", func(t *testing.T) {
+		if _, err := run(t, "variant", "remove", "button", "outline"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if exists, _ := utils.FileExistsFunc(genFile); exists {
+			t.Errorf("Expected the variants file to be removed with the last variant")
+		}
+	})
+}
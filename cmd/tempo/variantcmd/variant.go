@@ -14,11 +14,13 @@ func SetupVariantCommand(cmdCtx *app.AppContext) *cli.Command {
 		Usage:     "Define variant templates and generate instances from them",
 		UsageText: "tempo variant <subcommand> [options] [arguments]",
 		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
-			return ctx, app.IsTempoProject(cmdCtx.CWD)
+			return ctx, cmdCtx.CheckTempoProject(cmdCtx.Config.App.RequiresGoModule())
 		},
 		Commands: []*cli.Command{
 			setupVariantDefineSubCommand(cmdCtx),
 			setupVariantNewSubCommand(cmdCtx),
+			setupVariantListSubCommand(cmdCtx),
+			setupVariantRemoveSubCommand(cmdCtx),
 		},
 	}
 }
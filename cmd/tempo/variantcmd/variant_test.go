@@ -32,7 +32,7 @@ func TestSetupVariantCommand(t *testing.T) {
 	}
 
 	// Check Subcommands Exist
-	subcommands := map[string]bool{"define": false, "new": false}
+	subcommands := map[string]bool{"define": false, "new": false, "list": false, "remove": false}
 	for _, sub := range command.Commands {
 		if _, exists := subcommands[sub.Name]; exists {
 			subcommands[sub.Name] = true
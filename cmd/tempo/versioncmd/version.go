@@ -0,0 +1,110 @@
+package versioncmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"text/tabwriter"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/version"
+	"github.com/urfave/cli/v3"
+)
+
+// releaseURL is the endpoint queried by `--check`, replaced in tests.
+var releaseURL = version.LatestReleaseURL
+
+// versionReport is the JSON output of the "version" command.
+type versionReport struct {
+	version.BuildInfo
+	Update *version.UpdateCheck `json:"update,omitempty"`
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+// SetupVersionCommand creates the "version" command printing the build metadata.
+func SetupVersionCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "version",
+		Usage:     "Print the version and build metadata of tempo",
+		UsageText: "tempo version [--check] [--json]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "check",
+				Usage: "Query the GitHub releases to tell whether a newer tempo exists",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Print the version as JSON",
+			},
+		},
+		Action: runVersionCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runVersionCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		report := versionReport{BuildInfo: version.GetBuildInfo()}
+
+		if cmd.Bool("check") {
+			check, err := version.CheckForUpdate(ctx, http.DefaultClient, releaseURL, report.Version)
+			if err != nil {
+				return apperrors.Wrap("failed to check for updates", err)
+			}
+			report.Update = &check
+		}
+
+		if cmd.Bool("json") {
+			return writeJSON(os.Stdout, report)
+		}
+		if err := writeReport(os.Stdout, report.BuildInfo); err != nil {
+			return err
+		}
+
+		if report.Update != nil {
+			fmt.Println()
+			if report.Update.UpdateAvailable {
+				cmdCtx.Logger.Warning("A newer tempo is available").
+					WithAttrs("current", report.Update.Current, "latest", report.Update.Latest, "url", report.Update.URL)
+			} else {
+				cmdCtx.Logger.Success("tempo is up to date").WithAttrs("latest", report.Update.Latest)
+			}
+		}
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return apperrors.Wrap("failed to marshal version", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// writeReport writes the build metadata as an aligned list.
+func writeReport(w io.Writer, info version.BuildInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "tempo\tv%s\n", info.Version)
+	fmt.Fprintf(tw, "commit:\t%s\n", info.Commit)
+	fmt.Fprintf(tw, "built:\t%s\n", info.Date)
+	fmt.Fprintf(tw, "go:\t%s\n", info.GoVersion)
+	fmt.Fprintf(tw, "platform:\t%s\n", info.Platform)
+	return tw.Flush()
+}
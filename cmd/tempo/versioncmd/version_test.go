@@ -0,0 +1,57 @@
+package versioncmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/indaco/tempo/internal/version"
+	"github.com/urfave/cli/v3"
+)
+
+func TestVersionCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name": "v99.0.0", "html_url": "https://example.com/v99.0.0"}`))
+	}))
+	defer server.Close()
+
+	origURL := releaseURL
+	releaseURL = server.URL
+	defer func() { releaseURL = origURL }()
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupVersionCommand(&app.AppContext{Logger: logger.NewDefaultLogger()}),
+		},
+	}
+
+	current := "v" + version.GetVersion()
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{"text", []string{"tempo", "version"}, []string{"tempo", current, "commit:", "built:", "go:", "platform:"}},
+		{"json", []string{"tempo", "version", "--json"}, []string{`"version": "` + version.GetVersion() + `"`, `"go_version"`, `"platform"`}},
+		{"check", []string{"tempo", "version", "--check"}, []string{"A newer tempo is available", "latest: v99.0.0"}},
+		{"check json", []string{"tempo", "version", "--check", "--json"}, []string{`"latest": "v99.0.0"`, `"update_available": true`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := testutils.CaptureStdout(func() {
+				if err := cliApp.Run(context.Background(), tt.args); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			})
+			if err != nil {
+				t.Fatalf("Failed to capture stdout: %v", err)
+			}
+			testutils.ValidateCLIOutput(t, output, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package webcomponentcmd
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupWebComponentDefineSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	flags := getDefineFlags()
+
+	return &cli.Command{
+		Name:                   "define",
+		Usage:                  "Define a new web component template",
+		UsageText:              "tempo webcomponent define [options]",
+		UseShortOptionHandling: true,
+		Flags:                  flags,
+		Action:                 runWebComponentDefineSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getDefineFlags generates the core CLI flags shared across subcommands.
+func getDefineFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Force overwriting if already exists",
+		},
+		helpers.YesFlag(),
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Preview actions without making changes",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runWebComponentDefineSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+
+		// Step 1: Create template data
+		data := createTemplateData(cmd, cmdCtx.Config)
+
+		if data.DryRun {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.")
+		}
+
+		// Step 2: Check if templates folder for web component already exists
+		// Display a warning and stop if `--force` is not set
+		outputPath := filepath.Join(data.TemplatesDir, "webcomponent")
+		exists, err := utils.DirExists(outputPath)
+		if err != nil {
+			return err
+		} else if exists {
+			helpers.CheckEntityForDefine("webcomponent", outputPath, data.Force, helpers.AssumeYes(cmd, cmdCtx.Config), cmdCtx.Logger)
+
+			if !data.Force {
+				return nil
+			}
+		}
+
+		// Step 3: Retrieve web component actions
+		builtInActions, err := generator.BuildWebComponentActions(generator.CopyActionID, data.Force)
+		if err != nil {
+			return apperrors.Wrap("Failed to build web component actions", err)
+		}
+
+		// Step 4: Process actions
+		if err := generator.ProcessActions(ctx, cmdCtx.Logger, builtInActions, data); err != nil {
+			return apperrors.Wrap("Failed to process actions for web component", err)
+		}
+
+		if !data.DryRun {
+			// Step 5: Log success and asset information
+			helpers.LogSuccessMessages("webcomponent", cmdCtx.Config, cmdCtx.Logger)
+
+			// Step 6: Generate JSON action file
+			if err := generator.GenerateActionFile("webcomponent", data, builtInActions, cmdCtx.Logger); err != nil {
+				return err
+			}
+		}
+		helpers.ResetLogger(cmdCtx.Logger)
+
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// createTemplateData initializes the common fields of TemplateData
+// by resolving configuration values and CLI flags.
+// Web components always ship JavaScript, so WithJs is always enabled.
+func createTemplateData(cmd *cli.Command, cfg *config.Config) *generator.TemplateData {
+	TemplatesDir, ActionsDir := config.DerivedFolderPaths(cfg.TempoRoot)
+
+	data := &generator.TemplateData{
+		TemplatesDir:       TemplatesDir,
+		ActionsDir:         ActionsDir,
+		WithJs:             true,
+		Force:              cmd.Bool("force"),
+		DryRun:             cmd.Bool("dry-run"),
+		TemplateExtensions: cfg.Templates.Extensions,
+	}
+	data.SetTemplateLayers(cfg.Templates.LayeredEnabled())
+	return data
+}
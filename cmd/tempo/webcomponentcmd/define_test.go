@@ -0,0 +1,117 @@
+package webcomponentcmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/urfave/cli/v3"
+)
+
+// setupWebComponentTestApp prepares a tempo project in a temporary folder and returns
+// the CLI app together with its configuration.
+func setupWebComponentTestApp(t *testing.T) (*cli.Command, *config.Config) {
+	t.Helper()
+	tempDir := t.TempDir()
+
+	if err := testutils.CreateModFile(tempDir); err != nil {
+		t.Fatalf("Failed to create go.mod file: %v", err)
+	}
+
+	cfg := testutils.SetupConfig(tempDir, nil)
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: cfg,
+		CWD:    tempDir,
+	}
+
+	configPath := filepath.Join(tempDir, "tempo.yaml")
+	if err := testutils.WriteConfigToFile(configPath, cfg); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+
+	cliApp := &cli.Command{
+		Commands: []*cli.Command{
+			SetupWebComponentCommand(cliCtx),
+		},
+	}
+
+	return cliApp, cfg
+}
+
+func TestWebComponentCommand_DefineSubCmd(t *testing.T) {
+	cliApp, cfg := setupWebComponentTestApp(t)
+
+	output, err := testutils.CaptureStdout(func() {
+		args := []string{"tempo", "webcomponent", "define"}
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, output, []string{
+		"✔ Templates for the web component and assets (JS) have been created",
+		"✔ Tempo action file for 'webcomponent' has been created",
+	})
+
+	testutils.ValidateGeneratedFiles(t, []string{
+		filepath.Join(cfg.Paths.ActionsDir, "webcomponent.json"),
+		filepath.Join(cfg.Paths.TemplatesDir, "webcomponent", "templ", "element.templ.gotxt"),
+		filepath.Join(cfg.Paths.TemplatesDir, "webcomponent", "templ", "js", "element.templ.gotxt"),
+		filepath.Join(cfg.Paths.TemplatesDir, "webcomponent", "assets", "js", "element.js.gotxt"),
+	})
+}
+
+func TestWebComponentCommand_DefineSubCmd_WithDryRun(t *testing.T) {
+	cliApp, cfg := setupWebComponentTestApp(t)
+
+	args := []string{"tempo", "webcomponent", "define", "--dry-run"}
+	if err := cliApp.Run(context.Background(), args); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	notExpected := []string{
+		filepath.Join(cfg.Paths.ActionsDir, "webcomponent.json"),
+		filepath.Join(cfg.Paths.TemplatesDir, "webcomponent", "templ", "element.templ.gotxt"),
+	}
+	for _, path := range notExpected {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected file should not exist in dry-run mode: %s", path)
+		}
+	}
+}
+
+func TestWebComponentCommand_DefineSubCmd_AlreadyExists(t *testing.T) {
+	cliApp, _ := setupWebComponentTestApp(t)
+
+	args := []string{"tempo", "webcomponent", "define"}
+	if _, err := testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := cliApp.Run(context.Background(), args); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, output, []string{
+		"Templates for 'webcomponent' already exist.",
+		"Use '--force' to overwrite them.",
+	})
+}
@@ -0,0 +1,327 @@
+package webcomponentcmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/helpers"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/rendercache"
+	"github.com/indaco/tempo/internal/resolver"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/textprovider"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/validation"
+	"github.com/urfave/cli/v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Command Setup                                                             */
+/* ------------------------------------------------------------------------- */
+
+func setupWebComponentNewSubCommand(cmdCtx *app.AppContext) *cli.Command {
+	flags := getNewFlags()
+	return &cli.Command{
+		Name:                   "new",
+		Usage:                  "Generate a custom element and its templ wrapper from a template",
+		UsageText:              "tempo webcomponent new [options] [name]",
+		UseShortOptionHandling: true,
+		Flags:                  flags,
+		Before:                 validateWebComponentNewPrerequisites(cmdCtx.Config, cmdCtx.Logger),
+		Action:                 runWebComponentNewSubCommand(cmdCtx),
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Flag Generation                                                           */
+/* ------------------------------------------------------------------------- */
+
+// getNewFlags generates the core CLI flags shared across subcommands.
+func getNewFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "package",
+			Aliases: []string{"p"},
+			Usage:   "The Go package name where components will be generated (default: components)",
+		},
+		&cli.StringFlag{
+			Name:    "assets",
+			Aliases: []string{"a"},
+			Usage:   "The directory where asset files (e.g., CSS, JS) will be generated (default: assets)",
+		},
+		&cli.StringFlag{
+			Name:    "name",
+			Aliases: []string{"n"},
+			Usage:   "Name of the web component (or the first argument)",
+		},
+		&cli.StringFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Custom element tag name (default: kebab-cased name, prefixed with 'x-' when it has no hyphen)",
+		},
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Force overwriting if already exists",
+		},
+		helpers.YesFlag(),
+		&cli.BoolFlag{
+			Name:  "no-diff",
+			Usage: "Skip the diff of the changed files with '--force'",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Preview actions without making changes",
+		},
+		&cli.BoolFlag{
+			Name:  "a11y-audit",
+			Usage: "Check the generated .templ files for accessibility issues, e.g. <img> without alt (default: templates.a11y_audit)",
+		},
+		&cli.BoolFlag{
+			Name:  "trace",
+			Usage: "Log each executed action with its destination, elapsed time and rendered bytes",
+		},
+		&cli.StringFlag{
+			Name:  "trace-file",
+			Usage: "Export the action trace to a JSON file",
+		},
+		&cli.BoolFlag{
+			Name:  "git-add",
+			Usage: "Stage the files created or modified by the command",
+		},
+		&cli.BoolFlag{
+			Name:  "git-commit",
+			Usage: "Stage and commit the files created or modified by the command",
+		},
+		&cli.StringFlag{
+			Name:    "message",
+			Aliases: []string{"m"},
+			Usage:   "Commit message used with --git-commit (default: \"" + helpers.DefaultGitCommitMessage + "\")",
+		},
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Command Runner                                                            */
+/* ------------------------------------------------------------------------- */
+
+func runWebComponentNewSubCommand(cmdCtx *app.AppContext) func(ctx context.Context, cmd *cli.Command) error {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		helpers.EnableLoggerIndentation(cmdCtx.Logger)
+
+		// Step 1: Create template data, with the name given as flag or as argument
+		args, err := helpers.ResolvePositionalArgs(cmd, "name")
+		if err != nil {
+			return err
+		}
+		if args[0] == "" {
+			return helpers.RequiredArgError("name")
+		}
+		data, err := createWebComponentData(cmd, cmdCtx.Config, args[0])
+		if err != nil {
+			return apperrors.Wrap("Failed to create template data for web component", err)
+		}
+		if err := helpers.ResolveComponentImportPath(cmdCtx, data); err != nil {
+			return err
+		}
+
+		if data.DryRun {
+			cmdCtx.Logger.Info("Dry Run Mode: No changes will be made.\n")
+			cmdCtx.Logger.Reset()
+			return nil
+		}
+
+		// Step 2: Check if "webcomponent define" command has been executed
+		pathToActionsFile := filepath.Join(data.ActionsDir, "webcomponent.json")
+		exists, err := utils.FileExistsFunc(pathToActionsFile)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return apperrors.WrapCode(apperrors.CodeTemplatesNotFound, "Cannot find actions folder. Did you run 'tempo webcomponent define' before?")
+		}
+
+		// Step 3: Check if the web component already exists
+		// Display a warning and stop if `--force` is not set
+		outputPath := filepath.Join(data.GoPackage, data.ComponentName)
+		if exists, err = utils.DirExists(outputPath); err != nil {
+			return err
+		} else if exists {
+			helpers.CheckEntityForNew("webcomponent", data.ComponentName, data.GoPackage, data.Force, helpers.AssumeYes(cmd, cmdCtx.Config), cmdCtx.Logger)
+
+			if !data.Force {
+				return nil
+			}
+
+			// Review the changes to the existing files before overwriting them
+			process := func(ctx context.Context) error {
+				return generator.ProcessEntityActions(ctx, cmdCtx.Logger, pathToActionsFile, data, cmdCtx.Config)
+			}
+			if err := helpers.ConfirmOverwrite(ctx, data.Force, cmd.Bool("no-diff"), helpers.AssumeYes(cmd, cmdCtx.Config), os.Stdout, process); err != nil {
+				cmdCtx.Logger.Reset()
+				return err
+			}
+		}
+
+		// Step 4: Retrieve and process actions, tracking the changed files for git
+		snapshot, err := helpers.StartGitTracking(cmdCtx.CWD, cmd.Bool("git-add"), cmd.Bool("git-commit"))
+		if err != nil {
+			return err
+		}
+		traceFile := cmd.String("trace-file")
+		ctx, tracer := helpers.StartActionTrace(ctx, cmd.Bool("trace"), traceFile, cmdCtx.Logger)
+		processErr := generator.ProcessEntityActions(ctx, cmdCtx.Logger, pathToActionsFile, data, cmdCtx.Config)
+		// The trace is exported even when the processing failed, but that failure wins
+		traceErr := helpers.FinishActionTrace(tracer, traceFile, cmdCtx.Logger)
+		if processErr != nil {
+			helpers.WarnTraceNotExported(cmdCtx.Logger, traceErr)
+			return apperrors.Wrap("failed to process actions for web component", processErr, data.ComponentName)
+		}
+		if traceErr != nil {
+			return traceErr
+		}
+
+		// Step 5: Log success and asset information
+		componentPath := filepath.Join(data.GoPackage, data.ComponentName)
+		assetPath := filepath.Join(data.AssetsDir, data.ComponentName)
+
+		cmdCtx.Logger.Success("Web component files have been created").
+			WithAttrs(
+				"component", data.ComponentName,
+				"tag", data.TagName,
+				"component_path", componentPath,
+				"asset_path", assetPath,
+			)
+		cmdCtx.Summary = map[string]any{"component": data.ComponentName, "tag": data.TagName, "component_path": componentPath}
+
+		// Step 6: Audit the generated markup for accessibility issues
+		if cmd.Bool("a11y-audit") || cmdCtx.Config.Templates.A11yAuditEnabled() {
+			if _, err := helpers.AuditAccessibility(componentPath, cmdCtx.Logger); err != nil {
+				return err
+			}
+		}
+
+		// Step 7: Stage or commit the changed files
+		if err := helpers.FinishGitTracking(snapshot, cmd.Bool("git-commit"), cmd.String("message"), cmdCtx.Logger); err != nil {
+			return err
+		}
+
+		cmdCtx.Logger.Reset()
+
+		return nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Prerequisites Validation                                                  */
+/* ------------------------------------------------------------------------- */
+
+// validateWebComponentNewPrerequisites checks prerequisites for the "webcomponent new" subcommand, including:
+// - Initialized Tempo project (inherited from the main webcomponent command).
+// - Existence of the web component templates folder, only warned about when strict mode is disabled.
+func validateWebComponentNewPrerequisites(cfg *config.Config, log logger.Logger) func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	return func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+		foldersToCheck := map[string]string{
+			"templates_directory": filepath.Join(cfg.Paths.TemplatesDir, "webcomponent"),
+		}
+
+		_, err := helpers.CheckPrerequisiteFolders(
+			cfg,
+			log,
+			foldersToCheck,
+			"Have you run 'tempo webcomponent define' to set up your web component templates?",
+			[]string{"tempo webcomponent -h"},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return ctx, nil
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* Helper Functions                                                          */
+/* ------------------------------------------------------------------------- */
+
+// createWebComponentData initializes TemplateData for a web component.
+func createWebComponentData(cmd *cli.Command, cfg *config.Config, name string) (*generator.TemplateData, error) {
+	goPackage, err := resolver.ResolveString(
+		cmd.String("package"),
+		cfg.App.GoPackage,
+		"package",
+		config.DefaultGoPackage,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	assetsDir, err := resolver.ResolveString(
+		cmd.String("assets"),
+		cfg.App.AssetsDir,
+		"assets folder",
+		config.DefaultAssetsDir,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tagName := resolveTagName(cmd.String("tag"), name)
+	if err := validation.ValidateCustomElementName(tagName); err != nil {
+		return nil, err
+	}
+
+	TemplatesDir, ActionsDir := config.DerivedFolderPaths(cfg.TempoRoot)
+
+	if err := cfg.Layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	data := &generator.TemplateData{
+		TemplatesDir:       TemplatesDir,
+		ActionsDir:         ActionsDir,
+		GoModule:           cfg.App.GoModule,
+		GoModuleOptional:   !cfg.App.RequiresGoModule(),
+		GoPackage:          goPackage,
+		ComponentName:      gonameprovider.ToGoPackageName(name),
+		TagName:            tagName,
+		AssetsDir:          assetsDir,
+		StylesDir:          cfg.Layout.Styles(),
+		VariantsDir:        cfg.Layout.Variants(),
+		ScriptsDir:         cfg.Layout.Scripts(),
+		WithJs:             true,
+		CssLayer:           cfg.App.CssLayer,
+		GuardMarker:        cfg.Templates.GuardMarker,
+		Watermark:          cfg.Templates.Watermark,
+		Force:              cmd.Bool("force"),
+		DryRun:             cmd.Bool("dry-run"),
+		UserData:           cfg.Templates.UserData,
+		Header:             cfg.Templates.Header,
+		FileModes:          cfg.FileModes,
+		TemplateExtensions: cfg.Templates.Extensions,
+		RenderCache:        rendercache.Open(cfg.TempoRoot, cfg.Templates.RenderCacheEnabled(), cfg.Templates.FunctionProviders),
+	}
+	data.SetTemplateLayers(cfg.Templates.LayeredEnabled())
+	return data, nil
+}
+
+// resolveTagName returns the explicit tag when set, otherwise derives it from the
+// component name. Custom element names require a hyphen, so "x-" is prepended when missing.
+func resolveTagName(tag, name string) string {
+	if tag != "" {
+		return tag
+	}
+
+	tagName := textprovider.KebabCase(name)
+	if !strings.Contains(tagName, "-") {
+		tagName = "x-" + tagName
+	}
+	return tagName
+}
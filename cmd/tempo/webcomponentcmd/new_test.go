@@ -0,0 +1,112 @@
+package webcomponentcmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/indaco/tempo/internal/validation"
+)
+
+func runWebComponentDefine(t *testing.T, cliAppRun func(args []string) error) {
+	t.Helper()
+	if _, err := testutils.CaptureStdout(func() {
+		if err := cliAppRun([]string{"tempo", "webcomponent", "define"}); err != nil {
+			t.Fatalf("Failed to run 'webcomponent define': %v", err)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+}
+
+func TestWebComponentCommand_NewSubCmd(t *testing.T) {
+	cliApp, cfg := setupWebComponentTestApp(t)
+	run := func(args []string) error { return cliApp.Run(context.Background(), args) }
+
+	runWebComponentDefine(t, run)
+
+	output, err := testutils.CaptureStdout(func() {
+		if err := run([]string{"tempo", "webcomponent", "new", "--name", "counterBox"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+
+	testutils.ValidateCLIOutput(t, output, []string{
+		"✔ Web component files have been created",
+		"tag: counter-box",
+	})
+
+	templFile := filepath.Join(cfg.App.GoPackage, "counter_box", "counter_box.templ")
+	jsFile := filepath.Join(cfg.App.AssetsDir, "counter_box", "js", "element.js")
+	testutils.ValidateGeneratedFiles(t, []string{
+		templFile,
+		jsFile,
+		filepath.Join(cfg.App.GoPackage, "counter_box", "js", "element.templ"),
+	})
+
+	content, err := os.ReadFile(jsFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated JS file: %v", err)
+	}
+	if !strings.Contains(string(content), "customElements.define('counter-box'") {
+		t.Errorf("Expected custom element registration for 'counter-box', got:\n%s", content)
+	}
+
+	content, err = os.ReadFile(templFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated templ file: %v", err)
+	}
+	if !strings.Contains(string(content), "<counter-box { attrs... }>") {
+		t.Errorf("Expected templ wrapper to emit <counter-box>, got:\n%s", content)
+	}
+}
+
+func TestWebComponentCommand_NewSubCmd_InvalidTag(t *testing.T) {
+	cliApp, _ := setupWebComponentTestApp(t)
+	run := func(args []string) error { return cliApp.Run(context.Background(), args) }
+
+	runWebComponentDefine(t, run)
+
+	err := run([]string{"tempo", "webcomponent", "new", "--name", "counter", "--tag", "Counter"})
+	if !errors.Is(err, validation.ErrInvalidElementName) {
+		t.Fatalf("Expected ErrInvalidElementName, got %v", err)
+	}
+}
+
+func TestWebComponentCommand_NewSubCmd_MissingTemplates(t *testing.T) {
+	cliApp, _ := setupWebComponentTestApp(t)
+
+	err := cliApp.Run(context.Background(), []string{"tempo", "webcomponent", "new", "--name", "counter"})
+	if err == nil {
+		t.Fatal("Expected error when web component templates are missing, got nil")
+	}
+}
+
+func TestResolveTagName(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		compName string
+		expected string
+	}{
+		{name: "explicit tag", tag: "my-counter", compName: "counter", expected: "my-counter"},
+		{name: "hyphenated name", tag: "", compName: "date-picker", expected: "date-picker"},
+		{name: "camelCase name", tag: "", compName: "datePicker", expected: "date-picker"},
+		{name: "single word name", tag: "", compName: "counter", expected: "x-counter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveTagName(tt.tag, tt.compName); got != tt.expected {
+				t.Errorf("resolveTagName(%q, %q) = %q, want %q", tt.tag, tt.compName, got, tt.expected)
+			}
+		})
+	}
+}
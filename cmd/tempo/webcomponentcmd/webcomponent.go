@@ -0,0 +1,24 @@
+package webcomponentcmd
+
+import (
+	"context"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/urfave/cli/v3"
+)
+
+// SetupWebComponentCommand creates the "webcomponent" command with its "define" and "new" subcommand.
+func SetupWebComponentCommand(cmdCtx *app.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:      "webcomponent",
+		Usage:     "Define web component templates and generate custom elements from them",
+		UsageText: "tempo webcomponent <subcommand> [options] [arguments]",
+		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
+			return ctx, cmdCtx.IsTempoProject()
+		},
+		Commands: []*cli.Command{
+			setupWebComponentDefineSubCommand(cmdCtx),
+			setupWebComponentNewSubCommand(cmdCtx),
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package webcomponentcmd
+
+import (
+	"testing"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
+)
+
+func TestSetupWebComponentCommand(t *testing.T) {
+	cliCtx := &app.AppContext{
+		Logger: logger.NewDefaultLogger(),
+		Config: config.DefaultConfig(),
+		CWD:    t.TempDir(),
+	}
+
+	command := SetupWebComponentCommand(cliCtx)
+	if command == nil {
+		t.Fatal("SetupWebComponentCommand returned nil")
+	}
+
+	// Check Command Name
+	if command.Name != "webcomponent" {
+		t.Errorf("Expected command name 'webcomponent', got '%s'", command.Name)
+	}
+
+	// Check Subcommands Exist
+	subcommands := map[string]bool{"define": false, "new": false}
+	for _, sub := range command.Commands {
+		if _, exists := subcommands[sub.Name]; exists {
+			subcommands[sub.Name] = true
+		}
+	}
+
+	for name, found := range subcommands {
+		if !found {
+			t.Errorf("Expected subcommand '%s' to be present", name)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Package a11y audits the markup of generated .templ files for obvious accessibility
+// issues, such as images without alternative text. The checks are rules: the built-in
+// ones are registered by default and more can be added with Register.
+package a11y
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Finding is an accessibility issue found in a file.
+type Finding struct {
+	Rule    string
+	File    string
+	Line    int
+	Message string
+}
+
+// Rule checks the markup of a file. Check returns the findings of content, with their
+// Line and Message set: Audit fills in the rule name and the file.
+type Rule interface {
+	Name() string
+	Check(content string) []Finding
+}
+
+var (
+	rules = []Rule{
+		NewMissingAttrRule("img-alt", "img", "alt", "<img> has no alt attribute; use alt=\"\" for decorative images"),
+		NewMissingAttrRule("button-type", "button", "type", "<button> has no type attribute; set type=\"button\" unless it submits a form"),
+	}
+	mu sync.RWMutex
+)
+
+/* ------------------------------------------------------------------------- */
+/* REGISTRY                                                                  */
+/* ------------------------------------------------------------------------- */
+
+// Register adds a rule, replacing the registered rule with the same name.
+func Register(rule Rule) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	rules = slices.DeleteFunc(rules, func(r Rule) bool { return r.Name() == rule.Name() })
+	rules = append(rules, rule)
+}
+
+// Rules returns the registered rules.
+func Rules() []Rule {
+	mu.RLock()
+	defer mu.RUnlock()
+	return slices.Clone(rules)
+}
+
+/* ------------------------------------------------------------------------- */
+/* AUDIT                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Audit checks the content of file against the rules.
+func Audit(file, content string, rules []Rule) []Finding {
+	var findings []Finding
+	for _, rule := range rules {
+		for _, f := range rule.Check(content) {
+			f.Rule, f.File = rule.Name(), file
+			findings = append(findings, f)
+		}
+	}
+	slices.SortStableFunc(findings, func(a, b Finding) int { return a.Line - b.Line })
+	return findings
+}
+
+// AuditDir checks the .templ files of dir against the rules.
+func AuditDir(dir string, rules []Rule) ([]Finding, error) {
+	var findings []Finding
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".templ" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, Audit(path, string(content), rules)...)
+		return nil
+	})
+	if err != nil {
+		return findings, apperrors.Wrap("failed to audit folder", err, dir)
+	}
+	return findings, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* BUILT-IN RULES                                                            */
+/* ------------------------------------------------------------------------- */
+
+// attrSpread matches a templ attribute spread, e.g. { attrs... }, which may set any attribute.
+var attrSpread = regexp.MustCompile(`\{\s*[\w.]+\s*\.\.\.\s*\}`)
+
+// MissingAttrRule reports the elements of a tag lacking an attribute. Elements with an
+// attribute spread are skipped, as the attribute may be set at render time.
+type MissingAttrRule struct {
+	name    string
+	attr    *regexp.Regexp
+	tag     *regexp.Regexp
+	message string
+}
+
+// NewMissingAttrRule returns the rule reporting the <tag> elements without attr.
+func NewMissingAttrRule(name, tag, attr, message string) *MissingAttrRule {
+	return &MissingAttrRule{
+		name:    name,
+		tag:     regexp.MustCompile(`(?i)<` + regexp.QuoteMeta(tag) + `(\s[^>]*)?/?>`),
+		attr:    regexp.MustCompile(`(?i)(^|\s)` + regexp.QuoteMeta(attr) + `(\s*=|\s|/|$)`),
+		message: message,
+	}
+}
+
+// Name returns the rule name.
+func (r *MissingAttrRule) Name() string { return r.name }
+
+// Check returns the elements of content lacking the attribute.
+func (r *MissingAttrRule) Check(content string) []Finding {
+	var findings []Finding
+	for _, loc := range r.tag.FindAllStringSubmatchIndex(content, -1) {
+		attrs := ""
+		if loc[2] >= 0 {
+			attrs = content[loc[2]:loc[3]]
+		}
+		if r.attr.MatchString(attrs) || attrSpread.MatchString(attrs) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Line:    strings.Count(content[:loc[0]], "\n") + 1,
+			Message: r.message,
+		})
+	}
+	return findings
+}
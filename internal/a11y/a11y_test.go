@@ -0,0 +1,88 @@
+package a11y
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAudit_BuiltInRules(t *testing.T) {
+	content := `templ Card(src string, attrs templ.Attributes) {
+	<img src={ src }>
+	<img src={ src } alt="">
+	<img src={ src } { attrs... }/>
+	<button>Close</button>
+	<button type="button">Open</button>
+	<button
+		class="btn"
+	>Submit</button>
+}`
+
+	findings := Audit("card.templ", content, Rules())
+
+	want := []Finding{
+		{Rule: "img-alt", File: "card.templ", Line: 2},
+		{Rule: "button-type", File: "card.templ", Line: 5},
+		{Rule: "button-type", File: "card.templ", Line: 7},
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("Expected %d findings, got %+v", len(want), findings)
+	}
+	for i, f := range findings {
+		if f.Rule != want[i].Rule || f.File != want[i].File || f.Line != want[i].Line || f.Message == "" {
+			t.Errorf("Finding %d: expected %+v, got %+v", i, want[i], f)
+		}
+	}
+}
+
+type todoRule struct{}
+
+func (todoRule) Name() string { return "no-todo" }
+
+func (todoRule) Check(content string) []Finding {
+	if content == "TODO" {
+		return []Finding{{Line: 1, Message: "leftover TODO"}}
+	}
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	original := Rules()
+	defer func() { rules = original }()
+
+	Register(todoRule{})
+	Register(todoRule{})
+	if got := len(Rules()); got != len(original)+1 {
+		t.Fatalf("Expected the rule to be registered once, got %d rules", got)
+	}
+
+	if findings := Audit("x.templ", "TODO", Rules()); len(findings) != 1 || findings[0].Rule != "no-todo" {
+		t.Errorf("Expected the registered rule to report, got %+v", findings)
+	}
+}
+
+func TestAuditDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"button.templ":      "<button>Go</button>",
+		"button_templ.go":   "<button>Go</button>",
+		"nested/icon.templ": `<img src="icon.svg" alt="">`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	findings, err := AuditDir(dir, Rules())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].File != filepath.Join(dir, "button.templ") {
+		t.Errorf("Expected one finding in button.templ, got %+v", findings)
+	}
+}
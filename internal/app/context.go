@@ -3,10 +3,48 @@ package app
 import (
 	"github.com/indaco/tempo/internal/config"
 	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/utils"
 )
 
 type AppContext struct {
-	Logger logger.Logger
-	Config *config.Config
-	CWD    string
+	Logger      logger.Logger
+	Config      *config.Config
+	ConfigFile  string // Project config file set with --config; tempo.yaml or tempo.yml of CWD when empty
+	CWD         string
+	ModuleRoot  string                 // Go module root set with --module-root; auto-detected when empty
+	ModuleNames *utils.ModuleNameCache // Module names read from go.mod; read on every call when nil
+	Summary     map[string]any         // Outcome of the run set by the command, sent with the notifications
+
+	resolved resolvedModule // Module root last resolved by ResolveModule
+}
+
+// resolvedModule is the module root resolved for a working dir and a --module-root override.
+type resolvedModule struct {
+	cwd, moduleRoot, root string
+}
+
+// ResolveModule returns the root directory and the module path of the Go module
+// tempo works with, honoring the --module-root override stored in the context.
+// The root is resolved once per working dir and override, and the module path is
+// read from the cache until go.mod changes.
+func (c *AppContext) ResolveModule() (root, name string, err error) {
+	if r := c.resolved; r.root != "" && r.cwd == c.CWD && r.moduleRoot == c.ModuleRoot {
+		root = r.root
+	} else {
+		root, err = utils.ResolveModuleRoot(c.CWD, c.ModuleRoot)
+		if err != nil {
+			return "", "", err
+		}
+		c.resolved = resolvedModule{cwd: c.CWD, moduleRoot: c.ModuleRoot, root: root}
+	}
+
+	if c.ModuleNames == nil {
+		name, err = utils.GetModuleName(root)
+	} else {
+		name, err = c.ModuleNames.Name(root)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return root, name, nil
 }
@@ -1,35 +1,85 @@
 package app
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
-
-	"github.com/indaco/tempo/internal/config"
-	"github.com/indaco/tempo/internal/logger"
 )
 
-func TestCliCmdContextInitialization(t *testing.T) {
-	// Create mock dependencies
-	mockLogger := logger.NewDefaultLogger()
-	mockConfig := &config.Config{}
-	mockCWD := "/mock/directory"
+func TestAppContext_ResolveModule(t *testing.T) {
+	repo := t.TempDir()
+	for module, goMod := range map[string]string{
+		"api": "module example.com/api\n",
+		"web": "module example.com/web\n",
+	} {
+		if err := os.MkdirAll(filepath.Join(repo, module, "sub"), 0755); err != nil {
+			t.Fatalf("Failed to create module dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(repo, module, "go.mod"), []byte(goMod), 0644); err != nil {
+			t.Fatalf("Failed to create go.mod: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		cwd          string
+		moduleRoot   string
+		expectedRoot string
+		expectedName string
+		expectError  bool
+	}{
+		{name: "Nearest go.mod", cwd: filepath.Join(repo, "web", "sub"), expectedRoot: filepath.Join(repo, "web"), expectedName: "example.com/web"},
+		{name: "Module root override", cwd: filepath.Join(repo, "web", "sub"), moduleRoot: filepath.Join(repo, "api"), expectedRoot: filepath.Join(repo, "api"), expectedName: "example.com/api"},
+		{name: "No module", cwd: repo, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &AppContext{CWD: tt.cwd, ModuleRoot: tt.moduleRoot}
+			root, name, err := ctx.ResolveModule()
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error, got root %q", root)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if root != tt.expectedRoot || name != tt.expectedName {
+				t.Errorf("Expected (%q, %q), got (%q, %q)", tt.expectedRoot, tt.expectedName, root, name)
+			}
+		})
+	}
+}
 
-	// Initialize CLI context
-	cliCtx := &AppContext{
-		Logger: mockLogger,
-		Config: mockConfig,
-		CWD:    mockCWD,
+func TestAppContext_ResolveModule_ResolvedOnce(t *testing.T) {
+	repo := t.TempDir()
+	for _, module := range []string{"api", "web"} {
+		if err := os.MkdirAll(filepath.Join(repo, module, "sub"), 0755); err != nil {
+			t.Fatalf("Failed to create module dir: %v", err)
+		}
+		goMod := "module example.com/" + module + "\n"
+		if err := os.WriteFile(filepath.Join(repo, module, "go.mod"), []byte(goMod), 0644); err != nil {
+			t.Fatalf("Failed to create go.mod: %v", err)
+		}
 	}
 
-	// Check if fields are correctly assigned
-	if cliCtx.Logger != mockLogger {
-		t.Errorf("Expected Logger to be %v, but got %v", mockLogger, cliCtx.Logger)
+	ctx := &AppContext{CWD: filepath.Join(repo, "web", "sub")}
+	if root, _, err := ctx.ResolveModule(); err != nil || root != filepath.Join(repo, "web") {
+		t.Fatalf("Expected the web module, got %q (err: %v)", root, err)
 	}
 
-	if cliCtx.Config != mockConfig {
-		t.Errorf("Expected Config to be %v, but got %v", mockConfig, cliCtx.Config)
+	// The root is not looked up again for the same working dir and override
+	if err := os.WriteFile(filepath.Join(repo, "web", "sub", "go.mod"), []byte("module example.com/sub\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+	if root, name, err := ctx.ResolveModule(); err != nil || root != filepath.Join(repo, "web") || name != "example.com/web" {
+		t.Errorf("Expected the resolved web module, got (%q, %q) (err: %v)", root, name, err)
 	}
 
-	if cliCtx.CWD != mockCWD {
-		t.Errorf("Expected CWD to be %v, but got %v", mockCWD, cliCtx.CWD)
+	ctx.ModuleRoot = filepath.Join(repo, "api")
+	if root, name, err := ctx.ResolveModule(); err != nil || root != ctx.ModuleRoot || name != "example.com/api" {
+		t.Errorf("Expected the override to be resolved, got (%q, %q) (err: %v)", root, name, err)
 	}
 }
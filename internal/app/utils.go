@@ -1,7 +1,7 @@
 package app
 
 import (
-	"os"
+	"errors"
 	"path/filepath"
 
 	"github.com/indaco/tempo/internal/apperrors"
@@ -11,8 +11,17 @@ import (
 
 // IsTempoProject checks if any of the prioritized Tempo config files exist.
 func IsTempoProject(workingDir string) error {
-	if err := isGolangProject(workingDir); err != nil {
-		return err
+	return CheckTempoProject(workingDir, true)
+}
+
+// CheckTempoProject checks if any of the prioritized Tempo config files exist and,
+// when requireGoModule is set, that the working dir belongs to a Go module.
+// Asset-only projects disable the latter with app.require_go_module or --no-gomod-check.
+func CheckTempoProject(workingDir string, requireGoModule bool) error {
+	if requireGoModule {
+		if err := isGolangProject(workingDir); err != nil {
+			return err
+		}
 	}
 
 	for _, file := range config.TempoConfigFiles {
@@ -25,14 +34,69 @@ func IsTempoProject(workingDir string) error {
 		}
 	}
 
-	return apperrors.Wrap("no config file found; checked: %v. Run 'tempo init' first", config.TempoConfigFiles)
+	return apperrors.WrapCode(apperrors.CodeConfigNotFound, "no config file found; checked: %v. Run 'tempo init' first", config.TempoConfigFiles)
 }
 
-// isGolangProject checks if the working dir is a valid Golang project .
+// IsTempoProject checks that the project config file exists: the one set with --config,
+// or else one of the config files of the working dir, which must belong to a Go module.
+func (c *AppContext) IsTempoProject() error {
+	return c.CheckTempoProject(true)
+}
+
+// CheckTempoProject checks the project as CheckTempoProject for the working dir of the
+// context, with the config file set with --config, if any, instead of the ones of the
+// working dir.
+func (c *AppContext) CheckTempoProject(requireGoModule bool) error {
+	if c.ConfigFile == "" {
+		return CheckTempoProject(c.CWD, requireGoModule)
+	}
+	if requireGoModule {
+		if err := isGolangProject(c.CWD); err != nil {
+			return err
+		}
+	}
+	_, err := c.ConfigPath()
+	return err
+}
+
+// ConfigPath returns the path of the project config file: the one set with --config,
+// or else the first of the config files found in the working dir.
+func (c *AppContext) ConfigPath() (string, error) {
+	if c.ConfigFile != "" {
+		exists, isDir, err := utils.FileOrDirExistsFunc(c.ConfigFile)
+		if err != nil {
+			return "", apperrors.Wrap("error checking config file '%s'", err, c.ConfigFile)
+		}
+		if !exists || isDir {
+			return "", apperrors.WrapCode(apperrors.CodeConfigNotFound, "config file set with --config not found: %s", c.ConfigFile)
+		}
+		return c.ConfigFile, nil
+	}
+
+	for _, file := range config.TempoConfigFiles {
+		path := filepath.Join(c.CWD, file)
+		exists, isDir, err := utils.FileOrDirExistsFunc(path)
+		if err != nil {
+			return "", apperrors.Wrap("error checking config file '%s'", err, file)
+		}
+		if exists && !isDir {
+			return path, nil
+		}
+	}
+	return "", apperrors.WrapCode(apperrors.CodeConfigNotFound, "no config file found; checked: %v. Run 'tempo init' first", config.TempoConfigFiles)
+}
+
+// isGolangProject checks if the working dir belongs to a Go module, either through
+// the nearest enclosing go.mod or a go.work workspace.
 func isGolangProject(workingDir string) error {
-	goModPath := filepath.Join(workingDir, "go.mod")
-	if _, err := os.Stat(goModPath); os.IsNotExist(err) {
-		return apperrors.Wrap("missing go.mod file. Run 'go mod init' to create one")
+	_, err := utils.ResolveModuleRoot(workingDir, "")
+	switch {
+	case err == nil, errors.Is(err, utils.ErrAmbiguousWorkspace):
+		// A multi-module workspace is valid; the module is selected with --module-root
+		return nil
+	case errors.Is(err, utils.ErrGoModNotFound):
+		return apperrors.WrapCode(apperrors.CodeGoModMissing, "missing go.mod file. Run 'go mod init' to create one")
+	default:
+		return err
 	}
-	return nil
 }
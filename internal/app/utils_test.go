@@ -125,3 +125,80 @@ func TestIsTempoProject(t *testing.T) {
 		})
 	}
 }
+
+func TestIsTempoProject_Subdirectory(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte("module test"), 0644); err != nil {
+		t.Fatalf("failed to create go.mod: %v", err)
+	}
+
+	subDir := filepath.Join(tempDir, "web")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatalf("failed to create subdirectory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(subDir, "tempo.yaml"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create tempo.yaml: %v", err)
+	}
+
+	if err := IsTempoProject(subDir); err != nil {
+		t.Errorf("Expected subdirectory of a Go module to be a valid project, got: %v", err)
+	}
+}
+
+func TestCheckTempoProject_WithoutGoModule(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "tempo.yaml"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create tempo.yaml: %v", err)
+	}
+
+	if err := CheckTempoProject(tempDir, true); err == nil || !strings.Contains(err.Error(), "missing go.mod file") {
+		t.Errorf("Expected a missing go.mod error, got %v", err)
+	}
+	if err := CheckTempoProject(tempDir, false); err != nil {
+		t.Errorf("Expected no error without the Go module requirement, got %v", err)
+	}
+	if err := CheckTempoProject(t.TempDir(), false); err == nil || !strings.Contains(err.Error(), "no config file found") {
+		t.Errorf("Expected a missing config error, got %v", err)
+	}
+}
+
+func TestAppContext_ConfigPath(t *testing.T) {
+	projectDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectDir, "tempo.yml"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create tempo.yml: %v", err)
+	}
+	otherConfig := filepath.Join(t.TempDir(), "ci.yaml")
+	if err := os.WriteFile(otherConfig, []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create ci.yaml: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		ctx        *AppContext
+		want       string
+		wantErrMsg string
+	}{
+		{"Working dir config", &AppContext{CWD: projectDir}, filepath.Join(projectDir, "tempo.yml"), ""},
+		{"Config set with --config", &AppContext{CWD: projectDir, ConfigFile: otherConfig}, otherConfig, ""},
+		{"Missing --config file", &AppContext{CWD: projectDir, ConfigFile: filepath.Join(projectDir, "missing.yaml")}, "", "not found"},
+		{"No config file", &AppContext{CWD: t.TempDir()}, "", "no config file found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ctx.ConfigPath()
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Errorf("Expected an error containing %q, got %v", tt.wantErrMsg, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ConfigPath() = %q, %v, want %q", got, err, tt.want)
+			}
+			if err := tt.ctx.CheckTempoProject(false); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
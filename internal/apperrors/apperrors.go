@@ -40,7 +40,14 @@
 //     The package properly implements Unwrap() to support Go's error chain
 //     traversal with errors.Is() and errors.As().
 //
-//  6. Logging:
+//  6. Error Codes:
+//     Use WrapCode() for errors users can act on, with a code registered in
+//     codes.go. The code is shown when the error is logged and "tempo explain"
+//     prints its explanation, causes and fixes:
+//
+//     return apperrors.WrapCode(apperrors.CodeGoModMissing, "missing go.mod file")
+//
+//  7. Logging:
 //     Use LogErrorChain() for simple error logging or LogErrorChainWithAttrs()
 //     for detailed error information including attributes.
 package apperrors
@@ -73,6 +80,7 @@ type TempoErrorInterface interface {
 // TempoError represents an error with an additional context message, cause, and attributes.
 type TempoError struct {
 	Message string         // Context message for the error
+	Code    string         // Error code explained by "tempo explain", if any
 	Cause   error          // Underlying cause of the error, if any
 	Attrs   map[string]any // Additional attributes for the error
 }
@@ -102,10 +110,12 @@ func (e *TempoError) ToJSON() ([]byte, error) {
 
 	return json.Marshal(struct {
 		Message string         `json:"message"`
+		Code    string         `json:"code,omitempty"`
 		Cause   string         `json:"cause,omitempty"`
 		Attrs   map[string]any `json:"attrs,omitempty"`
 	}{
 		Message: e.Message,
+		Code:    e.Code,
 		Cause:   causeMessage,
 		Attrs:   e.Attrs,
 	})
@@ -121,6 +131,12 @@ func (e *TempoError) WithAttr(key string, value any) *TempoError {
 	return e
 }
 
+// WithCode sets the error code and returns the updated error.
+func (e *TempoError) WithCode(code string) *TempoError {
+	e.Code = code
+	return e
+}
+
 /* ------------------------------------------------------------------------- */
 /* INTERFACE IMPLEMENTATIONS                                                 */
 /* ------------------------------------------------------------------------- */
@@ -170,6 +186,22 @@ func Wrap(msg string, args ...any) error {
 	return NewTempoError(coloredMsg, cause)
 }
 
+// WrapCode is like Wrap, with an error code registered in codes.go.
+func WrapCode(code, msg string, args ...any) error {
+	return Wrap(msg, args...).(*TempoError).WithCode(code)
+}
+
+// CodeOf returns the first error code found in the error chain, or an empty string.
+func CodeOf(err error) string {
+	for err != nil {
+		if tempoErr, ok := err.(*TempoError); ok && tempoErr.Code != "" {
+			return tempoErr.Code
+		}
+		err = errors.Unwrap(err)
+	}
+	return ""
+}
+
 /* ------------------------------------------------------------------------- */
 /* LOGGING FUNCTIONS                                                         */
 /* ------------------------------------------------------------------------- */
@@ -187,11 +219,19 @@ func LogErrorChain(err error) {
 	output := color.Output
 	errorColor := color.New(color.FgRed, color.Bold).SprintFunc()
 
-	mustWrite(output, "%s\n", errorColor("X Something went wrong:"))
+	code := CodeOf(err)
+	if code != "" {
+		mustWrite(output, "%s\n", errorColor(fmt.Sprintf("X Something went wrong [%s]:", code)))
+	} else {
+		mustWrite(output, "%s\n", errorColor("X Something went wrong:"))
+	}
 	for err != nil {
 		mustWrite(output, "  %s %v\n", errorColor("->"), err)
 		err = errors.Unwrap(err)
 	}
+	if code != "" {
+		mustWrite(output, "  Run 'tempo explain %s' for the causes and fixes\n", code)
+	}
 }
 
 // LogErrorChainWithAttrs logs an error chain with additional attributes in a structured format.
@@ -109,7 +109,36 @@ func TestNewTempoError(t *testing.T) {
 	}
 }
 
-// TestWithCode has been removed as the Code field is no longer part of TempoError
+func TestWithCode(t *testing.T) {
+	err := WrapCode(CodeGoModMissing, "missing go.mod file")
+	wrapped := Wrap("init failed", err)
+
+	if got := CodeOf(wrapped); got != CodeGoModMissing {
+		t.Errorf("Unexpected code: got %q, want %q", got, CodeGoModMissing)
+	}
+	if got := CodeOf(Wrap("no code")); got != "" {
+		t.Errorf("Expected no code, got %q", got)
+	}
+	if err.Error() != "missing go.mod file" {
+		t.Errorf("The code must not change the message, got %q", err.Error())
+	}
+}
+
+func TestLogErrorChainWithCode(t *testing.T) {
+	var buf bytes.Buffer
+	color.Output = &buf // Redirect color output to the buffer
+
+	LogErrorChain(Wrap("sync failed", WrapCode(CodeMissingMarkers, "invalid or missing guard markers")))
+
+	want := `X Something went wrong [TEMPO-E007]:
+  -> sync failed
+  -> invalid or missing guard markers
+  Run 'tempo explain TEMPO-E007' for the causes and fixes
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Unexpected log output:\nGot:\n%s\nWant:\n%s", got, want)
+	}
+}
 
 func TestWithAttrs(t *testing.T) {
 	err := NewTempoError("operation failed", nil)
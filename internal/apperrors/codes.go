@@ -0,0 +1,324 @@
+package apperrors
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Error codes returned by the commands.
+const (
+	CodeConfigNotFound        = "TEMPO-E001"
+	CodeConfigInvalid         = "TEMPO-E002"
+	CodeGoModMissing          = "TEMPO-E003"
+	CodeConfigExists          = "TEMPO-E004"
+	CodeTemplatesNotFound     = "TEMPO-E005"
+	CodeComponentNotFound     = "TEMPO-E006"
+	CodeMissingMarkers        = "TEMPO-E007"
+	CodeGuardViolation        = "TEMPO-E008"
+	CodePluginLoad            = "TEMPO-E009"
+	CodeUnformattedFiles      = "TEMPO-E010"
+	CodeDependencyCycle       = "TEMPO-E011"
+	CodeInvalidOutputRules    = "TEMPO-E012"
+	CodeOverwriteNotConfirmed = "TEMPO-E013"
+	CodeBuildStepFailed       = "TEMPO-E014"
+	CodeManualEdits           = "TEMPO-E015"
+	CodeBudgetExceeded        = "TEMPO-E016"
+	CodeCIFailed              = "TEMPO-E017"
+	CodeOutOfSync             = "TEMPO-E018"
+	CodeSkippedFiles          = "TEMPO-E019"
+)
+
+// codePrefix starts every error code.
+const codePrefix = "TEMPO-"
+
+// CodeInfo explains an error code.
+type CodeInfo struct {
+	Code        string   `json:"code"`
+	Title       string   `json:"title"`
+	Explanation string   `json:"explanation"`
+	Causes      []string `json:"causes,omitempty"`
+	Fixes       []string `json:"fixes,omitempty"` // Commands or changes fixing the error
+}
+
+// registry holds the registered error codes by code.
+var registry = map[string]CodeInfo{}
+
+// RegisterCode adds the explanation of an error code to the registry. It panics when
+// the code is already registered, as codes must stay unique.
+func RegisterCode(info CodeInfo) {
+	if _, exists := registry[info.Code]; exists {
+		panic(fmt.Sprintf("apperrors: error code %s registered twice", info.Code))
+	}
+	registry[info.Code] = info
+}
+
+// LookupCode returns the explanation of an error code. The code is case insensitive
+// and its "TEMPO-" prefix is optional (e.g. "e007").
+func LookupCode(code string) (CodeInfo, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !strings.HasPrefix(code, codePrefix) {
+		code = codePrefix + code
+	}
+	info, ok := registry[code]
+	return info, ok
+}
+
+// Codes returns the registered error codes, sorted by code.
+func Codes() []CodeInfo {
+	codes := make([]CodeInfo, 0, len(registry))
+	for _, info := range registry {
+		codes = append(codes, info)
+	}
+	slices.SortFunc(codes, func(a, b CodeInfo) int { return strings.Compare(a.Code, b.Code) })
+	return codes
+}
+
+func init() {
+	RegisterCode(CodeInfo{
+		Code:        CodeConfigNotFound,
+		Title:       "No tempo config file found",
+		Explanation: "The command works on a tempo project, but none of the supported config files (tempo.yaml, tempo.yml) exists in the working directory.",
+		Causes: []string{
+			"The project was never initialized with tempo",
+			"The command runs from another directory than the project root",
+		},
+		Fixes: []string{
+			"tempo init",
+			"cd to the folder holding the config file, or pass --module-root",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeConfigInvalid,
+		Title:       "Invalid config file",
+		Explanation: "The config file could not be read or holds invalid values.",
+		Causes: []string{
+			"The file is not valid YAML",
+			"A setting has a value of the wrong type or out of range",
+		},
+		Fixes: []string{
+			"tempo schema config --output tempo.schema.json, then validate the file in your editor",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeGoModMissing,
+		Title:       "Missing go.mod file",
+		Explanation: "tempo generates Go code and requires a Go module, found through the nearest go.mod or a go.work workspace.",
+		Causes: []string{
+			"The Go module was not initialized yet",
+			"The command runs outside of the module",
+		},
+		Fixes: []string{
+			"go mod init <module-path>",
+			"tempo --no-gomod-check <command>, or set app.require_go_module: false",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeConfigExists,
+		Title:       "Config file already exists",
+		Explanation: "tempo init does not overwrite an existing config file.",
+		Causes: []string{
+			"The project is already initialized",
+		},
+		Fixes: []string{
+			"tempo config set <key> <value> to change a setting",
+			"Remove the config file, then run tempo init again",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeTemplatesNotFound,
+		Title:       "Templates or actions not found",
+		Explanation: "Generating an entity requires its templates and action file, created by the matching define command.",
+		Causes: []string{
+			"The define command was never run",
+			"paths.templates_dir or paths.actions_dir points to another folder",
+		},
+		Fixes: []string{
+			"tempo component define",
+			"tempo variant define",
+			"tempo webcomponent define",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeComponentNotFound,
+		Title:       "Component not found",
+		Explanation: "The command works on an existing component, but its folder does not exist.",
+		Causes: []string{
+			"The component name is misspelled",
+			"The component was generated in another folder",
+		},
+		Fixes: []string{
+			"tempo list",
+			"tempo component new --name <name>",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeMissingMarkers,
+		Title:       "Invalid or missing guard markers",
+		Explanation: "Synced CSS and JS are injected between the guard markers of the .templ file. The start marker must come before the end marker.",
+		Causes: []string{
+			"A marker was removed or edited by hand",
+			"The markers were swapped",
+			"templates.guard_marker changed since the file was generated",
+			"A CSS or JS template of 'tempo component define' has no guard markers",
+		},
+		Fixes: []string{
+			"Restore the markers, e.g. /* [tempo] BEGIN - Do not edit! This section is auto-generated. */ and its END line",
+			"tempo component new --name <name> --force to regenerate the component",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeGuardViolation,
+		Title:       "Injected content rejected by the guards",
+		Explanation: "The content injected by sync is checked against processor.guards, which limits its size and forbids some patterns.",
+		Causes: []string{
+			"An asset file grew above the configured size limit",
+			"An asset file contains a forbidden pattern",
+		},
+		Fixes: []string{
+			"Split or shrink the asset file",
+			"Raise the limits or adjust the patterns in processor.guards",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodePluginLoad,
+		Title:       "Plugins could not be loaded",
+		Explanation: "The action plugins listed in the config file are loaded before any command runs.",
+		Causes: []string{
+			"A plugin has no name or command",
+			"A plugin kind is neither 'action' nor 'transformer'",
+		},
+		Fixes: []string{
+			"Check the plugins section of the config file",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeUnformattedFiles,
+		Title:       "Files need formatting",
+		Explanation: "tempo fmt --check found action files or templates that are not formatted.",
+		Causes: []string{
+			"Files were edited without running tempo fmt",
+		},
+		Fixes: []string{
+			"tempo fmt",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeDependencyCycle,
+		Title:       "Dependency cycle between components",
+		Explanation: "The dependencies declared between the components form a cycle, so no processing order exists.",
+		Causes: []string{
+			"Two or more components depend on each other",
+		},
+		Fixes: []string{
+			"tempo list --tree",
+			"Move the shared code to a component both can depend on",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeInvalidOutputRules,
+		Title:       "Invalid output rules",
+		Explanation: "processor.outputs maps input globs to output folders. Every rule needs a valid glob and output template.",
+		Causes: []string{
+			"A glob is empty or malformed",
+			"An output template is empty or uses unknown fields (only .OutputDir and .Component exist)",
+		},
+		Fixes: []string{
+			"Fix the rule in processor.outputs of the config file",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeOverwriteNotConfirmed,
+		Title:       "Overwrite not confirmed",
+		Explanation: "With '--force', the generation commands show the diff of the existing files they would change and wait for a confirmation before overwriting them.",
+		Causes: []string{
+			"'--force' was set without '--yes' and some existing files differ from the rendered ones",
+		},
+		Fixes: []string{
+			"Review the diff, then re-run the command with '--force --yes'",
+			"Set assume_yes: true in the config to answer the confirmations in non-interactive runs",
+			"Re-run the command with '--force --no-diff' to overwrite without review",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeBuildStepFailed,
+		Title:       "Build step failed",
+		Explanation: "'tempo build' runs the sync, 'templ generate' and optionally 'go build ./...' in order, and stops at the first failing step. The summary shows which step failed.",
+		Causes: []string{
+			"Some assets could not be synced",
+			"The templ command is not installed or the .templ files do not compile",
+			"The generated Go code does not build",
+		},
+		Fixes: []string{
+			"Read the output of the failing step above the summary",
+			"Install templ or set 'build.templ' in tempo.yaml (e.g. 'go tool templ')",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeManualEdits,
+		Title:       "Guard region edited by hand",
+		Explanation: "With templates.manual_edits set, sync records a checksum of the injected content before the end guard marker. With 'refuse', a region that no longer matches its checksum is left untouched and its file fails.",
+		Causes: []string{
+			"The injected CSS or JS was edited in the .templ file instead of the source asset",
+		},
+		Fixes: []string{
+			"Move the changes to the source asset, then re-run 'tempo sync --overwrite-manual'",
+			"Set templates.manual_edits: warn to overwrite the edits with a warning",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeBudgetExceeded,
+		Title:       "Asset budget exceeded",
+		Explanation: "processor.budgets limits the total size of the CSS and JS files of each component. Sync warns about the components over budget, and fails with on_exceed: error or with '--check'.",
+		Causes: []string{
+			"A component gained CSS or JS beyond its budget",
+			"The budget is too low for the component",
+		},
+		Fixes: []string{
+			"Split or trim the assets of the component listed in the summary",
+			"Raise processor.budgets.css or processor.budgets.js in tempo.yaml",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeCIFailed,
+		Title:       "CI check failed",
+		Explanation: "'tempo ci' runs the config, doctor, fmt and sync checks set in ci.steps and fails when one of them fails. The report shows the error of each failing check.",
+		Causes: []string{
+			"tempo.yaml holds unknown keys or invalid values",
+			"The templates or actions folders are missing, or templ is not installed",
+			"Templates or actions files are not formatted",
+			"A component exceeds its asset budget",
+		},
+		Fixes: []string{
+			"Read the detail of the failing checks in the report",
+			"Run 'tempo fmt' or 'tempo explain <code>' for the error code of a check",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeOutOfSync,
+		Title:       "Components out of sync",
+		Explanation: "'tempo status --exit-code', 'tempo status --format sarif' and 'tempo ci' fail when an asset is newer than its .templ file, has no .templ file, or its .templ file has no guard markers.",
+		Causes: []string{
+			"Assets were edited without running tempo sync",
+			"A component was created without its .templ file, or its guard markers were removed",
+		},
+		Fixes: []string{
+			"tempo sync",
+			"tempo sync --repair to restore the guard markers",
+		},
+	})
+	RegisterCode(CodeInfo{
+		Code:        CodeSkippedFiles,
+		Title:       "Files skipped for a failing reason",
+		Explanation: "'tempo sync --fail-on-skip' fails when files are skipped for one of the given reason codes, listed with their counts. The summary reports the reason_code of each skipped file.",
+		Causes: []string{
+			"An asset has no .templ file (missing-output)",
+			"Several assets target the same .templ file, or a guard region was edited by hand (conflict)",
+			"Minified or binary assets are left out of sync (minified)",
+		},
+		Fixes: []string{
+			"tempo sync --summary json --verbose to list the skipped files",
+			"Create the missing .templ files, or remove the reason from '--fail-on-skip'",
+		},
+	})
+}
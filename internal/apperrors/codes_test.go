@@ -0,0 +1,47 @@
+package apperrors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookupCode(t *testing.T) {
+	for _, code := range []string{"TEMPO-E007", "tempo-e007", "E007", " e007 "} {
+		info, ok := LookupCode(code)
+		if !ok || info.Code != CodeMissingMarkers {
+			t.Errorf("LookupCode(%q) = %+v, %v; want %s", code, info, ok, CodeMissingMarkers)
+		}
+	}
+
+	if _, ok := LookupCode("TEMPO-E999"); ok {
+		t.Errorf("Expected an unknown code")
+	}
+}
+
+func TestCodes(t *testing.T) {
+	codes := Codes()
+	if len(codes) == 0 {
+		t.Fatal("Expected registered codes")
+	}
+
+	for i, info := range codes {
+		if i > 0 && codes[i-1].Code >= info.Code {
+			t.Errorf("Codes not sorted: %s before %s", codes[i-1].Code, info.Code)
+		}
+		if !strings.HasPrefix(info.Code, codePrefix) {
+			t.Errorf("Code %s does not start with %s", info.Code, codePrefix)
+		}
+		if info.Title == "" || info.Explanation == "" || len(info.Fixes) == 0 {
+			t.Errorf("Code %s lacks a title, an explanation or fixes", info.Code)
+		}
+	}
+}
+
+func TestRegisterCode_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for a duplicated code")
+		}
+	}()
+	RegisterCode(CodeInfo{Code: CodeConfigNotFound})
+}
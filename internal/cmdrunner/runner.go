@@ -10,6 +10,33 @@ import (
 	"github.com/indaco/tempo/internal/validation"
 )
 
+// Runner runs an external command in a directory. The commands running external tools
+// hold one in a package variable, so that tests can replace it (see testutils.StubRunner).
+type Runner func(ctx context.Context, dir string, command []string) error
+
+// RunCommandContext executes command, its name followed by its arguments, in dir until
+// ctx is done, streaming its output. It validates the directory to prevent command
+// execution in unsafe locations.
+func RunCommandContext(ctx context.Context, dir string, command []string) error {
+	// Validate directory to prevent command injection
+	if err := validation.ValidateDirectory(dir); err != nil {
+		return apperrors.Wrap("invalid directory", err)
+	}
+	if len(command) == 0 {
+		return apperrors.Wrap("empty command")
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return apperrors.Wrap("command failed", err)
+	}
+	return nil
+}
+
 // RunCommandWithTimeout executes a command with a specified timeout.
 // It validates the directory to prevent command execution in unsafe locations.
 func RunCommandWithTimeout(dir string, timeout time.Duration, command string, args ...string) error {
@@ -66,3 +93,30 @@ func RunCommandOutput(dir string, command string, args ...string) (string, error
 
 	return string(output), nil
 }
+
+// RunCommandStdout executes a command and returns its standard output while enforcing
+// a timeout. The standard error is passed through, e.g. for the compiler errors of
+// `go run`. It validates the directory to prevent command execution in unsafe locations.
+func RunCommandStdout(dir string, timeout time.Duration, command string, args ...string) ([]byte, error) {
+	// Validate directory to prevent command injection
+	if err := validation.ValidateDirectory(dir); err != nil {
+		return nil, apperrors.Wrap("invalid directory", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, apperrors.Wrap("command timed out after %v", err, timeout)
+	}
+	if err != nil {
+		return nil, apperrors.Wrap("command failed", err)
+	}
+
+	return output, nil
+}
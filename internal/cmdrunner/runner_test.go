@@ -2,6 +2,7 @@ package cmdrunner
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 	"os/exec"
@@ -168,3 +169,35 @@ func TestRunCommandOutput_ErrorOutput(t *testing.T) {
 		t.Fatal("Expected error for invalid path, got nil")
 	}
 }
+
+func TestRunCommandStdout(t *testing.T) {
+	output, err := RunCommandStdout(os.TempDir(), 10*time.Second, "echo", "Hello, Tempo!")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(string(output)) != "Hello, Tempo!" {
+		t.Errorf("Unexpected output: %q", output)
+	}
+
+	if _, err := RunCommandStdout(os.TempDir(), 10*time.Second, "invalid_command_xyz"); err == nil {
+		t.Error("Expected error for invalid command, got nil")
+	}
+}
+
+func TestRunCommandContext(t *testing.T) {
+	if err := RunCommandContext(context.Background(), os.TempDir(), []string{"echo", "Hello, Tempo!"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err := RunCommandContext(context.Background(), os.TempDir(), []string{"invalid_command_xyz"})
+	if !errors.Is(err, exec.ErrNotFound) {
+		t.Errorf("Expected exec.ErrNotFound for an invalid command, got %v", err)
+	}
+
+	if err := RunCommandContext(context.Background(), "-rf", []string{"echo"}); err == nil {
+		t.Error("Expected an error for an invalid directory")
+	}
+	if err := RunCommandContext(context.Background(), os.TempDir(), nil); err == nil {
+		t.Error("Expected an error for an empty command")
+	}
+}
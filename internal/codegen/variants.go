@@ -0,0 +1,96 @@
+// Package codegen generates the Go files tempo maintains next to the components.
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// VariantsFile is the name of the generated file declaring the variant names of a
+// component, in the component folder.
+const VariantsFile = "variants_gen.go"
+
+// VariantNames returns the sorted names of the variants of the component in
+// componentDir, from the .templ files of its variantsDir folder, e.g. css/variants.
+func VariantNames(componentDir, variantsDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(componentDir, filepath.FromSlash(variantsDir)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, apperrors.Wrap("failed to read the variants folder", err, componentDir)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".templ" {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".templ"))
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// VariantsFileContent renders the Go file of the package pkg declaring the Variant type
+// with one constant per variant name, e.g. VariantNeon, and the Variants list.
+func VariantsFileContent(pkg string, variants []string) ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteString("// Code generated by tempo. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&sb, "package %s\n\n", pkg)
+	fmt.Fprintf(&sb, "// Variant is the name of a variant of the %s component.\n", pkg)
+	sb.WriteString("type Variant string\n\n")
+	fmt.Fprintf(&sb, "// Variants of the %s component.\n", pkg)
+	sb.WriteString("const (\n")
+	consts := make([]string, 0, len(variants))
+	for _, name := range variants {
+		constName := "Variant" + gonameprovider.ToGoExportedName(name)
+		consts = append(consts, constName)
+		fmt.Fprintf(&sb, "%s Variant = %s\n", constName, strconv.Quote(name))
+	}
+	sb.WriteString(")\n\n")
+	fmt.Fprintf(&sb, "// Variants lists the variants of the %s component.\n", pkg)
+	fmt.Fprintf(&sb, "var Variants = []Variant{%s}\n", strings.Join(consts, ", "))
+
+	formatted, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return nil, apperrors.Wrap("failed to format variants file", err)
+	}
+	return formatted, nil
+}
+
+// WriteVariantsFile regenerates the variants file of the component in componentDir
+// from its variant templates in variantsDir, and removes it when the component has no
+// variant left. It returns the path of the file.
+func WriteVariantsFile(componentDir, variantsDir string) (string, error) {
+	path := filepath.Join(componentDir, VariantsFile)
+	variants, err := VariantNames(componentDir, variantsDir)
+	if err != nil {
+		return path, err
+	}
+
+	if len(variants) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return path, apperrors.Wrap("failed to remove variants file", err, path)
+		}
+		return path, nil
+	}
+
+	content, err := VariantsFileContent(gonameprovider.ToGoPackageName(filepath.Base(componentDir)), variants)
+	if err != nil {
+		return path, err
+	}
+	if err := utils.WriteToFile(path, content); err != nil {
+		return path, apperrors.Wrap("failed to write variants file", err, path)
+	}
+	return path, nil
+}
@@ -0,0 +1,76 @@
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestVariantsFileContent(t *testing.T) {
+	content, err := VariantsFileContent("button", []string{"neon", "outlineDark"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got := string(content)
+	for _, want := range []string{
+		"// Code generated by tempo. DO NOT EDIT.",
+		"package button",
+		"type Variant string",
+		`VariantNeon        Variant = "neon"`,
+		`VariantOutlineDark Variant = "outlineDark"`,
+		"var Variants = []Variant{VariantNeon, VariantOutlineDark}",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected content to contain %q, got:\n%s", want, got)
+		}
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), VariantsFile, content, 0); err != nil {
+		t.Errorf("Expected valid Go code, got %v", err)
+	}
+}
+
+func TestWriteVariantsFile(t *testing.T) {
+	componentDir := filepath.Join(t.TempDir(), "button")
+	path := filepath.Join(componentDir, VariantsFile)
+
+	t.Run("no variants", func(t *testing.T) {
+		if _, err := WriteVariantsFile(componentDir, "css/variants"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected no variants file, got %v", err)
+		}
+	})
+
+	testutils.CreateFile(t, filepath.Join(componentDir, "css", "variants", "outline.templ"), "package variants")
+	testutils.CreateFile(t, filepath.Join(componentDir, "css", "variants", "neon.templ"), "package variants")
+	testutils.CreateFile(t, filepath.Join(componentDir, "css", "variants", "notes.txt"), "")
+
+	t.Run("variants", func(t *testing.T) {
+		if _, err := WriteVariantsFile(componentDir, "css/variants"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read variants file: %v", err)
+		}
+		if !strings.Contains(string(content), "var Variants = []Variant{VariantNeon, VariantOutline}") {
+			t.Errorf("Unexpected content:\n%s", content)
+		}
+	})
+
+	t.Run("last variant removed", func(t *testing.T) {
+		_ = os.RemoveAll(filepath.Join(componentDir, "css", "variants"))
+		if _, err := WriteVariantsFile(componentDir, "css/variants"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected the variants file to be removed, got %v", err)
+		}
+	})
+}
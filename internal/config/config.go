@@ -1,9 +1,16 @@
 package config
 
 import (
+	"bytes"
+	"cmp"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/indaco/tempo/internal/apperrors"
 	"github.com/indaco/tempo/internal/utils"
@@ -16,11 +23,77 @@ import (
 
 // App contains application-specific settings.
 type App struct {
-	GoModule  string `yaml:"go_module,omitempty"`
-	GoPackage string `yaml:"go_package,omitempty"`
-	WithJs    bool   `yaml:"with_js,omitempty"`
-	CssLayer  string `yaml:"css_layer,omitempty"` //nolint:revive // matches YAML field name
-	AssetsDir string `yaml:"assets_dir,omitempty"`
+	GoModule        string `yaml:"go_module,omitempty"`
+	GoPackage       string `yaml:"go_package,omitempty"`
+	WithJs          *bool  `yaml:"with_js,omitempty"`       // Defaults to false; see JsEnabled
+	WithTsDecls     *bool  `yaml:"with_ts_decls,omitempty"` // Defaults to false; see TsDeclsEnabled
+	CssLayer        string `yaml:"css_layer,omitempty"`     //nolint:revive // matches YAML field name
+	AssetsDir       string `yaml:"assets_dir,omitempty"`
+	RequireGoModule *bool  `yaml:"require_go_module,omitempty"` // Defaults to true; see RequiresGoModule
+}
+
+// RequiresGoModule reports whether commands must run inside a Go module. It is true
+// unless require_go_module is explicitly disabled, e.g. for asset-only projects.
+func (a App) RequiresGoModule() bool {
+	return a.RequireGoModule == nil || *a.RequireGoModule
+}
+
+// JsEnabled reports whether components include JavaScript by default. It is false
+// unless with_js is set, so a project can turn off a global with_js: true.
+func (a App) JsEnabled() bool {
+	return a.WithJs != nil && *a.WithJs
+}
+
+// TsDeclsEnabled reports whether components include a TypeScript declaration stub by
+// default. It is false unless with_ts_decls is set.
+func (a App) TsDeclsEnabled() bool {
+	return a.WithTsDecls != nil && *a.WithTsDecls
+}
+
+// Layout defines the folders of the CSS, variant and JS files in the component
+// folders, relative to the component folder. The same layout is used in the assets
+// folder and in the Go package, so that sync maps each asset to its .templ file.
+type Layout struct {
+	StylesDir   string `yaml:"styles_dir,omitempty"`   // Defaults to css
+	VariantsDir string `yaml:"variants_dir,omitempty"` // Defaults to css/variants
+	ScriptsDir  string `yaml:"scripts_dir,omitempty"`  // Defaults to js
+}
+
+// Styles returns the folder of the CSS files, DefaultStylesDir when not set.
+func (l Layout) Styles() string {
+	return cmp.Or(l.StylesDir, DefaultStylesDir)
+}
+
+// Variants returns the folder of the variant files, DefaultVariantsDir when not set.
+func (l Layout) Variants() string {
+	return cmp.Or(l.VariantsDir, DefaultVariantsDir)
+}
+
+// Scripts returns the folder of the JS files, DefaultScriptsDir when not set.
+func (l Layout) Scripts() string {
+	return cmp.Or(l.ScriptsDir, DefaultScriptsDir)
+}
+
+// Validate checks that the layout folders are distinct folders inside the component
+// folder.
+func (l Layout) Validate() error {
+	dirs := []struct{ key, dir string }{
+		{"layout.styles_dir", l.Styles()},
+		{"layout.variants_dir", l.Variants()},
+		{"layout.scripts_dir", l.Scripts()},
+	}
+	seen := make(map[string]string, len(dirs))
+	for _, d := range dirs {
+		clean := filepath.ToSlash(filepath.Clean(d.dir))
+		if filepath.IsAbs(d.dir) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			return apperrors.Wrap("invalid %s '%s': expected a folder inside the component folder, e.g. css", d.key, d.dir)
+		}
+		if other, ok := seen[clean]; ok {
+			return apperrors.Wrap("invalid %s '%s': already used by %s", d.key, d.dir, other)
+		}
+		seen[clean] = d.key
+	}
+	return nil
 }
 
 // Paths defines paths used in the application.
@@ -31,14 +104,311 @@ type Paths struct {
 
 // Processor defines settings for the files processing.
 type Processor struct {
-	Workers       int    `yaml:"workers"`
-	SummaryFormat string `yaml:"summary_format"`
+	Workers       int          `yaml:"workers"`
+	SummaryFormat string       `yaml:"summary_format" jsonschema:"enum=compact|long|json|none"`
+	Autoprefixer  Autoprefixer `yaml:"autoprefixer,omitempty"`
+	ScopedCSS     ScopedCSS    `yaml:"scoped_css,omitempty"`
+	Guards        Guards       `yaml:"guards,omitempty"`
+	Budgets       Budgets      `yaml:"budgets,omitempty"`
+	Symlinks      string       `yaml:"symlinks,omitempty" jsonschema:"enum=follow|skip|error"` // Symbolic links in the assets folder; defaults to follow
+	Outputs       []OutputRule `yaml:"outputs,omitempty"`                                      // Output folders of the matching assets, first match wins
+	Retry         Retry        `yaml:"retry,omitempty"`
+	IOThrottle    string       `yaml:"io_throttle,omitempty"` // Limit on the disk IO of sync, e.g. "5MB/s" or "200ops/s"; disabled when empty
+	JSInjection   JSInjection  `yaml:"js_injection,omitempty"`
+	Languages     []Language   `yaml:"languages,omitempty"` // File types synced besides CSS and JS, e.g. JSON design tokens
+	SkipInputs    SkipInputs   `yaml:"skip_inputs,omitempty"`
+}
+
+// SkipInputs defines the already built assets left out of sync, e.g. minified bundles
+// or binary files, matched by name or detected by content. They are reported among the
+// skipped files.
+type SkipInputs struct {
+	Enabled  *bool    `yaml:"enabled,omitempty"`  // Defaults to true, see IsEnabled
+	Patterns []string `yaml:"patterns,omitempty"` // Globs on the file name or on the path relative to assets_dir; defaults to *.min.css and *.min.js
+	Sniff    *bool    `yaml:"sniff,omitempty"`    // Detect the minified and binary files by content; defaults to true, see Sniffs
+}
+
+// IsEnabled reports whether the minified and binary assets are skipped.
+func (s SkipInputs) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// Sniffs reports whether the assets are read to detect the minified and binary files.
+func (s SkipInputs) Sniffs() bool {
+	return s.Sniff == nil || *s.Sniff
+}
+
+// Language declares a file type synced into the .templ files besides CSS and JS. Its
+// content is escaped and injected between the guard markers of the matching .templ file,
+// in its own block, or exposed there as a Go string variable.
+type Language struct {
+	Extension string `yaml:"extension"`                                               // e.g. ".json" or ".svg"
+	Mode      string `yaml:"mode,omitempty" jsonschema:"enum=inject|go_var"`          // Defaults to inject
+	Escape    string `yaml:"escape,omitempty" jsonschema:"enum=none|html|json|templ"` // Defaults to json for .json and templ for .svg in the inject mode, none otherwise
+	Variable  string `yaml:"variable,omitempty"`                                      // Go variable of the go_var mode; derived from the file name when empty, e.g. tokensJSON
+}
+
+// JSInjection defines how the JS files are injected into the script blocks of the
+// .templ files.
+type JSInjection struct {
+	Escape   *bool `yaml:"escape,omitempty"`    // Escape "</script", "<!--" and "{{"; defaults to true, see Escapes
+	WrapIIFE bool  `yaml:"wrap_iife,omitempty"` // Wrap the code in an immediately invoked function expression
+}
+
+// Escapes reports whether the injected JS is escaped for the script blocks.
+func (j JSInjection) Escapes() bool {
+	return j.Escape == nil || *j.Escape
+}
+
+// Retry defines the retries of the files failing to sync with a transient file system
+// error (e.g. EBUSY or ETIMEDOUT on network file systems). Durations such as "100ms"
+// set the delay before the first retry, doubled after each retry up to MaxBackoff.
+type Retry struct {
+	MaxRetries int    `yaml:"max_retries,omitempty"` // Retries after the first attempt; defaults to 0 (disabled)
+	Backoff    string `yaml:"backoff,omitempty"`     // Defaults to 100ms
+	MaxBackoff string `yaml:"max_backoff,omitempty"` // Defaults to 5s
+}
+
+// Delays parses the backoff durations. Empty values return 0, leaving the defaults to the caller.
+func (r Retry) Delays() (backoff, maxBackoff time.Duration, err error) {
+	if r.MaxRetries < 0 {
+		return 0, 0, apperrors.Wrap("invalid processor.retry.max_retries: must not be negative")
+	}
+	if r.Backoff != "" {
+		if backoff, err = utils.ParseDuration(r.Backoff); err != nil {
+			return 0, 0, apperrors.Wrap("invalid processor.retry.backoff", err)
+		}
+	}
+	if r.MaxBackoff != "" {
+		if maxBackoff, err = utils.ParseDuration(r.MaxBackoff); err != nil {
+			return 0, 0, apperrors.Wrap("invalid processor.retry.max_backoff", err)
+		}
+	}
+	return backoff, maxBackoff, nil
+}
+
+// OutputRule sends the assets matching Input, a glob relative to the assets folder
+// where "**" matches any number of folders, to the output folder Output instead of
+// go_package. Output is a template with .OutputDir and .Component as data. File and
+// Region send the matched assets to a named guard region of a single .templ file, so
+// that several rules can inject into distinct regions of the same file.
+type OutputRule struct {
+	Input  string `yaml:"input" jsonschema:"required"`
+	Output string `yaml:"output" jsonschema:"required"`
+	File   string `yaml:"file,omitempty"`   // .templ file name in Output, a template with .Name as extra data
+	Region string `yaml:"region,omitempty"` // Guard region, e.g. "base" for the [tempo:base] markers
+}
+
+// Autoprefixer defines the vendor-prefixing pass applied to CSS files on sync.
+// Without Command, prefixes are added by the built-in implementation.
+type Autoprefixer struct {
+	Enabled  bool     `yaml:"enabled,omitempty"`
+	Browsers []string `yaml:"browsers,omitempty"` // Browserslist-style targets, e.g. ["safari 13", "firefox 78"]
+	Command  string   `yaml:"command,omitempty"`  // Binary reading CSS on stdin and writing the result on stdout
+	Args     []string `yaml:"args,omitempty"`
+}
+
+// ScopedCSS defines the scoping of the CSS class names to their component on sync, as
+// CSS modules do. The scoped names of each CSS file are written to a Go map in
+// <file>_classes.go, next to its .templ file.
+type ScopedCSS struct {
+	Enabled bool   `yaml:"enabled,omitempty"`
+	Style   string `yaml:"style,omitempty" jsonschema:"enum=hash|prefix"` // hash: <class>_<hash> (default), prefix: <component>__<class>
+}
+
+// Guards defines the limits enforced on the content injected into .templ files on sync.
+// Sizes are human-readable (e.g. "100KB") and checked after transformation and minification.
+type Guards struct {
+	MaxCSSSize        string   `yaml:"max_css_size,omitempty"`
+	MaxJSSize         string   `yaml:"max_js_size,omitempty"`
+	ForbiddenPatterns []string `yaml:"forbidden_patterns,omitempty"` // Regular expressions rejected in the injected content
+}
+
+// Budgets defines the limits on the total size of the CSS and JS files of each
+// component, checked on sync.
+type Budgets struct {
+	CSS      string `yaml:"css,omitempty"`                                    // e.g. "50KB"
+	JS       string `yaml:"js,omitempty"`                                     // e.g. "100KB"
+	OnExceed string `yaml:"on_exceed,omitempty" jsonschema:"enum=warn|error"` // Defaults to warn
+}
+
+// FileModes defines the permissions of the generated and synced files. Modes are octal
+// strings (e.g. "0644") narrowed by the umask when a file is created.
+type FileModes struct {
+	Default          string            `yaml:"default,omitempty"`           // Mode of new files; defaults to 0600
+	ByExtension      map[string]string `yaml:"by_extension,omitempty"`      // Mode per output file extension, e.g. .templ: "0664"
+	PreserveExisting *bool             `yaml:"preserve_existing,omitempty"` // Keep the mode and owner of overwritten files; defaults to true
+}
+
+// Policy parses the file modes into the policy applied when writing files.
+func (f FileModes) Policy() (*utils.FileModePolicy, error) {
+	preserveExisting := f.PreserveExisting == nil || *f.PreserveExisting
+	policy, err := utils.NewFileModePolicy(f.Default, f.ByExtension, preserveExisting)
+	if err != nil {
+		return nil, apperrors.Wrap("invalid file_modes", err)
+	}
+	return policy, nil
+}
+
+// History defines the opt-in local record of the tempo invocations, queried with
+// "tempo history". Nothing is recorded unless Enabled is set, and nothing leaves the machine.
+type History struct {
+	Enabled    *bool  `yaml:"enabled,omitempty"`     // Record each invocation; defaults to false
+	File       string `yaml:"file,omitempty"`        // History file; defaults to history.jsonl next to the user-level config
+	MaxEntries int    `yaml:"max_entries,omitempty"` // Entries kept, oldest dropped first; defaults to 1000
+	MaxAge     string `yaml:"max_age,omitempty"`     // Entries older than this are dropped, e.g. "90d"; disabled when empty
+}
+
+// Age parses MaxAge. An empty value returns 0, disabling the age limit.
+func (h History) Age() (time.Duration, error) {
+	return parseMaxAge("history.max_age", h.MaxAge)
+}
+
+// IsEnabled reports whether the invocations are recorded.
+func (h History) IsEnabled() bool {
+	return h.Enabled != nil && *h.Enabled
+}
+
+// Path returns the history file: File when set, HistoryFile next to the user-level
+// configuration file otherwise.
+func (h History) Path() (string, error) {
+	if h.File != "" {
+		return h.File, nil
+	}
+	globalPath, err := GlobalConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(globalPath), HistoryFile), nil
+}
+
+// Journal defines the retention of the generation journal, recording each generation
+// of a component to regenerate it later. The latest generation of each component is
+// always kept.
+type Journal struct {
+	MaxEntries int    `yaml:"max_entries,omitempty"` // Entries kept, oldest dropped first; defaults to 200
+	MaxAge     string `yaml:"max_age,omitempty"`     // Entries older than this are dropped, e.g. "30d"; disabled when empty
+}
+
+// Age parses MaxAge. An empty value returns 0, disabling the age limit.
+func (j Journal) Age() (time.Duration, error) {
+	return parseMaxAge("journal.max_age", j.MaxAge)
+}
+
+// parseMaxAge parses the max_age value of the config key.
+func parseMaxAge(key, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	age, err := utils.ParseDuration(value)
+	if err != nil {
+		return 0, apperrors.Wrap("invalid %s", err, key)
+	}
+	if age <= 0 {
+		return 0, apperrors.Wrap("invalid %s: must be positive", key)
+	}
+	return age, nil
+}
+
+// Notifications defines where the outcome of the runs is sent on completion, e.g. to a
+// bot reporting the design-system changes. Nothing is sent unless Webhook or Socket is set.
+type Notifications struct {
+	Webhook  string            `yaml:"webhook,omitempty"`  // URL receiving a POST of the JSON payload
+	Socket   string            `yaml:"socket,omitempty"`   // Unix socket receiving the JSON payload, one line per run
+	Headers  map[string]string `yaml:"headers,omitempty"`  // Webhook request headers; $VAR and ${VAR} are expanded from the environment
+	Commands []string          `yaml:"commands,omitempty"` // Commands notified, e.g. "sync" or "new"; defaults to DefaultNotifiedCommands
+	Timeout  string            `yaml:"timeout,omitempty"`  // Delivery timeout, e.g. "5s"; defaults to DefaultNotificationTimeout
+}
+
+// DefaultNotifiedCommands are the commands notified when none is configured. A command
+// also matches its subcommands: "new" matches "new page".
+var DefaultNotifiedCommands = []string{"sync", "component new", "variant new", "webcomponent new", "new"}
+
+// DefaultNotificationTimeout is the delivery timeout when none is configured.
+const DefaultNotificationTimeout = 5 * time.Second
+
+// IsEnabled reports whether the runs are notified.
+func (n Notifications) IsEnabled() bool {
+	return n.Webhook != "" || n.Socket != ""
+}
+
+// Notifies reports whether the runs of command, e.g. "component new", are notified.
+func (n Notifications) Notifies(command string) bool {
+	commands := n.Commands
+	if len(commands) == 0 {
+		commands = DefaultNotifiedCommands
+	}
+	for _, c := range commands {
+		if command == c || strings.HasPrefix(command, c+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryTimeout returns the parsed Timeout, DefaultNotificationTimeout when unset.
+func (n Notifications) DeliveryTimeout() (time.Duration, error) {
+	if n.Timeout == "" {
+		return DefaultNotificationTimeout, nil
+	}
+	timeout, err := time.ParseDuration(n.Timeout)
+	if err != nil || timeout <= 0 {
+		return 0, apperrors.Wrap("invalid notifications.timeout %q: expected a positive duration such as 5s", n.Timeout)
+	}
+	return timeout, nil
+}
+
+// Display defines how the output of the commands is printed.
+type Display struct {
+	RelativePaths bool `yaml:"relative_paths,omitempty"` // Print the paths relative to the project root, in the logs, summaries and JSON reports
+}
+
+// Build defines the steps run by "tempo build" after the sync.
+type Build struct {
+	Templ   string `yaml:"templ,omitempty"`    // Command running templ, e.g. "go tool templ"; defaults to "templ"
+	GoBuild *bool  `yaml:"go_build,omitempty"` // Run "go build ./..." after "templ generate"; see GoBuildEnabled
+}
+
+// GoBuildEnabled reports whether "tempo build" runs "go build ./...". It is false
+// unless go_build is set.
+func (b Build) GoBuildEnabled() bool {
+	return b.GoBuild != nil && *b.GoBuild
+}
+
+// TemplCommand returns the command running templ, split into the executable and its
+// leading arguments.
+func (b Build) TemplCommand() []string {
+	if fields := strings.Fields(b.Templ); len(fields) > 0 {
+		return fields
+	}
+	return []string{DefaultTemplCommand}
+}
+
+// JS defines the package manager run by "tempo js" in the JS folders of the components.
+type JS struct {
+	PackageManager string `yaml:"package_manager,omitempty"` // e.g. "pnpm" or "yarn"; defaults to "npm"
+}
+
+// PackageManagerCommand returns the command running the package manager, split into the
+// executable and its leading arguments.
+func (j JS) PackageManagerCommand() []string {
+	if fields := strings.Fields(j.PackageManager); len(fields) > 0 {
+		return fields
+	}
+	return []string{DefaultPackageManager}
+}
+
+// CI defines the checks chained by "tempo ci".
+type CI struct {
+	Steps    []string `yaml:"steps,omitempty"`     // Checks run in order among config, doctor, fmt and sync; defaults to all of them
+	FailFast bool     `yaml:"fail_fast,omitempty"` // Stop at the first failing check instead of running them all
+	Report   string   `yaml:"report,omitempty"`    // Path of the JSON report of the checks; none when empty
 }
 
 // TemplateFuncProvider represents a function provider that can be loaded from a local path or a remote URL.
 type TemplateFuncProvider struct {
 	Name  string `yaml:"name,omitempty"`
-	Type  string `yaml:"type,omitempty"` // "path" or "url"
+	Type  string `yaml:"type,omitempty" jsonschema:"enum=path|url"`
 	Value string `yaml:"value,omitempty"`
 }
 
@@ -46,32 +416,166 @@ type TemplateFuncProvider struct {
 type Templates struct {
 	Extensions        []string               `yaml:"extensions,omitempty"`
 	GuardMarker       string                 `yaml:"guard_marker,omitempty"`
+	Watermark         string                 `yaml:"watermark,omitempty"`    // Template for the comment added to generated files
+	Header            Header                 `yaml:"header,omitempty"`       // License or banner header added by the header actions
+	A11yAudit         *bool                  `yaml:"a11y_audit,omitempty"`   // Check the generated .templ files for accessibility issues; see A11yAuditEnabled
+	RenderCache       *bool                  `yaml:"render_cache,omitempty"` // Cache rendered templates on disk, keyed by content and data; see RenderCacheEnabled
+	Layered           *bool                  `yaml:"layered,omitempty"`      // Look up the missing templates in the user templates folder, then in the built-in ones; see LayeredEnabled
+	ManualEdits       string                 `yaml:"manual_edits,omitempty"` // Guard regions edited by hand since the last sync: "warn" or "refuse" (empty: not detected)
 	UserData          map[string]any         `yaml:"user_data,omitempty"`
 	FunctionProviders []TemplateFuncProvider `yaml:"function_providers,omitempty"`
 }
 
+// A11yAuditEnabled reports whether the generated .templ files are checked for
+// accessibility issues. It is false unless a11y_audit is set.
+func (t Templates) A11yAuditEnabled() bool {
+	return t.A11yAudit != nil && *t.A11yAudit
+}
+
+// RenderCacheEnabled reports whether the rendered templates are cached on disk. It is
+// false unless render_cache is set.
+func (t Templates) RenderCacheEnabled() bool {
+	return t.RenderCache != nil && *t.RenderCache
+}
+
+// LayeredEnabled reports whether the missing templates are looked up in the built-in
+// ones. It is false unless layered is set.
+func (t Templates) LayeredEnabled() bool {
+	return t.Layered != nil && *t.Layered
+}
+
+// Header is the license or banner header prepended by the "header" actions and by
+// 'tempo headers apply' to the files lacking it.
+type Header struct {
+	Template string `yaml:"template,omitempty"` // e.g. "Copyright {{ .Year }} {{ .Author }}", with .Year, .Author and .UserData
+	Author   string `yaml:"author,omitempty"`   // Value of .Author
+}
+
+// Components defines settings related to the generated components.
+type Components struct {
+	// Dependencies maps a component name to the components it requires (e.g. dropdown: [button]).
+	Dependencies map[string][]string `yaml:"dependencies,omitempty"`
+	// Tags maps a component name to its tags (e.g. button: [forms, experimental]), to
+	// select subsets of components with the --tag filters.
+	Tags map[string][]string `yaml:"tags,omitempty"`
+}
+
+// Plugin declares an external executable extending tempo as an action type or a
+// sync transformer. It communicates with tempo through JSON over stdin/stdout.
+type Plugin struct {
+	Name       string   `yaml:"name" jsonschema:"required"`
+	Kind       string   `yaml:"kind" jsonschema:"required,enum=action|transformer"`
+	Command    string   `yaml:"command" jsonschema:"required"`
+	Args       []string `yaml:"args,omitempty"`
+	Extensions []string `yaml:"extensions,omitempty"` // Files handled by a transformer, e.g. [".css"]
+}
+
+// Entity declares an entity type generated by 'tempo new <name>', such as a layout or a
+// page, from the templates and the actions file written for it.
+type Entity struct {
+	Name      string       `yaml:"name" jsonschema:"required"`
+	Usage     string       `yaml:"usage,omitempty"`
+	Templates string       `yaml:"templates,omitempty"` // Folder of the templates dir (default: the entity name)
+	Actions   string       `yaml:"actions,omitempty"`   // File of the actions dir (default: <name>.json)
+	Flags     []EntityFlag `yaml:"flags,omitempty"`
+}
+
+// EntityFlag declares a flag of an entity type. Its value is available to the templates
+// as .Flags.<name>.
+type EntityFlag struct {
+	Name     string `yaml:"name" jsonschema:"required"`
+	Type     string `yaml:"type,omitempty" jsonschema:"enum=string|bool|int"` // Defaults to string
+	Usage    string `yaml:"usage,omitempty"`
+	Default  string `yaml:"default,omitempty"`
+	Required bool   `yaml:"required,omitempty"`
+}
+
+// Entity flag types.
+const (
+	EntityFlagString = "string"
+	EntityFlagBool   = "bool"
+	EntityFlagInt    = "int"
+)
+
+// TemplatesFolder returns the folder of the entity templates, relative to the templates dir.
+func (e Entity) TemplatesFolder() string {
+	if e.Templates != "" {
+		return e.Templates
+	}
+	return e.Name
+}
+
+// ActionsFile returns the actions file of the entity, relative to the actions dir.
+func (e Entity) ActionsFile() string {
+	if e.Actions != "" {
+		return e.Actions
+	}
+	return e.Name + ".json"
+}
+
+// FlagType returns the type of the flag, string when unset.
+func (f EntityFlag) FlagType() string {
+	if f.Type != "" {
+		return f.Type
+	}
+	return EntityFlagString
+}
+
 // Config represents the configuration settings for the application.
 type Config struct {
-	TempoRoot string    `yaml:"tempo_root"`
-	App       App       `yaml:"app,omitempty"`
-	Paths     Paths     `yaml:"-"`
-	Processor Processor `yaml:"processor,omitempty"`
-	Templates Templates `yaml:"templates,omitempty"`
+	TempoRoot     string        `yaml:"tempo_root"`
+	Strict        *bool         `yaml:"strict,omitempty"`     // Fail on missing optional folders; defaults to true, see IsStrict
+	AssumeYes     *bool         `yaml:"assume_yes,omitempty"` // Answer yes to the confirmations, as with --yes; see AssumesYes
+	App           App           `yaml:"app,omitempty"`
+	Layout        Layout        `yaml:"layout,omitempty"`
+	Paths         Paths         `yaml:"-"`
+	Processor     Processor     `yaml:"processor,omitempty"`
+	Templates     Templates     `yaml:"templates,omitempty"`
+	Components    Components    `yaml:"components,omitempty"`
+	FileModes     FileModes     `yaml:"file_modes,omitempty"`
+	History       History       `yaml:"history,omitempty"`
+	Journal       Journal       `yaml:"journal,omitempty"`
+	Build         Build         `yaml:"build,omitempty"`
+	JS            JS            `yaml:"js,omitempty"`
+	CI            CI            `yaml:"ci,omitempty"`
+	Plugins       []Plugin      `yaml:"plugins,omitempty"`
+	Entities      []Entity      `yaml:"entities,omitempty"`
+	Notifications Notifications `yaml:"notifications,omitempty"`
+	Display       Display       `yaml:"display,omitempty"`
+}
+
+// AssumesYes reports whether the confirmations are answered yes by default. It is false
+// unless assume_yes is set, so a project can turn off a global assume_yes: true.
+func (c *Config) AssumesYes() bool {
+	return c.AssumeYes != nil && *c.AssumeYes
+}
+
+// IsStrict reports whether the commands fail when optional folders are missing. It is
+// true unless strict is explicitly disabled: the missing folders are then reported as
+// warnings, while hard requirements such as the config file still fail.
+func (c *Config) IsStrict() bool {
+	return c.Strict == nil || *c.Strict
 }
 
 // Default values for the configuration.
 const (
-	DefaultBaseDir       = ".tempo-files"
-	DefaultGoPackage     = "components"
-	DefaultAssetsDir     = "assets"
-	DefaultSummaryFormat = "compact"
-	DefaultGuardMarkText = "tempo"
+	DefaultBaseDir        = ".tempo-files"
+	DefaultGoPackage      = "components"
+	DefaultAssetsDir      = "assets"
+	DefaultSummaryFormat  = "compact"
+	DefaultGuardMarkText  = "tempo"
+	HistoryFile           = "history.jsonl"
+	DefaultTemplCommand   = "templ"
+	DefaultPackageManager = "npm"
+	DefaultStylesDir      = "css"
+	DefaultVariantsDir    = "css/variants"
+	DefaultScriptsDir     = "js"
 )
 
 var (
 	DefaultNumWorkers         = runtime.NumCPU() * 2
 	TempoConfigFiles          = []string{"tempo.yaml", "tempo.yml"}
-	DefaultTemplateExtensions = []string{".gotxt", ".gotmpl", ".tpl"}
+	DefaultTemplateExtensions = []string{".gotxt", ".gotmpl", ".tpl", ".hbs", ".handlebars"}
 )
 
 /* ------------------------------------------------------------------------- */
@@ -87,9 +591,13 @@ func DefaultConfig() *Config {
 		TempoRoot: DefaultBaseDir,
 		App: App{
 			GoPackage: DefaultGoPackage,
-			WithJs:    false,
 			AssetsDir: DefaultAssetsDir,
 		},
+		Layout: Layout{
+			StylesDir:   DefaultStylesDir,
+			VariantsDir: DefaultVariantsDir,
+			ScriptsDir:  DefaultScriptsDir,
+		},
 		Paths: Paths{
 			TemplatesDir: templatesDir,
 			ActionsDir:   actionsDir,
@@ -107,28 +615,75 @@ func DefaultConfig() *Config {
 }
 
 // LoadConfig loads the application configuration from a file or uses default values.
+// Values from the user-level config (see GlobalConfigPath) are applied first and
+// then overridden by the project tempo.yaml.
 func LoadConfig() (*Config, error) {
+	return LoadConfigFrom("")
+}
+
+// LoadConfigFrom loads the configuration as LoadConfig, reading the project config
+// from path instead of the tempo.yaml or tempo.yml file of the working directory when
+// path is not empty. As for these files, a missing file leaves the default values: the
+// commands requiring it check it (see app.AppContext.ConfigPath).
+func LoadConfigFrom(path string) (*Config, error) {
 	defaultConfig := DefaultConfig()
 
-	for _, file := range TempoConfigFiles {
-		if _, err := os.Stat(file); err == nil {
-			data, err := os.ReadFile(file)
+	globalPath, err := GlobalConfigPath()
+	if err == nil {
+		if _, err := os.Stat(globalPath); err == nil {
+			globalConfig, err := readConfigFile(globalPath)
 			if err != nil {
-				return nil, apperrors.Wrap("failed to read config file:", err, file)
+				return nil, err
 			}
+			defaultConfig = ensureDefaults(defaultConfig, globalConfig)
+		}
+	}
 
-			var fileConfig Config
-			if err := yaml.Unmarshal(data, &fileConfig); err != nil {
-				return nil, apperrors.Wrap("failed to parse config file:", err, file)
+	files := TempoConfigFiles
+	if path != "" {
+		files = []string{path}
+	}
+	for _, file := range files {
+		if _, err := os.Stat(file); err == nil {
+			fileConfig, err := readConfigFile(file)
+			if err != nil {
+				return nil, err
 			}
 
-			return ensureDefaults(defaultConfig, &fileConfig), nil
+			return ensureDefaults(defaultConfig, fileConfig), nil
 		}
 	}
 
 	return defaultConfig, nil
 }
 
+// LoadConfigFile loads the configuration file at path over the default values, without
+// the user-level config.
+func LoadConfigFile(path string) (*Config, error) {
+	fileConfig, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ensureDefaults(DefaultConfig(), fileConfig), nil
+}
+
+// ValidateFile checks that the configuration file at path parses and only holds known
+// settings: misspelled keys, ignored when the configuration is loaded, are reported.
+func ValidateFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return apperrors.Wrap("failed to read config file:", err, path)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var fileConfig Config
+	if err := decoder.Decode(&fileConfig); err != nil && !errors.Is(err, io.EOF) {
+		return apperrors.Wrap("invalid config file:", err, path)
+	}
+	return nil
+}
+
 // DerivedFolderPaths returns the derived folder paths based on the base folder.
 func DerivedFolderPaths(baseFolder string) (templatesDir, actionsDir string) {
 	templatesDir = filepath.Join(baseFolder, "templates")
@@ -140,6 +695,21 @@ func DerivedFolderPaths(baseFolder string) (templatesDir, actionsDir string) {
 /* UTILITY HELPERS                                                           */
 /* ------------------------------------------------------------------------- */
 
+// readConfigFile reads and parses a single YAML configuration file.
+func readConfigFile(file string) (*Config, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, apperrors.Wrap("failed to read config file:", err, file)
+	}
+
+	var fileConfig Config
+	if err := yaml.Unmarshal(data, &fileConfig); err != nil {
+		return nil, apperrors.Wrap("failed to parse config file:", err, file)
+	}
+
+	return &fileConfig, nil
+}
+
 // ensureDefaults merges a partial configuration (from a file) with the default configuration.
 //
 // This function updates the default configuration with any non-empty values
@@ -147,13 +717,31 @@ func DerivedFolderPaths(baseFolder string) (templatesDir, actionsDir string) {
 func ensureDefaults(defaultConfig, fileConfig *Config) *Config {
 	mergeRootConfig(defaultConfig, fileConfig)
 	mergeAppConfig(defaultConfig, fileConfig)
+	mergeLayoutConfig(defaultConfig, fileConfig)
 	mergeProcessorConfig(defaultConfig, fileConfig)
 	mergeTemplatesConfig(defaultConfig, fileConfig)
+	mergeComponentsConfig(defaultConfig, fileConfig)
+	mergeFileModesConfig(defaultConfig, fileConfig)
+	mergeHistoryConfig(defaultConfig, fileConfig)
+	mergeJournalConfig(defaultConfig, fileConfig)
+	mergeBuildConfig(defaultConfig, fileConfig)
+	mergeJSConfig(defaultConfig, fileConfig)
+	mergeCIConfig(defaultConfig, fileConfig)
+	mergePluginsConfig(defaultConfig, fileConfig)
+	mergeEntitiesConfig(defaultConfig, fileConfig)
+	mergeNotificationsConfig(defaultConfig, fileConfig)
+	mergeDisplayConfig(defaultConfig, fileConfig)
 	return defaultConfig
 }
 
 // mergeRootConfig merges root-level configuration settings.
 func mergeRootConfig(defaultConfig, fileConfig *Config) {
+	if fileConfig.Strict != nil {
+		defaultConfig.Strict = fileConfig.Strict
+	}
+	if fileConfig.AssumeYes != nil {
+		defaultConfig.AssumeYes = fileConfig.AssumeYes
+	}
 	if fileConfig.TempoRoot != "" {
 		resolvedRoot, err := utils.ResolvePath(fileConfig.TempoRoot)
 		if err == nil {
@@ -174,9 +762,12 @@ func mergeAppConfig(defaultConfig, fileConfig *Config) {
 			defaultConfig.App.GoPackage = resolved
 		}
 	}
-	if fileConfig.App.WithJs {
+	if fileConfig.App.WithJs != nil {
 		defaultConfig.App.WithJs = fileConfig.App.WithJs
 	}
+	if fileConfig.App.WithTsDecls != nil {
+		defaultConfig.App.WithTsDecls = fileConfig.App.WithTsDecls
+	}
 	if fileConfig.App.CssLayer != "" {
 		defaultConfig.App.CssLayer = fileConfig.App.CssLayer
 	}
@@ -185,6 +776,22 @@ func mergeAppConfig(defaultConfig, fileConfig *Config) {
 			defaultConfig.App.AssetsDir = resolved
 		}
 	}
+	if fileConfig.App.RequireGoModule != nil {
+		defaultConfig.App.RequireGoModule = fileConfig.App.RequireGoModule
+	}
+}
+
+// mergeLayoutConfig merges the folders of the component layout.
+func mergeLayoutConfig(defaultConfig, fileConfig *Config) {
+	if fileConfig.Layout.StylesDir != "" {
+		defaultConfig.Layout.StylesDir = fileConfig.Layout.StylesDir
+	}
+	if fileConfig.Layout.VariantsDir != "" {
+		defaultConfig.Layout.VariantsDir = fileConfig.Layout.VariantsDir
+	}
+	if fileConfig.Layout.ScriptsDir != "" {
+		defaultConfig.Layout.ScriptsDir = fileConfig.Layout.ScriptsDir
+	}
 }
 
 // mergeProcessorConfig merges processor configuration settings.
@@ -195,6 +802,66 @@ func mergeProcessorConfig(defaultConfig, fileConfig *Config) {
 	if fileConfig.Processor.SummaryFormat != "" {
 		defaultConfig.Processor.SummaryFormat = fileConfig.Processor.SummaryFormat
 	}
+	if fileConfig.Processor.Autoprefixer.Enabled {
+		defaultConfig.Processor.Autoprefixer = fileConfig.Processor.Autoprefixer
+	}
+	if fileConfig.Processor.ScopedCSS.Enabled {
+		defaultConfig.Processor.ScopedCSS = fileConfig.Processor.ScopedCSS
+	}
+	if fileConfig.Processor.Guards.MaxCSSSize != "" {
+		defaultConfig.Processor.Guards.MaxCSSSize = fileConfig.Processor.Guards.MaxCSSSize
+	}
+	if fileConfig.Processor.Guards.MaxJSSize != "" {
+		defaultConfig.Processor.Guards.MaxJSSize = fileConfig.Processor.Guards.MaxJSSize
+	}
+	if len(fileConfig.Processor.Guards.ForbiddenPatterns) > 0 {
+		defaultConfig.Processor.Guards.ForbiddenPatterns = fileConfig.Processor.Guards.ForbiddenPatterns
+	}
+	if fileConfig.Processor.Budgets.CSS != "" {
+		defaultConfig.Processor.Budgets.CSS = fileConfig.Processor.Budgets.CSS
+	}
+	if fileConfig.Processor.Budgets.JS != "" {
+		defaultConfig.Processor.Budgets.JS = fileConfig.Processor.Budgets.JS
+	}
+	if fileConfig.Processor.Budgets.OnExceed != "" {
+		defaultConfig.Processor.Budgets.OnExceed = fileConfig.Processor.Budgets.OnExceed
+	}
+	if fileConfig.Processor.Symlinks != "" {
+		defaultConfig.Processor.Symlinks = fileConfig.Processor.Symlinks
+	}
+	if fileConfig.Processor.IOThrottle != "" {
+		defaultConfig.Processor.IOThrottle = fileConfig.Processor.IOThrottle
+	}
+	if fileConfig.Processor.JSInjection.Escape != nil {
+		defaultConfig.Processor.JSInjection.Escape = fileConfig.Processor.JSInjection.Escape
+	}
+	if fileConfig.Processor.JSInjection.WrapIIFE {
+		defaultConfig.Processor.JSInjection.WrapIIFE = true
+	}
+	if len(fileConfig.Processor.Outputs) > 0 {
+		defaultConfig.Processor.Outputs = fileConfig.Processor.Outputs
+	}
+	if len(fileConfig.Processor.Languages) > 0 {
+		defaultConfig.Processor.Languages = fileConfig.Processor.Languages
+	}
+	if fileConfig.Processor.SkipInputs.Enabled != nil {
+		defaultConfig.Processor.SkipInputs.Enabled = fileConfig.Processor.SkipInputs.Enabled
+	}
+	if len(fileConfig.Processor.SkipInputs.Patterns) > 0 {
+		defaultConfig.Processor.SkipInputs.Patterns = fileConfig.Processor.SkipInputs.Patterns
+	}
+	if fileConfig.Processor.SkipInputs.Sniff != nil {
+		defaultConfig.Processor.SkipInputs.Sniff = fileConfig.Processor.SkipInputs.Sniff
+	}
+	if fileConfig.Processor.Retry.MaxRetries != 0 {
+		defaultConfig.Processor.Retry.MaxRetries = fileConfig.Processor.Retry.MaxRetries
+	}
+	if fileConfig.Processor.Retry.Backoff != "" {
+		defaultConfig.Processor.Retry.Backoff = fileConfig.Processor.Retry.Backoff
+	}
+	if fileConfig.Processor.Retry.MaxBackoff != "" {
+		defaultConfig.Processor.Retry.MaxBackoff = fileConfig.Processor.Retry.MaxBackoff
+	}
 }
 
 // mergeTemplatesConfig merges template configuration settings.
@@ -205,12 +872,185 @@ func mergeTemplatesConfig(defaultConfig, fileConfig *Config) {
 	if fileConfig.Templates.GuardMarker != "" {
 		defaultConfig.Templates.GuardMarker = fileConfig.Templates.GuardMarker
 	}
+	if fileConfig.Templates.Watermark != "" {
+		defaultConfig.Templates.Watermark = fileConfig.Templates.Watermark
+	}
+	if fileConfig.Templates.Header.Template != "" {
+		defaultConfig.Templates.Header.Template = fileConfig.Templates.Header.Template
+	}
+	if fileConfig.Templates.Header.Author != "" {
+		defaultConfig.Templates.Header.Author = fileConfig.Templates.Header.Author
+	}
+	if fileConfig.Templates.A11yAudit != nil {
+		defaultConfig.Templates.A11yAudit = fileConfig.Templates.A11yAudit
+	}
+	if fileConfig.Templates.RenderCache != nil {
+		defaultConfig.Templates.RenderCache = fileConfig.Templates.RenderCache
+	}
+	if fileConfig.Templates.Layered != nil {
+		defaultConfig.Templates.Layered = fileConfig.Templates.Layered
+	}
+	if fileConfig.Templates.ManualEdits != "" {
+		defaultConfig.Templates.ManualEdits = fileConfig.Templates.ManualEdits
+	}
 	if fileConfig.Templates.UserData != nil {
 		defaultConfig.Templates.UserData = fileConfig.Templates.UserData
 	}
 	if fileConfig.Templates.FunctionProviders != nil {
 		defaultConfig.Templates.FunctionProviders = fileConfig.Templates.FunctionProviders
-	} else {
+	} else if defaultConfig.Templates.FunctionProviders == nil {
 		defaultConfig.Templates.FunctionProviders = []TemplateFuncProvider{}
 	}
 }
+
+// mergeComponentsConfig merges component dependencies and tags. Entries from fileConfig
+// replace the ones declared for the same component.
+func mergeComponentsConfig(defaultConfig, fileConfig *Config) {
+	defaultConfig.Components.Dependencies = mergeComponentLists(defaultConfig.Components.Dependencies, fileConfig.Components.Dependencies)
+	defaultConfig.Components.Tags = mergeComponentLists(defaultConfig.Components.Tags, fileConfig.Components.Tags)
+}
+
+// mergeComponentLists merges the lists declared per component, the ones of fileLists
+// replacing the ones of the same component.
+func mergeComponentLists(defaultLists, fileLists map[string][]string) map[string][]string {
+	if len(fileLists) == 0 {
+		return defaultLists
+	}
+	if defaultLists == nil {
+		defaultLists = make(map[string][]string, len(fileLists))
+	}
+	for name, list := range fileLists {
+		defaultLists[name] = list
+	}
+	return defaultLists
+}
+
+// mergeFileModesConfig merges file modes. Modes per extension from fileConfig
+// replace the ones declared for the same extension.
+func mergeFileModesConfig(defaultConfig, fileConfig *Config) {
+	if fileConfig.FileModes.Default != "" {
+		defaultConfig.FileModes.Default = fileConfig.FileModes.Default
+	}
+	if len(fileConfig.FileModes.ByExtension) > 0 {
+		if defaultConfig.FileModes.ByExtension == nil {
+			defaultConfig.FileModes.ByExtension = make(map[string]string, len(fileConfig.FileModes.ByExtension))
+		}
+		for ext, mode := range fileConfig.FileModes.ByExtension {
+			defaultConfig.FileModes.ByExtension[ext] = mode
+		}
+	}
+	if fileConfig.FileModes.PreserveExisting != nil {
+		defaultConfig.FileModes.PreserveExisting = fileConfig.FileModes.PreserveExisting
+	}
+}
+
+// mergeHistoryConfig merges the invocation history settings.
+func mergeHistoryConfig(defaultConfig, fileConfig *Config) {
+	if fileConfig.History.Enabled != nil {
+		defaultConfig.History.Enabled = fileConfig.History.Enabled
+	}
+	if fileConfig.History.File != "" {
+		if resolved, err := utils.ResolvePath(fileConfig.History.File); err == nil {
+			defaultConfig.History.File = resolved
+		}
+	}
+	if fileConfig.History.MaxEntries != 0 {
+		defaultConfig.History.MaxEntries = fileConfig.History.MaxEntries
+	}
+	if fileConfig.History.MaxAge != "" {
+		defaultConfig.History.MaxAge = fileConfig.History.MaxAge
+	}
+}
+
+// mergeJournalConfig merges the retention of the generation journal.
+func mergeJournalConfig(defaultConfig, fileConfig *Config) {
+	if fileConfig.Journal.MaxEntries != 0 {
+		defaultConfig.Journal.MaxEntries = fileConfig.Journal.MaxEntries
+	}
+	if fileConfig.Journal.MaxAge != "" {
+		defaultConfig.Journal.MaxAge = fileConfig.Journal.MaxAge
+	}
+}
+
+// mergeBuildConfig merges the build settings.
+func mergeBuildConfig(defaultConfig, fileConfig *Config) {
+	if fileConfig.Build.Templ != "" {
+		defaultConfig.Build.Templ = fileConfig.Build.Templ
+	}
+	if fileConfig.Build.GoBuild != nil {
+		defaultConfig.Build.GoBuild = fileConfig.Build.GoBuild
+	}
+}
+
+// mergeJSConfig merges the JS settings.
+func mergeJSConfig(defaultConfig, fileConfig *Config) {
+	if fileConfig.JS.PackageManager != "" {
+		defaultConfig.JS.PackageManager = fileConfig.JS.PackageManager
+	}
+}
+
+// mergeCIConfig merges the checks of "tempo ci".
+func mergeCIConfig(defaultConfig, fileConfig *Config) {
+	if len(fileConfig.CI.Steps) > 0 {
+		defaultConfig.CI.Steps = fileConfig.CI.Steps
+	}
+	if fileConfig.CI.FailFast {
+		defaultConfig.CI.FailFast = true
+	}
+	if fileConfig.CI.Report != "" {
+		defaultConfig.CI.Report = fileConfig.CI.Report
+	}
+}
+
+// mergePluginsConfig merges plugins. Plugins from fileConfig replace plugins with the
+// same name and are appended otherwise.
+func mergePluginsConfig(defaultConfig, fileConfig *Config) {
+	for _, plugin := range fileConfig.Plugins {
+		idx := slices.IndexFunc(defaultConfig.Plugins, func(p Plugin) bool { return p.Name == plugin.Name })
+		if idx >= 0 {
+			defaultConfig.Plugins[idx] = plugin
+			continue
+		}
+		defaultConfig.Plugins = append(defaultConfig.Plugins, plugin)
+	}
+}
+
+// mergeEntitiesConfig merges entity types. Entities from fileConfig replace entities with
+// the same name and are appended otherwise.
+func mergeEntitiesConfig(defaultConfig, fileConfig *Config) {
+	for _, entity := range fileConfig.Entities {
+		idx := slices.IndexFunc(defaultConfig.Entities, func(e Entity) bool { return e.Name == entity.Name })
+		if idx >= 0 {
+			defaultConfig.Entities[idx] = entity
+			continue
+		}
+		defaultConfig.Entities = append(defaultConfig.Entities, entity)
+	}
+}
+
+// mergeNotificationsConfig merges the notifications settings.
+func mergeNotificationsConfig(defaultConfig, fileConfig *Config) {
+	n := fileConfig.Notifications
+	if n.Webhook != "" {
+		defaultConfig.Notifications.Webhook = n.Webhook
+	}
+	if n.Socket != "" {
+		defaultConfig.Notifications.Socket = n.Socket
+	}
+	if n.Headers != nil {
+		defaultConfig.Notifications.Headers = n.Headers
+	}
+	if n.Commands != nil {
+		defaultConfig.Notifications.Commands = n.Commands
+	}
+	if n.Timeout != "" {
+		defaultConfig.Notifications.Timeout = n.Timeout
+	}
+}
+
+// mergeDisplayConfig merges the output settings.
+func mergeDisplayConfig(defaultConfig, fileConfig *Config) {
+	if fileConfig.Display.RelativePaths {
+		defaultConfig.Display.RelativePaths = true
+	}
+}
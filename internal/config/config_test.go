@@ -6,7 +6,9 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/indaco/tempo/internal/utils"
 	"gopkg.in/yaml.v3"
@@ -17,9 +19,13 @@ func TestDefaultConfig(t *testing.T) {
 		TempoRoot: DefaultBaseDir,
 		App: App{
 			GoPackage: "components",
-			WithJs:    false,
 			AssetsDir: "assets",
 		},
+		Layout: Layout{
+			StylesDir:   DefaultStylesDir,
+			VariantsDir: DefaultVariantsDir,
+			ScriptsDir:  DefaultScriptsDir,
+		},
 		Paths: Paths{
 			TemplatesDir: filepath.Join(DefaultBaseDir, "templates"),
 			ActionsDir:   filepath.Join(DefaultBaseDir, "actions"),
@@ -60,6 +66,34 @@ func TestLoadConfig_NoFile(t *testing.T) {
 	}
 }
 
+func TestLoadConfigFrom(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("tempo.yaml", []byte("app:\n  go_package: ignored\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "ci.yaml")
+	if err := os.WriteFile(path, []byte("app:\n  go_package: ui\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadConfigFrom(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom() returned an error: %v", err)
+	}
+	if cfg.App.GoPackage != "ui" {
+		t.Errorf("Expected the go_package of %s, got %q", path, cfg.App.GoPackage)
+	}
+
+	cfg, err = LoadConfigFrom(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("LoadConfigFrom() returned an error: %v", err)
+	}
+	if cfg.App.GoPackage != DefaultConfig().App.GoPackage {
+		t.Errorf("Expected the default go_package for a missing file, got %q", cfg.App.GoPackage)
+	}
+}
+
 func TestLoadConfig_ReadError(t *testing.T) {
 	tempDir := t.TempDir()
 	if err := os.Chdir(tempDir); err != nil {
@@ -114,6 +148,7 @@ func TestLoadConfig_ParseError(t *testing.T) {
 
 func TestLoadConfig_WithFile(t *testing.T) {
 	tempDir := t.TempDir()
+	withJs := true
 	err := os.Chdir(tempDir)
 	if err != nil {
 		t.Fatalf("Failed to change directory to tempDir: %v", err)
@@ -125,10 +160,15 @@ func TestLoadConfig_WithFile(t *testing.T) {
 		App: App{
 			GoModule:  "github.com/custom/module",
 			GoPackage: "custom_package",
-			WithJs:    true,
+			WithJs:    &withJs,
 			CssLayer:  "custom_layer",
 			AssetsDir: "custom_assets",
 		},
+		Layout: Layout{
+			StylesDir:   DefaultStylesDir,
+			VariantsDir: DefaultVariantsDir,
+			ScriptsDir:  DefaultScriptsDir,
+		},
 		Paths: Paths{
 			TemplatesDir: "custom-tempo/templates",
 			ActionsDir:   "custom-tempo/actions",
@@ -136,6 +176,17 @@ func TestLoadConfig_WithFile(t *testing.T) {
 		Processor: Processor{
 			Workers:       8,
 			SummaryFormat: "json",
+			Autoprefixer: Autoprefixer{
+				Enabled:  true,
+				Browsers: []string{"safari 13", "firefox 78"},
+			},
+			Guards: Guards{
+				MaxCSSSize:        "100KB",
+				ForbiddenPatterns: []string{`@import\s+url`},
+			},
+			Outputs: []OutputRule{
+				{Input: "admin/**", Output: "internal/admin/ui"},
+			},
 		},
 		Templates: Templates{
 			Extensions:  DefaultTemplateExtensions,
@@ -173,6 +224,7 @@ func TestEnsureDefaults(t *testing.T) {
 		TempoRoot: "custom-tempo",
 		Templates: Templates{
 			GuardMarker: "custom-marker",
+			Watermark:   "Generated by {{ .UserData.framework }}",
 			UserData: map[string]any{
 				"framework": "tempo",
 			},
@@ -185,9 +237,13 @@ func TestEnsureDefaults(t *testing.T) {
 		TempoRoot: "custom-tempo",
 		App: App{
 			GoPackage: "components",
-			WithJs:    false,
 			AssetsDir: "assets",
 		},
+		Layout: Layout{
+			StylesDir:   DefaultStylesDir,
+			VariantsDir: DefaultVariantsDir,
+			ScriptsDir:  DefaultScriptsDir,
+		},
 		Paths: Paths{
 			TemplatesDir: filepath.Join("custom-tempo", "templates"),
 			ActionsDir:   filepath.Join("custom-tempo", "actions"),
@@ -199,6 +255,7 @@ func TestEnsureDefaults(t *testing.T) {
 		Templates: Templates{
 			Extensions:  DefaultTemplateExtensions,
 			GuardMarker: "custom-marker",
+			Watermark:   "Generated by {{ .UserData.framework }}",
 			UserData: map[string]any{
 				"framework": "tempo",
 			},
@@ -276,3 +333,408 @@ func TestLoadConfig_Fallback(t *testing.T) {
 		t.Errorf("Expected 'preferred_module', got '%s'", config.App.GoModule)
 	}
 }
+
+func TestMergePluginsConfig(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	defaultConfig.Plugins = []Plugin{
+		{Name: "postcss", Kind: "transformer", Command: "postcss-global"},
+		{Name: "license", Kind: "action", Command: "license-header"},
+	}
+	fileConfig := &Config{
+		Plugins: []Plugin{
+			{Name: "postcss", Kind: "transformer", Command: "./bin/postcss", Extensions: []string{".css"}},
+			{Name: "lint", Kind: "transformer", Command: "lint"},
+		},
+	}
+
+	mergePluginsConfig(defaultConfig, fileConfig)
+
+	expected := []Plugin{
+		{Name: "postcss", Kind: "transformer", Command: "./bin/postcss", Extensions: []string{".css"}},
+		{Name: "license", Kind: "action", Command: "license-header"},
+		{Name: "lint", Kind: "transformer", Command: "lint"},
+	}
+	if !reflect.DeepEqual(defaultConfig.Plugins, expected) {
+		t.Errorf("mergePluginsConfig() = %+v, want %+v", defaultConfig.Plugins, expected)
+	}
+}
+
+func TestMergeEntitiesConfig(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	defaultConfig.Entities = []Entity{{Name: "page"}, {Name: "layout"}}
+	fileConfig := &Config{
+		Entities: []Entity{
+			{Name: "page", Templates: "pages", Flags: []EntityFlag{{Name: "title", Required: true}}},
+			{Name: "email"},
+		},
+	}
+
+	mergeEntitiesConfig(defaultConfig, fileConfig)
+
+	expected := []Entity{
+		{Name: "page", Templates: "pages", Flags: []EntityFlag{{Name: "title", Required: true}}},
+		{Name: "layout"},
+		{Name: "email"},
+	}
+	if !reflect.DeepEqual(defaultConfig.Entities, expected) {
+		t.Errorf("mergeEntitiesConfig() = %+v, want %+v", defaultConfig.Entities, expected)
+	}
+	if got := expected[0].TemplatesFolder(); got != "pages" {
+		t.Errorf("Expected the configured templates folder, got %q", got)
+	}
+	if got := expected[1].ActionsFile(); got != "layout.json" {
+		t.Errorf("Expected the default actions file, got %q", got)
+	}
+	if got := expected[0].Flags[0].FlagType(); got != EntityFlagString {
+		t.Errorf("Expected the default flag type, got %q", got)
+	}
+}
+
+func TestMergeFileModesConfig(t *testing.T) {
+	preserve := false
+	defaultConfig := DefaultConfig()
+	defaultConfig.FileModes = FileModes{Default: "0644", ByExtension: map[string]string{".templ": "0664", ".css": "0640"}}
+	fileConfig := &Config{
+		FileModes: FileModes{ByExtension: map[string]string{".templ": "0660"}, PreserveExisting: &preserve},
+	}
+
+	mergeFileModesConfig(defaultConfig, fileConfig)
+
+	expected := FileModes{
+		Default:          "0644",
+		ByExtension:      map[string]string{".templ": "0660", ".css": "0640"},
+		PreserveExisting: &preserve,
+	}
+	if !reflect.DeepEqual(defaultConfig.FileModes, expected) {
+		t.Errorf("mergeFileModesConfig() = %+v, want %+v", defaultConfig.FileModes, expected)
+	}
+}
+
+func TestFileModes_Policy(t *testing.T) {
+	policy, err := FileModes{}.Policy()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !policy.PreserveExisting || policy.ModeFor("button.templ", 0) != utils.DefaultFileMode {
+		t.Errorf("Expected the default policy to preserve existing files with the default mode, got %+v", policy)
+	}
+
+	if _, err := (FileModes{Default: "0999"}).Policy(); err == nil {
+		t.Error("Expected an error for an invalid default mode")
+	}
+}
+
+func TestRetry_Delays(t *testing.T) {
+	backoff, maxBackoff, err := Retry{MaxRetries: 3, Backoff: "250ms", MaxBackoff: "2s"}.Delays()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if backoff != 250*time.Millisecond || maxBackoff != 2*time.Second {
+		t.Errorf("Unexpected delays: %v, %v", backoff, maxBackoff)
+	}
+
+	for _, retry := range []Retry{{MaxRetries: -1}, {Backoff: "soon"}, {MaxBackoff: "-1s"}} {
+		if _, _, err := retry.Delays(); err == nil {
+			t.Errorf("Expected an error for %+v", retry)
+		}
+	}
+}
+
+func TestMergeHistoryConfig(t *testing.T) {
+	enabled, disabled := true, false
+	defaultConfig := DefaultConfig()
+	defaultConfig.History = History{Enabled: &enabled, MaxEntries: 500}
+
+	mergeHistoryConfig(defaultConfig, &Config{History: History{Enabled: &disabled}})
+
+	if defaultConfig.History.IsEnabled() {
+		t.Error("Expected the project config to disable the history enabled globally")
+	}
+	if defaultConfig.History.MaxEntries != 500 {
+		t.Errorf("Expected max_entries to be kept, got %d", defaultConfig.History.MaxEntries)
+	}
+}
+
+func TestMergeAppConfig_WithJs(t *testing.T) {
+	enabled, disabled := true, false
+	defaultConfig := DefaultConfig()
+	defaultConfig.App.WithJs = &enabled
+
+	mergeAppConfig(defaultConfig, &Config{App: App{WithJs: &disabled}})
+
+	if defaultConfig.App.JsEnabled() {
+		t.Error("Expected the project config to disable the with_js enabled globally")
+	}
+
+	mergeAppConfig(defaultConfig, &Config{})
+	if defaultConfig.App.JsEnabled() {
+		t.Error("Expected an unset with_js to keep the current value")
+	}
+}
+
+func TestMergeRootConfig_AssumeYes(t *testing.T) {
+	enabled, disabled := true, false
+	defaultConfig := DefaultConfig()
+	defaultConfig.AssumeYes = &enabled
+
+	mergeRootConfig(defaultConfig, &Config{AssumeYes: &disabled})
+
+	if defaultConfig.AssumesYes() {
+		t.Error("Expected the project config to disable the assume_yes enabled globally")
+	}
+}
+
+func TestMergeConfig_BoolSettingsDisabledByProject(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		name    string
+		enable  func(cfg *Config, value *bool)
+		merge   func(defaultConfig, fileConfig *Config)
+		enabled func(cfg *Config) bool
+	}{
+		{
+			name:    "with_ts_decls",
+			enable:  func(cfg *Config, value *bool) { cfg.App.WithTsDecls = value },
+			merge:   mergeAppConfig,
+			enabled: func(cfg *Config) bool { return cfg.App.TsDeclsEnabled() },
+		},
+		{
+			name:    "a11y_audit",
+			enable:  func(cfg *Config, value *bool) { cfg.Templates.A11yAudit = value },
+			merge:   mergeTemplatesConfig,
+			enabled: func(cfg *Config) bool { return cfg.Templates.A11yAuditEnabled() },
+		},
+		{
+			name:    "render_cache",
+			enable:  func(cfg *Config, value *bool) { cfg.Templates.RenderCache = value },
+			merge:   mergeTemplatesConfig,
+			enabled: func(cfg *Config) bool { return cfg.Templates.RenderCacheEnabled() },
+		},
+		{
+			name:    "layered",
+			enable:  func(cfg *Config, value *bool) { cfg.Templates.Layered = value },
+			merge:   mergeTemplatesConfig,
+			enabled: func(cfg *Config) bool { return cfg.Templates.LayeredEnabled() },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Global config: enabled
+			cfg := DefaultConfig()
+			global := &Config{}
+			tt.enable(global, &enabled)
+			tt.merge(cfg, global)
+			if !tt.enabled(cfg) {
+				t.Fatalf("Expected %s to be enabled by the global config", tt.name)
+			}
+
+			// Unset in the project config: unchanged
+			tt.merge(cfg, &Config{})
+			if !tt.enabled(cfg) {
+				t.Errorf("Expected an unset %s to keep the current value", tt.name)
+			}
+
+			// Project config: disabled
+			project := &Config{}
+			tt.enable(project, &disabled)
+			tt.merge(cfg, project)
+			if tt.enabled(cfg) {
+				t.Errorf("Expected the project config to disable the %s enabled globally", tt.name)
+			}
+		})
+	}
+}
+
+func TestJS_PackageManagerCommand(t *testing.T) {
+	if got := (JS{}).PackageManagerCommand(); !slices.Equal(got, []string{DefaultPackageManager}) {
+		t.Errorf("Expected the default package manager, got %v", got)
+	}
+
+	defaultConfig := DefaultConfig()
+	mergeJSConfig(defaultConfig, &Config{JS: JS{PackageManager: "corepack pnpm"}})
+	if got := defaultConfig.JS.PackageManagerCommand(); !slices.Equal(got, []string{"corepack", "pnpm"}) {
+		t.Errorf("Expected the merged package manager split into fields, got %v", got)
+	}
+}
+
+func TestBuild_TemplCommand(t *testing.T) {
+	if got := (Build{}).TemplCommand(); !slices.Equal(got, []string{DefaultTemplCommand}) {
+		t.Errorf("Expected the default templ command, got %v", got)
+	}
+	if got := (Build{Templ: "go tool templ"}).TemplCommand(); !slices.Equal(got, []string{"go", "tool", "templ"}) {
+		t.Errorf("Expected the configured command split into fields, got %v", got)
+	}
+
+	enabled, disabled := true, false
+	defaultConfig := DefaultConfig()
+	mergeBuildConfig(defaultConfig, &Config{Build: Build{Templ: "./bin/templ", GoBuild: &enabled}})
+	if defaultConfig.Build.Templ != "./bin/templ" || !defaultConfig.Build.GoBuildEnabled() {
+		t.Errorf("Expected the build settings to be merged, got %+v", defaultConfig.Build)
+	}
+
+	mergeBuildConfig(defaultConfig, &Config{Build: Build{GoBuild: &disabled}})
+	if defaultConfig.Build.GoBuildEnabled() {
+		t.Error("Expected the project config to disable the go_build enabled globally")
+	}
+}
+
+func TestHistory_Path(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	path, err := History{}.Path()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	globalPath, _ := GlobalConfigPath()
+	if path != filepath.Join(filepath.Dir(globalPath), HistoryFile) {
+		t.Errorf("Expected the history next to the user-level config, got %s", path)
+	}
+
+	if path, _ := (History{File: "custom.jsonl"}).Path(); path != "custom.jsonl" {
+		t.Errorf("Expected the configured file, got %s", path)
+	}
+}
+
+func TestApp_RequiresGoModule(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	if !DefaultConfig().App.RequiresGoModule() {
+		t.Errorf("Expected a Go module to be required by default")
+	}
+
+	if err := os.WriteFile("tempo.yaml", []byte("app:\n  require_go_module: false\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.App.RequiresGoModule() {
+		t.Errorf("Expected require_go_module: false to disable the Go module requirement")
+	}
+}
+
+func TestNotifications_Notifies(t *testing.T) {
+	if (Notifications{}).IsEnabled() {
+		t.Error("Expected the notifications to be disabled without webhook or socket")
+	}
+
+	n := Notifications{Webhook: "http://localhost"}
+	for command, want := range map[string]bool{"sync": true, "component new": true, "new page": true, "component define": false, "list": false} {
+		if got := n.Notifies(command); got != want {
+			t.Errorf("Notifies(%q) = %v, want %v", command, got, want)
+		}
+	}
+
+	n.Commands = []string{"component"}
+	if !n.Notifies("component define") || n.Notifies("sync") {
+		t.Error("Expected the configured commands to replace the defaults")
+	}
+}
+
+func TestNotifications_DeliveryTimeout(t *testing.T) {
+	if timeout, err := (Notifications{}).DeliveryTimeout(); err != nil || timeout != DefaultNotificationTimeout {
+		t.Errorf("Expected the default timeout, got %v (%v)", timeout, err)
+	}
+	if timeout, err := (Notifications{Timeout: "2s"}).DeliveryTimeout(); err != nil || timeout != 2*time.Second {
+		t.Errorf("Expected 2s, got %v (%v)", timeout, err)
+	}
+	if _, err := (Notifications{Timeout: "soon"}).DeliveryTimeout(); err == nil {
+		t.Error("Expected an error for an invalid timeout")
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	tempDir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "valid", content: "app:\n  go_package: components\nci:\n  fail_fast: true\n"},
+		{name: "empty", content: ""},
+		{name: "unknown key", content: "app:\n  go_pakage: components\n", wantErr: true},
+		{name: "invalid yaml", content: "app: [unterminated", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tempDir, tt.name+".yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+			if err := ValidateFile(path); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMergeProcessorConfig_JSInjection(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	if !defaultConfig.Processor.JSInjection.Escapes() {
+		t.Error("Expected the injected JS to be escaped by default")
+	}
+
+	disabled := false
+	mergeProcessorConfig(defaultConfig, &Config{Processor: Processor{JSInjection: JSInjection{Escape: &disabled, WrapIIFE: true}}})
+	if defaultConfig.Processor.JSInjection.Escapes() || !defaultConfig.Processor.JSInjection.WrapIIFE {
+		t.Errorf("Expected the JS injection settings to be merged, got %+v", defaultConfig.Processor.JSInjection)
+	}
+}
+
+func TestMergeJournalConfig(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	mergeJournalConfig(defaultConfig, &Config{Journal: Journal{MaxEntries: 50, MaxAge: "30d"}})
+
+	age, err := defaultConfig.Journal.Age()
+	if err != nil || age != 30*24*time.Hour || defaultConfig.Journal.MaxEntries != 50 {
+		t.Errorf("Expected the journal retention to be merged, got %+v (age %s, err: %v)", defaultConfig.Journal, age, err)
+	}
+	if age, err := (History{}).Age(); err != nil || age != 0 {
+		t.Errorf("Expected no age limit by default, got %s (err: %v)", age, err)
+	}
+	for _, value := range []string{"soon", "0s"} {
+		if _, err := (History{MaxAge: value}).Age(); err == nil {
+			t.Errorf("Expected an error for max_age %q", value)
+		}
+	}
+}
+
+func TestMergeLayoutConfig(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	mergeLayoutConfig(defaultConfig, &Config{Layout: Layout{StylesDir: "styles"}})
+
+	want := Layout{StylesDir: "styles", VariantsDir: DefaultVariantsDir, ScriptsDir: DefaultScriptsDir}
+	if defaultConfig.Layout != want {
+		t.Errorf("Expected the layout %+v, got %+v", want, defaultConfig.Layout)
+	}
+	if err := defaultConfig.Layout.Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestLayout_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+	}{
+		{"default folder", Layout{StylesDir: "styles", VariantsDir: "", ScriptsDir: "css/variants"}},
+		{"absolute folder", Layout{StylesDir: "/css", VariantsDir: "variants", ScriptsDir: "js"}},
+		{"outside the component", Layout{StylesDir: "../css", VariantsDir: "variants", ScriptsDir: "js"}},
+		{"component folder", Layout{StylesDir: ".", VariantsDir: "variants", ScriptsDir: "js"}},
+		{"shared folder", Layout{StylesDir: "css", VariantsDir: "css/", ScriptsDir: "js"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.layout.Validate(); err == nil {
+				t.Errorf("Expected an error for %+v", tt.layout)
+			}
+		})
+	}
+}
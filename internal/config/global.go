@@ -0,0 +1,226 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES & CONSTANTS                                                         */
+/* ------------------------------------------------------------------------- */
+
+// GlobalConfigFile is the name of the user-level configuration file.
+const GlobalConfigFile = "config.yaml"
+
+// settingKind describes the expected type of a configurable value.
+type settingKind int
+
+const (
+	stringSetting settingKind = iota
+	boolSetting
+	intSetting
+)
+
+// SettableKeys lists the configuration keys that can be updated with `tempo config set`,
+// mapped to the type of value they accept.
+var SettableKeys = map[string]settingKind{
+	"assume_yes":                boolSetting,
+	"app.go_module":             stringSetting,
+	"app.go_package":            stringSetting,
+	"app.assets_dir":            stringSetting,
+	"app.with_js":               boolSetting,
+	"app.with_ts_decls":         boolSetting,
+	"app.css_layer":             stringSetting,
+	"app.require_go_module":     boolSetting,
+	"layout.styles_dir":         stringSetting,
+	"layout.variants_dir":       stringSetting,
+	"layout.scripts_dir":        stringSetting,
+	"processor.workers":         intSetting,
+	"processor.summary_format":  stringSetting,
+	"processor.io_throttle":     stringSetting,
+	"templates.guard_marker":    stringSetting,
+	"templates.watermark":       stringSetting,
+	"templates.header.template": stringSetting,
+	"templates.header.author":   stringSetting,
+	"templates.render_cache":    boolSetting,
+	"templates.layered":         boolSetting,
+	"templates.a11y_audit":      boolSetting,
+	"templates.manual_edits":    stringSetting,
+	"history.enabled":           boolSetting,
+	"history.max_entries":       intSetting,
+	"history.max_age":           stringSetting,
+	"journal.max_entries":       intSetting,
+	"journal.max_age":           stringSetting,
+	"build.templ":               stringSetting,
+	"build.go_build":            boolSetting,
+	"js.package_manager":        stringSetting,
+	"ci.fail_fast":              boolSetting,
+	"ci.report":                 stringSetting,
+	"notifications.webhook":     stringSetting,
+	"notifications.socket":      stringSetting,
+	"notifications.timeout":     stringSetting,
+	"display.relative_paths":    boolSetting,
+}
+
+/* ------------------------------------------------------------------------- */
+/* GLOBAL CONFIG                                                             */
+/* ------------------------------------------------------------------------- */
+
+// GlobalConfigPath returns the path to the user-level configuration file.
+// It honours $XDG_CONFIG_HOME and falls back to ~/.config when unset.
+func GlobalConfigPath() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", apperrors.Wrap("failed to resolve user home directory", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(configHome, "tempo", GlobalConfigFile), nil
+}
+
+// UserTemplatesDir returns the user-level templates folder, next to the user-level
+// configuration file. It mirrors the layout of the built-in templates.
+func UserTemplatesDir() (string, error) {
+	path, err := GlobalConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "templates"), nil
+}
+
+// SetValue sets a dotted configuration key (e.g. "processor.workers") in the YAML file
+// at filePath, creating the file and its parent folders when missing.
+// Existing content, ordering and comments are preserved.
+func SetValue(filePath, key, value string) error {
+	kind, ok := SettableKeys[key]
+	if !ok {
+		return apperrors.Wrap("unsupported config key %q", key)
+	}
+
+	valueNode, err := buildValueNode(kind, key, value)
+	if err != nil {
+		return err
+	}
+
+	root, err := readYAMLDocument(filePath)
+	if err != nil {
+		return err
+	}
+
+	node := root.Content[0]
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		node = mappingChild(node, part)
+	}
+	setMappingValue(node, parts[len(parts)-1], valueNode)
+
+	out, err := yaml.Marshal(root)
+	if err != nil {
+		return apperrors.Wrap("failed to encode config file", err, filePath)
+	}
+
+	if err := utils.EnsureDirExists(filepath.Dir(filePath)); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filePath, out, 0644); err != nil {
+		return apperrors.Wrap("failed to write config file", err, filePath)
+	}
+
+	return nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// buildValueNode validates value against the expected kind and returns its YAML node.
+func buildValueNode(kind settingKind, key, value string) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Value: value, Tag: "!!str"}
+
+	switch kind {
+	case boolSetting:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, apperrors.Wrap("invalid boolean value for %q", err, key)
+		}
+		node.Tag, node.Value = "!!bool", strconv.FormatBool(b)
+	case intSetting:
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return nil, apperrors.Wrap("value for %q must be a positive integer", key)
+		}
+		node.Tag, node.Value = "!!int", strconv.Itoa(n)
+	}
+
+	return node, nil
+}
+
+// readYAMLDocument loads the YAML document at filePath, returning an empty
+// mapping document when the file does not exist or is empty.
+func readYAMLDocument(filePath string) (*yaml.Node, error) {
+	doc := &yaml.Node{
+		Kind:    yaml.DocumentNode,
+		Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return doc, nil
+		}
+		return nil, apperrors.Wrap("failed to read config file", err, filePath)
+	}
+
+	var parsed yaml.Node
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, apperrors.Wrap("failed to parse config file", err, filePath)
+	}
+
+	if parsed.Kind != yaml.DocumentNode || len(parsed.Content) == 0 {
+		return doc, nil
+	}
+	if parsed.Content[0].Kind != yaml.MappingNode {
+		return nil, apperrors.Wrap("config file must contain a YAML mapping", filePath)
+	}
+
+	return &parsed, nil
+}
+
+// mappingChild returns the mapping stored under key, creating it when missing.
+func mappingChild(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			child := node.Content[i+1]
+			if child.Kind != yaml.MappingNode {
+				child.Kind, child.Tag, child.Value, child.Content = yaml.MappingNode, "!!map", "", nil
+			}
+			return child
+		}
+	}
+
+	child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+	return child
+}
+
+// setMappingValue replaces or appends the value stored under key.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			value.LineComment = node.Content[i+1].LineComment
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUserTemplatesDir(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	dir, err := UserTemplatesDir()
+	if err != nil {
+		t.Fatalf("UserTemplatesDir() returned an error: %v", err)
+	}
+	if expected := filepath.Join(configHome, "tempo", "templates"); dir != expected {
+		t.Errorf("UserTemplatesDir() = %q, want %q", dir, expected)
+	}
+}
+
+func TestGlobalConfigPath(t *testing.T) {
+	t.Run("XDG_CONFIG_HOME set", func(t *testing.T) {
+		configHome := t.TempDir()
+		t.Setenv("XDG_CONFIG_HOME", configHome)
+
+		path, err := GlobalConfigPath()
+		if err != nil {
+			t.Fatalf("GlobalConfigPath() returned an error: %v", err)
+		}
+
+		expected := filepath.Join(configHome, "tempo", "config.yaml")
+		if path != expected {
+			t.Errorf("GlobalConfigPath() = %q, want %q", path, expected)
+		}
+	})
+
+	t.Run("fallback to ~/.config", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("XDG_CONFIG_HOME", "")
+		t.Setenv("HOME", home)
+
+		path, err := GlobalConfigPath()
+		if err != nil {
+			t.Fatalf("GlobalConfigPath() returned an error: %v", err)
+		}
+
+		expected := filepath.Join(home, ".config", "tempo", "config.yaml")
+		if path != expected {
+			t.Errorf("GlobalConfigPath() = %q, want %q", path, expected)
+		}
+	})
+}
+
+func TestLoadConfig_GlobalBeneathProject(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	globalPath := filepath.Join(configHome, "tempo", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(globalPath), 0755); err != nil {
+		t.Fatalf("Failed to create global config dir: %v", err)
+	}
+	globalYaml := "processor:\n  workers: 3\n  summary_format: long\ntemplates:\n  guard_marker: global\n"
+	if err := os.WriteFile(globalPath, []byte(globalYaml), 0644); err != nil {
+		t.Fatalf("Failed to write global config: %v", err)
+	}
+
+	projectDir := t.TempDir()
+	if err := os.Chdir(projectDir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	if err := os.WriteFile("tempo.yaml", []byte("processor:\n  summary_format: json\n"), 0644); err != nil {
+		t.Fatalf("Failed to write project config: %v", err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() returned an error: %v", err)
+	}
+
+	if cfg.Processor.Workers != 3 {
+		t.Errorf("Expected workers from global config (3), got %d", cfg.Processor.Workers)
+	}
+	if cfg.Processor.SummaryFormat != "json" {
+		t.Errorf("Expected project summary format to win (json), got %q", cfg.Processor.SummaryFormat)
+	}
+	if cfg.Templates.GuardMarker != "global" {
+		t.Errorf("Expected guard marker from global config, got %q", cfg.Templates.GuardMarker)
+	}
+}
+
+func TestLoadConfig_GlobalParseError(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	globalPath := filepath.Join(configHome, "tempo", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(globalPath), 0755); err != nil {
+		t.Fatalf("Failed to create global config dir: %v", err)
+	}
+	if err := os.WriteFile(globalPath, []byte("processor: [broken"), 0644); err != nil {
+		t.Fatalf("Failed to write global config: %v", err)
+	}
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	if _, err := LoadConfig(); err == nil || !strings.Contains(err.Error(), "failed to parse config file:") {
+		t.Errorf("Expected parse error, got: %v", err)
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "tempo", "config.yaml")
+
+	if err := SetValue(filePath, "processor.workers", "6"); err != nil {
+		t.Fatalf("SetValue() returned an error: %v", err)
+	}
+	if err := SetValue(filePath, "app.with_js", "true"); err != nil {
+		t.Fatalf("SetValue() returned an error: %v", err)
+	}
+	if err := SetValue(filePath, "processor.workers", "12"); err != nil {
+		t.Fatalf("SetValue() returned an error: %v", err)
+	}
+
+	cfg, err := readConfigFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	if cfg.Processor.Workers != 12 {
+		t.Errorf("Expected workers 12, got %d", cfg.Processor.Workers)
+	}
+	if !cfg.App.JsEnabled() {
+		t.Errorf("Expected with_js to be true")
+	}
+}
+
+func TestSetValue_PreservesExistingContent(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "tempo.yaml")
+	content := "# project settings\napp:\n  go_module: github.com/example/app # module\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	if err := SetValue(filePath, "app.css_layer", "components"); err != nil {
+		t.Fatalf("SetValue() returned an error: %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	for _, want := range []string{"# project settings", "go_module: github.com/example/app # module", "css_layer: components"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected config file to contain %q, got:\n%s", want, data)
+		}
+	}
+}
+
+func TestSetValue_Errors(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.yaml")
+
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{name: "unknown key", key: "app.unknown", value: "x"},
+		{name: "invalid bool", key: "app.with_js", value: "maybe"},
+		{name: "invalid int", key: "processor.workers", value: "many"},
+		{name: "non-positive int", key: "processor.workers", value: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetValue(filePath, tt.key, tt.value); err == nil {
+				t.Errorf("SetValue(%q, %q) expected an error, got nil", tt.key, tt.value)
+			}
+		})
+	}
+}
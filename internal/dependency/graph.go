@@ -0,0 +1,180 @@
+// Package dependency resolves the dependencies declared between components.
+package dependency
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Graph maps a component name to the names of the components it depends on.
+type Graph map[string][]string
+
+// ErrCycle is returned when the declared dependencies contain a cycle.
+var ErrCycle = fmt.Errorf("dependency cycle detected")
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTOR                                                               */
+/* ------------------------------------------------------------------------- */
+
+// NewGraph builds a Graph from the declared dependencies, normalizing names with
+// the normalize function (if not nil) and removing duplicated entries.
+func NewGraph(declared map[string][]string, normalize func(string) string) Graph {
+	if normalize == nil {
+		normalize = func(s string) string { return s }
+	}
+
+	g := make(Graph, len(declared))
+	for name, deps := range declared {
+		key := normalize(name)
+		for _, dep := range deps {
+			dep = normalize(dep)
+			if dep != "" && !slices.Contains(g[key], dep) {
+				g[key] = append(g[key], dep)
+			}
+		}
+		if _, ok := g[key]; !ok {
+			g[key] = nil
+		}
+	}
+	return g
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// Resolve returns the transitive dependencies of name in generation order:
+// each component appears after the components it depends on. The component
+// itself is not included.
+func (g Graph) Resolve(name string) ([]string, error) {
+	var order []string
+	visited := map[string]bool{}
+	var path []string
+
+	var visit func(string) error
+	visit = func(node string) error {
+		if idx := slices.Index(path, node); idx != -1 {
+			cycle := append(slices.Clone(path[idx:]), node)
+			return apperrors.WrapCode(apperrors.CodeDependencyCycle, "dependency cycle detected: %s", ErrCycle, strings.Join(cycle, " -> "))
+		}
+		if visited[node] {
+			return nil
+		}
+
+		path = append(path, node)
+		for _, dep := range g[node] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+
+		visited[node] = true
+		if node != name {
+			order = append(order, node)
+		}
+		return nil
+	}
+
+	if err := visit(name); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Nodes returns the sorted names of all components found in the graph,
+// including those only referenced as dependencies.
+func (g Graph) Nodes() []string {
+	seen := map[string]bool{}
+	for name, deps := range g {
+		seen[name] = true
+		for _, dep := range deps {
+			seen[dep] = true
+		}
+	}
+	return sortedKeys(seen)
+}
+
+// Roots returns the sorted names of the components no other component depends on.
+func (g Graph) Roots() []string {
+	dependedOn := map[string]bool{}
+	for _, deps := range g {
+		for _, dep := range deps {
+			dependedOn[dep] = true
+		}
+	}
+
+	var roots []string
+	for _, name := range g.Nodes() {
+		if !dependedOn[name] {
+			roots = append(roots, name)
+		}
+	}
+	return roots
+}
+
+// WriteTree prints the dependency tree of each root. The optional label function
+// decorates node names (e.g. to flag missing components). Cycles are marked instead
+// of being followed.
+func (g Graph) WriteTree(w io.Writer, roots []string, label func(string) string) error {
+	if label == nil {
+		label = func(s string) string { return s }
+	}
+
+	var walk func(node, prefix string, ancestors []string) error
+	walk = func(node, prefix string, ancestors []string) error {
+		deps := g[node]
+		for i, dep := range deps {
+			branch, next := "├── ", "│   "
+			if i == len(deps)-1 {
+				branch, next = "└── ", "    "
+			}
+
+			if slices.Contains(ancestors, dep) {
+				if _, err := fmt.Fprintf(w, "%s%s%s (cycle)\n", prefix, branch, label(dep)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "%s%s%s\n", prefix, branch, label(dep)); err != nil {
+				return err
+			}
+			if err := walk(dep, prefix+next, append(ancestors, dep)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, root := range roots {
+		if _, err := fmt.Fprintln(w, label(root)); err != nil {
+			return err
+		}
+		if err := walk(root, "", []string{root}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
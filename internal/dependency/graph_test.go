@@ -0,0 +1,111 @@
+package dependency
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewGraph(t *testing.T) {
+	g := NewGraph(map[string][]string{
+		"Dropdown": {"Button", "button", ""},
+		"button":   nil,
+	}, strings.ToLower)
+
+	expected := Graph{"dropdown": {"button"}, "button": nil}
+	if !reflect.DeepEqual(g, expected) {
+		t.Errorf("Expected %v, got %v", expected, g)
+	}
+}
+
+func TestGraph_Resolve(t *testing.T) {
+	g := NewGraph(map[string][]string{
+		"dropdown": {"button", "menu"},
+		"menu":     {"icon", "button"},
+		"button":   {"icon"},
+		"a":        {"b"},
+		"b":        {"c"},
+		"c":        {"a"},
+	}, nil)
+
+	tests := []struct {
+		name      string
+		component string
+		expected  []string
+		wantCycle bool
+	}{
+		{name: "No dependencies", component: "icon", expected: nil},
+		{name: "Direct dependency", component: "button", expected: []string{"icon"}},
+		{name: "Transitive dependencies in generation order", component: "dropdown", expected: []string{"icon", "button", "menu"}},
+		{name: "Undeclared component", component: "card", expected: nil},
+		{name: "Cycle", component: "a", wantCycle: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := g.Resolve(tt.component)
+			if tt.wantCycle {
+				if !errors.Is(err, ErrCycle) {
+					t.Fatalf("Expected ErrCycle, got %v", err)
+				}
+				if !strings.Contains(err.Error(), "a -> b -> c -> a") {
+					t.Errorf("Expected cycle path in error, got %q", err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(order, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, order)
+			}
+		})
+	}
+}
+
+func TestGraph_NodesAndRoots(t *testing.T) {
+	g := NewGraph(map[string][]string{
+		"dropdown": {"button"},
+		"card":     nil,
+	}, nil)
+
+	if nodes := g.Nodes(); !reflect.DeepEqual(nodes, []string{"button", "card", "dropdown"}) {
+		t.Errorf("Unexpected nodes: %v", nodes)
+	}
+	if roots := g.Roots(); !reflect.DeepEqual(roots, []string{"card", "dropdown"}) {
+		t.Errorf("Unexpected roots: %v", roots)
+	}
+}
+
+func TestGraph_WriteTree(t *testing.T) {
+	g := NewGraph(map[string][]string{
+		"dropdown": {"button", "menu"},
+		"menu":     {"icon"},
+		"loop":     {"loop"},
+	}, nil)
+
+	var sb strings.Builder
+	label := func(name string) string {
+		if name == "icon" {
+			return name + " (missing)"
+		}
+		return name
+	}
+	if err := g.WriteTree(&sb, []string{"dropdown", "loop"}, label); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"dropdown",
+		"├── button",
+		"└── menu",
+		"    └── icon (missing)",
+		"loop",
+		"└── loop (cycle)",
+		"",
+	}, "\n")
+	if sb.String() != expected {
+		t.Errorf("Unexpected tree:\n%s\nexpected:\n%s", sb.String(), expected)
+	}
+}
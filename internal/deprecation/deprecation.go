@@ -0,0 +1,115 @@
+// Package deprecation records the deprecated components of a project and marks their
+// templ functions, so that their remaining uses can be migrated to a replacement.
+package deprecation
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// ManifestFile is the name of the file, in the tempo root folder, recording the
+// deprecated components.
+const ManifestFile = "deprecations.json"
+
+// commentPrefix starts the Go deprecation notice, recognized by linters and editors.
+const commentPrefix = "// Deprecated:"
+
+// templDecl matches the declaration of a templ function or method.
+var templDecl = regexp.MustCompile(`^templ\s+(\([^)]*\)\s*)?[A-Za-z_]\w*\s*\(`)
+
+// Entry describes a deprecated component.
+type Entry struct {
+	Replacement string `json:"replacement,omitempty"` // Component to use instead
+	Since       string `json:"since"`                 // Deprecation date, formatted as YYYY-MM-DD
+}
+
+// Manifest maps the package names of the deprecated components to their entry.
+type Manifest map[string]Entry
+
+// ManifestPath returns the path of the deprecation manifest in the tempo root folder.
+func ManifestPath(tempoRoot string) string {
+	return filepath.Join(tempoRoot, ManifestFile)
+}
+
+// Load reads the deprecation manifest at path. A missing file is an empty manifest.
+func Load(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Manifest{}, nil
+		}
+		return nil, apperrors.Wrap("failed to read deprecation manifest", err, path)
+	}
+
+	m := Manifest{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, apperrors.Wrap("invalid deprecation manifest", err, path)
+	}
+	return m, nil
+}
+
+// Save writes the deprecation manifest at path.
+func Save(path string, m Manifest) error {
+	if err := utils.WriteJSONToFile(path, m); err != nil {
+		return apperrors.Wrap("failed to write deprecation manifest", err, path)
+	}
+	return nil
+}
+
+// Status returns the deprecation status of a component shown by the listings, e.g.
+// "deprecated, use card", or "" when the component is not deprecated.
+func (m Manifest) Status(name string) string {
+	entry, ok := m[name]
+	if !ok {
+		return ""
+	}
+	if entry.Replacement == "" {
+		return "deprecated"
+	}
+	return "deprecated, use " + entry.Replacement
+}
+
+// Notice returns the deprecation notice of the component name.
+func Notice(name, replacement string) string {
+	text := commentPrefix + " the " + name + " component is deprecated"
+	if replacement != "" {
+		text += ", use " + replacement + " instead"
+	}
+	return text + "."
+}
+
+// MarkTempl adds the notice above the templ declarations of content, replacing their
+// previous deprecation notice, and reports whether content changed.
+func MarkTempl(content, notice string) (string, bool) {
+	lines := strings.SplitAfter(content, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if !templDecl.MatchString(line) {
+			out = append(out, line)
+			continue
+		}
+		if n := len(out); n > 0 && strings.HasPrefix(out[n-1], commentPrefix) {
+			out = out[:n-1]
+		}
+		out = append(out, notice+lineEnding(line), line)
+	}
+
+	marked := strings.Join(out, "")
+	return marked, marked != content
+}
+
+// lineEnding returns the line ending of line, defaulting to "\n".
+func lineEnding(line string) string {
+	if strings.HasSuffix(line, "\r\n") {
+		return "\r\n"
+	}
+	return "\n"
+}
@@ -0,0 +1,83 @@
+package deprecation
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	path := ManifestPath(filepath.Join(t.TempDir(), ".tempo-files"))
+
+	m, err := Load(path)
+	if err != nil || len(m) != 0 {
+		t.Fatalf("Expected an empty manifest, got %v err=%v", m, err)
+	}
+
+	m["old_card"] = Entry{Replacement: "card", Since: "2026-10-16"}
+	if err := Save(path, m); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got["old_card"] != m["old_card"] {
+		t.Errorf("Unexpected entry: got %+v, want %+v", got["old_card"], m["old_card"])
+	}
+	if status := got.Status("old_card"); status != "deprecated, use card" {
+		t.Errorf("Unexpected status: %q", status)
+	}
+	if status := got.Status("card"); status != "" {
+		t.Errorf("Expected no status for a component in use, got %q", status)
+	}
+}
+
+func TestMarkTempl(t *testing.T) {
+	notice := Notice("old_card", "card")
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		changed bool
+	}{
+		{
+			name:    "function",
+			content: "package old_card\n\ntempl OldCard() {\n}\n",
+			want:    "package old_card\n\n" + notice + "\ntempl OldCard() {\n}\n",
+			changed: true,
+		},
+		{
+			name:    "method and CRLF",
+			content: "package old_card\r\n\r\ntempl (c Card) Render() {\r\n}\r\n",
+			want:    "package old_card\r\n\r\n" + notice + "\r\ntempl (c Card) Render() {\r\n}\r\n",
+			changed: true,
+		},
+		{
+			name:    "already marked",
+			content: notice + "\ntempl OldCard() {\n}\n",
+			want:    notice + "\ntempl OldCard() {\n}\n",
+		},
+		{
+			name:    "previous notice replaced",
+			content: Notice("old_card", "") + "\ntempl OldCard() {\n}\n",
+			want:    notice + "\ntempl OldCard() {\n}\n",
+			changed: true,
+		},
+		{
+			name:    "no templ declaration",
+			content: "package old_card\n\nvar templates = 1\n",
+			want:    "package old_card\n\nvar templates = 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := MarkTempl(tt.content, notice)
+			if got != tt.want || changed != tt.changed {
+				t.Errorf("MarkTempl() = %q, %v; want %q, %v", got, changed, tt.want, tt.changed)
+			}
+		})
+	}
+}
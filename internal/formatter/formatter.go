@@ -0,0 +1,194 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Options configures which files are formatted.
+type Options struct {
+	ActionsDir   string   // Folder containing the JSON action files
+	TemplatesDir string   // Folder containing the template files
+	Extensions   []string // Template file extensions to format (e.g. ".gotxt")
+	Check        bool     // Report unformatted files without rewriting them
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// Run formats the action files and templates described by opts and returns
+// the paths of the files that were (or, in check mode, would be) changed.
+func Run(opts Options) ([]string, error) {
+	var changed []string
+
+	actionFiles, err := collectFiles(opts.ActionsDir, []string{".json"})
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range actionFiles {
+		updated, err := formatFile(path, FormatActions, opts.Check)
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			changed = append(changed, path)
+		}
+	}
+
+	templateFiles, err := collectFiles(opts.TemplatesDir, opts.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range templateFiles {
+		updated, err := formatFile(path, func(content []byte) ([]byte, error) {
+			return FormatTemplate(content), nil
+		}, opts.Check)
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			changed = append(changed, path)
+		}
+	}
+
+	return changed, nil
+}
+
+// FormatActions normalizes an actions JSON file: known fields in a stable order,
+// two-space indentation and a trailing newline. Unknown fields are rejected so
+// that formatting never drops data.
+func FormatActions(content []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+
+	var actions generator.JSONActionList
+	if err := decoder.Decode(&actions); err != nil {
+		return nil, apperrors.Wrap("failed to parse actions JSON", err)
+	}
+
+	return generator.MarshalActionsJSON(actions)
+}
+
+// FormatTemplate normalizes template whitespace: CRLF line endings become LF,
+// trailing spaces and tabs are trimmed from every line, and the file ends with
+// exactly one newline. Leading indentation is left untouched.
+func FormatTemplate(content []byte) []byte {
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	text = strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if text == "" {
+		return []byte{}
+	}
+	return []byte(text + "\n")
+}
+
+// FirstChangedLine returns the first line, starting from 1, of the file at path that
+// formatting changes, or 0 when the file is formatted. Files are formatted as actions
+// when they have the ".json" extension and as templates otherwise.
+func FirstChangedLine(path string) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, apperrors.Wrap("failed to read file", err, path)
+	}
+
+	formatted := FormatTemplate(content)
+	if filepath.Ext(path) == ".json" {
+		if formatted, err = FormatActions(content); err != nil {
+			return 0, apperrors.Wrap("failed to format file", err, path)
+		}
+	}
+
+	if bytes.Equal(content, formatted) {
+		return 0, nil
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	formattedLines := strings.SplitAfter(string(formatted), "\n")
+	for i, line := range lines {
+		if i >= len(formattedLines) || line != formattedLines[i] {
+			return i + 1, nil
+		}
+	}
+	return len(lines), nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// formatFile applies format to the file at path and rewrites it unless check is set.
+// It reports whether the formatted content differs from the original.
+func formatFile(path string, format func([]byte) ([]byte, error), check bool) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, apperrors.Wrap("failed to read file", err, path)
+	}
+
+	formatted, err := format(content)
+	if err != nil {
+		return false, apperrors.Wrap("failed to format file", err, path)
+	}
+
+	if bytes.Equal(content, formatted) {
+		return false, nil
+	}
+
+	if !check {
+		if err := utils.WriteToFile(path, formatted); err != nil {
+			return false, apperrors.Wrap("failed to write file", err, path)
+		}
+	}
+
+	return true, nil
+}
+
+// collectFiles returns the sorted list of files under root matching one of the extensions.
+// A missing root folder yields no files.
+func collectFiles(root string, extensions []string) ([]string, error) {
+	if root == "" {
+		return nil, nil
+	}
+
+	exists, err := utils.DirExists(root)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && slices.Contains(extensions, filepath.Ext(path)) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to walk directory", err, root)
+	}
+
+	slices.Sort(files)
+	return files, nil
+}
@@ -0,0 +1,182 @@
+package formatter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFormatTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "already formatted", input: "a\n  b\n", expected: "a\n  b\n"},
+		{name: "trailing whitespace", input: "a  \n\tb\t\n", expected: "a\n\tb\n"},
+		{name: "crlf line endings", input: "a\r\nb\r\n", expected: "a\nb\n"},
+		{name: "missing final newline", input: "a\nb", expected: "a\nb\n"},
+		{name: "extra final newlines", input: "a\n\n\n", expected: "a\n"},
+		{name: "empty file", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(FormatTemplate([]byte(tt.input))); got != tt.expected {
+				t.Errorf("FormatTemplate(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatActions(t *testing.T) {
+	input := `[{"path":"out.templ","item":"file","templateFile":"a.gotxt"}]`
+	expected := "[\n  {\n    \"item\": \"file\",\n    \"templateFile\": \"a.gotxt\",\n    \"path\": \"out.templ\"\n  }\n]\n"
+
+	got, err := FormatActions([]byte(input))
+	if err != nil {
+		t.Fatalf("FormatActions() returned error: %v", err)
+	}
+	if string(got) != expected {
+		t.Errorf("FormatActions() = %q, want %q", got, expected)
+	}
+
+	// Formatting must be idempotent
+	again, err := FormatActions(got)
+	if err != nil {
+		t.Fatalf("FormatActions() returned error: %v", err)
+	}
+	if string(again) != expected {
+		t.Errorf("FormatActions() is not idempotent: %q", again)
+	}
+}
+
+func TestFormatActions_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "invalid json", input: `[{"item":`},
+		{name: "unknown field", input: `[{"item":"file","unknown":true}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FormatActions([]byte(tt.input)); err == nil {
+				t.Errorf("FormatActions(%q) expected an error, got nil", tt.input)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tempDir := t.TempDir()
+	actionsDir := filepath.Join(tempDir, "actions")
+	templatesDir := filepath.Join(tempDir, "templates")
+
+	files := map[string]string{
+		filepath.Join(actionsDir, "component.json"):           `[{"item":"file","path":"a"}]`,
+		filepath.Join(templatesDir, "component", "a.gotxt"):   "ok\n",
+		filepath.Join(templatesDir, "component", "b.gotxt"):   "dirty  \n",
+		filepath.Join(templatesDir, "component", "c.unknown"): "ignored  \n",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	opts := Options{
+		ActionsDir:   actionsDir,
+		TemplatesDir: templatesDir,
+		Extensions:   []string{".gotxt"},
+		Check:        true,
+	}
+	expected := []string{
+		filepath.Join(actionsDir, "component.json"),
+		filepath.Join(templatesDir, "component", "b.gotxt"),
+	}
+
+	t.Run("check mode", func(t *testing.T) {
+		changed, err := Run(opts)
+		if err != nil {
+			t.Fatalf("Run() returned error: %v", err)
+		}
+		if !reflect.DeepEqual(changed, expected) {
+			t.Errorf("Run() = %v, want %v", changed, expected)
+		}
+
+		content, _ := os.ReadFile(filepath.Join(templatesDir, "component", "b.gotxt"))
+		if string(content) != "dirty  \n" {
+			t.Errorf("Check mode must not rewrite files, got %q", content)
+		}
+	})
+
+	t.Run("write mode", func(t *testing.T) {
+		opts.Check = false
+		changed, err := Run(opts)
+		if err != nil {
+			t.Fatalf("Run() returned error: %v", err)
+		}
+		if !reflect.DeepEqual(changed, expected) {
+			t.Errorf("Run() = %v, want %v", changed, expected)
+		}
+
+		changed, err = Run(opts)
+		if err != nil {
+			t.Fatalf("Run() returned error: %v", err)
+		}
+		if len(changed) != 0 {
+			t.Errorf("Expected no changes on second run, got %v", changed)
+		}
+	})
+}
+
+func TestRun_MissingFolders(t *testing.T) {
+	changed, err := Run(Options{
+		ActionsDir:   filepath.Join(t.TempDir(), "missing"),
+		TemplatesDir: "",
+		Extensions:   []string{".gotxt"},
+	})
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("Expected no changes, got %v", changed)
+	}
+}
+
+func TestFirstChangedLine(t *testing.T) {
+	tempDir := t.TempDir()
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected int
+	}{
+		{"formatted template", "a.gotxt", "line1\nline2\n", 0},
+		{"trailing spaces", "b.gotxt", "line1\nline2  \nline3\n", 2},
+		{"extra trailing newlines", "c.gotxt", "line1\n\n\n", 2},
+		{"unformatted actions", "d.json", "[{\"item\":\"file\",\"templateFile\":\"a\",\"path\":\"b\"}]", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tempDir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+			line, err := FirstChangedLine(path)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if line != tt.expected {
+				t.Errorf("Expected line %d, got %d", tt.expected, line)
+			}
+		})
+	}
+}
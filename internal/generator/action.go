@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/indaco/tempo/internal/apperrors"
 	"github.com/indaco/tempo/internal/config"
 	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/templateengine"
 	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/watermark"
 )
 
 /* ------------------------------------------------------------------------- */
@@ -30,30 +33,40 @@ type ActionHandler interface {
 // Action represents a templating action with configurable properties.
 // It is used internally to process actions based on type (copy/render).
 type Action struct {
-	Type         string `json:"type,omitempty"`         // "copy" or "render"
-	Item         string `json:"item,omitempty"`         // "file" or "folder"
-	Path         string `json:"path,omitempty"`         // Output path (for "file")
-	TemplateFile string `json:"templateFile,omitempty"` // Template file path (for "file")
-	Source       string `json:"source,omitempty"`       // Base directory (for "folder")
-	Destination  string `json:"destination,omitempty"`  // Destination directory (for "folder")
-	OnlyIfJs     bool   `json:"onlyIfJs,omitempty"`     // Include only if --js is true
-	SkipIfExists bool   `json:"skipIfExists,omitempty"` // Skips a file if it already exists
-	Force        bool   `json:"force,omitempty"`        // Overwrites files if they exist
+	Type          string `json:"type,omitempty"`          // "copy" or "render"
+	Item          string `json:"item,omitempty"`          // "file" or "folder"
+	Path          string `json:"path,omitempty"`          // Output path (for "file")
+	TemplateFile  string `json:"templateFile,omitempty"`  // Template file path (for "file")
+	Source        string `json:"source,omitempty"`        // Base directory (for "folder"), or built-in template copied to TemplateFile (for "copy" "file")
+	Destination   string `json:"destination,omitempty"`   // Destination directory (for "folder")
+	OnlyIfJs      bool   `json:"onlyIfJs,omitempty"`      // Include only if --js is true
+	OnlyIfTsDecls bool   `json:"onlyIfTsDecls,omitempty"` // Include only if --ts-decls is true
+	SkipIfExists  bool   `json:"skipIfExists,omitempty"`  // Skips a file if it already exists
+	Force         bool   `json:"force,omitempty"`         // Overwrites files if they exist
+	Engine        string `json:"engine,omitempty"`        // Template engine (defaults to the file extension mapping, then "go")
+	Mode          string `json:"mode,omitempty"`          // Octal mode of the rendered files, e.g. "0664" (defaults to the file_modes config)
+	Tier          string `json:"tier,omitempty"`          // Tier of the definition, e.g. "full" (see ComponentTiers)
 }
 
 // ActionList represents a collection of Action objects.
 type ActionList []Action
 
-// JSONAction represents a templating action without the `Type` field.
+// JSONAction represents a templating action as declared in the actions files.
+// Type is optional and only needed to select a plugin action (see internal/plugin).
 type JSONAction struct {
-	Item         string `json:"item"`
-	TemplateFile string `json:"templateFile,omitempty"`
-	Path         string `json:"path,omitempty"`
-	Source       string `json:"source,omitempty"`
-	Destination  string `json:"destination,omitempty"`
-	OnlyIfJs     bool   `json:"onlyIfJs,omitempty"`     // Include only if --js is true
-	SkipIfExists bool   `json:"skipIfExists,omitempty"` // Skips a file if it already exists
-	Force        bool   `json:"force,omitempty"`        // Overwrites files if they exist
+	Type          string `json:"type,omitempty"`
+	Item          string `json:"item" jsonschema:"required,enum=file|folder"`
+	TemplateFile  string `json:"templateFile,omitempty"`
+	Path          string `json:"path,omitempty"`
+	Source        string `json:"source,omitempty"`
+	Destination   string `json:"destination,omitempty"`
+	OnlyIfJs      bool   `json:"onlyIfJs,omitempty"`      // Include only if --js is true
+	OnlyIfTsDecls bool   `json:"onlyIfTsDecls,omitempty"` // Include only if --ts-decls is true
+	SkipIfExists  bool   `json:"skipIfExists,omitempty"`  // Skips a file if it already exists
+	Force         bool   `json:"force,omitempty"`         // Overwrites files if they exist
+	Engine        string `json:"engine,omitempty"`        // Template engine, e.g. "handlebars"
+	Mode          string `json:"mode,omitempty"`          // Octal mode of the rendered files, e.g. "0664"
+	Tier          string `json:"tier,omitempty"`          // Tier of the definition, e.g. "full": its JS is generated without --js
 }
 
 // JSONActionList represents a collection of JSONAction objects.
@@ -62,21 +75,31 @@ type JSONActionList []JSONAction
 const (
 	RenderActionID = "render"
 	CopyActionID   = "copy"
+	HeaderActionID = "header"
 )
 
 /* ------------------------------------------------------------------------- */
 /* CONVERSION METHODS                                                        */
 /* ------------------------------------------------------------------------- */
 
-// ToJSONAction converts an Action to JSONAction.
+// ToJSONAction converts an Action to JSONAction. The Source of a file, the built-in
+// template of a copy action, is left out.
 func (a *Action) ToJSONAction() JSONAction {
+	source := a.Source
+	if a.Item == "file" {
+		source = ""
+	}
 	return JSONAction{
-		Item:         a.Item,
-		TemplateFile: a.TemplateFile,
-		Path:         a.Path,
-		Source:       a.Source,
-		Destination:  a.Destination,
-		OnlyIfJs:     a.OnlyIfJs,
+		Item:          a.Item,
+		TemplateFile:  a.TemplateFile,
+		Path:          a.Path,
+		Source:        source,
+		Destination:   a.Destination,
+		OnlyIfJs:      a.OnlyIfJs,
+		OnlyIfTsDecls: a.OnlyIfTsDecls,
+		Engine:        a.Engine,
+		Mode:          a.Mode,
+		Tier:          a.Tier,
 	}
 }
 
@@ -90,15 +113,23 @@ func (al ActionList) ToJSONAction() []JSONAction {
 }
 
 // ToAction converts a JSONAction to an Action with a specified type.
+// The type declared by the JSONAction, if any, takes precedence.
 func (jsa *JSONAction) ToAction(actionType string) Action {
+	if jsa.Type != "" {
+		actionType = jsa.Type
+	}
 	return Action{
-		Type:         actionType,
-		Item:         jsa.Item,
-		TemplateFile: jsa.TemplateFile,
-		Path:         jsa.Path,
-		Source:       jsa.Source,
-		Destination:  jsa.Destination,
-		OnlyIfJs:     jsa.OnlyIfJs,
+		Type:          actionType,
+		Item:          jsa.Item,
+		TemplateFile:  jsa.TemplateFile,
+		Path:          jsa.Path,
+		Source:        jsa.Source,
+		Destination:   jsa.Destination,
+		OnlyIfJs:      jsa.OnlyIfJs,
+		OnlyIfTsDecls: jsa.OnlyIfTsDecls,
+		Engine:        jsa.Engine,
+		Mode:          jsa.Mode,
+		Tier:          jsa.Tier,
 	}
 }
 
@@ -118,14 +149,33 @@ func (jsaList JSONActionList) ToActions(actionType string) []Action {
 // CopyAction handles copying files and folders.
 type CopyAction struct{}
 
+// Execute copies the built-in template of the action into the templates folder. A file
+// is copied from Source when set, e.g. into a component-scoped folder, or else from
+// TemplateFile.
 func (a *CopyAction) Execute(_ context.Context, action Action, data *TemplateData) error {
 	switch action.Item {
 	case "file":
-		destination := filepath.Join(data.TemplatesDir, action.TemplateFile)
-		return utils.CopyFileFromEmbedFunc(action.TemplateFile, destination)
+		source := action.TemplateFile
+		if action.Source != "" {
+			source = action.Source
+		}
+		destination := filepath.Join(data.TemplatesDir, TemplateFileName(action.TemplateFile, data.templateExtensions()))
+		if data.Defaults != nil {
+			return utils.CopyFileFromFS(data.Defaults, source, destination)
+		}
+		return utils.CopyFileFromEmbedFunc(source, destination)
 	case "folder":
 		destinationPath := filepath.Join(data.TemplatesDir, action.Source)
-		return utils.CopyDirFromEmbedFunc(action.Source, destinationPath)
+		copyDir := utils.CopyDirFromEmbedFunc
+		if data.Defaults != nil {
+			copyDir = func(source, destination string) error {
+				return utils.CopyDirFromFS(data.Defaults, source, destination)
+			}
+		}
+		if err := copyDir(action.Source, destinationPath); err != nil {
+			return err
+		}
+		return renameTemplateFiles(destinationPath, data.templateExtensions())
 	default:
 		return apperrors.Wrap("unknown item type: %s", action.Item)
 	}
@@ -134,12 +184,22 @@ func (a *CopyAction) Execute(_ context.Context, action Action, data *TemplateDat
 // RenderAction handles rendering templates into files or folders.
 type RenderAction struct{}
 
-func (a *RenderAction) Execute(_ context.Context, action Action, data *TemplateData) error {
+// Execute renders the action with the UserData of the entity template folder, if any,
+// merged over the configured one (see EntityDataFile).
+func (a *RenderAction) Execute(ctx context.Context, action Action, data *TemplateData) error {
 	switch action.Item {
 	case "file":
-		return renderActionFile(action, data)
+		data, err := entityTemplateData(data, data.TemplatesDir, filepath.Dir(action.TemplateFile))
+		if err != nil {
+			return err
+		}
+		return renderActionFile(ctx, action, data)
 	case "folder":
-		return renderActionFolder(action, data)
+		data, err := entityTemplateData(data, data.TemplatesDir, action.Source)
+		if err != nil {
+			return err
+		}
+		return renderActionFolder(ctx, action, data)
 	default:
 		return apperrors.Wrap("unknown item type: %s", action.Item)
 	}
@@ -149,14 +209,18 @@ func (a *RenderAction) Execute(_ context.Context, action Action, data *TemplateD
 /* ACTION HELPERS                                                            */
 /* ------------------------------------------------------------------------- */
 
-func renderActionFile(action Action, data *TemplateData) error {
+func renderActionFile(ctx context.Context, action Action, data *TemplateData) error {
 	// Skip if this action is JS-specific but the --js flag is not set
-	if action.OnlyIfJs && !data.WithJs {
+	if action.OnlyIfJs && !data.WithJs && action.Tier != TierFull {
+		return nil
+	}
+	// Skip if this action generates TypeScript declarations but they are not enabled
+	if action.OnlyIfTsDecls && !data.WithTsDecls {
 		return nil
 	}
 	// Step 1: Read and render the template file content
 	filePath := filepath.Join(data.TemplatesDir, action.TemplateFile)
-	renderedContent, err := readAndRenderTemplate(filePath, data)
+	renderedContent, err := readAndRenderTemplate(filePath, action.Engine, data)
 	if err != nil {
 		return apperrors.Wrap("failed to process template file", err, action.TemplateFile)
 	}
@@ -167,11 +231,21 @@ func renderActionFile(action Action, data *TemplateData) error {
 		return apperrors.Wrap("failed to render output path", err, action.Path)
 	}
 
-	// Step 3: Handle output file existence and writing
-	return handleOutputFile(outputPath, renderedContent, action, utils.FileOrDirExists, utils.WriteStringToFile)
+	// Step 3: Add the watermark, if configured
+	renderedContent, err = applyWatermark(renderedContent, outputPath, data)
+	if err != nil {
+		return err
+	}
+
+	// Step 4: Handle output file existence and writing
+	writeFunc, err := outputWriteFunc(action, data)
+	if err != nil {
+		return err
+	}
+	return handleOutputFile(outputPath, renderedContent, action, utils.FileOrDirExists, previewWriteFunc(ctx, tracedWriteFunc(ctx, recordedWriteFunc(ctx, stagedWriteFunc(ctx, writeFunc)))))
 }
 
-func renderActionFolder(action Action, data *TemplateData) error {
+func renderActionFolder(ctx context.Context, action Action, data *TemplateData) error {
 	// Step 1: Render base and destination directories
 	base, destination, err := renderBaseAndDestination(action, data)
 	if err != nil {
@@ -179,12 +253,12 @@ func renderActionFolder(action Action, data *TemplateData) error {
 	}
 
 	// Step 2: Ensure the destination directory exists
-	if err := os.MkdirAll(destination, 0755); err != nil {
-		return apperrors.Wrap("failed to create destination directory", err, destination)
+	if err := ensureOutputDir(ctx, destination); err != nil {
+		return err
 	}
 
 	// Step 3: Read files from the base directory
-	files, err := readDir(base)
+	files, err := data.readTemplateDir(base)
 	if err != nil {
 		return apperrors.Wrap("failed to read base directory", err, base)
 	}
@@ -195,7 +269,7 @@ func renderActionFolder(action Action, data *TemplateData) error {
 		if err != nil {
 			return apperrors.Wrap("failed to get file info", err, entry.Name())
 		}
-		if err := processFileInActionFolder(fileInfo, base, destination, action, data); err != nil {
+		if err := processFileInActionFolder(ctx, fileInfo, base, destination, action, data); err != nil {
 			return err
 		}
 	}
@@ -238,19 +312,34 @@ func LoadUserActions(filePath string) ([]JSONAction, error) {
 
 // GenerateActionJSONFile marshals a slice of Actions into a JSON file.
 func GenerateActionJSONFile(filePath string, actions ActionList) error {
-	return utils.WriteJSONToFile(filePath, actions.ToJSONAction())
+	content, err := MarshalActionsJSON(actions.ToJSONAction())
+	if err != nil {
+		return err
+	}
+	return utils.WriteToFile(filePath, content)
+}
+
+// MarshalActionsJSON encodes actions in the canonical actions file format:
+// two-space indentation, fields in declaration order and a trailing newline.
+func MarshalActionsJSON(actions JSONActionList) ([]byte, error) {
+	content, err := json.MarshalIndent(actions, "", "  ")
+	if err != nil {
+		return nil, apperrors.Wrap("failed to marshal actions to JSON", err)
+	}
+	return append(content, '\n'), nil
 }
 
 /* ------------------------------------------------------------------------- */
 /* HELPER FUNCTIONS                                                          */
 /* ------------------------------------------------------------------------- */
 
-// GenerateActionFile generates an action file for the given entity type.
+// GenerateActionFile generates an action file for the given entity type. The template
+// files are named after the templating extensions, as copied by the copy actions.
 func GenerateActionFile(entityType string, data *TemplateData, actions []Action, logger logger.Logger) error {
 	actionFileName := fmt.Sprintf("%s.json", entityType)
 	actionsPath := filepath.Join(data.ActionsDir, actionFileName)
 
-	if err := GenerateActionJSONFile(actionsPath, actions); err != nil {
+	if err := GenerateActionJSONFile(actionsPath, withTemplateExtensions(actions, data.templateExtensions())); err != nil {
 		return apperrors.Wrap("Failed to generate action file for %s", entityType, err)
 	}
 
@@ -356,21 +445,75 @@ func readDir(path string) ([]os.DirEntry, error) {
 	return os.ReadDir(path) // Fallback to normal directory reading
 }
 
-// readAndRenderTemplate reads a file and renders its content.
-func readAndRenderTemplate(filePath string, data *TemplateData) (string, error) {
-	content, err := readFile(filePath)
+// readAndRenderTemplate reads a file and renders its content with the template engine
+// selected by engineName or, when empty, by the file extension.
+func readAndRenderTemplate(filePath, engineName string, data *TemplateData) (string, error) {
+	engine, err := templateengine.Resolve(engineName, filePath)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := data.readTemplate(filePath)
 	if err != nil {
 		return "", apperrors.Wrap("failed to read file", err, filePath)
 	}
 
-	renderedContent, err := utils.RenderTemplate(string(content), data)
+	if err := checkGoModuleUsage(string(content), data); err != nil {
+		return "", apperrors.Wrap("failed to render template", err, filePath)
+	}
+
+	key, cacheable := data.RenderCache.Key(engine.Name(), string(content), data)
+	if cacheable {
+		if cached, ok := data.RenderCache.Get(key); ok {
+			return cached, nil
+		}
+	}
+
+	renderedContent, err := engine.Render(string(content), data)
 	if err != nil {
 		return "", apperrors.Wrap("failed to render template", err, filePath)
 	}
 
+	if cacheable {
+		if err := data.RenderCache.Put(key, renderedContent); err != nil {
+			return "", err
+		}
+	}
 	return renderedContent, nil
 }
 
+// checkGoModuleUsage returns an error when, in a project without a required Go module,
+// the template references .GoModule or .ComponentImportPath while none is known, instead
+// of rendering an empty value.
+func checkGoModuleUsage(content string, data *TemplateData) error {
+	if !data.GoModuleOptional {
+		return nil
+	}
+	refs := templateReferences(content, map[string]bool{"GoModule": true, "ComponentImportPath": true})
+	if data.GoModule == "" && slices.Contains(refs, ".GoModule") {
+		return apperrors.Wrap("the template uses .GoModule but no Go module was found; set app.go_module in the config file")
+	}
+	if data.ComponentImportPath == "" && slices.Contains(refs, ".ComponentImportPath") {
+		return apperrors.Wrap("the template uses .ComponentImportPath but no go.mod file was found")
+	}
+	return nil
+}
+
+// applyWatermark adds the configured watermark on top of the rendered content, using
+// the comment syntax of the output file. File types without a comment syntax are left unchanged.
+func applyWatermark(content, outputPath string, data *TemplateData) (string, error) {
+	w, err := watermark.New(data.Watermark, data.UserData)
+	if err != nil {
+		return "", err
+	}
+
+	comment, err := w.Comment(data.ComponentName, outputPath)
+	if err != nil {
+		return "", err
+	}
+	return watermark.Prepend(content, comment), nil
+}
+
 // renderBaseAndDestination resolves and renders the base and destination directories.
 func renderBaseAndDestination(action Action, data *TemplateData) (string, string, error) {
 	base, err := utils.RenderTemplate(filepath.Join(data.TemplatesDir, action.Source), data)
@@ -387,24 +530,62 @@ func renderBaseAndDestination(action Action, data *TemplateData) (string, string
 }
 
 // processFileInActionFolder processes a single file inside the action folder.
-func processFileInActionFolder(file os.FileInfo, base, destination string, action Action, data *TemplateData) error {
-	// Skip directories and specific system files
-	if file.IsDir() || file.Name() == ".DS_Store" {
+func processFileInActionFolder(ctx context.Context, file os.FileInfo, base, destination string, action Action, data *TemplateData) error {
+	// Skip directories, specific system files and the entity data and props files
+	if file.IsDir() || file.Name() == ".DS_Store" || file.Name() == EntityDataFile || file.Name() == PropsSchemaFile {
 		return nil
 	}
 
 	originalFilename := file.Name()
-	transformedFilename := utils.RemoveTemplatingExtension(originalFilename, config.DefaultTemplateExtensions)
+	transformedFilename := utils.RemoveTemplatingExtension(originalFilename, data.templateExtensions())
 	outputPath := filepath.Join(destination, transformedFilename)
 
 	// Step 1: Read and render file content
-	renderedContent, err := readAndRenderTemplate(filepath.Join(base, originalFilename), data)
+	renderedContent, err := readAndRenderTemplate(filepath.Join(base, originalFilename), action.Engine, data)
 	if err != nil {
 		return err
 	}
 
-	// Step 2: Handle file existence and writing
-	return handleOutputFile(outputPath, renderedContent, action, utils.FileOrDirExists, utils.WriteStringToFile)
+	// Step 2: Add the watermark, if configured
+	renderedContent, err = applyWatermark(renderedContent, outputPath, data)
+	if err != nil {
+		return err
+	}
+
+	// Step 3: Handle file existence and writing
+	writeFunc, err := outputWriteFunc(action, data)
+	if err != nil {
+		return err
+	}
+	return handleOutputFile(outputPath, renderedContent, action, utils.FileOrDirExists, previewWriteFunc(ctx, tracedWriteFunc(ctx, recordedWriteFunc(ctx, stagedWriteFunc(ctx, writeFunc)))))
+}
+
+// WriteActionOutput writes content to outputPath honoring the action SkipIfExists, Force
+// and Mode settings and the file modes of data, like the built-in render action.
+// It is used by plugin actions.
+func WriteActionOutput(ctx context.Context, action Action, data *TemplateData, outputPath, content string) error {
+	writeFunc, err := outputWriteFunc(action, data)
+	if err != nil {
+		return err
+	}
+	return handleOutputFile(outputPath, content, action, utils.FileOrDirExists, previewWriteFunc(ctx, tracedWriteFunc(ctx, recordedWriteFunc(ctx, stagedWriteFunc(ctx, writeFunc)))))
+}
+
+// outputWriteFunc returns the function writing the output files of action with the
+// mode of the action, if set, or the one configured in data for the output file type.
+func outputWriteFunc(action Action, data *TemplateData) (func(string, string) error, error) {
+	mode, err := utils.ParseFileMode(action.Mode)
+	if err != nil {
+		return nil, apperrors.Wrap("invalid action mode", err, action.Mode)
+	}
+	policy, err := data.FileModes.Policy()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(path, content string) error {
+		return policy.WriteFile(path, []byte(content), mode)
+	}, nil
 }
 
 func handleOutputFile(
@@ -10,10 +10,13 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/indaco/tempo/internal/config"
 	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/rendercache"
+	"github.com/indaco/tempo/internal/templateengine"
 	"github.com/indaco/tempo/internal/testutils"
 	"github.com/indaco/tempo/internal/utils"
 )
@@ -130,11 +133,11 @@ func TestGenerateActionFile(t *testing.T) {
 func TestActionConversion(t *testing.T) {
 	t.Run("ToJSONAction", func(t *testing.T) {
 		actions := ActionList{
-			{Type: "render", Item: "file", TemplateFile: "template1", Path: "path1"},
+			{Type: "render", Item: "file", TemplateFile: "template1", Path: "path1", Engine: "handlebars"},
 		}
 
 		expectedJSONActions := []JSONAction{
-			{Item: "file", TemplateFile: "template1", Path: "path1"},
+			{Item: "file", TemplateFile: "template1", Path: "path1", Engine: "handlebars"},
 		}
 
 		if !reflect.DeepEqual(actions.ToJSONAction(), expectedJSONActions) {
@@ -155,6 +158,16 @@ func TestActionConversion(t *testing.T) {
 			t.Errorf("JSONActionList.ToActions() = %v; want %v", jsonActions.ToActions("render"), expectedActions)
 		}
 	})
+
+	t.Run("ToActions with declared type", func(t *testing.T) {
+		jsonActions := JSONActionList{
+			{Type: "license", Item: "file", Path: "path1"},
+		}
+
+		if got := jsonActions.ToActions("render"); got[0].Type != "license" {
+			t.Errorf("Expected declared type %q to take precedence, got %q", "license", got[0].Type)
+		}
+	})
 }
 
 func TestLoadUserActions(t *testing.T) {
@@ -269,7 +282,7 @@ func TestRenderActionFile(t *testing.T) {
 	}
 
 	// Render the file
-	err = renderActionFile(action, data)
+	err = renderActionFile(context.Background(), action, data)
 	if err != nil {
 		t.Fatalf("Unexpected error rendering action file: %v\nTemplate Path: %s\nFile Content: %s", err, templateFile, content)
 	}
@@ -286,6 +299,212 @@ func TestRenderActionFile(t *testing.T) {
 	}
 }
 
+func TestRenderActionFile_GoModuleOptional(t *testing.T) {
+	tempDir := t.TempDir()
+	templateFile := filepath.Join(tempDir, "component.templ.gotxt")
+	if err := os.WriteFile(templateFile, []byte("import \"{{ .GoModule }}/components\""), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+	importFile := filepath.Join(tempDir, "page.templ.gotxt")
+	if err := os.WriteFile(importFile, []byte("import \"{{ .ComponentImportPath }}/css\""), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+	plainFile := filepath.Join(tempDir, "base.css.gotxt")
+	if err := os.WriteFile(plainFile, []byte(".{{ .ComponentName }} {}"), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		template    string
+		data        *TemplateData
+		expectError bool
+	}{
+		{name: "Go module required", template: templateFile, data: &TemplateData{}},
+		{name: "Go module set", template: templateFile, data: &TemplateData{GoModule: "example.com/app", GoModuleOptional: true}},
+		{name: "Go module unused", template: plainFile, data: &TemplateData{ComponentName: "button", GoModuleOptional: true}},
+		{name: "Go module used but unknown", template: templateFile, data: &TemplateData{GoModuleOptional: true}, expectError: true},
+		{name: "Import path set", template: importFile, data: &TemplateData{ComponentImportPath: "example.com/app/components/button", GoModuleOptional: true}},
+		{name: "Import path used but unknown", template: importFile, data: &TemplateData{GoModule: "example.com/app", GoModuleOptional: true}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := Action{TemplateFile: tt.template, Path: filepath.Join(t.TempDir(), "output")}
+			err := renderActionFile(context.Background(), action, tt.data)
+			if tt.expectError {
+				for e := err; e != nil; e = errors.Unwrap(e) {
+					if strings.Contains(e.Error(), "was found") {
+						return
+					}
+				}
+				t.Errorf("Expected a Go module error, got %v", err)
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRenderActionFile_Watermark(t *testing.T) {
+	tempDir := t.TempDir()
+	templateFile := filepath.Join(tempDir, "button.templ.gotxt")
+	if err := os.WriteFile(templateFile, []byte("package {{ .ComponentName }}"), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		outputFile  string
+		watermark   string
+		expected    string
+		expectError bool
+	}{
+		{name: "Templ file", outputFile: "button.templ", watermark: "Generated for {{ .UserData.author }}", expected: "// Generated for Jane\npackage button"},
+		{name: "CSS file", outputFile: "button.css", watermark: "{{ .ComponentName }}", expected: "/* button */\npackage button"},
+		{name: "Unsupported file type", outputFile: "button.txt", watermark: "{{ .ComponentName }}", expected: "package button"},
+		{name: "Disabled", outputFile: "disabled.templ", expected: "package button"},
+		{name: "Invalid template", outputFile: "invalid.templ", watermark: "{{ .UserData.missing }}", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFile := filepath.Join(tempDir, tt.outputFile)
+			action := Action{TemplateFile: templateFile, Path: outputFile}
+			data := &TemplateData{
+				ComponentName: "button",
+				Watermark:     tt.watermark,
+				UserData:      map[string]any{"author": "Jane"},
+			}
+
+			err := renderActionFile(context.Background(), action, data)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error")
+				}
+				if fileExists(outputFile) {
+					t.Errorf("Expected no file to be written on watermark errors")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			rendered, err := os.ReadFile(outputFile)
+			if err != nil {
+				t.Fatalf("Failed to read rendered file: %v", err)
+			}
+			if string(rendered) != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, string(rendered))
+			}
+		})
+	}
+}
+
+func TestRenderActionFile_FileModes(t *testing.T) {
+	tempDir := t.TempDir()
+	templateFile := filepath.Join(tempDir, "button.templ.gotxt")
+	if err := os.WriteFile(templateFile, []byte("package {{ .ComponentName }}"), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+
+	// umaskedMode returns the mode of a file created with mode, narrowed by the umask.
+	umaskedMode := func(mode os.FileMode) os.FileMode {
+		probe := filepath.Join(t.TempDir(), "probe")
+		if err := os.WriteFile(probe, nil, mode); err != nil {
+			t.Fatalf("Failed to write probe file: %v", err)
+		}
+		info, err := os.Stat(probe)
+		if err != nil {
+			t.Fatalf("Failed to stat probe file: %v", err)
+		}
+		return info.Mode().Perm()
+	}
+
+	fileModes := config.FileModes{Default: "0644", ByExtension: map[string]string{".templ": "0640"}}
+	tests := []struct {
+		name        string
+		outputFile  string
+		mode        string
+		expected    os.FileMode
+		expectError bool
+	}{
+		{name: "Mode per extension", outputFile: "button.templ", expected: 0o640},
+		{name: "Default mode", outputFile: "button.go", expected: 0o644},
+		{name: "Action mode", outputFile: "action.templ", mode: "0600", expected: 0o600},
+		{name: "Invalid action mode", outputFile: "invalid.templ", mode: "rw", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFile := filepath.Join(tempDir, tt.outputFile)
+			action := Action{TemplateFile: templateFile, Path: outputFile, Mode: tt.mode}
+			data := &TemplateData{ComponentName: "button", FileModes: fileModes}
+
+			err := renderActionFile(context.Background(), action, data)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			info, err := os.Stat(outputFile)
+			if err != nil {
+				t.Fatalf("Failed to stat rendered file: %v", err)
+			}
+			if want := umaskedMode(tt.expected); info.Mode().Perm() != want {
+				t.Errorf("Expected mode %o, got %o", want, info.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestRenderActionFile_RenderCache(t *testing.T) {
+	tempDir := t.TempDir()
+	templateFile := filepath.Join(tempDir, "button.templ.gotxt")
+	if err := os.WriteFile(templateFile, []byte("package {{ .ComponentName }}"), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+
+	renderCache := rendercache.Open(filepath.Join(tempDir, ".tempo-files"), true, nil)
+	data := &TemplateData{ComponentName: "button", RenderCache: renderCache}
+	outputFile := filepath.Join(tempDir, "button.templ")
+	action := Action{TemplateFile: templateFile, Path: outputFile}
+
+	if err := renderActionFile(context.Background(), action, data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	entries, err := os.ReadDir(renderCache.Dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected one render cache entry, got %v (err: %v)", entries, err)
+	}
+
+	// The second render reads the cached entry
+	if err := os.WriteFile(filepath.Join(renderCache.Dir, entries[0].Name()), []byte("package cached"), 0644); err != nil {
+		t.Fatalf("Failed to update the render cache entry: %v", err)
+	}
+	if err := os.Remove(outputFile); err != nil {
+		t.Fatalf("Failed to remove rendered file: %v", err)
+	}
+	if err := renderActionFile(context.Background(), action, data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read rendered file: %v", err)
+	}
+	if string(content) != "package cached" {
+		t.Errorf("Expected the cached render, got %q", content)
+	}
+}
+
 func TestRenderActionFolder(t *testing.T) {
 	tempDir := t.TempDir()
 	baseDir := filepath.Join(tempDir, "templates")
@@ -324,7 +543,7 @@ func TestRenderActionFolder(t *testing.T) {
 	}
 
 	// Run renderActionFolder
-	err = renderActionFolder(action, data)
+	err = renderActionFolder(context.Background(), action, data)
 	if err != nil {
 		t.Fatalf("Unexpected error rendering folder: %v", err)
 	}
@@ -348,6 +567,79 @@ func TestRenderActionFolder(t *testing.T) {
 	}
 }
 
+func TestRenderActionFile_TemplateEngines(t *testing.T) {
+	tests := []struct {
+		name         string
+		templateFile string
+		content      string
+		engine       string
+		expected     string
+		expectError  bool
+	}{
+		{name: "Default Go engine", templateFile: "hello.gotxt", content: "Hello {{ .ComponentName }}", expected: "Hello World"},
+		{name: "Handlebars by extension", templateFile: "hello.hbs", content: "Hello {{ComponentName}}", expected: "Hello World"},
+		{name: "Handlebars by action engine", templateFile: "hello.gotxt", content: "Hello {{ComponentName}}", engine: "handlebars", expected: "Hello World"},
+		{name: "Unknown engine", templateFile: "hello.gotxt", content: "Hello", engine: "plush", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tempDir, tt.templateFile), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create template file: %v", err)
+			}
+
+			outputFile := filepath.Join(tempDir, "output.txt")
+			action := Action{TemplateFile: tt.templateFile, Path: outputFile, Engine: tt.engine}
+			data := &TemplateData{TemplatesDir: tempDir, ComponentName: "World"}
+
+			err := renderActionFile(context.Background(), action, data)
+			if tt.expectError {
+				if !errors.Is(err, templateengine.ErrUnknownEngine) {
+					t.Fatalf("Expected unknown template engine error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error rendering action file: %v", err)
+			}
+
+			renderedData, err := os.ReadFile(outputFile)
+			if err != nil {
+				t.Fatalf("Failed to read rendered file: %v", err)
+			}
+			if string(renderedData) != tt.expected {
+				t.Errorf("Unexpected rendered content: got %q, expected %q", string(renderedData), tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderActionFolder_HandlebarsExtension(t *testing.T) {
+	tempDir := t.TempDir()
+	baseDir := filepath.Join(tempDir, "templates")
+	destDir := filepath.Join(tempDir, "output")
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		t.Fatalf("Failed to create base dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(baseDir, "button.css.hbs"), []byte(".{{ComponentName}} {}"), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+
+	action := Action{Item: "folder", Source: baseDir, Destination: destDir}
+	if err := renderActionFolder(context.Background(), action, &TemplateData{ComponentName: "button"}); err != nil {
+		t.Fatalf("Unexpected error rendering folder: %v", err)
+	}
+
+	renderedData, err := os.ReadFile(filepath.Join(destDir, "button.css"))
+	if err != nil {
+		t.Fatalf("Failed to read rendered file: %v", err)
+	}
+	if string(renderedData) != ".button {}" {
+		t.Errorf("Unexpected rendered content: got %q", string(renderedData))
+	}
+}
+
 // TestHandleOutputFile tests error handling in handleOutputFile
 func TestHandleOutputFile(t *testing.T) {
 	tempDir := t.TempDir()
@@ -561,7 +853,7 @@ func TestRenderAction_Execute_File(t *testing.T) {
 	}
 	data := createTestTemplateData(templatesDir)
 	// Execute file rendering.
-	err := renderActionFile(action, data)
+	err := renderActionFile(context.Background(), action, data)
 	if err != nil {
 		t.Fatalf("renderActionFile returned error: %v", err)
 	}
@@ -603,7 +895,7 @@ func TestRenderAction_Execute_Folder(t *testing.T) {
 		TemplatesDir:  tempDir,
 		ComponentName: "World",
 	}
-	err := renderActionFolder(action, data)
+	err := renderActionFolder(context.Background(), action, data)
 	if err != nil {
 		t.Fatalf("renderActionFolder returned error: %v", err)
 	}
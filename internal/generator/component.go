@@ -1,7 +1,38 @@
 package generator
 
+import (
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+// Tiers of the component definition, selected by "tempo component define".
+const (
+	TierMinimal  = "minimal"  // The .templ file and the base CSS only
+	TierStandard = "standard" // The base CSS with themes, and the JS with --js
+	TierFull     = "full"     // The standard files plus the JS, the docs and a test, always generated
+)
+
+// ComponentTiers lists the tiers of the component definition.
+var ComponentTiers = []string{TierMinimal, TierStandard, TierFull}
+
 // BuildComponentActions generates the list of actions required to scaffold a new component.
-func BuildComponentActions(actionType string, force, withJs bool) ([]Action, error) {
+// With withTsDecls, a TypeScript declaration stub is generated next to the JS asset.
+func BuildComponentActions(actionType string, force, withJs, withTsDecls bool) ([]Action, error) {
+	return BuildComponentTierActions(actionType, TierStandard, force, withJs, withTsDecls)
+}
+
+// BuildComponentTierActions generates the actions of the given tier of the component
+// definition (see ComponentTiers). The actions of the minimal and full tiers are marked
+// with it, recording the tier in the actions file: the JS of the full tier is then
+// generated whether or not --js is set.
+func BuildComponentTierActions(actionType, tier string, force, withJs, withTsDecls bool) ([]Action, error) {
+	switch tier {
+	case TierMinimal, TierStandard, TierFull:
+	default:
+		return nil, apperrors.Wrap("unknown component tier '%s'; valid tiers are: %s", tier, strings.Join(ComponentTiers, ", "))
+	}
+
 	actions := []Action{
 		// [Templ] - Main component
 		{
@@ -16,7 +47,7 @@ func BuildComponentActions(actionType string, force, withJs bool) ([]Action, err
 			Type:         actionType,
 			Item:         "file",
 			TemplateFile: "component/assets/css/base.css.gotxt",
-			Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/css/base.css",
+			Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/{{ .StylesDir }}/base.css",
 		},
 
 		// [CSS] - Templ base
@@ -24,24 +55,28 @@ func BuildComponentActions(actionType string, force, withJs bool) ([]Action, err
 			Type:         actionType,
 			Item:         "file",
 			TemplateFile: "component/templ/css/base-css.templ.gotxt",
-			Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/css/base.templ",
+			Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/{{ .StylesDir }}/base.templ",
 		},
+	}
 
-		// [CSS] - Asset themes folder
-		{
-			Type:        actionType,
-			Item:        "folder",
-			Source:      "component/assets/css/themes",
-			Destination: "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/css/themes",
-		},
+	if tier != TierMinimal {
+		actions = append(actions,
+			// [CSS] - Asset themes folder
+			Action{
+				Type:        actionType,
+				Item:        "folder",
+				Source:      "component/assets/css/themes",
+				Destination: "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/{{ .StylesDir }}/themes",
+			},
 
-		// [CSS] - Templ themes folder
-		{
-			Type:        actionType,
-			Item:        "folder",
-			Source:      "component/templ/css/themes",
-			Destination: "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/css/themes",
-		},
+			// [CSS] - Templ themes folder
+			Action{
+				Type:        actionType,
+				Item:        "folder",
+				Source:      "component/templ/css/themes",
+				Destination: "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/{{ .StylesDir }}/themes",
+			},
+		)
 	}
 
 	// Apply force if requested
@@ -51,15 +86,25 @@ func BuildComponentActions(actionType string, force, withJs bool) ([]Action, err
 		}
 	}
 
+	if tier != TierStandard {
+		if tier == TierFull {
+			actions = append(actions, fullTierActions(actionType, withTsDecls)...)
+		}
+		for i := range actions {
+			actions[i].Tier = tier
+		}
+		return actions, nil
+	}
+
 	// [JS] - Optional files
-	if withJs {
+	if withJs && tier == TierStandard {
 		actions = append(actions,
 			// [JS] - Asset
 			Action{
 				Type:         actionType,
 				Item:         "file",
 				TemplateFile: "component/assets/js/script.js.gotxt",
-				Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/js/script.js",
+				Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/{{ .ScriptsDir }}/script.js",
 				OnlyIfJs:     withJs,
 			},
 			// [JS] - Templ
@@ -67,11 +112,72 @@ func BuildComponentActions(actionType string, force, withJs bool) ([]Action, err
 				Type:         actionType,
 				Item:         "file",
 				TemplateFile: "component/templ/js/script.templ.gotxt",
-				Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/js/script.templ",
+				Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/{{ .ScriptsDir }}/script.templ",
 				OnlyIfJs:     withJs,
 			},
 		)
+
+		// [JS] - TypeScript declarations
+		if withTsDecls {
+			actions = append(actions, Action{
+				Type:          actionType,
+				Item:          "file",
+				TemplateFile:  "component/assets/js/script.d.ts.gotxt",
+				Path:          "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/{{ .ScriptsDir }}/script.d.ts",
+				OnlyIfJs:      withJs,
+				OnlyIfTsDecls: withTsDecls,
+			})
+		}
 	}
 
 	return actions, nil
 }
+
+// fullTierActions returns the actions added by the full tier: the JS asset and its templ
+// file, the TypeScript declarations with withTsDecls, the docs and a render test.
+func fullTierActions(actionType string, withTsDecls bool) []Action {
+	actions := []Action{
+		// [JS] - Asset
+		{
+			Type:         actionType,
+			Item:         "file",
+			TemplateFile: "component/assets/js/script.js.gotxt",
+			Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/{{ .ScriptsDir }}/script.js",
+		},
+		// [JS] - Templ
+		{
+			Type:         actionType,
+			Item:         "file",
+			TemplateFile: "component/templ/js/script.templ.gotxt",
+			Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/{{ .ScriptsDir }}/script.templ",
+		},
+	}
+
+	// [JS] - TypeScript declarations
+	if withTsDecls {
+		actions = append(actions, Action{
+			Type:          actionType,
+			Item:          "file",
+			TemplateFile:  "component/assets/js/script.d.ts.gotxt",
+			Path:          "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/{{ .ScriptsDir }}/script.d.ts",
+			OnlyIfTsDecls: withTsDecls,
+		})
+	}
+
+	return append(actions,
+		// [Docs] - Usage of the component
+		Action{
+			Type:         actionType,
+			Item:         "file",
+			TemplateFile: "component/docs/README.md.gotxt",
+			Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/README.md",
+		},
+		// [Test] - Render test of the component
+		Action{
+			Type:         actionType,
+			Item:         "file",
+			TemplateFile: "component/templ/component_test.go.gotxt",
+			Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/{{ .ComponentName | goPackageName }}_test.go",
+		},
+	)
+}
@@ -2,6 +2,8 @@ package generator
 
 import (
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -10,7 +12,7 @@ func TestBuildComponentActions(t *testing.T) {
 	withJs := true
 
 	t.Run("Force", func(t *testing.T) {
-		actions, err := BuildComponentActions(actionType, true, false)
+		actions, err := BuildComponentActions(actionType, true, false, false)
 		if err != nil {
 			t.Fatalf("BuildComponentActions() returned an error: %v", err)
 		}
@@ -38,7 +40,7 @@ func TestBuildComponentActions(t *testing.T) {
 	})
 
 	t.Run("WithoutJS", func(t *testing.T) {
-		actions, err := BuildComponentActions(actionType, false, false)
+		actions, err := BuildComponentActions(actionType, false, false, false)
 		if err != nil {
 			t.Fatalf("BuildComponentActions() returned an error: %v", err)
 		}
@@ -59,7 +61,7 @@ func TestBuildComponentActions(t *testing.T) {
 	})
 
 	t.Run("WithJS", func(t *testing.T) {
-		actions, err := BuildComponentActions(actionType, false, withJs)
+		actions, err := BuildComponentActions(actionType, false, withJs, false)
 		if err != nil {
 			t.Fatalf("BuildComponentActions() returned an error: %v", err)
 		}
@@ -77,7 +79,7 @@ func TestBuildComponentActions(t *testing.T) {
 			Type:         actionType,
 			Item:         "file",
 			TemplateFile: "component/templ/js/script.templ.gotxt",
-			Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/js/script.templ",
+			Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/{{ .ScriptsDir }}/script.templ",
 			OnlyIfJs:     true,
 		}
 
@@ -86,8 +88,46 @@ func TestBuildComponentActions(t *testing.T) {
 		}
 	})
 
+	t.Run("WithTsDecls", func(t *testing.T) {
+		actions, err := BuildComponentActions(actionType, false, withJs, true)
+		if err != nil {
+			t.Fatalf("BuildComponentActions() returned an error: %v", err)
+		}
+
+		if len(actions) != 8 {
+			t.Errorf("BuildComponentActions() = %d actions; want 8 actions", len(actions))
+		}
+
+		lastAction := actions[len(actions)-1]
+		expectedLastAction := Action{
+			Type:          actionType,
+			Item:          "file",
+			TemplateFile:  "component/assets/js/script.d.ts.gotxt",
+			Path:          "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/{{ .ScriptsDir }}/script.d.ts",
+			OnlyIfJs:      true,
+			OnlyIfTsDecls: true,
+		}
+
+		if !reflect.DeepEqual(lastAction, expectedLastAction) {
+			t.Errorf("Last action = %v; want %v", lastAction, expectedLastAction)
+		}
+	})
+
+	t.Run("WithTsDeclsWithoutJS", func(t *testing.T) {
+		actions, err := BuildComponentActions(actionType, false, false, true)
+		if err != nil {
+			t.Fatalf("BuildComponentActions() returned an error: %v", err)
+		}
+
+		for _, action := range actions {
+			if action.OnlyIfTsDecls {
+				t.Errorf("Unexpected TypeScript declarations action found: %v", action)
+			}
+		}
+	})
+
 	t.Run("ActionType", func(t *testing.T) {
-		actions, err := BuildComponentActions("copy", false, false)
+		actions, err := BuildComponentActions("copy", false, false, false)
 		if err != nil {
 			t.Fatalf("BuildComponentActions() returned an error: %v", err)
 		}
@@ -100,3 +140,94 @@ func TestBuildComponentActions(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildComponentTierActions(t *testing.T) {
+	templateFiles := func(actions []Action) []string {
+		var files []string
+		for _, action := range actions {
+			files = append(files, action.TemplateFile+action.Source)
+		}
+		return files
+	}
+
+	t.Run("Minimal", func(t *testing.T) {
+		actions, err := BuildComponentTierActions(CopyActionID, TierMinimal, false, true, true)
+		if err != nil {
+			t.Fatalf("BuildComponentTierActions() returned an error: %v", err)
+		}
+
+		want := []string{
+			"component/templ/component.templ.gotxt",
+			"component/assets/css/base.css.gotxt",
+			"component/templ/css/base-css.templ.gotxt",
+		}
+		if got := templateFiles(actions); !reflect.DeepEqual(got, want) {
+			t.Errorf("BuildComponentTierActions() = %v; want %v", got, want)
+		}
+		for _, action := range actions {
+			if action.Tier != TierMinimal {
+				t.Errorf("Action.Tier = %q; want %q", action.Tier, TierMinimal)
+			}
+		}
+	})
+
+	t.Run("Standard", func(t *testing.T) {
+		actions, err := BuildComponentTierActions(CopyActionID, TierStandard, false, true, false)
+		if err != nil {
+			t.Fatalf("BuildComponentTierActions() returned an error: %v", err)
+		}
+		standard, _ := BuildComponentActions(CopyActionID, false, true, false)
+		if !reflect.DeepEqual(actions, standard) {
+			t.Errorf("BuildComponentTierActions() = %v; want %v", actions, standard)
+		}
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		actions, err := BuildComponentTierActions(CopyActionID, TierFull, false, false, false)
+		if err != nil {
+			t.Fatalf("BuildComponentTierActions() returned an error: %v", err)
+		}
+
+		got := templateFiles(actions)
+		for _, want := range []string{
+			"component/assets/js/script.js.gotxt",
+			"component/templ/js/script.templ.gotxt",
+			"component/docs/README.md.gotxt",
+			"component/templ/component_test.go.gotxt",
+		} {
+			if !slices.Contains(got, want) {
+				t.Errorf("Expected the full tier to include %s, got %v", want, got)
+			}
+		}
+		if slices.Contains(got, "component/assets/js/script.d.ts.gotxt") {
+			t.Errorf("Unexpected TypeScript declarations without withTsDecls: %v", got)
+		}
+		for _, action := range actions {
+			if action.Tier != TierFull || action.OnlyIfJs {
+				t.Errorf("Expected a full tier action run without --js, got %v", action)
+			}
+		}
+	})
+
+	t.Run("Unknown tier", func(t *testing.T) {
+		if _, err := BuildComponentTierActions(CopyActionID, "huge", false, false, false); err == nil {
+			t.Error("Expected an error for an unknown tier")
+		}
+	})
+}
+
+func TestRunActions_FullTierWithoutJs(t *testing.T) {
+	// An unknown action type fails once the action reaches its handler
+	action := Action{Type: "unknown", Item: "file", TemplateFile: "component/assets/js/script.js.gotxt"}
+	data := &TemplateData{}
+
+	if err := runActions(t.Context(), nil, []Action{action}, data); err != nil {
+		t.Errorf("Expected the JS action to be skipped without --js, got %v", err)
+	}
+
+	action.Tier = TierFull
+	err := runActions(t.Context(), nil, []Action{action}, data)
+	if err == nil || !strings.Contains(err.Error(), "unknown action type") {
+		t.Errorf("Expected the full tier JS action to be run without --js, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/logger"
+)
+
+// Names of the entities and folder scaffolded by the demo project.
+const (
+	DemoComponentName = "button"
+	DemoVariantName   = "outline"
+	DemoDir           = "cmd/tempo-demo"
+)
+
+// BuildDemoActions generates the list of actions required to scaffold the main package
+// of the demo project, serving a page that uses the demo component.
+func BuildDemoActions(actionType string, force bool) ([]Action, error) {
+	actions := []Action{
+		{
+			Type:         actionType,
+			Item:         "file",
+			TemplateFile: "demo/main.go.gotxt",
+			Path:         DemoDir + "/main.go",
+		},
+		{
+			Type:         actionType,
+			Item:         "file",
+			TemplateFile: "demo/page.templ.gotxt",
+			Path:         DemoDir + "/page.templ",
+		},
+	}
+
+	if force {
+		for i := range actions {
+			actions[i].Force = true
+		}
+	}
+
+	return actions, nil
+}
+
+// ScaffoldDemo creates a runnable sample project: it defines the component and variant
+// templates with their actions files, then generates the demo component, its variant
+// and the main package serving them. Assets are not synced into the templ files.
+func ScaffoldDemo(ctx context.Context, logger logger.Logger, data *TemplateData) error {
+	componentActions, err := BuildComponentActions(CopyActionID, data.Force, data.WithJs, data.WithTsDecls)
+	if err != nil {
+		return err
+	}
+	variantActions, err := BuildVariantActions(CopyActionID, data.Force)
+	if err != nil {
+		return err
+	}
+	demoActions, err := BuildDemoActions(CopyActionID, data.Force)
+	if err != nil {
+		return err
+	}
+
+	// Step 1: Define the templates and their actions files
+	for _, actions := range [][]Action{componentActions, variantActions, demoActions} {
+		if err := ProcessActions(ctx, logger, actions, data); err != nil {
+			return apperrors.Wrap("failed to define the demo templates", err)
+		}
+	}
+	if err := GenerateActionFile("component", data, componentActions, logger); err != nil {
+		return err
+	}
+	if err := GenerateActionFile("variant", data, variantActions, logger); err != nil {
+		return err
+	}
+
+	// Step 2: Generate the component, its variant and the main package
+	demoData := *data
+	demoData.ComponentName = DemoComponentName
+	demoData.VariantName = DemoVariantName
+
+	for _, actions := range [][]Action{componentActions, variantActions, demoActions} {
+		if err := ProcessActions(ctx, logger, toRenderActions(withTemplateExtensions(actions, data.templateExtensions())), &demoData); err != nil {
+			return apperrors.Wrap("failed to generate the demo project", err)
+		}
+	}
+
+	logger.Success("Demo project has been created").
+		WithAttrs(
+			"component", DemoComponentName,
+			"variant", DemoVariantName,
+			"main_package", DemoDir,
+		)
+	return nil
+}
+
+// toRenderActions returns a copy of actions with the render type.
+func toRenderActions(actions []Action) []Action {
+	rendered := make([]Action, len(actions))
+	for i, action := range actions {
+		action.Type = RenderActionID
+		rendered[i] = action
+	}
+	return rendered
+}
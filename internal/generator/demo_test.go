@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/indaco/tempo/internal/utils"
+)
+
+func TestBuildDemoActions(t *testing.T) {
+	actions, err := BuildDemoActions(CopyActionID, true)
+	if err != nil {
+		t.Fatalf("BuildDemoActions() returned an error: %v", err)
+	}
+
+	if len(actions) != 2 {
+		t.Fatalf("BuildDemoActions() = %d actions; want 2", len(actions))
+	}
+	for _, action := range actions {
+		if !action.Force || action.Type != CopyActionID {
+			t.Errorf("Expected a forced copy action, got %+v", action)
+		}
+		if !utils.IsEmbedded(action.TemplateFile) {
+			t.Errorf("Expected %s to be embedded", action.TemplateFile)
+		}
+	}
+}
+
+func TestToRenderActions(t *testing.T) {
+	actions := []Action{{Type: CopyActionID, Item: "file"}}
+
+	rendered := toRenderActions(actions)
+	if rendered[0].Type != RenderActionID {
+		t.Errorf("Expected a render action, got %q", rendered[0].Type)
+	}
+	if actions[0].Type != CopyActionID {
+		t.Errorf("Expected the original actions to be left unchanged")
+	}
+}
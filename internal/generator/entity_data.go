@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"gopkg.in/yaml.v3"
+)
+
+// EntityDataFile is the name of the user data file of an entity template folder
+// (e.g. templates/component/.tempo-data.yaml). Its values are merged into
+// TemplateData.UserData only when rendering the templates of that folder.
+const EntityDataFile = ".tempo-data.yaml"
+
+// LoadEntityUserData reads the user data file of the template folder dir.
+// A missing file returns nil values.
+func LoadEntityUserData(dir string) (map[string]any, error) {
+	path := filepath.Join(dir, EntityDataFile)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, apperrors.Wrap("failed to read user data file", err, path)
+	}
+
+	var userData map[string]any
+	if err := yaml.Unmarshal(content, &userData); err != nil {
+		return nil, apperrors.Wrap("failed to parse user data file", err, path)
+	}
+	return userData, nil
+}
+
+// MergeUserData returns a new map with the values of override deep-merged over base.
+// Nested maps are merged key by key; any other value of override replaces the base one.
+func MergeUserData(base, override map[string]any) map[string]any {
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = make(map[string]any, len(override))
+	}
+	for key, value := range override {
+		nested, ok := value.(map[string]any)
+		baseNested, baseOk := merged[key].(map[string]any)
+		if ok && baseOk {
+			merged[key] = MergeUserData(baseNested, nested)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// EntityTemplateData returns data as the templates of the entity template folder
+// entityDir, e.g. "component", receive it (see entityTemplateData).
+func EntityTemplateData(data *TemplateData, entityDir string) (*TemplateData, error) {
+	return entityTemplateData(data, data.TemplatesDir, entityDir)
+}
+
+// entityTemplateData returns data with the user data file of the entity template folder
+// of templateDir merged into its UserData, below its CLIUserData, and the props of its props schema file, if
+// any, set as its Props. The entity folder is the first folder of templateDir, relative
+// to templatesDir. data is returned as is when the template is not inside a folder or
+// the folder has neither a user data file nor a props schema file.
+func entityTemplateData(data *TemplateData, templatesDir, templateDir string) (*TemplateData, error) {
+	entityDir, _, _ := strings.Cut(filepath.ToSlash(filepath.Clean(templateDir)), "/")
+	if entityDir == "" || entityDir == "." || entityDir == ".." {
+		return data, nil
+	}
+
+	userData, err := LoadEntityUserData(filepath.Join(templatesDir, entityDir))
+	if err != nil {
+		return data, err
+	}
+	props, err := LoadEntityProps(filepath.Join(templatesDir, entityDir))
+	if err != nil {
+		return data, err
+	}
+	if userData == nil && props == nil {
+		return data, nil
+	}
+
+	merged := *data
+	if userData != nil {
+		merged.UserData = MergeUserData(MergeUserData(data.UserData, userData), data.CLIUserData)
+	}
+	if props != nil {
+		merged.Props = props
+	}
+	return &merged, nil
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMergeUserData(t *testing.T) {
+	base := map[string]any{
+		"author": "Jane Doe",
+		"tokens": map[string]any{"radius": "4px", "color": "blue"},
+	}
+	override := map[string]any{
+		"tokens": map[string]any{"color": "red"},
+		"size":   "md",
+	}
+
+	merged := MergeUserData(base, override)
+
+	expected := map[string]any{
+		"author": "Jane Doe",
+		"tokens": map[string]any{"radius": "4px", "color": "red"},
+		"size":   "md",
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Expected %v, got %v", expected, merged)
+	}
+	if base["tokens"].(map[string]any)["color"] != "blue" {
+		t.Errorf("Expected the base user data to be left unchanged, got %v", base)
+	}
+}
+
+func TestLoadEntityUserData(t *testing.T) {
+	dir := t.TempDir()
+
+	userData, err := LoadEntityUserData(dir)
+	if err != nil || userData != nil {
+		t.Errorf("Expected no user data and no error without a file, got %v, %v", userData, err)
+	}
+
+	writeFixture(t, dir, EntityDataFile, "tokens:\n  radius: 4px\n")
+	userData, err = LoadEntityUserData(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if userData["tokens"].(map[string]any)["radius"] != "4px" {
+		t.Errorf("Unexpected user data: %v", userData)
+	}
+
+	writeFixture(t, dir, EntityDataFile, "tokens: [")
+	if _, err := LoadEntityUserData(dir); err == nil {
+		t.Error("Expected an error for an invalid user data file")
+	}
+}
+
+func TestRenderAction_Execute_EntityUserData(t *testing.T) {
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	outputDir := filepath.Join(tempDir, "out")
+
+	writeFixture(t, templatesDir, "button/"+EntityDataFile, "tokens:\n  radius: 4px\n")
+	writeFixture(t, templatesDir, "button/css/name.css.gotxt", "{{ .UserData.author }} {{ .UserData.tokens.radius }}")
+	writeFixture(t, templatesDir, "input/name.css.gotxt", "{{ .UserData.author }} {{ index .UserData \"tokens\" }}")
+
+	data := &TemplateData{TemplatesDir: templatesDir, UserData: map[string]any{"author": "Jane"}}
+	handler := &RenderAction{}
+
+	buttonFolder := Action{Item: "folder", Source: "button/css", Destination: filepath.Join(outputDir, "button")}
+	if err := handler.Execute(context.Background(), buttonFolder, data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	inputFile := Action{Item: "file", TemplateFile: "input/name.css.gotxt", Path: filepath.Join(outputDir, "input.css")}
+	if err := handler.Execute(context.Background(), inputFile, data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := map[string]string{
+		filepath.Join(outputDir, "button", "name.css"): "Jane 4px",
+		filepath.Join(outputDir, "input.css"):          "Jane <no value>",
+	}
+	for path, expected := range tests {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		if string(content) != expected {
+			t.Errorf("Expected %q in %s, got %q", expected, path, content)
+		}
+	}
+
+	if _, ok := data.UserData["tokens"]; ok {
+		t.Errorf("Expected the entity user data not to leak into the shared data, got %v", data.UserData)
+	}
+}
+
+func TestRenderActionFolder_SkipsEntityDataFile(t *testing.T) {
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	writeFixture(t, templatesDir, "button/"+EntityDataFile, "size: md\n")
+	writeFixture(t, templatesDir, "button/name.txt.gotxt", "{{ .UserData.size }}")
+
+	action := Action{Item: "folder", Source: "button", Destination: filepath.Join(tempDir, "out")}
+	if err := (&RenderAction{}).Execute(context.Background(), action, &TemplateData{TemplatesDir: templatesDir}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, "out", EntityDataFile)); !os.IsNotExist(err) {
+		t.Errorf("Expected the user data file not to be rendered")
+	}
+}
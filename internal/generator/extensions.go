@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/templateengine"
+)
+
+// BuiltInTemplateExtension is the templating extension of the built-in templates.
+const BuiltInTemplateExtension = ".gotxt"
+
+// TemplateFileName returns the name of a built-in template file once copied to a
+// project using the templating extensions: when they do not include
+// BuiltInTemplateExtension, it is replaced with the first of them rendered by the Go
+// template engine, e.g. ".tmpl".
+func TemplateFileName(name string, extensions []string) string {
+	if filepath.Ext(name) != BuiltInTemplateExtension || slices.Contains(extensions, BuiltInTemplateExtension) {
+		return name
+	}
+	for _, ext := range extensions {
+		if engine, err := templateengine.Resolve("", ext); err == nil && engine.Name() == templateengine.GoEngineName {
+			return strings.TrimSuffix(name, BuiltInTemplateExtension) + ext
+		}
+	}
+	return name
+}
+
+// templateExtensions returns the templating extensions of the project, or the default
+// ones when not set.
+func (d *TemplateData) templateExtensions() []string {
+	if len(d.TemplateExtensions) > 0 {
+		return d.TemplateExtensions
+	}
+	return config.DefaultTemplateExtensions
+}
+
+// withTemplateExtensions returns a copy of actions with their template files named
+// after the templating extensions (see TemplateFileName).
+func withTemplateExtensions(actions []Action, extensions []string) []Action {
+	renamed := make([]Action, len(actions))
+	for i, action := range actions {
+		if action.Item == "file" {
+			action.TemplateFile = TemplateFileName(action.TemplateFile, extensions)
+		}
+		renamed[i] = action
+	}
+	return renamed
+}
+
+// renameTemplateFiles renames the built-in template files copied to dir after the
+// templating extensions (see TemplateFileName).
+func renameTemplateFiles(dir string, extensions []string) error {
+	if TemplateFileName(BuiltInTemplateExtension, extensions) == BuiltInTemplateExtension {
+		return nil
+	}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if renamed := TemplateFileName(path, extensions); renamed != path {
+			return os.Rename(path, renamed)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return apperrors.Wrap("failed to rename the template files", err, dir)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTemplateFileName(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions []string
+		want       string
+	}{
+		{"component.templ.gotxt", []string{".gotxt", ".tpl"}, "component.templ.gotxt"},
+		{"component.templ.gotxt", []string{".tmpl"}, "component.templ.tmpl"},
+		{"component.templ.gotxt", []string{".hbs", ".tmpl"}, "component.templ.tmpl"},
+		{"component.templ.gotxt", []string{".hbs"}, "component.templ.gotxt"},
+		{"component.templ.gotxt", nil, "component.templ.gotxt"},
+		{"base.css", []string{".tmpl"}, "base.css"},
+	}
+	for _, tt := range tests {
+		if got := TemplateFileName(tt.name, tt.extensions); got != tt.want {
+			t.Errorf("TemplateFileName(%q, %v) = %q, want %q", tt.name, tt.extensions, got, tt.want)
+		}
+	}
+}
+
+func TestRenameTemplateFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"dark.css.gotxt", filepath.Join("nested", "light.css.gotxt"), "notes.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := renameTemplateFiles(dir, []string{".tmpl"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, name := range []string{"dark.css.tmpl", filepath.Join("nested", "light.css.tmpl"), "notes.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s to exist: %v", name, err)
+		}
+	}
+
+	if err := renameTemplateFiles(filepath.Join(dir, "missing"), []string{".tmpl"}); err != nil {
+		t.Errorf("Expected a missing folder to be ignored, got %v", err)
+	}
+}
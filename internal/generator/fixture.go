@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"gopkg.in/yaml.v3"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES & CONSTANTS                                                         */
+/* ------------------------------------------------------------------------- */
+
+// TemplateFixture is a named TemplateData used to render the templates without
+// scaffolding an entity.
+type TemplateFixture struct {
+	Name string
+	Data *TemplateData
+}
+
+// TemplateRenderResult is the outcome of rendering a template with a fixture.
+type TemplateRenderResult struct {
+	Template string // Path relative to the templates directory
+	Fixture  string
+	Err      error
+}
+
+// fixtureExtensions lists the accepted fixture file extensions. JSON is parsed as YAML.
+var fixtureExtensions = []string{".yaml", ".yml", ".json"}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// LoadTemplateFixtures reads the fixture files of dir, sorted by name. Each fixture
+// overrides the values of base with the fields it sets (e.g. `component_name: button`);
+// its user_data keys are merged into the base UserData. A missing dir returns no fixtures.
+func LoadTemplateFixtures(dir string, base *TemplateData) ([]TemplateFixture, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, apperrors.Wrap("failed to read fixtures folder", err, dir)
+	}
+
+	var fixtures []TemplateFixture
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || !slices.Contains(fixtureExtensions, ext) {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, apperrors.Wrap("failed to read fixture", err, path)
+		}
+
+		data := *base
+		data.UserData = maps.Clone(base.UserData)
+		if data.UserData == nil {
+			data.UserData = make(map[string]any)
+		}
+		if err := yaml.Unmarshal(content, &data); err != nil {
+			return nil, apperrors.Wrap("failed to parse fixture", err, path)
+		}
+
+		fixtures = append(fixtures, TemplateFixture{
+			Name: strings.TrimSuffix(entry.Name(), ext),
+			Data: &data,
+		})
+	}
+	return fixtures, nil
+}
+
+// RenderTemplates renders every template file of templatesDir with the given extensions
+// against each fixture, with the engine selected by the file extension and the user
+// data file of the entity template folder merged into the fixture UserData. Nothing is
+// written: the results report the rendering error, if any, of each template and fixture pair.
+func RenderTemplates(templatesDir string, extensions []string, fixtures []TemplateFixture) ([]TemplateRenderResult, error) {
+	var results []TemplateRenderResult
+	err := filepath.WalkDir(templatesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !slices.Contains(extensions, filepath.Ext(path)) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(templatesDir, path)
+		if err != nil {
+			rel = path
+		}
+		rel = filepath.ToSlash(rel)
+
+		for _, fixture := range fixtures {
+			data, err := entityTemplateData(fixture.Data, templatesDir, filepath.Dir(rel))
+			if err != nil {
+				return err
+			}
+			_, renderErr := readAndRenderTemplate(path, "", data)
+			results = append(results, TemplateRenderResult{Template: rel, Fixture: fixture.Name, Err: renderErr})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to scan templates", err, templatesDir)
+	}
+	return results, nil
+}
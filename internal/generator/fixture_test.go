@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTemplateFixtures(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "b-input.json", `{"component_name": "input", "with_js": true}`)
+	writeFixture(t, dir, "a-button.yaml", "component_name: button\nuser_data:\n  size: md\n")
+	writeFixture(t, dir, "notes.txt", "ignored")
+
+	base := &TemplateData{GoPackage: "components", ComponentName: "sample", UserData: map[string]any{"author": "Jane Doe"}}
+	fixtures, err := LoadTemplateFixtures(dir, base)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(fixtures) != 2 || fixtures[0].Name != "a-button" || fixtures[1].Name != "b-input" {
+		t.Fatalf("Expected the a-button and b-input fixtures, got %+v", fixtures)
+	}
+
+	button := fixtures[0].Data
+	if button.ComponentName != "button" || button.GoPackage != "components" {
+		t.Errorf("Expected the fixture to override the base values, got %+v", button)
+	}
+	if button.UserData["size"] != "md" || button.UserData["author"] != "Jane Doe" {
+		t.Errorf("Expected the user data to be merged, got %v", button.UserData)
+	}
+	if _, ok := base.UserData["size"]; ok {
+		t.Errorf("Expected the base user data to be left unchanged, got %v", base.UserData)
+	}
+	if !fixtures[1].Data.WithJs {
+		t.Errorf("Expected the JSON fixture to set with_js")
+	}
+}
+
+func TestLoadTemplateFixtures_MissingDir(t *testing.T) {
+	fixtures, err := LoadTemplateFixtures(filepath.Join(t.TempDir(), "missing"), &TemplateData{})
+	if err != nil || fixtures != nil {
+		t.Errorf("Expected no fixtures and no error, got %v, %v", fixtures, err)
+	}
+}
+
+func TestLoadTemplateFixtures_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "broken.yaml", "component_name: [")
+
+	if _, err := LoadTemplateFixtures(dir, &TemplateData{}); err == nil {
+		t.Error("Expected an error for an invalid fixture")
+	}
+}
+
+func TestRenderTemplates(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "component/name.gotxt", "{{ .ComponentName }}")
+	writeFixture(t, dir, "component/size.gotxt", "{{ .UserData.size.default }}")
+	writeFixture(t, dir, "component/README.md", "{{ .Unknown }}")
+
+	fixtures := []TemplateFixture{
+		{Name: "nested", Data: &TemplateData{ComponentName: "button", UserData: map[string]any{"size": map[string]any{"default": "md"}}}},
+		{Name: "flat", Data: &TemplateData{ComponentName: "button", UserData: map[string]any{"size": "md"}}},
+	}
+
+	results, err := RenderTemplates(dir, []string{".gotxt"}, fixtures)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %+v", results)
+	}
+
+	for _, result := range results {
+		shouldFail := result.Template == "component/size.gotxt" && result.Fixture == "flat"
+		if (result.Err != nil) != shouldFail {
+			t.Errorf("Unexpected result for %s with %s: %v", result.Template, result.Fixture, result.Err)
+		}
+	}
+}
+
+func writeFixture(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+}
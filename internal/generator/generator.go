@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
 	"github.com/indaco/tempo/internal/logger"
 	"github.com/indaco/tempo/internal/utils"
 )
@@ -36,6 +37,17 @@ var _ ActionProcessor = (*DefaultActionProcessor)(nil)
 var actionHandlers = map[string]ActionHandler{
 	CopyActionID:   &CopyAction{},
 	RenderActionID: &RenderAction{},
+	HeaderActionID: &HeaderAction{},
+}
+
+// RegisterActionHandler registers the handler for a custom action type, replacing any
+// handler previously registered with the same id. Built-in action types cannot be replaced.
+func RegisterActionHandler(id string, handler ActionHandler) error {
+	if id == CopyActionID || id == RenderActionID || id == HeaderActionID {
+		return apperrors.Wrap("cannot replace built-in action type", id)
+	}
+	actionHandlers[id] = handler
+	return nil
 }
 
 // Default function implementation (kept for backward compatibility)
@@ -44,12 +56,42 @@ var actionHandlers = map[string]ActionHandler{
 var ProcessActionsFunc = ProcessActions
 
 // ProcessActions processes a list of actions using the appropriate handlers.
+// The rendered files are staged and only written into the project once all actions
+// succeed (see Staging).
 func ProcessActions(ctx context.Context, logger logger.Logger, actions []Action, data *TemplateData) error {
 	// Validate context - use Background as fallback for non-critical operations
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
+	// Data built without the layout config renders the default layout
+	data.SetLayout(config.Layout{})
+
+	// Nothing to stage in dry-run or preview mode, or when a caller already stages
+	if hooks := ActionHooksFromContext(ctx); data.DryRun || hooks.Preview != nil || hooks.Staging != nil {
+		return runActions(ctx, logger, actions, data)
+	}
+
+	staging, err := NewStaging()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = staging.Discard() }()
+
+	if err := runActions(WithActionHooks(ctx, func(h *ActionHooks) { h.Staging = staging }), logger, actions, data); err != nil {
+		return err
+	}
+	if err := staging.Commit(); err != nil {
+		return apperrors.Wrap("failed to commit staged files", err)
+	}
+	return nil
+}
+
+// runActions executes the actions in order, stopping at the first failure.
+func runActions(ctx context.Context, logger logger.Logger, actions []Action, data *TemplateData) error {
+	hooks := ActionHooksFromContext(ctx)
+	tracer, observer := hooks.Tracer, hooks.Observer
+
 	for _, action := range actions {
 		if data.DryRun {
 			handleDryRun(logger, action, data)
@@ -57,7 +99,13 @@ func ProcessActions(ctx context.Context, logger logger.Logger, actions []Action,
 		}
 
 		// Skip JS-related actions unless OnlyIfJs/WithJs is true
-		if isJsAction(action) && !data.WithJs {
+		// The full definition tier always generates its JS
+		if isJsAction(action) && !data.WithJs && action.Tier != TierFull {
+			continue
+		}
+
+		// Skip TypeScript declaration actions unless WithTsDecls is true
+		if action.OnlyIfTsDecls && !data.WithTsDecls {
 			continue
 		}
 
@@ -66,7 +114,20 @@ func ProcessActions(ctx context.Context, logger logger.Logger, actions []Action,
 			return apperrors.Wrap("unknown action type", action.Type)
 		}
 
-		if err := handler.Execute(ctx, action, data); err != nil {
+		if tracer != nil {
+			tracer.begin(action, data)
+		}
+
+		err := handler.Execute(ctx, action, data)
+
+		if tracer != nil {
+			tracer.end(err)
+		}
+		if observer != nil {
+			observer(action, err)
+		}
+
+		if err != nil {
 			return apperrors.Wrap("error executing action", err, action.Type)
 		}
 	}
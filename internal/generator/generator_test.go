@@ -24,6 +24,19 @@ func (m *MockActionHandler) Execute(ctx context.Context, action Action, data *Te
 	return nil
 }
 
+// useRealActionHandlers registers the built-in action handlers for the duration of
+// the test, as other tests replace them with mocks.
+func useRealActionHandlers(t *testing.T) {
+	t.Helper()
+	origHandlers := actionHandlers
+	actionHandlers = map[string]ActionHandler{
+		CopyActionID:   &CopyAction{},
+		RenderActionID: &RenderAction{},
+		HeaderActionID: &HeaderAction{},
+	}
+	t.Cleanup(func() { actionHandlers = origHandlers })
+}
+
 func TestProcessActions(t *testing.T) {
 	logger := logger.NewDefaultLogger()
 	tests := []struct {
@@ -265,3 +278,34 @@ func TestHandleDryRun_Unknown(t *testing.T) {
 		t.Errorf("Expected warning message to mention unknown action type, got: %s", msg)
 	}
 }
+
+type recordingHandler struct {
+	executed []Action
+}
+
+func (h *recordingHandler) Execute(_ context.Context, action Action, _ *TemplateData) error {
+	h.executed = append(h.executed, action)
+	return nil
+}
+
+func TestRegisterActionHandler(t *testing.T) {
+	handler := &recordingHandler{}
+	if err := RegisterActionHandler("custom-test", handler); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { delete(actionHandlers, "custom-test") })
+
+	actions := []Action{{Type: "custom-test", Item: "file", Path: "out.txt"}}
+	if err := ProcessActions(context.Background(), &testutils.MockLogger{}, actions, &TemplateData{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(handler.executed) != 1 || handler.executed[0].Path != "out.txt" {
+		t.Errorf("Expected the custom handler to execute the action, got %+v", handler.executed)
+	}
+
+	for _, id := range []string{CopyActionID, RenderActionID} {
+		if err := RegisterActionHandler(id, handler); err == nil {
+			t.Errorf("Expected an error when replacing built-in action %q", id)
+		}
+	}
+}
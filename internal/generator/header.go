@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/header"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// HeaderAction prepends the license or banner header of the templates.header config to
+// the output files lacking it. With item "file" it applies to the file at Path, with
+// item "folder" to the files under Destination. It follows the actions writing the files
+// and does nothing when no header is configured.
+type HeaderAction struct{}
+
+// Execute applies the header to the targets of the action, written by the previous
+// actions or already in the project. Missing files and file types without a comment
+// syntax are left out.
+func (a *HeaderAction) Execute(ctx context.Context, action Action, data *TemplateData) error {
+	h, err := header.New(data.Header, data.UserData)
+	if err != nil || h == nil {
+		return err
+	}
+
+	targets, err := headerTargets(ctx, action, data)
+	if err != nil {
+		return err
+	}
+
+	writeFunc, err := outputWriteFunc(action, data)
+	if err != nil {
+		return err
+	}
+	write := previewWriteFunc(ctx, tracedWriteFunc(ctx, recordedWriteFunc(ctx, stagedWriteFunc(ctx, writeFunc))))
+
+	for _, target := range targets {
+		content, ok, err := readOutput(ctx, target)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		updated, changed, err := h.Apply(content, target)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		if err := write(target, updated); err != nil {
+			return apperrors.Wrap("failed to write header", err, target)
+		}
+	}
+	return nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HEADER HELPERS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// headerTargets returns the output files of a header action, sorted.
+func headerTargets(ctx context.Context, action Action, data *TemplateData) ([]string, error) {
+	switch action.Item {
+	case "file":
+		path, err := utils.RenderTemplate(action.Path, data)
+		if err != nil {
+			return nil, apperrors.Wrap("failed to render output path", err, action.Path)
+		}
+		return []string{path}, nil
+	case "folder":
+		destination, err := utils.RenderTemplate(action.Destination, data)
+		if err != nil {
+			return nil, apperrors.Wrap("failed to render destination directory", err, action.Destination)
+		}
+		return folderOutputs(ctx, destination)
+	default:
+		return nil, apperrors.Wrap("unknown item type: %s", action.Item)
+	}
+}
+
+// folderOutputs returns the files directly under dir: the ones in the project and the
+// ones staged or previewed by the previous actions.
+func folderOutputs(ctx context.Context, dir string) ([]string, error) {
+	var paths []string
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, apperrors.Wrap("failed to read destination directory", err, dir)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	var pending []string
+	hooks := ActionHooksFromContext(ctx)
+	if s := hooks.Staging; s != nil {
+		pending = append(pending, s.paths()...)
+	}
+	if p := hooks.Preview; p != nil {
+		pending = append(pending, p.paths()...)
+	}
+	for _, path := range pending {
+		if filepath.Clean(filepath.Dir(path)) == filepath.Clean(dir) {
+			paths = append(paths, filepath.Clean(path))
+		}
+	}
+
+	slices.Sort(paths)
+	return slices.Compact(paths), nil
+}
+
+// readOutput returns the content of the output file at path as written by the previous
+// actions: previewed, staged or in the project. It returns false when the file is missing.
+func readOutput(ctx context.Context, path string) (string, bool, error) {
+	hooks := ActionHooksFromContext(ctx)
+	if p := hooks.Preview; p != nil {
+		if content, ok := p.read(path); ok {
+			return content, true, nil
+		}
+	}
+	if s := hooks.Staging; s != nil {
+		content, ok, err := s.read(path)
+		if err != nil || ok {
+			return content, ok, err
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, apperrors.Wrap("failed to read output file", err, path)
+	}
+	return string(content), true, nil
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestHeaderAction_Execute(t *testing.T) {
+	useRealActionHandlers(t)
+
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	testutils.CreateFile(t, filepath.Join(templatesDir, "button", "button.templ.gotxt"), "package {{ .ComponentName }}\n")
+	testutils.CreateFile(t, filepath.Join(templatesDir, "button", "base.css.gotxt"), ".{{ .ComponentName }} {}\n")
+	testutils.CreateFile(t, filepath.Join(templatesDir, "button", "data.json.gotxt"), "{}\n")
+
+	outDir := filepath.Join(tempDir, "out", "button")
+	actions := []Action{
+		{Type: RenderActionID, Item: "folder", Source: "button", Destination: outDir, Force: true},
+		{Type: HeaderActionID, Item: "folder", Destination: outDir},
+	}
+	newData := func(template string) *TemplateData {
+		return &TemplateData{
+			TemplatesDir:  templatesDir,
+			ComponentName: "button",
+			Header:        config.Header{Template: template, Author: "ACME Inc."},
+		}
+	}
+	read := func(t *testing.T, name string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(outDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	t.Run("No header configured", func(t *testing.T) {
+		if err := ProcessActions(context.Background(), &testutils.MockLogger{}, actions, newData("")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := read(t, "button.templ"); got != "package button\n" {
+			t.Errorf("Expected no header, got %q", got)
+		}
+	})
+
+	t.Run("Prepends the header to the rendered files", func(t *testing.T) {
+		if err := ProcessActions(context.Background(), &testutils.MockLogger{}, actions, newData("Copyright {{ .Year }} {{ .Author }}")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for name, prefix := range map[string]string{"button.templ": "// Copyright ", "base.css": "/* Copyright "} {
+			if got := read(t, name); !strings.HasPrefix(got, prefix) || !strings.Contains(got, "ACME Inc.") {
+				t.Errorf("Expected %s to start with the header, got %q", name, got)
+			}
+		}
+		if got := read(t, "data.json"); got != "{}\n" {
+			t.Errorf("Expected the file without a comment syntax to be unchanged, got %q", got)
+		}
+	})
+
+	t.Run("Previews the header", func(t *testing.T) {
+		if err := os.RemoveAll(outDir); err != nil {
+			t.Fatal(err)
+		}
+		preview := NewOverwritePreview()
+		ctx := WithActionHooks(context.Background(), func(h *ActionHooks) { h.Preview = preview })
+		if err := ProcessActions(ctx, &testutils.MockLogger{}, actions, newData("Copyright {{ .Author }}")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		content, ok := preview.read(filepath.Join(outDir, "button.templ"))
+		if !ok || content != "// Copyright ACME Inc.\n\npackage button\n" {
+			t.Errorf("Expected the previewed content to have the header, got %q", content)
+		}
+		if _, err := os.Stat(outDir); !os.IsNotExist(err) {
+			t.Errorf("Expected no output written when previewing")
+		}
+	})
+}
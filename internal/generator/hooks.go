@@ -0,0 +1,36 @@
+package generator
+
+import "context"
+
+// ActionObserver is notified after each executed action, with the error it failed with,
+// if any, e.g. to report the progress of a generation in a host application.
+type ActionObserver func(action Action, err error)
+
+// ActionHooks are the optional hooks run while the actions are processed. A nil hook
+// is skipped.
+type ActionHooks struct {
+	Tracer   *Tracer           // Collects one trace entry per action
+	Observer ActionObserver    // Notified after each action
+	Recorder *FileRecorder     // Collects the paths of the written files, also by sync
+	Preview  *OverwritePreview // Records the overwrite diffs; no output file is written
+	Staging  *Staging          // Stages the written files until all actions succeed
+}
+
+type actionHooksKey struct{}
+
+// WithActionHooks returns a copy of ctx carrying the hooks of ctx, if any, as changed
+// by set, so that the hooks of different callers compose.
+func WithActionHooks(ctx context.Context, set func(hooks *ActionHooks)) context.Context {
+	hooks := ActionHooksFromContext(ctx)
+	set(&hooks)
+	return context.WithValue(ctx, actionHooksKey{}, hooks)
+}
+
+// ActionHooksFromContext returns the hooks stored in ctx, or no hooks if none are set.
+func ActionHooksFromContext(ctx context.Context) ActionHooks {
+	if ctx == nil {
+		return ActionHooks{}
+	}
+	hooks, _ := ctx.Value(actionHooksKey{}).(ActionHooks)
+	return hooks
+}
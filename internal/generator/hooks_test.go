@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestProcessActions_WithActionObserver(t *testing.T) {
+	useRealActionHandlers(t)
+
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	testutils.CreateFile(t, filepath.Join(templatesDir, "hello.gotxt"), "Hello {{ .ComponentName }}")
+
+	actions := []Action{
+		{Type: RenderActionID, Item: "file", TemplateFile: "hello.gotxt", Path: filepath.Join(tempDir, "out", "hello.txt")},
+		{Type: RenderActionID, Item: "file", TemplateFile: "missing.gotxt", Path: filepath.Join(tempDir, "out", "missing.txt")},
+	}
+	data := &TemplateData{TemplatesDir: templatesDir, ComponentName: "button"}
+
+	var observed []string
+	var lastErr error
+	ctx := WithActionHooks(context.Background(), func(h *ActionHooks) {
+		h.Observer = func(action Action, err error) {
+			observed = append(observed, action.TemplateFile)
+			lastErr = err
+		}
+	})
+
+	if err := ProcessActions(ctx, &testutils.MockLogger{}, actions, data); err == nil {
+		t.Fatal("Expected an error for the missing template")
+	}
+	if len(observed) != 2 || observed[0] != "hello.gotxt" || observed[1] != "missing.gotxt" {
+		t.Errorf("Expected both actions to be observed, got %v", observed)
+	}
+	if lastErr == nil {
+		t.Error("Expected the failing action to be observed with its error")
+	}
+}
+
+func TestActionHooksFromContext(t *testing.T) {
+	hooks := ActionHooksFromContext(context.Background())
+	if hooks.Tracer != nil || hooks.Observer != nil || hooks.Recorder != nil || hooks.Preview != nil || hooks.Staging != nil {
+		t.Errorf("Expected no hooks for a context without hooks, got %+v", hooks)
+	}
+
+	tracer := NewTracer(nil)
+	recorder := NewFileRecorder()
+	ctx := WithActionHooks(context.Background(), func(h *ActionHooks) { h.Tracer = tracer })
+	ctx = WithActionHooks(ctx, func(h *ActionHooks) { h.Recorder = recorder })
+
+	hooks = ActionHooksFromContext(ctx)
+	if hooks.Tracer != tracer {
+		t.Errorf("Expected tracer %p to be kept, got %p", tracer, hooks.Tracer)
+	}
+	if hooks.Recorder != recorder {
+		t.Errorf("Expected recorder %p, got %p", recorder, hooks.Recorder)
+	}
+
+	ctx = WithActionHooks(ctx, func(h *ActionHooks) { h.Tracer = nil })
+	if got := ActionHooksFromContext(ctx); got.Tracer != nil || got.Recorder != recorder {
+		t.Errorf("Expected only the tracer to be removed, got %+v", got)
+	}
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/templates"
+)
+
+// SetTemplateLayers sets Templates and Defaults to the layered lookup of the templates
+// when enabled (see config.Templates.Layered): a template is read from TemplatesDir,
+// then from the user templates folder (see config.UserTemplatesDir), then from the
+// built-in templates, and the copy actions copy from the last two. When disabled, the
+// templates are read from TemplatesDir only and copied from the built-in ones.
+func (d *TemplateData) SetTemplateLayers(enabled bool) {
+	if !enabled {
+		d.Templates, d.Defaults = nil, nil
+		return
+	}
+
+	var user fs.FS
+	if dir, err := config.UserTemplatesDir(); err == nil {
+		user = os.DirFS(dir)
+	}
+	d.Defaults = templates.NewLayeredFS(user, templates.EmbeddedFiles)
+	d.Templates = templates.NewLayeredFS(os.DirFS(d.TemplatesDir), d.Defaults)
+}
+
+// readTemplate reads the template file at path, in TemplatesDir. With Templates set, it
+// is read from its layers, a template of the lower layers keeping its built-in name
+// (see builtInTemplateName).
+func (d *TemplateData) readTemplate(path string) ([]byte, error) {
+	name, ok := d.templateName(path)
+	if !ok {
+		return readFile(path)
+	}
+
+	content, err := fs.ReadFile(d.Templates, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		if builtIn := builtInTemplateName(name, d.templateExtensions()); builtIn != name {
+			content, err = fs.ReadFile(d.Templates, builtIn)
+		}
+	}
+	if err != nil {
+		return nil, apperrors.Wrap("template file not found in the template layers: %s", err, path)
+	}
+	return content, nil
+}
+
+// readTemplateDir reads the entries of the template folder at path, in TemplatesDir,
+// merged from the layers of Templates when set.
+func (d *TemplateData) readTemplateDir(path string) ([]os.DirEntry, error) {
+	name, ok := d.templateName(path)
+	if !ok {
+		return readDir(path)
+	}
+	return fs.ReadDir(d.Templates, name)
+}
+
+// templateName returns the name in Templates of the file at path, when Templates is set
+// and path is in TemplatesDir.
+func (d *TemplateData) templateName(path string) (string, bool) {
+	if d.Templates == nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(d.TemplatesDir, path)
+	if err != nil {
+		return "", false
+	}
+	name := filepath.ToSlash(rel)
+	return name, fs.ValidPath(name)
+}
+
+// builtInTemplateName returns the name of a project template file in the built-in
+// templates: the reverse of TemplateFileName.
+func builtInTemplateName(name string, extensions []string) string {
+	ext := filepath.Ext(name)
+	if ext == "" || ext == BuiltInTemplateExtension {
+		return name
+	}
+	builtIn := strings.TrimSuffix(name, ext) + BuiltInTemplateExtension
+	if TemplateFileName(builtIn, extensions) != name {
+		return name
+	}
+	return builtIn
+}
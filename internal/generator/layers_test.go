@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/indaco/tempo/internal/templates"
+)
+
+func TestBuiltInTemplateName(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions []string
+		want       string
+	}{
+		{"component/templ/component.templ.tmpl", []string{".tmpl"}, "component/templ/component.templ.gotxt"},
+		{"component/templ/component.templ.gotxt", []string{".tmpl"}, "component/templ/component.templ.gotxt"},
+		{"component/templ/component.templ.tmpl", []string{".gotxt", ".tmpl"}, "component/templ/component.templ.tmpl"},
+		{"component/templ/component.templ.hbs", []string{".hbs", ".tmpl"}, "component/templ/component.templ.hbs"},
+		{"component/README", []string{".tmpl"}, "component/README"},
+	}
+	for _, tt := range tests {
+		if got := builtInTemplateName(tt.name, tt.extensions); got != tt.want {
+			t.Errorf("builtInTemplateName(%q, %v) = %q, want %q", tt.name, tt.extensions, got, tt.want)
+		}
+	}
+}
+
+func TestSetTemplateLayers(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	outputDir := filepath.Join(tempDir, "out")
+
+	// The project overrides the component template only
+	projectTempl := filepath.Join(templatesDir, "component", "templ", "component.templ.tmpl")
+	if err := os.MkdirAll(filepath.Dir(projectTempl), 0755); err != nil {
+		t.Fatalf("Failed to create templates folder: %v", err)
+	}
+	if err := os.WriteFile(projectTempl, []byte("project {{ .ComponentName }}"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	data := &TemplateData{TemplatesDir: templatesDir, ComponentName: "button", TemplateExtensions: []string{".tmpl"}}
+	data.SetTemplateLayers(true)
+
+	tests := []struct {
+		templateFile string
+		want         string
+	}{
+		{"component/templ/component.templ.tmpl", "project button"},
+		{"component/assets/css/base.css.tmpl", ""}, // Built-in template, under its built-in name
+	}
+	for _, tt := range tests {
+		output := filepath.Join(outputDir, filepath.Base(tt.templateFile))
+		action := Action{TemplateFile: tt.templateFile, Path: output}
+		if err := renderActionFile(context.Background(), action, data); err != nil {
+			t.Fatalf("Unexpected error rendering %s: %v", tt.templateFile, err)
+		}
+		content, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("Failed to read rendered file: %v", err)
+		}
+		if tt.want != "" && string(content) != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, content)
+		}
+		if tt.want == "" && len(content) == 0 {
+			t.Errorf("Expected the built-in template of %s to be rendered", tt.templateFile)
+		}
+	}
+
+	data.SetTemplateLayers(false)
+	if data.Templates != nil || data.Defaults != nil {
+		t.Error("Expected no template layers once disabled")
+	}
+	if err := renderActionFile(context.Background(), Action{TemplateFile: "component/assets/css/base.css.tmpl", Path: filepath.Join(outputDir, "x")}, data); err == nil {
+		t.Error("Expected an error for a template missing from the templates folder")
+	}
+}
+
+func TestCopyAction_Execute_Defaults(t *testing.T) {
+	templatesDir := t.TempDir()
+	data := &TemplateData{
+		TemplatesDir: templatesDir,
+		Defaults: templates.NewLayeredFS(fstest.MapFS{
+			"component/templ/component.templ.gotxt": {Data: []byte("user template")},
+		}, templates.EmbeddedFiles),
+	}
+
+	action := Action{Item: "folder", Source: "component"}
+	if err := (&CopyAction{}).Execute(context.Background(), action, data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(templatesDir, "component", "templ", "component.templ.gotxt"))
+	if err != nil {
+		t.Fatalf("Failed to read copied template: %v", err)
+	}
+	if string(content) != "user template" {
+		t.Errorf("Expected the user template to hide the built-in one, got %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(templatesDir, "component", "assets", "css", "base.css.gotxt")); err != nil {
+		t.Errorf("Expected the built-in templates to be copied too: %v", err)
+	}
+}
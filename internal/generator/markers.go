@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/processor"
+)
+
+var (
+	// guardMarkerExpr matches the template expressions of the guard marker name, e.g.
+	// "{{ .GuardMarker }}".
+	guardMarkerExpr = regexp.MustCompile(`\{\{-?\s*\.GuardMarker\s*-?\}\}`)
+	// syncTargetTag matches the style and script elements sync injects the assets into.
+	syncTargetTag = regexp.MustCompile(`<(style|script)[\s>]`)
+)
+
+// CheckTemplateGuardMarkers checks that the CSS and JS templ templates of the folder at
+// dir, i.e. the ones with a style or script element, hold the guard markers named
+// markerName, either as is or as the GuardMarker template variable: without them, sync
+// injects nothing into the generated files. The error names the first template file
+// missing them.
+func CheckTemplateGuardMarkers(dir, markerName string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return apperrors.Wrap("failed to read template folder", err, dir)
+		}
+		if d.IsDir() || !strings.Contains(d.Name(), ".templ.") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return apperrors.Wrap("failed to read template file", err, path)
+		}
+		if !syncTargetTag.Match(content) {
+			return nil
+		}
+		resolved := guardMarkerExpr.ReplaceAllLiteralString(string(content), markerName)
+		if _, _, ok := processor.GuardRegion(resolved, markerName); !ok {
+			return apperrors.WrapCode(apperrors.CodeMissingMarkers,
+				"template %s has a style or script element but no %s guard markers, so sync would never update it", path, markerName)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/testutils"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+func TestCheckTemplateGuardMarkers(t *testing.T) {
+	const (
+		withVariable = "<style>\n/* [{{ .GuardMarker }}] BEGIN - Do not edit! This section is auto-generated. */\n/* [{{ .GuardMarker }}] END */\n</style>\n"
+		withName     = "<script>\n/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo] END */\n</script>\n"
+		noMarkers    = "<style type=\"text/css\">\n</style>\n"
+	)
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{"markers as variable", map[string]string{"css/base-css.templ.gotxt": withVariable}, ""},
+		{"markers as name", map[string]string{"js/script.templ.gotxt": withName}, ""},
+		{"no style or script element", map[string]string{"component.templ.gotxt": "templ Button() {}\n"}, ""},
+		{"not a templ template", map[string]string{"assets/css/base.css.gotxt": noMarkers}, ""},
+		{"missing markers", map[string]string{"css/base-css.templ.gotxt": withVariable, "css/themes/dark.templ.gotxt": noMarkers}, "dark.templ.gotxt"},
+		{"other marker name", map[string]string{"js/script.templ.gotxt": strings.ReplaceAll(withName, "[tempo]", "[other]")}, "script.templ.gotxt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				testutils.CreateFile(t, filepath.Join(dir, filepath.FromSlash(name)), content)
+			}
+
+			err := CheckTemplateGuardMarkers(dir, "tempo")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Expected an error naming %s, got %v", tt.wantErr, err)
+			}
+			if code := apperrors.CodeOf(err); code != apperrors.CodeMissingMarkers {
+				t.Errorf("Expected code %s, got %s", apperrors.CodeMissingMarkers, code)
+			}
+		})
+	}
+}
+
+func TestCheckTemplateGuardMarkers_BuiltIn(t *testing.T) {
+	dir := t.TempDir()
+	for _, source := range []string{"component", "webcomponent"} {
+		if err := utils.CopyDirFromEmbed(source, filepath.Join(dir, source)); err != nil {
+			t.Fatalf("Failed to copy the built-in templates: %v", err)
+		}
+	}
+
+	if err := CheckTemplateGuardMarkers(dir, "tempo"); err != nil {
+		t.Errorf("Expected the built-in templates to hold the guard markers, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// OverwriteDiff is the change an action would make to an existing file.
+type OverwriteDiff struct {
+	Path string
+	Diff string // Unified diff from the existing to the rendered content
+}
+
+// OverwritePreview collects the changes the actions would make to the existing files.
+// While it is attached to the context, no output file is written.
+type OverwritePreview struct {
+	diffs    []OverwriteDiff
+	contents map[string]string // Content recorded for each output path
+	mu       sync.Mutex
+}
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTOR                                                               */
+/* ------------------------------------------------------------------------- */
+
+// NewOverwritePreview creates an empty OverwritePreview.
+func NewOverwritePreview() *OverwritePreview {
+	return &OverwritePreview{contents: make(map[string]string)}
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// Diffs returns a copy of the collected diffs, in the order of the actions.
+func (p *OverwritePreview) Diffs() []OverwriteDiff {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	diffs := make([]OverwriteDiff, len(p.diffs))
+	copy(diffs, p.diffs)
+	return diffs
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// record diffs the content of the existing file at path with content. A path written
+// again, e.g. by a header action, replaces its previous diff.
+func (p *OverwritePreview) record(path, content string) error {
+	p.mu.Lock()
+	p.contents[path] = content
+	p.mu.Unlock()
+
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil // New files are not overwrites
+		}
+		return apperrors.Wrap("failed to read existing file", err, path)
+	}
+
+	diff := utils.UnifiedDiff(path, path, string(existing), content)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.diffs = slices.DeleteFunc(p.diffs, func(d OverwriteDiff) bool { return d.Path == path })
+	if diff != "" {
+		p.diffs = append(p.diffs, OverwriteDiff{Path: path, Diff: diff})
+	}
+	return nil
+}
+
+// read returns the content recorded for path, and false when none is recorded.
+func (p *OverwritePreview) read(path string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	content, ok := p.contents[path]
+	return content, ok
+}
+
+// paths returns the recorded output paths.
+func (p *OverwritePreview) paths() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Collect(maps.Keys(p.contents))
+}
+
+// previewWriteFunc replaces writeFunc with the recording of the overwrite diffs when
+// a preview is found in ctx.
+func previewWriteFunc(ctx context.Context, writeFunc func(string, string) error) func(string, string) error {
+	p := ActionHooksFromContext(ctx).Preview
+	if p == nil {
+		return writeFunc
+	}
+	return p.record
+}
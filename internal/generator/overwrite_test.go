@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestProcessActions_WithOverwritePreview(t *testing.T) {
+	useRealActionHandlers(t)
+
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	testutils.CreateFile(t, filepath.Join(templatesDir, "hello.gotxt"), "Hello {{ .ComponentName }}\n")
+	testutils.CreateFile(t, filepath.Join(templatesDir, "same.gotxt"), "Same\n")
+
+	changedPath := filepath.Join(tempDir, "out", "hello.txt")
+	samePath := filepath.Join(tempDir, "out", "same.txt")
+	newPath := filepath.Join(tempDir, "out", "new.txt")
+	testutils.CreateFile(t, changedPath, "Hello world\n")
+	testutils.CreateFile(t, samePath, "Same\n")
+
+	actions := []Action{
+		{Type: RenderActionID, Item: "file", TemplateFile: "hello.gotxt", Path: changedPath, Force: true},
+		{Type: RenderActionID, Item: "file", TemplateFile: "same.gotxt", Path: samePath, Force: true},
+		{Type: RenderActionID, Item: "file", TemplateFile: "same.gotxt", Path: newPath, Force: true},
+	}
+	data := &TemplateData{TemplatesDir: templatesDir, ComponentName: "button"}
+
+	preview := NewOverwritePreview()
+	ctx := WithActionHooks(context.Background(), func(h *ActionHooks) { h.Preview = preview })
+	if err := ProcessActions(ctx, &testutils.MockLogger{}, actions, data); err != nil {
+		t.Fatalf("ProcessActions returned error: %v", err)
+	}
+
+	diffs := preview.Diffs()
+	if len(diffs) != 1 || diffs[0].Path != changedPath {
+		t.Fatalf("Expected one diff for %s, got %+v", changedPath, diffs)
+	}
+	if !strings.Contains(diffs[0].Diff, "-Hello world\n+Hello button\n") {
+		t.Errorf("Unexpected diff:\n%s", diffs[0].Diff)
+	}
+
+	if content, _ := os.ReadFile(changedPath); string(content) != "Hello world\n" {
+		t.Errorf("Expected the existing file to be left untouched, got %q", content)
+	}
+	if _, err := os.Stat(newPath); !os.IsNotExist(err) {
+		t.Errorf("Expected no new file to be written during the preview")
+	}
+}
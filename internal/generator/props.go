@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"errors"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"gopkg.in/yaml.v3"
+)
+
+// PropsSchemaFile is the name of the props schema file of an entity template folder
+// (e.g. templates/component/.tempo-props.yaml). Its props are exposed to the templates
+// of that folder as TemplateData.Props.
+const PropsSchemaFile = ".tempo-props.yaml"
+
+// Prop is a component prop declared in a props schema file.
+type Prop struct {
+	Name        string `yaml:"name"`                  // Go identifier, e.g. "label"
+	Type        string `yaml:"type"`                  // Go type, e.g. "string" or "templ.Attributes"
+	Default     string `yaml:"default,omitempty"`     // Default value, e.g. "primary" or "false"
+	Description string `yaml:"description,omitempty"` // Documentation of the prop
+}
+
+// PropsSchema is the content of a props schema file.
+type PropsSchema struct {
+	Props []Prop `yaml:"props"`
+}
+
+// DefaultValue returns the default value of the prop as a Go literal: Default quoted
+// for string props and as is otherwise, or the zero value of the type when Default is empty.
+func (p Prop) DefaultValue() string {
+	switch {
+	case p.Type == "string":
+		return strconv.Quote(p.Default)
+	case p.Default != "":
+		return p.Default
+	case p.Type == "bool":
+		return "false"
+	case isNumericType(p.Type):
+		return "0"
+	default:
+		return "nil"
+	}
+}
+
+// ParsePropsSchema parses and validates the content of a props schema file: every prop
+// needs a name that is a Go identifier, unique in the schema, and a type.
+func ParsePropsSchema(content []byte) ([]Prop, error) {
+	var schema PropsSchema
+	if err := yaml.Unmarshal(content, &schema); err != nil {
+		return nil, apperrors.Wrap("failed to parse props schema", err)
+	}
+
+	seen := make(map[string]bool, len(schema.Props))
+	for i, prop := range schema.Props {
+		position := strconv.Itoa(i + 1)
+		switch {
+		case !token.IsIdentifier(prop.Name):
+			return nil, apperrors.Wrap("prop %s: name %s is not a valid Go identifier", position, strconv.Quote(prop.Name))
+		case seen[prop.Name]:
+			return nil, apperrors.Wrap("prop %s: duplicated name %s", position, prop.Name)
+		case strings.TrimSpace(prop.Type) == "":
+			return nil, apperrors.Wrap("prop %s: missing type", prop.Name)
+		}
+		seen[prop.Name] = true
+	}
+	return schema.Props, nil
+}
+
+// LoadPropsSchema reads and validates the props schema file at path.
+func LoadPropsSchema(path string) ([]Prop, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, apperrors.Wrap("failed to read props schema", err, path)
+	}
+
+	props, err := ParsePropsSchema(content)
+	if err != nil {
+		return nil, apperrors.Wrap("invalid props schema", err, path)
+	}
+	return props, nil
+}
+
+// LoadEntityProps reads the props schema file of the template folder dir.
+// A missing file returns nil values.
+func LoadEntityProps(dir string) ([]Prop, error) {
+	path := filepath.Join(dir, PropsSchemaFile)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return LoadPropsSchema(path)
+}
+
+// isNumericType reports whether typ is a Go numeric type.
+func isNumericType(typ string) bool {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"float32", "float64", "byte", "rune":
+		return true
+	}
+	return false
+}
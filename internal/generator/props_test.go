@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePropsSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr bool
+	}{
+		{"Valid", "props:\n  - name: label\n    type: string\n  - name: size\n    type: int\n", 2, false},
+		{"Empty", "", 0, false},
+		{"Invalid name", "props:\n  - name: my-label\n    type: string\n", 0, true},
+		{"Duplicated name", "props:\n  - name: label\n    type: string\n  - name: label\n    type: bool\n", 0, true},
+		{"Missing type", "props:\n  - name: label\n", 0, true},
+		{"Invalid YAML", "props: [", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			props, err := ParsePropsSchema([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePropsSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(props) != tt.want {
+				t.Errorf("Expected %d props, got %d", tt.want, len(props))
+			}
+		})
+	}
+}
+
+func TestProp_DefaultValue(t *testing.T) {
+	tests := []struct {
+		prop Prop
+		want string
+	}{
+		{Prop{Type: "string", Default: "primary"}, `"primary"`},
+		{Prop{Type: "string"}, `""`},
+		{Prop{Type: "bool", Default: "true"}, "true"},
+		{Prop{Type: "bool"}, "false"},
+		{Prop{Type: "int"}, "0"},
+		{Prop{Type: "templ.Attributes"}, "nil"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.prop.DefaultValue(); got != tt.want {
+			t.Errorf("DefaultValue() of %+v = %s, want %s", tt.prop, got, tt.want)
+		}
+	}
+}
+
+func TestLoadEntityProps(t *testing.T) {
+	tempDir := t.TempDir()
+
+	props, err := LoadEntityProps(tempDir)
+	if err != nil || props != nil {
+		t.Fatalf("Expected no props without a schema file, got %v, %v", props, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tempDir, PropsSchemaFile), []byte("props:\n  - name: label\n    type: string\n"), 0644); err != nil {
+		t.Fatalf("Failed to write props schema: %v", err)
+	}
+	props, err = LoadEntityProps(tempDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(props) != 1 || props[0].Name != "label" {
+		t.Errorf("Unexpected props: %+v", props)
+	}
+}
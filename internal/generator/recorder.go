@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"context"
+	"sync"
+)
+
+// FileRecorder collects the paths of the files written by the actions or by sync, e.g.
+// to report them once the command completes.
+type FileRecorder struct {
+	paths    []string
+	recorded map[string]bool
+	mu       sync.Mutex
+}
+
+// NewFileRecorder creates an empty FileRecorder.
+func NewFileRecorder() *FileRecorder {
+	return &FileRecorder{recorded: make(map[string]bool)}
+}
+
+// Paths returns the paths of the written files, in the order of their first write.
+func (r *FileRecorder) Paths() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	paths := make([]string, len(r.paths))
+	copy(paths, r.paths)
+	return paths
+}
+
+// Record adds a written file. A file written several times is recorded once.
+func (r *FileRecorder) Record(path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.recorded[path] {
+		return
+	}
+	r.recorded[path] = true
+	r.paths = append(r.paths, path)
+}
+
+// recordedWriteFunc wraps writeFunc so that successful writes are recorded on the file
+// recorder found in ctx, if any.
+func recordedWriteFunc(ctx context.Context, writeFunc func(string, string) error) func(string, string) error {
+	r := ActionHooksFromContext(ctx).Recorder
+	if r == nil {
+		return writeFunc
+	}
+
+	return func(path, content string) error {
+		if err := writeFunc(path, content); err != nil {
+			return err
+		}
+		r.Record(path)
+		return nil
+	}
+}
@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestProcessActions_WithFileRecorder(t *testing.T) {
+	useRealActionHandlers(t)
+
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	if err := os.MkdirAll(templatesDir, 0755); err != nil {
+		t.Fatalf("Failed to create templates dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templatesDir, "hello.gotxt"), []byte("Hello {{ .ComponentName }}"), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+
+	actions := []Action{
+		{Type: RenderActionID, Item: "file", TemplateFile: "hello.gotxt", Path: filepath.Join(tempDir, "out", "a.txt")},
+		{Type: RenderActionID, Item: "file", TemplateFile: "hello.gotxt", Path: filepath.Join(tempDir, "out", "b.txt")},
+	}
+	data := &TemplateData{TemplatesDir: templatesDir, ComponentName: "button"}
+
+	recorder := NewFileRecorder()
+	ctx := WithActionHooks(context.Background(), func(h *ActionHooks) { h.Recorder = recorder })
+	if err := ProcessActions(ctx, &testutils.MockLogger{}, actions, data); err != nil {
+		t.Fatalf("ProcessActions returned error: %v", err)
+	}
+
+	expected := []string{filepath.Join(tempDir, "out", "a.txt"), filepath.Join(tempDir, "out", "b.txt")}
+	if got := recorder.Paths(); !slices.Equal(got, expected) {
+		t.Errorf("Expected recorded files %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,269 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Staging holds the files written by the actions in a temporary tree and moves them
+// into the project once all actions succeed, so that a failing action leaves no
+// half-created entity behind.
+type Staging struct {
+	dir   string
+	files []stagedFile
+	index map[string]int // Position of the staged files by output path
+	dirs  []string       // Output folders to create
+	mu    sync.Mutex
+}
+
+// stagedFile is an output file waiting in the staging tree.
+type stagedFile struct {
+	path   string                     // Output path in the project
+	staged string                     // Path in the staging tree
+	write  func(string, string) error // Writes the output file, honoring its mode
+}
+
+// committedFile records the state of an output path before the commit, to restore it.
+type committedFile struct {
+	path        string
+	existed     bool
+	backup      []byte
+	mode        fs.FileMode
+	createdDirs []string // Folders created for the file, deepest first
+}
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTOR                                                               */
+/* ------------------------------------------------------------------------- */
+
+// NewStaging creates a Staging backed by a new temporary folder.
+func NewStaging() (*Staging, error) {
+	dir, err := os.MkdirTemp("", "tempo-staging-*")
+	if err != nil {
+		return nil, apperrors.Wrap("failed to create staging folder", err)
+	}
+	return &Staging{dir: dir, index: make(map[string]int)}, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// Commit creates the staged folders and writes the staged files into the project.
+// When a write fails, the files already written are restored to their previous
+// content, or removed, along with the folders created for them.
+func (s *Staging) Commit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var done []committedFile
+	for _, dir := range s.dirs {
+		created := missingDirs(dir)
+		done = append(done, committedFile{createdDirs: created})
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			rollback(done)
+			return apperrors.Wrap("failed to create destination directory", err, dir)
+		}
+	}
+
+	for _, f := range s.files {
+		content, err := os.ReadFile(f.staged)
+		if err != nil {
+			rollback(done)
+			return apperrors.Wrap("failed to read staged file", err, f.staged)
+		}
+
+		committed, err := snapshotPath(f.path)
+		if err != nil {
+			rollback(done)
+			return err
+		}
+		done = append(done, committed)
+
+		if err := f.write(f.path, string(content)); err != nil {
+			rollback(done)
+			return apperrors.Wrap("failed to write rendered content", err, f.path)
+		}
+	}
+	return nil
+}
+
+// Discard removes the staging tree.
+func (s *Staging) Discard() error {
+	if err := os.RemoveAll(s.dir); err != nil {
+		return apperrors.Wrap("failed to remove staging folder", err, s.dir)
+	}
+	return nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// stage writes content to the staging tree, to be written at path with write on commit.
+func (s *Staging) stage(path, content string, write func(string, string) error) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return apperrors.Wrap("failed to resolve output path", err, path)
+	}
+	staged := filepath.Join(s.dir, strings.TrimPrefix(abs, filepath.VolumeName(abs)))
+
+	if err := os.MkdirAll(filepath.Dir(staged), 0700); err != nil {
+		return apperrors.Wrap("failed to create staging folder", err, staged)
+	}
+	if err := os.WriteFile(staged, []byte(content), 0600); err != nil {
+		return apperrors.Wrap("failed to write staged file", err, staged)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file := stagedFile{path: path, staged: staged, write: write}
+	if i, ok := s.index[abs]; ok {
+		s.files[i] = file
+		return nil
+	}
+	s.index[abs] = len(s.files)
+	s.files = append(s.files, file)
+	return nil
+}
+
+// read returns the content staged for path, and false when none is staged.
+func (s *Staging) read(path string) (string, bool, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false, apperrors.Wrap("failed to resolve output path", err, path)
+	}
+
+	s.mu.Lock()
+	i, ok := s.index[abs]
+	var staged string
+	if ok {
+		staged = s.files[i].staged
+	}
+	s.mu.Unlock()
+	if !ok {
+		return "", false, nil
+	}
+
+	content, err := os.ReadFile(staged)
+	if err != nil {
+		return "", false, apperrors.Wrap("failed to read staged file", err, staged)
+	}
+	return string(content), true, nil
+}
+
+// paths returns the output paths of the staged files.
+func (s *Staging) paths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	paths := make([]string, len(s.files))
+	for i, f := range s.files {
+		paths[i] = f.path
+	}
+	return paths
+}
+
+// mkdir records an output folder to create on commit.
+func (s *Staging) mkdir(dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !slices.Contains(s.dirs, dir) {
+		s.dirs = append(s.dirs, dir)
+	}
+}
+
+// stagedWriteFunc stages the files written by writeFunc when a staging is found in ctx.
+func stagedWriteFunc(ctx context.Context, writeFunc func(string, string) error) func(string, string) error {
+	s := ActionHooksFromContext(ctx).Staging
+	if s == nil {
+		return writeFunc
+	}
+	return func(path, content string) error {
+		return s.stage(path, content, writeFunc)
+	}
+}
+
+// ensureOutputDir creates the output folder dir, records it when staging or does
+// nothing when previewing the overwrites.
+func ensureOutputDir(ctx context.Context, dir string) error {
+	hooks := ActionHooksFromContext(ctx)
+	if hooks.Preview != nil {
+		return nil
+	}
+	if s := hooks.Staging; s != nil {
+		s.mkdir(dir)
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return apperrors.Wrap("failed to create destination directory", err, dir)
+	}
+	return nil
+}
+
+// snapshotPath records the content and mode of the file at path, if any, and the
+// folders missing to create it.
+func snapshotPath(path string) (committedFile, error) {
+	committed := committedFile{path: path, createdDirs: missingDirs(filepath.Dir(path))}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return committed, nil
+	}
+	if err != nil {
+		return committed, apperrors.Wrap("error checking output file", err, path)
+	}
+
+	backup, err := os.ReadFile(path)
+	if err != nil {
+		return committed, apperrors.Wrap("failed to read existing file", err, path)
+	}
+	committed.existed, committed.backup, committed.mode = true, backup, info.Mode().Perm()
+	return committed, nil
+}
+
+// missingDirs returns dir and its parents that do not exist yet, deepest first.
+func missingDirs(dir string) []string {
+	var missing []string
+	for {
+		if _, err := os.Stat(dir); !errors.Is(err, fs.ErrNotExist) {
+			return missing
+		}
+		missing = append(missing, dir)
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return missing
+		}
+		dir = parent
+	}
+}
+
+// rollback restores the committed files, in reverse order, and removes the folders
+// created for them. Errors are ignored: rollback is best effort after a failure.
+func rollback(done []committedFile) {
+	for i := len(done) - 1; i >= 0; i-- {
+		c := done[i]
+		switch {
+		case c.path == "":
+		case c.existed:
+			_ = os.WriteFile(c.path, c.backup, c.mode)
+			_ = os.Chmod(c.path, c.mode)
+		default:
+			_ = os.Remove(c.path)
+		}
+		for _, dir := range c.createdDirs {
+			_ = os.Remove(dir) // Only removes empty folders
+		}
+	}
+}
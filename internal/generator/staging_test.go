@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestProcessActions_StagesUntilAllSucceed(t *testing.T) {
+	useRealActionHandlers(t)
+
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	testutils.CreateFile(t, filepath.Join(templatesDir, "hello.gotxt"), "Hello {{ .ComponentName }}")
+	data := &TemplateData{TemplatesDir: templatesDir, ComponentName: "button"}
+
+	firstPath := filepath.Join(tempDir, "out", "button", "first.txt")
+	actions := []Action{
+		{Type: RenderActionID, Item: "file", TemplateFile: "hello.gotxt", Path: firstPath},
+		{Type: RenderActionID, Item: "file", TemplateFile: "missing.gotxt", Path: filepath.Join(tempDir, "out", "button", "second.txt")},
+	}
+
+	t.Run("Failing action", func(t *testing.T) {
+		if err := ProcessActions(context.Background(), &testutils.MockLogger{}, actions, data); err == nil {
+			t.Fatal("Expected an error for the missing template")
+		}
+		if _, err := os.Stat(filepath.Join(tempDir, "out")); !os.IsNotExist(err) {
+			t.Errorf("Expected no output written when an action fails")
+		}
+	})
+
+	t.Run("All actions succeed", func(t *testing.T) {
+		if err := ProcessActions(context.Background(), &testutils.MockLogger{}, actions[:1], data); err != nil {
+			t.Fatalf("ProcessActions returned error: %v", err)
+		}
+		if content, _ := os.ReadFile(firstPath); string(content) != "Hello button" {
+			t.Errorf("Expected the staged file to be written, got %q", content)
+		}
+	})
+}
+
+func TestStaging_CommitRollback(t *testing.T) {
+	tempDir := t.TempDir()
+	existingPath := filepath.Join(tempDir, "existing.txt")
+	newPath := filepath.Join(tempDir, "new", "file.txt")
+	testutils.CreateFile(t, existingPath, "original")
+
+	staging, err := NewStaging()
+	if err != nil {
+		t.Fatalf("NewStaging returned error: %v", err)
+	}
+	defer func() { _ = staging.Discard() }()
+
+	write := func(path, content string) error {
+		return os.WriteFile(path, []byte(content), 0644)
+	}
+	failingWrite := func(string, string) error { return errors.New("disk full") }
+
+	for _, f := range []struct {
+		path  string
+		write func(string, string) error
+	}{
+		{existingPath, write},
+		{newPath, write},
+		{filepath.Join(tempDir, "failing.txt"), failingWrite},
+	} {
+		if err := staging.stage(f.path, "updated", f.write); err != nil {
+			t.Fatalf("stage returned error: %v", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if err := os.Remove(filepath.Dir(newPath)); err != nil {
+		t.Fatalf("Failed to remove folder: %v", err)
+	}
+
+	if err := staging.Commit(); err == nil {
+		t.Fatal("Expected the commit to fail")
+	}
+
+	if content, _ := os.ReadFile(existingPath); string(content) != "original" {
+		t.Errorf("Expected the existing file to be restored, got %q", content)
+	}
+	if _, err := os.Stat(filepath.Dir(newPath)); !os.IsNotExist(err) {
+		t.Errorf("Expected the created folder to be removed on rollback")
+	}
+}
@@ -1,32 +1,133 @@
 package generator
 
+import (
+	"cmp"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/rendercache"
+)
+
 // TemplateData represents the data used to populate templates during file generation.
 //
 // Fields:
 // - TemplatesDir: The root directory containing template files.
 // - ActionsDir: The root directory containing actions files.
 // - GoModule: The name of the Go module being worked on.
+// - GoModuleOptional: If true, the project does not require a Go module and templates using GoModule fail when it is unknown.
 // - GoPackage: The Go package name where components will be organized and generated.
 // - ComponentName: The name of the component being generated.
+// - ComponentImportPath: The import path of the component folder, the Go module followed by the
+// path of the folder in the module (see SetComponentImportPath). Empty when no Go module is known.
 // - VariantName: The name of the variant being generated (if applicable).
+// - TagName: The custom element tag name for web components (if applicable).
 // - AssetsDir: The directory where asset files (e.g., CSS, JS) will be generated.
+// - StylesDir, VariantsDir, ScriptsDir: The folders of the CSS, variant and JS files in the component
+// folders, from the layout config (see SetLayout).
 // - WithJs: Indicates whether or not JavaScript is required for the component.
+// - WithTsDecls: Indicates whether a TypeScript declaration stub is generated next to the JS asset.
 // - CssLayer: The name of the CSS layer to associate with component styles.
 // - GuardMarker: A text placeholder or sentinel used in template files to mark auto-generated sections.
+// - Watermark: The template of the comment added on top of rendered files (empty to disable).
+// - Header: The license or banner header prepended by the header actions, from the templates.header config.
 // - Force: If true, existing files will be overwritten without prompting for confirmation.
 // - DryRun: If true, no files will be written; instead, the process will simulate changes and display what would happen.
+// - CLIUserData: The user data given with the --data-file and --data flags. It is merged into UserData and
+// applied again over the user data file of the entity template folder, so it always takes precedence.
+// - Props: The props declared in the props schema file of the entity template folder (see PropsSchemaFile).
+// - Markup, Styles: The templ markup and the CSS converted from the HTML snippet given with
+// `tempo component new --from-html`, not indented (see the indent template function).
+// - FileModes: The permissions of the rendered files, from the file_modes config.
+// - TemplateExtensions: The templating extensions removed from the files of the rendered folders and
+// given to the copied built-in templates, from the templates.extensions config (defaults when empty).
+// - Entity: The entity type generated by 'tempo new <entity>', declared in the entities config.
+// - Flags: The values of the flags declared by the entity type, keyed by flag name.
+// - RenderCache: The on-disk cache of the rendered templates, nil when templates.render_cache is disabled.
+// - Templates: The file system of the rendered templates, rooted at TemplatesDir, e.g. the layers set by
+// SetTemplateLayers. Nil reads TemplatesDir from the disk.
+// - Defaults: The file system of the built-in templates copied by the copy actions, nil for the embedded ones.
+//
+// The yaml tags name the fields in the template fixtures (see LoadTemplateFixtures).
 type TemplateData struct {
-	TemplatesDir  string
-	ActionsDir    string
-	GoModule      string
-	GoPackage     string
-	ComponentName string
-	VariantName   string
-	AssetsDir     string
-	WithJs        bool
-	CssLayer      string //nolint:revive // matches config field name
-	GuardMarker   string
-	Force         bool
-	DryRun        bool
-	UserData      map[string]any
+	TemplatesDir        string             `yaml:"templates_dir"`
+	ActionsDir          string             `yaml:"actions_dir"`
+	GoModule            string             `yaml:"go_module"`
+	GoModuleOptional    bool               `yaml:"go_module_optional"`
+	GoPackage           string             `yaml:"go_package"`
+	ComponentName       string             `yaml:"component_name"`
+	ComponentImportPath string             `yaml:"component_import_path"`
+	VariantName         string             `yaml:"variant_name"`
+	TagName             string             `yaml:"tag_name"`
+	AssetsDir           string             `yaml:"assets_dir"`
+	StylesDir           string             `yaml:"styles_dir"`
+	VariantsDir         string             `yaml:"variants_dir"`
+	ScriptsDir          string             `yaml:"scripts_dir"`
+	WithJs              bool               `yaml:"with_js"`
+	WithTsDecls         bool               `yaml:"with_ts_decls"`
+	CssLayer            string             `yaml:"css_layer"` //nolint:revive // matches config field name
+	GuardMarker         string             `yaml:"guard_marker"`
+	Watermark           string             `yaml:"watermark"`
+	Force               bool               `yaml:"force"`
+	DryRun              bool               `yaml:"dry_run"`
+	UserData            map[string]any     `yaml:"user_data"`
+	CLIUserData         map[string]any     `yaml:"-" json:"-"`
+	Props               []Prop             `yaml:"props"`
+	Markup              string             `yaml:"markup,omitempty"`
+	Styles              string             `yaml:"styles,omitempty"`
+	Header              config.Header      `yaml:"-" json:"-"`
+	FileModes           config.FileModes   `yaml:"-" json:"-"`
+	TemplateExtensions  []string           `yaml:"-" json:"-"`
+	Entity              string             `yaml:"entity"`
+	Flags               map[string]any     `yaml:"flags"`
+	RenderCache         *rendercache.Cache `yaml:"-" json:"-"`
+	Templates           fs.FS              `yaml:"-" json:"-"`
+	Defaults            fs.FS              `yaml:"-" json:"-"`
+}
+
+// SetLayout sets the folders of the component layout that are not set yet, e.g. by a
+// recorded generation, from layout.
+func (d *TemplateData) SetLayout(layout config.Layout) {
+	d.StylesDir = cmp.Or(d.StylesDir, layout.Styles())
+	d.VariantsDir = cmp.Or(d.VariantsDir, layout.Variants())
+	d.ScriptsDir = cmp.Or(d.ScriptsDir, layout.Scripts())
+}
+
+// SetComponentImportPath sets ComponentImportPath to the import path of the component
+// folder, GoPackage/ComponentName, in the Go module named moduleName rooted at moduleRoot.
+// GoModule, when set, must match moduleName so that the rendered imports compile.
+func (d *TemplateData) SetComponentImportPath(moduleRoot, moduleName string) error {
+	if d.GoModule != "" && d.GoModule != moduleName {
+		return apperrors.Wrap("app.go_module %s does not match the module %s declared in go.mod; update the config file", d.GoModule, moduleName)
+	}
+
+	importPath, err := ComponentImportPath(moduleRoot, moduleName, filepath.Join(d.GoPackage, d.ComponentName))
+	if err != nil {
+		return err
+	}
+	d.ComponentImportPath = importPath
+	return nil
+}
+
+// ComponentImportPath returns the import path of the folder dir in the Go module named
+// moduleName rooted at moduleRoot. It fails when dir is outside of the module.
+func ComponentImportPath(moduleRoot, moduleName, dir string) (string, error) {
+	absRoot, err := filepath.Abs(moduleRoot)
+	if err != nil {
+		return "", apperrors.Wrap("failed to resolve the module root", err, moduleRoot)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", apperrors.Wrap("failed to resolve the component folder", err, dir)
+	}
+	rel, err := filepath.Rel(absRoot, absDir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", apperrors.Wrap("component folder %s is outside of the Go module %s", dir, moduleRoot)
+	}
+	if rel == "." {
+		return strings.TrimSuffix(moduleName, "/"), nil
+	}
+	return strings.TrimSuffix(moduleName, "/") + "/" + filepath.ToSlash(rel), nil
 }
@@ -1,7 +1,10 @@
 package generator
 
 import (
+	"path/filepath"
 	"testing"
+
+	"github.com/indaco/tempo/internal/config"
 )
 
 func TestTemplateDataInitialization(t *testing.T) {
@@ -82,3 +85,57 @@ func TestTemplateDataBooleanFlags(t *testing.T) {
 		t.Errorf("Expected DryRun to be true, got false")
 	}
 }
+
+func TestTemplateData_SetComponentImportPath(t *testing.T) {
+	moduleRoot := t.TempDir()
+
+	tests := []struct {
+		name      string
+		data      TemplateData
+		want      string
+		expectErr bool
+	}{
+		{
+			name: "nested package",
+			data: TemplateData{GoPackage: filepath.Join(moduleRoot, "ui", "custom-package"), ComponentName: "button"},
+			want: "example.com/app/ui/custom-package/button",
+		},
+		{
+			name: "matching go module",
+			data: TemplateData{GoModule: "example.com/app", GoPackage: filepath.Join(moduleRoot, "components"), ComponentName: "card"},
+			want: "example.com/app/components/card",
+		},
+		{
+			name:      "go module mismatch",
+			data:      TemplateData{GoModule: "example.com/other", GoPackage: filepath.Join(moduleRoot, "components"), ComponentName: "card"},
+			expectErr: true,
+		},
+		{
+			name:      "outside of the module",
+			data:      TemplateData{GoPackage: filepath.Dir(moduleRoot), ComponentName: "card"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.data.SetComponentImportPath(moduleRoot, "example.com/app")
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("SetComponentImportPath() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.data.ComponentImportPath != tt.want {
+				t.Errorf("Expected import path %q, got %q", tt.want, tt.data.ComponentImportPath)
+			}
+		})
+	}
+}
+
+func TestTemplateData_SetLayout(t *testing.T) {
+	// The recorded folders are kept, the others come from the layout or its defaults
+	data := TemplateData{StylesDir: "styles"}
+	data.SetLayout(config.Layout{StylesDir: "css", ScriptsDir: "scripts"})
+
+	if data.StylesDir != "styles" || data.VariantsDir != config.DefaultVariantsDir || data.ScriptsDir != "scripts" {
+		t.Errorf("Unexpected layout: styles %q, variants %q, scripts %q", data.StylesDir, data.VariantsDir, data.ScriptsDir)
+	}
+}
@@ -0,0 +1,229 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES & CONSTANTS                                                         */
+/* ------------------------------------------------------------------------- */
+
+// TemplateVariable describes a variable available inside the action templates.
+type TemplateVariable struct {
+	Name        string   `json:"name"` // e.g. ".ComponentName" or ".UserData.author"
+	Type        string   `json:"type"`
+	Sample      string   `json:"sample"`
+	Description string   `json:"description"`
+	UsedIn      []string `json:"used_in,omitempty"` // Template files referencing the variable
+}
+
+// templateDataDescriptions documents the TemplateData fields, keyed by field name.
+var templateDataDescriptions = map[string]string{
+	"TemplatesDir":        "The root directory containing template files",
+	"ActionsDir":          "The root directory containing actions files",
+	"GoModule":            "The name of the Go module being worked on",
+	"GoModuleOptional":    "Whether the project runs without a Go module (app.require_go_module: false)",
+	"GoPackage":           "The Go package name where components are generated",
+	"ComponentName":       "The name of the component being generated",
+	"ComponentImportPath": "The import path of the component folder in the Go module, e.g. example.com/app/components/button",
+	"VariantName":         "The name of the variant being generated (variants only)",
+	"TagName":             "The custom element tag name (web components only)",
+	"AssetsDir":           "The directory where asset files (CSS, JS) are generated",
+	"StylesDir":           "The folder of the CSS files in the component folders, from layout.styles_dir",
+	"VariantsDir":         "The folder of the variant files in the component folders, from layout.variants_dir",
+	"ScriptsDir":          "The folder of the JS files in the component folders, from layout.scripts_dir",
+	"WithJs":              "Whether JavaScript is required for the component",
+	"WithTsDecls":         "Whether a TypeScript declaration stub is generated next to the JS asset",
+	"CssLayer":            "The name of the CSS layer associated with component styles",
+	"GuardMarker":         "The marker delimiting the auto-generated sections",
+	"Watermark":           "The template of the comment added on top of rendered files",
+	"Force":               "Whether existing files are overwritten",
+	"DryRun":              "Whether the run only previews the changes",
+	"UserData":            "The user-defined values from the templates.user_data config, the .tempo-data.yaml of the entity template folder and the --data-file and --data flags",
+	"Props":               "The props (name, type, default, description) from the .tempo-props.yaml of the entity template folder",
+	"Markup":              "The templ markup converted from the HTML snippet of --from-html (component new only)",
+	"Styles":              "The CSS of the <style> elements and inline styles of the --from-html snippet (component new only)",
+	"Entity":              "The entity type declared in the entities config (tempo new <entity> only)",
+	"Flags":               "The values of the flags declared by the entity type (tempo new <entity> only)",
+}
+
+// templateActionRe matches the actions of Go and handlebars templates.
+var templateActionRe = regexp.MustCompile(`\{\{(.*?)\}\}`)
+
+// templateFieldRe matches the field references inside a template action.
+var templateFieldRe = regexp.MustCompile(`(?:^|[^\w.])\.?([A-Z]\w*(?:\.\w+)*)`)
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// DescribeTemplateVariables lists the TemplateData fields and the flattened UserData keys
+// with the values of sample. Fields tagged `yaml:"-"` are internal and skipped. References maps variable names to the template files using
+// them (see FindTemplateReferences); referenced UserData keys missing from sample are
+// listed too, with the "undefined" type.
+func DescribeTemplateVariables(sample *TemplateData, references map[string][]string) []TemplateVariable {
+	var vars []TemplateVariable
+
+	value := reflect.ValueOf(*sample)
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if field.Name == "UserData" || field.Tag.Get("yaml") == "-" {
+			continue
+		}
+		name := "." + field.Name
+		vars = append(vars, TemplateVariable{
+			Name:        name,
+			Type:        field.Type.String(),
+			Sample:      fmt.Sprint(value.Field(i).Interface()),
+			Description: templateDataDescriptions[field.Name],
+			UsedIn:      references[name],
+		})
+	}
+
+	userData := make(map[string]any)
+	flattenUserData(".UserData", sample.UserData, userData)
+	for _, name := range slices.Sorted(maps.Keys(userData)) {
+		vars = append(vars, TemplateVariable{
+			Name:        name,
+			Type:        valueType(userData[name]),
+			Sample:      fmt.Sprint(userData[name]),
+			Description: "User-defined value",
+			UsedIn:      references[name],
+		})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(references)) {
+		if !strings.HasPrefix(name, ".UserData.") || hasUserDataKey(userData, name) {
+			continue
+		}
+		vars = append(vars, TemplateVariable{
+			Name:        name,
+			Type:        "undefined",
+			Description: "Referenced in templates but not set in templates.user_data",
+			UsedIn:      references[name],
+		})
+	}
+
+	return vars
+}
+
+// FindTemplateReferences scans the template files of templatesDir with the given
+// extensions and maps each referenced TemplateData field or UserData key (e.g.
+// ".ComponentName", ".UserData.author") to the sorted files using it.
+func FindTemplateReferences(templatesDir string, extensions []string) (map[string][]string, error) {
+	fields := make(map[string]bool)
+	for _, field := range reflect.VisibleFields(reflect.TypeFor[TemplateData]()) {
+		fields[field.Name] = true
+	}
+
+	references := make(map[string][]string)
+	err := filepath.WalkDir(templatesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == templatesDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || !slices.Contains(extensions, filepath.Ext(path)) {
+			return nil
+		}
+
+		content, err := utils.ReadFileAsString(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(templatesDir, path)
+		if err != nil {
+			rel = path
+		}
+		rel = filepath.ToSlash(rel)
+
+		for _, name := range templateReferences(content, fields) {
+			if !slices.Contains(references[name], rel) {
+				references[name] = append(references[name], rel)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to scan templates", err, templatesDir)
+	}
+
+	for name := range references {
+		slices.Sort(references[name])
+	}
+	return references, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// templateReferences returns the TemplateData fields and UserData keys referenced
+// in the actions of the template content.
+func templateReferences(content string, fields map[string]bool) []string {
+	var names []string
+	for _, action := range templateActionRe.FindAllStringSubmatch(content, -1) {
+		for _, match := range templateFieldRe.FindAllStringSubmatch(action[1], -1) {
+			parts := strings.Split(match[1], ".")
+			switch {
+			case parts[0] == "UserData" && len(parts) > 1:
+				names = append(names, "."+match[1])
+			case fields[parts[0]]:
+				names = append(names, "."+parts[0])
+			}
+		}
+	}
+	return names
+}
+
+// flattenUserData adds the leaf values of data to out, keyed by their dotted path.
+func flattenUserData(prefix string, data map[string]any, out map[string]any) {
+	for key, value := range data {
+		name := prefix + "." + key
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			flattenUserData(name, nested, out)
+			continue
+		}
+		out[name] = value
+	}
+}
+
+// hasUserDataKey reports whether name is a flattened key or the parent of one.
+func hasUserDataKey(userData map[string]any, name string) bool {
+	if _, ok := userData[name]; ok {
+		return true
+	}
+	for key := range userData {
+		if strings.HasPrefix(key, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// valueType returns a readable type name for a YAML value.
+func valueType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "map"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
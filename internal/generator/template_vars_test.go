@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestFindTemplateReferences(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"component/templ/component.templ.gotxt": "package {{ .ComponentName | goPackageName }}\n// {{ .UserData.author }}",
+		"component/assets/css/base.css.gotxt":   "{{- if isEmpty .CssLayer -}}\n:root {}\n{{- end -}}",
+		"webcomponent/element.js.hbs":           "customElements.define('{{TagName}}', {{UserData.config.prefix}});",
+		"component/README.md":                   "{{ .VariantName }} is ignored",
+	}
+	for path, content := range files {
+		testutils.CreateFile(t, filepath.Join(tempDir, path), content)
+	}
+
+	references, err := FindTemplateReferences(tempDir, []string{".gotxt", ".hbs"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string][]string{
+		".ComponentName":          {"component/templ/component.templ.gotxt"},
+		".UserData.author":        {"component/templ/component.templ.gotxt"},
+		".CssLayer":               {"component/assets/css/base.css.gotxt"},
+		".TagName":                {"webcomponent/element.js.hbs"},
+		".UserData.config.prefix": {"webcomponent/element.js.hbs"},
+	}
+	if len(references) != len(expected) {
+		t.Fatalf("Expected %d references, got %v", len(expected), references)
+	}
+	for name, want := range expected {
+		if !slices.Equal(references[name], want) {
+			t.Errorf("Expected %s to be used in %v, got %v", name, want, references[name])
+		}
+	}
+}
+
+func TestFindTemplateReferences_MissingDir(t *testing.T) {
+	references, err := FindTemplateReferences(filepath.Join(t.TempDir(), "missing"), []string{".gotxt"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(references) != 0 {
+		t.Errorf("Expected no references, got %v", references)
+	}
+}
+
+func TestDescribeTemplateVariables(t *testing.T) {
+	sample := &TemplateData{
+		GoModule:      "github.com/example/project",
+		ComponentName: "button",
+		WithJs:        true,
+		UserData: map[string]any{
+			"author": "Jane Doe",
+			"year":   2025,
+			"config": map[string]any{"theme": "dark"},
+		},
+	}
+	references := map[string][]string{
+		".ComponentName":   {"component/templ/component.templ.gotxt"},
+		".UserData.author": {"component/templ/component.templ.gotxt"},
+		".UserData.email":  {"component/templ/component.templ.gotxt"},
+		".UserData.config": {"component/assets/css/base.css.gotxt"},
+	}
+
+	vars := DescribeTemplateVariables(sample, references)
+
+	byName := make(map[string]TemplateVariable, len(vars))
+	for _, v := range vars {
+		byName[v.Name] = v
+	}
+
+	if _, ok := byName[".UserData"]; ok {
+		t.Errorf("Expected UserData to be flattened")
+	}
+
+	checks := []TemplateVariable{
+		{Name: ".ComponentName", Type: "string", Sample: "button"},
+		{Name: ".WithJs", Type: "bool", Sample: "true"},
+		{Name: ".UserData.author", Type: "string", Sample: "Jane Doe"},
+		{Name: ".UserData.year", Type: "int", Sample: "2025"},
+		{Name: ".UserData.config.theme", Type: "string", Sample: "dark"},
+		{Name: ".UserData.email", Type: "undefined"},
+	}
+	for _, want := range checks {
+		got, ok := byName[want.Name]
+		if !ok {
+			t.Errorf("Expected variable %s", want.Name)
+			continue
+		}
+		if got.Type != want.Type || got.Sample != want.Sample {
+			t.Errorf("Expected %s to be %s (%q), got %s (%q)", want.Name, want.Type, want.Sample, got.Type, got.Sample)
+		}
+	}
+
+	if used := byName[".ComponentName"].UsedIn; len(used) != 1 {
+		t.Errorf("Expected .ComponentName to be used in one file, got %v", used)
+	}
+	if byName[".GoModule"].Description == "" {
+		t.Errorf("Expected a description for .GoModule")
+	}
+	if _, ok := byName[".UserData.config"]; ok {
+		t.Errorf("Expected .UserData.config not to be reported as undefined")
+	}
+	for _, internal := range []string{".CLIUserData", ".Header", ".TemplateExtensions", ".RenderCache", ".Templates", ".Defaults"} {
+		if _, ok := byName[internal]; ok {
+			t.Errorf("Expected the internal field %s to be skipped", internal)
+		}
+	}
+	for _, v := range vars {
+		if v.Description == "" {
+			t.Errorf("Expected a description for %s", v.Name)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// TraceWrite describes a single file written while executing an action.
+type TraceWrite struct {
+	Path  string `json:"path"`
+	Bytes int    `json:"bytes"`
+}
+
+// TraceEntry holds the tracing details collected for a single action.
+type TraceEntry struct {
+	Type        string       `json:"type"`
+	Item        string       `json:"item"`
+	Template    string       `json:"template,omitempty"`
+	Destination string       `json:"destination,omitempty"`
+	Bytes       int          `json:"bytes"`
+	Elapsed     string       `json:"elapsed"`
+	Writes      []TraceWrite `json:"writes,omitempty"`
+	Error       string       `json:"error,omitempty"`
+	start       time.Time
+}
+
+// Tracer collects per-action trace entries while actions are processed.
+// When a logger is set, each completed entry is also logged.
+type Tracer struct {
+	logger  logger.Logger
+	entries []TraceEntry
+	current *TraceEntry
+	mu      sync.Mutex
+}
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTOR                                                               */
+/* ------------------------------------------------------------------------- */
+
+// NewTracer creates a Tracer. Pass a nil logger to collect entries silently.
+func NewTracer(log logger.Logger) *Tracer {
+	return &Tracer{logger: log}
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// Entries returns a copy of the collected trace entries.
+func (t *Tracer) Entries() []TraceEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entries := make([]TraceEntry, len(t.entries))
+	copy(entries, t.entries)
+	return entries
+}
+
+// WriteJSON exports the collected trace entries to a JSON file.
+func (t *Tracer) WriteJSON(filePath string) error {
+	if err := utils.WriteJSONToFile(filePath, t.Entries()); err != nil {
+		return apperrors.Wrap("failed to write trace file", err, filePath)
+	}
+	return nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// begin starts tracing a new action.
+func (t *Tracer) begin(action Action, data *TemplateData) {
+	entry := &TraceEntry{
+		Type:  action.Type,
+		Item:  action.Item,
+		start: time.Now(),
+	}
+
+	switch action.Item {
+	case "file":
+		entry.Template = action.TemplateFile
+		entry.Destination = renderOrRaw(action.Path, data)
+	case "folder":
+		entry.Template = action.Source
+		entry.Destination = renderOrRaw(action.Destination, data)
+	}
+
+	t.mu.Lock()
+	t.current = entry
+	t.mu.Unlock()
+}
+
+// recordWrite attaches a written file to the action currently being traced.
+func (t *Tracer) recordWrite(path string, size int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.current == nil {
+		return
+	}
+	t.current.Writes = append(t.current.Writes, TraceWrite{Path: path, Bytes: size})
+	t.current.Bytes += size
+}
+
+// end completes the action currently being traced and logs it if a logger is set.
+func (t *Tracer) end(err error) {
+	t.mu.Lock()
+	entry := t.current
+	t.current = nil
+	if entry == nil {
+		t.mu.Unlock()
+		return
+	}
+	entry.Elapsed = time.Since(entry.start).String()
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	t.entries = append(t.entries, *entry)
+	t.mu.Unlock()
+
+	if t.logger == nil {
+		return
+	}
+
+	t.logger.Info(fmt.Sprintf("Trace: %s %s", entry.Type, entry.Item)).
+		WithAttrs(
+			"template", entry.Template,
+			"destination", entry.Destination,
+			"elapsed", entry.Elapsed,
+			"bytes", entry.Bytes,
+		)
+}
+
+// tracedWriteFunc wraps writeFunc so that successful writes are recorded
+// on the tracer found in ctx, if any.
+func tracedWriteFunc(ctx context.Context, writeFunc func(string, string) error) func(string, string) error {
+	t := ActionHooksFromContext(ctx).Tracer
+	if t == nil {
+		return writeFunc
+	}
+
+	return func(path, content string) error {
+		if err := writeFunc(path, content); err != nil {
+			return err
+		}
+		t.recordWrite(path, len(content))
+		return nil
+	}
+}
+
+// renderOrRaw renders a template string, falling back to the raw value on error.
+func renderOrRaw(value string, data *TemplateData) string {
+	rendered, err := utils.RenderTemplate(value, data)
+	if err != nil {
+		return value
+	}
+	return rendered
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestProcessActions_WithTracer(t *testing.T) {
+	useRealActionHandlers(t)
+
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	if err := os.MkdirAll(templatesDir, 0755); err != nil {
+		t.Fatalf("Failed to create templates dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templatesDir, "hello.gotxt"), []byte("Hello {{ .ComponentName }}"), 0644); err != nil {
+		t.Fatalf("Failed to create template file: %v", err)
+	}
+
+	actions := []Action{
+		{
+			Type:         RenderActionID,
+			Item:         "file",
+			TemplateFile: "hello.gotxt",
+			Path:         filepath.Join(tempDir, "out", "{{ .ComponentName }}.txt"),
+		},
+	}
+	data := &TemplateData{TemplatesDir: templatesDir, ComponentName: "button"}
+
+	mockLogger := &testutils.MockLogger{}
+	tracer := NewTracer(mockLogger)
+	ctx := WithActionHooks(context.Background(), func(h *ActionHooks) { h.Tracer = tracer })
+
+	if err := ProcessActions(ctx, mockLogger, actions, data); err != nil {
+		t.Fatalf("ProcessActions returned error: %v", err)
+	}
+
+	entries := tracer.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 trace entry, got %d", len(entries))
+	}
+
+	entry := entries[0]
+	expectedDest := filepath.Join(tempDir, "out", "button.txt")
+	if entry.Destination != expectedDest {
+		t.Errorf("Expected destination %q, got %q", expectedDest, entry.Destination)
+	}
+	if entry.Template != "hello.gotxt" {
+		t.Errorf("Expected template %q, got %q", "hello.gotxt", entry.Template)
+	}
+	if entry.Bytes != len("Hello button") {
+		t.Errorf("Expected %d bytes, got %d", len("Hello button"), entry.Bytes)
+	}
+	if len(entry.Writes) != 1 || entry.Writes[0].Path != expectedDest {
+		t.Errorf("Expected a single write to %q, got %+v", expectedDest, entry.Writes)
+	}
+	if entry.Elapsed == "" {
+		t.Errorf("Expected elapsed time to be set")
+	}
+
+	if len(mockLogger.Logs) != 1 || !strings.Contains(mockLogger.Logs[0], "Trace: render file") {
+		t.Errorf("Expected a trace log line, got %v", mockLogger.Logs)
+	}
+}
+
+func TestTracer_RecordsError(t *testing.T) {
+	tracer := NewTracer(nil)
+	tracer.begin(Action{Type: RenderActionID, Item: "file", Path: "out.txt"}, &TemplateData{})
+	tracer.end(errors.New("boom"))
+
+	entries := tracer.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 trace entry, got %d", len(entries))
+	}
+	if entries[0].Error != "boom" {
+		t.Errorf("Expected error %q, got %q", "boom", entries[0].Error)
+	}
+}
+
+func TestTracer_WriteJSON(t *testing.T) {
+	tracer := NewTracer(nil)
+	tracer.begin(Action{Type: RenderActionID, Item: "folder", Source: "src", Destination: "dest"}, &TemplateData{})
+	tracer.recordWrite("dest/a.css", 10)
+	tracer.recordWrite("dest/b.css", 5)
+	tracer.end(nil)
+
+	traceFile := filepath.Join(t.TempDir(), "trace.json")
+	if err := tracer.WriteJSON(traceFile); err != nil {
+		t.Fatalf("WriteJSON returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(traceFile)
+	if err != nil {
+		t.Fatalf("Failed to read trace file: %v", err)
+	}
+
+	var entries []TraceEntry
+	if err := json.Unmarshal(content, &entries); err != nil {
+		t.Fatalf("Failed to parse trace file: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 trace entry, got %d", len(entries))
+	}
+	if entries[0].Bytes != 15 || len(entries[0].Writes) != 2 {
+		t.Errorf("Unexpected trace entry: %+v", entries[0])
+	}
+	if entries[0].Destination != "dest" || entries[0].Template != "src" {
+		t.Errorf("Unexpected folder source/destination: %+v", entries[0])
+	}
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"os"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadUserDataFile reads the YAML user data file at path, e.g. the one given with
+// --data-file.
+func LoadUserDataFile(path string) (map[string]any, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, apperrors.Wrap("failed to read user data file", err, path)
+	}
+
+	var userData map[string]any
+	if err := yaml.Unmarshal(content, &userData); err != nil {
+		return nil, apperrors.Wrap("failed to parse user data file", err, path)
+	}
+	return userData, nil
+}
+
+// ParseUserDataArgs parses "key=value" arguments, e.g. the ones given with --data,
+// into user data. Dotted keys set nested values ("theme.color=red") and values are
+// parsed as YAML scalars, so "true" and "3" are a bool and an int. Later arguments
+// override earlier ones.
+func ParseUserDataArgs(args []string) (map[string]any, error) {
+	userData := make(map[string]any)
+	for _, arg := range args {
+		key, raw, found := strings.Cut(arg, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, apperrors.Wrap("invalid user data %q: expected key=value", arg)
+		}
+
+		value := parseUserDataValue(raw)
+		parts := strings.Split(key, ".")
+		for i := len(parts) - 1; i > 0; i-- {
+			value = map[string]any{parts[i]: value}
+		}
+		userData = MergeUserData(userData, map[string]any{parts[0]: value})
+	}
+	return userData, nil
+}
+
+// parseUserDataValue returns raw parsed as a YAML scalar, or as is when it is not one
+// (e.g. "a: b" or "null").
+func parseUserDataValue(raw string) any {
+	var parsed any
+	if err := yaml.Unmarshal([]byte(raw), &parsed); err != nil {
+		return raw
+	}
+	switch parsed.(type) {
+	case bool, int, float64, string:
+		return parsed
+	default:
+		return raw
+	}
+}
+
+// ResolveCLIUserData returns the user data given on the command line: the values of
+// dataFile (if set) with the args (see ParseUserDataArgs) deep-merged over them.
+// It returns nil when neither is given.
+func ResolveCLIUserData(dataFile string, args []string) (map[string]any, error) {
+	if dataFile == "" && len(args) == 0 {
+		return nil, nil
+	}
+
+	var userData map[string]any
+	if dataFile != "" {
+		var err error
+		if userData, err = LoadUserDataFile(dataFile); err != nil {
+			return nil, err
+		}
+	}
+
+	argsData, err := ParseUserDataArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return MergeUserData(userData, argsData), nil
+}
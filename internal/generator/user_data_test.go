@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseUserDataArgs(t *testing.T) {
+	userData, err := ParseUserDataArgs([]string{
+		"author=Jane Doe",
+		"theme.color=red",
+		"theme.radius=4",
+		"rounded=true",
+		"tags=a,b",
+		"note=key: value",
+		"color=#fff",
+		"empty=",
+		"theme.color=blue",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]any{
+		"author":  "Jane Doe",
+		"theme":   map[string]any{"color": "blue", "radius": 4},
+		"rounded": true,
+		"tags":    "a,b",
+		"note":    "key: value",
+		"color":   "#fff",
+		"empty":   "",
+	}
+	if !reflect.DeepEqual(userData, expected) {
+		t.Errorf("Expected %v, got %v", expected, userData)
+	}
+
+	for _, arg := range []string{"author", "=value", " =value"} {
+		if _, err := ParseUserDataArgs([]string{arg}); err == nil {
+			t.Errorf("Expected an error for %q", arg)
+		}
+	}
+}
+
+func TestResolveCLIUserData(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "data.yaml", "author: John\ntheme:\n  color: green\n  radius: 2px\n")
+	dataFile := filepath.Join(dir, "data.yaml")
+
+	userData, err := ResolveCLIUserData("", nil)
+	if err != nil || userData != nil {
+		t.Errorf("Expected no user data without flags, got %v, %v", userData, err)
+	}
+
+	userData, err = ResolveCLIUserData(dataFile, []string{"theme.color=red"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]any{
+		"author": "John",
+		"theme":  map[string]any{"color": "red", "radius": "2px"},
+	}
+	if !reflect.DeepEqual(userData, expected) {
+		t.Errorf("Expected %v, got %v", expected, userData)
+	}
+
+	if _, err := ResolveCLIUserData(filepath.Join(dir, "missing.yaml"), nil); err == nil {
+		t.Error("Expected an error for a missing data file")
+	}
+	writeFixture(t, dir, "invalid.yaml", "theme: [")
+	if _, err := ResolveCLIUserData(filepath.Join(dir, "invalid.yaml"), nil); err == nil {
+		t.Error("Expected an error for an invalid data file")
+	}
+}
+
+func TestRenderAction_Execute_CLIUserDataPrecedence(t *testing.T) {
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	outputPath := filepath.Join(tempDir, "out", "button.css")
+
+	writeFixture(t, templatesDir, "button/"+EntityDataFile, "tokens:\n  radius: 4px\n  color: blue\n")
+	writeFixture(t, templatesDir, "button/name.css.gotxt",
+		"{{ .UserData.author }} {{ .UserData.tokens.radius }} {{ .UserData.tokens.color }}")
+
+	cliUserData := map[string]any{"tokens": map[string]any{"color": "red"}}
+	data := &TemplateData{
+		TemplatesDir: templatesDir,
+		UserData:     MergeUserData(map[string]any{"author": "Jane"}, cliUserData),
+		CLIUserData:  cliUserData,
+	}
+
+	action := Action{Item: "file", TemplateFile: "button/name.css.gotxt", Path: outputPath}
+	if err := (&RenderAction{}).Execute(context.Background(), action, data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", outputPath, err)
+	}
+	// config < .tempo-data.yaml < command line
+	if expected := "Jane 4px red"; string(content) != expected {
+		t.Errorf("Expected %q, got %q", expected, content)
+	}
+}
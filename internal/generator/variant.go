@@ -1,5 +1,15 @@
 package generator
 
+import (
+	"path"
+	"strings"
+)
+
+// VariantTemplatesDir is the folder of the variant templates, relative to the templates
+// folder. The templates of the variants of a single component are in a subfolder named
+// after it (see ComponentVariantTemplatesDir).
+const VariantTemplatesDir = "component-variant"
+
 // BuildVariantActions generates the list of actions required to scaffold a new variant
 // for an existing component.
 func BuildVariantActions(actionType string, force bool) ([]Action, error) {
@@ -8,13 +18,13 @@ func BuildVariantActions(actionType string, force bool) ([]Action, error) {
 			Type:         actionType,
 			Item:         "file",
 			TemplateFile: "component-variant/name.templ.gotxt",
-			Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/css/variants/{{ .VariantName | goUnexportedName }}.templ",
+			Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/{{ .VariantsDir }}/{{ .VariantName | goUnexportedName }}.templ",
 		},
 		{
 			Type:         actionType,
 			Item:         "file",
 			TemplateFile: "component-variant/assets/css/name.css.gotxt",
-			Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/css/variants/{{ .VariantName | goUnexportedName }}.css",
+			Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/{{ .VariantsDir }}/{{ .VariantName | goUnexportedName }}.css",
 		},
 	}
 
@@ -26,3 +36,33 @@ func BuildVariantActions(actionType string, force bool) ([]Action, error) {
 
 	return actions, nil
 }
+
+// ComponentVariantTemplatesDir returns the folder of the variant templates of component,
+// relative to the templates folder, e.g. component-variant/button.
+func ComponentVariantTemplatesDir(component string) string {
+	return path.Join(VariantTemplatesDir, component)
+}
+
+// VariantActionFileName returns the name of the action file of the variants: variant.json,
+// or variant-<component>.json for the variants of component when set.
+func VariantActionFileName(component string) string {
+	if component == "" {
+		return "variant.json"
+	}
+	return "variant-" + component + ".json"
+}
+
+// ScopeVariantActions returns a copy of the variant actions with their templates in the
+// folder of component (see ComponentVariantTemplatesDir). Source is set to the built-in
+// template, so that the copy actions scaffold the scoped folder.
+func ScopeVariantActions(actions []Action, component string) []Action {
+	scoped := make([]Action, len(actions))
+	for i, action := range actions {
+		if action.Item == "file" {
+			action.Source = action.TemplateFile
+			action.TemplateFile = path.Join(ComponentVariantTemplatesDir(component), strings.TrimPrefix(action.TemplateFile, VariantTemplatesDir+"/"))
+		}
+		scoped[i] = action
+	}
+	return scoped
+}
@@ -20,13 +20,13 @@ func TestBuildVariantActions(t *testing.T) {
 				Type:         actionType,
 				Item:         "file",
 				TemplateFile: "component-variant/name.templ.gotxt",
-				Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/css/variants/{{ .VariantName | goUnexportedName }}.templ",
+				Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/{{ .VariantsDir }}/{{ .VariantName | goUnexportedName }}.templ",
 			},
 			{
 				Type:         actionType,
 				Item:         "file",
 				TemplateFile: "component-variant/assets/css/name.css.gotxt",
-				Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/css/variants/{{ .VariantName | goUnexportedName }}.css",
+				Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/{{ .VariantsDir }}/{{ .VariantName | goUnexportedName }}.css",
 			},
 		}
 
@@ -55,14 +55,14 @@ func TestBuildVariantActions(t *testing.T) {
 				Type:         actionType,
 				Item:         "file",
 				TemplateFile: "component-variant/name.templ.gotxt",
-				Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/css/variants/{{ .VariantName | goUnexportedName }}.templ",
+				Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/{{ .VariantsDir }}/{{ .VariantName | goUnexportedName }}.templ",
 				Force:        true,
 			},
 			{
 				Type:         actionType,
 				Item:         "file",
 				TemplateFile: "component-variant/assets/css/name.css.gotxt",
-				Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/css/variants/{{ .VariantName | goUnexportedName }}.css",
+				Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/{{ .VariantsDir }}/{{ .VariantName | goUnexportedName }}.css",
 				Force:        true,
 			},
 		}
@@ -0,0 +1,39 @@
+package generator
+
+// BuildWebComponentActions generates the list of actions required to scaffold a new
+// web component (custom element) together with its templ wrapper.
+func BuildWebComponentActions(actionType string, force bool) ([]Action, error) {
+	actions := []Action{
+		// [Templ] - Wrapper emitting the custom element tag
+		{
+			Type:         actionType,
+			Item:         "file",
+			TemplateFile: "webcomponent/templ/element.templ.gotxt",
+			Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/{{ .ComponentName | goPackageName }}.templ",
+		},
+
+		// [JS] - Custom element class
+		{
+			Type:         actionType,
+			Item:         "file",
+			TemplateFile: "webcomponent/assets/js/element.js.gotxt",
+			Path:         "{{ .AssetsDir }}/{{ .ComponentName | goPackageName }}/{{ .ScriptsDir }}/element.js",
+		},
+
+		// [JS] - Templ script block the asset is synced into
+		{
+			Type:         actionType,
+			Item:         "file",
+			TemplateFile: "webcomponent/templ/js/element.templ.gotxt",
+			Path:         "{{ .GoPackage }}/{{ .ComponentName | goPackageName }}/{{ .ScriptsDir }}/element.templ",
+		},
+	}
+
+	if force {
+		for i := range actions {
+			actions[i].Force = true
+		}
+	}
+
+	return actions, nil
+}
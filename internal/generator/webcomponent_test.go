@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestBuildWebComponentActions(t *testing.T) {
+	actionType := "render"
+
+	t.Run("GenerateWebComponentActions", func(t *testing.T) {
+		actions, err := BuildWebComponentActions(actionType, false)
+		if err != nil {
+			t.Fatalf("BuildWebComponentActions() returned an error: %v", err)
+		}
+
+		expectedTemplates := []string{
+			"webcomponent/templ/element.templ.gotxt",
+			"webcomponent/assets/js/element.js.gotxt",
+			"webcomponent/templ/js/element.templ.gotxt",
+		}
+
+		if len(actions) != len(expectedTemplates) {
+			t.Fatalf("BuildWebComponentActions() = %d actions; want %d actions", len(actions), len(expectedTemplates))
+		}
+
+		for i, action := range actions {
+			if action.Type != actionType || action.Item != "file" {
+				t.Errorf("Action[%d] has unexpected type/item: %v", i, action)
+			}
+			if action.TemplateFile != expectedTemplates[i] {
+				t.Errorf("Action[%d].TemplateFile = %s; want %s", i, action.TemplateFile, expectedTemplates[i])
+			}
+			if action.Force {
+				t.Errorf("Action[%d].Force should be false", i)
+			}
+		}
+	})
+
+	t.Run("GenerateWebComponentActions_WithForce", func(t *testing.T) {
+		actions, err := BuildWebComponentActions(actionType, true)
+		if err != nil {
+			t.Fatalf("BuildWebComponentActions() returned an error: %v", err)
+		}
+
+		for i, action := range actions {
+			if !action.Force {
+				t.Errorf("Action[%d].Force = false; want true", i)
+			}
+		}
+	})
+}
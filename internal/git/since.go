@@ -0,0 +1,96 @@
+package git
+
+import (
+	"path/filepath"
+	"slices"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	apperrors "github.com/indaco/tempo/internal/apperrors"
+)
+
+// ChangedSince returns the sorted absolute paths of the files of the repository
+// containing dir changed since ref, e.g. "origin/main": the files changed by the commits
+// since the merge base of ref and HEAD, as in "git diff ref...HEAD", and the files
+// modified or untracked in the worktree. Deleted files are left out.
+func ChangedSince(dir, ref string) ([]string, error) {
+	repo, err := gogit.PlainOpenWithOptions(dir, &gogit.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to open git repository", err, dir)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, apperrors.Wrap("failed to open git worktree", err, dir)
+	}
+
+	changed, err := committedChanges(repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return nil, apperrors.Wrap("failed to read git status", err, dir)
+	}
+	for path, fileStatus := range status {
+		if fileStatus.Worktree != gogit.Deleted && (fileStatus.Worktree != gogit.Unmodified || fileStatus.Staging != gogit.Unmodified) {
+			changed[path] = true
+		}
+	}
+
+	root := wt.Filesystem.Root()
+	paths := make([]string, 0, len(changed))
+	for path := range changed {
+		paths = append(paths, filepath.Join(root, filepath.FromSlash(path)))
+	}
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// committedChanges returns the worktree-relative paths of the files added or modified
+// by the commits between the merge base of ref and HEAD, and HEAD.
+func committedChanges(repo *gogit.Repository, ref string) (map[string]bool, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, apperrors.Wrap("failed to resolve git ref '%s'", err, ref)
+	}
+	base, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, apperrors.Wrap("failed to read git commit", err, hash.String())
+	}
+
+	headRef, err := repo.Head()
+	if err != nil {
+		return nil, apperrors.Wrap("failed to resolve git HEAD", err)
+	}
+	head, err := repo.CommitObject(headRef.Hash())
+	if err != nil {
+		return nil, apperrors.Wrap("failed to read git commit", err, headRef.Hash().String())
+	}
+
+	if bases, err := base.MergeBase(head); err == nil && len(bases) > 0 {
+		base = bases[0]
+	}
+
+	baseTree, err := base.Tree()
+	if err != nil {
+		return nil, apperrors.Wrap("failed to read git tree", err, base.Hash.String())
+	}
+	headTree, err := head.Tree()
+	if err != nil {
+		return nil, apperrors.Wrap("failed to read git tree", err, head.Hash.String())
+	}
+	changes, err := object.DiffTree(baseTree, headTree)
+	if err != nil {
+		return nil, apperrors.Wrap("failed to diff git trees", err, ref)
+	}
+
+	changed := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		if change.To.Name != "" {
+			changed[change.To.Name] = true
+		}
+	}
+	return changed, nil
+}
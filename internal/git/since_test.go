@@ -0,0 +1,62 @@
+package git
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestChangedSince(t *testing.T) {
+	dir, repo := setupWorktree(t)
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Failed to resolve HEAD: %v", err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/base", head.Hash())); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+
+	// A commit after the ref, then an untracked file
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to open worktree: %v", err)
+	}
+	writeFile(t, dir, "assets/button/button.css", ".btn {}")
+	if _, err := wt.Add("assets/button/button.css"); err != nil {
+		t.Fatalf("Failed to add file: %v", err)
+	}
+	signature := &object.Signature{Name: "Jane Doe", Email: "jane@example.com", When: time.Now()}
+	if _, err := wt.Commit("button", &gogit.CommitOptions{Author: signature}); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	writeFile(t, dir, "untracked.txt", "new")
+	writeFile(t, dir, "ignored.txt", "ignored")
+
+	changed, err := ChangedSince(dir, "base")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var want []string
+	for _, name := range []string{"assets/button/button.css", "dirty.txt", "untracked.txt"} {
+		want = append(want, filepath.Join(dir, filepath.FromSlash(name)))
+	}
+	slices.Sort(want)
+	if !slices.Equal(changed, want) {
+		t.Errorf("Expected %v, got %v", want, changed)
+	}
+}
+
+func TestChangedSince_UnknownRef(t *testing.T) {
+	dir, _ := setupWorktree(t)
+
+	if _, err := ChangedSince(dir, "no-such-ref"); err == nil {
+		t.Fatal("Expected an error for an unknown ref")
+	}
+}
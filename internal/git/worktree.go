@@ -0,0 +1,156 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	apperrors "github.com/indaco/tempo/internal/apperrors"
+)
+
+// Snapshot records the dirty files of a Git worktree before tempo changes it, so that
+// the files created or modified afterwards can be staged and committed. It relies on
+// go-git and does not need a git binary.
+type Snapshot struct {
+	repo   *gogit.Repository
+	root   string
+	dirty  map[string]plumbing.Hash // Content hash of the files already dirty
+	staged []string                 // Files already staged, which a commit would include
+}
+
+// TakeSnapshot opens the repository containing dir and records its dirty files.
+func TakeSnapshot(dir string) (*Snapshot, error) {
+	repo, err := gogit.PlainOpenWithOptions(dir, &gogit.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, apperrors.Wrap("failed to open git repository", err, dir)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, apperrors.Wrap("failed to open git worktree", err, dir)
+	}
+
+	s := &Snapshot{repo: repo, root: wt.Filesystem.Root()}
+	status, err := s.status()
+	if err != nil {
+		return nil, err
+	}
+	if s.dirty, err = s.dirtyFiles(status); err != nil {
+		return nil, err
+	}
+	for path, fileStatus := range status {
+		if fileStatus.Staging != gogit.Unmodified && fileStatus.Staging != gogit.Untracked {
+			s.staged = append(s.staged, path)
+		}
+	}
+	slices.Sort(s.staged)
+	return s, nil
+}
+
+// CheckCommittable returns an error when files were already staged when the snapshot
+// was taken: a commit would include them with the files changed since.
+func (s *Snapshot) CheckCommittable() error {
+	if len(s.staged) == 0 {
+		return nil
+	}
+	return apperrors.Wrap("cannot commit: the index already has staged changes (%s). Commit or unstage them first, or use '--git-add' only", strings.Join(s.staged, ", "))
+}
+
+// ChangedFiles returns the sorted worktree-relative paths of the files created, modified
+// or deleted since the snapshot. Files already dirty count only if their content changed.
+func (s *Snapshot) ChangedFiles() ([]string, error) {
+	status, err := s.status()
+	if err != nil {
+		return nil, err
+	}
+	dirty, err := s.dirtyFiles(status)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for path, hash := range dirty {
+		if before, ok := s.dirty[path]; !ok || before != hash {
+			changed = append(changed, path)
+		}
+	}
+	slices.Sort(changed)
+	return changed, nil
+}
+
+// Stage adds the given worktree-relative paths to the index.
+func (s *Snapshot) Stage(paths []string) error {
+	wt, err := s.repo.Worktree()
+	if err != nil {
+		return apperrors.Wrap("failed to open git worktree", err, s.root)
+	}
+
+	for _, path := range paths {
+		stage := wt.Add
+		if _, err := os.Lstat(filepath.Join(s.root, filepath.FromSlash(path))); os.IsNotExist(err) {
+			stage = wt.Remove
+		}
+		if _, err := stage(path); err != nil {
+			return apperrors.Wrap("failed to stage file", err, path)
+		}
+	}
+	return nil
+}
+
+// Commit records the staged changes with the author configured in git and returns
+// the hash of the new commit. It refuses to commit when files were already staged at
+// snapshot time (see CheckCommittable).
+func (s *Snapshot) Commit(message string) (string, error) {
+	if err := s.CheckCommittable(); err != nil {
+		return "", err
+	}
+
+	wt, err := s.repo.Worktree()
+	if err != nil {
+		return "", apperrors.Wrap("failed to open git worktree", err, s.root)
+	}
+
+	hash, err := wt.Commit(message, &gogit.CommitOptions{})
+	if err != nil {
+		return "", apperrors.Wrap("failed to commit changes", err, s.root)
+	}
+	return hash.String(), nil
+}
+
+// status returns the git status of the worktree.
+func (s *Snapshot) status() (gogit.Status, error) {
+	wt, err := s.repo.Worktree()
+	if err != nil {
+		return nil, apperrors.Wrap("failed to open git worktree", err, s.root)
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return nil, apperrors.Wrap("failed to read git status", err, s.root)
+	}
+	return status, nil
+}
+
+// dirtyFiles maps the paths of the files that differ from HEAD or the index to the
+// hash of their current content (the zero hash for deleted files).
+func (s *Snapshot) dirtyFiles(status gogit.Status) (map[string]plumbing.Hash, error) {
+	dirty := make(map[string]plumbing.Hash, len(status))
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == gogit.Unmodified {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(path)))
+		switch {
+		case os.IsNotExist(err):
+			dirty[path] = plumbing.ZeroHash
+		case err != nil:
+			return nil, apperrors.Wrap("failed to read file", err, path)
+		default:
+			dirty[path] = plumbing.ComputeHash(plumbing.BlobObject, content)
+		}
+	}
+	return dirty, nil
+}
@@ -0,0 +1,211 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// setupWorktree creates a repository with a committed, an already dirty and an
+// ignored file, and a configured author.
+func setupWorktree(t *testing.T) (string, *gogit.Repository) {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("Failed to init repository: %v", err)
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatalf("Failed to read repository config: %v", err)
+	}
+	cfg.User.Name, cfg.User.Email = "Jane Doe", "jane@example.com"
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatalf("Failed to write repository config: %v", err)
+	}
+
+	writeFile(t, dir, "committed.txt", "v1")
+	writeFile(t, dir, "dirty.txt", "v1")
+	writeFile(t, dir, ".gitignore", "ignored.txt\n")
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to open worktree: %v", err)
+	}
+	for _, path := range []string{"committed.txt", "dirty.txt", ".gitignore"} {
+		if _, err := wt.Add(path); err != nil {
+			t.Fatalf("Failed to add %s: %v", path, err)
+		}
+	}
+	signature := &object.Signature{Name: "Jane Doe", Email: "jane@example.com", When: time.Now()}
+	if _, err := wt.Commit("initial", &gogit.CommitOptions{Author: signature}); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	writeFile(t, dir, "dirty.txt", "v2")
+	return dir, repo
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+}
+
+func TestSnapshot_ChangedFiles(t *testing.T) {
+	dir, _ := setupWorktree(t)
+
+	snapshot, err := TakeSnapshot(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	writeFile(t, dir, "components/button/button.templ", "package button")
+	writeFile(t, dir, "committed.txt", "v2")
+	writeFile(t, dir, "ignored.txt", "ignored")
+
+	changed, err := snapshot.ChangedFiles()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"committed.txt", "components/button/button.templ"}
+	if !slices.Equal(changed, expected) {
+		t.Errorf("Expected changed files %v, got %v", expected, changed)
+	}
+
+	writeFile(t, dir, "dirty.txt", "v3")
+	if changed, _ = snapshot.ChangedFiles(); !slices.Contains(changed, "dirty.txt") {
+		t.Errorf("Expected an already dirty file modified again to be reported, got %v", changed)
+	}
+}
+
+func TestSnapshot_StageAndCommit(t *testing.T) {
+	dir, repo := setupWorktree(t)
+
+	// The snapshot is taken from a subfolder of the worktree
+	writeFile(t, dir, "components/.keep", "")
+	snapshot, err := TakeSnapshot(filepath.Join(dir, "components"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	writeFile(t, dir, "components/button/button.templ", "package button")
+	if err := os.Remove(filepath.Join(dir, "committed.txt")); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+
+	changed, err := snapshot.ChangedFiles()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := snapshot.Stage(changed); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	hash, err := snapshot.Commit("Add button")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	commit, err := repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		t.Fatalf("Failed to read commit: %v", err)
+	}
+	if commit.Message != "Add button" || commit.Author.Name != "Jane Doe" {
+		t.Errorf("Unexpected commit: %q by %s", commit.Message, commit.Author.Name)
+	}
+
+	files, err := commit.Files()
+	if err != nil {
+		t.Fatalf("Failed to list commit files: %v", err)
+	}
+	var committed []string
+	_ = files.ForEach(func(f *object.File) error {
+		committed = append(committed, f.Name)
+		return nil
+	})
+	slices.Sort(committed)
+	expected := []string{".gitignore", "components/button/button.templ", "dirty.txt"}
+	if !slices.Equal(committed, expected) {
+		t.Errorf("Expected committed files %v, got %v", expected, committed)
+	}
+
+	// The file dirty before the snapshot is left out of the commit
+	if content, _ := readCommitted(commit, "dirty.txt"); content != "v1" {
+		t.Errorf("Expected dirty.txt to keep its committed content, got %q", content)
+	}
+}
+
+func TestSnapshot_CommitWithStagedFiles(t *testing.T) {
+	dir, repo := setupWorktree(t)
+
+	// An unrelated file staged by the user before tempo runs
+	writeFile(t, dir, "notes.txt", "draft")
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to open worktree: %v", err)
+	}
+	if _, err := wt.Add("notes.txt"); err != nil {
+		t.Fatalf("Failed to stage notes.txt: %v", err)
+	}
+
+	snapshot, err := TakeSnapshot(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := snapshot.CheckCommittable(); err == nil || !strings.Contains(err.Error(), "notes.txt") {
+		t.Errorf("Expected the staged notes.txt to be reported, got %v", err)
+	}
+
+	writeFile(t, dir, "components/button/button.templ", "package button")
+	changed, err := snapshot.ChangedFiles()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := snapshot.Stage(changed); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := snapshot.Commit("Add button"); err == nil {
+		t.Fatal("Expected the commit to be refused")
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Failed to read HEAD: %v", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatalf("Failed to read commit: %v", err)
+	}
+	if commit.Message != "initial" {
+		t.Errorf("Expected no new commit, got %q", commit.Message)
+	}
+}
+
+func TestTakeSnapshot_NotARepository(t *testing.T) {
+	if _, err := TakeSnapshot(t.TempDir()); err == nil {
+		t.Fatal("Expected an error outside a git repository")
+	}
+}
+
+func readCommitted(commit *object.Commit, path string) (string, error) {
+	file, err := commit.File(path)
+	if err != nil {
+		return "", err
+	}
+	return file.Contents()
+}
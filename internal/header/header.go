@@ -0,0 +1,136 @@
+// Package header renders the license or banner header prepended to the generated files
+// by the "header" actions and by `tempo headers apply`.
+package header
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/watermark"
+)
+
+// yearPlaceholder stands for the year when matching the headers of the previous years.
+const yearPlaceholder = "\x00YEAR\x00"
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Data is the data available to the header template.
+//
+// Fields:
+// - Year: The current year, e.g. 2026.
+// - Author: The author from the templates.header config.
+// - UserData: The user-defined data from the templates.user_data config.
+type Data struct {
+	Year     string
+	Author   string
+	UserData map[string]any
+}
+
+// Header renders a header template. A nil Header is disabled and leaves the files
+// unchanged.
+type Header struct {
+	template string
+	author   string
+	userData map[string]any
+	year     string
+}
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTOR                                                               */
+/* ------------------------------------------------------------------------- */
+
+// New creates a Header from the templates.header config. It returns nil when the
+// template is empty, and an error when it cannot be rendered with the given user data.
+func New(cfg config.Header, userData map[string]any) (*Header, error) {
+	if strings.TrimSpace(cfg.Template) == "" {
+		return nil, nil
+	}
+
+	h := &Header{
+		template: cfg.Template,
+		author:   cfg.Author,
+		userData: userData,
+		year:     strconv.Itoa(time.Now().Year()),
+	}
+
+	// Render once to report template errors before any file is written
+	if _, err := h.render(h.year); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// Comment returns the header formatted as a comment for the file type of filePath
+// (see watermark.FormatComment). It returns an empty string when the header is
+// disabled or the file type has no known comment syntax.
+func (h *Header) Comment(filePath string) (string, error) {
+	if h == nil {
+		return "", nil
+	}
+	text, err := h.render(h.year)
+	if err != nil || text == "" {
+		return "", err
+	}
+	return watermark.FormatComment(text, filePath), nil
+}
+
+// Apply returns content with the header prepended, and true, when content lacks it.
+// The header is followed by a blank line, so that it is not read as the doc comment of
+// a Go package. A header rendered with another year counts as present, so that the files are not
+// changed every year. Content is returned unchanged when the header is disabled or the
+// file type has no known comment syntax.
+func (h *Header) Apply(content, filePath string) (string, bool, error) {
+	comment, err := h.Comment(filePath)
+	if err != nil || comment == "" {
+		return content, false, err
+	}
+
+	present, err := h.matcher(filePath)
+	if err != nil {
+		return content, false, err
+	}
+	if present.MatchString(content) {
+		return content, false, nil
+	}
+	return watermark.Prepend(content, comment+"\n"), true, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// render returns the header text for the given year.
+func (h *Header) render(year string) (string, error) {
+	text, err := utils.RenderTemplate(h.template, Data{
+		Year:     year,
+		Author:   h.author,
+		UserData: h.userData,
+	})
+	if err != nil {
+		return "", apperrors.Wrap("failed to render header template", err)
+	}
+	return strings.TrimSpace(text), nil
+}
+
+// matcher returns the pattern of the header comment of filePath, matching any year or
+// range of years, e.g. 2024-2026.
+func (h *Header) matcher(filePath string) (*regexp.Regexp, error) {
+	text, err := h.render(yearPlaceholder)
+	if err != nil {
+		return nil, err
+	}
+	pattern := regexp.QuoteMeta(watermark.FormatComment(text, filePath))
+	pattern = strings.ReplaceAll(pattern, yearPlaceholder, `\d{4}(?:\s*-\s*\d{4})?`)
+	return regexp.Compile(pattern)
+}
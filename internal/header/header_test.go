@@ -0,0 +1,102 @@
+package header
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/indaco/tempo/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("empty template disables the header", func(t *testing.T) {
+		h, err := New(config.Header{Template: "  "}, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if h != nil {
+			t.Fatalf("Expected a nil header, got %+v", h)
+		}
+
+		content, changed, err := h.Apply("body", "button.go")
+		if err != nil || changed || content != "body" {
+			t.Errorf("Expected a nil header to leave the content unchanged, got %q, %v (err: %v)", content, changed, err)
+		}
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		if _, err := New(config.Header{Template: "{{ .Year"}, nil); err == nil {
+			t.Errorf("Expected a parse error")
+		}
+	})
+}
+
+func TestHeader_Apply(t *testing.T) {
+	h, err := New(config.Header{
+		Template: "Copyright {{ .Year }} {{ .Author }}\nSPDX-License-Identifier: {{ .UserData.license }}",
+		Author:   "ACME Inc.",
+	}, map[string]any{"license": "MIT"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	year := strconv.Itoa(time.Now().Year())
+
+	tests := []struct {
+		name     string
+		content  string
+		path     string
+		expected string
+		changed  bool
+	}{
+		{
+			name:     "Go file",
+			content:  "package button\n",
+			path:     "button.go",
+			expected: "// Copyright " + year + " ACME Inc.\n// SPDX-License-Identifier: MIT\n\npackage button\n",
+			changed:  true,
+		},
+		{
+			name:     "CSS file",
+			content:  ".button {}\n",
+			path:     "base.css",
+			expected: "/*\nCopyright " + year + " ACME Inc.\nSPDX-License-Identifier: MIT\n*/\n\n.button {}\n",
+			changed:  true,
+		},
+		{
+			name:     "Header of another year",
+			content:  "// Copyright 2019-2021 ACME Inc.\n// SPDX-License-Identifier: MIT\n\npackage button\n",
+			path:     "button.templ",
+			expected: "// Copyright 2019-2021 ACME Inc.\n// SPDX-License-Identifier: MIT\n\npackage button\n",
+		},
+		{
+			name:     "No comment syntax",
+			content:  "{}",
+			path:     "data.json",
+			expected: "{}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, changed, err := h.Apply(tt.content, tt.path)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if changed != tt.changed || content != tt.expected {
+				t.Errorf("Expected %q (changed: %v), got %q (changed: %v)", tt.expected, tt.changed, content, changed)
+			}
+		})
+	}
+
+	t.Run("Applied once", func(t *testing.T) {
+		once, _, _ := h.Apply("package button\n", "button.go")
+		twice, changed, err := h.Apply(once, "button.go")
+		if err != nil || changed || twice != once {
+			t.Errorf("Expected the header not to be added twice, got %q (err: %v)", twice, err)
+		}
+		if strings.Count(twice, "Copyright") != 1 {
+			t.Errorf("Expected a single header, got %q", twice)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package helpers
+
+import (
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/urfave/cli/v3"
+)
+
+// ResolvePositionalArgs returns the values of the given string flags. The positional
+// arguments fill, in order, the flags that are not set, so that
+// `tempo variant new button neon` is the same as `--component button --name neon`.
+// Values left empty are not set either way. Extra arguments are an error.
+func ResolvePositionalArgs(cmd *cli.Command, flagNames ...string) ([]string, error) {
+	args := cmd.Args().Slice()
+	values := make([]string, len(flagNames))
+	for i, name := range flagNames {
+		values[i] = cmd.String(name)
+		if values[i] == "" && len(args) > 0 {
+			values[i], args = args[0], args[1:]
+		}
+	}
+
+	if len(args) > 0 {
+		return nil, apperrors.Wrap("unexpected argument(s): %s", strings.Join(args, " "))
+	}
+	return values, nil
+}
+
+// RequiredArgError reports a required value given neither as flag nor as argument.
+func RequiredArgError(flagName string) error {
+	return apperrors.Wrap(`Required flag "%s" not set. Use '--%s' or pass it as argument`, flagName, flagName)
+}
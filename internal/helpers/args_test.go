@@ -0,0 +1,66 @@
+package helpers
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestResolvePositionalArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		{"flags", []string{"cmd", "--component", "button", "--name", "neon"}, []string{"button", "neon"}, ""},
+		{"arguments", []string{"cmd", "button", "neon"}, []string{"button", "neon"}, ""},
+		{"flag and argument", []string{"cmd", "--component", "button", "neon"}, []string{"button", "neon"}, ""},
+		{"missing values", []string{"cmd", "button"}, []string{"button", ""}, ""},
+		{"extra arguments", []string{"cmd", "button", "neon", "extra"}, nil, "unexpected argument(s): extra"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			var gotErr error
+			cmd := &cli.Command{
+				Name: "cmd",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "component"},
+					&cli.StringFlag{Name: "name"},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					got, gotErr = ResolvePositionalArgs(cmd, "component", "name")
+					return nil
+				},
+			}
+			if err := cmd.Run(context.Background(), tt.args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if tt.wantErr != "" {
+				if gotErr == nil || !strings.Contains(gotErr.Error(), tt.wantErr) {
+					t.Errorf("Expected error %q, got %v", tt.wantErr, gotErr)
+				}
+				return
+			}
+			if gotErr != nil {
+				t.Fatalf("Unexpected error: %v", gotErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ResolvePositionalArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequiredArgError(t *testing.T) {
+	err := RequiredArgError("name")
+	if !strings.Contains(err.Error(), `Required flag "name" not set`) {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package helpers
+
+import (
+	"github.com/indaco/tempo/internal/a11y"
+	"github.com/indaco/tempo/internal/logger"
+)
+
+// AuditAccessibility checks the .templ files generated in dir with the registered
+// accessibility rules and logs the findings as warnings. The findings never fail the
+// generation: it returns their number.
+func AuditAccessibility(dir string, logr logger.Logger) (int, error) {
+	findings, err := a11y.AuditDir(dir, a11y.Rules())
+	if err != nil {
+		return 0, err
+	}
+	for _, f := range findings {
+		logr.Warning(f.Message).WithAttrs("file", f.File, "line", f.Line, "rule", f.Rule)
+	}
+	if len(findings) > 0 {
+		logr.Warning("Accessibility audit found issues").WithAttrs("path", dir, "issues", len(findings))
+	}
+	return len(findings), nil
+}
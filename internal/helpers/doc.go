@@ -14,6 +14,7 @@
 //
 // Functions for building user-friendly error messages:
 //   - BuildMissingFoldersError - Build error message for missing folder validation
+//   - CheckPrerequisiteFolders - Fail on missing folders, or warn when strict mode is disabled
 //
 // # Entity Helpers (entity.go)
 //
@@ -21,6 +22,37 @@
 //   - CheckEntityForNew - Log warning/info when creating entities that exist
 //   - CheckEntityForDefine - Log warning/info when defining templates that exist
 //
+// # Trace Helpers (trace.go)
+//
+// Functions for wiring action tracing into generator commands:
+//   - StartActionTrace - Attach a generator tracer when `--trace` or `--trace-file` is set
+//   - FinishActionTrace - Export the collected trace to a JSON file
+//   - WarnTraceNotExported - Log a trace that couldn't be exported after a failure
+//
+// # Argument Helpers (args.go)
+//
+// Functions for accepting flag values as positional arguments:
+//   - ResolvePositionalArgs - Fill the unset flags with the positional arguments
+//   - RequiredArgError - Report a value given neither as flag nor as argument
+//
+// # Overwrite Helpers (overwrite.go)
+//
+// Functions for reviewing the files overwritten with `--force`:
+//   - ConfirmOverwrite - Print the diff of the changed files and require `--yes`
+//   - YesFlag - The `--yes` flag shared by the commands asking for a confirmation
+//   - AssumeYes - Resolve `--yes` and the assume_yes config
+//
+// # Git Helpers (git.go)
+//
+// Functions for wiring `--git-add` and `--git-commit` into generation and sync commands:
+//   - StartGitTracking - Snapshot the worktree before the command changes files
+//   - FinishGitTracking - Stage the changed files and optionally commit them
+//
+// # Audit Helpers (audit.go)
+//
+// Functions for checking the generated files:
+//   - AuditAccessibility - Log the accessibility issues of the generated .templ files
+//
 // # Usage
 //
 // These helpers are designed to be used in CLI command implementations:
@@ -9,18 +9,23 @@ import (
 )
 
 // CheckEntityForNew logs a warning or info message when creating a new entity that already exists.
-// It handles both component and variant entity types with appropriate path formatting.
-func CheckEntityForNew(entityType, entityName, outputPath string, force bool, logr logger.Logger) {
-	// Select logging function and action message based on `force` flag
+// It handles component, web component and variant entity types with appropriate path formatting.
+// With `--yes` and without `--force`, keeping the entity is the expected outcome and is logged as info.
+func CheckEntityForNew(entityType, entityName, outputPath string, force, yes bool, logr logger.Logger) {
+	// Select logging function and action message based on `force` and `yes` flags
 	logFunc, action := logr.Warning, "Use '--force' to overwrite it. Any changes will be lost."
-	if force {
+	switch {
+	case force:
 		logFunc, action = logr.Info, "Overwriting due to '--force' flag."
+	case yes:
+		logFunc, action = logr.Info, "Keeping it: '--yes' does not overwrite without '--force'."
 	}
 
 	// Determine the appropriate path format based on entity type
 	paths := map[string]string{
-		"component": filepath.Join(outputPath, entityName),
-		"variant":   outputPath,
+		"component":    filepath.Join(outputPath, entityName),
+		"webcomponent": filepath.Join(outputPath, entityName),
+		"variant":      outputPath,
 	}
 	path, exists := paths[entityType]
 	if !exists {
@@ -32,11 +37,15 @@ func CheckEntityForNew(entityType, entityName, outputPath string, force bool, lo
 }
 
 // CheckEntityForDefine logs a warning or info message when defining templates that already exist.
-func CheckEntityForDefine(entityType, outputPath string, force bool, logr logger.Logger) {
-	// Select logging function and action message based on `force` flag
+// With `--yes` and without `--force`, keeping the templates is logged as info.
+func CheckEntityForDefine(entityType, outputPath string, force, yes bool, logr logger.Logger) {
+	// Select logging function and action message based on `force` and `yes` flags
 	logFunc, action := logr.Warning, "Use '--force' to overwrite them. Any changes will be lost."
-	if force {
+	switch {
+	case force:
 		logFunc, action = logr.Info, "Overwriting due to '--force' flag."
+	case yes:
+		logFunc, action = logr.Info, "Keeping them: '--yes' does not overwrite without '--force'."
 	}
 
 	msg := fmt.Sprintf("Templates for '%s' already exist.\n  %s", entityType, action)
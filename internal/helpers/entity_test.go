@@ -20,6 +20,7 @@ func TestCheckEntityForNew(t *testing.T) {
 	}{
 		{"Component Exists Without Force", "component", "button", "/mock/path/button", false, true, "/mock/path/button/button"},
 		{"Component Exists With Force", "component", "button", "/mock/path/button", true, false, "/mock/path/button/button"},
+		{"Web Component Exists Without Force", "webcomponent", "counter", "/mock/path", false, true, "/mock/path/counter"},
 		{"Variant Exists Without Force", "variant", "outline", "/mock/path/button/css/variants/outline.templ", false, true, "/mock/path/button/css/variants/outline.templ"},
 		{"Variant Exists With Force", "variant", "outline", "/mock/path/button/css/variants/outline.templ", true, false, "/mock/path/button/css/variants/outline.templ"},
 		{"Unknown Entity Type", "unknown", "mystery", "/mock/path/unknown", false, true, "/mock/path/unknown"},
@@ -29,7 +30,7 @@ func TestCheckEntityForNew(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			output, err := testutils.CaptureStdout(func() {
 				logr := logger.NewDefaultLogger()
-				CheckEntityForNew("component", tc.entityName, tc.outputPath, tc.force, logr)
+				CheckEntityForNew("component", tc.entityName, tc.outputPath, tc.force, false, logr)
 			})
 
 			if err != nil {
@@ -88,7 +89,7 @@ func TestCheckEntityForDefine_Component(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			output, err := testutils.CaptureStdout(func() {
 				logr := logger.NewDefaultLogger()
-				CheckEntityForDefine("component", tt.outputPath, tt.force, logr)
+				CheckEntityForDefine("component", tt.outputPath, tt.force, false, logr)
 			})
 
 			if err != nil {
@@ -134,7 +135,7 @@ func TestCheckEntityForDefine_Variant(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			output, err := testutils.CaptureStdout(func() {
 				logr := logger.NewDefaultLogger()
-				CheckEntityForDefine("variant", tt.outputPath, tt.force, logr)
+				CheckEntityForDefine("variant", tt.outputPath, tt.force, false, logr)
 			})
 
 			if err != nil {
@@ -146,3 +147,15 @@ func TestCheckEntityForDefine_Variant(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckEntityForNew_Yes(t *testing.T) {
+	output, err := testutils.CaptureStdout(func() {
+		CheckEntityForNew("component", "button", "/mock/path", false, true, logger.NewDefaultLogger())
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	if !strings.Contains(output, "'--yes' does not overwrite without '--force'") {
+		t.Errorf("Expected the entity to be kept with --yes, got: %s", output)
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
 	"github.com/indaco/tempo/internal/templatefuncs/providers/textprovider"
+	"github.com/indaco/tempo/internal/utils"
 )
 
 // BuildMissingFoldersError constructs an error message for missing folders.
@@ -35,7 +38,7 @@ func BuildMissingFoldersError(missingFolders map[string]string, contextMsg strin
 
 	// Append help commands if available
 	if len(helpCommands) > 0 {
-		sb.WriteString("\n💡 Need help? Run:\n")
+		sb.WriteString("\n" + logger.Prefix("hint") + "Need help? Run:\n")
 		for _, cmd := range helpCommands {
 			fmt.Fprintf(&sb, "  - %s\n", cmd)
 		}
@@ -43,3 +46,28 @@ func BuildMissingFoldersError(missingFolders map[string]string, contextMsg strin
 
 	return errors.New(strings.TrimSpace(sb.String()))
 }
+
+// CheckPrerequisiteFolders returns the missing folders among the given ones, with the
+// error built by BuildMissingFoldersError. When cfg is not strict (see Config.IsStrict),
+// the missing folders are logged as a warning instead and no error is returned.
+func CheckPrerequisiteFolders(cfg *config.Config, log logger.Logger, folders map[string]string, contextMsg string, helpCommands []string) (map[string]string, error) {
+	missingFolders, err := utils.CheckMissingFolders(folders)
+	if err != nil || len(missingFolders) == 0 {
+		return missingFolders, err
+	}
+	if cfg.IsStrict() {
+		return missingFolders, BuildMissingFoldersError(missingFolders, contextMsg, helpCommands)
+	}
+
+	keys := make([]string, 0, len(missingFolders))
+	for name := range missingFolders {
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	attrs := make([]any, 0, 2*len(keys))
+	for _, name := range keys {
+		attrs = append(attrs, name, missingFolders[name])
+	}
+	log.Warning("Missing folders ignored (strict mode disabled)").WithAttrs(attrs...)
+	return missingFolders, nil
+}
@@ -1,8 +1,12 @@
 package helpers
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/logger"
 )
 
 func TestBuildMissingFoldersError(t *testing.T) {
@@ -84,3 +88,33 @@ Missing folders:
 		})
 	}
 }
+
+func TestCheckPrerequisiteFolders(t *testing.T) {
+	tempDir := t.TempDir()
+	folders := map[string]string{
+		"existing": tempDir,
+		"missing":  filepath.Join(tempDir, "missing"),
+	}
+	log := logger.NewDefaultLogger()
+
+	t.Run("Strict Mode", func(t *testing.T) {
+		missing, err := CheckPrerequisiteFolders(&config.Config{}, log, folders, "", nil)
+		if err == nil || !strings.Contains(err.Error(), "Missing folders:") {
+			t.Fatalf("Expected a missing folders error, got: %v", err)
+		}
+		if len(missing) != 1 || missing["missing"] == "" {
+			t.Errorf("Expected only the missing folder, got: %v", missing)
+		}
+	})
+
+	t.Run("Strict Mode Disabled", func(t *testing.T) {
+		strict := false
+		missing, err := CheckPrerequisiteFolders(&config.Config{Strict: &strict}, log, folders, "", nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(missing) != 1 || missing["missing"] == "" {
+			t.Errorf("Expected only the missing folder, got: %v", missing)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package helpers
+
+import (
+	"github.com/indaco/tempo/internal/git"
+	"github.com/indaco/tempo/internal/logger"
+)
+
+// DefaultGitCommitMessage is the commit message used by `--git-commit` without `--message`.
+const DefaultGitCommitMessage = "Update files generated by tempo"
+
+// StartGitTracking snapshots the Git worktree containing dir when `--git-add` or
+// `--git-commit` is set, so that the files changed by the command can be staged.
+// With `--git-commit`, it fails before any file is written when the index already has
+// staged changes, which the commit would include. It returns a nil snapshot when Git
+// integration is disabled.
+func StartGitTracking(dir string, gitAdd, gitCommit bool) (*git.Snapshot, error) {
+	if !gitAdd && !gitCommit {
+		return nil, nil
+	}
+	snapshot, err := git.TakeSnapshot(dir)
+	if err != nil {
+		return nil, err
+	}
+	if gitCommit {
+		if err := snapshot.CheckCommittable(); err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+// FinishGitTracking stages the files changed since the snapshot and, with `--git-commit`,
+// commits them. An empty message falls back to DefaultGitCommitMessage.
+func FinishGitTracking(snapshot *git.Snapshot, gitCommit bool, message string, log logger.Logger) error {
+	if snapshot == nil {
+		return nil
+	}
+
+	files, err := snapshot.ChangedFiles()
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		log.Info("No changed files to stage")
+		return nil
+	}
+
+	if err := snapshot.Stage(files); err != nil {
+		return err
+	}
+	log.Success("Changed files have been staged").WithAttrs("files", len(files))
+
+	if !gitCommit {
+		return nil
+	}
+
+	if message == "" {
+		message = DefaultGitCommitMessage
+	}
+	hash, err := snapshot.Commit(message)
+	if err != nil {
+		return err
+	}
+	log.Success("Changes have been committed").WithAttrs("commit", hash[:7], "message", message)
+	return nil
+}
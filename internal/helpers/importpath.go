@@ -0,0 +1,23 @@
+package helpers
+
+import (
+	"errors"
+
+	"github.com/indaco/tempo/internal/app"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// ResolveComponentImportPath sets the ComponentImportPath of data from the Go module of
+// the project. Without a Go module, it is left empty when the module is optional, and
+// the templates using it fail to render.
+func ResolveComponentImportPath(cmdCtx *app.AppContext, data *generator.TemplateData) error {
+	moduleRoot, moduleName, err := cmdCtx.ResolveModule()
+	if err != nil {
+		if data.GoModuleOptional && errors.Is(err, utils.ErrGoModNotFound) {
+			return nil
+		}
+		return err
+	}
+	return data.SetComponentImportPath(moduleRoot, moduleName)
+}
@@ -28,6 +28,8 @@ func LogSuccessMessages(entityType string, cfg *config.Config, logger logger.Log
 		message = "Templates for the component and assets (CSS and JS) have been created"
 	case "component-variant":
 		message = "Templates for the component variant and assets (CSS) have been created"
+	case "webcomponent":
+		message = "Templates for the web component and assets (JS) have been created"
 	default:
 		message = "Templates and assets have been created"
 	}
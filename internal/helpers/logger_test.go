@@ -77,6 +77,11 @@ func TestLogSuccessMessages(t *testing.T) {
 			entityType: "component-variant",
 			expected:   "✔ Templates for the component variant and assets (CSS) have been created",
 		},
+		{
+			name:       "Web Component",
+			entityType: "webcomponent",
+			expected:   "✔ Templates for the web component and assets (JS) have been created",
+		},
 		{
 			name:       "Default Case",
 			entityType: "unknown",
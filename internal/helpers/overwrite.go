@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/urfave/cli/v3"
+)
+
+// YesFlag returns the `--yes` flag shared by the commands asking for a confirmation.
+// It never implies `--force`: existing files are only overwritten with `--force`, whose
+// diff review `--yes` then skips.
+func YesFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:    "yes",
+		Aliases: []string{"y"},
+		Usage:   "Answer yes to the confirmations (default: the assume_yes config); existing files are still only overwritten with '--force'",
+	}
+}
+
+// AssumeYes reports whether the confirmations are answered yes. An explicit `--yes`
+// (or `--yes=false`) wins over the assume_yes config.
+func AssumeYes(cmd *cli.Command, cfg *config.Config) bool {
+	if cmd.IsSet("yes") {
+		return cmd.Bool("yes")
+	}
+	return cfg != nil && cfg.AssumesYes()
+}
+
+// ConfirmOverwrite previews, when `--force` is set, the changes process would make to
+// the existing files and writes their unified diff to w. Unless `--yes` is set, it
+// returns an error when some files would change, before anything is overwritten.
+// With `--no-diff`, `--force` overwrites the files without review.
+func ConfirmOverwrite(ctx context.Context, force, noDiff, yes bool, w io.Writer, process func(context.Context) error) error {
+	if !force || noDiff {
+		return nil
+	}
+
+	// The preview is not traced: only the actual run is
+	preview := generator.NewOverwritePreview()
+	ctx = generator.WithActionHooks(ctx, func(h *generator.ActionHooks) {
+		h.Tracer = nil
+		h.Preview = preview
+	})
+	if err := process(ctx); err != nil {
+		return err
+	}
+
+	diffs := preview.Diffs()
+	if len(diffs) == 0 {
+		return nil
+	}
+	for _, diff := range diffs {
+		fmt.Fprint(w, diff.Diff)
+	}
+	if yes {
+		return nil
+	}
+
+	return apperrors.WrapCode(apperrors.CodeOverwriteNotConfirmed,
+		"'--force' would overwrite %d changed file(s). Review the diff above, then re-run with '--yes' to proceed or '--no-diff' to skip the review",
+		len(diffs))
+}
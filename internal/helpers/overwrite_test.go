@@ -0,0 +1,46 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/indaco/tempo/internal/config"
+	"github.com/urfave/cli/v3"
+)
+
+func TestAssumeYes(t *testing.T) {
+	enabled := true
+	cfg := &config.Config{AssumeYes: &enabled}
+
+	tests := []struct {
+		name string
+		args []string
+		cfg  *config.Config
+		want bool
+	}{
+		{"no flag, no config", []string{"cmd"}, nil, false},
+		{"flag", []string{"cmd", "--yes"}, nil, true},
+		{"config", []string{"cmd"}, cfg, true},
+		{"flag disables config", []string{"cmd", "--yes=false"}, cfg, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bool
+			cmd := &cli.Command{
+				Name:  "cmd",
+				Flags: []cli.Flag{YesFlag()},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					got = AssumeYes(cmd, tt.cfg)
+					return nil
+				},
+			}
+			if err := cmd.Run(context.Background(), tt.args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/tags"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/urfave/cli/v3"
+)
+
+// TagFlag returns the `--tag` flag shared by the commands working on a subset of the
+// components, selected by their tags in components.tags.
+func TagFlag() cli.Flag {
+	return &cli.StringSliceFlag{
+		Name:  "tag",
+		Usage: "Only the components with one of the given tags (components.tags), e.g. --tag forms",
+	}
+}
+
+// ExcludeTagFlag returns the `--exclude-tag` flag, leaving out the components with one
+// of the given tags.
+func ExcludeTagFlag() cli.Flag {
+	return &cli.StringSliceFlag{
+		Name:  "exclude-tag",
+		Usage: "Leave out the components with one of the given tags (components.tags), e.g. --exclude-tag experimental",
+	}
+}
+
+// NewTagFilter returns the filter of the `--tag` and `--exclude-tag` flags over the
+// components.tags config, or nil when neither is set.
+func NewTagFilter(cmd *cli.Command, cfg *config.Config) (*tags.Filter, error) {
+	return tags.NewFilter(cfg.Components.Tags, cmd.StringSlice("tag"), cmd.StringSlice("exclude-tag"), gonameprovider.ToGoPackageName)
+}
@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateDataFormats lists the formats of the '--print-data' flag.
+var TemplateDataFormats = []string{"yaml", "json"}
+
+// PrintTemplateData writes data as the templates of the entity template folder entityDir
+// receive it, with the user data and the props of the folder merged, in the given format:
+// "yaml", keyed as in the template fixtures, or "json", keyed by the Go field names used
+// in the templates.
+func PrintTemplateData(w io.Writer, data *generator.TemplateData, entityDir, format string) error {
+	resolved, err := generator.EntityTemplateData(data, entityDir)
+	if err != nil {
+		return err
+	}
+	printed := *resolved
+	printed.SetLayout(config.Layout{})
+
+	var content []byte
+	switch strings.ToLower(format) {
+	case "yaml":
+		content, err = yaml.Marshal(&printed)
+	case "json":
+		content, err = json.MarshalIndent(&printed, "", "  ")
+		content = append(content, '\n')
+	default:
+		return apperrors.Wrap("invalid value for '--print-data': %s; valid formats are: %s", format, strings.Join(TemplateDataFormats, ", "))
+	}
+	if err != nil {
+		return apperrors.Wrap("failed to marshal the template data", err)
+	}
+
+	_, err = w.Write(content)
+	return err
+}
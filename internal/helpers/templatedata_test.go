@@ -0,0 +1,71 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/testutils"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPrintTemplateData(t *testing.T) {
+	templatesDir := t.TempDir()
+	testutils.CreateFile(t, filepath.Join(templatesDir, "component", generator.EntityDataFile), "theme: dark\nowner: entity\n")
+
+	data := &generator.TemplateData{
+		TemplatesDir:  templatesDir,
+		ComponentName: "button",
+		UserData:      map[string]any{"owner": "config"},
+		CLIUserData:   map[string]any{"theme": "light"},
+	}
+
+	t.Run("YAML", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := PrintTemplateData(&buf, data, "component", "yaml"); err != nil {
+			t.Fatalf("PrintTemplateData() error = %v", err)
+		}
+
+		var printed map[string]any
+		if err := yaml.Unmarshal(buf.Bytes(), &printed); err != nil {
+			t.Fatalf("Invalid YAML %q: %v", buf.String(), err)
+		}
+		if printed["component_name"] != "button" || printed["styles_dir"] != "css" {
+			t.Errorf("Expected the component name and the default layout, got %v", printed)
+		}
+		// The CLI user data takes precedence over the entity one, itself over the config
+		want := map[string]any{"owner": "entity", "theme": "light"}
+		if userData, _ := printed["user_data"].(map[string]any); !maps.Equal(userData, want) {
+			t.Errorf("Expected the user data %v, got %v", want, printed["user_data"])
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := PrintTemplateData(&buf, data, "component", "json"); err != nil {
+			t.Fatalf("PrintTemplateData() error = %v", err)
+		}
+
+		var printed map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &printed); err != nil {
+			t.Fatalf("Invalid JSON %q: %v", buf.String(), err)
+		}
+		if printed["ComponentName"] != "button" {
+			t.Errorf("Expected the fields keyed by their Go names, got %v", printed)
+		}
+		if _, ok := printed["CLIUserData"]; ok {
+			t.Errorf("Expected the internal fields to be left out, got %v", printed)
+		}
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		err := PrintTemplateData(&bytes.Buffer{}, data, "component", "toml")
+		if err == nil || !strings.Contains(err.Error(), "invalid value for '--print-data'") {
+			t.Errorf("Expected an invalid format error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package helpers
+
+import (
+	"context"
+
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/logger"
+)
+
+// StartActionTrace attaches a generator.Tracer to ctx when tracing is requested
+// through `--trace` or `--trace-file`. Per-action log lines are only printed with `--trace`.
+// It returns the original context and a nil tracer when tracing is disabled.
+func StartActionTrace(ctx context.Context, trace bool, traceFile string, log logger.Logger) (context.Context, *generator.Tracer) {
+	if !trace && traceFile == "" {
+		return ctx, nil
+	}
+
+	var traceLogger logger.Logger
+	if trace {
+		traceLogger = log
+	}
+
+	tracer := generator.NewTracer(traceLogger)
+	return generator.WithActionHooks(ctx, func(h *generator.ActionHooks) { h.Tracer = tracer }), tracer
+}
+
+// FinishActionTrace exports the collected trace entries when a trace file is set.
+func FinishActionTrace(tracer *generator.Tracer, traceFile string, log logger.Logger) error {
+	if tracer == nil || traceFile == "" {
+		return nil
+	}
+
+	if err := tracer.WriteJSON(traceFile); err != nil {
+		return err
+	}
+
+	log.Info("Action trace exported").WithAttrs("trace_file", traceFile)
+	return nil
+}
+
+// WarnTraceNotExported logs the error of FinishActionTrace, if any, when the actions
+// failed too, so that it doesn't hide their error.
+func WarnTraceNotExported(log logger.Logger, err error) {
+	if err != nil {
+		log.Warning("Action trace not exported").WithAttrs("error", err)
+	}
+}
@@ -0,0 +1,182 @@
+// Package history records the tempo invocations in a local JSON Lines file and
+// summarizes them, so the slow commands can be spotted without any telemetry.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// DefaultMaxEntries is the number of entries kept when none is configured.
+const DefaultMaxEntries = 1000
+
+// Results of a recorded invocation.
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// Entry is a recorded tempo invocation. Only the names of the flags are kept, as
+// their values may hold paths or commit messages.
+type Entry struct {
+	Time     time.Time     `json:"time"`
+	Command  string        `json:"command"` // e.g. "sync" or "component new"
+	Flags    []string      `json:"flags,omitempty"`
+	Dir      string        `json:"dir"`
+	Version  string        `json:"version"`
+	Duration time.Duration `json:"duration"`
+	Result   string        `json:"result"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// CommandStats aggregates the entries of a command.
+type CommandStats struct {
+	Command  string        `json:"command"`
+	Runs     int           `json:"runs"`
+	Failures int           `json:"failures"`
+	Average  time.Duration `json:"average"`
+	Max      time.Duration `json:"max"`
+	Total    time.Duration `json:"total"`
+}
+
+// Append adds entry to the history file at path, creating it when missing, and prunes
+// the entries beyond maxEntries or older than maxAge (see Prune).
+func Append(path string, entry Entry, maxEntries int, maxAge time.Duration) error {
+	entries, err := Load(path)
+	if err != nil {
+		return err
+	}
+	entries, _ = Prune(append(entries, entry), maxEntries, maxAge, entry.Time)
+	return Save(path, entries)
+}
+
+// Prune returns the latest maxEntries entries (DefaultMaxEntries when not positive)
+// recorded at most maxAge before now (no age limit when not positive), and the number
+// of removed entries. The latest successful entry is always kept.
+func Prune(entries []Entry, maxEntries int, maxAge time.Duration, now time.Time) (kept []Entry, removed int) {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+
+	lastSuccess := -1
+	for i, e := range entries {
+		if e.Result == ResultSuccess {
+			lastSuccess = i
+		}
+	}
+
+	first := max(len(entries)-maxEntries, 0)
+	for i, e := range entries {
+		tooOld := maxAge > 0 && e.Time.Before(now.Add(-maxAge))
+		if (i < first || tooOld) && i != lastSuccess {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	if len(kept) > maxEntries { // The latest successful entry is kept over the limit
+		kept = slices.Delete(kept, 1, len(kept)-maxEntries+1)
+	}
+	return kept, len(entries) - len(kept)
+}
+
+// Save writes the entries to the history file at path, replacing its content.
+func Save(path string, entries []Entry) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := encoder.Encode(e); err != nil {
+			return apperrors.Wrap("failed to encode history entry", err)
+		}
+	}
+
+	if err := utils.EnsureDirExists(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return apperrors.Wrap("failed to write history file", err, path)
+	}
+	return nil
+}
+
+// Load reads the entries of the history file at path, oldest first. A missing file
+// returns no entries and lines that cannot be parsed are ignored.
+func Load(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, apperrors.Wrap("failed to read history file", err, path)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, apperrors.Wrap("failed to read history file", err, path)
+	}
+	return entries, nil
+}
+
+// Filter returns the entries of command (any command when empty) recorded after since
+// (any time when zero).
+func Filter(entries []Entry, command string, since time.Time) []Entry {
+	var filtered []Entry
+	for _, entry := range entries {
+		if command != "" && entry.Command != command {
+			continue
+		}
+		if !since.IsZero() && entry.Time.Before(since) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+// Summarize aggregates the entries per command, slowest average first.
+func Summarize(entries []Entry) []CommandStats {
+	byCommand := make(map[string]*CommandStats)
+	for _, entry := range entries {
+		stats, ok := byCommand[entry.Command]
+		if !ok {
+			stats = &CommandStats{Command: entry.Command}
+			byCommand[entry.Command] = stats
+		}
+		stats.Runs++
+		stats.Total += entry.Duration
+		stats.Max = max(stats.Max, entry.Duration)
+		if entry.Result == ResultError {
+			stats.Failures++
+		}
+	}
+
+	summary := make([]CommandStats, 0, len(byCommand))
+	for _, stats := range byCommand {
+		stats.Average = stats.Total / time.Duration(stats.Runs)
+		summary = append(summary, *stats)
+	}
+	slices.SortFunc(summary, func(a, b CommandStats) int {
+		return cmp.Or(cmp.Compare(b.Average, a.Average), cmp.Compare(a.Command, b.Command))
+	})
+	return summary
+}
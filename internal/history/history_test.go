@@ -0,0 +1,131 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppend_KeepsLatestEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tempo", "history.jsonl")
+
+	for i := range 5 {
+		entry := Entry{Command: "sync", Duration: time.Duration(i) * time.Second, Result: ResultSuccess}
+		if err := Append(path, entry, 3, 0); err != nil {
+			t.Fatalf("Append() returned an error: %v", err)
+		}
+	}
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned an error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].Duration != 2*time.Second || entries[2].Duration != 4*time.Second {
+		t.Errorf("Expected the latest entries, oldest first, got %+v", entries)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tempDir := t.TempDir()
+
+	entries, err := Load(filepath.Join(tempDir, "missing.jsonl"))
+	if err != nil || entries != nil {
+		t.Fatalf("Expected no entries for a missing file, got %v, %v", entries, err)
+	}
+
+	path := filepath.Join(tempDir, "history.jsonl")
+	content := `{"command":"sync","result":"success"}` + "\nnot json\n" + `{"command":"fmt","result":"error"}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write history file: %v", err)
+	}
+
+	entries, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned an error: %v", err)
+	}
+	if len(entries) != 2 || entries[1].Command != "fmt" {
+		t.Errorf("Expected the valid entries only, got %+v", entries)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	now := time.Now()
+	entries := []Entry{
+		{Command: "sync", Time: now.Add(-48 * time.Hour)},
+		{Command: "sync", Time: now},
+		{Command: "component new", Time: now},
+	}
+
+	if got := Filter(entries, "sync", time.Time{}); len(got) != 2 {
+		t.Errorf("Expected 2 sync entries, got %+v", got)
+	}
+	if got := Filter(entries, "", now.Add(-time.Hour)); len(got) != 2 {
+		t.Errorf("Expected 2 recent entries, got %+v", got)
+	}
+	if got := Filter(entries, "sync", now.Add(-time.Hour)); len(got) != 1 {
+		t.Errorf("Expected 1 recent sync entry, got %+v", got)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	entries := []Entry{
+		{Command: "fmt", Duration: time.Second, Result: ResultSuccess},
+		{Command: "sync", Duration: 2 * time.Second, Result: ResultSuccess},
+		{Command: "sync", Duration: 4 * time.Second, Result: ResultError},
+	}
+
+	stats := Summarize(entries)
+	if len(stats) != 2 {
+		t.Fatalf("Expected stats for 2 commands, got %+v", stats)
+	}
+
+	want := CommandStats{Command: "sync", Runs: 2, Failures: 1, Average: 3 * time.Second, Max: 4 * time.Second, Total: 6 * time.Second}
+	if stats[0] != want {
+		t.Errorf("Expected the slowest command first %+v, got %+v", want, stats[0])
+	}
+	if stats[1].Command != "fmt" {
+		t.Errorf("Expected fmt last, got %+v", stats[1])
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Command: "a", Time: now.Add(-40 * 24 * time.Hour), Result: ResultSuccess},
+		{Command: "b", Time: now.Add(-3 * time.Hour), Result: ResultSuccess},
+		{Command: "c", Time: now.Add(-2 * time.Hour), Result: ResultError},
+		{Command: "d", Time: now.Add(-time.Hour), Result: ResultError},
+	}
+	commands := func(entries []Entry) string {
+		var names string
+		for _, e := range entries {
+			names += e.Command
+		}
+		return names
+	}
+
+	tests := []struct {
+		name        string
+		maxEntries  int
+		maxAge      time.Duration
+		wantKept    string
+		wantRemoved int
+	}{
+		{"defaults", 0, 0, "abcd", 0},
+		{"max age", 0, 30 * 24 * time.Hour, "bcd", 1},
+		{"keeps the latest success", 2, 0, "bd", 2},
+		{"latest success older than the age", 0, time.Hour / 2, "b", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, removed := Prune(entries, tt.maxEntries, tt.maxAge, now)
+			if commands(kept) != tt.wantKept || removed != tt.wantRemoved {
+				t.Errorf("Expected %q kept and %d removed, got %q and %d", tt.wantKept, tt.wantRemoved, commands(kept), removed)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Package htmlimport converts an HTML fragment, e.g. a designer prototype, into the
+// initial templ markup and CSS of a component, for `tempo component new --from-html`.
+// The conversion is heuristic: the result is a starting point to review, not a
+// finished component.
+package htmlimport
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// indentUnit indents the nested elements of the markup, as in the built-in templates.
+const indentUnit = "    "
+
+// voidElements are written self-closed, e.g. <br/>.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// booleanAttributes are written without value when empty, e.g. disabled. The other
+// empty attributes keep their empty value, e.g. alt="".
+var booleanAttributes = map[string]bool{
+	"allowfullscreen": true, "async": true, "autofocus": true, "autoplay": true, "checked": true,
+	"controls": true, "default": true, "defer": true, "disabled": true, "formnovalidate": true,
+	"hidden": true, "inert": true, "ismap": true, "itemscope": true, "loop": true, "multiple": true,
+	"muted": true, "nomodule": true, "novalidate": true, "open": true, "playsinline": true,
+	"readonly": true, "required": true, "reversed": true, "selected": true,
+}
+
+// textEscaper escapes the text content: HTML special characters, and the braces
+// starting and ending the templ expressions.
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "{", `{ "{" }`, "}", `{ "}" }`)
+
+// attrEscaper escapes the quoted attribute values.
+var attrEscaper = strings.NewReplacer("&", "&amp;", `"`, "&quot;")
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Result is the converted fragment.
+type Result struct {
+	Markup string // templ markup of the component body, not indented
+	Styles string // CSS of the <style> elements and the inline styles, not indented
+}
+
+// converter holds the state of a conversion.
+type converter struct {
+	prefix  string
+	markup  strings.Builder
+	styles  []string
+	classes int
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// Convert parses the HTML fragment read from r and returns its templ markup and CSS.
+// The <style> elements are moved to the CSS, and the inline style attributes are
+// replaced with generated classes named after prefix, e.g. button-1. The text braces
+// are escaped, the void elements self-closed and the whitespace between the elements
+// dropped.
+func Convert(r io.Reader, prefix string) (Result, error) {
+	nodes, err := html.ParseFragment(r, &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return Result{}, apperrors.Wrap("failed to parse HTML fragment", err)
+	}
+
+	c := &converter{prefix: prefix}
+	for _, n := range nodes {
+		c.writeNode(n, 0)
+	}
+
+	markup := strings.TrimSpace(c.markup.String())
+	if markup == "" && len(c.styles) == 0 {
+		return Result{}, apperrors.Wrap("the HTML fragment has no markup")
+	}
+	return Result{Markup: markup, Styles: strings.Join(c.styles, "\n\n")}, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// writeNode writes n and its children at the given depth.
+func (c *converter) writeNode(n *html.Node, depth int) {
+	indent := strings.Repeat(indentUnit, depth)
+
+	switch n.Type {
+	case html.TextNode:
+		if text := collapseSpace(n.Data); text != "" {
+			c.markup.WriteString(indent + textEscaper.Replace(text) + "\n")
+		}
+	case html.CommentNode:
+		c.markup.WriteString(indent + "<!-- " + strings.TrimSpace(n.Data) + " -->\n")
+	case html.ElementNode:
+		c.writeElement(n, depth)
+	case html.DocumentNode:
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			c.writeNode(child, depth)
+		}
+	}
+}
+
+// writeElement writes the element n: inline when its only child is a text, nested
+// otherwise.
+func (c *converter) writeElement(n *html.Node, depth int) {
+	indent := strings.Repeat(indentUnit, depth)
+
+	if n.DataAtom == atom.Style {
+		if css := strings.TrimSpace(textContent(n)); css != "" {
+			c.styles = append(c.styles, css)
+		}
+		return
+	}
+
+	open := "<" + n.Data + c.attributes(n)
+	if voidElements[n.Data] {
+		c.markup.WriteString(indent + open + "/>\n")
+		return
+	}
+	closeTag := "</" + n.Data + ">"
+
+	// Raw text elements keep their content as is
+	if n.DataAtom == atom.Script || n.DataAtom == atom.Pre || n.DataAtom == atom.Textarea {
+		c.markup.WriteString(indent + open + ">" + rawContent(n) + closeTag + "\n")
+		return
+	}
+
+	if text, ok := onlyText(n); ok {
+		c.markup.WriteString(indent + open + ">" + textEscaper.Replace(text) + closeTag + "\n")
+		return
+	}
+
+	c.markup.WriteString(indent + open + ">\n")
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.writeNode(child, depth+1)
+	}
+	c.markup.WriteString(indent + closeTag + "\n")
+}
+
+// attributes returns the attributes of n, with the inline style moved to a generated
+// class.
+func (c *converter) attributes(n *html.Node) string {
+	var style string
+	attrs := make([]html.Attribute, 0, len(n.Attr))
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == "style" {
+			style = attr.Val
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+
+	if rule := cssDeclarations(style); rule != "" {
+		c.classes++
+		class := fmt.Sprintf("%s-%d", c.prefix, c.classes)
+		c.styles = append(c.styles, "."+class+" {\n"+rule+"}")
+		attrs = addClass(attrs, class)
+	}
+
+	var sb strings.Builder
+	for _, attr := range attrs {
+		key := attr.Key
+		if attr.Namespace != "" {
+			key = attr.Namespace + ":" + key
+		}
+		sb.WriteString(" " + key)
+		if attr.Val != "" || !booleanAttributes[key] {
+			sb.WriteString(`="` + attrEscaper.Replace(attr.Val) + `"`)
+		}
+	}
+	return sb.String()
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// addClass appends class to the class attribute of attrs, adding it when missing.
+func addClass(attrs []html.Attribute, class string) []html.Attribute {
+	for i, attr := range attrs {
+		if attr.Namespace == "" && attr.Key == "class" {
+			attrs[i].Val = strings.TrimSpace(attr.Val + " " + class)
+			return attrs
+		}
+	}
+	return append(attrs, html.Attribute{Key: "class", Val: class})
+}
+
+// cssDeclarations returns the declarations of an inline style, one per line.
+func cssDeclarations(style string) string {
+	var sb strings.Builder
+	for decl := range strings.SplitSeq(style, ";") {
+		if decl = strings.TrimSpace(decl); decl != "" {
+			sb.WriteString("  " + decl + ";\n")
+		}
+	}
+	return sb.String()
+}
+
+// onlyText returns the collapsed text of n when it is its only child.
+func onlyText(n *html.Node) (string, bool) {
+	if n.FirstChild == nil {
+		return "", true
+	}
+	if n.FirstChild != n.LastChild || n.FirstChild.Type != html.TextNode {
+		return "", false
+	}
+	return collapseSpace(n.FirstChild.Data), true
+}
+
+// textContent returns the text of the children of n.
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.TextNode {
+			sb.WriteString(child.Data)
+		}
+	}
+	return sb.String()
+}
+
+// rawContent returns the content of a raw text element: as is for scripts, escaped
+// otherwise.
+func rawContent(n *html.Node) string {
+	text := textContent(n)
+	if n.DataAtom == atom.Script {
+		return text
+	}
+	return textEscaper.Replace(text)
+}
+
+// collapseSpace replaces the runs of whitespace of s with a single space, and trims it.
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
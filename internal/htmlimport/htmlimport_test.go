@@ -0,0 +1,44 @@
+package htmlimport
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	snippet := `<style>
+  .card { padding: 1rem; }
+</style>
+<div class="card" style="color: red; margin: 0">
+  <h2>Title {draft}</h2>
+  <img src="a.png" alt="">
+  <button disabled data-x='say "hi"'>Go</button>
+  <!-- footer -->
+</div>`
+
+	result, err := Convert(strings.NewReader(snippet), "card")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	wantMarkup := `<div class="card card-1">
+    <h2>Title { "{" }draft{ "}" }</h2>
+    <img src="a.png" alt=""/>
+    <button disabled data-x="say &quot;hi&quot;">Go</button>
+    <!-- footer -->
+</div>`
+	if result.Markup != wantMarkup {
+		t.Errorf("Unexpected markup:\n%s\nwant:\n%s", result.Markup, wantMarkup)
+	}
+
+	wantStyles := ".card { padding: 1rem; }\n\n.card-1 {\n  color: red;\n  margin: 0;\n}"
+	if result.Styles != wantStyles {
+		t.Errorf("Unexpected styles:\n%s\nwant:\n%s", result.Styles, wantStyles)
+	}
+}
+
+func TestConvert_Empty(t *testing.T) {
+	if _, err := Convert(strings.NewReader("  \n "), "card"); err == nil {
+		t.Error("Expected an error for an empty fragment, got nil")
+	}
+}
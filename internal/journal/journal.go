@@ -0,0 +1,319 @@
+// Package journal records the generations of components with their template data and
+// a snapshot of their templates, so that a component can be regenerated as it was.
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// Files of the journal folder, in the tempo root folder.
+const (
+	Dir          = "journal"
+	EntriesFile  = "journal.jsonl"
+	SnapshotsDir = "snapshots"
+	ActionsFile  = "actions.json"
+	TemplatesDir = "templates"
+	idTimeLayout = "20060102T150405.000"
+)
+
+// DefaultMaxEntries is the number of entries kept when none is configured.
+const DefaultMaxEntries = 200
+
+// Retention limits the entries kept in the journal.
+type Retention struct {
+	MaxEntries int           // Entries kept, oldest removed first; DefaultMaxEntries when not positive
+	MaxAge     time.Duration // Age of the oldest entry kept; no limit when not positive
+}
+
+// RetentionFromConfig returns the retention set in the journal config.
+func RetentionFromConfig(cfg config.Journal) (Retention, error) {
+	maxAge, err := cfg.Age()
+	if err != nil {
+		return Retention{}, err
+	}
+	return Retention{MaxEntries: cfg.MaxEntries, MaxAge: maxAge}, nil
+}
+
+// Entry is a recorded generation of an entity.
+type Entry struct {
+	ID          string                 `json:"id"` // e.g. 20261016T101500.000-button
+	Time        time.Time              `json:"time"`
+	Entity      string                 `json:"entity"` // e.g. "component"
+	Name        string                 `json:"name"`
+	ActionsFile string                 `json:"actions_file"` // Actions file used at the time
+	Data        generator.TemplateData `json:"data"`
+	CLIUserData map[string]any         `json:"cli_user_data,omitempty"` // --data and --data-file values
+}
+
+// Journal is the journal folder of a project.
+type Journal struct {
+	root      string
+	retention Retention
+}
+
+// New returns the journal stored in the tempo root folder, pruned with retention on
+// each recorded generation.
+func New(tempoRoot string, retention Retention) *Journal {
+	return &Journal{root: filepath.Join(tempoRoot, Dir), retention: retention}
+}
+
+// SnapshotDir returns the folder holding the actions file and the templates of entry.
+func (j *Journal) SnapshotDir(id string) string {
+	return filepath.Join(j.root, SnapshotsDir, id)
+}
+
+// Record adds a generation of the entity name, rendered with data and the actions file,
+// to the journal, with a snapshot of the actions file and of the entity template folders
+// it uses. The entries beyond the retention, and their snapshot, are removed (see Prune).
+func (j *Journal) Record(entity, name, actionsFile string, data *generator.TemplateData) (Entry, error) {
+	entries, err := j.Load()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	now := time.Now()
+	entry := Entry{
+		ID:          now.UTC().Format(idTimeLayout) + "-" + name,
+		Time:        now,
+		Entity:      entity,
+		Name:        name,
+		ActionsFile: actionsFile,
+		Data:        *data,
+		CLIUserData: data.CLIUserData,
+	}
+	for n := 2; slices.ContainsFunc(entries, func(e Entry) bool { return e.ID == entry.ID }); n++ {
+		entry.ID = fmt.Sprintf("%s-%s-%d", now.UTC().Format(idTimeLayout), name, n)
+	}
+
+	if err := j.snapshot(entry.ID, actionsFile, data.TemplatesDir); err != nil {
+		return Entry{}, err
+	}
+
+	kept, removed := Prune(append(entries, entry), j.retention, now)
+	if err := j.removeSnapshots(removed); err != nil {
+		return Entry{}, err
+	}
+	return entry, j.save(kept)
+}
+
+// PruneFile removes the entries of the journal beyond its retention, and their
+// snapshot, and returns the kept and removed entries. With dryRun, nothing is removed.
+func (j *Journal) PruneFile(dryRun bool) (kept, removed []Entry, err error) {
+	entries, err := j.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	kept, removed = Prune(entries, j.retention, time.Now())
+	if dryRun || len(removed) == 0 {
+		return kept, removed, nil
+	}
+	if err := j.removeSnapshots(removed); err != nil {
+		return nil, nil, err
+	}
+	return kept, removed, j.save(kept)
+}
+
+// Prune splits the entries into the ones kept by retention and the removed ones, both
+// oldest first. The latest entry of each entity, the state it can be regenerated
+// from, is always kept.
+func Prune(entries []Entry, retention Retention, now time.Time) (kept, removed []Entry) {
+	maxEntries := retention.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+
+	latest := make(map[string]int) // Index of the latest entry of each entity
+	for i, e := range entries {
+		latest[e.Entity+"/"+e.Name] = i
+	}
+
+	// Walk the entries latest first, keeping the protected ones and the recent ones
+	// within the limit
+	keep := make([]bool, len(entries))
+	count := 0
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if latest[e.Entity+"/"+e.Name] == i {
+			keep[i] = true
+			count++
+		}
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if keep[i] || count >= maxEntries || (retention.MaxAge > 0 && e.Time.Before(now.Add(-retention.MaxAge))) {
+			continue
+		}
+		keep[i] = true
+		count++
+	}
+
+	for i, e := range entries {
+		if keep[i] {
+			kept = append(kept, e)
+		} else {
+			removed = append(removed, e)
+		}
+	}
+	return kept, removed
+}
+
+// removeSnapshots removes the snapshot folders of the entries.
+func (j *Journal) removeSnapshots(entries []Entry) error {
+	for _, e := range entries {
+		if err := os.RemoveAll(j.SnapshotDir(e.ID)); err != nil {
+			return apperrors.Wrap("failed to remove journal snapshot", err, e.ID)
+		}
+	}
+	return nil
+}
+
+// Load reads the entries of the journal, oldest first. A missing journal has no entries
+// and lines that cannot be parsed are ignored.
+func (j *Journal) Load() ([]Entry, error) {
+	path := filepath.Join(j.root, EntriesFile)
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, apperrors.Wrap("failed to read journal", err, path)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, apperrors.Wrap("failed to read journal", err, path)
+	}
+	return entries, nil
+}
+
+// Find returns the entry of the entity name matching at: the entry with this ID, or the
+// latest one recorded at or before this time (RFC 3339, "2006-01-02T15:04:05" or
+// "2006-01-02", in local time). An empty at returns the latest entry.
+func Find(entries []Entry, entity, name, at string) (Entry, error) {
+	var until time.Time
+	if at != "" {
+		for _, e := range entries {
+			if e.ID == at {
+				if e.Entity != entity || e.Name != name {
+					return Entry{}, apperrors.Wrap("journal entry %s records the %s '%s'", at, e.Entity, e.Name)
+				}
+				return e, nil
+			}
+		}
+		t, err := parseTime(at)
+		if err != nil {
+			return Entry{}, err
+		}
+		until = t
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.Entity != entity || e.Name != name || (!until.IsZero() && e.Time.After(until)) {
+			continue
+		}
+		return e, nil
+	}
+	if at != "" {
+		return Entry{}, apperrors.Wrap("no generation of the %s '%s' recorded at or before %s", entity, name, at)
+	}
+	return Entry{}, apperrors.Wrap("no generation of the %s '%s' recorded in the journal", entity, name)
+}
+
+// parseTime parses a time given as RFC 3339, date and time or date. A date matches the
+// whole day.
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04:05", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t.Add(24*time.Hour - time.Nanosecond), nil
+	}
+	return time.Time{}, apperrors.Wrap("invalid '--at' value %s: expected a journal entry ID or a time such as 2006-01-02T15:04:05", value)
+}
+
+// snapshot copies the actions file and the entity template folders it uses.
+func (j *Journal) snapshot(id, actionsFile, templatesDir string) error {
+	dir := j.SnapshotDir(id)
+	actions, err := generator.LoadUserActions(actionsFile)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(actionsFile)
+	if err != nil {
+		return apperrors.Wrap("failed to read actions file", err, actionsFile)
+	}
+	if err := utils.WriteToFile(filepath.Join(dir, ActionsFile), content); err != nil {
+		return apperrors.Wrap("failed to write journal snapshot", err, dir)
+	}
+
+	copied := make(map[string]bool)
+	for _, action := range actions {
+		rel := action.TemplateFile
+		if rel == "" {
+			rel = action.Source
+		}
+		entityDir, _, _ := strings.Cut(filepath.ToSlash(filepath.Clean(rel)), "/")
+		if entityDir == "" || entityDir == "." || entityDir == ".." || copied[entityDir] {
+			continue
+		}
+		copied[entityDir] = true
+
+		src := filepath.Join(templatesDir, entityDir)
+		if exists, err := utils.DirExists(src); err != nil || !exists {
+			continue
+		}
+		if err := os.CopyFS(filepath.Join(dir, TemplatesDir, entityDir), os.DirFS(src)); err != nil {
+			return apperrors.Wrap("failed to write journal snapshot", err, dir)
+		}
+	}
+	return nil
+}
+
+// save writes the entries to the journal file.
+func (j *Journal) save(entries []Entry) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := encoder.Encode(e); err != nil {
+			return apperrors.Wrap("failed to encode journal entry", err)
+		}
+	}
+
+	path := filepath.Join(j.root, EntriesFile)
+	if err := utils.EnsureDirExists(j.root); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return apperrors.Wrap("failed to write journal", err, path)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+// setupTemplates writes an actions file rendering a template of the component folder
+// and returns the actions file and the templates folder.
+func setupTemplates(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	templatesDir := filepath.Join(dir, "templates")
+	actionsFile := filepath.Join(dir, "actions", "component.json")
+	testutils.CreateFile(t, filepath.Join(templatesDir, "component", "templ", "component.templ.gotxt"), "package {{ .ComponentName }}")
+	testutils.CreateFile(t, filepath.Join(templatesDir, "other", "unused.gotxt"), "unused")
+	testutils.CreateFile(t, actionsFile, `[{"item":"file","templateFile":"component/templ/component.templ.gotxt","path":"{{ .GoPackage }}/{{ .ComponentName }}/{{ .ComponentName }}.templ"}]`)
+	return actionsFile, templatesDir
+}
+
+func TestJournal_Record(t *testing.T) {
+	actionsFile, templatesDir := setupTemplates(t)
+	j := New(filepath.Join(t.TempDir(), ".tempo-files"), Retention{})
+
+	data := &generator.TemplateData{
+		TemplatesDir:  templatesDir,
+		ComponentName: "button",
+		WithJs:        true,
+		CLIUserData:   map[string]any{"theme": "dark"},
+	}
+	entry, err := j.Record("component", "button", actionsFile, data)
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if !strings.HasSuffix(entry.ID, "-button") {
+		t.Errorf("Unexpected entry ID: %s", entry.ID)
+	}
+
+	entries, err := j.Load()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected one entry, got %d (err=%v)", len(entries), err)
+	}
+	got := entries[0]
+	if got.ID != entry.ID || !got.Data.WithJs || got.CLIUserData["theme"] != "dark" || got.ActionsFile != actionsFile {
+		t.Errorf("Unexpected entry: %+v", got)
+	}
+
+	snapshot := j.SnapshotDir(entry.ID)
+	if _, err := os.Stat(filepath.Join(snapshot, ActionsFile)); err != nil {
+		t.Errorf("Expected the actions file in the snapshot: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(snapshot, TemplatesDir, "component", "templ", "component.templ.gotxt")); err != nil {
+		t.Errorf("Expected the component templates in the snapshot: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(snapshot, TemplatesDir, "other")); !os.IsNotExist(err) {
+		t.Errorf("Expected the unused templates to be left out of the snapshot")
+	}
+
+	// A second generation in the same millisecond gets a distinct ID
+	second, err := j.Record("component", "button", actionsFile, data)
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if second.ID == entry.ID {
+		t.Errorf("Expected distinct entry IDs, got %s twice", entry.ID)
+	}
+}
+
+func TestFind(t *testing.T) {
+	base := time.Date(2026, 10, 16, 10, 0, 0, 0, time.Local)
+	entries := []Entry{
+		{ID: "a", Entity: "component", Name: "button", Time: base},
+		{ID: "b", Entity: "component", Name: "card", Time: base.Add(time.Hour)},
+		{ID: "c", Entity: "component", Name: "button", Time: base.Add(2 * time.Hour)},
+		{ID: "d", Entity: "component", Name: "button", Time: base.Add(48 * time.Hour)},
+	}
+
+	tests := []struct {
+		name    string
+		at      string
+		want    string
+		wantErr bool
+	}{
+		{name: "latest", at: "", want: "d"},
+		{name: "by ID", at: "a", want: "a"},
+		{name: "ID of another component", at: "b", wantErr: true},
+		{name: "before a time", at: "2026-10-16T11:30:00", want: "a"},
+		{name: "date matches the whole day", at: "2026-10-16", want: "c"},
+		{name: "RFC 3339", at: base.Add(3 * time.Hour).Format(time.RFC3339), want: "c"},
+		{name: "before the first generation", at: "2026-10-15", wantErr: true},
+		{name: "invalid", at: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Find(entries, "component", "button", tt.at)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got entry %s", got.ID)
+				}
+				return
+			}
+			if err != nil || got.ID != tt.want {
+				t.Errorf("Find() = %s, %v; want %s", got.ID, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := Find(entries, "component", "modal", ""); err == nil {
+		t.Errorf("Expected an error for a component never generated")
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{ID: "a", Entity: "component", Name: "card", Time: now.Add(-60 * 24 * time.Hour)},
+		{ID: "b", Entity: "component", Name: "button", Time: now.Add(-50 * 24 * time.Hour)},
+		{ID: "c", Entity: "component", Name: "button", Time: now.Add(-2 * time.Hour)},
+		{ID: "d", Entity: "component", Name: "button", Time: now.Add(-time.Hour)},
+		{ID: "e", Entity: "component", Name: "button", Time: now},
+	}
+	ids := func(entries []Entry) string {
+		var ids []string
+		for _, e := range entries {
+			ids = append(ids, e.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	tests := []struct {
+		name        string
+		retention   Retention
+		wantKept    string
+		wantRemoved string
+	}{
+		{"defaults", Retention{}, "a,b,c,d,e", ""},
+		{"max entries", Retention{MaxEntries: 3}, "a,d,e", "b,c"},
+		{"max age", Retention{MaxAge: 30 * 24 * time.Hour}, "a,c,d,e", "b"},
+		{"both", Retention{MaxEntries: 2, MaxAge: 30 * 24 * time.Hour}, "a,e", "b,c,d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, removed := Prune(entries, tt.retention, now)
+			if ids(kept) != tt.wantKept || ids(removed) != tt.wantRemoved {
+				t.Errorf("Expected kept %q and removed %q, got %q and %q", tt.wantKept, tt.wantRemoved, ids(kept), ids(removed))
+			}
+		})
+	}
+}
+
+func TestJournal_PruneFile(t *testing.T) {
+	actionsFile, templatesDir := setupTemplates(t)
+	tempoRoot := filepath.Join(t.TempDir(), ".tempo-files")
+
+	data := &generator.TemplateData{TemplatesDir: templatesDir, ComponentName: "button"}
+	first, err := New(tempoRoot, Retention{}).Record("component", "button", actionsFile, data)
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if _, err := New(tempoRoot, Retention{}).Record("component", "button", actionsFile, data); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	j := New(tempoRoot, Retention{MaxEntries: 1})
+	kept, removed, err := j.PruneFile(true)
+	if err != nil || len(kept) != 1 || len(removed) != 1 || removed[0].ID != first.ID {
+		t.Fatalf("Expected the first entry to be pruned, got kept %v, removed %v (err=%v)", kept, removed, err)
+	}
+	if entries, _ := j.Load(); len(entries) != 2 {
+		t.Errorf("Expected the dry run to keep the journal, got %d entries", len(entries))
+	}
+
+	if _, _, err := j.PruneFile(false); err != nil {
+		t.Fatalf("PruneFile failed: %v", err)
+	}
+	if entries, _ := j.Load(); len(entries) != 1 || entries[0].ID == first.ID {
+		t.Errorf("Expected the latest entry to be kept, got %+v", entries)
+	}
+	if _, err := os.Stat(j.SnapshotDir(first.ID)); !os.IsNotExist(err) {
+		t.Errorf("Expected the snapshot of the pruned entry to be removed")
+	}
+}
@@ -0,0 +1,199 @@
+// Package jsonoutput turns a tempo run into a structured result, printed instead of the
+// human text with --output-format json, so that tools wrapping tempo do not scrape its output.
+package jsonoutput
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/logger"
+)
+
+// Output formats of the --output-format flag.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Statuses of a completed run.
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+// Result is the JSON document printed for a completed run.
+type Result struct {
+	Command    string          `json:"command"` // e.g. "sync" or "component new"
+	Status     string          `json:"status"`
+	Error      string          `json:"error,omitempty"`
+	Code       string          `json:"code,omitempty"` // Error code, e.g. TEMPO-E001
+	DurationMs int64           `json:"duration_ms"`
+	Files      []string        `json:"files"`    // Files written by the generator actions or by sync
+	Warnings   []string        `json:"warnings"` // Messages of the warning entries
+	Errors     []string        `json:"errors"`   // Messages of the error entries and the run error
+	Messages   []logger.Record `json:"messages"` // All the log entries, in order
+	Summary    map[string]any  `json:"summary,omitempty"`
+	Output     string          `json:"output,omitempty"` // Other text printed by the command, e.g. tables
+}
+
+// Recorder is a logger collecting its entries instead of printing them.
+type Recorder interface {
+	StartRecording()
+	Records() []logger.Record
+}
+
+// Session collects the outcome of a run: the log entries, the written files and the text
+// printed on the standard output.
+type Session struct {
+	recorder Recorder
+	files    *generator.FileRecorder
+	stdout   *os.File
+	pipe     *os.File
+	output   chan string
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// ValidateFormat returns an error when format is not a known output format.
+func ValidateFormat(format string) error {
+	if format != FormatText && format != FormatJSON {
+		return apperrors.Wrap("unknown output format '%s' (expected %s or %s)", format, FormatText, FormatJSON)
+	}
+	return nil
+}
+
+// Start starts collecting the outcome of the run: log supports recording, the files
+// written by the actions run with the returned context are recorded and the standard
+// output is captured until Finish.
+func Start(ctx context.Context, log logger.Logger) (context.Context, *Session, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return ctx, nil, apperrors.Wrap("failed to capture the standard output", err)
+	}
+
+	s := &Session{
+		files:  generator.NewFileRecorder(),
+		stdout: os.Stdout,
+		pipe:   w,
+		output: make(chan string),
+	}
+	if recorder, ok := log.(Recorder); ok {
+		recorder.StartRecording()
+		s.recorder = recorder
+	}
+
+	go func() {
+		var buf bytes.Buffer
+		_, _ = buf.ReadFrom(r)
+		_ = r.Close()
+		s.output <- buf.String()
+	}()
+	os.Stdout = w
+
+	return generator.WithActionHooks(ctx, func(h *generator.ActionHooks) { h.Recorder = s.files }), s, nil
+}
+
+// Finish restores the standard output and returns the result of the run of command
+// completed with runErr.
+func (s *Session) Finish(command string, duration time.Duration, summary map[string]any, runErr error) Result {
+	os.Stdout = s.stdout
+	_ = s.pipe.Close()
+	output := <-s.output
+
+	res := Result{
+		Command:    command,
+		Status:     StatusSuccess,
+		DurationMs: duration.Milliseconds(),
+		Files:      displayPaths(s.files.Paths()),
+		Warnings:   []string{},
+		Errors:     []string{},
+		Messages:   []logger.Record{},
+		Summary:    displaySummary(summary),
+		Output:     output,
+	}
+	if s.recorder != nil {
+		res.Messages = s.recorder.Records()
+	}
+	for _, m := range res.Messages {
+		switch m.Level {
+		case "warning":
+			res.Warnings = append(res.Warnings, m.Message)
+		case "error":
+			res.Errors = append(res.Errors, m.Message)
+		}
+	}
+	if runErr != nil {
+		res.Status = StatusError
+		res.Error = runErr.Error()
+		res.Code = apperrors.CodeOf(runErr)
+		res.Errors = append(res.Errors, res.Error)
+	}
+	return res
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// displayPaths returns the paths as printed (see logger.DisplayPath).
+func displayPaths(paths []string) []string {
+	for i, path := range paths {
+		paths[i] = logger.DisplayPath(path)
+	}
+	return paths
+}
+
+// displaySummary returns the summary with its paths as printed when they are printed
+// relative to the project root (see logger.SetPathRoot). The summary goes through JSON,
+// so that the paths held by structs are found too.
+func displaySummary(summary map[string]any) map[string]any {
+	if summary == nil || !logger.RelativePaths() {
+		return summary
+	}
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return summary
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var decoded map[string]any
+	if err := dec.Decode(&decoded); err != nil {
+		return summary
+	}
+	return displayValue(decoded).(map[string]any)
+}
+
+// displayValue replaces the paths of a decoded JSON value with their printed form.
+func displayValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return logger.DisplayPath(v)
+	case map[string]any:
+		for key, item := range v {
+			v[key] = displayValue(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = displayValue(item)
+		}
+	}
+	return value
+}
+
+// Write writes the result to w as indented JSON.
+func Write(w io.Writer, res Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(res); err != nil {
+		return apperrors.Wrap("failed to write the JSON output", err)
+	}
+	return nil
+}
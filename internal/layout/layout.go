@@ -0,0 +1,58 @@
+// Package layout records the folders holding the assets and the components of a
+// project, so that files stranded by a change of the config paths can be moved.
+package layout
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// ManifestFile is the name of the file, in the tempo root folder, recording the layout
+// of the last sync or migration.
+const ManifestFile = "layout.json"
+
+// Layout is the location of the project files.
+type Layout struct {
+	AssetsDir string `json:"assets_dir"` // Folder of the CSS and JS files
+	GoPackage string `json:"go_package"` // Folder of the component packages
+}
+
+// ManifestPath returns the path of the layout manifest in the tempo root folder.
+func ManifestPath(tempoRoot string) string {
+	return filepath.Join(tempoRoot, ManifestFile)
+}
+
+// Load reads the layout manifest at path. It reports false when the file is missing.
+func Load(path string) (Layout, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Layout{}, false, nil
+		}
+		return Layout{}, false, apperrors.Wrap("failed to read layout manifest", err, path)
+	}
+
+	var l Layout
+	if err := json.Unmarshal(data, &l); err != nil {
+		return Layout{}, false, apperrors.Wrap("invalid layout manifest", err, path)
+	}
+	return l, true, nil
+}
+
+// Save writes the layout manifest at path, with cleaned folder paths.
+func Save(path string, l Layout) error {
+	l = Layout{AssetsDir: filepath.Clean(l.AssetsDir), GoPackage: filepath.Clean(l.GoPackage)}
+	if existing, ok, err := Load(path); err == nil && ok && existing == l {
+		return nil
+	}
+	if err := utils.WriteJSONToFile(path, l); err != nil {
+		return apperrors.Wrap("failed to write layout manifest", err, path)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package layout
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	path := ManifestPath(filepath.Join(t.TempDir(), ".tempo-files"))
+
+	if _, ok, err := Load(path); err != nil || ok {
+		t.Fatalf("Expected no manifest, got ok=%v err=%v", ok, err)
+	}
+
+	if err := Save(path, Layout{AssetsDir: "assets/", GoPackage: "./components"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, ok, err := Load(path)
+	if err != nil || !ok {
+		t.Fatalf("Expected a manifest, got ok=%v err=%v", ok, err)
+	}
+	if want := (Layout{AssetsDir: "assets", GoPackage: "components"}); got != want {
+		t.Errorf("Unexpected layout: got %+v, want %+v", got, want)
+	}
+}
@@ -38,6 +38,7 @@ type LogEntry struct {
 	level     string         // Log level (info, success, warning, error)
 	icon      string         // Icon associated with the log level
 	message   string         // Main log message
+	text      string         // Main log message, without styling
 	attrs     []KeyValue     // Attributes stored in insertion order
 	timestamp *time.Time     // Optional timestamp for the log entry
 	logger    *DefaultLogger // Reference to the logger instance
@@ -54,9 +55,18 @@ type KeyValue struct {
 type DefaultLogger struct {
 	indentEnabled    bool
 	timestampEnabled bool
+	recording        bool
+	records          []*LogEntry
 	mu               sync.Mutex
 }
 
+// Record is a log entry collected by a recording logger.
+type Record struct {
+	Level   string         `json:"level"`
+	Message string         `json:"message"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
 // levels holds the log levels and their associated icons.
 var levels = map[string]string{
 	"default": "",
@@ -139,9 +149,34 @@ func (l *DefaultLogger) Hint(message string, args ...any) *LogEntry {
 
 // Blank prints a blank line
 func (l *DefaultLogger) Blank() {
+	if l.isRecording() {
+		return
+	}
 	mustWriteln(color.Output)
 }
 
+// StartRecording makes the logger collect its entries, returned by Records, instead of
+// printing them.
+func (l *DefaultLogger) StartRecording() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.recording = true
+}
+
+// Records returns the entries collected since StartRecording, with their attributes.
+func (l *DefaultLogger) Records() []Record {
+	l.mu.Lock()
+	entries := make([]*LogEntry, len(l.records))
+	copy(entries, l.records)
+	l.mu.Unlock()
+
+	records := make([]Record, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, e.record())
+	}
+	return records
+}
+
 // WithIndent enables or disables message indentation.
 func (l *DefaultLogger) WithIndent(enabled bool) {
 	l.mu.Lock()
@@ -162,9 +197,9 @@ func (l *DefaultLogger) WithTimestamp(enabled bool) {
 
 // createLogEntry initializes a new LogEntry with the given level and message.
 func (l *DefaultLogger) createLogEntry(level, message string, args ...any) *LogEntry {
-	icon, ok := levels[level]
-	if !ok {
-		icon = "?" // Default icon for unknown levels
+	icon := "?" // Default icon for unknown levels
+	if _, ok := levels[level]; ok {
+		icon = Icon(level)
 	}
 
 	// Get the style function based on log level
@@ -174,6 +209,7 @@ func (l *DefaultLogger) createLogEntry(level, message string, args ...any) *LogE
 	}
 
 	formattedMessage := styleFunc(message)
+	text := message
 
 	// White (non-bold) formatting for the arguments
 	plain := styleWrapper(color.New(color.FgHiWhite).Sprint)
@@ -184,12 +220,14 @@ func (l *DefaultLogger) createLogEntry(level, message string, args ...any) *LogE
 			formattedArgs = append(formattedArgs, fmt.Sprint(args[i]))
 		}
 		formattedMessage += " " + plain(strings.Join(formattedArgs, " "))
+		text += " " + strings.Join(formattedArgs, " ")
 	}
 
 	entry := &LogEntry{
 		level:   level,
 		icon:    icon,
 		message: formattedMessage,
+		text:    text,
 		attrs:   []KeyValue{},
 		logger:  l,
 	}
@@ -199,10 +237,25 @@ func (l *DefaultLogger) createLogEntry(level, message string, args ...any) *LogE
 		entry.timestamp = &now
 	}
 
+	l.mu.Lock()
+	if l.recording {
+		l.records = append(l.records, entry)
+		l.mu.Unlock()
+		return entry
+	}
+	l.mu.Unlock()
+
 	entry.log()
 	return entry
 }
 
+// isRecording reports whether the logger collects its entries instead of printing them.
+func (l *DefaultLogger) isRecording() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.recording
+}
+
 // styleWrapper wraps a `Sprint` function to match the `func(string) string` signature.
 func styleWrapper(sprintFunc func(a ...any) string) func(string) string {
 	return func(input string) string {
@@ -271,10 +324,32 @@ func (e *LogEntry) WithAttrs(attrs ...any) *LogEntry {
 	}
 
 	e.attrs = append(e.attrs, newAttrs...)
+	if e.logger != nil && e.logger.isRecording() {
+		return e
+	}
 	e.logAttrs()
 	return e
 }
 
+// record returns the entry as a Record, with errors among its attributes as strings.
+func (e *LogEntry) record() Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	r := Record{Level: e.level, Message: e.text}
+	if len(e.attrs) > 0 {
+		r.Attrs = make(map[string]any, len(e.attrs))
+		for _, attr := range e.attrs {
+			if err, ok := attr.Value.(error); ok {
+				r.Attrs[attr.Key] = err.Error()
+				continue
+			}
+			r.Attrs[attr.Key] = displayValue(attr.Value)
+		}
+	}
+	return r
+}
+
 // logAttrs logs the attributes in a structured format.
 func (e *LogEntry) logAttrs() {
 	if len(e.attrs) == 0 {
@@ -285,7 +360,7 @@ func (e *LogEntry) logAttrs() {
 	argColor := color.New(color.Faint).SprintFunc()
 
 	for _, attr := range e.attrs {
-		line := fmt.Sprintf("  - %s: %v", argColor(attr.Key), attr.Value)
+		line := fmt.Sprintf("  - %s: %v", argColor(attr.Key), displayValue(attr.Value))
 		if e.indentEnabled() {
 			line = "  " + line
 		}
@@ -1,6 +1,8 @@
 package logger_test
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -211,3 +213,30 @@ func TestLogAttrsWithIndent(t *testing.T) {
 		})
 	}
 }
+
+func TestLoggerRecording(t *testing.T) {
+	l := logger.NewDefaultLogger()
+	l.StartRecording()
+
+	output, err := testutils.CaptureStdout(func() {
+		l.Info("Generating", "button")
+		l.Blank()
+		l.Warning("Skipped file").WithAttrs("path", "a.templ", "error", errors.New("boom"))
+		l.Success("Done")
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	if output != "" {
+		t.Errorf("expected no output while recording, got: %q", output)
+	}
+
+	expected := []logger.Record{
+		{Level: "info", Message: "Generating button"},
+		{Level: "warning", Message: "Skipped file", Attrs: map[string]any{"path": "a.templ", "error": "boom"}},
+		{Level: "success", Message: "Done"},
+	}
+	if got := l.Records(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected records %+v, got %+v", expected, got)
+	}
+}
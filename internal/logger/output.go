@@ -0,0 +1,188 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/fatih/color"
+)
+
+/* ------------------------------------------------------------------------- */
+/* COLOR                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Color modes accepted by SetColorMode.
+const (
+	ColorAuto   = "auto"   // Colors on a terminal, unless NO_COLOR is set or FORCE_COLOR forces them
+	ColorAlways = "always" // Colors even when the output is redirected
+	ColorNever  = "never"  // Plain output
+)
+
+// ColorModes lists the supported color modes.
+var ColorModes = []string{ColorAuto, ColorAlways, ColorNever}
+
+// ColorEnabled reports whether the output is colored for the given mode. In auto mode,
+// FORCE_COLOR (any value but "0" or "false") enables colors, then NO_COLOR (any
+// non-empty value) and TERM=dumb disable them, otherwise they follow isTerminal.
+func ColorEnabled(mode string, lookupEnv func(string) (string, bool), isTerminal bool) (bool, error) {
+	switch mode {
+	case ColorAlways:
+		return true, nil
+	case ColorNever:
+		return false, nil
+	case ColorAuto, "":
+	default:
+		return false, fmt.Errorf("invalid color mode %q (expected one of: %s)", mode, strings.Join(ColorModes, ", "))
+	}
+
+	if value, ok := lookupEnv("FORCE_COLOR"); ok && value != "0" && !strings.EqualFold(value, "false") {
+		return true, nil
+	}
+	if value, ok := lookupEnv("NO_COLOR"); ok && value != "" {
+		return false, nil
+	}
+	if value, _ := lookupEnv("TERM"); value == "dumb" {
+		return false, nil
+	}
+	return isTerminal, nil
+}
+
+// SetColorMode enables or disables the colors of the whole output for the given mode,
+// checking whether stdout is a terminal in auto mode.
+func SetColorMode(mode string) error {
+	enabled, err := ColorEnabled(mode, os.LookupEnv, isTerminal(os.Stdout))
+	if err != nil {
+		return err
+	}
+	color.NoColor = !enabled
+	return nil
+}
+
+// isTerminal reports whether f is a character device, as terminals are.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+/* ------------------------------------------------------------------------- */
+/* ICONS                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// noEmoji replaces the icons with plain text when set (see SetEmoji).
+var noEmoji atomic.Bool
+
+// asciiIcons holds the plain text replacement of the icons.
+var asciiIcons = map[string]string{
+	"info":    "[info]",
+	"success": "[ok]",
+	"warning": "[warn]",
+	"error":   "[error]",
+	"hint":    "[hint]",
+	"arrow":   "->",
+}
+
+// glyphIcons holds the icons not tied to a log level.
+var glyphIcons = map[string]string{
+	"arrow":     "→",
+	"summary":   "📋",
+	"processed": "✅",
+	"failed":    "❌",
+	"skipped":   "📌",
+	"compare":   "🔍",
+}
+
+// SetEmoji enables or disables the icons of the output. Without them, the log levels
+// are shown as plain text tags (e.g. "[ok]") and section icons are dropped.
+func SetEmoji(enabled bool) {
+	noEmoji.Store(!enabled)
+}
+
+// Icon returns the icon of a log level (e.g. "success") or of an output section
+// (e.g. "summary"), or its plain text replacement when the icons are disabled.
+func Icon(name string) string {
+	if noEmoji.Load() {
+		return asciiIcons[name]
+	}
+	if icon, ok := levels[name]; ok {
+		return icon
+	}
+	return glyphIcons[name]
+}
+
+// Prefix returns the icon of name followed by a space, or nothing when it has no
+// icon, to start a line with it.
+func Prefix(name string) string {
+	if icon := Icon(name); icon != "" {
+		return icon + " "
+	}
+	return ""
+}
+
+/* ------------------------------------------------------------------------- */
+/* PATHS                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// pathRoot holds the resolved folder the printed paths are relative to (see SetPathRoot).
+var pathRoot atomic.Value
+
+// SetPathRoot prints the absolute paths of the output relative to root, e.g. the project
+// root, instead of the long temporary folders of CI runners. Symbolic links are resolved
+// on both sides, so that /tmp and /private/tmp match. An empty root prints the paths as is.
+func SetPathRoot(root string) {
+	if root == "" {
+		pathRoot.Store("")
+		return
+	}
+	pathRoot.Store(resolvePath(root))
+}
+
+// RelativePaths reports whether the paths are printed relative to a root (see SetPathRoot).
+func RelativePaths() bool {
+	root, _ := pathRoot.Load().(string)
+	return root != ""
+}
+
+// DisplayPath returns path as printed: relative to the root set with SetPathRoot when it
+// is an absolute path inside it, as is otherwise.
+func DisplayPath(path string) string {
+	root, _ := pathRoot.Load().(string)
+	if root == "" || !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(root, resolvePath(path))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
+}
+
+// resolvePath returns the absolute path with the symbolic links of its longest existing
+// part resolved: the files about to be written do not exist yet.
+func resolvePath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	missing := ""
+	for dir := abs; ; dir = filepath.Dir(dir) {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			return filepath.Join(resolved, missing)
+		}
+		if filepath.Dir(dir) == dir {
+			return abs
+		}
+		missing = filepath.Join(filepath.Base(dir), missing)
+	}
+}
+
+// displayValue returns an attribute value as printed: the absolute paths are shown with
+// DisplayPath.
+func displayValue(value any) any {
+	if s, ok := value.(string); ok {
+		return DisplayPath(s)
+	}
+	return value
+}
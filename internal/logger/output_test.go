@@ -0,0 +1,116 @@
+package logger_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		env        map[string]string
+		isTerminal bool
+		want       bool
+	}{
+		{name: "Auto on terminal", mode: logger.ColorAuto, isTerminal: true, want: true},
+		{name: "Auto redirected", mode: logger.ColorAuto, isTerminal: false, want: false},
+		{name: "NO_COLOR", mode: logger.ColorAuto, env: map[string]string{"NO_COLOR": "1"}, isTerminal: true, want: false},
+		{name: "Empty NO_COLOR", mode: logger.ColorAuto, env: map[string]string{"NO_COLOR": ""}, isTerminal: true, want: true},
+		{name: "Dumb terminal", mode: "", env: map[string]string{"TERM": "dumb"}, isTerminal: true, want: false},
+		{name: "FORCE_COLOR", mode: logger.ColorAuto, env: map[string]string{"FORCE_COLOR": "1", "NO_COLOR": "1"}, isTerminal: false, want: true},
+		{name: "FORCE_COLOR=0", mode: logger.ColorAuto, env: map[string]string{"FORCE_COLOR": "0"}, isTerminal: true, want: true},
+		{name: "Always", mode: logger.ColorAlways, env: map[string]string{"NO_COLOR": "1"}, want: true},
+		{name: "Never", mode: logger.ColorNever, env: map[string]string{"FORCE_COLOR": "1"}, isTerminal: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookupEnv := func(key string) (string, bool) {
+				value, ok := tt.env[key]
+				return value, ok
+			}
+			got, err := logger.ColorEnabled(tt.mode, lookupEnv, tt.isTerminal)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ColorEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := logger.ColorEnabled("sometimes", nil, true); err == nil {
+		t.Error("Expected an error for an invalid color mode")
+	}
+}
+
+func TestSetEmoji(t *testing.T) {
+	t.Cleanup(func() { logger.SetEmoji(true) })
+
+	if logger.Icon("success") != "✔" || logger.Prefix("summary") != "📋 " {
+		t.Errorf("Expected icons by default, got %q and %q", logger.Icon("success"), logger.Prefix("summary"))
+	}
+
+	logger.SetEmoji(false)
+	if logger.Prefix("summary") != "" || logger.Icon("arrow") != "->" {
+		t.Errorf("Expected plain text without icons, got %q and %q", logger.Prefix("summary"), logger.Icon("arrow"))
+	}
+
+	output, err := testutils.CaptureStdout(func() {
+		logger.NewDefaultLogger().Warning("Low disk space")
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	if output != "[warn] Low disk space\n" {
+		t.Errorf("Expected a plain text tag, got %q", output)
+	}
+}
+
+func TestDisplayPath(t *testing.T) {
+	t.Cleanup(func() { logger.SetPathRoot("") })
+
+	root := t.TempDir()
+	link := filepath.Join(t.TempDir(), "project")
+	if err := os.Symlink(root, link); err != nil {
+		t.Skipf("Symbolic links not supported: %v", err)
+	}
+	inside := filepath.Join(root, "components", "button", "button.templ")
+
+	if got := logger.DisplayPath(inside); got != inside {
+		t.Errorf("Expected the path as is without a root, got %q", got)
+	}
+
+	logger.SetPathRoot(link)
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"inside the root", inside, filepath.Join("components", "button", "button.templ")},
+		{"through the symlink", filepath.Join(link, "assets", "base.css"), filepath.Join("assets", "base.css")},
+		{"root itself", root, "."},
+		{"relative path", filepath.Join("assets", "base.css"), filepath.Join("assets", "base.css")},
+		{"outside the root", filepath.Dir(root), filepath.Dir(root)},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := logger.DisplayPath(tt.path); got != tt.want {
+				t.Errorf("DisplayPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	l := logger.NewDefaultLogger()
+	l.StartRecording()
+	l.Success("Component created").WithAttrs("path", inside, "count", 1)
+	if got := l.Records()[0].Attrs["path"]; got != filepath.Join("components", "button", "button.templ") {
+		t.Errorf("Expected the recorded path relative to the root, got %v", got)
+	}
+}
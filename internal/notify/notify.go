@@ -0,0 +1,129 @@
+// Package notify sends the outcome of the tempo runs to a webhook or a Unix socket, so
+// that bots can report the changes, e.g. to a chat channel.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+)
+
+// EventRunCompleted is the event of the payload sent on completion of a run.
+const EventRunCompleted = "run.completed"
+
+// Statuses of a completed run.
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+// Payload is the JSON document sent for a completed run.
+type Payload struct {
+	Event      string         `json:"event"`
+	Command    string         `json:"command"` // e.g. "sync" or "component new"
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	Time       time.Time      `json:"time"`
+	DurationMs int64          `json:"duration_ms"`
+	Dir        string         `json:"dir"`
+	Version    string         `json:"version"`
+	Summary    map[string]any `json:"summary,omitempty"` // Outcome reported by the command, e.g. the processed files
+}
+
+// NewPayload returns the payload of a run of command completed with runErr.
+func NewPayload(command, dir, version string, duration time.Duration, summary map[string]any, runErr error) Payload {
+	p := Payload{
+		Event:      EventRunCompleted,
+		Command:    command,
+		Status:     StatusSuccess,
+		Time:       time.Now().Add(-duration),
+		DurationMs: duration.Milliseconds(),
+		Dir:        dir,
+		Version:    version,
+		Summary:    summary,
+	}
+	if runErr != nil {
+		p.Status = StatusError
+		p.Error = runErr.Error()
+	}
+	return p
+}
+
+// Send delivers the payload to the configured webhook and socket. Both are attempted:
+// the returned error joins their failures.
+func Send(ctx context.Context, n config.Notifications, p Payload) error {
+	timeout, err := n.DeliveryTimeout()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return apperrors.Wrap("failed to marshal notification", err)
+	}
+
+	var errs []error
+	if n.Webhook != "" {
+		if err := postWebhook(ctx, n.Webhook, n.Headers, body); err != nil {
+			errs = append(errs, apperrors.Wrap("failed to notify webhook", err, n.Webhook))
+		}
+	}
+	if n.Socket != "" {
+		if err := writeSocket(ctx, n.Socket, body); err != nil {
+			errs = append(errs, apperrors.Wrap("failed to notify socket", err, n.Socket))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// postWebhook posts body to url with the headers, expanded from the environment.
+func postWebhook(ctx context.Context, url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, os.ExpandEnv(value))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// writeSocket writes body, followed by a newline, to the Unix socket at path.
+func writeSocket(ctx context.Context, path string, body []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+	}
+	_, err = conn.Write(append(body, '\n'))
+	return err
+}
@@ -0,0 +1,121 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/indaco/tempo/internal/config"
+)
+
+func TestNewPayload(t *testing.T) {
+	p := NewPayload("sync", "/project", "v1.0.0", 1500*time.Millisecond, map[string]any{"failed": 1}, errors.New("boom"))
+
+	if p.Event != EventRunCompleted || p.Command != "sync" || p.DurationMs != 1500 {
+		t.Errorf("Unexpected payload: %+v", p)
+	}
+	if p.Status != StatusError || p.Error != "boom" {
+		t.Errorf("Expected an error status, got %s (%s)", p.Status, p.Error)
+	}
+	if p := NewPayload("sync", "", "", 0, nil, nil); p.Status != StatusSuccess || p.Error != "" {
+		t.Errorf("Expected a success status, got %s (%s)", p.Status, p.Error)
+	}
+}
+
+func TestSend_Webhook(t *testing.T) {
+	t.Setenv("TEMPO_TEST_TOKEN", "secret")
+
+	var received Payload
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := config.Notifications{
+		Webhook: server.URL,
+		Headers: map[string]string{"Authorization": "Bearer ${TEMPO_TEST_TOKEN}"},
+	}
+	if err := Send(context.Background(), n, NewPayload("component new", "/project", "dev", time.Second, nil, nil)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if received.Command != "component new" || received.Status != StatusSuccess {
+		t.Errorf("Unexpected payload received: %+v", received)
+	}
+	if auth != "Bearer secret" {
+		t.Errorf("Expected the header to be expanded, got %q", auth)
+	}
+}
+
+func TestSend_WebhookError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := Send(context.Background(), config.Notifications{Webhook: server.URL}, NewPayload("sync", "", "", 0, nil, nil))
+	if err == nil {
+		t.Fatal("Expected an error for a failing webhook")
+	}
+}
+
+func TestSend_Socket(t *testing.T) {
+	// Unix socket paths are limited in length: avoid the long test temp dirs.
+	dir, err := os.MkdirTemp("", "tempo")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "notify.sock")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("Unix sockets are not available: %v", err)
+	}
+	defer listener.Close()
+
+	lines := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		lines <- line
+	}()
+
+	if err := Send(context.Background(), config.Notifications{Socket: socket}, NewPayload("sync", "", "", 0, nil, nil)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	select {
+	case line := <-lines:
+		var p Payload
+		if err := json.Unmarshal([]byte(line), &p); err != nil || p.Command != "sync" {
+			t.Errorf("Unexpected line received: %q (%v)", line, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the notification")
+	}
+}
+
+func TestSend_InvalidTimeout(t *testing.T) {
+	err := Send(context.Background(), config.Notifications{Webhook: "http://localhost", Timeout: "-1s"}, Payload{})
+	if err == nil {
+		t.Fatal("Expected an error for an invalid timeout")
+	}
+}
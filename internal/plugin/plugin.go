@@ -0,0 +1,231 @@
+// Package plugin runs external executables extending tempo as action types or
+// sync transformers.
+//
+// Protocol: tempo starts the plugin command for every call, writes a single JSON
+// Request to its stdin and reads a single JSON Response from its stdout. A non-zero
+// exit code or a non-empty "error" field fails the call; stderr is reported with it.
+//
+//	{"protocol":1,"kind":"transformer","plugin":"postcss","file":"assets/button/css/base.css","content":"..."}
+//	{"content":"..."}
+//
+//	{"protocol":1,"kind":"action","plugin":"license","action":{...},"data":{...}}
+//	{"files":[{"path":"components/button/LICENSE","content":"..."}]}
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/processor"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES & CONSTANTS                                                         */
+/* ------------------------------------------------------------------------- */
+
+// ProtocolVersion is the version of the JSON protocol sent with every request.
+const ProtocolVersion = 1
+
+// Plugin kinds.
+const (
+	KindAction      = "action"
+	KindTransformer = "transformer"
+)
+
+var (
+	// ErrInvalidPlugin is returned when a plugin declaration is incomplete or invalid.
+	ErrInvalidPlugin = fmt.Errorf("invalid plugin")
+
+	// ErrPluginFailed is returned when a plugin exits with an error or reports one.
+	ErrPluginFailed = fmt.Errorf("plugin failed")
+)
+
+// Request is the JSON message written to the plugin stdin.
+type Request struct {
+	Protocol int                     `json:"protocol"`
+	Kind     string                  `json:"kind"`
+	Plugin   string                  `json:"plugin"`
+	Action   *generator.Action       `json:"action,omitempty"`  // For actions
+	Data     *generator.TemplateData `json:"data,omitempty"`    // For actions
+	File     string                  `json:"file,omitempty"`    // For transformers
+	Content  string                  `json:"content,omitempty"` // For transformers
+}
+
+// File is a file generated by an action plugin.
+type File struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// Response is the JSON message read from the plugin stdout.
+type Response struct {
+	Content string `json:"content,omitempty"` // Transformed content, for transformers
+	Files   []File `json:"files,omitempty"`   // Files to write, for actions
+	Error   string `json:"error,omitempty"`
+}
+
+// Plugin is an external executable speaking the JSON protocol.
+type Plugin struct {
+	Name       string
+	Kind       string
+	Command    string
+	Args       []string
+	Extensions []string
+}
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTOR                                                               */
+/* ------------------------------------------------------------------------- */
+
+// New creates a Plugin from its configuration.
+func New(cfg config.Plugin) (*Plugin, error) {
+	switch {
+	case strings.TrimSpace(cfg.Name) == "":
+		return nil, apperrors.Wrap("plugin name is required", ErrInvalidPlugin)
+	case strings.TrimSpace(cfg.Command) == "":
+		return nil, apperrors.Wrap("plugin %s: command is required", ErrInvalidPlugin, cfg.Name)
+	case cfg.Kind != KindAction && cfg.Kind != KindTransformer:
+		return nil, apperrors.Wrap("plugin %s: kind must be 'action' or 'transformer', got '%s'", ErrInvalidPlugin, cfg.Name, cfg.Kind)
+	}
+
+	extensions := make([]string, 0, len(cfg.Extensions))
+	for _, ext := range cfg.Extensions {
+		ext = strings.ToLower(ext)
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		extensions = append(extensions, ext)
+	}
+
+	return &Plugin{
+		Name:       cfg.Name,
+		Kind:       cfg.Kind,
+		Command:    cfg.Command,
+		Args:       slices.Clone(cfg.Args),
+		Extensions: extensions,
+	}, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// Call runs the plugin with the given request and returns its response.
+func (p *Plugin) Call(ctx context.Context, req Request) (Response, error) {
+	req.Protocol = ProtocolVersion
+	req.Kind = p.Kind
+	req.Plugin = p.Name
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return Response{}, apperrors.Wrap("failed to encode plugin request", err, p.Name)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return Response{}, apperrors.Wrap("plugin %s failed: %s", ErrPluginFailed, p.Name, strings.TrimSpace(stderr.String()+" "+err.Error()))
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return Response{}, apperrors.Wrap("plugin %s returned an invalid response", err, p.Name)
+	}
+	if resp.Error != "" {
+		return Response{}, apperrors.Wrap("plugin %s failed: %s", ErrPluginFailed, p.Name, resp.Error)
+	}
+	return resp, nil
+}
+
+// Transform sends the content of a file to a transformer plugin and returns the result.
+func (p *Plugin) Transform(ctx context.Context, filePath, content string) (string, error) {
+	resp, err := p.Call(ctx, Request{File: filePath, Content: content})
+	if err != nil {
+		return "", err
+	}
+	return resp.Content, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* ACTION HANDLER                                                            */
+/* ------------------------------------------------------------------------- */
+
+// ActionHandler executes actions whose type is the name of an action plugin.
+type ActionHandler struct {
+	Plugin *Plugin
+}
+
+// Execute sends the action and template data to the plugin and writes the returned files,
+// honoring the action SkipIfExists and Force settings.
+func (h *ActionHandler) Execute(ctx context.Context, action generator.Action, data *generator.TemplateData) error {
+	resp, err := h.Plugin.Call(ctx, Request{Action: &action, Data: data})
+	if err != nil {
+		return err
+	}
+
+	for _, file := range resp.Files {
+		if strings.TrimSpace(file.Path) == "" {
+			return apperrors.Wrap("plugin %s returned a file without path", ErrPluginFailed, h.Plugin.Name)
+		}
+		if err := generator.WriteActionOutput(ctx, action, data, file.Path, file.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* REGISTRATION                                                              */
+/* ------------------------------------------------------------------------- */
+
+// RegisterActions registers the action plugins as action types, so that actions files
+// can use them with `"type": "<plugin name>"`.
+func RegisterActions(plugins []config.Plugin) error {
+	for _, cfg := range plugins {
+		p, err := New(cfg)
+		if err != nil {
+			return err
+		}
+		if p.Kind != KindAction {
+			continue
+		}
+		if err := generator.RegisterActionHandler(p.Name, &ActionHandler{Plugin: p}); err != nil {
+			return apperrors.Wrap("failed to register plugin %s", err, p.Name)
+		}
+	}
+	return nil
+}
+
+// Transformers returns the transformer plugins as sync transformers, in declaration order.
+func Transformers(ctx context.Context, plugins []config.Plugin) ([]processor.ExternalTransformer, error) {
+	var transformers []processor.ExternalTransformer
+	for _, cfg := range plugins {
+		p, err := New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if p.Kind != KindTransformer {
+			continue
+		}
+		transformers = append(transformers, processor.ExternalTransformer{
+			Name:       p.Name,
+			Extensions: p.Extensions,
+			Transform: func(filePath, content string) (string, error) {
+				return p.Transform(ctx, filePath, content)
+			},
+		})
+	}
+	return transformers, nil
+}
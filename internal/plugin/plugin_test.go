@@ -0,0 +1,212 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+// shellPlugin returns a plugin config running script with sh. The request is saved
+// to the returned file.
+func shellPlugin(t *testing.T, name, kind, script string) (config.Plugin, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	requestFile := filepath.Join(t.TempDir(), "request.json")
+	return config.Plugin{
+		Name:    name,
+		Kind:    kind,
+		Command: "sh",
+		Args:    []string{"-c", `cat > "$0"; ` + script, requestFile},
+	}, requestFile
+}
+
+func readRequest(t *testing.T, requestFile string) Request {
+	t.Helper()
+
+	data, err := os.ReadFile(requestFile)
+	if err != nil {
+		t.Fatalf("Failed to read request: %v", err)
+	}
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatalf("Failed to decode request: %v", err)
+	}
+	return req
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         config.Plugin
+		expectError bool
+	}{
+		{name: "Valid transformer", cfg: config.Plugin{Name: "postcss", Kind: KindTransformer, Command: "postcss"}},
+		{name: "Valid action", cfg: config.Plugin{Name: "license", Kind: KindAction, Command: "license"}},
+		{name: "Missing name", cfg: config.Plugin{Kind: KindAction, Command: "license"}, expectError: true},
+		{name: "Missing command", cfg: config.Plugin{Name: "license", Kind: KindAction}, expectError: true},
+		{name: "Unknown kind", cfg: config.Plugin{Name: "license", Kind: "hook", Command: "license"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if tt.expectError && !errors.Is(err, ErrInvalidPlugin) {
+				t.Errorf("Expected ErrInvalidPlugin, got %v", err)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+
+	p, err := New(config.Plugin{Name: "postcss", Kind: KindTransformer, Command: "postcss", Extensions: []string{"CSS", ".scss"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.Extensions[0] != ".css" || p.Extensions[1] != ".scss" {
+		t.Errorf("Expected normalized extensions, got %v", p.Extensions)
+	}
+}
+
+func TestPlugin_Transform(t *testing.T) {
+	cfg, requestFile := shellPlugin(t, "upper", KindTransformer, `printf '{"content":"TRANSFORMED"}'`)
+	p, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := p.Transform(context.Background(), "base.css", ".button{}")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if content != "TRANSFORMED" {
+		t.Errorf("Expected transformed content, got %q", content)
+	}
+
+	req := readRequest(t, requestFile)
+	if req.Protocol != ProtocolVersion || req.Kind != KindTransformer || req.Plugin != "upper" ||
+		req.File != "base.css" || req.Content != ".button{}" {
+		t.Errorf("Unexpected request: %+v", req)
+	}
+}
+
+func TestPlugin_CallErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		sentinel error
+	}{
+		{name: "Non-zero exit", script: `echo "boom" >&2; exit 1`, sentinel: ErrPluginFailed},
+		{name: "Reported error", script: `printf '{"error":"unsupported syntax"}'`, sentinel: ErrPluginFailed},
+		{name: "Invalid response", script: `printf 'not json'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, _ := shellPlugin(t, "failing", KindTransformer, tt.script)
+			p, err := New(cfg)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			_, err = p.Transform(context.Background(), "base.css", "")
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+			if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+				t.Errorf("Expected %v, got %v", tt.sentinel, err)
+			}
+		})
+	}
+}
+
+func TestActionHandler_Execute(t *testing.T) {
+	tempDir := t.TempDir()
+	outputFile := filepath.Join(tempDir, "LICENSE")
+	existingFile := filepath.Join(tempDir, "EXISTING")
+	testutils.CreateFile(t, existingFile, "keep")
+
+	cfg, requestFile := shellPlugin(t, "license", KindAction,
+		`printf '{"files":[{"path":"`+outputFile+`","content":"MIT"},{"path":"`+existingFile+`","content":"new"}]}'`)
+	p, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	handler := &ActionHandler{Plugin: p}
+	action := generator.Action{Type: "license", Item: "file", SkipIfExists: true}
+	data := &generator.TemplateData{ComponentName: "button"}
+
+	if err := handler.Execute(context.Background(), action, data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if content, _ := os.ReadFile(outputFile); string(content) != "MIT" {
+		t.Errorf("Expected generated file content %q, got %q", "MIT", string(content))
+	}
+	if content, _ := os.ReadFile(existingFile); string(content) != "keep" {
+		t.Errorf("Expected existing file to be skipped, got %q", string(content))
+	}
+
+	req := readRequest(t, requestFile)
+	if req.Kind != KindAction || req.Action == nil || req.Action.Type != "license" ||
+		req.Data == nil || req.Data.ComponentName != "button" {
+		t.Errorf("Unexpected request: %+v", req)
+	}
+}
+
+func TestRegisterActions(t *testing.T) {
+	cfg, _ := shellPlugin(t, "touch-plugin", KindAction, `printf '{}'`)
+
+	if err := RegisterActions([]config.Plugin{cfg}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	actions := []generator.Action{{Type: "touch-plugin", Item: "file"}}
+	if err := generator.ProcessActions(context.Background(), &testutils.MockLogger{}, actions, &generator.TemplateData{}); err != nil {
+		t.Errorf("Expected the plugin action type to be registered, got: %v", err)
+	}
+
+	builtIn := config.Plugin{Name: generator.RenderActionID, Kind: KindAction, Command: "sh"}
+	if err := RegisterActions([]config.Plugin{builtIn}); err == nil {
+		t.Errorf("Expected an error when replacing a built-in action type")
+	}
+}
+
+func TestTransformers(t *testing.T) {
+	transformerCfg, _ := shellPlugin(t, "postcss", KindTransformer, `printf '{"content":"out"}'`)
+	transformerCfg.Extensions = []string{"css"}
+	actionCfg := config.Plugin{Name: "license", Kind: KindAction, Command: "license"}
+
+	transformers, err := Transformers(context.Background(), []config.Plugin{actionCfg, transformerCfg})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(transformers) != 1 || transformers[0].Name != "postcss" {
+		t.Fatalf("Expected only the transformer plugin, got %+v", transformers)
+	}
+	if !transformers[0].Matches("base.css") || transformers[0].Matches("script.js") {
+		t.Errorf("Unexpected extension matching for %v", transformers[0].Extensions)
+	}
+
+	content, err := transformers[0].Transform("base.css", "in")
+	if err != nil || content != "out" {
+		t.Errorf("Expected transformed content %q, got %q (err: %v)", "out", content, err)
+	}
+
+	if _, err := Transformers(context.Background(), []config.Plugin{{Name: "broken", Kind: KindTransformer}}); err == nil {
+		t.Errorf("Expected an error for an invalid plugin")
+	}
+}
@@ -0,0 +1,61 @@
+package processor
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	apperrors "github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/processor/transformers"
+)
+
+// AutoprefixerName is the name of the vendor-prefixing transformer.
+const AutoprefixerName = "autoprefixer"
+
+// AutoprefixerOptions configures the vendor-prefixing pass applied to CSS files.
+type AutoprefixerOptions struct {
+	Browsers []string // Browserslist-style targets, e.g. "safari 13"
+	Command  string   // External binary; the built-in implementation is used when empty
+	Args     []string
+}
+
+// NewAutoprefixer returns a transformer adding vendor prefixes to CSS files.
+// The built-in implementation handles the properties known to esbuild. With a
+// Command, the CSS is piped through it instead and the targets are passed in the
+// BROWSERSLIST environment variable, e.g. for `postcss --use autoprefixer`.
+func NewAutoprefixer(ctx context.Context, opts AutoprefixerOptions) (ExternalTransformer, error) {
+	transformer := ExternalTransformer{Name: AutoprefixerName, Extensions: []string{".css"}}
+
+	if strings.TrimSpace(opts.Command) == "" {
+		prefixer, err := transformers.NewPrefixTransformer(opts.Browsers)
+		if err != nil {
+			return ExternalTransformer{}, apperrors.Wrap("invalid autoprefixer configuration", err)
+		}
+		transformer.Transform = func(_, content string) (string, error) {
+			return prefixer.Transform(content)
+		}
+		return transformer, nil
+	}
+
+	command, args := opts.Command, slices.Clone(opts.Args)
+	browsers := strings.Join(opts.Browsers, ", ")
+	transformer.Transform = func(filePath, content string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, command, args...)
+		cmd.Stdin = strings.NewReader(content)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if browsers != "" {
+			cmd.Env = append(os.Environ(), "BROWSERSLIST="+browsers)
+		}
+
+		if err := cmd.Run(); err != nil {
+			return "", apperrors.Wrap("autoprefixer command failed for %s: %s", err, filePath, strings.TrimSpace(stderr.String()))
+		}
+		return strings.TrimRight(stdout.String(), "\n"), nil
+	}
+	return transformer, nil
+}
@@ -0,0 +1,75 @@
+package processor
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestNewAutoprefixer_BuiltIn(t *testing.T) {
+	transformer, err := NewAutoprefixer(context.Background(), AutoprefixerOptions{Browsers: []string{"safari 12"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if transformer.Name != AutoprefixerName || !transformer.Matches("assets/button/css/base.css") || transformer.Matches("assets/button/js/script.js") {
+		t.Errorf("Unexpected transformer: %+v", transformer)
+	}
+
+	output, err := transformer.Transform("base.css", ".button { user-select: none; }")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "-webkit-user-select: none") {
+		t.Errorf("Expected a vendor prefix, got:\n%s", output)
+	}
+}
+
+func TestNewAutoprefixer_InvalidBrowsers(t *testing.T) {
+	if _, err := NewAutoprefixer(context.Background(), AutoprefixerOptions{Browsers: []string{"last 2 versions"}}); err == nil {
+		t.Fatal("Expected an error for an unsupported browser query")
+	}
+}
+
+func TestNewAutoprefixer_Command(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	transformer, err := NewAutoprefixer(context.Background(), AutoprefixerOptions{
+		Browsers: []string{"> 1%", "last 2 versions"},
+		Command:  "sh",
+		Args:     []string{"-c", `printf '/* %s */\n' "$BROWSERSLIST"; cat`},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output, err := transformer.Transform("base.css", ".button{}\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if output != "/* > 1%, last 2 versions */\n.button{}" {
+		t.Errorf("Unexpected output: %q", output)
+	}
+}
+
+func TestNewAutoprefixer_CommandFailure(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	transformer, err := NewAutoprefixer(context.Background(), AutoprefixerOptions{
+		Command: "sh",
+		Args:    []string{"-c", "echo 'boom' >&2; exit 1"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = transformer.Transform("base.css", ".button{}")
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Expected the command stderr in the error, got %v", err)
+	}
+}
@@ -1,8 +1,11 @@
 package processor
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/evanw/esbuild/pkg/api"
@@ -146,3 +149,75 @@ func BenchmarkMinifierProcessor_JS(b *testing.B) {
 		}
 	}
 }
+
+// benchLargeCSS returns a multi-MB CSS file, as generated by utility-first frameworks.
+func benchLargeCSS() string {
+	var sb strings.Builder
+	for i := 0; sb.Len() < 4<<20; i++ {
+		fmt.Fprintf(&sb, ".u-%d { margin: %dpx; padding: %dpx; }\n", i, i%64, i%32)
+	}
+	return sb.String()
+}
+
+// injectWithStrings is the former injection, reading and rebuilding whole files as
+// strings, kept to measure the allocations saved by streaming.
+func injectWithStrings(inputFilePath, outputFilePath, markerName string) error {
+	inputContent, err := os.ReadFile(inputFilePath)
+	if err != nil {
+		return err
+	}
+	outputContent, err := os.ReadFile(outputFilePath)
+	if err != nil {
+		return err
+	}
+
+	startMarker, endMarker := GuardMarkers(markerName)
+	startIndex := bytes.Index(outputContent, []byte(startMarker))
+	endIndex := bytes.Index(outputContent, []byte(endMarker))
+
+	beforeMarker := strings.TrimRight(string(outputContent[:startIndex+len(startMarker)]), " \n") + "\n"
+	afterMarker := strings.TrimLeft(string(outputContent[endIndex:]), " \n")
+
+	var sb strings.Builder
+	sb.WriteString(beforeMarker)
+	sb.WriteString(string(inputContent) + "\n")
+	sb.WriteString(afterMarker)
+	return os.WriteFile(outputFilePath, []byte(sb.String()), 0644)
+}
+
+// BenchmarkInject_LargeCSS injects a multi-MB CSS file into a templ file holding its
+// previous version, as on every sync, with the former string-based injection and with
+// the streaming PassthroughProcessor.
+func BenchmarkInject_LargeCSS(b *testing.B) {
+	dir := b.TempDir()
+	inputPath := filepath.Join(dir, "input.css")
+	outputPath := filepath.Join(dir, "output.templ")
+
+	css := benchLargeCSS()
+	startMarker, endMarker := GuardMarkers("tempo")
+	if err := os.WriteFile(inputPath, []byte(css), 0644); err != nil {
+		b.Fatalf("failed to write input: %v", err)
+	}
+	if err := os.WriteFile(outputPath, []byte("templ Styles() {\n<style>\n"+startMarker+"\n"+css+"\n"+endMarker+"\n</style>\n}\n"), 0644); err != nil {
+		b.Fatalf("failed to write output: %v", err)
+	}
+
+	b.Run("strings", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := injectWithStrings(inputPath, outputPath, "tempo"); err != nil {
+				b.Fatalf("injection failed: %v", err)
+			}
+		}
+	})
+
+	b.Run("stream", func(b *testing.B) {
+		p := &PassthroughProcessor{}
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := p.Process(inputPath, outputPath, "tempo"); err != nil {
+				b.Fatalf("Process failed: %v", err)
+			}
+		}
+	})
+}
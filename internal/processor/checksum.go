@@ -0,0 +1,151 @@
+package processor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// Policies for the guard regions edited by hand since the last sync.
+const (
+	ManualEditsWarn   = "warn"   // Overwrite the edits, reporting them
+	ManualEditsRefuse = "refuse" // Keep the edited file and fail its processing
+)
+
+// ManualEditsPolicies lists the valid policies for the guard regions edited by hand.
+var ManualEditsPolicies = []string{ManualEditsWarn, ManualEditsRefuse}
+
+// GuardChecksum records a checksum of the content injected between the guard markers,
+// on a line before the END marker, so that the next sync detects the regions edited by
+// hand in the meantime. Regions without a checksum line are never reported.
+type GuardChecksum struct {
+	Policy    string                      // ManualEditsWarn or ManualEditsRefuse
+	Overwrite bool                        // Overwrite the edited regions, whatever the policy
+	OnEdit    func(outputFilePath string) // Reports an edited region about to be overwritten (nil to ignore)
+}
+
+// ChecksumLine returns the line recording the checksum of a guard region.
+func ChecksumLine(markerName, sum string) string {
+	return fmt.Sprintf("%s%s */", checksumPrefix(markerName), sum)
+}
+
+// IsChecksumLine reports whether line, trimmed, records the checksum of a guard region.
+func IsChecksumLine(line, markerName string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, checksumPrefix(markerName)) && strings.HasSuffix(trimmed, " */")
+}
+
+// checksumPrefix returns the start of the checksum line, up to the checksum.
+func checksumPrefix(markerName string) string {
+	return fmt.Sprintf("/* [%s] CHECKSUM sha256:", markerName)
+}
+
+// checksumLineLen is the length of the checksum line.
+func checksumLineLen(markerName string) int {
+	return len(checksumPrefix(markerName)) + sha256.Size*2 + len(" */")
+}
+
+// maxRegionIndent is the longest indentation of the END marker preserved on sync.
+const maxRegionIndent = 64
+
+// oldRegion inspects the old content of a guard region while it is skipped, to keep
+// its layout: the line ending after the BEGIN marker and the indentation of the END
+// marker. With a checksum, the content is hashed, but for the last bytes that may be
+// the checksum line, held back until the region is complete.
+type oldRegion struct {
+	markerName string
+	head       []byte    // Start of the region, up to its first line ending
+	tail       []byte    // End of the region, not hashed yet
+	hash       hash.Hash // nil without checksum
+}
+
+func newOldRegion(markerName string, withChecksum bool) *oldRegion {
+	r := &oldRegion{markerName: markerName}
+	if withChecksum {
+		r.hash = sha256.New()
+	}
+	return r
+}
+
+// Write records p, the next bytes of the region.
+func (r *oldRegion) Write(p []byte) (int, error) {
+	if n := len(r.head); n < maxRegionIndent && (n == 0 || r.head[n-1] != '\n') {
+		take := min(len(p), maxRegionIndent-n)
+		if i := bytes.IndexByte(p[:take], '\n'); i >= 0 {
+			take = i + 1
+		}
+		r.head = append(r.head, p[:take]...)
+	}
+
+	keep := checksumLineLen(r.markerName) + len("\r\n")*2 + maxRegionIndent
+	if len(p) >= keep {
+		if r.hash != nil {
+			r.hash.Write(r.tail)
+			r.hash.Write(p[:len(p)-keep])
+		}
+		r.tail = append(r.tail[:0], p[len(p)-keep:]...)
+		return len(p), nil
+	}
+
+	r.tail = append(r.tail, p...)
+	if extra := len(r.tail) - keep; extra > 0 {
+		if r.hash != nil {
+			r.hash.Write(r.tail[:extra])
+		}
+		r.tail = append(r.tail[:0], r.tail[extra:]...)
+	}
+	return len(p), nil
+}
+
+// Lead returns the blanks and the line ending following the BEGIN marker, or a newline
+// when the region does not start with a line ending.
+func (r *oldRegion) Lead() string {
+	if len(r.head) > 0 && r.head[len(r.head)-1] == '\n' && len(bytes.Trim(r.head, " \t\r\n")) == 0 {
+		return string(r.head)
+	}
+	return "\n"
+}
+
+// EOL returns the line ending of the region: "\r\n" or "\n".
+func (r *oldRegion) EOL() string {
+	if strings.HasSuffix(r.Lead(), "\r\n") {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// Indent returns the indentation of the END marker on its own line, if any.
+func (r *oldRegion) Indent() string {
+	i := bytes.LastIndexByte(r.tail, '\n')
+	if i == -1 || len(bytes.Trim(r.tail[i+1:], " \t")) > 0 {
+		return ""
+	}
+	return string(r.tail[i+1:])
+}
+
+// Edited reports whether the region holds a checksum line, before the END marker line,
+// that no longer matches the content before it.
+func (r *oldRegion) Edited() bool {
+	if r.hash == nil {
+		return false
+	}
+
+	rest := bytes.TrimRight(r.tail, " \t")
+	rest, ok := bytes.CutSuffix(rest, []byte("\n"))
+	if !ok {
+		return false
+	}
+	rest = bytes.TrimSuffix(rest, []byte("\r"))
+	i := bytes.LastIndexByte(rest, '\n')
+	line := rest[i+1:]
+	if i == -1 || len(line) != checksumLineLen(r.markerName) || !IsChecksumLine(string(line), r.markerName) {
+		return false
+	}
+
+	r.hash.Write(bytes.TrimSuffix(rest[:i], []byte("\r")))
+	recorded := strings.TrimSuffix(strings.TrimPrefix(string(line), checksumPrefix(r.markerName)), " */")
+	return recorded != hex.EncodeToString(r.hash.Sum(nil))
+}
@@ -0,0 +1,93 @@
+package processor
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+// injectWithChecksum injects content into output with the given checksum.
+func injectWithChecksum(t *testing.T, output, content string, checksum *GuardChecksum) (string, error) {
+	t.Helper()
+	var out strings.Builder
+	inject := func(w io.Writer) error {
+		_, err := io.WriteString(w, content)
+		return err
+	}
+	_, err := injectStream(strings.NewReader(output), &out, "tempo", "/* generated */", inject, checksum, "button.templ")
+	return out.String(), err
+}
+
+func TestInjectStream_Checksum(t *testing.T) {
+	startMarker, endMarker := GuardMarkers("tempo")
+	template := "<style>\n" + startMarker + "\n" + endMarker + "\n</style>\n"
+
+	var edited []string
+	warn := &GuardChecksum{Policy: ManualEditsWarn, OnEdit: func(path string) { edited = append(edited, path) }}
+
+	synced, err := injectWithChecksum(t, template, ".btn{}", warn)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(synced, "\n")
+	if !IsChecksumLine(lines[len(lines)-4], "tempo") {
+		t.Fatalf("Expected a checksum line before the end marker, got:\n%s", synced)
+	}
+
+	// Re-syncing an untouched region reports nothing
+	resynced, err := injectWithChecksum(t, synced, ".btn{}", warn)
+	if err != nil || resynced != synced || len(edited) != 0 {
+		t.Fatalf("Expected an untouched region to be re-synced silently, got %v (%v)", edited, err)
+	}
+
+	handEdited := strings.Replace(synced, ".btn{}", ".btn{color:red}", 1)
+
+	t.Run("Warn", func(t *testing.T) {
+		edited = nil
+		got, err := injectWithChecksum(t, handEdited, ".btn{}", warn)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got != synced || len(edited) != 1 || edited[0] != "button.templ" {
+			t.Errorf("Expected the edit to be overwritten and reported, got %v", edited)
+		}
+	})
+
+	t.Run("Refuse", func(t *testing.T) {
+		_, err := injectWithChecksum(t, handEdited, ".btn{}", &GuardChecksum{Policy: ManualEditsRefuse})
+		if apperrors.CodeOf(err) != apperrors.CodeManualEdits {
+			t.Errorf("Expected code %s, got %v", apperrors.CodeManualEdits, err)
+		}
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		got, err := injectWithChecksum(t, handEdited, ".btn{}", &GuardChecksum{Policy: ManualEditsRefuse, Overwrite: true})
+		if err != nil || got != synced {
+			t.Errorf("Expected the edit to be overwritten, got %v", err)
+		}
+	})
+
+	t.Run("Region without checksum", func(t *testing.T) {
+		legacy := "<style>\n" + startMarker + "\n.old{}\n" + endMarker + "\n</style>\n"
+		if _, err := injectWithChecksum(t, legacy, ".btn{}", &GuardChecksum{Policy: ManualEditsRefuse}); err != nil {
+			t.Errorf("Expected a region without checksum to be synced, got %v", err)
+		}
+	})
+}
+
+func TestGuardRegion_Checksum(t *testing.T) {
+	startMarker, endMarker := GuardMarkers("tempo")
+	content := startMarker + "\n.btn{}\n" + ChecksumLine("tempo", "abc") + "\n" + endMarker + "\n"
+
+	start, end, ok := GuardRegion(content, "tempo")
+	if !ok || start != 2 || end != 2 {
+		t.Errorf("Expected the region to exclude the checksum line, got %d-%d (%v)", start, end, ok)
+	}
+
+	ejected, _ := RemoveGuardMarkers(content, "tempo")
+	if ejected != ".btn{}\n" {
+		t.Errorf("Expected the checksum line to be removed, got %q", ejected)
+	}
+}
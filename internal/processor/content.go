@@ -0,0 +1,36 @@
+package processor
+
+import (
+	apperrors "github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/processor/transformers"
+)
+
+// ProcessContent transforms content as the processor of filePath would, without reading
+// or writing files. The result is injected between the guard markers of templContent;
+// when templContent is empty, the guard markers and the injected content alone are returned.
+// filePath only selects the transformations and the watermark: it does not need to exist.
+func (f *ProcessorFactory) ProcessContent(filePath, content, templContent, markerName string) (string, error) {
+	cfg := transformers.TransformationConfig{
+		RawData:    content,
+		Transform:  func(input string) (string, error) { return input, nil },
+		MarkerName: markerName,
+		Watermark:  f.watermarkComment(filePath),
+	}
+	if transforms := f.transforms(filePath); len(transforms) > 0 {
+		cfg.Transform = chainTransforms(transforms)
+	}
+
+	if templContent == "" {
+		startMarker, endMarker := GuardMarkers(markerName)
+		templContent = startMarker + "\n" + endMarker + "\n"
+	}
+
+	result, injected, err := injectTransformed(cfg, []byte(templContent), f.Checksum, "templ content")
+	if err != nil {
+		return "", err
+	}
+	if !injected {
+		return "", apperrors.WrapCode(apperrors.CodeMissingMarkers, "no %s guard markers found in templ content", markerName)
+	}
+	return result, nil
+}
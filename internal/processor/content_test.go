@@ -0,0 +1,53 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProcessorFactory_ProcessContent(t *testing.T) {
+	factory := &ProcessorFactory{Production: true}
+	startMarker, endMarker := GuardMarkers("tempo")
+
+	t.Run("Block only", func(t *testing.T) {
+		result, err := factory.ProcessContent("base.css", ".btn {\n  color: red;\n}\n", "", "tempo")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := startMarker + "\n.btn{color:red}\n\n" + endMarker + "\n"
+		if result != expected {
+			t.Errorf("Expected:\n%q\nGot:\n%q", expected, result)
+		}
+	})
+
+	t.Run("Templ content", func(t *testing.T) {
+		templ := "templ Button() {\n<style>\n" + startMarker + "\nold\n" + endMarker + "\n</style>\n}\n"
+		result, err := factory.ProcessContent("script.js", "let  a = 1;", templ, "tempo")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if strings.Contains(result, "old") || !strings.Contains(result, "let a=1;") {
+			t.Errorf("Expected the injected content to replace the old one, got:\n%s", result)
+		}
+		if !strings.HasPrefix(result, "templ Button() {") || !strings.HasSuffix(result, "</style>\n}\n") {
+			t.Errorf("Expected the content around the markers to be kept, got:\n%s", result)
+		}
+	})
+
+	t.Run("Missing markers", func(t *testing.T) {
+		if _, err := factory.ProcessContent("base.css", ".btn{}", "templ Button() {}", "tempo"); err == nil {
+			t.Error("Expected an error for templ content without guard markers")
+		}
+	})
+
+	t.Run("Guards", func(t *testing.T) {
+		guards, err := NewInjectionGuards(GuardOptions{MaxCSSSize: "4B"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		guarded := &ProcessorFactory{Guards: guards}
+		if _, err := guarded.ProcessContent("base.css", ".btn { color: red; }", "", "tempo"); err == nil {
+			t.Error("Expected the guards to reject the content")
+		}
+	})
+}
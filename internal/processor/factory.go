@@ -1,10 +1,16 @@
 package processor
 
 import (
+	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/evanw/esbuild/pkg/api"
 	"github.com/indaco/tempo/internal/processor/transformers"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/watermark"
 )
 
 // ProcessorFactoryInterface defines the behavior of a processor factory.
@@ -15,23 +21,138 @@ type ProcessorFactoryInterface interface {
 // Ensure ProcessorFactory implements the interface.
 var _ ProcessorFactoryInterface = (*ProcessorFactory)(nil)
 
+// ExternalTransformer transforms the content of the files matching Extensions before
+// it is injected (and minified in production mode), e.g. a PostCSS plugin.
+type ExternalTransformer struct {
+	Name       string
+	Extensions []string // e.g. [".css"]; empty matches every file
+	Transform  func(filePath, content string) (string, error)
+}
+
+// Matches reports whether the transformer handles the given file.
+func (t ExternalTransformer) Matches(filePath string) bool {
+	if len(t.Extensions) == 0 {
+		return true
+	}
+	return slices.Contains(t.Extensions, strings.ToLower(filepath.Ext(filePath)))
+}
+
 // ProcessorFactory provides the correct FileProcessor based on file extension.
 type ProcessorFactory struct {
-	Production bool // Whether to use minification
+	Production   bool                  // Whether to use minification
+	Watermark    *watermark.Watermark  // Watermark added before the injected content (nil to disable)
+	InputDir     string                // Root of the input files, used to detect the component name
+	Transformers []ExternalTransformer // External transformers applied before minification
+	Scoper       *CSSScoper            // Scopes the CSS class names to their component (nil to disable)
+	Guards       *InjectionGuards      // Limits checked on the final content (nil to disable)
+	FileModes    *utils.FileModePolicy // Permissions of the written files (nil for the defaults)
+	Input        *InputFS              // File system of the input files (nil for the OS one)
+	Checksum     *GuardChecksum        // Checksum of the guard regions (nil to disable)
+	JS           *JSInjector           // Escapes and wraps the JS content (nil to inject it as is)
+	Languages    AssetLanguages        // File types synced besides CSS and JS (nil for none)
 }
 
 // GetProcessor returns the appropriate FileProcessor.
 func (f *ProcessorFactory) GetProcessor(filePath string) FileProcessor {
+	comment := f.watermarkComment(filePath)
+	transforms := f.transforms(filePath)
+
+	if len(transforms) == 0 {
+		return &PassthroughProcessor{Watermark: comment, FileModes: f.FileModes, Input: f.Input, Checksum: f.Checksum}
+	}
+	return &MinifierProcessor{Transform: chainTransforms(transforms), Watermark: comment, FileModes: f.FileModes, Input: f.Input, Checksum: f.Checksum}
+}
+
+// transforms returns the transformations applied to the content of filePath: the
+// scoping of the CSS class names, the matching external transformers, minification
+// in production mode, the preparation of the JS content for the script blocks or of
+// the content of the asset languages, then the guards.
+func (f *ProcessorFactory) transforms(filePath string) []func(string) (string, error) {
 	ext := filepath.Ext(filePath)
 	loader := GetLoader(ext)
-	if f.Production && (ext == ".js" || ext == ".css") {
-		if loader == api.LoaderNone {
-			return &PassthroughProcessor{} // Fallback if loader is unknown
+
+	var transforms []func(string) (string, error)
+	if f.Scoper != nil && ext == ".css" {
+		scoper := f.Scoper
+		transforms = append(transforms, func(content string) (string, error) {
+			scoped, _ := scoper.Scope(filePath, content)
+			return scoped, nil
+		})
+	}
+	for _, t := range f.Transformers {
+		if t.Matches(filePath) {
+			transform := t.Transform
+			transforms = append(transforms, func(content string) (string, error) {
+				return transform(filePath, content)
+			})
+		}
+	}
+	if f.Production && (ext == ".js" || ext == ".css") && loader != api.LoaderNone {
+		transforms = append(transforms, newEsbuildTransformer(loader).Transform)
+	}
+	if f.JS != nil && ext == ".js" {
+		transforms = append(transforms, f.JS.Prepare)
+	}
+	if lang, ok := f.Languages.Lookup(filePath); ok {
+		transforms = append(transforms, func(content string) (string, error) {
+			return lang.Prepare(filePath, content)
+		})
+	}
+	if f.Guards != nil {
+		guards := f.Guards
+		transforms = append(transforms, func(content string) (string, error) {
+			return content, guards.Check(filePath, content)
+		})
+	}
+	return transforms
+}
+
+// chainTransforms returns a transformation applying the given ones in order.
+func chainTransforms(transforms []func(string) (string, error)) func(string) (string, error) {
+	if len(transforms) == 1 {
+		return transforms[0]
+	}
+	return func(content string) (string, error) {
+		var err error
+		for _, transform := range transforms {
+			if content, err = transform(content); err != nil {
+				return "", err
+			}
 		}
-		return &MinifierProcessor{Transform: newEsbuildTransformer(loader).Transform}
+		return content, nil
+	}
+}
+
+// watermarkComment renders the watermark for the component owning filePath, dated with
+// its modification time so that unchanged assets keep the same injected content; the
+// current date is used when filePath does not exist (e.g. stdin content). The watermark
+// template is validated when created, so render errors only drop it.
+func (f *ProcessorFactory) watermarkComment(filePath string) string {
+	if f.Watermark == nil {
+		return ""
+	}
+	date := time.Now()
+	if info, err := os.Stat(filePath); err == nil {
+		date = info.ModTime()
+	}
+	comment, err := f.Watermark.CommentAt(componentNameFromPath(f.InputDir, filePath), filePath, date)
+	if err != nil {
+		return ""
 	}
+	return comment
+}
 
-	return &PassthroughProcessor{}
+// componentNameFromPath returns the first directory of filePath relative to inputDir,
+// which is the component folder (e.g. assets/button/css/base.css -> button).
+func componentNameFromPath(inputDir, filePath string) string {
+	rel, err := filepath.Rel(inputDir, filePath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.Base(filepath.Dir(filePath))
+	}
+	if first, _, found := strings.Cut(filepath.ToSlash(rel), "/"); found {
+		return first
+	}
+	return filepath.Base(filepath.Dir(filePath))
 }
 
 // newEsbuildTransformer initializes an EsbuildTransformer with the correct loader.
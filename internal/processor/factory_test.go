@@ -1,9 +1,15 @@
 package processor
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/evanw/esbuild/pkg/api"
+	"github.com/indaco/tempo/internal/watermark"
 )
 
 func TestProcessorFactory_GetProcessor(t *testing.T) {
@@ -45,6 +51,151 @@ func TestProcessorFactory_GetProcessor(t *testing.T) {
 	}
 }
 
+func TestProcessorFactory_GetProcessor_Watermark(t *testing.T) {
+	w, err := watermark.New("{{ .ComponentName }}", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	inputDir := filepath.Join("assets")
+
+	factory := ProcessorFactory{Watermark: w, InputDir: inputDir}
+	processor, ok := factory.GetProcessor(filepath.Join(inputDir, "button", "css", "base.css")).(*PassthroughProcessor)
+	if !ok {
+		t.Fatalf("Expected PassthroughProcessor")
+	}
+	if processor.Watermark != "/* button */" {
+		t.Errorf("Expected watermark %q, got %q", "/* button */", processor.Watermark)
+	}
+
+	factory.Production = true
+	minifier, ok := factory.GetProcessor(filepath.Join(inputDir, "card", "script.js")).(*MinifierProcessor)
+	if !ok {
+		t.Fatalf("Expected MinifierProcessor")
+	}
+	if minifier.Watermark != "/* card */" {
+		t.Errorf("Expected watermark %q, got %q", "/* card */", minifier.Watermark)
+	}
+
+	factory = ProcessorFactory{InputDir: inputDir}
+	if p := factory.GetProcessor("styles.css").(*PassthroughProcessor); p.Watermark != "" {
+		t.Errorf("Expected no watermark when disabled, got %q", p.Watermark)
+	}
+}
+
+func TestProcessorFactory_GetProcessor_WatermarkDate(t *testing.T) {
+	w, err := watermark.New("{{ .Date }}", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	inputDir := t.TempDir()
+	filePath := filepath.Join(inputDir, "button", "base.css")
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filePath, []byte(".btn {}"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	modTime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+	if err := os.Chtimes(filePath, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set the modification time: %v", err)
+	}
+
+	factory := ProcessorFactory{Watermark: w, InputDir: inputDir}
+	processor := factory.GetProcessor(filePath).(*PassthroughProcessor)
+	if processor.Watermark != "/* 2024-03-01 */" {
+		t.Errorf("Expected the watermark to be dated with the asset modification time, got %q", processor.Watermark)
+	}
+}
+
+func TestProcessorFactory_GetProcessor_Transformers(t *testing.T) {
+	var calls []string
+	upper := ExternalTransformer{
+		Name:       "upper",
+		Extensions: []string{".css"},
+		Transform: func(filePath, content string) (string, error) {
+			calls = append(calls, filePath)
+			return strings.ToUpper(content), nil
+		},
+	}
+	suffix := ExternalTransformer{
+		Name: "suffix",
+		Transform: func(_, content string) (string, error) {
+			return content + "!", nil
+		},
+	}
+	factory := ProcessorFactory{Transformers: []ExternalTransformer{upper, suffix}}
+
+	minifier, ok := factory.GetProcessor("base.css").(*MinifierProcessor)
+	if !ok {
+		t.Fatalf("Expected MinifierProcessor when transformers match")
+	}
+	got, err := minifier.Transform("a")
+	if err != nil || got != "A!" {
+		t.Errorf("Expected chained transforms to return %q, got %q (err: %v)", "A!", got, err)
+	}
+	if len(calls) != 1 || calls[0] != "base.css" {
+		t.Errorf("Expected the transformer to receive the file path, got %v", calls)
+	}
+
+	js, ok := factory.GetProcessor("script.js").(*MinifierProcessor)
+	if !ok {
+		t.Fatalf("Expected MinifierProcessor for extension-less transformer")
+	}
+	if got, _ := js.Transform("a"); got != "a!" {
+		t.Errorf("Expected only the matching transformer to run, got %q", got)
+	}
+
+	failing := ProcessorFactory{Transformers: []ExternalTransformer{{
+		Transform: func(_, _ string) (string, error) { return "", errors.New("boom") },
+	}, suffix}}
+	if _, err := failing.GetProcessor("base.css").(*MinifierProcessor).Transform("a"); err == nil {
+		t.Errorf("Expected the transformer error to be returned")
+	}
+}
+
+func TestProcessorFactory_GetProcessor_Guards(t *testing.T) {
+	suffix := ExternalTransformer{
+		Name: "suffix",
+		Transform: func(_, content string) (string, error) {
+			return content + "!!", nil
+		},
+	}
+	factory := ProcessorFactory{
+		Transformers: []ExternalTransformer{suffix},
+		Guards:       &InjectionGuards{MaxCSSSize: 3},
+	}
+
+	minifier, ok := factory.GetProcessor("base.css").(*MinifierProcessor)
+	if !ok {
+		t.Fatalf("Expected MinifierProcessor when guards are set")
+	}
+	if got, err := minifier.Transform("a"); err != nil || got != "a!!" {
+		t.Errorf("Expected content within the limit to pass, got %q (err: %v)", got, err)
+	}
+	if _, err := minifier.Transform("ab"); err == nil {
+		t.Errorf("Expected the guards to check the transformed content")
+	}
+}
+
+func TestComponentNameFromPath(t *testing.T) {
+	tests := []struct {
+		inputDir string
+		filePath string
+		expected string
+	}{
+		{"assets", filepath.Join("assets", "button", "css", "base.css"), "button"},
+		{"assets", filepath.Join("assets", "button", "variants", "outline.css"), "button"},
+		{"assets", filepath.Join("other", "card", "card.css"), "card"},
+		{"assets", filepath.Join("assets", "global.css"), "assets"},
+	}
+
+	for _, tt := range tests {
+		if got := componentNameFromPath(tt.inputDir, tt.filePath); got != tt.expected {
+			t.Errorf("componentNameFromPath(%q, %q) = %q, expected %q", tt.inputDir, tt.filePath, got, tt.expected)
+		}
+	}
+}
+
 func TestNewEsbuildTransformer(t *testing.T) {
 	tests := []struct {
 		loader   api.Loader
@@ -62,3 +213,18 @@ func TestNewEsbuildTransformer(t *testing.T) {
 		}
 	}
 }
+
+func TestProcessorFactory_GetProcessor_JSInjector(t *testing.T) {
+	factory := ProcessorFactory{JS: &JSInjector{Escape: true}}
+
+	if _, ok := factory.GetProcessor("base.css").(*PassthroughProcessor); !ok {
+		t.Errorf("Expected the JS injector to be skipped for CSS files")
+	}
+	minifier, ok := factory.GetProcessor("script.js").(*MinifierProcessor)
+	if !ok {
+		t.Fatalf("Expected MinifierProcessor for JS files with the JS injector")
+	}
+	if got, err := minifier.Transform(`s = "</script>";`); err != nil || got != `s = "<\/script>";` {
+		t.Errorf("Expected the JS content to be escaped, got %q (err: %v)", got, err)
+	}
+}
@@ -0,0 +1,289 @@
+package processor
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	apperrors "github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// utf8BOM is the byte order mark starting some UTF-8 files.
+const utf8BOM = "\uFEFF"
+
+// markerPlaceholder stands for a removed guard marker while its line is checked.
+const markerPlaceholder = "\x00"
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// RepairStatus describes the outcome of a guard markers repair.
+type RepairStatus string
+
+const (
+	RepairOK         RepairStatus = "ok"         // Markers are valid, nothing to do
+	RepairNoMarkers  RepairStatus = "no_markers" // File has no markers at all
+	RepairFixed      RepairStatus = "fixed"      // Markers have been reinserted or deduplicated
+	RepairUnresolved RepairStatus = "unresolved" // Markers are broken but cannot be repaired safely
+)
+
+// RepairResult holds the outcome of a guard markers repair.
+type RepairResult struct {
+	Status  RepairStatus
+	Issues  []string // Detected problems (e.g. "missing END marker")
+	Reason  string   // Why the repair failed, for RepairUnresolved
+	Content string   // Repaired content, for RepairFixed
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// RegionMarker returns the marker name of a named guard region, e.g. "tempo:base" for
+// the region "base", or markerName itself for the default region.
+func RegionMarker(markerName, region string) string {
+	if region == "" {
+		return markerName
+	}
+	return markerName + ":" + region
+}
+
+// GuardMarkers returns the BEGIN and END guard markers for the given marker name.
+func GuardMarkers(markerName string) (startMarker, endMarker string) {
+	startMarker = fmt.Sprintf("/* [%s] BEGIN - Do not edit! This section is auto-generated. */", markerName)
+	endMarker = fmt.Sprintf("/* [%s] END */", markerName)
+	return startMarker, endMarker
+}
+
+// GuardRegion returns the 1-based line range strictly between the BEGIN and END guard
+// markers of the first guarded region of content, without the checksum line. It returns
+// false when the markers are missing or misordered. The range is empty (end < start)
+// when nothing has been injected yet.
+func GuardRegion(content, markerName string) (startLine, endLine int, ok bool) {
+	scanner := newMarkerScanner(strings.NewReader(content), markerName)
+
+	var before, region strings.Builder
+	if token, _ := scanner.Next(&before); token != tokenBegin {
+		return 0, 0, false
+	}
+	if token, _ := scanner.Next(&region); token != tokenEnd {
+		return 0, 0, false
+	}
+
+	startLine = strings.Count(before.String(), "\n") + 2
+	endLine = startLine - 2 + strings.Count(region.String(), "\n")
+	last := strings.TrimSuffix(strings.TrimRight(region.String(), " \t"), "\n")
+	if endLine >= startLine && IsChecksumLine(last[strings.LastIndex(last, "\n")+1:], markerName) {
+		endLine--
+	}
+	return startLine, endLine, true
+}
+
+// RepairGuardMarkers detects missing, duplicated or misordered guard markers and
+// reinserts a single BEGIN/END pair inside the <style> or <script> block where
+// the markers were found. Existing content of the block is kept between the markers
+// and is replaced on the next sync.
+func RepairGuardMarkers(content, markerName string) RepairResult {
+	startMarker, endMarker := GuardMarkers(markerName)
+
+	beginCount := strings.Count(content, startMarker)
+	endCount := strings.Count(content, endMarker)
+	startIndex := strings.Index(content, startMarker)
+	endIndex := strings.Index(content, endMarker)
+
+	if beginCount == 0 && endCount == 0 {
+		return RepairResult{Status: RepairNoMarkers}
+	}
+	if beginCount == 1 && endCount == 1 && startIndex < endIndex {
+		return RepairResult{Status: RepairOK}
+	}
+
+	issues := detectMarkerIssues(beginCount, endCount, startIndex, endIndex)
+
+	lines := strings.Split(content, "\n")
+	anchor := firstMarkerLine(lines, startMarker, endMarker)
+
+	openLine, closeLine, reason := findEnclosingBlock(lines, anchor)
+	if reason != "" {
+		return RepairResult{Status: RepairUnresolved, Issues: issues, Reason: reason}
+	}
+
+	var repaired []string
+	for i, line := range lines {
+		if i == closeLine {
+			repaired = append(repaired, endMarker)
+		}
+
+		if strings.Contains(line, startMarker) || strings.Contains(line, endMarker) {
+			line = strings.ReplaceAll(line, startMarker, "")
+			line = strings.ReplaceAll(line, endMarker, "")
+			// Drop lines holding only markers; the tag lines are never blank
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+		}
+
+		repaired = append(repaired, line)
+		if i == openLine {
+			repaired = append(repaired, startMarker)
+		}
+	}
+
+	return RepairResult{Status: RepairFixed, Issues: issues, Content: strings.Join(repaired, "\n")}
+}
+
+// RepairGuardMarkersInFile repairs the guard markers of a file. The file is rewritten
+// only when the markers have been fixed and dryRun is false.
+func RepairGuardMarkersInFile(filePath, markerName string, dryRun bool) (RepairResult, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return RepairResult{}, apperrors.Wrap("failed to read file", err, filePath)
+	}
+
+	result := RepairGuardMarkers(string(content), markerName)
+	if result.Status != RepairFixed || dryRun {
+		return result, nil
+	}
+
+	if err := utils.WriteStringToFile(filePath, result.Content); err != nil {
+		return result, apperrors.Wrap("failed to write repaired file", err, filePath)
+	}
+	return result, nil
+}
+
+// RemoveGuardMarkers removes the guard markers of content, keeping the content injected
+// between them, so that sync no longer updates it. Lines holding only a marker or a
+// checksum are dropped. It reports whether any marker was found.
+func RemoveGuardMarkers(content, markerName string) (string, bool) {
+	body, hasBOM := strings.CutPrefix(content, utf8BOM)
+
+	// Markers are replaced with a placeholder, to drop the lines holding only a marker
+	var marked strings.Builder
+	scanner := newMarkerScanner(strings.NewReader(body), markerName)
+	found := false
+	for {
+		token, _ := scanner.Next(&marked)
+		if token == tokenNone {
+			break
+		}
+		found = true
+		marked.WriteString(markerPlaceholder)
+	}
+	if !found {
+		return content, false
+	}
+
+	lines := strings.Split(marked.String(), "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == markerPlaceholder || IsChecksumLine(trimmed, markerName) {
+			continue
+		}
+		kept = append(kept, strings.ReplaceAll(line, markerPlaceholder, ""))
+	}
+
+	stripped := strings.Join(kept, "\n")
+	if hasBOM {
+		stripped = utf8BOM + stripped
+	}
+	return stripped, true
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// detectMarkerIssues describes what is wrong with the markers.
+func detectMarkerIssues(beginCount, endCount, startIndex, endIndex int) []string {
+	var issues []string
+	switch {
+	case beginCount == 0:
+		issues = append(issues, "missing BEGIN marker")
+	case beginCount > 1:
+		issues = append(issues, fmt.Sprintf("duplicated BEGIN marker (%d)", beginCount))
+	}
+	switch {
+	case endCount == 0:
+		issues = append(issues, "missing END marker")
+	case endCount > 1:
+		issues = append(issues, fmt.Sprintf("duplicated END marker (%d)", endCount))
+	}
+	if startIndex != -1 && endIndex != -1 && endIndex < startIndex {
+		issues = append(issues, "END marker before BEGIN marker")
+	}
+	return issues
+}
+
+// firstMarkerLine returns the index of the first line containing a marker.
+func firstMarkerLine(lines []string, startMarker, endMarker string) int {
+	for i, line := range lines {
+		if strings.Contains(line, startMarker) || strings.Contains(line, endMarker) {
+			return i
+		}
+	}
+	return -1
+}
+
+// findEnclosingBlock finds the opening and closing lines of the <style> or <script>
+// block containing the anchor line. It returns a reason when no such block is found.
+func findEnclosingBlock(lines []string, anchor int) (openLine, closeLine int, reason string) {
+	openLine, closeLine = -1, -1
+	tag := ""
+
+	for i := anchor; i >= 0; i-- {
+		if i != anchor && closingTag(lines[i]) != "" {
+			break
+		}
+		if t := openingTag(lines[i]); t != "" {
+			openLine, tag = i, t
+			break
+		}
+	}
+	if openLine == -1 {
+		return -1, -1, "no enclosing <style> or <script> block found before the markers"
+	}
+
+	for i := anchor; i < len(lines); i++ {
+		if i != openLine && openingTag(lines[i]) != "" {
+			break
+		}
+		if t := closingTag(lines[i]); t != "" {
+			if t != tag {
+				break
+			}
+			closeLine = i
+			break
+		}
+	}
+	if closeLine == -1 {
+		return -1, -1, fmt.Sprintf("no closing </%s> tag found after the markers", tag)
+	}
+	if openLine == closeLine {
+		return -1, -1, fmt.Sprintf("<%s> block opens and closes on the same line", tag)
+	}
+
+	return openLine, closeLine, ""
+}
+
+// openingTag returns "style" or "script" when the line opens one of these tags.
+func openingTag(line string) string {
+	for _, tag := range []string{"style", "script"} {
+		if strings.Contains(line, "<"+tag+">") || strings.Contains(line, "<"+tag+" ") {
+			return tag
+		}
+	}
+	return ""
+}
+
+// closingTag returns "style" or "script" when the line closes one of these tags.
+func closingTag(line string) string {
+	for _, tag := range []string{"style", "script"} {
+		if strings.Contains(line, "</"+tag+">") {
+			return tag
+		}
+	}
+	return ""
+}
@@ -0,0 +1,251 @@
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+const (
+	testBegin = "/* [tempo] BEGIN - Do not edit! This section is auto-generated. */"
+	testEnd   = "/* [tempo] END */"
+)
+
+func TestGuardMarkers(t *testing.T) {
+	start, end := GuardMarkers("custom")
+	if start != "/* [custom] BEGIN - Do not edit! This section is auto-generated. */" {
+		t.Errorf("Unexpected start marker: %q", start)
+	}
+	if end != "/* [custom] END */" {
+		t.Errorf("Unexpected end marker: %q", end)
+	}
+}
+
+func TestRegionMarker(t *testing.T) {
+	if got := RegionMarker("tempo", ""); got != "tempo" {
+		t.Errorf("Expected the default region to keep the marker name, got %q", got)
+	}
+	if got := RegionMarker("tempo", "base"); got != "tempo:base" {
+		t.Errorf("Expected tempo:base, got %q", got)
+	}
+}
+
+func TestGuardRegion(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantStart int
+		wantEnd   int
+		wantOK    bool
+	}{
+		{
+			name:      "Injected content",
+			content:   strings.Join([]string{"<style>", testBegin, ".a{}", ".b{}", testEnd, "</style>"}, "\n"),
+			wantStart: 3,
+			wantEnd:   4,
+			wantOK:    true,
+		},
+		{
+			name:      "Empty region",
+			content:   strings.Join([]string{"<style>", testBegin, testEnd, "</style>"}, "\n"),
+			wantStart: 3,
+			wantEnd:   2,
+			wantOK:    true,
+		},
+		{
+			name:      "Markers in strings are ignored",
+			content:   strings.Join([]string{`<p title="` + testBegin + `">`, "<style>", testBegin, ".a{}", "'" + testEnd + "'", testEnd, "</style>"}, "\n"),
+			wantStart: 4,
+			wantEnd:   5,
+			wantOK:    true,
+		},
+		{
+			name:    "Missing END marker",
+			content: strings.Join([]string{"<style>", testBegin, ".a{}", "</style>"}, "\n"),
+		},
+		{
+			name:    "Misordered markers",
+			content: strings.Join([]string{testEnd, ".a{}", testBegin}, "\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := GuardRegion(tt.content, "tempo")
+			if ok != tt.wantOK || start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("GuardRegion() = (%d, %d, %v), want (%d, %d, %v)",
+					start, end, ok, tt.wantStart, tt.wantEnd, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRepairGuardMarkers(t *testing.T) {
+	valid := strings.Join([]string{
+		"templ Button() {",
+		`<style type="text/css">`,
+		testBegin,
+		".button{}",
+		testEnd,
+		"</style>",
+		"}",
+	}, "\n")
+
+	tests := []struct {
+		name           string
+		content        string
+		expectedStatus RepairStatus
+		expectedIssue  string
+		expected       string
+	}{
+		{
+			name:           "Valid markers",
+			content:        valid,
+			expectedStatus: RepairOK,
+		},
+		{
+			name:           "No markers",
+			content:        "templ Button() {\n}",
+			expectedStatus: RepairNoMarkers,
+		},
+		{
+			name:           "Missing END marker",
+			content:        strings.Replace(valid, testEnd+"\n", "", 1),
+			expectedStatus: RepairFixed,
+			expectedIssue:  "missing END marker",
+			expected:       valid,
+		},
+		{
+			name:           "Missing BEGIN marker",
+			content:        strings.Replace(valid, testBegin+"\n", "", 1),
+			expectedStatus: RepairFixed,
+			expectedIssue:  "missing BEGIN marker",
+			expected:       valid,
+		},
+		{
+			name:           "Duplicated BEGIN marker",
+			content:        strings.Replace(valid, testBegin, testBegin+"\n"+testBegin, 1),
+			expectedStatus: RepairFixed,
+			expectedIssue:  "duplicated BEGIN marker (2)",
+			expected:       valid,
+		},
+		{
+			name:           "Swapped markers",
+			content:        strings.Join([]string{"templ Button() {", "<script>", testEnd, ".button{}", testBegin, "</script>", "}"}, "\n"),
+			expectedStatus: RepairFixed,
+			expectedIssue:  "END marker before BEGIN marker",
+			expected:       strings.Join([]string{"templ Button() {", "<script>", testBegin, ".button{}", testEnd, "</script>", "}"}, "\n"),
+		},
+		{
+			name:           "Marker on the tag line",
+			content:        strings.Join([]string{"<style>" + testBegin, ".button{}", "</style>"}, "\n"),
+			expectedStatus: RepairFixed,
+			expectedIssue:  "missing END marker",
+			expected:       strings.Join([]string{"<style>", testBegin, ".button{}", testEnd, "</style>"}, "\n"),
+		},
+		{
+			name:           "No enclosing block",
+			content:        "templ Button() {\n" + testBegin + "\n}",
+			expectedStatus: RepairUnresolved,
+			expectedIssue:  "missing END marker",
+		},
+		{
+			name:           "Unclosed block",
+			content:        "<style>\n" + testBegin + "\n}",
+			expectedStatus: RepairUnresolved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RepairGuardMarkers(tt.content, "tempo")
+
+			if result.Status != tt.expectedStatus {
+				t.Fatalf("Expected status %q, got %q (reason: %s)", tt.expectedStatus, result.Status, result.Reason)
+			}
+			if tt.expectedIssue != "" && !strings.Contains(strings.Join(result.Issues, "; "), tt.expectedIssue) {
+				t.Errorf("Expected issue %q, got %v", tt.expectedIssue, result.Issues)
+			}
+			if result.Status == RepairUnresolved && result.Reason == "" {
+				t.Errorf("Expected a reason for unresolved repair")
+			}
+			if tt.expected != "" && result.Content != tt.expected {
+				t.Errorf("Unexpected repaired content:\n%s\nexpected:\n%s", result.Content, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRepairGuardMarkersInFile(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "button.templ")
+	broken := "<style>\n" + testBegin + "\n</style>"
+	testutils.CreateFile(t, filePath, broken)
+
+	t.Run("dry run does not write", func(t *testing.T) {
+		result, err := RepairGuardMarkersInFile(filePath, "tempo", true)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Status != RepairFixed {
+			t.Fatalf("Expected status %q, got %q", RepairFixed, result.Status)
+		}
+		assertFileContent(t, filePath, broken)
+	})
+
+	t.Run("repair writes the file", func(t *testing.T) {
+		if _, err := RepairGuardMarkersInFile(filePath, "tempo", false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		assertFileContent(t, filePath, "<style>\n"+testBegin+"\n"+testEnd+"\n</style>")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := RepairGuardMarkersInFile(filepath.Join(tempDir, "missing.templ"), "tempo", false); err == nil {
+			t.Errorf("Expected an error for a missing file")
+		}
+		if _, err := os.Stat(filepath.Join(tempDir, "missing.templ")); !os.IsNotExist(err) {
+			t.Errorf("Expected missing file not to be created")
+		}
+	})
+}
+
+func assertFileContent(t *testing.T, filePath, expected string) {
+	t.Helper()
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != expected {
+		t.Errorf("Unexpected content in %s:\n%s\nexpected:\n%s", filePath, string(data), expected)
+	}
+}
+
+func TestRemoveGuardMarkers(t *testing.T) {
+	startMarker, endMarker := GuardMarkers("tempo")
+	content := "templ Style() {\n\t<style>\n\t\t" + startMarker + "\n\t\t.a { color: red; }\n\t\t" + endMarker + "\n\t</style>\n}\n"
+
+	got, found := RemoveGuardMarkers(content, "tempo")
+	if !found {
+		t.Fatal("Expected guard markers to be found")
+	}
+	want := "templ Style() {\n\t<style>\n\t\t.a { color: red; }\n\t</style>\n}\n"
+	if got != want {
+		t.Errorf("Unexpected content:\ngot:  %q\nwant: %q", got, want)
+	}
+
+	if _, found := RemoveGuardMarkers(want, "tempo"); found {
+		t.Errorf("Expected no guard markers")
+	}
+
+	// The BOM is kept and the markers in strings are text
+	quoted := `s := "` + startMarker + `"`
+	got, _ = RemoveGuardMarkers(utf8BOM+startMarker+"\n"+quoted+"\n"+endMarker+"\n", "tempo")
+	if want := utf8BOM + quoted + "\n"; got != want {
+		t.Errorf("Unexpected content:\ngot:  %q\nwant: %q", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package processor
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	apperrors "github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// GuardOptions configures the limits enforced on the content injected into .templ files.
+type GuardOptions struct {
+	MaxCSSSize        string // Human-readable size, e.g. "100KB"; empty for no limit
+	MaxJSSize         string
+	ForbiddenPatterns []string // Regular expressions
+}
+
+// InjectionGuards rejects injected content that is too large or matches a forbidden pattern.
+type InjectionGuards struct {
+	MaxCSSSize        int64 // Bytes; 0 for no limit
+	MaxJSSize         int64
+	ForbiddenPatterns []*regexp.Regexp
+}
+
+// NewInjectionGuards parses the guard options. It returns nil when no guard is set.
+func NewInjectionGuards(opts GuardOptions) (*InjectionGuards, error) {
+	if opts.MaxCSSSize == "" && opts.MaxJSSize == "" && len(opts.ForbiddenPatterns) == 0 {
+		return nil, nil
+	}
+
+	guards := &InjectionGuards{}
+	for _, limit := range []struct {
+		name  string
+		value string
+		dest  *int64
+	}{
+		{"max_css_size", opts.MaxCSSSize, &guards.MaxCSSSize},
+		{"max_js_size", opts.MaxJSSize, &guards.MaxJSSize},
+	} {
+		if limit.value == "" {
+			continue
+		}
+		size, err := utils.ParseBytes(limit.value)
+		if err != nil {
+			return nil, apperrors.Wrap("invalid guards.%s", err, limit.name)
+		}
+		*limit.dest = size
+	}
+
+	for _, pattern := range opts.ForbiddenPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, apperrors.Wrap("invalid guards.forbidden_patterns entry %q", err, pattern)
+		}
+		guards.ForbiddenPatterns = append(guards.ForbiddenPatterns, re)
+	}
+
+	return guards, nil
+}
+
+// Check returns an error when the content injected for filePath breaks a guard.
+func (g *InjectionGuards) Check(filePath, content string) error {
+	limit := g.MaxJSSize
+	if strings.ToLower(filepath.Ext(filePath)) == ".css" {
+		limit = g.MaxCSSSize
+	}
+	if size := int64(len(content)); limit > 0 && size > limit {
+		return apperrors.WrapCode(apperrors.CodeGuardViolation, "injected content is %s, above the %s limit set in processor.guards",
+			utils.FormatBytes(size), utils.FormatBytes(limit))
+	}
+
+	for _, re := range g.ForbiddenPatterns {
+		if match := re.FindString(content); match != "" {
+			return apperrors.WrapCode(apperrors.CodeGuardViolation, "injected content contains %q, forbidden by the pattern %s", match, re.String())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewInjectionGuards(t *testing.T) {
+	guards, err := NewInjectionGuards(GuardOptions{})
+	if err != nil || guards != nil {
+		t.Errorf("Expected no guards without options, got %+v (err: %v)", guards, err)
+	}
+
+	guards, err = NewInjectionGuards(GuardOptions{MaxCSSSize: "1KB", MaxJSSize: "2 KB", ForbiddenPatterns: []string{`@import\s+url`}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if guards.MaxCSSSize != 1024 || guards.MaxJSSize != 2048 || len(guards.ForbiddenPatterns) != 1 {
+		t.Errorf("Unexpected guards: %+v", guards)
+	}
+
+	for _, opts := range []GuardOptions{
+		{MaxCSSSize: "big"},
+		{MaxJSSize: "10XB"},
+		{ForbiddenPatterns: []string{"("}},
+	} {
+		if _, err := NewInjectionGuards(opts); err == nil {
+			t.Errorf("Expected an error for %+v", opts)
+		}
+	}
+}
+
+func TestInjectionGuards_Check(t *testing.T) {
+	guards, err := NewInjectionGuards(GuardOptions{
+		MaxCSSSize:        "16B",
+		MaxJSSize:         "32B",
+		ForbiddenPatterns: []string{`@import\s+url\(\s*.?http`},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+		wantErr  string
+	}{
+		{name: "CSS within limit", filePath: "base.css", content: ".a{color:red}"},
+		{name: "CSS above limit", filePath: "base.css", content: strings.Repeat("a", 17), wantErr: "above the"},
+		{name: "JS uses its own limit", filePath: "script.js", content: strings.Repeat("a", 17)},
+		{name: "JS above limit", filePath: "script.js", content: strings.Repeat("a", 33), wantErr: "above the"},
+		{name: "Forbidden pattern", filePath: "base.css", content: "@import url(http", wantErr: "forbidden by the pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guards.Check(tt.filePath, tt.content)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package processor
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	apperrors "github.com/indaco/tempo/internal/apperrors"
+)
+
+// InputFS reads the input files from an fs.FS, such as a zip bundle or an embed.FS,
+// instead of the OS file system. Input paths keep the form Root/<path>, so that their
+// output paths are resolved as for files of the input folder, and are opened as
+// <path> in FS. A nil InputFS reads the OS file system.
+type InputFS struct {
+	FS   fs.FS
+	Root string // Input folder the input paths start with
+}
+
+// NewInputFS returns the InputFS reading the input paths under root from fsys, or nil
+// to read them from the OS file system when fsys is nil.
+func NewInputFS(fsys fs.FS, root string) *InputFS {
+	if fsys == nil {
+		return nil
+	}
+	return &InputFS{FS: fsys, Root: filepath.Clean(root)}
+}
+
+// Open opens the input file at path.
+func (in *InputFS) Open(path string) (fs.File, error) {
+	if in == nil {
+		return os.Open(path)
+	}
+	name, err := in.name(path)
+	if err != nil {
+		return nil, err
+	}
+	return in.FS.Open(name)
+}
+
+// ReadFile reads the content of the input file at path.
+func (in *InputFS) ReadFile(path string) ([]byte, error) {
+	if in == nil {
+		return os.ReadFile(path)
+	}
+	name, err := in.name(path)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadFile(in.FS, name)
+}
+
+// Stat describes the input file at path.
+func (in *InputFS) Stat(path string) (fs.FileInfo, error) {
+	if in == nil {
+		return os.Stat(path)
+	}
+	name, err := in.name(path)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Stat(in.FS, name)
+}
+
+// name returns the name in FS of the input file at path.
+func (in *InputFS) name(path string) (string, error) {
+	rel, err := filepath.Rel(in.Root, filepath.Clean(path))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", apperrors.Wrap("input file %s is outside of the input folder %s", path, in.Root)
+	}
+	return filepath.ToSlash(rel), nil
+}
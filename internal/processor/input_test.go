@@ -0,0 +1,63 @@
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestInputFS(t *testing.T) {
+	in := NewInputFS(fstest.MapFS{
+		"button/base.css": {Data: []byte(".btn{}")},
+	}, "assets")
+
+	data, err := in.ReadFile(filepath.Join("assets", "button", "base.css"))
+	if err != nil || string(data) != ".btn{}" {
+		t.Errorf("Expected the content of the bundled file, got %q (err: %v)", data, err)
+	}
+	if _, err := in.Stat(filepath.Join("assets", "button", "base.css")); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := in.Open(filepath.Join("assets", "button", "missing.css")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+	if _, err := in.ReadFile(filepath.Join("other", "base.css")); err == nil || !strings.Contains(err.Error(), "outside of the input folder") {
+		t.Errorf("Expected an error for a file outside of the input folder, got %v", err)
+	}
+}
+
+func TestInputFS_Nil(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "base.css")
+	if err := os.WriteFile(path, []byte(".btn{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	in := NewInputFS(nil, "assets")
+	data, err := in.ReadFile(path)
+	if err != nil || string(data) != ".btn{}" {
+		t.Errorf("Expected the OS file to be read, got %q (err: %v)", data, err)
+	}
+}
+
+func TestPassthroughProcessor_InputFS(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "base.templ")
+	templ := "<style>\n/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo] END */\n</style>\n"
+	if err := os.WriteFile(output, []byte(templ), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &PassthroughProcessor{Input: NewInputFS(fstest.MapFS{"base.css": {Data: []byte(".zip{}")}}, "assets")}
+	if err := p.Process(filepath.Join("assets", "base.css"), output, "tempo"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), ".zip{}") {
+		t.Errorf("Expected the bundled content to be injected, got:\n%s", content)
+	}
+}
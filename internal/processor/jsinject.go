@@ -0,0 +1,261 @@
+package processor
+
+import (
+	"regexp"
+	"strings"
+
+	apperrors "github.com/indaco/tempo/internal/apperrors"
+)
+
+// esModuleRe matches the top-level import and export statements, which cannot be
+// wrapped in a function.
+var esModuleRe = regexp.MustCompile(`(?m)^\s*(?:import\s*[\w{*"']|export\s)`)
+
+// regexPrecedingKeywords are the keywords after which a slash starts a regular
+// expression literal instead of a division.
+var regexPrecedingKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "case": true, "do": true, "else": true,
+	"in": true, "of": true, "new": true, "delete": true, "void": true, "throw": true,
+	"yield": true, "await": true,
+}
+
+// JSInjector prepares the JS content injected into the script blocks of .templ files,
+// instead of injecting it as plain text.
+type JSInjector struct {
+	Escape   bool // Escape the sequences ending the script element or starting a templ expression
+	WrapIIFE bool // Wrap the code in an immediately invoked function expression
+}
+
+// NewJSInjector returns the JSInjector with the given options, or nil when both are
+// disabled and the JS content is injected as is.
+func NewJSInjector(escape, wrapIIFE bool) *JSInjector {
+	if !escape && !wrapIIFE {
+		return nil
+	}
+	return &JSInjector{Escape: escape, WrapIIFE: wrapIIFE}
+}
+
+// Prepare wraps and escapes the JS content as configured.
+func (j *JSInjector) Prepare(content string) (string, error) {
+	if j.WrapIIFE && strings.TrimSpace(content) != "" {
+		if esModuleRe.MatchString(content) {
+			return "", apperrors.Wrap("cannot wrap an ES module in an IIFE: remove its import and export statements or disable processor.js_injection.wrap_iife")
+		}
+		content = "(() => {\n" + strings.TrimRight(content, "\n") + "\n})();\n"
+	}
+	if j.Escape {
+		content = EscapeScript(content)
+	}
+	return content, nil
+}
+
+// jsContext is the lexical context of a position in JS code.
+type jsContext int
+
+const (
+	jsCode jsContext = iota
+	jsLineComment
+	jsBlockComment
+	jsString
+	jsTemplate
+	jsRegex
+)
+
+// EscapeScript escapes the JS content of a templ script block: the "</script" and
+// "<!--" sequences, which end or alter the script element in the HTML parser, and the
+// "{{" delimiters of the templ expressions. The escapes keep the behavior of the code:
+// string, template and regular expression literals get JS escapes, while the code and
+// the comments get a space. Template literals are followed through their ${}
+// substitutions, so that the code nested in them is escaped as code.
+func EscapeScript(js string) string {
+	var sb strings.Builder
+	sb.Grow(len(js))
+
+	state := jsCode
+	var quote byte      // Quote of the current string literal
+	var inClass bool    // Inside a character class of a regular expression literal
+	var braces int      // Depth of the braces opened in the code
+	var templates []int // Brace depth of the code of each open template substitution
+	var prev byte       // Last significant character of the code
+	var word string     // Last identifier of the code, when prev ends it
+
+	for i := 0; i < len(js); i++ {
+		c := js[i]
+		rest := js[i:]
+
+		// Sequences escaped in every context
+		if c == '<' && hasPrefixFold(rest, "</script") {
+			if state == jsCode {
+				sb.WriteString("< ")
+				prev, word = '<', ""
+				continue
+			}
+			sb.WriteString(`<\/`)
+			sb.WriteString(rest[2:len("</script")])
+			i += len("</script") - 1
+			continue
+		}
+		if c == '<' && strings.HasPrefix(rest, "<!--") && state != jsCode {
+			sb.WriteString(`<\x21--`)
+			i += len("<!--") - 1
+			continue
+		}
+		if c == '{' && strings.HasPrefix(rest, "{{") {
+			switch state {
+			case jsString, jsTemplate, jsRegex:
+				sb.WriteString(`{\x7b`)
+				i++
+				continue
+			case jsLineComment, jsBlockComment:
+				sb.WriteString("{ ")
+				continue
+			}
+			// In the code, the first brace opens a block: the space is written below
+		}
+
+		switch state {
+		case jsLineComment:
+			if c == '\n' {
+				state = jsCode
+			}
+			sb.WriteByte(c)
+
+		case jsBlockComment:
+			if c == '*' && strings.HasPrefix(rest, "*/") {
+				sb.WriteString("*/")
+				i++
+				state = jsCode
+				continue
+			}
+			sb.WriteByte(c)
+
+		case jsString:
+			sb.WriteByte(c)
+			switch c {
+			case '\\':
+				if i+1 < len(js) {
+					i++
+					sb.WriteByte(js[i])
+				}
+			case quote, '\n':
+				state, prev, word = jsCode, quote, ""
+			}
+
+		case jsTemplate:
+			sb.WriteByte(c)
+			switch {
+			case c == '\\':
+				if i+1 < len(js) {
+					i++
+					sb.WriteByte(js[i])
+				}
+			case c == '`':
+				state, prev, word = jsCode, '`', ""
+			case c == '$' && strings.HasPrefix(rest, "${"):
+				sb.WriteByte('{')
+				i++
+				templates = append(templates, braces)
+				braces++
+				state, prev, word = jsCode, '{', ""
+			}
+
+		case jsRegex:
+			sb.WriteByte(c)
+			switch {
+			case c == '\\':
+				if i+1 < len(js) {
+					i++
+					sb.WriteByte(js[i])
+				}
+			case c == '[':
+				inClass = true
+			case c == ']':
+				inClass = false
+			case c == '/' && !inClass, c == '\n':
+				state, prev, word = jsCode, '/', ""
+				// The flags following the literal are read as an identifier
+				for i+1 < len(js) && isJSIdentByte(js[i+1]) {
+					i++
+					sb.WriteByte(js[i])
+				}
+				word = "" // Not a keyword: a slash after the flags is a division
+				prev = 'a'
+			}
+
+		default: // jsCode
+			switch {
+			case c == '/' && strings.HasPrefix(rest, "//"):
+				state = jsLineComment
+				sb.WriteString("//")
+				i++
+				continue
+			case c == '/' && strings.HasPrefix(rest, "/*"):
+				state = jsBlockComment
+				sb.WriteString("/*")
+				i++
+				continue
+			case c == '/' && startsRegex(prev, word):
+				state, inClass = jsRegex, false
+			case c == '\'' || c == '"':
+				state, quote = jsString, c
+			case c == '`':
+				state = jsTemplate
+			case c == '{':
+				braces++
+				if strings.HasPrefix(rest, "{{") {
+					sb.WriteString("{ ")
+					prev, word = c, ""
+					continue
+				}
+			case c == '}':
+				braces--
+				if n := len(templates); n > 0 && templates[n-1] == braces {
+					templates = templates[:n-1]
+					state = jsTemplate
+				}
+			}
+			sb.WriteByte(c)
+
+			if state == jsCode && !isJSSpace(c) {
+				if isJSIdentByte(c) {
+					if !isJSIdentByte(prev) {
+						word = ""
+					}
+					word += string(c)
+				} else {
+					word = ""
+				}
+				prev = c
+			}
+		}
+	}
+	return sb.String()
+}
+
+// startsRegex reports whether a slash following the significant character prev, and
+// the identifier word it ends, starts a regular expression literal.
+func startsRegex(prev byte, word string) bool {
+	if prev == 0 {
+		return true
+	}
+	if isJSIdentByte(prev) {
+		return regexPrecedingKeywords[word]
+	}
+	return !strings.ContainsRune(")]}`'\"", rune(prev))
+}
+
+// isJSIdentByte reports whether c can be part of an identifier or a number.
+func isJSIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// isJSSpace reports whether c is a white space or a line terminator.
+func isJSSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+// hasPrefixFold reports whether s starts with prefix, ignoring the ASCII case.
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
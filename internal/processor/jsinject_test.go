@@ -0,0 +1,72 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewJSInjector(t *testing.T) {
+	if injector := NewJSInjector(false, false); injector != nil {
+		t.Errorf("Expected no injector without options, got %+v", injector)
+	}
+	if injector := NewJSInjector(true, false); injector == nil || !injector.Escape || injector.WrapIIFE {
+		t.Errorf("Unexpected injector: %+v", injector)
+	}
+}
+
+func TestEscapeScript(t *testing.T) {
+	tests := []struct {
+		name string
+		js   string
+		want string
+	}{
+		{"plain code", "const a = b / 2;", "const a = b / 2;"},
+		{"string", `el.innerHTML = "</script><!-- {{ x }}";`, `el.innerHTML = "<\/script><\x21-- {\x7b x }}";`},
+		{"case preserved", `s = '</SCRIPT>';`, `s = '<\/SCRIPT>';`},
+		{"escaped quote", `s = "a\"</script>";`, `s = "a\"<\/script>";`},
+		{"template literal", "s = `<div>{{</script>`;", "s = `<div>{\\x7b<\\/script>`;"},
+		{"template substitution", "s = `${ {a: 1}.a } </script>`;", "s = `${ {a: 1}.a } <\\/script>`;"},
+		{"nested template", "s = `${ `</script>` + x }`;", "s = `${ `<\\/script>` + x }`;"},
+		{"regex", `re = /<\/script>|{{/g;`, `re = /<\/script>|{\x7b/g;`},
+		{"regex after keyword", `return /</script/i.test(s);`, `return /<\/script/i.test(s);`},
+		{"regex class", `re = /[/]</script/;`, `re = /[/]<\/script/;`},
+		{"division", `x = a / b </script/ c;`, `x = a / b < /script/ c;`},
+		{"line comment", "// see </script> and {{ x }}\nrun();", "// see <\\/script> and { { x }}\nrun();"},
+		{"block comment", "/* <!-- {{ */ run();", `/* <\x21-- { { */ run();`},
+		{"code braces", "if (a) {{ run(); }}", "if (a) { { run(); }}"},
+		{"comment delimiter in string", `s = "// {{"; t = "{{";`, `s = "// {\x7b"; t = "{\x7b";`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeScript(tt.js); got != tt.want {
+				t.Errorf("EscapeScript(%q) = %q, want %q", tt.js, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSInjector_Prepare(t *testing.T) {
+	injector := &JSInjector{Escape: true, WrapIIFE: true}
+
+	got, err := injector.Prepare("const tag = '</script>';\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "(() => {\nconst tag = '<\\/script>';\n})();\n"; got != want {
+		t.Errorf("Expected the wrapped and escaped code %q, got %q", want, got)
+	}
+
+	if got, err := injector.Prepare("  \n"); err != nil || got != "  \n" {
+		t.Errorf("Expected empty content to be kept as is, got %q (err: %v)", got, err)
+	}
+
+	for _, js := range []string{"import { a } from './a.js';\na();", "export const a = 1;", "import './side-effect.js';"} {
+		if _, err := injector.Prepare(js); err == nil || !strings.Contains(err.Error(), "ES module") {
+			t.Errorf("Expected an error wrapping the ES module %q, got %v", js, err)
+		}
+	}
+	if _, err := injector.Prepare("const m = await import('./a.js');"); err != nil {
+		t.Errorf("Expected a dynamic import to be wrapped, got %v", err)
+	}
+}
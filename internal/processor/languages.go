@@ -0,0 +1,203 @@
+package processor
+
+import (
+	"go/token"
+	"html"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/evanw/esbuild/pkg/api"
+	apperrors "github.com/indaco/tempo/internal/apperrors"
+)
+
+// Modes of the asset languages.
+const (
+	LanguageInject = "inject" // The content goes between the guard markers as is
+	LanguageGoVar  = "go_var" // The content goes between the guard markers as a Go string variable
+)
+
+// Escapes of the content of the asset languages.
+const (
+	EscapeNone  = "none"  // Injected as is
+	EscapeHTML  = "html"  // HTML text: <, >, &, ' and " as entities
+	EscapeJSON  = "json"  // JSON in a script block: <, > and & as \u escapes, valid in JSON strings
+	EscapeTempl = "templ" // Markup in a templ element: { and } as entities, so they do not start expressions
+)
+
+// LanguageModes and LanguageEscapes list the valid modes and escapes.
+var (
+	LanguageModes   = []string{LanguageInject, LanguageGoVar}
+	LanguageEscapes = []string{EscapeNone, EscapeHTML, EscapeJSON, EscapeTempl}
+)
+
+// defaultEscapes are the escapes of the known extensions injected in the inject mode.
+var defaultEscapes = map[string]string{
+	".json": EscapeJSON,
+	".svg":  EscapeTempl,
+}
+
+// jsonEscaper escapes the characters ending or altering a script element in JSON.
+var jsonEscaper = strings.NewReplacer("<", `\u003c`, ">", `\u003e`, "&", `\u0026`)
+
+// templEscaper escapes the braces starting and ending the templ expressions.
+var templEscaper = strings.NewReplacer("{", "&#123;", "}", "&#125;")
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// AssetLanguage is a file type synced into the .templ files besides CSS and JS, e.g.
+// JSON design tokens or SVG icons. Its content is escaped and injected between the guard
+// markers of the .templ file, in its own block, or exposed there as a Go variable.
+type AssetLanguage struct {
+	Extension string // File extension, e.g. ".json"
+	Mode      string // LanguageInject (default) or LanguageGoVar
+	Escape    string // One of LanguageEscapes; defaults per extension in the inject mode, none otherwise
+	Variable  string // Name of the Go variable; derived from the file name when empty, e.g. tokensJSON
+}
+
+// AssetLanguages holds the asset languages by extension. A nil AssetLanguages only
+// supports CSS and JS.
+type AssetLanguages map[string]AssetLanguage
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTOR                                                               */
+/* ------------------------------------------------------------------------- */
+
+// NewAssetLanguages validates the asset languages and sets their defaults. CSS and JS
+// cannot be redeclared, and an extension is declared once.
+func NewAssetLanguages(langs ...AssetLanguage) (AssetLanguages, error) {
+	if len(langs) == 0 {
+		return nil, nil
+	}
+
+	languages := make(AssetLanguages, len(langs))
+	for _, lang := range langs {
+		ext := strings.ToLower(strings.TrimSpace(lang.Extension))
+		if ext != "" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		switch {
+		case len(ext) < 2:
+			return nil, apperrors.Wrap("invalid processor.languages: missing extension")
+		case GetLoader(ext) != api.LoaderNone:
+			return nil, apperrors.Wrap("invalid processor.languages: %s is synced natively", ext)
+		case languages[ext].Extension != "":
+			return nil, apperrors.Wrap("invalid processor.languages: %s is declared twice", ext)
+		}
+		lang.Extension = ext
+
+		if lang.Mode == "" {
+			lang.Mode = LanguageInject
+		}
+		if !slices.Contains(LanguageModes, lang.Mode) {
+			return nil, apperrors.Wrap("invalid processor.languages mode %s for %s (valid: %s)", lang.Mode, ext, strings.Join(LanguageModes, ", "))
+		}
+
+		if lang.Escape == "" {
+			lang.Escape = EscapeNone
+			if lang.Mode == LanguageInject && defaultEscapes[ext] != "" {
+				lang.Escape = defaultEscapes[ext]
+			}
+		}
+		if !slices.Contains(LanguageEscapes, lang.Escape) {
+			return nil, apperrors.Wrap("invalid processor.languages escape %s for %s (valid: %s)", lang.Escape, ext, strings.Join(LanguageEscapes, ", "))
+		}
+
+		if lang.Variable != "" && (lang.Mode != LanguageGoVar || !token.IsIdentifier(lang.Variable)) {
+			return nil, apperrors.Wrap("invalid processor.languages variable %s for %s: must be a Go identifier, in the go_var mode", lang.Variable, ext)
+		}
+		languages[ext] = lang
+	}
+	return languages, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// Lookup returns the asset language of filePath, and false when its extension is not
+// declared.
+func (l AssetLanguages) Lookup(filePath string) (AssetLanguage, bool) {
+	lang, ok := l[strings.ToLower(filepath.Ext(filePath))]
+	return lang, ok
+}
+
+// Supports reports whether filePath is synced: a CSS or JS file, or a file of a
+// declared asset language.
+func (l AssetLanguages) Supports(filePath string) bool {
+	if GetLoader(filepath.Ext(filePath)) != api.LoaderNone {
+		return true
+	}
+	_, ok := l.Lookup(filePath)
+	return ok
+}
+
+// Prepare escapes the content of filePath and, in the go_var mode, declares it as a Go
+// string variable.
+func (a AssetLanguage) Prepare(filePath, content string) (string, error) {
+	switch a.Escape {
+	case EscapeHTML:
+		content = html.EscapeString(content)
+	case EscapeJSON:
+		content = jsonEscaper.Replace(content)
+	case EscapeTempl:
+		content = templEscaper.Replace(content)
+	}
+
+	if a.Mode != LanguageGoVar {
+		return content, nil
+	}
+	name := a.Variable
+	if name == "" {
+		name = variableName(filePath)
+	}
+	return "var " + name + " = " + goStringLiteral(content) + "\n", nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// variableName derives the name of the Go variable of filePath from its base name and
+// extension, e.g. design-tokens.json -> designTokensJSON.
+func variableName(filePath string) string {
+	base := filepath.Base(filePath)
+	ext := filepath.Ext(base)
+
+	var sb strings.Builder
+	upper := false
+	for _, r := range strings.TrimSuffix(base, ext) {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			upper = sb.Len() > 0
+		case sb.Len() == 0 && unicode.IsDigit(r):
+			sb.WriteByte('_')
+			sb.WriteRune(r)
+		case upper:
+			sb.WriteRune(unicode.ToUpper(r))
+			upper = false
+		case sb.Len() == 0:
+			sb.WriteRune(unicode.ToLower(r))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	if sb.Len() == 0 {
+		sb.WriteString("asset")
+	}
+	sb.WriteString(strings.ToUpper(strings.TrimPrefix(ext, ".")))
+	return sb.String()
+}
+
+// goStringLiteral returns content as a Go raw string literal when it can be one, for
+// the readability of the .templ file, or else as an interpreted one.
+func goStringLiteral(content string) string {
+	if !strings.ContainsAny(content, "`\r") {
+		return "`" + content + "`"
+	}
+	return strconv.Quote(content)
+}
@@ -0,0 +1,91 @@
+package processor
+
+import (
+	"testing"
+)
+
+func TestNewAssetLanguages(t *testing.T) {
+	languages, err := NewAssetLanguages(
+		AssetLanguage{Extension: "JSON"},
+		AssetLanguage{Extension: ".svg"},
+		AssetLanguage{Extension: ".txt", Mode: LanguageGoVar},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for path, want := range map[string]AssetLanguage{
+		"tokens.json": {Extension: ".json", Mode: LanguageInject, Escape: EscapeJSON},
+		"icon.SVG":    {Extension: ".svg", Mode: LanguageInject, Escape: EscapeTempl},
+		"notes.txt":   {Extension: ".txt", Mode: LanguageGoVar, Escape: EscapeNone},
+	} {
+		if got, ok := languages.Lookup(path); !ok || got != want {
+			t.Errorf("Expected %+v for %s, got %+v", want, path, got)
+		}
+	}
+	if !languages.Supports("base.css") || languages.Supports("image.png") {
+		t.Error("Expected CSS and the declared extensions only to be supported")
+	}
+	if AssetLanguages(nil).Supports("tokens.json") {
+		t.Error("Expected no asset language to be supported without declarations")
+	}
+
+	invalid := map[string][]AssetLanguage{
+		"missing extension":   {{}},
+		"native extension":    {{Extension: ".js"}},
+		"declared twice":      {{Extension: ".json"}, {Extension: "json"}},
+		"invalid mode":        {{Extension: ".json", Mode: "embed"}},
+		"invalid escape":      {{Extension: ".json", Escape: "url"}},
+		"invalid variable":    {{Extension: ".json", Mode: LanguageGoVar, Variable: "my-tokens"}},
+		"variable for inject": {{Extension: ".json", Variable: "tokens"}},
+	}
+	for name, langs := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewAssetLanguages(langs...); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestAssetLanguage_Prepare(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     AssetLanguage
+		path     string
+		content  string
+		expected string
+	}{
+		{name: "JSON escape", lang: AssetLanguage{Escape: EscapeJSON}, path: "tokens.json", content: `{"a": "</script>&"}`, expected: `{"a": "\u003c/script\u003e\u0026"}`},
+		{name: "Templ escape", lang: AssetLanguage{Escape: EscapeTempl}, path: "icon.svg", content: `<svg>{x}</svg>`, expected: `<svg>&#123;x&#125;</svg>`},
+		{name: "HTML escape", lang: AssetLanguage{Escape: EscapeHTML}, path: "a.txt", content: `<b>`, expected: `&lt;b&gt;`},
+		{name: "Go variable", lang: AssetLanguage{Mode: LanguageGoVar}, path: "design-tokens.json", content: `{}`, expected: "var designTokensJSON = `{}`\n"},
+		{name: "Named Go variable", lang: AssetLanguage{Mode: LanguageGoVar, Variable: "Icon"}, path: "icon.svg", content: "a`b", expected: "var Icon = \"a`b\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.lang.Prepare(tt.path, tt.content)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestVariableName(t *testing.T) {
+	for path, want := range map[string]string{
+		"tokens.json":         "tokensJSON",
+		"dir/Icon-close.svg":  "iconCloseSVG",
+		"2x_logo.svg":         "_2xLogoSVG",
+		"---.svg":             "assetSVG",
+		"design tokens.jsonc": "designTokensJSONC",
+	} {
+		if got := variableName(path); got != want {
+			t.Errorf("variableName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
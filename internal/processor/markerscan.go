@@ -0,0 +1,245 @@
+package processor
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+)
+
+// scanState is the lexical context of the scanned byte.
+type scanState int
+
+const (
+	scanCode         scanState = iota
+	scanString                 // Inside a string literal, up to its closing quote or the end of its line
+	scanLineComment            // Inside a // comment, up to the end of its line
+	scanBlockComment           // Inside a /* */ comment
+)
+
+// markerToken is a guard marker found by markerScanner.
+type markerToken int
+
+const (
+	tokenNone  markerToken = iota // End of the input
+	tokenBegin                    // BEGIN guard marker
+	tokenEnd                      // END guard marker
+)
+
+// markerScanner is a small tokenizer splitting a .templ file into text and guard
+// markers. A marker is only recognized as a whole block comment: the marker text found
+// inside a string literal, a // comment or another comment is text. Comments do not
+// nest, as in CSS and JS, and string literals end at the end of their line, so that a
+// stray quote in HTML text never hides the markers of the following lines. Text is
+// copied as is, byte for byte, BOMs and line endings included; only the comments that
+// may still be a marker are held in memory.
+type markerScanner struct {
+	r         *bufio.Reader
+	begin     string
+	end       string
+	state     scanState
+	quote     byte   // Closing quote of the string literal
+	escaped   bool   // Previous byte of the string literal is a backslash
+	slash     bool   // Previous byte is a '/' that may start a comment
+	buffering bool   // The block comment is held in comment, as it may be a marker
+	comment   []byte // Block comment read so far, while buffering
+	prev      byte   // Previous byte of a block comment too long to be a marker
+	out       []byte // Text not yet copied
+}
+
+// newMarkerScanner returns a scanner of the guard markers of markerName in r.
+func newMarkerScanner(r io.Reader, markerName string) *markerScanner {
+	begin, end := GuardMarkers(markerName)
+	return &markerScanner{
+		r:     bufio.NewReaderSize(r, streamBufferSize),
+		begin: begin,
+		end:   end,
+	}
+}
+
+// Next copies the text up to the next guard marker to w, without the marker, and
+// returns the marker, or tokenNone at the end of the input.
+func (s *markerScanner) Next(w io.Writer) (markerToken, error) {
+	for {
+		if _, err := s.r.Peek(1); err != nil {
+			if !errors.Is(err, io.EOF) {
+				return tokenNone, err
+			}
+			s.flushPending()
+			return tokenNone, s.flush(w)
+		}
+
+		chunk, _ := s.r.Peek(s.r.Buffered())
+		n, token, err := s.scanChunk(w, chunk)
+		_, _ = s.r.Discard(n)
+		if err != nil {
+			return tokenNone, err
+		}
+		if token != tokenNone {
+			return token, s.flush(w)
+		}
+		if len(s.out) >= streamBufferSize {
+			if err := s.flush(w); err != nil {
+				return tokenNone, err
+			}
+		}
+	}
+}
+
+// scanChunk processes the bytes of chunk up to the end of the first marker, and returns
+// the number of bytes processed with the marker, if any. Runs of plain text are copied
+// to w at once.
+func (s *markerScanner) scanChunk(w io.Writer, chunk []byte) (int, markerToken, error) {
+	for i := 0; i < len(chunk); i++ {
+		var special int
+		switch {
+		case s.state == scanCode && !s.slash:
+			special = bytes.IndexAny(chunk[i:], "/\"'`")
+		case s.state == scanLineComment:
+			special = bytes.IndexByte(chunk[i:], '\n')
+		}
+		if special == -1 {
+			return len(chunk), tokenNone, s.copyText(w, chunk[i:])
+		}
+		if err := s.copyText(w, chunk[i:i+special]); err != nil {
+			return i, tokenNone, err
+		}
+		i += special
+
+		if token := s.scan(chunk[i]); token != tokenNone {
+			return i + 1, token, nil
+		}
+	}
+	return len(chunk), tokenNone, nil
+}
+
+// copyText copies a run of text to w, after the pending text. Short runs are only
+// added to the pending text.
+func (s *markerScanner) copyText(w io.Writer, text []byte) error {
+	if len(text) < 512 {
+		s.out = append(s.out, text...)
+		return nil
+	}
+	if err := s.flush(w); err != nil {
+		return err
+	}
+	_, err := w.Write(text)
+	return err
+}
+
+// scan processes c and returns the marker it completes, if any.
+func (s *markerScanner) scan(c byte) markerToken {
+	switch s.state {
+	case scanString:
+		s.out = append(s.out, c)
+		switch {
+		case s.escaped:
+			s.escaped = false
+		case c == '\\':
+			s.escaped = true
+		case c == s.quote, c == '\n':
+			s.state = scanCode
+		}
+
+	case scanLineComment:
+		s.out = append(s.out, c)
+		if c == '\n' {
+			s.state = scanCode
+		}
+
+	case scanBlockComment:
+		return s.scanComment(c)
+
+	default:
+		s.scanCode(c)
+	}
+	return tokenNone
+}
+
+// scanCode processes c outside of strings and comments.
+func (s *markerScanner) scanCode(c byte) {
+	if s.slash {
+		s.slash = false
+		switch c {
+		case '*':
+			s.state = scanBlockComment
+			s.buffering = true
+			s.comment = append(s.comment[:0], '/', '*')
+			return
+		case '/':
+			s.state = scanLineComment
+			s.out = append(s.out, '/', '/')
+			return
+		}
+		s.out = append(s.out, '/')
+	}
+
+	switch c {
+	case '/':
+		s.slash = true
+	case '"', '\'', '`':
+		s.state = scanString
+		s.quote = c
+		s.out = append(s.out, c)
+	default:
+		s.out = append(s.out, c)
+	}
+}
+
+// scanComment processes c inside a block comment and returns the marker it completes.
+func (s *markerScanner) scanComment(c byte) markerToken {
+	if !s.buffering {
+		s.out = append(s.out, c)
+		if s.prev == '*' && c == '/' {
+			s.state = scanCode
+		}
+		s.prev = c
+		return tokenNone
+	}
+
+	s.comment = append(s.comment, c)
+	n := len(s.comment)
+	if n >= 4 && c == '/' && s.comment[n-2] == '*' {
+		s.state = scanCode
+		s.buffering = false
+		switch string(s.comment) {
+		case s.begin:
+			return tokenBegin
+		case s.end:
+			return tokenEnd
+		}
+		s.out = append(s.out, s.comment...)
+		return tokenNone
+	}
+
+	// Longer than the markers: copy the rest of the comment as it is read
+	if n > max(len(s.begin), len(s.end)) {
+		s.buffering = false
+		s.prev = c
+		s.out = append(s.out, s.comment...)
+	}
+	return tokenNone
+}
+
+// flushPending moves the bytes held while looking for a marker to the text, at the
+// end of the input.
+func (s *markerScanner) flushPending() {
+	if s.slash {
+		s.slash = false
+		s.out = append(s.out, '/')
+	}
+	if s.buffering {
+		s.buffering = false
+		s.out = append(s.out, s.comment...)
+	}
+}
+
+// flush copies the pending text to w.
+func (s *markerScanner) flush(w io.Writer) error {
+	if len(s.out) == 0 {
+		return nil
+	}
+	_, err := w.Write(s.out)
+	s.out = s.out[:0]
+	return err
+}
@@ -0,0 +1,108 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+)
+
+// scanMarkers returns the text pieces of content and the markers between them.
+func scanMarkers(t testing.TB, content string) ([]string, []markerToken) {
+	t.Helper()
+	scanner := newMarkerScanner(strings.NewReader(content), "tempo")
+	var texts []string
+	var tokens []markerToken
+	for {
+		var text strings.Builder
+		token, err := scanner.Next(&text)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		texts = append(texts, text.String())
+		if token == tokenNone {
+			return texts, tokens
+		}
+		tokens = append(tokens, token)
+	}
+}
+
+func TestMarkerScanner(t *testing.T) {
+	begin, end := GuardMarkers("tempo")
+
+	tests := []struct {
+		name    string
+		content string
+		tokens  []markerToken
+	}{
+		{name: "Markers", content: "<style>\n" + begin + "\n.a{}\n" + end + "\n</style>", tokens: []markerToken{tokenBegin, tokenEnd}},
+		{name: "Multiple regions", content: begin + end + "\n" + begin + "x" + end, tokens: []markerToken{tokenBegin, tokenEnd, tokenBegin, tokenEnd}},
+		{name: "BOM", content: utf8BOM + begin + "\n" + end, tokens: []markerToken{tokenBegin, tokenEnd}},
+		{name: "Marker in a double-quoted string", content: `s := "` + begin + `"`},
+		{name: "Marker in a single-quoted string", content: `x = '` + end + `';`},
+		{name: "Marker in a template literal", content: "`" + begin + "`"},
+		{name: "Escaped quote in a string", content: `"a\"` + begin + `"`},
+		{name: "Stray quote ends at the end of its line", content: "<p>Don't</p>\n" + begin + end, tokens: []markerToken{tokenBegin, tokenEnd}},
+		{name: "Marker inside a comment", content: "/* note " + begin + " */"},
+		{name: "Marker nested in a comment", content: "/* outer " + begin + end, tokens: []markerToken{tokenEnd}},
+		{name: "Marker in a line comment", content: "// " + begin + "\n" + end, tokens: []markerToken{tokenEnd}},
+		{name: "Other marker name", content: strings.ReplaceAll(begin, "tempo", "other")},
+		{name: "Division and comments", content: "a = b / c; /**/ /*/ */" + begin, tokens: []markerToken{tokenBegin}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			texts, tokens := scanMarkers(t, tt.content)
+			if len(tokens) != len(tt.tokens) {
+				t.Fatalf("Expected tokens %v, got %v", tt.tokens, tokens)
+			}
+			for i := range tokens {
+				if tokens[i] != tt.tokens[i] {
+					t.Fatalf("Expected tokens %v, got %v", tt.tokens, tokens)
+				}
+			}
+			if got := joinScanned(texts, tokens); got != tt.content {
+				t.Errorf("Expected the content to be preserved, got %q", got)
+			}
+		})
+	}
+}
+
+// joinScanned rebuilds the scanned content from its text pieces and markers.
+func joinScanned(texts []string, tokens []markerToken) string {
+	begin, end := GuardMarkers("tempo")
+	var sb strings.Builder
+	for i, text := range texts {
+		sb.WriteString(text)
+		if i < len(tokens) {
+			if tokens[i] == tokenBegin {
+				sb.WriteString(begin)
+			} else {
+				sb.WriteString(end)
+			}
+		}
+	}
+	return sb.String()
+}
+
+func FuzzMarkerScanner(f *testing.F) {
+	begin, end := GuardMarkers("tempo")
+	for _, seed := range []string{
+		"",
+		begin + "\n.a{}\n" + end,
+		utf8BOM + "<style>\r\n  " + begin + "\r\n  " + end + "\r\n</style>",
+		`"` + begin + `" /* ` + end + ` */ // ` + begin + "\n" + end,
+		"/* /* " + begin + " */ */" + end,
+		"'\\" + begin,
+		"/",
+		"/*",
+		"/* [tempo] BEGIN",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, content string) {
+		texts, tokens := scanMarkers(t, content)
+		if got := joinScanned(texts, tokens); got != content {
+			t.Errorf("Scanned content differs:\ngot:  %q\nwant: %q", got, content)
+		}
+	})
+}
@@ -1,18 +1,21 @@
 package processor
 
 import (
-	"os"
-
 	apperrors "github.com/indaco/tempo/internal/apperrors"
 	"github.com/indaco/tempo/internal/processor/transformers"
+	"github.com/indaco/tempo/internal/utils"
 )
 
 type MinifierProcessor struct {
 	Transform func(string) (string, error) // Transformation function
+	Watermark string                       // Comment written before the injected content
+	FileModes *utils.FileModePolicy        // Permissions of the output file (nil for the defaults)
+	Input     *InputFS                     // File system of the input file (nil for the OS one)
+	Checksum  *GuardChecksum               // Checksum of the guard region (nil to disable)
 }
 
 func (p *MinifierProcessor) Process(inputFilePath, outputFilePath, markerName string) error {
-	inputContent, err := os.ReadFile(inputFilePath)
+	inputContent, err := p.Input.ReadFile(inputFilePath)
 	if err != nil {
 		return apperrors.Wrap("failed to read input file", err)
 	}
@@ -21,7 +24,8 @@ func (p *MinifierProcessor) Process(inputFilePath, outputFilePath, markerName st
 		RawData:    string(inputContent),
 		Transform:  p.Transform,
 		MarkerName: markerName,
+		Watermark:  p.Watermark,
 	}
 
-	return processWithTransformation(transformerConfig, outputFilePath)
+	return processWithTransformation(transformerConfig, outputFilePath, p.FileModes, p.Checksum)
 }
@@ -1,28 +1,55 @@
 package processor
 
 import (
-	"os"
+	"io"
+	"io/fs"
 
 	apperrors "github.com/indaco/tempo/internal/apperrors"
-	"github.com/indaco/tempo/internal/processor/transformers"
+	"github.com/indaco/tempo/internal/utils"
 )
 
 // StandardProcessor processes files without modifications.
-type PassthroughProcessor struct{}
+type PassthroughProcessor struct {
+	Watermark string                // Comment written before the injected content
+	FileModes *utils.FileModePolicy // Permissions of the output file (nil for the defaults)
+	Input     *InputFS              // File system of the input file (nil for the OS one)
+	Checksum  *GuardChecksum        // Checksum of the guard region (nil to disable)
+}
 
 // Process simply inserts the raw content from the input file into the output file.
 func (p *PassthroughProcessor) Process(inputFilePath, outputFilePath, markerName string) error {
-	// Read input file content
-	inputContent, err := os.ReadFile(inputFilePath)
+	// Open the input file: its content is streamed into the output file
+	input, err := p.Input.Open(inputFilePath)
 	if err != nil {
 		return apperrors.Wrap("failed to read input file", err)
 	}
+	defer func() { _ = input.Close() }()
 
-	transformerConfig := transformers.TransformationConfig{
-		RawData:    string(inputContent),
-		Transform:  func(input string) (string, error) { return input, nil },
-		MarkerName: markerName,
+	// Each guard region of the output file receives the whole input file
+	copied := false
+	inject := func(w io.Writer) error {
+		if copied {
+			if input, err = p.rewind(input, inputFilePath); err != nil {
+				return apperrors.Wrap("failed to read input file", err)
+			}
+		}
+		copied = true
+		if _, err := io.Copy(w, input); err != nil {
+			return apperrors.Wrap("failed to read input file", err)
+		}
+		return nil
 	}
 
-	return processWithTransformation(transformerConfig, outputFilePath)
+	return processWithInjection(outputFilePath, markerName, p.Watermark, inject, p.FileModes, p.Checksum)
+}
+
+// rewind returns input read from the start again: it is seeked when possible, and
+// reopened otherwise.
+func (p *PassthroughProcessor) rewind(input fs.File, inputFilePath string) (fs.File, error) {
+	if seeker, ok := input.(io.Seeker); ok {
+		_, err := seeker.Seek(0, io.SeekStart)
+		return input, err
+	}
+	_ = input.Close()
+	return p.Input.Open(inputFilePath)
 }
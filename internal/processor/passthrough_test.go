@@ -39,6 +39,35 @@ func Button() {
 /* [tempo] BEGIN - Do not edit! This section is auto-generated. */
 .button { color: blue; }
 /* [tempo] END */
+}`,
+			expectError: false,
+		},
+		{
+			name:         "Content inserted in every guarded region",
+			inputContent: ".button { color: blue; }",
+			outputContent: `package button
+
+func Button() {
+/* [tempo] BEGIN - Do not edit! This section is auto-generated. */
+/* [tempo] END */
+}
+
+func ButtonPreview() {
+/* [tempo] BEGIN - Do not edit! This section is auto-generated. */
+/* [tempo] END */
+}`,
+			expectedContent: `package button
+
+func Button() {
+/* [tempo] BEGIN - Do not edit! This section is auto-generated. */
+.button { color: blue; }
+/* [tempo] END */
+}
+
+func ButtonPreview() {
+/* [tempo] BEGIN - Do not edit! This section is auto-generated. */
+.button { color: blue; }
+/* [tempo] END */
 }`,
 			expectError: false,
 		},
@@ -0,0 +1,301 @@
+package processor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	apperrors "github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// Styles of the scoped class names.
+const (
+	ScopeHash   = "hash"   // <class>_<hash of the component>, e.g. btn_3f2a1c
+	ScopePrefix = "prefix" // <component>__<class>, e.g. button__btn
+)
+
+// ScopeStyles lists the supported styles of the scoped class names.
+var ScopeStyles = []string{ScopeHash, ScopePrefix}
+
+// ClassesFileSuffix ends the name of the Go files holding the scoped class names.
+const ClassesFileSuffix = "_classes.go"
+
+// ruleContainers are the at-rules whose blocks hold style rules.
+var ruleContainers = []string{"media", "supports", "container", "layer", "scope", "document", "starting-style"}
+
+// templPackageRe matches the package clause of a .templ file.
+var templPackageRe = regexp.MustCompile(`(?m)^package\s+([A-Za-z_][A-Za-z0-9_]*)`)
+
+// CSSScoper rewrites the class selectors of CSS files with names scoped to their
+// component, as CSS modules do, so that components cannot clash on class names.
+type CSSScoper struct {
+	Style    string // ScopeHash (default) or ScopePrefix
+	InputDir string // Root of the input files, used to detect the component name
+}
+
+// NewCSSScoper validates the style of the scoped class names.
+func NewCSSScoper(style, inputDir string) (*CSSScoper, error) {
+	if style == "" {
+		style = ScopeHash
+	}
+	if !slices.Contains(ScopeStyles, style) {
+		return nil, apperrors.Wrap("invalid processor.scoped_css.style %s (valid: %s)", style, strings.Join(ScopeStyles, ", "))
+	}
+	return &CSSScoper{Style: style, InputDir: inputDir}, nil
+}
+
+// Scope rewrites the class selectors of the CSS content of filePath and returns the
+// rewritten content with the scoped name of every class. Declarations, comments,
+// strings, attribute selectors and the blocks of at-rules such as @keyframes or
+// @font-face are left untouched.
+func (s *CSSScoper) Scope(filePath, content string) (string, map[string]string) {
+	component := componentNameFromPath(s.InputDir, filePath)
+	classes := make(map[string]string)
+
+	var out, prelude strings.Builder
+	var blocks []bool // Whether each open block holds style rules
+	inRules := func() bool { return len(blocks) == 0 || blocks[len(blocks)-1] }
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := strings.Index(content[i+2:], "*/")
+			if end == -1 {
+				end = len(content) - i - 4
+			}
+			prelude.WriteString(content[i : i+end+4])
+			i += end + 3
+		case c == '"' || c == '\'':
+			end := skipString(content, i)
+			prelude.WriteString(content[i:end])
+			i = end - 1
+		case c == '{':
+			text := prelude.String()
+			prelude.Reset()
+			rules := false
+			if inRules() {
+				if name, ok := atRuleName(text); ok {
+					rules = slices.Contains(ruleContainers, name)
+				} else {
+					text = s.scopeSelector(text, component, classes)
+					rules = true
+				}
+			}
+			out.WriteString(text)
+			out.WriteByte(c)
+			blocks = append(blocks, rules)
+		case c == '}' || c == ';':
+			out.WriteString(prelude.String())
+			prelude.Reset()
+			out.WriteByte(c)
+			if c == '}' && len(blocks) > 0 {
+				blocks = blocks[:len(blocks)-1]
+			}
+		default:
+			prelude.WriteByte(c)
+		}
+	}
+	out.WriteString(prelude.String())
+
+	return out.String(), classes
+}
+
+// ScopedName returns the scoped name of a class of the given component.
+func (s *CSSScoper) ScopedName(component, class string) string {
+	if s.Style == ScopePrefix {
+		return cssIdent(component) + "__" + class
+	}
+	sum := sha256.Sum256([]byte(component))
+	return class + "_" + hex.EncodeToString(sum[:3])
+}
+
+// WriteClassesFile writes, next to the .templ file at templPath, the Go file mapping
+// the class names of the CSS file at cssPath to their scoped names. The file is only
+// rewritten when its content changes.
+func (s *CSSScoper) WriteClassesFile(cssPath, templPath string, classes map[string]string, fileModes *utils.FileModePolicy) error {
+	pkg := filepath.Base(filepath.Dir(templPath))
+	if templ, err := os.ReadFile(templPath); err == nil {
+		if match := templPackageRe.FindSubmatch(templ); match != nil {
+			pkg = string(match[1])
+		}
+	}
+
+	base := strings.TrimSuffix(filepath.Base(cssPath), filepath.Ext(cssPath))
+	content, err := classesFileContent(pkg, goIdent(base)+"Classes", filepath.Base(cssPath), classes)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(filepath.Dir(templPath), base+ClassesFileSuffix)
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+		return nil
+	}
+	if err := fileModes.WriteFile(path, content, 0); err != nil {
+		return apperrors.Wrap("failed to write scoped classes file", err, path)
+	}
+	return nil
+}
+
+// scopeSelector rewrites the class selectors of a selector list, recording their
+// scoped names in classes.
+func (s *CSSScoper) scopeSelector(selector, component string, classes map[string]string) string {
+	var sb strings.Builder
+	for i := 0; i < len(selector); i++ {
+		c := selector[i]
+		switch {
+		case c == '/' && i+1 < len(selector) && selector[i+1] == '*':
+			end := strings.Index(selector[i+2:], "*/")
+			if end == -1 {
+				sb.WriteString(selector[i:])
+				return sb.String()
+			}
+			sb.WriteString(selector[i : i+end+4])
+			i += end + 3
+		case c == '[':
+			end := strings.IndexByte(selector[i:], ']')
+			if end == -1 {
+				sb.WriteString(selector[i:])
+				return sb.String()
+			}
+			sb.WriteString(selector[i : i+end+1])
+			i += end
+		case c == '.' && i+1 < len(selector) && isIdentStart(selector[i+1:]):
+			end := identEnd(selector, i+1)
+			class := selector[i+1 : end]
+			scoped, ok := classes[class]
+			if !ok {
+				scoped = s.ScopedName(component, class)
+				classes[class] = scoped
+			}
+			sb.WriteByte('.')
+			sb.WriteString(scoped)
+			i = end - 1
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// classesFileContent renders the Go file declaring the map of the scoped class names.
+func classesFileContent(pkg, varName, source string, classes map[string]string) ([]byte, error) {
+	names := make([]string, 0, len(classes))
+	for class := range classes {
+		names = append(names, class)
+	}
+	slices.Sort(names)
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by tempo. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&sb, "package %s\n\n", pkg)
+	fmt.Fprintf(&sb, "// %s maps the class names of %s to their scoped names.\n", varName, source)
+	fmt.Fprintf(&sb, "var %s = map[string]string{\n", varName)
+	for _, class := range names {
+		fmt.Fprintf(&sb, "%s: %s,\n", strconv.Quote(class), strconv.Quote(classes[class]))
+	}
+	sb.WriteString("}\n")
+
+	formatted, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return nil, apperrors.Wrap("failed to format scoped classes file", err)
+	}
+	return formatted, nil
+}
+
+// atRuleName returns the lowercased name of the at-rule starting the prelude.
+func atRuleName(prelude string) (string, bool) {
+	prelude = strings.TrimSpace(prelude)
+	if !strings.HasPrefix(prelude, "@") {
+		return "", false
+	}
+	name := prelude[1:identEnd(prelude, 1)]
+	return strings.ToLower(name), true
+}
+
+// skipString returns the index following the quoted string starting at start.
+func skipString(content string, start int) int {
+	quote := content[start]
+	for i := start + 1; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(content)
+}
+
+// isIdentStart reports whether s starts with a CSS identifier.
+func isIdentStart(s string) bool {
+	if s[0] == '-' {
+		s = s[1:]
+		if s == "" {
+			return false
+		}
+	}
+	c := s[0]
+	return c == '_' || c == '\\' || c >= 0x80 || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// identEnd returns the index following the CSS identifier starting at start.
+func identEnd(s string, start int) int {
+	i := start
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i += 2
+		case c == '-' || c == '_' || c >= 0x80 || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9'):
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// cssIdent replaces the characters of name not allowed in a CSS identifier with "-".
+func cssIdent(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '-'
+	}, name)
+}
+
+// goIdent converts a file name to an exported Go identifier (e.g. "card-base" -> "CardBase").
+func goIdent(name string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if sb.Len() == 0 && unicode.IsDigit(r) {
+			sb.WriteRune('X')
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	if sb.Len() == 0 {
+		return "Styles"
+	}
+	return sb.String()
+}
@@ -0,0 +1,133 @@
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCSSScoper_Scope(t *testing.T) {
+	scoper, err := NewCSSScoper(ScopePrefix, "assets")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	filePath := filepath.Join("assets", "button", "css", "base.css")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		classes  map[string]string
+	}{
+		{
+			name:     "class selectors",
+			input:    ".btn, .btn-primary:hover > .icon { color: red; }",
+			expected: ".button__btn, .button__btn-primary:hover > .button__icon { color: red; }",
+			classes:  map[string]string{"btn": "button__btn", "btn-primary": "button__btn-primary", "icon": "button__icon"},
+		},
+		{
+			name:     "declarations untouched",
+			input:    ".a { margin: .5em; background: url(img/bg.png); }",
+			expected: ".button__a { margin: .5em; background: url(img/bg.png); }",
+			classes:  map[string]string{"a": "button__a"},
+		},
+		{
+			name:     "comments strings and attributes untouched",
+			input:    "/* .note */ a[href$=\".pdf\"]::after { content: \".x\"; }",
+			expected: "/* .note */ a[href$=\".pdf\"]::after { content: \".x\"; }",
+			classes:  map[string]string{},
+		},
+		{
+			name:     "media and nesting",
+			input:    "@media (min-width: 40em) { .card { &.active { color: red; } } }",
+			expected: "@media (min-width: 40em) { .button__card { &.button__active { color: red; } } }",
+			classes:  map[string]string{"card": "button__card", "active": "button__active"},
+		},
+		{
+			name:     "keyframes and font-face untouched",
+			input:    "@keyframes spin { 12.5% { opacity: .5; } } @font-face { src: url(a.woff2); }",
+			expected: "@keyframes spin { 12.5% { opacity: .5; } } @font-face { src: url(a.woff2); }",
+			classes:  map[string]string{},
+		},
+		{
+			name:     "pseudo classes with selectors",
+			input:    ":is(.a, .b) :not(.c) {}",
+			expected: ":is(.button__a, .button__b) :not(.button__c) {}",
+			classes:  map[string]string{"a": "button__a", "b": "button__b", "c": "button__c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, classes := scoper.Scope(filePath, tt.input)
+			if got != tt.expected {
+				t.Errorf("Unexpected output:\ngot:  %s\nwant: %s", got, tt.expected)
+			}
+			if !reflect.DeepEqual(classes, tt.classes) {
+				t.Errorf("Unexpected classes: got %v, want %v", classes, tt.classes)
+			}
+		})
+	}
+}
+
+func TestCSSScoper_ScopedName(t *testing.T) {
+	hash, err := NewCSSScoper("", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	name := hash.ScopedName("button", "btn")
+	if !strings.HasPrefix(name, "btn_") || len(name) != len("btn_")+6 {
+		t.Errorf("Unexpected hashed name %q", name)
+	}
+	if name != hash.ScopedName("button", "btn") || name == hash.ScopedName("card", "btn") {
+		t.Errorf("Hashed names must be stable and differ between components")
+	}
+
+	prefix := &CSSScoper{Style: ScopePrefix}
+	if got := prefix.ScopedName("my button", "btn"); got != "my-button__btn" {
+		t.Errorf("Unexpected prefixed name %q", got)
+	}
+}
+
+func TestNewCSSScoper_InvalidStyle(t *testing.T) {
+	if _, err := NewCSSScoper("bem", ""); err == nil {
+		t.Errorf("Expected an error for an invalid style")
+	}
+}
+
+func TestCSSScoper_WriteClassesFile(t *testing.T) {
+	tempDir := t.TempDir()
+	templPath := filepath.Join(tempDir, "button", "card-base.templ")
+	if err := os.MkdirAll(filepath.Dir(templPath), 0755); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if err := os.WriteFile(templPath, []byte("package ui\n\ntempl CardBase() {}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write templ file: %v", err)
+	}
+
+	scoper := &CSSScoper{Style: ScopePrefix}
+	classes := map[string]string{"title": "button__title", "btn": "button__btn"}
+	if err := scoper.WriteClassesFile("card-base.css", templPath, classes, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "button", "card-base"+ClassesFileSuffix))
+	if err != nil {
+		t.Fatalf("Failed to read classes file: %v", err)
+	}
+	expected := `// Code generated by tempo. DO NOT EDIT.
+
+package ui
+
+// CardBaseClasses maps the class names of card-base.css to their scoped names.
+var CardBaseClasses = map[string]string{
+	"btn":   "button__btn",
+	"title": "button__title",
+}
+`
+	if string(content) != expected {
+		t.Errorf("Unexpected classes file:\n%s", content)
+	}
+}
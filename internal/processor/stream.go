@@ -0,0 +1,122 @@
+package processor
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	apperrors "github.com/indaco/tempo/internal/apperrors"
+)
+
+// streamBufferSize is the size of the read buffer used to scan the output files.
+const streamBufferSize = 64 * 1024
+
+// injection writes the content injected between the guard markers.
+type injection func(w io.Writer) error
+
+// injectStream copies r to w, replacing the content of every guarded region, between
+// the guard markers, with the one written by inject. It only holds a few bytes of r in
+// memory: a guarded region is skipped, not buffered, and inject is only called once
+// both its markers have been found. The text outside of the regions, the line ending
+// after the BEGIN marker and the indentation of the END marker are preserved. It
+// returns false, without calling inject, when r has no guard markers (see markerScanner
+// for how they are recognized). With a checksum, the old content is checked against its
+// recorded checksum and the checksum of the new content is recorded before the END
+// marker. outputName identifies r in errors.
+func injectStream(r io.Reader, w io.Writer, markerName, watermark string, inject injection, checksum *GuardChecksum, outputName string) (bool, error) {
+	scanner := newMarkerScanner(r, markerName)
+	bw := bufio.NewWriterSize(w, streamBufferSize)
+
+	injected := false
+	for {
+		// Step 1: Copy the content up to the next start marker
+		token, err := scanner.Next(bw)
+		if err != nil {
+			return false, apperrors.Wrap("failed to read output file", err, outputName)
+		}
+		if token == tokenNone {
+			break
+		}
+		if token == tokenEnd {
+			return false, invalidGuardMarkersError(outputName) // End marker first
+		}
+
+		// Step 2: Skip the old content up to the end marker, checking it was not edited
+		old := newOldRegion(markerName, checksum != nil)
+		token, err = scanner.Next(old)
+		if err != nil {
+			return false, apperrors.Wrap("failed to read output file", err, outputName)
+		}
+		if token != tokenEnd {
+			return false, invalidGuardMarkersError(outputName)
+		}
+		if err := checkManualEdits(old, checksum, outputName); err != nil {
+			return false, err
+		}
+
+		// Step 3: Write the markers around the new content
+		if err := writeRegion(bw, old, markerName, watermark, inject, checksum, outputName); err != nil {
+			return false, err
+		}
+		injected = true
+	}
+
+	if !injected {
+		return false, nil // No markers
+	}
+	if err := bw.Flush(); err != nil {
+		return false, apperrors.Wrap("failed to write output content", err, outputName)
+	}
+	return true, nil
+}
+
+// checkManualEdits applies the policy of checksum to the old region when it was edited
+// by hand since the last sync.
+func checkManualEdits(old *oldRegion, checksum *GuardChecksum, outputName string) error {
+	if checksum == nil || checksum.Overwrite || !old.Edited() {
+		return nil
+	}
+	if checksum.Policy == ManualEditsRefuse {
+		return apperrors.WrapCode(apperrors.CodeManualEdits,
+			"the guard region of %s was edited by hand since the last sync. Move the changes to the source asset, or re-run with '--overwrite-manual'", outputName)
+	}
+	if checksum.OnEdit != nil {
+		checksum.OnEdit(outputName)
+	}
+	return nil
+}
+
+// writeRegion writes the guard markers around the new content, with the layout of the
+// old region. Injection errors are returned as is.
+func writeRegion(w io.Writer, old *oldRegion, markerName, watermark string, inject injection, checksum *GuardChecksum, outputName string) error {
+	startMarker, endMarker := GuardMarkers(markerName)
+	eol := old.EOL()
+	header := old.Lead()
+	if watermark != "" {
+		header += watermark + eol
+	}
+	if _, err := io.WriteString(w, startMarker+header); err != nil {
+		return apperrors.Wrap("failed to write output content", err, outputName)
+	}
+
+	// The checksum covers the region up to its checksum line
+	content := w
+	sum := sha256.New()
+	if checksum != nil {
+		sum.Write([]byte(header))
+		content = io.MultiWriter(w, sum)
+	}
+	if err := inject(content); err != nil {
+		return err
+	}
+	if checksum != nil {
+		if _, err := io.WriteString(w, eol+ChecksumLine(markerName, hex.EncodeToString(sum.Sum(nil)))); err != nil {
+			return apperrors.Wrap("failed to write output content", err, outputName)
+		}
+	}
+	if _, err := io.WriteString(w, eol+old.Indent()+endMarker); err != nil {
+		return apperrors.Wrap("failed to write output content", err, outputName)
+	}
+	return nil
+}
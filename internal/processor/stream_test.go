@@ -0,0 +1,160 @@
+package processor
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+func TestInjectStream(t *testing.T) {
+	startMarker, endMarker := GuardMarkers("tempo")
+	longLine := strings.Repeat("a{color:red}", streamBufferSize/4)
+
+	tests := []struct {
+		name      string
+		output    string
+		watermark string
+		want      string
+		injected  bool
+		wantErr   bool
+	}{
+		{
+			name:     "Replaces the guarded content",
+			output:   "<style>\n" + startMarker + "\nold\n" + endMarker + "\n</style>\n",
+			want:     "<style>\n" + startMarker + "\nnew\n" + endMarker + "\n</style>\n",
+			injected: true,
+		},
+		{
+			name:      "Writes the watermark",
+			output:    startMarker + endMarker,
+			watermark: "/* generated */",
+			want:      startMarker + "\n/* generated */\nnew\n" + endMarker,
+			injected:  true,
+		},
+		{
+			name:     "Long lines around and inside the markers",
+			output:   longLine + startMarker + longLine + "\n" + longLine + endMarker + longLine,
+			want:     longLine + startMarker + "\nnew\n" + endMarker + longLine,
+			injected: true,
+		},
+		{
+			name:     "Replaces every guarded region",
+			output:   startMarker + "\nold\n" + endMarker + "\n<hr>\n" + startMarker + endMarker + "\n",
+			want:     startMarker + "\nnew\n" + endMarker + "\n<hr>\n" + startMarker + "\nnew\n" + endMarker + "\n",
+			injected: true,
+		},
+		{
+			name:     "Preserves the line endings and the indentation",
+			output:   "\uFEFF<style>\r\n\t" + startMarker + "  \r\n\told\r\n\t" + endMarker + "\r\n</style>",
+			want:     "\uFEFF<style>\r\n\t" + startMarker + "  \r\nnew\r\n\t" + endMarker + "\r\n</style>",
+			injected: true,
+		},
+		{
+			name:     "Ignores the markers in strings and comments",
+			output:   `const s = "` + endMarker + `";` + "\n/* " + startMarker + " */\n" + startMarker + "\n'" + endMarker + "'\n" + endMarker,
+			want:     `const s = "` + endMarker + `";` + "\n/* " + startMarker + " */\n" + startMarker + "\nnew\n" + endMarker,
+			injected: true,
+		},
+		{
+			name:   "No markers",
+			output: "templ Button() {}\n",
+		},
+		{
+			name:   "Markers only in strings",
+			output: "s := \"" + startMarker + endMarker + "\"\n",
+		},
+		{
+			name:    "Missing end marker",
+			output:  startMarker + "\nold\n",
+			wantErr: true,
+		},
+		{
+			name:    "End marker before start marker",
+			output:  endMarker + "\n" + startMarker + "\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			inject := func(w io.Writer) error {
+				called = true
+				_, err := io.WriteString(w, "new")
+				return err
+			}
+
+			var out bytes.Buffer
+			injected, err := injectStream(strings.NewReader(tt.output), &out, "tempo", tt.watermark, inject, nil, "output.templ")
+			if tt.wantErr {
+				if apperrors.CodeOf(err) != apperrors.CodeMissingMarkers {
+					t.Fatalf("Expected %s error, got %v", apperrors.CodeMissingMarkers, err)
+				}
+				if called {
+					t.Errorf("Expected no injection with invalid markers")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if injected != tt.injected || called != tt.injected {
+				t.Fatalf("Expected injected=%v, got injected=%v called=%v", tt.injected, injected, called)
+			}
+			if injected && out.String() != tt.want {
+				t.Errorf("Unexpected output:\ngot:  %.200q\nwant: %.200q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestInjectStream_InjectionError(t *testing.T) {
+	startMarker, endMarker := GuardMarkers("tempo")
+	inject := func(io.Writer) error { return errors.New("transform failed") }
+
+	_, err := injectStream(strings.NewReader(startMarker+endMarker), io.Discard, "tempo", "", inject, nil, "output.templ")
+	if err == nil || !strings.Contains(err.Error(), "transform failed") {
+		t.Errorf("Expected the injection error, got %v", err)
+	}
+}
+
+func FuzzInjectStream(f *testing.F) {
+	startMarker, endMarker := GuardMarkers("tempo")
+	for _, seed := range []string{
+		"<style>\n" + startMarker + "\nold\n" + endMarker + "\n</style>\n",
+		"\uFEFF" + startMarker + "\r\n  " + endMarker + startMarker + endMarker,
+		`"` + startMarker + `"` + startMarker + "'" + endMarker + "\n" + endMarker,
+		"/* " + startMarker + " */" + endMarker,
+		startMarker + "\n" + ChecksumLine("tempo", strings.Repeat("0", 64)) + "\n" + endMarker,
+	} {
+		f.Add(seed)
+	}
+
+	inject := func(w io.Writer) error {
+		_, err := io.WriteString(w, ".btn{color:red}")
+		return err
+	}
+	checksum := &GuardChecksum{Policy: ManualEditsWarn}
+
+	f.Fuzz(func(t *testing.T, output string) {
+		var first bytes.Buffer
+		injected, err := injectStream(strings.NewReader(output), &first, "tempo", "/* generated */", inject, checksum, "output.templ")
+		if err != nil || !injected {
+			return
+		}
+
+		// Syncing again the synced content changes nothing
+		var second bytes.Buffer
+		injected, err = injectStream(bytes.NewReader(first.Bytes()), &second, "tempo", "/* generated */", inject, checksum, "output.templ")
+		if err != nil || !injected {
+			t.Fatalf("Expected the synced content to be injected again, got injected=%v err=%v", injected, err)
+		}
+		if second.String() != first.String() {
+			t.Errorf("Expected a stable sync:\nfirst:  %q\nsecond: %q", first.String(), second.String())
+		}
+	})
+}
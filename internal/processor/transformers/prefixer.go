@@ -0,0 +1,85 @@
+package transformers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+)
+
+// browserVersionRe matches the versions accepted in browser targets (e.g. 13, 13.1).
+var browserVersionRe = regexp.MustCompile(`^\d+(\.\d+){0,2}$`)
+
+// browserEngines maps browserslist browser names to esbuild engines.
+var browserEngines = map[string]api.EngineName{
+	"chrome":   api.EngineChrome,
+	"and_chr":  api.EngineChrome,
+	"edge":     api.EngineEdge,
+	"firefox":  api.EngineFirefox,
+	"ff":       api.EngineFirefox,
+	"and_ff":   api.EngineFirefox,
+	"ie":       api.EngineIE,
+	"explorer": api.EngineIE,
+	"ios":      api.EngineIOS,
+	"ios_saf":  api.EngineIOS,
+	"opera":    api.EngineOpera,
+	"safari":   api.EngineSafari,
+}
+
+// PrefixTransformer adds the vendor prefixes required by the target browsers to CSS.
+// The content is reprinted by esbuild but not minified.
+type PrefixTransformer struct {
+	Engines []api.Engine
+}
+
+// NewPrefixTransformer creates a PrefixTransformer from browserslist-style targets
+// such as "safari 13", "ios_saf >= 12" or "firefox 78".
+func NewPrefixTransformer(browsers []string) (*PrefixTransformer, error) {
+	if len(browsers) == 0 {
+		return nil, fmt.Errorf("at least one target browser is required")
+	}
+
+	engines := make([]api.Engine, 0, len(browsers))
+	for _, browser := range browsers {
+		engine, err := parseBrowserTarget(browser)
+		if err != nil {
+			return nil, err
+		}
+		engines = append(engines, engine)
+	}
+	return &PrefixTransformer{Engines: engines}, nil
+}
+
+func (p *PrefixTransformer) Transform(input string) (string, error) {
+	result := api.Transform(input, api.TransformOptions{
+		Loader:  api.LoaderCSS,
+		Engines: p.Engines,
+	})
+
+	if len(result.Errors) > 0 {
+		return "", fmt.Errorf("esbuild vendor prefixing error: %v", result.Errors)
+	}
+
+	return strings.TrimRight(string(result.Code), "\n"), nil
+}
+
+// parseBrowserTarget parses a "<browser> [>=] <version>" target.
+func parseBrowserTarget(target string) (api.Engine, error) {
+	fields := strings.Fields(strings.ToLower(target))
+	if len(fields) == 3 && fields[1] == ">=" {
+		fields = []string{fields[0], fields[2]}
+	}
+	if len(fields) != 2 {
+		return api.Engine{}, fmt.Errorf("unsupported browser target %q, expected '<browser> <version>'", target)
+	}
+
+	name, ok := browserEngines[fields[0]]
+	if !ok {
+		return api.Engine{}, fmt.Errorf("unsupported browser %q in target %q", fields[0], target)
+	}
+	if !browserVersionRe.MatchString(fields[1]) {
+		return api.Engine{}, fmt.Errorf("invalid version %q in target %q", fields[1], target)
+	}
+	return api.Engine{Name: name, Version: fields[1]}, nil
+}
@@ -0,0 +1,93 @@
+package transformers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestNewPrefixTransformer(t *testing.T) {
+	transformer, err := NewPrefixTransformer([]string{"Safari 13.1", "ios_saf >= 12", "firefox 78"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []api.Engine{
+		{Name: api.EngineSafari, Version: "13.1"},
+		{Name: api.EngineIOS, Version: "12"},
+		{Name: api.EngineFirefox, Version: "78"},
+	}
+	if len(transformer.Engines) != len(expected) {
+		t.Fatalf("Expected %d engines, got %+v", len(expected), transformer.Engines)
+	}
+	for i, engine := range expected {
+		if transformer.Engines[i] != engine {
+			t.Errorf("Expected engine %+v, got %+v", engine, transformer.Engines[i])
+		}
+	}
+}
+
+func TestNewPrefixTransformer_Errors(t *testing.T) {
+	tests := map[string][]string{
+		"no targets":        nil,
+		"unknown browser":   {"netscape 4"},
+		"unsupported query": {"> 1%"},
+		"missing version":   {"safari"},
+		"invalid version":   {"safari tp"},
+	}
+
+	for name, browsers := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewPrefixTransformer(browsers); err == nil {
+				t.Errorf("Expected an error for %v", browsers)
+			}
+		})
+	}
+}
+
+func TestPrefixTransformer_Transform(t *testing.T) {
+	transformer, err := NewPrefixTransformer([]string{"safari 12"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output, err := transformer.Transform(".card { backdrop-filter: blur(4px); user-select: none; }")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{"-webkit-backdrop-filter: blur(4px)", "-webkit-user-select: none", "backdrop-filter: blur(4px)"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+	if strings.HasSuffix(output, "\n") {
+		t.Errorf("Expected no trailing newline, got %q", output)
+	}
+}
+
+func TestPrefixTransformer_ModernTargetsKeepContent(t *testing.T) {
+	transformer, err := NewPrefixTransformer([]string{"chrome 120"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output, err := transformer.Transform(".button { user-select: none; }")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(output, "-webkit-") {
+		t.Errorf("Expected no vendor prefix for modern targets, got:\n%s", output)
+	}
+}
+
+func TestPrefixTransformer_InvalidCSS(t *testing.T) {
+	transformer := &PrefixTransformer{Engines: []api.Engine{{Name: api.EngineSafari, Version: "12"}}}
+
+	_, err := transformer.Transform(".button { color: red; ")
+	if err != nil && !testutils.Contains(err.Error(), "esbuild vendor prefixing error") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
@@ -10,4 +10,5 @@ type TransformationConfig struct {
 	RawData    string
 	Transform  func(string) (string, error)
 	MarkerName string
+	Watermark  string // Comment written before the transformed content (optional)
 }
@@ -2,7 +2,7 @@ package processor
 
 import (
 	"bytes"
-	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -13,49 +13,71 @@ import (
 
 // processWithTransformation applies a transformation function to the input content
 // and inserts the transformed content between configurable guard markers in the output file.
-func processWithTransformation(cfg transformers.TransformationConfig, outputFilePath string) error {
+// The output file is written with the given file mode policy (nil for the defaults), and
+// the checksum of the guard region is checked and recorded unless checksum is nil.
+func processWithTransformation(cfg transformers.TransformationConfig, outputFilePath string, fileModes *utils.FileModePolicy, checksum *GuardChecksum) error {
+	return processWithInjection(outputFilePath, cfg.MarkerName, cfg.Watermark, transformInjection(cfg), fileModes, checksum)
+}
 
-	// Step 1: Read the output file content
-	outputContent, err := os.ReadFile(outputFilePath)
+// processWithInjection streams the output file, replacing the content between the guard
+// markers with the one written by inject, so that large files are never held in memory.
+// The output file is left untouched when it has no guard markers.
+func processWithInjection(outputFilePath, markerName, watermark string, inject injection, fileModes *utils.FileModePolicy, checksum *GuardChecksum) error {
+	// Step 1: Stream the output file to a temporary file, injecting the new content
+	output, err := os.Open(outputFilePath)
 	if err != nil {
 		return apperrors.Wrap("failed to read output file", err)
 	}
+	defer output.Close()
 
-	// Step 2: Validate Guard Markers
-	startMarker := fmt.Sprintf("/* [%s] BEGIN - Do not edit! This section is auto-generated. */", cfg.MarkerName)
-	endMarker := fmt.Sprintf("/* [%s] END */", cfg.MarkerName)
-
-	startIndex := bytes.Index(outputContent, []byte(startMarker))
-	endIndex := bytes.Index(outputContent, []byte(endMarker))
-
-	if err := validateGuardMarkers(startIndex, endIndex, outputFilePath); err != nil {
-		return err
+	tmp, err := os.CreateTemp("", "tempo-inject-*")
+	if err != nil {
+		return apperrors.Wrap("failed to create temporary file", err)
 	}
-	if startIndex == -1 && endIndex == -1 {
-		return nil // No processing required if markers are absent
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	injected, err := injectStream(output, tmp, markerName, watermark, inject, checksum, outputFilePath)
+	if err != nil || !injected {
+		return err // No processing required if markers are absent
 	}
 
-	// Step 3: Apply transformation
-	transformedContent, err := cfg.Transform(cfg.RawData)
-	if err != nil {
-		return apperrors.Wrap("failed to transform content", err)
+	// Step 2: Write the updated content back to the output file
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return apperrors.Wrap("failed to read temporary file", err)
+	}
+	if err := fileModes.WriteFileFrom(outputFilePath, tmp, 0); err != nil {
+		return apperrors.Wrap("failed to write updated content to output file", err)
 	}
 
-	// Step 4: Construct new content (removing any old content between markers)
-	beforeMarker := strings.TrimRight(string(outputContent[:startIndex+len(startMarker)]), " \n") + "\n"
-	afterMarker := strings.TrimLeft(string(outputContent[endIndex:]), " \n")
+	return nil
+}
 
+// injectTransformed returns outputContent with the transformed content of cfg between
+// the guard markers, replacing any old content. It returns false, without applying the
+// transformation, when outputContent has no guard markers. outputName identifies the
+// content in errors.
+func injectTransformed(cfg transformers.TransformationConfig, outputContent []byte, checksum *GuardChecksum, outputName string) (string, bool, error) {
 	var updatedContent strings.Builder
-	updatedContent.WriteString(beforeMarker)
-	updatedContent.WriteString(transformedContent + "\n")
-	updatedContent.WriteString(afterMarker)
-
-	// Step 5: Write the updated content back to the output file
-	if err := utils.WriteStringToFile(outputFilePath, updatedContent.String()); err != nil {
-		return apperrors.Wrap("failed to write updated content to output file", err)
+	injected, err := injectStream(bytes.NewReader(outputContent), &updatedContent, cfg.MarkerName, cfg.Watermark, transformInjection(cfg), checksum, outputName)
+	if err != nil || !injected {
+		return "", false, err
 	}
+	return updatedContent.String(), true, nil
+}
 
-	return nil
+// transformInjection returns the injection writing the transformed content of cfg.
+func transformInjection(cfg transformers.TransformationConfig) injection {
+	return func(w io.Writer) error {
+		transformedContent, err := cfg.Transform(cfg.RawData)
+		if err != nil {
+			return apperrors.Wrap("failed to transform content", err)
+		}
+		_, err = io.WriteString(w, transformedContent)
+		return err
+	}
 }
 
 // validateGuardMarkers ensures the markers exist and are properly ordered
@@ -64,7 +86,12 @@ func validateGuardMarkers(startIndex, endIndex int, outputFilePath string) error
 	case startIndex == -1 && endIndex == -1:
 		return nil // No markers, no error
 	case startIndex == -1 || endIndex == -1 || startIndex > endIndex:
-		return fmt.Errorf("invalid or missing guard markers in %s", outputFilePath)
+		return invalidGuardMarkersError(outputFilePath)
 	}
 	return nil
 }
+
+// invalidGuardMarkersError reports missing or misordered guard markers in outputFilePath.
+func invalidGuardMarkersError(outputFilePath string) error {
+	return apperrors.WrapCode(apperrors.CodeMissingMarkers, "invalid or missing guard markers in %s", outputFilePath)
+}
@@ -35,7 +35,7 @@ func Button() {
 	}
 
 	// Execute transformation
-	err := processWithTransformation(cfg, outputFilePath)
+	err := processWithTransformation(cfg, outputFilePath, nil, nil)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -61,6 +61,44 @@ minified-content
 	}
 }
 
+func TestProcessWithTransformation_Watermark(t *testing.T) {
+	tempDir := t.TempDir()
+	outputFilePath := filepath.Join(tempDir, "output.templ")
+	testutils.CreateFile(t, outputFilePath, `<style>
+/* [tempo] BEGIN - Do not edit! This section is auto-generated. */
+/* [tempo] END */
+</style>`)
+
+	cfg := transformers.TransformationConfig{
+		RawData:    ".button{}",
+		Transform:  func(input string) (string, error) { return input, nil },
+		MarkerName: "tempo",
+		Watermark:  "/* Generated by tempo */",
+	}
+
+	// Run twice: the watermark is replaced with the rest of the guarded content
+	for range 2 {
+		if err := processWithTransformation(cfg, outputFilePath, nil, nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	resultContent, err := os.ReadFile(outputFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	expectedContent := `<style>
+/* [tempo] BEGIN - Do not edit! This section is auto-generated. */
+/* Generated by tempo */
+.button{}
+/* [tempo] END */
+</style>`
+	if string(resultContent) != expectedContent {
+		t.Errorf("Expected output:\n%s\nGot:\n%s", expectedContent, string(resultContent))
+	}
+}
+
 func TestProcessWithTransformation_MissingGuardMarkers(t *testing.T) {
 	tempDir := t.TempDir()
 	outputFilePath := filepath.Join(tempDir, "output.templ")
@@ -86,7 +124,7 @@ console.log("Hello world");
 	}
 
 	// Execute transformation (should fail)
-	err := processWithTransformation(cfg, outputFilePath)
+	err := processWithTransformation(cfg, outputFilePath, nil, nil)
 	if err != nil {
 		t.Fatal("Expected error due to missing guard markers, but got none")
 	}
@@ -118,7 +156,7 @@ func Button() {
 	}
 
 	// Execute transformation (should fail)
-	err := processWithTransformation(cfg, outputFilePath)
+	err := processWithTransformation(cfg, outputFilePath, nil, nil)
 	if err == nil {
 		t.Fatal("Expected error due to transformation failure, but got none")
 	}
@@ -145,7 +183,7 @@ func TestProcessWithTransformation_ReadFailure(t *testing.T) {
 	}
 
 	// Execute transformation (should fail due to missing file)
-	err := processWithTransformation(cfg, outputFilePath)
+	err := processWithTransformation(cfg, outputFilePath, nil, nil)
 	if err == nil {
 		t.Fatal("Expected error due to missing output file, but got none")
 	}
@@ -0,0 +1,96 @@
+// Package progress streams the progress of sync and batch generation runs as
+// machine-readable events, one JSON object per line, so that tools such as IDE plugins
+// can show a live progress without parsing the final summary.
+package progress
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+// Formats of the --progress-format flag.
+const (
+	FormatNone   = "none"
+	FormatNDJSON = "ndjson"
+)
+
+// Formats lists the valid formats of the --progress-format flag.
+var Formats = []string{FormatNone, FormatNDJSON}
+
+// Event is the kind of a progress event.
+type Event string
+
+const (
+	EventQueued  Event = "queued"  // The file is waiting for a worker
+	EventStarted Event = "started" // The file is being processed
+	EventDone    Event = "done"    // The file has been processed
+	EventSkipped Event = "skipped" // The file has been left out, see Reason
+	EventError   Event = "error"   // The file could not be processed, see Error
+)
+
+// Record is a progress event, written as one line of JSON.
+type Record struct {
+	Time      time.Time `json:"time"`
+	Event     Event     `json:"event"`
+	File      string    `json:"file,omitempty"`      // Source file, e.g. an asset file
+	Dest      string    `json:"dest,omitempty"`      // Output file or folder (if known)
+	Component string    `json:"component,omitempty"` // Component of a batch generation
+	Reason    string    `json:"reason,omitempty"`    // Why the file was skipped
+	Error     string    `json:"error,omitempty"`
+}
+
+// Reporter writes the progress events to a writer, or passes them to a function. A nil
+// Reporter discards them, so that callers do not check whether the progress is enabled.
+// It is safe for concurrent use.
+type Reporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	fn  func(Record)
+	now func() time.Time
+}
+
+// New returns a Reporter writing the events to w in the given format, or nil for
+// FormatNone and an empty format.
+func New(format string, w io.Writer) (*Reporter, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", FormatNone:
+		return nil, nil
+	case FormatNDJSON:
+		return &Reporter{enc: json.NewEncoder(w), now: time.Now}, nil
+	default:
+		return nil, apperrors.Wrap("invalid progress format '%s'; valid formats are: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// NewFunc returns a Reporter passing the events to fn, e.g. to render them in a host
+// application, or nil when fn is nil. fn is never called concurrently.
+func NewFunc(fn func(Record)) *Reporter {
+	if fn == nil {
+		return nil
+	}
+	return &Reporter{fn: fn, now: time.Now}
+}
+
+// Emit writes the event rec, timestamped when its time is not set. Write errors are
+// ignored: the progress must never fail the run.
+func (r *Reporter) Emit(rec Record) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rec.Time.IsZero() {
+		rec.Time = r.now().UTC()
+	}
+	if r.fn != nil {
+		r.fn(rec)
+		return
+	}
+	_ = r.enc.Encode(rec)
+}
@@ -0,0 +1,74 @@
+package progress
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		format    string
+		expectNil bool
+		expectErr bool
+	}{
+		{format: "", expectNil: true},
+		{format: FormatNone, expectNil: true},
+		{format: FormatNDJSON},
+		{format: " NDJSON "},
+		{format: "xml", expectNil: true, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			r, err := New(tt.format, &bytes.Buffer{})
+			if tt.expectErr != (err != nil) {
+				t.Fatalf("New(%q) error = %v, expectErr %v", tt.format, err, tt.expectErr)
+			}
+			if tt.expectNil != (r == nil) {
+				t.Errorf("New(%q) = %v, expectNil %v", tt.format, r, tt.expectNil)
+			}
+		})
+	}
+}
+
+func TestReporter_Emit(t *testing.T) {
+	var buf bytes.Buffer
+	r, err := New(FormatNDJSON, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	r.Emit(Record{Event: EventQueued, File: "assets/button/base.css", Dest: "components/button/base.templ"})
+	r.Emit(Record{Event: EventError, File: "assets/card/base.css", Error: "missing marker"})
+
+	want := `{"time":"2026-01-02T03:04:05Z","event":"queued","file":"assets/button/base.css","dest":"components/button/base.templ"}
+{"time":"2026-01-02T03:04:05Z","event":"error","file":"assets/card/base.css","error":"missing marker"}
+`
+	if buf.String() != want {
+		t.Errorf("Expected the events:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestReporter_EmitNil(t *testing.T) {
+	var r *Reporter
+	r.Emit(Record{Event: EventDone}) // Must not panic
+}
+
+func TestNewFunc(t *testing.T) {
+	if NewFunc(nil) != nil {
+		t.Error("Expected a nil Reporter for a nil function")
+	}
+
+	var got []Record
+	r := NewFunc(func(rec Record) { got = append(got, rec) })
+	r.Emit(Record{Event: EventDone, File: "assets/button/base.css"})
+
+	if len(got) != 1 || got[0].Event != EventDone || got[0].File != "assets/button/base.css" {
+		t.Fatalf("Expected the event to be passed to the function, got %+v", got)
+	}
+	if got[0].Time.IsZero() {
+		t.Error("Expected the event to be timestamped")
+	}
+}
@@ -0,0 +1,89 @@
+// Package rendercache stores rendered templates on disk, keyed by the template content,
+// the engine, a hash of the data, the tempo version and the function providers, so that
+// repeated runs skip rendering them again.
+package rendercache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/version"
+)
+
+// DirName is the name of the folder, in the tempo root folder, holding the cache.
+const DirName = "render-cache"
+
+// Cache is a folder of rendered templates, one file per key. A nil Cache stores nothing.
+type Cache struct {
+	Dir   string
+	scope []byte // Version and function providers the renders depend on besides the data
+}
+
+// Path returns the path of the cache folder in the tempo root folder.
+func Path(tempoRoot string) string {
+	return filepath.Join(tempoRoot, DirName)
+}
+
+// Open returns the cache of the tempo root folder, or nil when it is not enabled. The
+// renders are cached for the running tempo version and the given function providers,
+// whose template functions change them.
+func Open(tempoRoot string, enabled bool, providers []config.TemplateFuncProvider) *Cache {
+	if !enabled {
+		return nil
+	}
+	scope, _ := json.Marshal(struct {
+		Version   string                        `json:"version"`
+		Providers []config.TemplateFuncProvider `json:"providers"`
+	}{version.GetVersion(), providers})
+	return &Cache{Dir: Path(tempoRoot), scope: scope}
+}
+
+// Key returns the key of content rendered by engine with data. It reports false when
+// the cache is nil or data cannot be encoded as JSON: such renders are not cached.
+func (c *Cache) Key(engine, content string, data any) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", false
+	}
+
+	h := sha256.New()
+	for _, part := range [][]byte{c.scope, []byte(engine), []byte(content), encoded} {
+		fmt.Fprintf(h, "%d:", len(part))
+		h.Write(part)
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// Get returns the rendered template stored under key, reporting false when missing.
+func (c *Cache) Get(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	data, err := os.ReadFile(filepath.Join(c.Dir, key))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// Put stores the rendered template under key.
+func (c *Cache) Put(key, rendered string) error {
+	if c == nil {
+		return nil
+	}
+	path := filepath.Join(c.Dir, key)
+	if err := utils.WriteStringToFile(path, rendered); err != nil {
+		return apperrors.Wrap("failed to write render cache entry", err, path)
+	}
+	return nil
+}
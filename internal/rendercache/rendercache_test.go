@@ -0,0 +1,86 @@
+package rendercache
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/config"
+)
+
+func TestKey(t *testing.T) {
+	c := Open(t.TempDir(), true, nil)
+	data := map[string]string{"Name": "button"}
+	key, ok := c.Key("go", "{{ .Name }}", data)
+	if !ok || key == "" {
+		t.Fatalf("Expected a key, got %q (ok: %v)", key, ok)
+	}
+
+	if again, _ := c.Key("go", "{{ .Name }}", map[string]string{"Name": "button"}); again != key {
+		t.Errorf("Expected the same key for the same input, got %q and %q", key, again)
+	}
+	for _, other := range []struct {
+		engine, content string
+		data            any
+	}{
+		{"handlebars", "{{ .Name }}", data},
+		{"go", "{{ .Name }}!", data},
+		{"go", "{{ .Name }}", map[string]string{"Name": "card"}},
+	} {
+		if changed, _ := c.Key(other.engine, other.content, other.data); changed == key {
+			t.Errorf("Expected a different key for %+v", other)
+		}
+	}
+
+	if _, ok := c.Key("go", "{{ . }}", func() {}); ok {
+		t.Error("Expected no key for data that cannot be encoded")
+	}
+	var disabled *Cache
+	if _, ok := disabled.Key("go", "{{ .Name }}", data); ok {
+		t.Error("Expected no key for a nil cache")
+	}
+}
+
+func TestKey_Scope(t *testing.T) {
+	data := map[string]string{"Name": "button"}
+	key, _ := Open(t.TempDir(), true, nil).Key("go", "{{ .Name }}", data)
+
+	providers := []config.TemplateFuncProvider{{Name: "custom", Type: "path", Value: "./providers/custom"}}
+	if changed, _ := Open(t.TempDir(), true, providers).Key("go", "{{ .Name }}", data); changed == key {
+		t.Error("Expected a different key with other function providers")
+	}
+
+	// Stands for a cache opened by another tempo version
+	other := &Cache{scope: []byte(`{"version":"0.0.0"}`)}
+	if changed, _ := other.Key("go", "{{ .Name }}", data); changed == key {
+		t.Error("Expected a different key with another tempo version")
+	}
+}
+
+func TestCache(t *testing.T) {
+	tempRoot := t.TempDir()
+
+	if c := Open(tempRoot, false, nil); c != nil {
+		t.Fatalf("Expected no cache when disabled, got %+v", c)
+	}
+	var disabled *Cache
+	if err := disabled.Put("key", "content"); err != nil {
+		t.Errorf("Expected a nil cache to ignore Put, got: %v", err)
+	}
+	if _, ok := disabled.Get("key"); ok {
+		t.Error("Expected a nil cache to store nothing")
+	}
+
+	c := Open(tempRoot, true, nil)
+	if c.Dir != filepath.Join(tempRoot, DirName) {
+		t.Errorf("Unexpected cache folder: %s", c.Dir)
+	}
+	if _, ok := c.Get("key"); ok {
+		t.Error("Expected a miss on an empty cache")
+	}
+	if err := c.Put("key", "rendered"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if rendered, ok := c.Get("key"); !ok || rendered != "rendered" {
+		t.Errorf("Expected the stored content, got %q (ok: %v)", rendered, ok)
+	}
+}
@@ -0,0 +1,156 @@
+// Package sarif writes check results in the SARIF 2.1.0 format, so that code scanning
+// tools (e.g. GitHub code scanning) can show them as annotations at their position.
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+// Version and schema of the written SARIF logs.
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Result levels.
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+)
+
+// Log is a SARIF log holding the results of a single run.
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run is the execution of a tool and its results.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+// Tool describes the tool that produced the results.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver is the tool component that ran the checks.
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []Rule `json:"rules,omitempty"`
+}
+
+// Rule describes a check reported by the results.
+type Rule struct {
+	ID               string  `json:"id"`
+	ShortDescription Message `json:"shortDescription"`
+}
+
+// Result is a problem found by a check.
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	Level     string     `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+}
+
+// Message is the text of a result or rule.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Location is the position of a result.
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation is a region of a file.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+// ArtifactLocation is the file of a location, as a slash-separated path relative to
+// the repository root.
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Region is the position of a result in a file, starting from line 1.
+type Region struct {
+	StartLine int `json:"startLine"`
+}
+
+// New returns an empty log for the given tool.
+func New(name, version, informationURI string) *Log {
+	return &Log{
+		Schema:  Schema,
+		Version: Version,
+		Runs: []Run{{
+			Tool:    Tool{Driver: Driver{Name: name, Version: version, InformationURI: informationURI}},
+			Results: []Result{},
+		}},
+	}
+}
+
+// AddRule declares a rule, unless a rule with the same ID is already declared.
+func (l *Log) AddRule(id, description string) {
+	driver := &l.Runs[0].Tool.Driver
+	if slices.ContainsFunc(driver.Rules, func(r Rule) bool { return r.ID == id }) {
+		return
+	}
+	driver.Rules = append(driver.Rules, Rule{ID: id, ShortDescription: Message{Text: description}})
+}
+
+// AddResult adds a result at the given line of path (no region when line is not
+// positive). path is made relative to root when possible.
+func (l *Log) AddResult(ruleID, level, message, root, path string, line int) {
+	location := PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: relativeURI(root, path)}}
+	if line > 0 {
+		location.Region = &Region{StartLine: line}
+	}
+	l.Runs[0].Results = append(l.Runs[0].Results, Result{
+		RuleID:    ruleID,
+		Level:     level,
+		Message:   Message{Text: message},
+		Locations: []Location{{PhysicalLocation: location}},
+	})
+}
+
+// Results returns the results of the log.
+func (l *Log) Results() []Result {
+	return l.Runs[0].Results
+}
+
+// Write writes the log as indented JSON.
+func (l *Log) Write(w io.Writer) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return apperrors.Wrap("failed to marshal SARIF log", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// relativeURI returns path relative to root as a slash-separated path, or path itself
+// when it is outside of root.
+func relativeURI(root, path string) string {
+	if root != "" && filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	return filepath.ToSlash(path)
+}
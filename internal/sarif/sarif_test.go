@@ -0,0 +1,63 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestLog(t *testing.T) {
+	root := t.TempDir()
+
+	log := New("tempo", "1.0.0", "https://example.com")
+	log.AddRule("tempo/rule", "A rule")
+	log.AddRule("tempo/rule", "A duplicate rule")
+	log.AddResult("tempo/rule", LevelError, "In root", root, filepath.Join(root, "a", "b.json"), 3)
+	log.AddResult("tempo/rule", LevelWarning, "Relative", root, "c/d.gotxt", 0)
+
+	var buf bytes.Buffer
+	if err := log.Write(&buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	var decoded Log
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, buf.String())
+	}
+
+	if decoded.Version != Version || decoded.Schema != Schema {
+		t.Errorf("Unexpected version or schema: %q, %q", decoded.Version, decoded.Schema)
+	}
+	if rules := decoded.Runs[0].Tool.Driver.Rules; len(rules) != 1 || rules[0].ShortDescription.Text != "A rule" {
+		t.Errorf("Unexpected rules: %+v", rules)
+	}
+
+	results := decoded.Results()
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	first := results[0].Locations[0].PhysicalLocation
+	if first.ArtifactLocation.URI != "a/b.json" {
+		t.Errorf("Expected path relative to root, got %q", first.ArtifactLocation.URI)
+	}
+	if first.Region == nil || first.Region.StartLine != 3 {
+		t.Errorf("Expected start line 3, got %+v", first.Region)
+	}
+
+	second := results[1].Locations[0].PhysicalLocation
+	if second.ArtifactLocation.URI != "c/d.gotxt" || second.Region != nil {
+		t.Errorf("Unexpected location: %+v", second)
+	}
+}
+
+func TestNew_NoResults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := New("tempo", "", "").Write(&buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"results": []`)) {
+		t.Errorf("Expected an empty results array, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,107 @@
+// Package schema generates JSON Schema documents from Go structs, so that the
+// documented file formats never drift from the types parsing them.
+package schema
+
+import (
+	"reflect"
+	"strings"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES & CONSTANTS                                                         */
+/* ------------------------------------------------------------------------- */
+
+// Draft is the JSON Schema dialect of the generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document, limited to the keywords tempo generates.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"` // false, true or a *Schema
+	Enum                 []string           `json:"enum,omitempty"`
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// Generate returns the JSON Schema of t. Struct fields are named after tagName ("json"
+// or "yaml"); fields tagged "-" are skipped. The `jsonschema` tag refines a field, e.g.
+// `jsonschema:"required,enum=file|folder"`.
+func Generate(title string, t reflect.Type, tagName string) *Schema {
+	s := typeSchema(t, tagName)
+	s.Schema = Draft
+	s.Title = title
+	return s
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// typeSchema maps a Go type to its schema.
+func typeSchema(t reflect.Type, tagName string) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: typeSchema(t.Elem(), tagName)}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return &Schema{Type: "object", AdditionalProperties: true}
+		}
+		return &Schema{Type: "object", AdditionalProperties: typeSchema(t.Elem(), tagName)}
+	case reflect.Struct:
+		return structSchema(t, tagName)
+	default:
+		return &Schema{} // Any value
+	}
+}
+
+// structSchema maps the exported fields of a struct to the properties of an object.
+func structSchema(t reflect.Type, tagName string) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema), AdditionalProperties: false}
+
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get(tagName), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := typeSchema(field.Type, tagName)
+		for _, option := range strings.Split(field.Tag.Get("jsonschema"), ",") {
+			switch {
+			case option == "required":
+				s.Required = append(s.Required, name)
+			case strings.HasPrefix(option, "enum="):
+				fieldSchema.Enum = strings.Split(strings.TrimPrefix(option, "enum="), "|")
+			}
+		}
+		s.Properties[name] = fieldSchema
+	}
+
+	return s
+}
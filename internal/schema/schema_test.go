@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type testItem struct {
+	Name  string         `yaml:"name" jsonschema:"required"`
+	Kind  string         `yaml:"kind,omitempty" jsonschema:"enum=a|b"`
+	Count int            `yaml:"count,omitempty"`
+	Ratio float64        `yaml:"ratio,omitempty"`
+	Flag  *bool          `yaml:"flag,omitempty"`
+	Extra map[string]any `yaml:"extra,omitempty"`
+	Tags  map[string][]string
+	Skip  string `yaml:"-"`
+}
+
+type testRoot struct {
+	Items []testItem `yaml:"items"`
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate("test", reflect.TypeFor[testRoot](), "yaml")
+
+	if s.Schema != Draft || s.Title != "test" || s.Type != "object" {
+		t.Fatalf("Unexpected root schema: %+v", s)
+	}
+
+	items := s.Properties["items"]
+	if items == nil || items.Type != "array" || items.Items == nil {
+		t.Fatalf("Expected items to be an array, got %+v", items)
+	}
+
+	item := items.Items
+	if item.AdditionalProperties != false {
+		t.Errorf("Expected structs to reject unknown properties")
+	}
+	if !slices.Equal(item.Required, []string{"name"}) {
+		t.Errorf("Expected [name] to be required, got %v", item.Required)
+	}
+
+	types := map[string]string{
+		"name": "string", "kind": "string", "count": "integer", "ratio": "number",
+		"flag": "boolean", "extra": "object", "Tags": "object",
+	}
+	if len(item.Properties) != len(types) {
+		t.Errorf("Expected %d properties, got %v", len(types), item.Properties)
+	}
+	for name, typ := range types {
+		if p := item.Properties[name]; p == nil || p.Type != typ {
+			t.Errorf("Expected %s to be %s, got %+v", name, typ, p)
+		}
+	}
+
+	if !slices.Equal(item.Properties["kind"].Enum, []string{"a", "b"}) {
+		t.Errorf("Expected kind enum [a b], got %v", item.Properties["kind"].Enum)
+	}
+	if item.Properties["extra"].AdditionalProperties != true {
+		t.Errorf("Expected free-form maps to accept any value")
+	}
+	if tags, ok := item.Properties["Tags"].AdditionalProperties.(*Schema); !ok || tags.Type != "array" {
+		t.Errorf("Expected Tags values to be arrays, got %+v", item.Properties["Tags"].AdditionalProperties)
+	}
+}
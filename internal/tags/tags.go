@@ -0,0 +1,105 @@
+// Package tags selects the components of a project by the tags declared for them in
+// components.tags, e.g. to sync or list the experimental components only.
+package tags
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Filter selects the components having one of the included tags, when any, and none of
+// the excluded ones. A nil Filter selects every component.
+type Filter struct {
+	tags    map[string][]string
+	include []string
+	exclude []string
+}
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTOR                                                               */
+/* ------------------------------------------------------------------------- */
+
+// NewFilter returns the filter of the included and excluded tags over the tags declared
+// per component, normalizing the component names with the normalize function (if not
+// nil). It returns nil when no tag is given.
+func NewFilter(declared map[string][]string, include, exclude []string, normalize func(string) string) (*Filter, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+
+	f := &Filter{tags: ByComponent(declared, normalize)}
+	var err error
+	if f.include, err = cleanTags(include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = cleanTags(exclude); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// ByComponent returns the tags declared per component, with the component names
+// normalized by the normalize function (if not nil) and the tags trimmed and deduplicated.
+func ByComponent(declared map[string][]string, normalize func(string) string) map[string][]string {
+	if normalize == nil {
+		normalize = func(s string) string { return s }
+	}
+
+	byComponent := make(map[string][]string, len(declared))
+	for name, tags := range declared {
+		key := normalize(name)
+		for _, tag := range tags {
+			if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(byComponent[key], tag) {
+				byComponent[key] = append(byComponent[key], tag)
+			}
+		}
+	}
+	return byComponent
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// Match reports whether the component name is selected. A file outside of any component
+// folder, with an empty name, has no tags.
+func (f *Filter) Match(name string) bool {
+	if f == nil {
+		return true
+	}
+	tags := f.tags[name]
+	if slices.ContainsFunc(f.exclude, func(tag string) bool { return slices.Contains(tags, tag) }) {
+		return false
+	}
+	return len(f.include) == 0 || slices.ContainsFunc(f.include, func(tag string) bool { return slices.Contains(tags, tag) })
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// cleanTags trims the tags given on the command line, which may also be comma-separated,
+// and rejects the empty ones.
+func cleanTags(values []string) ([]string, error) {
+	var tags []string
+	for _, value := range values {
+		for tag := range strings.SplitSeq(value, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "" {
+				return nil, apperrors.Wrap("invalid tag filter %q: empty tag", value)
+			}
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
@@ -0,0 +1,60 @@
+package tags
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilter_Match(t *testing.T) {
+	declared := map[string][]string{
+		"Button":     {"forms"},
+		"text_input": {"forms", " experimental "},
+		"card":       {"layout"},
+	}
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []string
+	}{
+		{"include", []string{"forms"}, nil, []string{"button", "text_input"}},
+		{"exclude", nil, []string{"experimental"}, []string{"button", "card", "modal", ""}},
+		{"include and exclude", []string{"forms"}, []string{"experimental"}, []string{"button"}},
+		{"comma-separated", []string{"forms, layout"}, nil, []string{"button", "text_input", "card"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFilter(declared, tt.include, tt.exclude, strings.ToLower)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var got []string
+			for _, name := range []string{"button", "text_input", "card", "modal", ""} {
+				if f.Match(name) {
+					got = append(got, name)
+				}
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("Expected %q to be selected, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewFilter_NoTags(t *testing.T) {
+	f, err := NewFilter(map[string][]string{"button": {"forms"}}, nil, nil, nil)
+	if err != nil || f != nil {
+		t.Fatalf("Expected a nil filter without tags, got %v, %v", f, err)
+	}
+	if !f.Match("button") {
+		t.Error("Expected a nil filter to select every component")
+	}
+}
+
+func TestNewFilter_EmptyTag(t *testing.T) {
+	if _, err := NewFilter(nil, []string{"forms,"}, nil, nil); err == nil {
+		t.Error("Expected an error for an empty tag")
+	}
+}
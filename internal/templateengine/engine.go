@@ -0,0 +1,132 @@
+// Package templateengine provides the template engines used to render action templates.
+//
+// The Go text/template engine is the default. Additional engines are selected
+// either explicitly (the `engine` field of an action) or from the template file
+// extension (e.g. ".hbs" for handlebars).
+package templateengine
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+/* ------------------------------------------------------------------------- */
+/* INTERFACES                                                                */
+/* ------------------------------------------------------------------------- */
+
+// Engine renders template content with the provided data.
+type Engine interface {
+	Name() string
+	Render(content string, data any) (string, error)
+}
+
+/* ------------------------------------------------------------------------- */
+/* TYPES & CONSTANTS                                                         */
+/* ------------------------------------------------------------------------- */
+
+const (
+	GoEngineName         = "go"
+	HandlebarsEngineName = "handlebars"
+)
+
+// DefaultEngineName is the engine used when neither the action nor the file extension selects one.
+const DefaultEngineName = GoEngineName
+
+// ErrUnknownEngine is returned when an action references an engine that is not registered.
+var ErrUnknownEngine = fmt.Errorf("unknown template engine")
+
+var (
+	engines = map[string]Engine{
+		GoEngineName:         &GoEngine{},
+		HandlebarsEngineName: &HandlebarsEngine{},
+	}
+
+	// extensionEngines maps template file extensions to engine names.
+	extensionEngines = map[string]string{
+		".hbs":        HandlebarsEngineName,
+		".handlebars": HandlebarsEngineName,
+	}
+
+	mu sync.RWMutex
+)
+
+/* ------------------------------------------------------------------------- */
+/* REGISTRY                                                                  */
+/* ------------------------------------------------------------------------- */
+
+// Register adds or replaces an engine, optionally mapping file extensions to it.
+func Register(engine Engine, extensions ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	engines[engine.Name()] = engine
+	for _, ext := range extensions {
+		extensionEngines[strings.ToLower(ext)] = engine.Name()
+	}
+}
+
+// Get returns the engine registered under name.
+func Get(name string) (Engine, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	engine, ok := engines[name]
+	if !ok {
+		return nil, apperrors.Wrap("unknown template engine: %s (available: %s)", ErrUnknownEngine, name, strings.Join(namesLocked(), ", "))
+	}
+	return engine, nil
+}
+
+// Resolve selects the engine for a template: an explicit name wins, then the
+// template file extension, then the default Go engine.
+func Resolve(name, templatePath string) (Engine, error) {
+	if name != "" {
+		return Get(name)
+	}
+
+	mu.RLock()
+	mapped, ok := extensionEngines[strings.ToLower(filepath.Ext(templatePath))]
+	mu.RUnlock()
+	if ok {
+		return Get(mapped)
+	}
+
+	return Get(DefaultEngineName)
+}
+
+// Names returns the sorted names of all registered engines.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return namesLocked()
+}
+
+func namesLocked() []string {
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+/* ------------------------------------------------------------------------- */
+/* GO ENGINE                                                                 */
+/* ------------------------------------------------------------------------- */
+
+// GoEngine renders templates with text/template and the registered template functions.
+type GoEngine struct{}
+
+// Name returns the engine name.
+func (e *GoEngine) Name() string { return GoEngineName }
+
+// Render renders content using utils.RenderTemplate.
+func (e *GoEngine) Render(content string, data any) (string, error) {
+	return utils.RenderTemplate(content, data)
+}
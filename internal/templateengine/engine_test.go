@@ -0,0 +1,95 @@
+package templateengine
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type upperEngine struct{}
+
+func (e *upperEngine) Name() string { return "upper" }
+
+func (e *upperEngine) Render(content string, data any) (string, error) {
+	return strings.ToUpper(content), nil
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name         string
+		engineName   string
+		templatePath string
+		expected     string
+		expectError  bool
+	}{
+		{name: "Default engine", templatePath: "component.templ.gotxt", expected: GoEngineName},
+		{name: "Extension mapping .hbs", templatePath: "component.templ.hbs", expected: HandlebarsEngineName},
+		{name: "Extension mapping is case-insensitive", templatePath: "component.templ.HANDLEBARS", expected: HandlebarsEngineName},
+		{name: "Explicit engine wins over extension", engineName: GoEngineName, templatePath: "component.templ.hbs", expected: GoEngineName},
+		{name: "Unknown engine", engineName: "plush", templatePath: "component.templ.gotxt", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := Resolve(tt.engineName, tt.templatePath)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error, got engine %q", engine.Name())
+				}
+				if !errors.Is(err, ErrUnknownEngine) {
+					t.Errorf("Expected ErrUnknownEngine, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if engine.Name() != tt.expected {
+				t.Errorf("Expected engine %q, got %q", tt.expected, engine.Name())
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register(&upperEngine{}, ".UP")
+	t.Cleanup(func() {
+		mu.Lock()
+		delete(engines, "upper")
+		delete(extensionEngines, ".up")
+		mu.Unlock()
+	})
+
+	if !slices.Contains(Names(), "upper") {
+		t.Fatalf("Expected 'upper' in registered engines, got %v", Names())
+	}
+
+	engine, err := Resolve("", "hello.up")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output, err := engine.Render("hello", nil)
+	if err != nil {
+		t.Fatalf("Unexpected render error: %v", err)
+	}
+	if output != "HELLO" {
+		t.Errorf("Expected %q, got %q", "HELLO", output)
+	}
+}
+
+func TestGoEngine_Render(t *testing.T) {
+	engine, err := Get(GoEngineName)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output, err := engine.Render("Hello {{ .Name }}", map[string]string{"Name": "tempo"})
+	if err != nil {
+		t.Fatalf("Unexpected render error: %v", err)
+	}
+	if output != "Hello tempo" {
+		t.Errorf("Expected %q, got %q", "Hello tempo", output)
+	}
+}
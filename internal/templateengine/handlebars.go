@@ -0,0 +1,610 @@
+package templateengine
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+/* ------------------------------------------------------------------------- */
+/* HANDLEBARS ENGINE                                                         */
+/* ------------------------------------------------------------------------- */
+
+// HandlebarsEngine renders a dependency-free subset of the Handlebars syntax:
+//   - `{{path}}` (HTML-escaped) and `{{{path}}}` (raw), with dotted paths, `this`, `../` and `@index`/`@key`/`@first`/`@last`
+//   - block helpers `#if`, `#unless`, `#each` and `#with`, with `{{else}}`
+//   - helper calls using the registered template functions, e.g. `{{goPackageName ComponentName}}`
+//   - comments `{{! ... }}` and `{{!-- ... --}}`
+//
+// Block tags and comments standing alone on a line remove the whole line, as in Handlebars.
+type HandlebarsEngine struct{}
+
+// hbsParsed caches the parsed templates by content.
+var hbsParsed sync.Map
+
+// Name returns the engine name.
+func (e *HandlebarsEngine) Name() string { return HandlebarsEngineName }
+
+// Render parses and renders content against data.
+func (e *HandlebarsEngine) Render(content string, data any) (string, error) {
+	nodes, err := parseHandlebarsContent(content)
+	if err != nil {
+		return "", err
+	}
+
+	r := &hbsRenderer{funcs: utils.TemplateFuncs()}
+	var sb strings.Builder
+	if err := r.render(&sb, nodes, []hbsFrame{{value: data}}); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// parseHandlebarsContent returns the parsed nodes of content, from the cache when it
+// was parsed before.
+func parseHandlebarsContent(content string) ([]hbsNode, error) {
+	if nodes, ok := hbsParsed.Load(content); ok {
+		return nodes.([]hbsNode), nil
+	}
+
+	tokens, err := tokenizeHandlebars(content)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, rest, err := parseHandlebars(tokens, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, apperrors.Wrap("handlebars: unexpected {{/%s}}", rest[0].value)
+	}
+	hbsParsed.Store(content, nodes)
+	return nodes, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* TOKENIZER                                                                 */
+/* ------------------------------------------------------------------------- */
+
+type hbsTokenKind int
+
+const (
+	hbsText hbsTokenKind = iota
+	hbsEscaped
+	hbsRaw
+	hbsOpen
+	hbsClose
+	hbsElse
+	hbsComment
+)
+
+type hbsToken struct {
+	kind  hbsTokenKind
+	value string
+}
+
+// standalone reports whether the token removes its line when it is alone on it.
+func (t hbsToken) standalone() bool {
+	return t.kind == hbsOpen || t.kind == hbsClose || t.kind == hbsElse || t.kind == hbsComment
+}
+
+func tokenizeHandlebars(content string) ([]hbsToken, error) {
+	var tokens []hbsToken
+
+	for len(content) > 0 {
+		start := strings.Index(content, "{{")
+		if start == -1 {
+			tokens = append(tokens, hbsToken{kind: hbsText, value: content})
+			break
+		}
+		if start > 0 {
+			tokens = append(tokens, hbsToken{kind: hbsText, value: content[:start]})
+		}
+		content = content[start:]
+
+		var token hbsToken
+		var end int
+		switch {
+		case strings.HasPrefix(content, "{{!--"):
+			end = strings.Index(content, "--}}")
+			if end == -1 {
+				return nil, apperrors.Wrap("handlebars: unclosed comment")
+			}
+			token, end = hbsToken{kind: hbsComment}, end+len("--}}")
+		case strings.HasPrefix(content, "{{{"):
+			end = strings.Index(content, "}}}")
+			if end == -1 {
+				return nil, apperrors.Wrap("handlebars: unclosed {{{")
+			}
+			token, end = hbsToken{kind: hbsRaw, value: strings.TrimSpace(content[3:end])}, end+len("}}}")
+		default:
+			end = strings.Index(content, "}}")
+			if end == -1 {
+				return nil, apperrors.Wrap("handlebars: unclosed {{")
+			}
+			token, end = classifyHandlebarsTag(strings.TrimSpace(content[2:end])), end+len("}}")
+		}
+
+		tokens = append(tokens, token)
+		content = content[end:]
+	}
+
+	stripStandaloneLines(tokens)
+	return tokens, nil
+}
+
+func classifyHandlebarsTag(inner string) hbsToken {
+	switch {
+	case strings.HasPrefix(inner, "!"):
+		return hbsToken{kind: hbsComment}
+	case strings.HasPrefix(inner, "#"):
+		return hbsToken{kind: hbsOpen, value: strings.TrimSpace(inner[1:])}
+	case strings.HasPrefix(inner, "/"):
+		return hbsToken{kind: hbsClose, value: strings.TrimSpace(inner[1:])}
+	case inner == "else" || inner == "^":
+		return hbsToken{kind: hbsElse}
+	default:
+		return hbsToken{kind: hbsEscaped, value: inner}
+	}
+}
+
+// stripStandaloneLines removes the surrounding whitespace and newline of block
+// tags and comments that are the only content on their line.
+func stripStandaloneLines(tokens []hbsToken) {
+	for i, token := range tokens {
+		if !token.standalone() {
+			continue
+		}
+
+		prevOK, prevCut := true, -1
+		if i > 0 && tokens[i-1].kind == hbsText {
+			prev := tokens[i-1].value
+			lineStart := strings.LastIndex(prev, "\n") + 1
+			if strings.TrimLeft(prev[lineStart:], " \t") != "" || (lineStart == 0 && i-1 > 0) {
+				prevOK = false
+			}
+			prevCut = lineStart
+		} else if i > 0 {
+			prevOK = false
+		}
+
+		nextOK, nextCut := true, -1
+		if i+1 < len(tokens) && tokens[i+1].kind == hbsText {
+			next := tokens[i+1].value
+			lineEnd := strings.Index(next, "\n")
+			rest := next
+			if lineEnd != -1 {
+				rest = next[:lineEnd]
+			}
+			if strings.TrimRight(rest, " \t\r") != "" || (lineEnd == -1 && i+2 < len(tokens)) {
+				nextOK = false
+			}
+			if lineEnd == -1 {
+				nextCut = len(next)
+			} else {
+				nextCut = lineEnd + 1
+			}
+		} else if i+1 < len(tokens) {
+			nextOK = false
+		}
+
+		if !prevOK || !nextOK {
+			continue
+		}
+		if prevCut >= 0 {
+			tokens[i-1].value = tokens[i-1].value[:prevCut]
+		}
+		if nextCut >= 0 {
+			tokens[i+1].value = tokens[i+1].value[nextCut:]
+		}
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* PARSER                                                                    */
+/* ------------------------------------------------------------------------- */
+
+type hbsNode struct {
+	kind     hbsTokenKind // hbsText, hbsEscaped, hbsRaw or hbsOpen (block)
+	text     string
+	args     []string
+	children []hbsNode
+	inverse  []hbsNode
+}
+
+// parseHandlebars parses tokens until the closing tag of block (or the end when block is empty).
+// It returns the parsed nodes and the tokens following the closing tag.
+func parseHandlebars(tokens []hbsToken, block string) ([]hbsNode, []hbsToken, error) {
+	var nodes []hbsNode
+
+	for len(tokens) > 0 {
+		token := tokens[0]
+		tokens = tokens[1:]
+
+		switch token.kind {
+		case hbsComment:
+			continue
+		case hbsText:
+			if token.value != "" {
+				nodes = append(nodes, hbsNode{kind: hbsText, text: token.value})
+			}
+		case hbsEscaped, hbsRaw:
+			args, err := splitHandlebarsArgs(token.value)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(args) == 0 {
+				return nil, nil, apperrors.Wrap("handlebars: empty expression")
+			}
+			nodes = append(nodes, hbsNode{kind: token.kind, args: args})
+		case hbsElse:
+			if block == "" {
+				return nil, nil, apperrors.Wrap("handlebars: {{else}} outside of a block")
+			}
+			// Return control to the block parser, which collects the inverse section
+			return nodes, append([]hbsToken{token}, tokens...), nil
+		case hbsClose:
+			if token.value != block {
+				return nil, nil, apperrors.Wrap("handlebars: unexpected {{/%s}} (expected {{/%s}})", token.value, block)
+			}
+			return nodes, append([]hbsToken{token}, tokens...), nil
+		case hbsOpen:
+			node, rest, err := parseHandlebarsBlock(token, tokens)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, node)
+			tokens = rest
+		}
+	}
+
+	if block != "" {
+		return nil, nil, apperrors.Wrap("handlebars: missing {{/%s}}", block)
+	}
+	return nodes, nil, nil
+}
+
+func parseHandlebarsBlock(open hbsToken, tokens []hbsToken) (hbsNode, []hbsToken, error) {
+	args, err := splitHandlebarsArgs(open.value)
+	if err != nil {
+		return hbsNode{}, nil, err
+	}
+	if len(args) < 2 {
+		return hbsNode{}, nil, apperrors.Wrap("handlebars: block {{#%s}} requires an argument", open.value)
+	}
+	name := args[0]
+
+	children, rest, err := parseHandlebars(tokens, name)
+	if err != nil {
+		return hbsNode{}, nil, err
+	}
+
+	var inverse []hbsNode
+	if rest[0].kind == hbsElse {
+		inverse, rest, err = parseHandlebars(rest[1:], name)
+		if err != nil {
+			return hbsNode{}, nil, err
+		}
+	}
+
+	// rest[0] is the closing tag
+	return hbsNode{kind: hbsOpen, text: name, args: args[1:], children: children, inverse: inverse}, rest[1:], nil
+}
+
+// splitHandlebarsArgs splits an expression on whitespace, keeping quoted strings together.
+func splitHandlebarsArgs(expr string) ([]string, error) {
+	var args []string
+	for expr = strings.TrimSpace(expr); expr != ""; expr = strings.TrimSpace(expr) {
+		if expr[0] == '"' || expr[0] == '\'' {
+			end := strings.IndexByte(expr[1:], expr[0])
+			if end == -1 {
+				return nil, apperrors.Wrap("handlebars: unterminated string in %q", expr)
+			}
+			args = append(args, expr[:end+2])
+			expr = expr[end+2:]
+			continue
+		}
+
+		end := strings.IndexAny(expr, " \t\n")
+		if end == -1 {
+			end = len(expr)
+		}
+		args = append(args, expr[:end])
+		expr = expr[end:]
+	}
+	return args, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* RENDERER                                                                  */
+/* ------------------------------------------------------------------------- */
+
+type hbsFrame struct {
+	value any
+	vars  map[string]any // @index, @key, @first, @last
+}
+
+type hbsRenderer struct {
+	funcs map[string]any
+}
+
+var hbsEscaper = strings.NewReplacer(
+	"&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;",
+	"'", "&#x27;", "`", "&#x60;", "=", "&#x3D;",
+)
+
+func (r *hbsRenderer) render(sb *strings.Builder, nodes []hbsNode, stack []hbsFrame) error {
+	for _, node := range nodes {
+		switch node.kind {
+		case hbsText:
+			sb.WriteString(node.text)
+		case hbsEscaped, hbsRaw:
+			value, err := r.evalExpr(node.args, stack)
+			if err != nil {
+				return err
+			}
+			out := stringify(value)
+			if node.kind == hbsEscaped {
+				out = hbsEscaper.Replace(out)
+			}
+			sb.WriteString(out)
+		case hbsOpen:
+			if err := r.renderBlock(sb, node, stack); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *hbsRenderer) renderBlock(sb *strings.Builder, node hbsNode, stack []hbsFrame) error {
+	value, err := r.evalExpr(node.args, stack)
+	if err != nil {
+		return err
+	}
+
+	switch node.text {
+	case "if":
+		if truthy(value) {
+			return r.render(sb, node.children, stack)
+		}
+		return r.render(sb, node.inverse, stack)
+	case "unless":
+		if !truthy(value) {
+			return r.render(sb, node.children, stack)
+		}
+		return r.render(sb, node.inverse, stack)
+	case "with":
+		if !truthy(value) {
+			return r.render(sb, node.inverse, stack)
+		}
+		return r.render(sb, node.children, append(stack, hbsFrame{value: value}))
+	case "each":
+		return r.renderEach(sb, node, value, stack)
+	default:
+		return apperrors.Wrap("handlebars: unknown block helper %q", node.text)
+	}
+}
+
+func (r *hbsRenderer) renderEach(sb *strings.Builder, node hbsNode, value any, stack []hbsFrame) error {
+	v := indirect(reflect.ValueOf(value))
+	if !v.IsValid() || !truthy(value) {
+		return r.render(sb, node.inverse, stack)
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			frame := hbsFrame{value: v.Index(i).Interface(), vars: map[string]any{
+				"index": i, "first": i == 0, "last": i == v.Len()-1,
+			}}
+			if err := r.render(sb, node.children, append(stack, frame)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		for i, key := range keys {
+			frame := hbsFrame{value: v.MapIndex(key).Interface(), vars: map[string]any{
+				"key": key.Interface(), "index": i, "first": i == 0, "last": i == len(keys)-1,
+			}}
+			if err := r.render(sb, node.children, append(stack, frame)); err != nil {
+				return err
+			}
+		}
+	default:
+		return apperrors.Wrap(fmt.Sprintf("handlebars: {{#each}} expects a list or a map, got %T", value))
+	}
+	return nil
+}
+
+// evalExpr evaluates a single value or a helper call ("helper arg1 arg2").
+func (r *hbsRenderer) evalExpr(args []string, stack []hbsFrame) (any, error) {
+	if len(args) == 1 {
+		if _, isHelper := r.funcs[args[0]]; !isHelper || strings.ContainsAny(args[0], ".@/") {
+			return evalArg(args[0], stack), nil
+		}
+	}
+
+	fn, ok := r.funcs[args[0]]
+	if !ok {
+		return nil, apperrors.Wrap("handlebars: unknown helper %q", args[0])
+	}
+
+	values := make([]any, len(args)-1)
+	for i, arg := range args[1:] {
+		values[i] = evalArg(arg, stack)
+	}
+	return callHelper(args[0], fn, values)
+}
+
+// evalArg resolves a literal (string, number, boolean) or a path against the context stack.
+func evalArg(arg string, stack []hbsFrame) any {
+	if len(arg) >= 2 && (arg[0] == '"' || arg[0] == '\'') {
+		return arg[1 : len(arg)-1]
+	}
+	if arg == "true" || arg == "false" {
+		return arg == "true"
+	}
+	if n, err := strconv.Atoi(arg); err == nil {
+		return n
+	}
+	return lookupPath(arg, stack)
+}
+
+// lookupPath resolves `this`, `../parent`, `@var` and dotted paths. Missing values resolve to nil.
+func lookupPath(path string, stack []hbsFrame) any {
+	depth := len(stack) - 1
+	for strings.HasPrefix(path, "../") {
+		path = path[3:]
+		if depth > 0 {
+			depth--
+		}
+	}
+	frame := stack[depth]
+
+	if strings.HasPrefix(path, "@") {
+		return frame.vars[path[1:]]
+	}
+
+	current := frame.value
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "this"), ".")
+	if path == "" {
+		return current
+	}
+
+	for _, part := range strings.Split(path, ".") {
+		current = field(current, part)
+		if current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
+// field returns the struct field or map entry named name, or nil when missing.
+func field(value any, name string) any {
+	v := indirect(reflect.ValueOf(value))
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		entry := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		if !entry.IsValid() {
+			return nil
+		}
+		return entry.Interface()
+	case reflect.Struct:
+		f := v.FieldByNameFunc(func(fieldName string) bool { return strings.EqualFold(fieldName, name) })
+		if !f.IsValid() || !f.CanInterface() {
+			return nil
+		}
+		return f.Interface()
+	}
+	return nil
+}
+
+// callHelper invokes a registered template function with the evaluated arguments.
+func callHelper(name string, fn any, args []any) (any, error) {
+	fv := reflect.ValueOf(fn)
+	ft := fv.Type()
+
+	if (!ft.IsVariadic() && len(args) != ft.NumIn()) || (ft.IsVariadic() && len(args) < ft.NumIn()-1) {
+		return nil, apperrors.Wrap(fmt.Sprintf("handlebars: helper %q expects %d argument(s), got %d", name, ft.NumIn(), len(args)))
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var paramType reflect.Type
+		if ft.IsVariadic() && i >= ft.NumIn()-1 {
+			paramType = ft.In(ft.NumIn() - 1).Elem()
+		} else {
+			paramType = ft.In(i)
+		}
+
+		argValue := reflect.ValueOf(arg)
+		switch {
+		case !argValue.IsValid():
+			argValue = reflect.Zero(paramType)
+		case argValue.Type().AssignableTo(paramType):
+		case argValue.Type().ConvertibleTo(paramType):
+			argValue = argValue.Convert(paramType)
+		case paramType.Kind() == reflect.String:
+			argValue = reflect.ValueOf(stringify(arg))
+		default:
+			return nil, apperrors.Wrap(fmt.Sprintf("handlebars: invalid argument %d for helper %q", i+1, name))
+		}
+		in[i] = argValue
+	}
+
+	out := fv.Call(in)
+	if len(out) == 2 && !out[1].IsNil() {
+		return nil, apperrors.Wrap("handlebars: helper %q failed", out[1].Interface().(error), name)
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out[0].Interface(), nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* VALUE HELPERS                                                             */
+/* ------------------------------------------------------------------------- */
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// truthy follows the Handlebars rules: nil, false, zero, "" and empty lists are falsy.
+func truthy(value any) bool {
+	v := indirect(reflect.ValueOf(value))
+	if !v.IsValid() {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() > 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() != 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() != 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() != 0
+	}
+	return true
+}
+
+func stringify(value any) string {
+	if value == nil {
+		return ""
+	}
+	v := indirect(reflect.ValueOf(value))
+	if !v.IsValid() {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,114 @@
+package templateengine
+
+import (
+	"strings"
+	"testing"
+)
+
+type hbsTestData struct {
+	ComponentName string
+	WithJs        bool
+	Tags          []string
+	Owner         *hbsTestOwner
+	Meta          map[string]string
+}
+
+type hbsTestOwner struct {
+	Name string
+}
+
+func TestHandlebarsEngine_Render(t *testing.T) {
+	structData := hbsTestData{
+		ComponentName: "button",
+		WithJs:        true,
+		Tags:          []string{"ui", "form"},
+		Owner:         &hbsTestOwner{Name: "design"},
+		Meta:          map[string]string{"b": "2", "a": "1"},
+	}
+	mapData := map[string]any{"Text": "<b>", "Empty": []string{}}
+
+	tests := []struct {
+		name     string
+		template string
+		data     any
+		expected string
+	}{
+		{name: "Plain text", template: "no tags here", data: structData, expected: "no tags here"},
+		{name: "Variable", template: "Hello {{ComponentName}}", data: structData, expected: "Hello button"},
+		{name: "Lower-case field lookup", template: "{{componentName}}", data: structData, expected: "button"},
+		{name: "Dotted path", template: "{{Owner.Name}}", data: structData, expected: "design"},
+		{name: "Missing value renders empty", template: "[{{Missing.Value}}]", data: structData, expected: "[]"},
+		{name: "Escaped output", template: "{{Text}}", data: mapData, expected: "&lt;b&gt;"},
+		{name: "Raw output", template: "{{{Text}}}", data: mapData, expected: "<b>"},
+		{name: "Comments", template: "a{{! short }}b{{!-- long }} --}}c", data: structData, expected: "abc"},
+		{name: "If block", template: "{{#if WithJs}}js{{/if}}", data: structData, expected: "js"},
+		{name: "If else block", template: "{{#if Owner.Missing}}yes{{else}}no{{/if}}", data: structData, expected: "no"},
+		{name: "Unless block", template: "{{#unless Empty}}none{{/unless}}", data: mapData, expected: "none"},
+		{name: "Each over slice", template: "{{#each Tags}}{{@index}}:{{this}}{{#unless @last}},{{/unless}}{{/each}}", data: structData, expected: "0:ui,1:form"},
+		{name: "Each over map in key order", template: "{{#each Meta}}{{@key}}={{this}};{{/each}}", data: structData, expected: "a=1;b=2;"},
+		{name: "Each with parent lookup", template: "{{#each Tags}}{{../ComponentName}}-{{this}} {{/each}}", data: structData, expected: "button-ui button-form "},
+		{name: "Each else on empty list", template: "{{#each Empty}}x{{else}}empty{{/each}}", data: mapData, expected: "empty"},
+		{name: "With block", template: "{{#with Owner}}{{Name}}{{/with}}", data: structData, expected: "design"},
+		{name: "Helper call", template: "{{goPackageName \"counterBox\"}}", data: structData, expected: "counter_box"},
+		{name: "Helper call with path argument", template: "{{kebabCase ComponentName}}", data: structData, expected: "button"},
+		{
+			name:     "Standalone block lines are removed",
+			template: "start\n{{#if WithJs}}\n  js\n{{/if}}\nend\n",
+			data:     structData,
+			expected: "start\n  js\nend\n",
+		},
+		{
+			name:     "Standalone comment line is removed",
+			template: "{{!-- header --}}\nbody\n",
+			data:     structData,
+			expected: "body\n",
+		},
+	}
+
+	engine := &HandlebarsEngine{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := engine.Render(tt.template, tt.data)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if output != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestHandlebarsEngine_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		errMsg   string
+	}{
+		{name: "Unclosed tag", template: "{{ComponentName", errMsg: "unclosed {{"},
+		{name: "Unclosed comment", template: "{{!-- comment", errMsg: "unclosed comment"},
+		{name: "Missing close", template: "{{#if WithJs}}x", errMsg: "missing {{/if}}"},
+		{name: "Mismatched close", template: "{{#if WithJs}}x{{/each}}", errMsg: "unexpected {{/each}}"},
+		{name: "Stray close", template: "x{{/if}}", errMsg: "unexpected {{/if}}"},
+		{name: "Else outside block", template: "{{else}}", errMsg: "outside of a block"},
+		{name: "Unknown block helper", template: "{{#loop Tags}}x{{/loop}}", errMsg: "unknown block helper"},
+		{name: "Unknown helper", template: "{{shout ComponentName}}", errMsg: "unknown helper"},
+		{name: "Helper arity", template: "{{kebabCase ComponentName ComponentName}}", errMsg: "expects 1 argument(s), got 2"},
+		{name: "Each over scalar", template: "{{#each ComponentName}}x{{/each}}", errMsg: "expects a list or a map, got string"},
+		{name: "Each over bool", template: "{{#each WithJs}}x{{/each}}", errMsg: "expects a list or a map, got bool"},
+	}
+
+	engine := &HandlebarsEngine{}
+	data := map[string]any{"ComponentName": "button", "WithJs": true}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := engine.Render(tt.template, data)
+			if err == nil {
+				t.Fatalf("Expected an error containing %q", tt.errMsg)
+			}
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package textprovider
 
 import (
+	"path"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -17,6 +18,12 @@ func IsValidValue(value string, allowedValues []string) bool {
 	return slices.Contains(allowedValues, value)
 }
 
+// PathBase returns the last element of a slash-separated path, e.g. "variants" for
+// "css/variants".
+func PathBase(input string) string {
+	return path.Base(filepath.ToSlash(strings.TrimSpace(input)))
+}
+
 // NormalizePath normalizes a path by removing dots, leading/trailing slashes, and white spaces.
 func NormalizePath(input string) string {
 	// Trim spaces
@@ -54,3 +61,42 @@ func SnakeToTitle(s string) string {
 	}
 	return strings.Join(words, " ")
 }
+
+// KebabCase converts camelCase, PascalCase, snake_case and space separated strings to kebab-case.
+func KebabCase(s string) string {
+	var sb strings.Builder
+	runes := []rune(strings.TrimSpace(s))
+
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == ' ' || r == '-':
+			if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "-") {
+				sb.WriteRune('-')
+			}
+		case unicode.IsUpper(r):
+			if i > 0 && sb.Len() > 0 && !strings.HasSuffix(sb.String(), "-") &&
+				(unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+					(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				sb.WriteRune('-')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "-")
+}
+
+// Indent prefixes the non-empty lines of s with n spaces, e.g. to nest a multi-line
+// value inside a templ component or a CSS layer.
+func Indent(n int, s string) string {
+	prefix := strings.Repeat(" ", max(n, 0))
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -139,6 +139,23 @@ func TestNormalizePath(t *testing.T) {
 	}
 }
 
+func TestPathBase(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"css", "css"},
+		{"css/variants", "variants"},
+		{"styles/variants/", "variants"},
+	}
+
+	for _, tt := range tests {
+		if result := PathBase(tt.input); result != tt.expected {
+			t.Errorf("pathBase(%q) = %q; expected %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
 func TestTitleCase(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -189,3 +206,49 @@ func TestSnakeToTitle(t *testing.T) {
 		})
 	}
 }
+
+func TestKebabCase(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"button", "button"},
+		{"myButton", "my-button"},
+		{"MyButton", "my-button"},
+		{"my_button", "my-button"},
+		{"my button", "my-button"},
+		{"my-button", "my-button"},
+		{"HTTPClient", "http-client"},
+		{"  Trailing_ ", "trailing"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := KebabCase(tt.input); result != tt.expected {
+				t.Errorf("KebabCase(%q) = %q; expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIndent(t *testing.T) {
+	tests := []struct {
+		n        int
+		input    string
+		expected string
+	}{
+		{4, "<div>\n  <p>Hi</p>\n</div>", "    <div>\n      <p>Hi</p>\n    </div>"},
+		{2, ".a {}\n\n.b {}", "  .a {}\n\n  .b {}"},
+		{-1, "x", "x"},
+		{2, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := Indent(tt.n, tt.input); result != tt.expected {
+				t.Errorf("Indent(%d, %q) = %q; expected %q", tt.n, tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -12,15 +12,21 @@ type TextProvider struct{}
 // GetFunctions returns the built-in template functions.
 // Supported Functions:
 //   - `normalizePath`: normalizes a path string.
+//   - `pathBase`: Returns the last element of a path.
 //   - `isEmpty`: Checks if a string is empty.
 //   - `titleCase`: Capitalizes the first letter of a word and preserves the rest of the word as-is.
-//   - `isEmpty`: Converts a snake_case string to Title Case.
+//   - `snakeToTitle`: Converts a snake_case string to Title Case.
+//   - `kebabCase`: Converts a string to kebab-case.
+//   - `indent`: Indents the non-empty lines of a string by a number of spaces.
 func (p *TextProvider) GetFunctions() template.FuncMap {
 	return template.FuncMap{
 		"normalizePath": NormalizePath,
+		"pathBase":      PathBase,
 		"isEmpty":       IsEmptyString,
 		"titleCase":     TitleCase,
 		"snakeToTitle":  SnakeToTitle,
+		"kebabCase":     KebabCase,
+		"indent":        Indent,
 	}
 }
 
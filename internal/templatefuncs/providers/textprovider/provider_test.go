@@ -10,6 +10,10 @@ func TestTextProvider(t *testing.T) {
 		t.Errorf("Expected function 'normalizePath' to be registered, but it was not found.")
 	}
 
+	if _, exists := funcs["pathBase"]; !exists {
+		t.Errorf("Expected function 'pathBase' to be registered, but it was not found.")
+	}
+
 	if _, exists := funcs["isEmpty"]; !exists {
 		t.Errorf("Expected function 'isEmpty' to be registered, but it was not found.")
 	}
@@ -17,4 +21,8 @@ func TestTextProvider(t *testing.T) {
 	if _, exists := funcs["titleCase"]; !exists {
 		t.Errorf("Expected function 'titleCase' to be registered, but it was not found.")
 	}
+
+	if _, exists := funcs["kebabCase"]; !exists {
+		t.Errorf("Expected function 'kebabCase' to be registered, but it was not found.")
+	}
 }
@@ -11,17 +11,28 @@ import (
 // Global function registry for template functions.
 var funcRegistry = template.FuncMap{}
 
+// version is incremented on every registration, so that parsed templates bound to
+// the previous functions can be discarded.
+var version uint64
+
 // RegisterFuncProvider allows external function providers to register their functions.
 func RegisterFuncProvider(provider templatefuncs.TemplateFuncProvider) {
 	maps.Copy(funcRegistry, provider.GetFunctions())
+	version++
 }
 
 // RegisterFunction registers an individual function.
 func RegisterFunction(name string, fn any) {
 	funcRegistry[name] = fn
+	version++
 }
 
 // GetRegisteredFunctions returns all registered template functions.
 func GetRegisteredFunctions() template.FuncMap {
 	return funcRegistry
 }
+
+// Version returns a number changing whenever functions are registered.
+func Version() uint64 {
+	return version
+}
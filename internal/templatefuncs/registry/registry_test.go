@@ -43,6 +43,15 @@ func TestRegisterFunction(t *testing.T) {
 }
 
 // TestGetRegisteredFunctions ensures retrieving all functions works
+func TestVersion(t *testing.T) {
+	before := Version()
+	RegisterFunction("versionFunc", func() string { return "" })
+
+	if Version() == before {
+		t.Errorf("Expected the version to change after a registration, got %d", before)
+	}
+}
+
 func TestGetRegisteredFunctions(t *testing.T) {
 	// Clear registry before testing
 	funcRegistry = template.FuncMap{}
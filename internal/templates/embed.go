@@ -2,5 +2,5 @@ package templates
 
 import "embed"
 
-//go:embed component component-variant
+//go:embed component component-variant webcomponent demo
 var EmbeddedFiles embed.FS
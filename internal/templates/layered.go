@@ -0,0 +1,109 @@
+package templates
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"strings"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// LayeredFS is a read-only file system looking up each file in a list of layers, e.g.
+// the project templates, the user templates and the built-in ones: a file of a layer
+// hides the files with the same name in the next layers, and the folders of all the
+// layers are merged. Any fs.FS can be a layer, such as os.DirFS, an embed.FS or a zip
+// bundle.
+type LayeredFS struct {
+	layers []fs.FS
+}
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTOR                                                               */
+/* ------------------------------------------------------------------------- */
+
+// NewLayeredFS returns the file system of the given layers, first to last. Nil layers
+// are left out.
+func NewLayeredFS(layers ...fs.FS) *LayeredFS {
+	l := &LayeredFS{layers: make([]fs.FS, 0, len(layers))}
+	for _, layer := range layers {
+		if layer != nil {
+			l.layers = append(l.layers, layer)
+		}
+	}
+	return l
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// Open opens the named file of the first layer holding it.
+func (l *LayeredFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	for _, layer := range l.layers {
+		f, err := layer.Open(name)
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadFile reads the named file of the first layer holding it.
+func (l *LayeredFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	for _, layer := range l.layers {
+		content, err := fs.ReadFile(layer, name)
+		if err == nil {
+			return content, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir returns the entries of the named folder in all the layers holding it, sorted
+// by name. An entry of a layer hides the entries with the same name in the next ones.
+func (l *LayeredFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	var entries []fs.DirEntry
+	seen := make(map[string]bool)
+	found := false
+	for _, layer := range l.layers {
+		layerEntries, err := fs.ReadDir(layer, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, entry := range layerEntries {
+			if !seen[entry.Name()] {
+				seen[entry.Name()] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+	return entries, nil
+}
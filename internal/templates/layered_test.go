@@ -0,0 +1,90 @@
+package templates
+
+import (
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func newTestLayers() *LayeredFS {
+	project := fstest.MapFS{
+		"component/templ/component.templ.gotxt": {Data: []byte("project")},
+	}
+	user := fstest.MapFS{
+		"component/templ/component.templ.gotxt": {Data: []byte("user")},
+		"component/assets/css/base.css.gotxt":   {Data: []byte("user css")},
+	}
+	return NewLayeredFS(project, nil, user, EmbeddedFiles)
+}
+
+func TestLayeredFS_ReadFile(t *testing.T) {
+	layers := newTestLayers()
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"component/templ/component.templ.gotxt", "project"},
+		{"component/assets/css/base.css.gotxt", "user css"},
+	}
+	for _, tt := range tests {
+		content, err := fs.ReadFile(layers, tt.name)
+		if err != nil {
+			t.Fatalf("Unexpected error reading %s: %v", tt.name, err)
+		}
+		if string(content) != tt.want {
+			t.Errorf("Expected %s to be read from the %q layer, got %q", tt.name, tt.want, content)
+		}
+	}
+
+	// The built-in templates are the last layer
+	if _, err := fs.ReadFile(layers, "component/assets/js/script.js.gotxt"); err != nil {
+		t.Errorf("Expected the built-in template, got %v", err)
+	}
+
+	if _, err := fs.ReadFile(layers, "missing.gotxt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected fs.ErrNotExist, got %v", err)
+	}
+	if _, err := layers.Open("../outside"); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("Expected fs.ErrInvalid, got %v", err)
+	}
+}
+
+func TestLayeredFS_ReadDir(t *testing.T) {
+	layers := NewLayeredFS(
+		fstest.MapFS{"css/base.css": {Data: []byte("a")}},
+		fstest.MapFS{"css/base.css": {Data: []byte("b")}, "css/themes/dark.css": {Data: []byte("c")}},
+	)
+
+	entries, err := fs.ReadDir(layers, "css")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if want := []string{"base.css", "themes"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected the merged entries %v, got %v", want, names)
+	}
+
+	var files []string
+	err = fs.WalkDir(layers, ".", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"css/base.css", "css/themes/dark.css"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("Expected the walked files %v, got %v", want, files)
+	}
+
+	if _, err := fs.ReadDir(layers, "js"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected fs.ErrNotExist, got %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package testutils
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/cmdrunner"
+)
+
+// StubRunner replaces *runner, the external command runner of a command package, for the
+// duration of the test. The commands are recorded instead of run, as their arguments
+// joined with spaces, prefixed with "<label(dir)>: " when label is set. The recorded calls
+// listed in failures return the given error.
+func StubRunner(t *testing.T, runner *cmdrunner.Runner, label func(dir string) string, failures map[string]error) *[]string {
+	t.Helper()
+	var calls []string
+	original := *runner
+	*runner = func(_ context.Context, dir string, command []string) error {
+		call := strings.Join(command, " ")
+		if label != nil {
+			call = label(dir) + ": " + call
+		}
+		calls = append(calls, call)
+		return failures[call]
+	}
+	t.Cleanup(func() { *runner = original })
+	return &calls
+}
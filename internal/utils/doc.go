@@ -19,6 +19,15 @@
 //   - RemoveTemplatingExtension - Extension handling
 //   - GetModuleName - Go module detection
 //
+// # Go Modules & Workspaces (gomod.go)
+//
+// Functions for locating the Go module in multi-module repositories:
+//   - FindModuleRoot, FindGoWork - Walk up to the nearest go.mod / go.work
+//   - WorkspaceModules - Modules listed in a go.work file
+//   - ResolveModuleRoot - Explicit root, nearest go.mod, then go.work workspace
+//   - ModuleNameCache - Module names cached per module root until go.mod changes,
+//     optionally persisted across runs in the user cache folder
+//
 // # Embedded Resources (embed.go)
 //
 // Functions for working with embedded filesystems:
@@ -37,8 +46,14 @@
 //   - ContainsSubstring - Case-insensitive substring check
 //   - ExtractNameFromURL, ExtractNameFromPath - Name extraction
 //
+// # Diff (diff.go)
+//
+// Functions for comparing contents:
+//   - UnifiedDiff - Line-based unified diff of two contents
+//
 // # Type Conversion (numbers.go)
 //
 // Functions for type conversion:
 //   - Int64ToInt - Safe int64 to int conversion
+//   - FormatBytes, ParseBytes, ParseDuration - Human-readable sizes and durations
 package utils
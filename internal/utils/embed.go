@@ -4,6 +4,7 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 
 	"github.com/indaco/tempo/internal/apperrors"
@@ -23,9 +24,14 @@ func IsEmbedded(path string) bool {
 
 // ReadEmbeddedFile reads a file from embedded resources and returns its content as a byte slice.
 func ReadEmbeddedFile(path string) ([]byte, error) {
-	file, err := embeddedFiles.Open(path)
+	return readFSFile(embeddedFiles, path)
+}
+
+// readFSFile reads the file at path of fsys.
+func readFSFile(fsys fs.FS, path string) ([]byte, error) {
+	file, err := fsys.Open(path)
 	if err != nil {
-		return nil, apperrors.Wrap("failed to open embedded file '%s'", err, path)
+		return nil, apperrors.Wrap("failed to open file '%s'", err, path)
 	}
 	defer func() {
 		if err := file.Close(); err != nil {
@@ -40,9 +46,14 @@ func ReadEmbeddedFile(path string) ([]byte, error) {
 
 // ReadEmbeddedDir reads the entries of a directory from embedded resources.
 func ReadEmbeddedDir(path string) ([]os.DirEntry, error) {
-	entries, err := fs.ReadDir(embeddedFiles, path)
+	return readFSDir(embeddedFiles, path)
+}
+
+// readFSDir reads the entries of the directory at path of fsys.
+func readFSDir(fsys fs.FS, path string) ([]os.DirEntry, error) {
+	entries, err := fs.ReadDir(fsys, path)
 	if err != nil {
-		return nil, apperrors.Wrap("failed to read embedded directory '%s'", err, path)
+		return nil, apperrors.Wrap("failed to read directory '%s'", err, path)
 	}
 	return entries, nil
 }
@@ -60,29 +71,35 @@ var CopyDirFromEmbedFunc = CopyDirFromEmbed
 
 // CopyDirFromEmbed copies all files and subdirectories from an embedded source to a destination directory.
 func CopyDirFromEmbed(source, destination string) error {
+	return CopyDirFromFS(embeddedFiles, source, destination)
+}
+
+// CopyDirFromFS copies all files and subdirectories of the source directory of fsys,
+// e.g. the embedded templates or a layered file system, to a destination directory.
+func CopyDirFromFS(fsys fs.FS, source, destination string) error {
 	// Ensure the destination directory exists
 	if err := ensureDirExists(destination); err != nil {
 		return err
 	}
 
 	// Read the source directory entries
-	entries, err := ReadEmbeddedDir(source)
+	entries, err := readFSDir(fsys, source)
 	if err != nil {
 		return err
 	}
 
 	for _, entry := range entries {
-		srcPath := filepath.Join(source, entry.Name())
+		srcPath := path.Join(source, entry.Name())
 		destPath := filepath.Join(destination, entry.Name())
 
 		if entry.IsDir() {
 			// Recursively copy subdirectories
-			if err := CopyDirFromEmbed(srcPath, destPath); err != nil {
+			if err := CopyDirFromFS(fsys, srcPath, destPath); err != nil {
 				return err
 			}
 		} else {
 			// Copy individual files
-			if err := CopyFileFromEmbed(srcPath, destPath); err != nil {
+			if err := CopyFileFromFS(fsys, srcPath, destPath); err != nil {
 				return err
 			}
 		}
@@ -95,13 +112,18 @@ var CopyFileFromEmbedFunc = CopyFileFromEmbed
 
 // CopyFileFromEmbed copies a single file from an embedded source to a destination file path.
 func CopyFileFromEmbed(source, destination string) error {
+	return CopyFileFromFS(embeddedFiles, source, destination)
+}
+
+// CopyFileFromFS copies the source file of fsys to a destination file path.
+func CopyFileFromFS(fsys fs.FS, source, destination string) error {
 	// Ensure the destination directory exists
 	if err := ensureDirExists(filepath.Dir(destination)); err != nil {
 		return err
 	}
 
-	// Read the embedded file content
-	content, err := ReadEmbeddedFile(source)
+	// Read the source file content
+	content, err := readFSFile(fsys, source)
 	if err != nil {
 		return err
 	}
@@ -90,7 +90,7 @@ func TestReadEmbeddedDir(t *testing.T) {
 			name:    "Valid embedded directory",
 			path:    "component",
 			wantErr: false,
-			want:    []string{"assets", "templ"},
+			want:    []string{"assets", "docs", "templ"},
 		},
 		{
 			name:    "Valid embedded directory",
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+// DefaultFileMode is the mode of the files created by tempo when none is configured.
+const DefaultFileMode os.FileMode = 0600
+
+// FileModePolicy selects the permissions of the files written by tempo. Modes are
+// requested when a file is created, so the process umask still applies. A nil policy
+// creates files with DefaultFileMode and keeps the mode of existing files.
+type FileModePolicy struct {
+	Default          os.FileMode            // Mode of new files (0 for DefaultFileMode)
+	ByExtension      map[string]os.FileMode // Mode of new files per extension (e.g. ".templ"), over Default
+	PreserveExisting bool                   // Keep the mode and owner of overwritten files
+}
+
+// ParseFileMode parses an octal permission mode such as "0644", "644" or "0o664".
+// An empty value returns 0.
+func ParseFileMode(value string) (os.FileMode, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	mode, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(value), "0o"), 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("invalid file mode %q (expected octal permissions, e.g. 0644)", value)
+	}
+	return os.FileMode(mode), nil
+}
+
+// NewFileModePolicy parses the default mode and the modes per extension of a policy.
+// Extensions are matched case-insensitively, with or without the leading dot.
+func NewFileModePolicy(defaultMode string, byExtension map[string]string, preserveExisting bool) (*FileModePolicy, error) {
+	mode, err := ParseFileMode(defaultMode)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &FileModePolicy{
+		Default:          mode,
+		ByExtension:      make(map[string]os.FileMode, len(byExtension)),
+		PreserveExisting: preserveExisting,
+	}
+	for ext, value := range byExtension {
+		mode, err := ParseFileMode(value)
+		if err != nil {
+			return nil, apperrors.Wrap("invalid file mode for %s", err, ext)
+		}
+		policy.ByExtension[normalizeExtension(ext)] = mode
+	}
+	return policy, nil
+}
+
+// ModeFor returns the mode of a new file at path: override when set, then the mode
+// of the path extension, then the default one.
+func (p *FileModePolicy) ModeFor(path string, override os.FileMode) os.FileMode {
+	if override != 0 {
+		return override
+	}
+	if p == nil {
+		return DefaultFileMode
+	}
+	if mode, ok := p.ByExtension[normalizeExtension(filepath.Ext(path))]; ok && mode != 0 {
+		return mode
+	}
+	if p.Default != 0 {
+		return p.Default
+	}
+	return DefaultFileMode
+}
+
+// WriteFile writes content to path, ensuring its directory exists. New files are
+// created with ModeFor(path, override), narrowed by the umask. Existing files are
+// overwritten in place, keeping their mode and owner, unless the policy does not
+// preserve them: they are then replaced by a new file created with the policy mode.
+func (p *FileModePolicy) WriteFile(path string, content []byte, override os.FileMode) error {
+	return p.WriteFileFrom(path, bytes.NewReader(content), override)
+}
+
+// WriteFileFrom is WriteFile for a content streamed from r.
+func (p *FileModePolicy) WriteFileFrom(path string, r io.Reader, override os.FileMode) error {
+	if err := EnsureDirExists(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	if p != nil && !p.PreserveExisting {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return apperrors.Wrap("failed to replace file '%s'", err, path)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, p.ModeFor(path, override))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// normalizeExtension returns ext lowercased with a leading dot.
+func normalizeExtension(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFileMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    os.FileMode
+		wantErr bool
+	}{
+		{input: "", want: 0},
+		{input: "0644", want: 0o644},
+		{input: "664", want: 0o664},
+		{input: "0o600", want: 0o600},
+		{input: " 0755 ", want: 0o755},
+		{input: "0888", wantErr: true},
+		{input: "01777", wantErr: true},
+		{input: "rw-r--r--", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFileMode(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFileMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFileMode(%q) = %o, want %o", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFileModePolicy_ModeFor(t *testing.T) {
+	policy, err := NewFileModePolicy("0644", map[string]string{"templ": "0664", ".CSS": "0640"}, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		policy   *FileModePolicy
+		path     string
+		override os.FileMode
+		want     os.FileMode
+	}{
+		{policy: policy, path: "button.templ", want: 0o664},
+		{policy: policy, path: "base.css", want: 0o640},
+		{policy: policy, path: "main.go", want: 0o644},
+		{policy: policy, path: "button.templ", override: 0o600, want: 0o600},
+		{policy: &FileModePolicy{}, path: "main.go", want: DefaultFileMode},
+		{policy: nil, path: "main.go", want: DefaultFileMode},
+		{policy: nil, path: "main.go", override: 0o664, want: 0o664},
+	}
+
+	for _, tt := range tests {
+		if got := tt.policy.ModeFor(tt.path, tt.override); got != tt.want {
+			t.Errorf("ModeFor(%q, %o) = %o, want %o", tt.path, tt.override, got, tt.want)
+		}
+	}
+
+	if _, err := NewFileModePolicy("0644", map[string]string{".templ": "abc"}, true); err == nil {
+		t.Error("Expected an error for an invalid mode per extension")
+	}
+}
+
+func TestFileModePolicy_WriteFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// umaskedMode returns the mode of a file created with mode, narrowed by the umask.
+	umaskedMode := func(mode os.FileMode) os.FileMode {
+		probe := filepath.Join(t.TempDir(), "probe")
+		if err := os.WriteFile(probe, nil, mode); err != nil {
+			t.Fatalf("Failed to write probe file: %v", err)
+		}
+		info, err := os.Stat(probe)
+		if err != nil {
+			t.Fatalf("Failed to stat probe file: %v", err)
+		}
+		return info.Mode().Perm()
+	}
+	modeOf := func(path string) os.FileMode {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Failed to stat %s: %v", path, err)
+		}
+		return info.Mode().Perm()
+	}
+
+	t.Run("New file", func(t *testing.T) {
+		policy := &FileModePolicy{Default: 0o664, PreserveExisting: true}
+		path := filepath.Join(tempDir, "new", "button.templ")
+		if err := policy.WriteFile(path, []byte("content"), 0); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got, want := modeOf(path), umaskedMode(0o664); got != want {
+			t.Errorf("Expected mode %o, got %o", want, got)
+		}
+	})
+
+	t.Run("Preserve existing", func(t *testing.T) {
+		policy := &FileModePolicy{Default: 0o664, PreserveExisting: true}
+		path := filepath.Join(tempDir, "preserved.templ")
+		if err := os.WriteFile(path, []byte("old"), 0o600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if err := os.Chmod(path, 0o640); err != nil {
+			t.Fatalf("Failed to change mode: %v", err)
+		}
+
+		if err := policy.WriteFile(path, []byte("new"), 0); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := modeOf(path); got != 0o640 {
+			t.Errorf("Expected the existing mode 640 to be kept, got %o", got)
+		}
+	})
+
+	t.Run("Replace existing", func(t *testing.T) {
+		policy := &FileModePolicy{Default: 0o644}
+		path := filepath.Join(tempDir, "replaced.templ")
+		if err := os.WriteFile(path, []byte("old"), 0o600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+
+		if err := policy.WriteFile(path, []byte("new"), 0); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got, want := modeOf(path), umaskedMode(0o644); got != want {
+			t.Errorf("Expected mode %o, got %o", want, got)
+		}
+		if content, _ := os.ReadFile(path); string(content) != "new" {
+			t.Errorf("Expected the new content, got %q", content)
+		}
+	})
+}
@@ -0,0 +1,268 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"golang.org/x/mod/modfile"
+)
+
+var (
+	// ErrGoModNotFound is returned when no go.mod can be found for a directory.
+	ErrGoModNotFound = fmt.Errorf("missing go.mod file. Run 'go mod init' to create one")
+
+	// ErrAmbiguousWorkspace is returned when a go.work workspace has several modules
+	// and none of them contains the working directory.
+	ErrAmbiguousWorkspace = fmt.Errorf("multiple modules found in go.work")
+)
+
+// FindModuleRoot walks up from startDir and returns the directory of the nearest
+// enclosing go.mod file. It returns ErrGoModNotFound if there is none.
+func FindModuleRoot(startDir string) (string, error) {
+	dir, err := findUp(startDir, "go.mod")
+	if err != nil {
+		return "", err
+	}
+	if dir == "" {
+		return "", ErrGoModNotFound
+	}
+	return dir, nil
+}
+
+// FindGoWork walks up from startDir and returns the path of the nearest go.work file.
+// It returns an empty string if there is none.
+func FindGoWork(startDir string) (string, error) {
+	dir, err := findUp(startDir, "go.work")
+	if err != nil || dir == "" {
+		return "", err
+	}
+	return filepath.Join(dir, "go.work"), nil
+}
+
+// WorkspaceModules returns the absolute directories of the modules listed by the
+// `use` directives of a go.work file.
+func WorkspaceModules(goWorkPath string) ([]string, error) {
+	content, err := os.ReadFile(goWorkPath)
+	if err != nil {
+		return nil, apperrors.Wrap("error reading go.work file", err)
+	}
+
+	workFile, err := modfile.ParseWork(goWorkPath, content, nil)
+	if err != nil {
+		return nil, apperrors.Wrap("error parsing go.work file", err)
+	}
+
+	baseDir := filepath.Dir(goWorkPath)
+	modules := make([]string, 0, len(workFile.Use))
+	for _, use := range workFile.Use {
+		dir := use.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(baseDir, dir)
+		}
+		modules = append(modules, filepath.Clean(dir))
+	}
+	return modules, nil
+}
+
+// ResolveModuleRoot returns the root directory of the Go module tempo should work with.
+//
+// Resolution order:
+//  1. moduleRoot, when set (relative paths are resolved against startDir).
+//  2. The nearest go.mod found walking up from startDir.
+//  3. The single module of the nearest go.work workspace. ErrAmbiguousWorkspace is
+//     returned when the workspace has several modules.
+func ResolveModuleRoot(startDir, moduleRoot string) (string, error) {
+	if moduleRoot != "" {
+		if !filepath.IsAbs(moduleRoot) {
+			moduleRoot = filepath.Join(startDir, moduleRoot)
+		}
+		if _, err := os.Stat(filepath.Join(moduleRoot, "go.mod")); err != nil {
+			return "", apperrors.Wrap("no go.mod file found in module root", ErrGoModNotFound, moduleRoot)
+		}
+		return filepath.Clean(moduleRoot), nil
+	}
+
+	root, err := FindModuleRoot(startDir)
+	if err == nil {
+		return root, nil
+	}
+	if !errors.Is(err, ErrGoModNotFound) {
+		return "", err
+	}
+
+	goWork, err := FindGoWork(startDir)
+	if err != nil {
+		return "", err
+	}
+	if goWork == "" {
+		return "", ErrGoModNotFound
+	}
+
+	modules, err := WorkspaceModules(goWork)
+	if err != nil {
+		return "", err
+	}
+	switch len(modules) {
+	case 0:
+		return "", apperrors.Wrap("no modules listed in go.work", ErrGoModNotFound, goWork)
+	case 1:
+		return modules[0], nil
+	default:
+		return "", apperrors.Wrap(
+			"multiple modules found in go.work (%s). Use '--module-root' to select one",
+			ErrAmbiguousWorkspace, strings.Join(modules, ", "),
+		)
+	}
+}
+
+// ModuleNameCacheFile is the name of the file, in the user cache folder, persisting
+// the module names across runs.
+const ModuleNameCacheFile = "module-names.json"
+
+// ModuleNameCache caches the module names read from the go.mod files, keyed by module
+// root, so that commands run in tight loops do not parse go.mod on every call. An entry
+// is read again when the modification time or the size of its go.mod changes. With a
+// file, the entries are kept across runs. It is safe for concurrent use.
+type ModuleNameCache struct {
+	file    string // Persists the entries; memory only when empty
+	load    sync.Once
+	mu      sync.Mutex
+	entries map[string]moduleNameEntry
+}
+
+// moduleNameEntry is a module name along with the state of the go.mod it was read from.
+type moduleNameEntry struct {
+	Name    string    `json:"name"`
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+}
+
+// NewModuleNameCache creates an empty ModuleNameCache. Its entries are read from and
+// saved to file, unless file is empty.
+func NewModuleNameCache(file string) *ModuleNameCache {
+	return &ModuleNameCache{file: file, entries: make(map[string]moduleNameEntry)}
+}
+
+// DefaultModuleNameCachePath returns the path of the module names file in the user
+// cache folder, e.g. $XDG_CACHE_HOME/tempo/module-names.json.
+func DefaultModuleNameCachePath() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", apperrors.Wrap("failed to resolve user cache directory", err)
+	}
+	return filepath.Join(cacheDir, "tempo", ModuleNameCacheFile), nil
+}
+
+// Name returns the module name of the go.mod file in moduleRoot (see GetModuleName),
+// from the cache when go.mod did not change since it was read.
+func (c *ModuleNameCache) Name(moduleRoot string) (string, error) {
+	c.load.Do(c.read)
+
+	key := filepath.Clean(moduleRoot)
+	info, err := os.Stat(filepath.Join(key, "go.mod"))
+	if err != nil {
+		c.forget(key)
+		return "", apperrors.Wrap("error reading go.mod file", err)
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && entry.ModTime.Equal(info.ModTime()) && entry.Size == info.Size() {
+		return entry.Name, nil
+	}
+
+	name, err := GetModuleName(key)
+	if err != nil {
+		c.forget(key)
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = moduleNameEntry{Name: name, ModTime: info.ModTime(), Size: info.Size()}
+	c.mu.Unlock()
+	c.save()
+	return name, nil
+}
+
+// forget removes the entry of moduleRoot.
+func (c *ModuleNameCache) forget(moduleRoot string) {
+	c.mu.Lock()
+	_, ok := c.entries[moduleRoot]
+	delete(c.entries, moduleRoot)
+	c.mu.Unlock()
+	if ok {
+		c.save()
+	}
+}
+
+// read loads the entries saved in the cache file. A missing or unreadable file leaves
+// the cache empty: the names are read from go.mod again.
+func (c *ModuleNameCache) read() {
+	if c.file == "" {
+		return
+	}
+	content, err := os.ReadFile(c.file)
+	if err != nil {
+		return
+	}
+	var entries map[string]moduleNameEntry
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for root, entry := range entries {
+		if _, ok := c.entries[root]; !ok {
+			c.entries[root] = entry
+		}
+	}
+}
+
+// save writes the entries to the cache file. Failures are ignored: the next run reads
+// the names from go.mod again.
+func (c *ModuleNameCache) save() {
+	if c.file == "" {
+		return
+	}
+	c.mu.Lock()
+	content, err := json.Marshal(c.entries)
+	c.mu.Unlock()
+	if err != nil {
+		return
+	}
+	_ = WriteToFile(c.file, content)
+}
+
+// findUp walks up from startDir looking for a file with the given name and
+// returns the directory containing it, or an empty string if not found.
+func findUp(startDir, name string) (string, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", apperrors.Wrap("failed to resolve directory", err, startDir)
+	}
+
+	for {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err == nil && !info.IsDir() {
+			return dir, nil
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return "", apperrors.Wrap("error checking %s", err, filepath.Join(dir, name))
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
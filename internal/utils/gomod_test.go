@@ -0,0 +1,253 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setupMultiModuleRepo creates a repository with a go.work file listing two modules:
+//
+//	repo/go.work
+//	repo/api/go.mod        (example.com/api)
+//	repo/api/internal/x/
+//	repo/web/go.mod        (example.com/web)
+func setupMultiModuleRepo(t *testing.T, goWork string) string {
+	t.Helper()
+
+	repo := t.TempDir()
+	files := map[string]string{
+		"go.work":    goWork,
+		"api/go.mod": "module example.com/api\n\ngo 1.23\n",
+		"web/go.mod": "module example.com/web\n\ngo 1.23\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(repo, "api", "internal", "x"), 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	return repo
+}
+
+func TestFindModuleRoot(t *testing.T) {
+	repo := setupMultiModuleRepo(t, "go 1.23\n\nuse (\n\t./api\n\t./web\n)\n")
+
+	root, err := FindModuleRoot(filepath.Join(repo, "api", "internal", "x"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if root != filepath.Join(repo, "api") {
+		t.Errorf("Expected %q, got %q", filepath.Join(repo, "api"), root)
+	}
+
+	if _, err := FindModuleRoot(repo); !errors.Is(err, ErrGoModNotFound) {
+		t.Errorf("Expected ErrGoModNotFound, got %v", err)
+	}
+}
+
+func TestFindGoWorkAndWorkspaceModules(t *testing.T) {
+	repo := setupMultiModuleRepo(t, "go 1.23\n\nuse (\n\t./api\n\t./web\n)\n")
+
+	goWork, err := FindGoWork(filepath.Join(repo, "web"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if goWork != filepath.Join(repo, "go.work") {
+		t.Errorf("Expected %q, got %q", filepath.Join(repo, "go.work"), goWork)
+	}
+
+	modules, err := WorkspaceModules(goWork)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{filepath.Join(repo, "api"), filepath.Join(repo, "web")}
+	if !reflect.DeepEqual(modules, expected) {
+		t.Errorf("Expected %v, got %v", expected, modules)
+	}
+
+	if goWork, err := FindGoWork(t.TempDir()); err != nil || goWork != "" {
+		t.Errorf("Expected no go.work, got %q (err: %v)", goWork, err)
+	}
+}
+
+func TestWorkspaceModules_InvalidFile(t *testing.T) {
+	goWork := filepath.Join(t.TempDir(), "go.work")
+	if err := os.WriteFile(goWork, []byte("use (\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.work: %v", err)
+	}
+	if _, err := WorkspaceModules(goWork); err == nil {
+		t.Errorf("Expected a parse error")
+	}
+}
+
+func TestResolveModuleRoot(t *testing.T) {
+	multi := setupMultiModuleRepo(t, "go 1.23\n\nuse (\n\t./api\n\t./web\n)\n")
+	single := setupMultiModuleRepo(t, "go 1.23\n\nuse ./web\n")
+
+	tests := []struct {
+		name       string
+		startDir   string
+		moduleRoot string
+		expected   string
+		expectErr  error
+	}{
+		{name: "Nearest go.mod from subdirectory", startDir: filepath.Join(multi, "api", "internal", "x"), expected: filepath.Join(multi, "api")},
+		{name: "Explicit relative module root", startDir: multi, moduleRoot: "web", expected: filepath.Join(multi, "web")},
+		{name: "Explicit absolute module root", startDir: t.TempDir(), moduleRoot: filepath.Join(multi, "api"), expected: filepath.Join(multi, "api")},
+		{name: "Explicit module root without go.mod", startDir: multi, moduleRoot: "missing", expectErr: ErrGoModNotFound},
+		{name: "Single-module workspace", startDir: single, expected: filepath.Join(single, "web")},
+		{name: "Multi-module workspace", startDir: multi, expectErr: ErrAmbiguousWorkspace},
+		{name: "No module", startDir: t.TempDir(), expectErr: ErrGoModNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := ResolveModuleRoot(tt.startDir, tt.moduleRoot)
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) {
+					t.Fatalf("Expected error %v, got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if root != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, root)
+			}
+		})
+	}
+}
+
+func TestModuleNameCache_Name(t *testing.T) {
+	root := t.TempDir()
+	goMod := filepath.Join(root, "go.mod")
+	writeGoMod := func(t *testing.T, content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(goMod, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write go.mod: %v", err)
+		}
+		if err := os.Chtimes(goMod, modTime, modTime); err != nil {
+			t.Fatalf("Failed to change file times: %v", err)
+		}
+	}
+	modTime := time.Now().Add(-time.Hour)
+	writeGoMod(t, "module example.com/api\n", modTime)
+
+	cache := NewModuleNameCache("")
+	name, err := cache.Name(root)
+	if err != nil || name != "example.com/api" {
+		t.Fatalf("Expected example.com/api, got %q (err: %v)", name, err)
+	}
+
+	t.Run("Cached while go.mod is unchanged", func(t *testing.T) {
+		// Same size and time: the stale entry is served, proving go.mod is not parsed
+		writeGoMod(t, "module example.com/apx\n", modTime)
+		if name, err := cache.Name(root); err != nil || name != "example.com/api" {
+			t.Errorf("Expected the cached example.com/api, got %q (err: %v)", name, err)
+		}
+	})
+
+	t.Run("Invalidated when go.mod changes", func(t *testing.T) {
+		writeGoMod(t, "module example.com/web\n", modTime.Add(time.Minute))
+		if name, err := cache.Name(root); err != nil || name != "example.com/web" {
+			t.Errorf("Expected example.com/web, got %q (err: %v)", name, err)
+		}
+	})
+
+	t.Run("Missing go.mod", func(t *testing.T) {
+		if err := os.Remove(goMod); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cache.Name(root); err == nil {
+			t.Error("Expected an error for a missing go.mod")
+		}
+	})
+}
+
+func TestModuleNameCache_Persisted(t *testing.T) {
+	root := t.TempDir()
+	goMod := filepath.Join(root, "go.mod")
+	if err := os.WriteFile(goMod, []byte("module example.com/api\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	cacheFile := filepath.Join(t.TempDir(), "tempo", ModuleNameCacheFile)
+
+	if name, err := NewModuleNameCache(cacheFile).Name(root); err != nil || name != "example.com/api" {
+		t.Fatalf("Expected example.com/api, got %q (err: %v)", name, err)
+	}
+	if _, err := os.Stat(cacheFile); err != nil {
+		t.Fatalf("Expected the cache file to be written: %v", err)
+	}
+
+	// Same size and time: a new cache serves the saved entry, proving go.mod is not parsed
+	info, err := os.Stat(goMod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(goMod, []byte("module example.com/apx\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	if err := os.Chtimes(goMod, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("Failed to change file times: %v", err)
+	}
+	if name, err := NewModuleNameCache(cacheFile).Name(root); err != nil || name != "example.com/api" {
+		t.Errorf("Expected the saved example.com/api, got %q (err: %v)", name, err)
+	}
+
+	t.Run("Unreadable cache file", func(t *testing.T) {
+		if err := os.WriteFile(cacheFile, []byte("{"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if name, err := NewModuleNameCache(cacheFile).Name(root); err != nil || name != "example.com/apx" {
+			t.Errorf("Expected example.com/apx read from go.mod, got %q (err: %v)", name, err)
+		}
+	})
+}
+
+// BenchmarkModuleName compares reading the module name from go.mod with reading it from
+// the cache file, as each new tempo run does.
+func BenchmarkModuleName(b *testing.B) {
+	root := b.TempDir()
+	var goMod strings.Builder
+	goMod.WriteString("module example.com/app\n\ngo 1.23\n\nrequire (\n")
+	for i := range 100 {
+		fmt.Fprintf(&goMod, "\texample.com/dep%d v1.%d.0\n", i, i)
+	}
+	goMod.WriteString(")\n")
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(goMod.String()), 0644); err != nil {
+		b.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	b.Run("go.mod", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := GetModuleName(root); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cache file", func(b *testing.B) {
+		cacheFile := filepath.Join(b.TempDir(), ModuleNameCacheFile)
+		if _, err := NewModuleNameCache(cacheFile).Name(root); err != nil {
+			b.Fatal(err)
+		}
+		for b.Loop() {
+			if _, err := NewModuleNameCache(cacheFile).Name(root); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
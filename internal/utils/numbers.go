@@ -3,11 +3,84 @@ package utils
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// byteUnits maps the size suffixes accepted by ParseBytes to their multiplier.
+var byteUnits = map[string]float64{
+	"":   1,
+	"B":  1,
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+}
+
+// durationUnits maps the day and week suffixes accepted by ParseDuration to their length.
+var durationUnits = map[string]time.Duration{
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
 func Int64ToInt(i64 int64) (int, error) {
 	if i64 > math.MaxInt || i64 < math.MinInt {
 		return 0, fmt.Errorf("int64 value %d overflows int", i64)
 	}
 	return int(i64), nil
 }
+
+// FormatBytes returns a human-readable representation of a size in bytes (e.g. "1.5 KB").
+func FormatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// ParseBytes parses a human-readable size (e.g. "512", "100KB", "1.5 MB") into bytes.
+// Units are case-insensitive and use powers of 1024, as FormatBytes does.
+func ParseBytes(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	number := strings.TrimRight(value, "KMGB")
+	unit := strings.TrimSpace(value[len(number):])
+
+	multiplier, ok := byteUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit %q", unit)
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+	return int64(n * multiplier), nil
+}
+
+// ParseDuration parses a duration as time.ParseDuration does, also accepting a whole
+// number of days or weeks (e.g. "30d", "2w"). Negative durations are rejected.
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > 1 {
+		if unit, ok := durationUnits[value[len(value)-1:]]; ok {
+			n, err := strconv.Atoi(value[:len(value)-1])
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return d, nil
+}
@@ -17,25 +17,21 @@ import (
 
 var registerOnce sync.Once
 
+// parsedTemplates caches the parsed templates by content, for the registered functions
+// of parsedVersion.
+var (
+	parsedMu        sync.Mutex
+	parsedTemplates = map[string]*template.Template{}
+	parsedVersion   uint64
+)
+
 // RenderTemplate renders a template string with the provided data.
 //
 // This function combines the `text/template` package with additional
-// user registered functions to extend templating capabilities.
+// user registered functions to extend templating capabilities. The parsed
+// templates are cached, so rendering the same content again only executes it.
 func RenderTemplate(templateContent string, data any) (string, error) {
-	// Ensure all registered functions (default + user-defined) are available
-	registerOnce.Do(func() {
-		registry.RegisterFuncProvider(textprovider.Provider)
-		registry.RegisterFuncProvider(gonameprovider.Provider)
-		registry.RegisterFuncProvider(lookupprovider.Provider)
-	})
-
-	// Retrieve all registered functions, including user-defined ones
-	funcMap := registry.GetRegisteredFunctions()
-
-	tmpl, err := template.New("template").
-		Funcs(funcMap).
-		Option("missingkey=error").
-		Parse(templateContent)
+	tmpl, err := parseTemplate(templateContent)
 	if err != nil {
 		return "", apperrors.Wrap("failed to run RenderTemplate", err)
 	}
@@ -48,3 +44,42 @@ func RenderTemplate(templateContent string, data any) (string, error) {
 
 	return buf.String(), nil
 }
+
+// parseTemplate returns the parsed template for content, from the cache unless
+// functions were registered since it was parsed.
+func parseTemplate(content string) (*template.Template, error) {
+	funcMap := TemplateFuncs()
+
+	parsedMu.Lock()
+	defer parsedMu.Unlock()
+
+	if version := registry.Version(); version != parsedVersion {
+		clear(parsedTemplates)
+		parsedVersion = version
+	}
+	if tmpl, ok := parsedTemplates[content]; ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := template.New("template").
+		Funcs(funcMap).
+		Option("missingkey=error").
+		Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	parsedTemplates[content] = tmpl
+	return tmpl, nil
+}
+
+// TemplateFuncs returns all registered template functions, including user-defined ones.
+// The built-in providers are registered on first use.
+func TemplateFuncs() template.FuncMap {
+	registerOnce.Do(func() {
+		registry.RegisterFuncProvider(textprovider.Provider)
+		registry.RegisterFuncProvider(gonameprovider.Provider)
+		registry.RegisterFuncProvider(lookupprovider.Provider)
+	})
+
+	return registry.GetRegisteredFunctions()
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"testing"
+
+	"github.com/indaco/tempo/internal/templatefuncs/registry"
 )
 
 func TestRenderTemplate(t *testing.T) {
@@ -166,3 +168,27 @@ func TestRenderTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderTemplate_ParsedTemplateCache(t *testing.T) {
+	content := "Hello, {{ .Name }}!"
+	for _, name := range []string{"World", "Gopher"} {
+		output, err := RenderTemplate(content, map[string]string{"Name": name})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if want := "Hello, " + name + "!"; output != want {
+			t.Errorf("Unexpected output:\nGot: %q\nWant: %q", output, want)
+		}
+	}
+
+	// Registering a function discards the templates parsed with the previous ones
+	content = "{{ cachedGreeting }}"
+	registry.RegisterFunction("cachedGreeting", func() string { return "first" })
+	if output, err := RenderTemplate(content, nil); err != nil || output != "first" {
+		t.Fatalf("Expected %q, got %q (err: %v)", "first", output, err)
+	}
+	registry.RegisterFunction("cachedGreeting", func() string { return "second" })
+	if output, err := RenderTemplate(content, nil); err != nil || output != "second" {
+		t.Errorf("Expected %q, got %q (err: %v)", "second", output, err)
+	}
+}
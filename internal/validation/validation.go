@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	apperrors "github.com/indaco/tempo/internal/apperrors"
@@ -18,6 +19,25 @@ var ErrPathTraversal = fmt.Errorf("path traversal detected")
 // ErrInvalidPath indicates an invalid path.
 var ErrInvalidPath = fmt.Errorf("invalid path")
 
+// ErrInvalidElementName indicates an invalid custom element tag name.
+var ErrInvalidElementName = fmt.Errorf("invalid custom element name")
+
+// customElementNamePattern matches valid custom element names: lowercase, starting
+// with a letter and containing at least one hyphen.
+var customElementNamePattern = regexp.MustCompile(`^[a-z][a-z0-9._]*(-[a-z0-9._]*)+$`)
+
+// reservedElementNames lists names reserved by the HTML specification.
+var reservedElementNames = map[string]bool{
+	"annotation-xml":   true,
+	"color-profile":    true,
+	"font-face":        true,
+	"font-face-src":    true,
+	"font-face-uri":    true,
+	"font-face-format": true,
+	"font-face-name":   true,
+	"missing-glyph":    true,
+}
+
 // allowedGitSchemes defines the allowed URL schemes for git operations.
 var allowedGitSchemes = map[string]bool{
 	"https": true,
@@ -130,3 +150,17 @@ func SanitizePath(path string) (string, error) {
 
 	return cleaned, nil
 }
+
+// ValidateCustomElementName ensures name is a valid custom element tag name.
+// Names must be lowercase, start with a letter, contain a hyphen and not be reserved.
+func ValidateCustomElementName(name string) error {
+	if !customElementNamePattern.MatchString(name) {
+		return apperrors.Wrap("custom element names must be lowercase, start with a letter and contain a hyphen", ErrInvalidElementName, name)
+	}
+
+	if reservedElementNames[name] {
+		return apperrors.Wrap("custom element name is reserved", ErrInvalidElementName, name)
+	}
+
+	return nil
+}
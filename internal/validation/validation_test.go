@@ -284,3 +284,36 @@ func TestSanitizePath(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCustomElementName(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		wantErr error
+	}{
+		{name: "simple hyphenated name", tag: "my-button", wantErr: nil},
+		{name: "multiple hyphens", tag: "x-date-picker", wantErr: nil},
+		{name: "digits and dots", tag: "app-card.v2", wantErr: nil},
+		{name: "empty name", tag: "", wantErr: ErrInvalidElementName},
+		{name: "missing hyphen", tag: "button", wantErr: ErrInvalidElementName},
+		{name: "uppercase letters", tag: "My-Button", wantErr: ErrInvalidElementName},
+		{name: "starts with digit", tag: "1-button", wantErr: ErrInvalidElementName},
+		{name: "starts with hyphen", tag: "-button", wantErr: ErrInvalidElementName},
+		{name: "reserved name", tag: "font-face", wantErr: ErrInvalidElementName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCustomElementName(tt.tag)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("ValidateCustomElementName(%q) error = %v, want nil", tt.tag, err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateCustomElementName(%q) error = %v, want %v", tt.tag, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Build metadata, set at link time, e.g.:
+//
+//	go build -ldflags "-X github.com/indaco/tempo/internal/version.commit=$(git rev-parse HEAD)"
+//
+// When unset, they fall back to the VCS information recorded by the Go toolchain.
+var (
+	commit = ""
+	date   = ""
+)
+
+// BuildInfo describes the running tempo binary.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// readBuildInfo is replaced in tests.
+var readBuildInfo = debug.ReadBuildInfo
+
+// GetBuildInfo returns the version and build metadata of the running binary. Unknown
+// values are reported as "unknown".
+func GetBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   GetVersion(),
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if bi, ok := readBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.Date == "":
+				info.Date = setting.Value
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.Date == "" {
+		info.Date = "unknown"
+	}
+	return info
+}
@@ -0,0 +1,78 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"golang.org/x/mod/semver"
+)
+
+// LatestReleaseURL is the GitHub API endpoint of the latest tempo release.
+const LatestReleaseURL = "https://api.github.com/repos/indaco/tempo/releases/latest"
+
+// checkTimeout bounds the request for the latest release.
+const checkTimeout = 10 * time.Second
+
+// UpdateCheck is the result of a check for a newer release.
+type UpdateCheck struct {
+	Current         string `json:"current"`
+	Latest          string `json:"latest"`
+	URL             string `json:"url,omitempty"`
+	UpdateAvailable bool   `json:"update_available"`
+}
+
+// CheckForUpdate queries the latest release at url (LatestReleaseURL in production) and
+// compares it with the current version.
+func CheckForUpdate(ctx context.Context, client *http.Client, url, current string) (UpdateCheck, error) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return UpdateCheck{}, apperrors.Wrap("failed to create release request", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return UpdateCheck{}, apperrors.Wrap("failed to query the latest release", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return UpdateCheck{}, apperrors.Wrap("failed to query the latest release: %s", resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return UpdateCheck{}, apperrors.Wrap("invalid latest release response", err)
+	}
+
+	latest := canonical(release.TagName)
+	if !semver.IsValid(latest) {
+		return UpdateCheck{}, apperrors.Wrap("invalid latest release version %s", release.TagName)
+	}
+
+	return UpdateCheck{
+		Current:         canonical(current),
+		Latest:          latest,
+		URL:             release.HTMLURL,
+		UpdateAvailable: semver.Compare(latest, canonical(current)) > 0,
+	}, nil
+}
+
+// canonical returns version with the "v" prefix expected by the semver package.
+func canonical(version string) string {
+	version = strings.TrimSpace(version)
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return version
+}
@@ -0,0 +1,77 @@
+package version
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckForUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		current   string
+		want      UpdateCheck
+		expectErr bool
+	}{
+		{
+			name:    "Newer release",
+			status:  http.StatusOK,
+			body:    `{"tag_name": "v0.4.0", "html_url": "https://github.com/indaco/tempo/releases/tag/v0.4.0"}`,
+			current: "0.3.0",
+			want: UpdateCheck{
+				Current:         "v0.3.0",
+				Latest:          "v0.4.0",
+				URL:             "https://github.com/indaco/tempo/releases/tag/v0.4.0",
+				UpdateAvailable: true,
+			},
+		},
+		{
+			name:    "Up to date",
+			status:  http.StatusOK,
+			body:    `{"tag_name": "0.3.0"}`,
+			current: "0.3.0",
+			want:    UpdateCheck{Current: "v0.3.0", Latest: "v0.3.0"},
+		},
+		{
+			name:      "Error status",
+			status:    http.StatusForbidden,
+			body:      `{"message": "rate limited"}`,
+			current:   "0.3.0",
+			expectErr: true,
+		},
+		{
+			name:      "Invalid tag",
+			status:    http.StatusOK,
+			body:      `{"tag_name": "latest"}`,
+			current:   "0.3.0",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			got, err := CheckForUpdate(context.Background(), server.Client(), server.URL, tt.current)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("Expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CheckForUpdate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
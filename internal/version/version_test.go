@@ -1,6 +1,8 @@
 package version
 
 import (
+	"runtime"
+	"runtime/debug"
 	"testing"
 )
 
@@ -13,3 +15,43 @@ func TestGetVersion(t *testing.T) {
 		t.Errorf("GetVersion() = %q; want %q", got, expectedVersion)
 	}
 }
+
+func TestGetBuildInfo(t *testing.T) {
+	origRead := readBuildInfo
+	defer func() { readBuildInfo = origRead }()
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "abc123"},
+			{Key: "vcs.time", Value: "2025-01-02T03:04:05Z"},
+		}}, true
+	}
+
+	t.Run("VCS fallback", func(t *testing.T) {
+		info := GetBuildInfo()
+		if info.Version != GetVersion() || info.Commit != "abc123" || info.Date != "2025-01-02T03:04:05Z" {
+			t.Errorf("Unexpected build info: %+v", info)
+		}
+		if info.GoVersion != runtime.Version() || info.Platform != runtime.GOOS+"/"+runtime.GOARCH {
+			t.Errorf("Unexpected runtime info: %+v", info)
+		}
+	})
+
+	t.Run("Link time values", func(t *testing.T) {
+		commit, date = "def456", "2025-06-07"
+		defer func() { commit, date = "", "" }()
+
+		info := GetBuildInfo()
+		if info.Commit != "def456" || info.Date != "2025-06-07" {
+			t.Errorf("Expected the link time values, got %+v", info)
+		}
+	})
+
+	t.Run("Unknown values", func(t *testing.T) {
+		readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+
+		info := GetBuildInfo()
+		if info.Commit != "unknown" || info.Date != "unknown" {
+			t.Errorf("Expected unknown commit and date, got %+v", info)
+		}
+	})
+}
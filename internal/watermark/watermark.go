@@ -0,0 +1,157 @@
+// Package watermark renders the optional comment added to generated files and to
+// the content injected by `tempo sync`.
+package watermark
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/version"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Data is the data available to the watermark template.
+//
+// Fields:
+// - ComponentName: The name of the component the file belongs to.
+// - Date: The generation date (YYYY-MM-DD), the asset modification date when synced.
+// - TempoVersion: The version of tempo generating the file.
+// - UserData: The user-defined data from the templates.user_data config.
+type Data struct {
+	ComponentName string
+	Date          string
+	TempoVersion  string
+	UserData      map[string]any
+}
+
+// Watermark renders a watermark template. A nil Watermark is disabled and renders nothing.
+type Watermark struct {
+	template string
+	userData map[string]any
+	date     string
+}
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTOR                                                               */
+/* ------------------------------------------------------------------------- */
+
+// New creates a Watermark from the given template. It returns nil when the template
+// is empty, and an error when it cannot be rendered with the given user data.
+func New(tmpl string, userData map[string]any) (*Watermark, error) {
+	if strings.TrimSpace(tmpl) == "" {
+		return nil, nil
+	}
+
+	w := &Watermark{
+		template: tmpl,
+		userData: userData,
+		date:     time.Now().Format(time.DateOnly),
+	}
+
+	// Render once to report template errors before any file is written
+	if _, err := w.Render("component"); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// Render returns the watermark text for the given component, dated today.
+func (w *Watermark) Render(componentName string) (string, error) {
+	if w == nil {
+		return "", nil
+	}
+	return w.render(componentName, w.date)
+}
+
+// Comment returns the watermark for the given component, formatted as a comment
+// for the file type of filePath. It returns an empty string when the watermark is
+// disabled or the file type has no known comment syntax.
+func (w *Watermark) Comment(componentName, filePath string) (string, error) {
+	text, err := w.Render(componentName)
+	if err != nil || text == "" {
+		return "", err
+	}
+	return FormatComment(text, filePath), nil
+}
+
+// CommentAt is like Comment, with the date of the watermark taken from date instead of
+// today. Sync dates the injected content with the modification time of the asset, so
+// that re-running it on another day does not rewrite unchanged outputs.
+func (w *Watermark) CommentAt(componentName, filePath string, date time.Time) (string, error) {
+	if w == nil {
+		return "", nil
+	}
+	text, err := w.render(componentName, date.Format(time.DateOnly))
+	if err != nil || text == "" {
+		return "", err
+	}
+	return FormatComment(text, filePath), nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* PRIVATE METHODS                                                           */
+/* ------------------------------------------------------------------------- */
+
+// render executes the watermark template with the given date.
+func (w *Watermark) render(componentName, date string) (string, error) {
+	text, err := utils.RenderTemplate(w.template, Data{
+		ComponentName: componentName,
+		Date:          date,
+		TempoVersion:  version.GetVersion(),
+		UserData:      w.userData,
+	})
+	if err != nil {
+		return "", apperrors.Wrap("failed to render watermark template", err)
+	}
+	return strings.TrimSpace(text), nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// FormatComment wraps text in the comment syntax of the file type of filePath:
+//   - .css, .js, .mjs, .ts: /* ... */
+//   - .go, .templ: // ...
+//   - .html: <!-- ... -->
+//
+// Other file types return an empty string.
+func FormatComment(text, filePath string) string {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".css", ".js", ".mjs", ".ts":
+		text = strings.ReplaceAll(text, "*/", "* /")
+		if !strings.Contains(text, "\n") {
+			return "/* " + text + " */"
+		}
+		return "/*\n" + text + "\n*/"
+	case ".go", ".templ":
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("// "+line, " ")
+		}
+		return strings.Join(lines, "\n")
+	case ".html":
+		return "<!-- " + strings.ReplaceAll(text, "-->", "-- >") + " -->"
+	default:
+		return ""
+	}
+}
+
+// Prepend adds the comment on top of content. Content is returned unchanged when
+// the comment is empty.
+func Prepend(content, comment string) string {
+	if comment == "" {
+		return content
+	}
+	return comment + "\n" + content
+}
@@ -0,0 +1,129 @@
+package watermark
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/indaco/tempo/internal/version"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("empty template disables the watermark", func(t *testing.T) {
+		w, err := New("  ", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if w != nil {
+			t.Fatalf("Expected a nil watermark, got %+v", w)
+		}
+
+		text, err := w.Render("button")
+		if err != nil || text != "" {
+			t.Errorf("Expected empty text from a nil watermark, got %q (err: %v)", text, err)
+		}
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		if _, err := New("{{ .Missing", nil); err == nil {
+			t.Errorf("Expected a parse error")
+		}
+	})
+
+	t.Run("missing user data key", func(t *testing.T) {
+		if _, err := New("{{ .UserData.author }}", map[string]any{}); err == nil {
+			t.Errorf("Expected an error for a missing user data key")
+		}
+	})
+}
+
+func TestWatermark_Render(t *testing.T) {
+	w, err := New("{{ .ComponentName }} by {{ .UserData.author }} - tempo v{{ .TempoVersion }} ({{ .Date }})\n", map[string]any{"author": "Jane"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	text, err := w.Render("button")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "button by Jane - tempo v" + version.GetVersion() + " (" + time.Now().Format(time.DateOnly) + ")"
+	if text != expected {
+		t.Errorf("Expected %q, got %q", expected, text)
+	}
+}
+
+func TestWatermark_Comment(t *testing.T) {
+	w, err := New("Generated for {{ .ComponentName }}", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	comment, err := w.Comment("button", "button.css")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if comment != "/* Generated for button */" {
+		t.Errorf("Unexpected comment: %q", comment)
+	}
+
+	comment, err = w.Comment("button", "button.txt")
+	if err != nil || comment != "" {
+		t.Errorf("Expected no comment for unsupported file type, got %q (err: %v)", comment, err)
+	}
+}
+
+func TestWatermark_CommentAt(t *testing.T) {
+	w, err := New("{{ .ComponentName }} ({{ .Date }})", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	comment, err := w.CommentAt("button", "button.css", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if comment != "/* button (2024-03-01) */" {
+		t.Errorf("Unexpected comment: %q", comment)
+	}
+
+	var disabled *Watermark
+	if comment, err := disabled.CommentAt("button", "button.css", time.Now()); err != nil || comment != "" {
+		t.Errorf("Expected no comment from a nil watermark, got %q (err: %v)", comment, err)
+	}
+}
+
+func TestFormatComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		filePath string
+		expected string
+	}{
+		{name: "CSS single line", text: "tempo", filePath: "a.css", expected: "/* tempo */"},
+		{name: "JS multi line", text: "tempo\nauthor", filePath: "a.js", expected: "/*\ntempo\nauthor\n*/"},
+		{name: "Comment terminator", text: "a */ b", filePath: "a.css", expected: "/* a * / b */"},
+		{name: "Templ", text: "tempo\n\nauthor", filePath: "a.templ", expected: "// tempo\n//\n// author"},
+		{name: "Go upper case extension", text: "tempo", filePath: "A.GO", expected: "// tempo"},
+		{name: "HTML", text: "tempo", filePath: "a.html", expected: "<!-- tempo -->"},
+		{name: "Unsupported", text: "tempo", filePath: "a.json", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatComment(tt.text, tt.filePath); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPrepend(t *testing.T) {
+	if got := Prepend("content", ""); got != "content" {
+		t.Errorf("Expected unchanged content, got %q", got)
+	}
+	if got := Prepend("content", "// tempo"); !strings.HasPrefix(got, "// tempo\ncontent") {
+		t.Errorf("Expected comment on top, got %q", got)
+	}
+}
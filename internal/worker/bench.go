@@ -0,0 +1,192 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+// Default values of a BenchOptions.
+const (
+	DefaultBenchFiles    = 500
+	DefaultBenchFileSize = 4 * 1024
+)
+
+// benchFilesPerComponent is the number of asset files of each synthetic component.
+const benchFilesPerComponent = 10
+
+// BenchOptions configures a run of the worker pool on a synthetic asset tree.
+type BenchOptions struct {
+	Files      int    // Number of asset files, half CSS and half JS (default: DefaultBenchFiles)
+	FileSize   int64  // Minimum size of each asset file in bytes (default: DefaultBenchFileSize)
+	Workers    int    // Number of concurrent workers (default: runtime.NumCPU() * 2)
+	Production bool   // Minify the injected content, as `--prod` does
+	MarkerName string // Guard marker of the .templ files (default: "tempo")
+}
+
+// BenchResult reports the throughput of a benchmark run.
+type BenchResult struct {
+	Files    int           `json:"files"`
+	Bytes    int64         `json:"bytes"`
+	Workers  int           `json:"workers"`
+	Failed   int           `json:"failed"`
+	Skipped  int           `json:"skipped"`
+	Duration time.Duration `json:"duration"`
+}
+
+// FilesPerSecond returns the number of files processed per second.
+func (r BenchResult) FilesPerSecond() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Files) / r.Duration.Seconds()
+}
+
+// BytesPerSecond returns the number of asset bytes processed per second.
+func (r BenchResult) BytesPerSecond() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Bytes) / r.Duration.Seconds()
+}
+
+// GenerateBenchTree writes a synthetic asset tree in dir: an "assets" folder of CSS and
+// JS files spread over components, and a "components" folder with the matching .templ
+// files. It returns the jobs processing the tree.
+func GenerateBenchTree(dir string, opts BenchOptions) ([]Job, error) {
+	opts = opts.withDefaults()
+	inputDir := filepath.Join(dir, "assets")
+	outputDir := filepath.Join(dir, "components")
+
+	css := benchContent(".bench-%d { color: #333; padding: 4px 8px; margin: 0 auto; }\n", opts.FileSize)
+	js := benchContent("document.querySelectorAll('.bench-%d').forEach((el) => el.classList.add('ready'));\n", opts.FileSize)
+	templ := []byte(fmt.Sprintf("package bench\n\n/* [%[1]s] BEGIN - Do not edit! This section is auto-generated. */\n/* [%[1]s] END */\n", opts.MarkerName))
+
+	jobs := make([]Job, 0, opts.Files)
+	for i := range opts.Files {
+		component := fmt.Sprintf("component%04d", i/benchFilesPerComponent)
+		name, content := fmt.Sprintf("style%d.css", i), css
+		if i%2 == 1 {
+			name, content = fmt.Sprintf("script%d.js", i), js
+		}
+
+		input := filepath.Join(inputDir, component, name)
+		output := filepath.Join(outputDir, component, strings.TrimSuffix(name, filepath.Ext(name))+".templ")
+		for path, data := range map[string][]byte{input: content, output: templ} {
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return nil, apperrors.Wrap("failed to create benchmark folder", err, filepath.Dir(path))
+			}
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				return nil, apperrors.Wrap("failed to write benchmark file", err, path)
+			}
+		}
+		jobs = append(jobs, Job{InputPath: input, OutputPath: output})
+	}
+	return jobs, nil
+}
+
+// RunBench generates a synthetic asset tree in dir (see GenerateBenchTree) and measures
+// the time taken by the worker pool to process it. The generation is not measured.
+func RunBench(ctx context.Context, dir string, opts BenchOptions) (BenchResult, error) {
+	opts = opts.withDefaults()
+	jobs, err := GenerateBenchTree(dir, opts)
+	if err != nil {
+		return BenchResult{}, err
+	}
+
+	poolOpts, err := NewWorkerPoolOptions(ctx, filepath.Join(dir, "assets"), filepath.Join(dir, "components"),
+		WithMarkerName(opts.MarkerName),
+		WithProduction(opts.Production),
+		WithForce(true),
+	)
+	if err != nil {
+		return BenchResult{}, err
+	}
+	if opts.Workers > 0 {
+		poolOpts.NumWorkers = opts.Workers
+	}
+
+	return runBenchJobs(ctx, poolOpts, jobs)
+}
+
+// runBenchJobs processes the jobs with a new worker pool and reports the throughput.
+func runBenchJobs(ctx context.Context, poolOpts WorkerPoolOptions, jobs []Job) (BenchResult, error) {
+	manager := NewWorkerPoolManager(poolOpts)
+	result := BenchResult{Workers: poolOpts.NumWorkers}
+
+	var collector sync.WaitGroup
+	collector.Add(2)
+	go func() {
+		defer collector.Done()
+		for range manager.ErrorsChan {
+			result.Failed++
+		}
+	}()
+	go func() {
+		defer collector.Done()
+		for range manager.SkippedChan {
+			result.Skipped++
+		}
+	}()
+
+	start := time.Now()
+	go func() {
+		defer close(manager.JobChan)
+		for _, job := range jobs {
+			select {
+			case manager.JobChan <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	workersErr := manager.StartWorkers(ctx, poolOpts.NumWorkers, false)
+	result.Duration = time.Since(start)
+
+	close(manager.ErrorsChan)
+	close(manager.SkippedChan)
+	collector.Wait()
+
+	result.Files = len(manager.Metrics.ProcessedFiles)
+	for _, path := range manager.Metrics.ProcessedFiles {
+		if info, err := os.Stat(path); err == nil {
+			result.Bytes += info.Size()
+		}
+	}
+	if workersErr != nil {
+		return result, apperrors.Wrap("benchmark stopped before processing all files", workersErr)
+	}
+	return result, ctx.Err()
+}
+
+// withDefaults returns the options with the defaults of the unset values.
+func (o BenchOptions) withDefaults() BenchOptions {
+	if o.Files <= 0 {
+		o.Files = DefaultBenchFiles
+	}
+	if o.FileSize <= 0 {
+		o.FileSize = DefaultBenchFileSize
+	}
+	if o.MarkerName == "" {
+		o.MarkerName = "tempo"
+	}
+	return o
+}
+
+// benchContent repeats the format, numbered from 0, until it reaches size bytes. Whole
+// lines are kept so that the content stays valid CSS or JS.
+func benchContent(format string, size int64) []byte {
+	var sb strings.Builder
+	sb.Grow(int(size))
+	for i := 0; int64(sb.Len()) < size; i++ {
+		fmt.Fprintf(&sb, format, i)
+	}
+	return []byte(sb.String())
+}
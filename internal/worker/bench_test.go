@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/indaco/tempo/internal/processor"
@@ -185,3 +186,73 @@ func BenchmarkWorkerPool_SchedulingOverhead(b *testing.B) {
 		close(manager.SkippedChan)
 	}
 }
+
+// BenchmarkWorkerPool_SyntheticTree runs the `tempo sync --bench` harness: the worker
+// pool processes a synthetic tree of CSS and JS assets, reporting the throughput.
+func BenchmarkWorkerPool_SyntheticTree(b *testing.B) {
+	ctx := context.Background()
+	dir := b.TempDir()
+
+	jobs, err := GenerateBenchTree(dir, BenchOptions{Files: 200})
+	if err != nil {
+		b.Fatalf("failed to generate the benchmark tree: %v", err)
+	}
+	opts, err := NewWorkerPoolOptions(ctx, filepath.Join(dir, "assets"), filepath.Join(dir, "components"),
+		WithMarkerName("tempo"),
+		WithNumWorkers(4),
+	)
+	if err != nil {
+		b.Fatalf("failed to create the worker pool options: %v", err)
+	}
+
+	var files int
+	var bytes int64
+	b.ResetTimer()
+	for b.Loop() {
+		result, err := runBenchJobs(ctx, opts, jobs)
+		if err != nil || result.Failed > 0 {
+			b.Fatalf("benchmark run failed: %v (%d failed files)", err, result.Failed)
+		}
+		files += result.Files
+		bytes += result.Bytes
+	}
+
+	b.ReportMetric(float64(files)/b.Elapsed().Seconds(), "files/s")
+	b.SetBytes(bytes / int64(b.N))
+}
+
+func TestRunBench(t *testing.T) {
+	dir := t.TempDir()
+
+	result, err := RunBench(context.Background(), dir, BenchOptions{Files: 25, FileSize: 512, Workers: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Files != 25 || result.Failed != 0 || result.Skipped != 0 || result.Workers != 2 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if result.Bytes < 25*512 {
+		t.Errorf("Expected at least %d bytes, got %d", 25*512, result.Bytes)
+	}
+	if result.Duration <= 0 || result.FilesPerSecond() <= 0 || result.BytesPerSecond() <= 0 {
+		t.Errorf("Expected a positive throughput, got %+v", result)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "components", "component0000", "style0.templ"))
+	if err != nil {
+		t.Fatalf("Failed to read the synced templ file: %v", err)
+	}
+	if !strings.Contains(string(content), ".bench-0 {") {
+		t.Errorf("Expected the CSS to be injected, got:\n%s", content)
+	}
+}
+
+func TestRunBench_Production(t *testing.T) {
+	result, err := RunBench(context.Background(), t.TempDir(), BenchOptions{Files: 4, FileSize: 256, Production: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Files != 4 || result.Failed != 0 {
+		t.Errorf("Expected the minified assets to be processed, got %+v", result)
+	}
+}
@@ -0,0 +1,153 @@
+package worker
+
+import (
+	"cmp"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// Actions on an exceeded asset budget.
+const (
+	BudgetWarn  = "warn"
+	BudgetError = "error"
+)
+
+// BudgetActions lists the supported actions on an exceeded asset budget.
+var BudgetActions = []string{BudgetWarn, BudgetError}
+
+// AssetBudget limits the total size of the CSS and JS files of each component, that is
+// of each top-level folder of the input folder.
+type AssetBudget struct {
+	CSS  int64 // Bytes; 0 for no limit
+	JS   int64
+	Fail bool // An exceeded budget fails the sync instead of warning
+}
+
+// BudgetViolation is a component whose CSS or JS files exceed their budget.
+type BudgetViolation struct {
+	Component string `json:"component"`
+	Kind      string `json:"kind"` // css or js
+	Size      int64  `json:"size"`
+	Limit     int64  `json:"limit"`
+}
+
+// ParseAssetBudget parses the budgets, human-readable sizes such as "50KB", and the
+// action on an exceeded budget (warn when empty). It returns nil when no budget is set.
+func ParseAssetBudget(css, js, onExceed string) (*AssetBudget, error) {
+	if css == "" && js == "" {
+		return nil, nil
+	}
+
+	budget := &AssetBudget{}
+	for _, limit := range []struct {
+		name  string
+		value string
+		dest  *int64
+	}{
+		{"css", css, &budget.CSS},
+		{"js", js, &budget.JS},
+	} {
+		if limit.value == "" {
+			continue
+		}
+		size, err := utils.ParseBytes(limit.value)
+		if err != nil {
+			return nil, apperrors.Wrap("invalid processor.budgets.%s", err, limit.name)
+		}
+		*limit.dest = size
+	}
+
+	switch onExceed {
+	case "", BudgetWarn:
+	case BudgetError:
+		budget.Fail = true
+	default:
+		return nil, apperrors.Wrap("invalid processor.budgets.on_exceed '%s': expected one of %s", onExceed, strings.Join(BudgetActions, ", "))
+	}
+	return budget, nil
+}
+
+// Check returns the components of the input folder of opts whose CSS or JS files exceed
+// the budget, sorted by component. The files of the input folder itself, the hidden
+// folders and the node_modules folders are left out.
+func (b *AssetBudget) Check(opts WorkerPoolOptions) ([]BudgetViolation, error) {
+	sizes := make(map[string]map[string]int64)
+	walkFn := func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Reported by the sync
+		}
+		if d.IsDir() {
+			if path != opts.InputDir && (d.Name() == "node_modules" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		var kind string
+		switch processor.GetLoader(filepath.Ext(path)) {
+		case api.LoaderCSS:
+			kind = "css"
+		case api.LoaderJS:
+			kind = "js"
+		default:
+			return nil
+		}
+		component := budgetComponent(opts.InputDir, path)
+		if component == "" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return apperrors.Wrap("failed to read file info", err, path)
+		}
+		if sizes[component] == nil {
+			sizes[component] = make(map[string]int64)
+		}
+		sizes[component][kind] += info.Size()
+		return nil
+	}
+
+	var err error
+	if opts.InputFS != nil {
+		err = WalkInputFS(opts.InputFS, opts.InputDir, walkFn)
+	} else {
+		err = WalkInputDir(opts.InputDir, opts.SymlinkPolicy, walkFn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []BudgetViolation
+	for component, bySize := range sizes {
+		for kind, limit := range map[string]int64{"css": b.CSS, "js": b.JS} {
+			if size := bySize[kind]; limit > 0 && size > limit {
+				violations = append(violations, BudgetViolation{Component: component, Kind: kind, Size: size, Limit: limit})
+			}
+		}
+	}
+	slices.SortFunc(violations, func(a, b BudgetViolation) int {
+		return cmp.Or(cmp.Compare(a.Component, b.Component), cmp.Compare(a.Kind, b.Kind))
+	})
+	return violations, nil
+}
+
+// budgetComponent returns the top-level folder of inputDir holding path, or "" for a
+// file of inputDir itself.
+func budgetComponent(inputDir, path string) string {
+	rel, err := filepath.Rel(inputDir, path)
+	if err != nil {
+		return ""
+	}
+	component, _, found := strings.Cut(filepath.ToSlash(rel), "/")
+	if !found {
+		return ""
+	}
+	return component
+}
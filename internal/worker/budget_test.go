@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestParseAssetBudget(t *testing.T) {
+	tests := []struct {
+		name     string
+		css, js  string
+		onExceed string
+		want     *AssetBudget
+		wantErr  bool
+	}{
+		{name: "disabled", onExceed: "error"},
+		{name: "warn by default", css: "50KB", want: &AssetBudget{CSS: 50 * 1024}},
+		{name: "error", js: "1MB", onExceed: "error", want: &AssetBudget{JS: 1024 * 1024, Fail: true}},
+		{name: "invalid size", css: "lots", wantErr: true},
+		{name: "invalid action", css: "1KB", onExceed: "ignore", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAssetBudget(tt.css, tt.js, tt.onExceed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAssetBudget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				if got != nil && !tt.wantErr {
+					t.Errorf("Expected no budget, got %+v", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.want {
+				t.Errorf("ParseAssetBudget() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssetBudget_Check(t *testing.T) {
+	inputDir := t.TempDir()
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "css", "base.css"), strings.Repeat("a", 600))
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "css", "themes", "dark.css"), strings.Repeat("a", 600))
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "js", "script.js"), strings.Repeat("a", 100))
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "js", "node_modules", "dep", "index.js"), strings.Repeat("a", 5000))
+	testutils.CreateFile(t, filepath.Join(inputDir, "card", "js", "script.js"), strings.Repeat("a", 3000))
+	testutils.CreateFile(t, filepath.Join(inputDir, "global.css"), strings.Repeat("a", 5000))
+
+	budget := &AssetBudget{CSS: 1024, JS: 2048}
+	violations, err := budget.Check(WorkerPoolOptions{InputDir: inputDir})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []BudgetViolation{
+		{Component: "button", Kind: "css", Size: 1200, Limit: 1024},
+		{Component: "card", Kind: "js", Size: 3000, Limit: 2048},
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("Expected %d violations, got %+v", len(want), violations)
+	}
+	for i := range want {
+		if violations[i] != want[i] {
+			t.Errorf("Violation %d = %+v, want %+v", i, violations[i], want[i])
+		}
+	}
+}
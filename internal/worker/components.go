@@ -0,0 +1,20 @@
+package worker
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ComponentName returns the component of an input file: its top-level folder in
+// inputDir, or "" for a file of inputDir itself.
+func ComponentName(inputDir, path string) string {
+	rel, err := filepath.Rel(inputDir, path)
+	if err != nil {
+		return ""
+	}
+	first, _, found := strings.Cut(filepath.ToSlash(rel), "/")
+	if !found {
+		return ""
+	}
+	return first
+}
@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestComponentName(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{filepath.Join("assets", "button", "css", "base.css"), "button"},
+		{filepath.Join("assets", "button", "base.css"), "button"},
+		{filepath.Join("assets", "global.css"), ""},
+		{"assets", ""},
+	}
+	for _, tt := range tests {
+		if got := ComponentName("assets", tt.path); got != tt.want {
+			t.Errorf("ComponentName(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
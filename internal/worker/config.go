@@ -0,0 +1,197 @@
+package worker
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/plugin"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/tags"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/watermark"
+)
+
+/* ------------------------------------------------------------------------- */
+/* Options From The Configuration                                            */
+/* ------------------------------------------------------------------------- */
+
+// ConfigOverrides holds the sync settings given on top of the configuration, e.g. by
+// the command-line flags. Zero values fall back to the configuration.
+type ConfigOverrides struct {
+	Marker               string                      // Name of the guard markers (templates.guard_marker)
+	Symlinks             string                      // Symbolic links policy (processor.symlinks)
+	IOThrottle           string                      // Limit on the disk IO (processor.io_throttle)
+	Retries              *int                        // Retries of the transient failures (processor.retry.max_retries)
+	IncludeTags          []string                    // Only the components with one of these tags
+	ExcludeTags          []string                    // Leave out the components with one of these tags
+	OverwriteManualEdits bool                        // Overwrite the guard regions edited by hand, even with templates.manual_edits: refuse
+	OnManualEdit         func(outputFilePath string) // Called when a guard region edited by hand is overwritten
+}
+
+// ConfigOptions returns the worker pool options set by cfg and overrides: how the assets
+// are processed and how the .templ files are written. Every sync entry point builds its
+// options here, so that a setting can't be honored by one of them only. inputDir is the
+// folder of the assets, used to scope their CSS.
+func ConfigOptions(ctx context.Context, cfg *config.Config, inputDir string, overrides ConfigOverrides) ([]WorkerPoolOption, error) {
+	wm, err := watermark.New(cfg.Templates.Watermark, cfg.Templates.UserData)
+	if err != nil {
+		return nil, err
+	}
+
+	transformers, err := plugin.Transformers(ctx, cfg.Plugins)
+	if err != nil {
+		return nil, err
+	}
+
+	// Vendor prefixes are added after the plugins, on the final CSS
+	if prefixCfg := cfg.Processor.Autoprefixer; prefixCfg.Enabled {
+		autoprefixer, err := processor.NewAutoprefixer(ctx, processor.AutoprefixerOptions{
+			Browsers: prefixCfg.Browsers,
+			Command:  prefixCfg.Command,
+			Args:     prefixCfg.Args,
+		})
+		if err != nil {
+			return nil, err
+		}
+		transformers = append(transformers, autoprefixer)
+	}
+
+	var scoper *processor.CSSScoper
+	if scopedCfg := cfg.Processor.ScopedCSS; scopedCfg.Enabled {
+		if scoper, err = processor.NewCSSScoper(scopedCfg.Style, inputDir); err != nil {
+			return nil, err
+		}
+	}
+
+	guards, err := processor.NewInjectionGuards(processor.GuardOptions{
+		MaxCSSSize:        cfg.Processor.Guards.MaxCSSSize,
+		MaxJSSize:         cfg.Processor.Guards.MaxJSSize,
+		ForbiddenPatterns: cfg.Processor.Guards.ForbiddenPatterns,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	symlinkPolicy := cmp.Or(overrides.Symlinks, cfg.Processor.Symlinks, DefaultSymlinkPolicy)
+	if err := ValidateSymlinkPolicy(symlinkPolicy); err != nil {
+		return nil, err
+	}
+
+	fileModes, err := cfg.FileModes.Policy()
+	if err != nil {
+		return nil, err
+	}
+
+	outputRules, err := ConfigOutputRules(cfg.Processor.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	retryCfg := cfg.Processor.Retry
+	if overrides.Retries != nil {
+		retryCfg.MaxRetries = *overrides.Retries
+	}
+	backoff, maxBackoff, err := retryCfg.Delays()
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := newGuardChecksum(cfg.Templates.ManualEdits, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	ioThrottle, err := ParseIOThrottle(cmp.Or(overrides.IOThrottle, cfg.Processor.IOThrottle))
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := ConfigLanguages(cfg.Processor.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	skipper, err := ConfigInputSkipper(cfg.Processor.SkipInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	tagFilter, err := tags.NewFilter(cfg.Components.Tags, overrides.IncludeTags, overrides.ExcludeTags, gonameprovider.ToGoPackageName)
+	if err != nil {
+		return nil, err
+	}
+
+	jsInjection := cfg.Processor.JSInjection
+	return []WorkerPoolOption{
+		WithMarkerName(cmp.Or(overrides.Marker, cfg.Templates.GuardMarker, config.DefaultGuardMarkText)),
+		WithWatermark(wm),
+		WithTransformers(transformers),
+		WithScopedCSS(scoper),
+		WithGuards(guards),
+		WithJSInjector(processor.NewJSInjector(jsInjection.Escapes(), jsInjection.WrapIIFE)),
+		WithAssetLanguages(languages),
+		WithInputSkipper(skipper),
+		WithTags(tagFilter),
+		WithSymlinkPolicy(symlinkPolicy),
+		WithFileModes(fileModes),
+		WithOutputRules(outputRules),
+		WithRetry(RetryPolicy{MaxRetries: retryCfg.MaxRetries, Backoff: backoff, MaxBackoff: maxBackoff}),
+		WithChecksum(checksum),
+		WithIOThrottle(ioThrottle),
+	}, nil
+}
+
+// ConfigLanguages validates the asset languages of the configuration.
+func ConfigLanguages(cfgLanguages []config.Language) (processor.AssetLanguages, error) {
+	langs := make([]processor.AssetLanguage, 0, len(cfgLanguages))
+	for _, l := range cfgLanguages {
+		langs = append(langs, processor.AssetLanguage{Extension: l.Extension, Mode: l.Mode, Escape: l.Escape, Variable: l.Variable})
+	}
+	return processor.NewAssetLanguages(langs...)
+}
+
+// ConfigInputSkipper returns the detection of the minified and binary assets of the
+// configuration, or nil when disabled.
+func ConfigInputSkipper(cfg config.SkipInputs) (*InputSkipper, error) {
+	if !cfg.IsEnabled() {
+		return nil, nil
+	}
+	return NewInputSkipper(cfg.Patterns, cfg.Sniffs())
+}
+
+// ConfigOutputRules validates the output rules of the configuration.
+func ConfigOutputRules(cfgRules []config.OutputRule) ([]OutputRule, error) {
+	rules := make([]OutputRule, 0, len(cfgRules))
+	for _, r := range cfgRules {
+		rule, err := NewOutputRule(r.Input, r.Output)
+		if err == nil {
+			rule, err = rule.WithRegion(r.File, r.Region)
+		}
+		if err != nil {
+			return nil, apperrors.WrapCode(apperrors.CodeInvalidOutputRules, "invalid processor.outputs", err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// newGuardChecksum returns the checksum of the guard regions set by templates.manual_edits,
+// or nil when it is not set.
+func newGuardChecksum(policy string, overrides ConfigOverrides) (*processor.GuardChecksum, error) {
+	if policy == "" {
+		return nil, nil
+	}
+	if !slices.Contains(processor.ManualEditsPolicies, policy) {
+		return nil, apperrors.WrapCode(apperrors.CodeConfigInvalid, "invalid templates.manual_edits %q: expected one of %s",
+			policy, strings.Join(processor.ManualEditsPolicies, ", "))
+	}
+	return &processor.GuardChecksum{
+		Policy:    policy,
+		Overwrite: overrides.OverwriteManualEdits,
+		OnEdit:    overrides.OnManualEdit,
+	}, nil
+}
@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/processor"
+)
+
+func TestConfigOptions(t *testing.T) {
+	newOptions := func(t *testing.T, cfg *config.Config, overrides ConfigOverrides) WorkerPoolOptions {
+		t.Helper()
+		configOpts, err := ConfigOptions(context.Background(), cfg, t.TempDir(), overrides)
+		if err != nil {
+			t.Fatalf("ConfigOptions() returned an error: %v", err)
+		}
+		opts, err := NewWorkerPoolOptions(context.Background(), "input", "output", configOpts...)
+		if err != nil {
+			t.Fatalf("NewWorkerPoolOptions() returned an error: %v", err)
+		}
+		return opts
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		opts := newOptions(t, config.DefaultConfig(), ConfigOverrides{})
+		if opts.Checksum != nil || opts.IOThrottle != nil || opts.Tags != nil {
+			t.Errorf("Expected no checksum, IO throttle or tag filter, got %+v", opts)
+		}
+		if opts.MarkerName != config.DefaultGuardMarkText || opts.SymlinkPolicy != DefaultSymlinkPolicy {
+			t.Errorf("Expected the default marker and symlink policy, got %q and %q", opts.MarkerName, opts.SymlinkPolicy)
+		}
+	})
+
+	t.Run("configuration", func(t *testing.T) {
+		cfg := config.DefaultConfig()
+		cfg.Templates.ManualEdits = processor.ManualEditsRefuse
+		cfg.Processor.IOThrottle = "5MB/s"
+		cfg.Processor.Retry.MaxRetries = 2
+
+		opts := newOptions(t, cfg, ConfigOverrides{})
+		if opts.Checksum == nil || opts.Checksum.Policy != processor.ManualEditsRefuse || opts.Checksum.Overwrite {
+			t.Errorf("Expected a refusing checksum, got %+v", opts.Checksum)
+		}
+		if opts.IOThrottle == nil {
+			t.Error("Expected the IO throttle of the configuration")
+		}
+		if opts.Retry.MaxRetries != 2 {
+			t.Errorf("Expected 2 retries, got %d", opts.Retry.MaxRetries)
+		}
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		cfg := config.DefaultConfig()
+		cfg.Templates.ManualEdits = processor.ManualEditsRefuse
+		cfg.Processor.Retry.MaxRetries = 2
+		retries := 0
+
+		opts := newOptions(t, cfg, ConfigOverrides{
+			Marker:               "custom",
+			Symlinks:             SymlinkSkip,
+			Retries:              &retries,
+			IncludeTags:          []string{"forms"},
+			OverwriteManualEdits: true,
+		})
+		if opts.MarkerName != "custom" || opts.SymlinkPolicy != SymlinkSkip || opts.Retry.MaxRetries != 0 {
+			t.Errorf("Expected the overridden marker, symlink policy and retries, got %+v", opts)
+		}
+		if opts.Tags == nil {
+			t.Error("Expected a tag filter")
+		}
+		if opts.Checksum == nil || !opts.Checksum.Overwrite {
+			t.Errorf("Expected an overwriting checksum, got %+v", opts.Checksum)
+		}
+	})
+
+	t.Run("invalid manual edits policy", func(t *testing.T) {
+		cfg := config.DefaultConfig()
+		cfg.Templates.ManualEdits = "ignore"
+		if _, err := ConfigOptions(context.Background(), cfg, t.TempDir(), ConfigOverrides{}); err == nil {
+			t.Error("Expected an error for an invalid policy")
+		}
+	})
+}
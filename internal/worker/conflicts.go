@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"slices"
+
+	"github.com/indaco/tempo/internal/processor"
+)
+
+// FindOutputConflicts groups jobs by Target and returns the outputs matched by more
+// than one supported input file (e.g. base.css and base.js both targeting the default
+// region of base.templ), mapped to their sorted input paths. Jobs injecting into
+// distinct regions of the same file do not conflict. The files of the given asset
+// languages are supported along with CSS and JS. Processing these jobs concurrently
+// would race on the same .templ file, and the last one would overwrite the others.
+func FindOutputConflicts(jobs []Job, languages processor.AssetLanguages) map[string][]string {
+	byOutput := make(map[string][]string, len(jobs))
+	for _, job := range jobs {
+		if !languages.Supports(job.InputPath) {
+			continue // Skipped by the workers, cannot conflict
+		}
+		byOutput[job.Target()] = append(byOutput[job.Target()], job.InputPath)
+	}
+
+	conflicts := make(map[string][]string)
+	for output, inputs := range byOutput {
+		if len(inputs) > 1 {
+			slices.Sort(inputs)
+			conflicts[output] = inputs
+		}
+	}
+	return conflicts
+}
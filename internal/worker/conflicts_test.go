@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindOutputConflicts(t *testing.T) {
+	jobs := []Job{
+		{InputPath: "assets/button/base.js", OutputPath: "components/button/base.templ"},
+		{InputPath: "assets/button/base.css", OutputPath: "components/button/base.templ"},
+		{InputPath: "assets/button/base.md", OutputPath: "components/button/base.templ"},
+		{InputPath: "assets/card/base.css", OutputPath: "components/card/base.templ"},
+		{InputPath: "assets/card/notes.txt", OutputPath: "components/card/notes.templ"},
+		{InputPath: "assets/card/notes.md", OutputPath: "components/card/notes.templ"},
+	}
+
+	expected := map[string][]string{
+		"components/button/base.templ": {"assets/button/base.css", "assets/button/base.js"},
+	}
+
+	if got := FindOutputConflicts(jobs, nil); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	regions := []Job{
+		{InputPath: "assets/button/base.css", OutputPath: "components/button/button.templ", Region: "base"},
+		{InputPath: "assets/button/variants.css", OutputPath: "components/button/button.templ", Region: "variants"},
+		{InputPath: "assets/button/extra.css", OutputPath: "components/button/button.templ", Region: "variants"},
+	}
+	expected = map[string][]string{
+		"components/button/button.templ [variants]": {"assets/button/extra.css", "assets/button/variants.css"},
+	}
+	if got := FindOutputConflicts(regions, nil); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := FindOutputConflicts(nil, nil); len(got) != 0 {
+		t.Errorf("Expected no conflicts, got %v", got)
+	}
+}
@@ -3,8 +3,11 @@ package worker
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/logger"
 	"github.com/indaco/tempo/internal/utils"
 )
 
@@ -18,8 +21,80 @@ const (
 	SkipUnchangedFile    SkipType = "unchanged_file"    // File not changed
 	SkipQueueFull        SkipType = "queue_full"        // job queue is full
 	SkipExcluded         SkipType = "user_skipped"      // Excluded by user
+	SkipOutputConflict   SkipType = "output_conflict"   // Output file matched by several input files
+	SkipSymlink          SkipType = "symlink"           // Symbolic link not followed
+	SkipManualEdits      SkipType = "manual_edits"      // Guard region edited by hand, kept interactively
+	SkipPrebuilt         SkipType = "prebuilt_file"     // Minified or binary file, left out by processor.skip_inputs
 )
 
+// SkipReason is the reason code of a skipped file, grouping the skip types into the
+// categories counted in the summary and checked by '--fail-on-skip'.
+type SkipReason string
+
+const (
+	ReasonExcluded      SkipReason = "excluded"       // Excluded by the user, or a system file
+	ReasonUnchanged     SkipReason = "unchanged"      // Not changed since the last run or the git ref
+	ReasonMinified      SkipReason = "minified"       // Minified or binary file (see InputSkipper)
+	ReasonIgnored       SkipReason = "ignored"        // Unsupported file type, or symbolic link not followed
+	ReasonMissingOutput SkipReason = "missing-output" // Missing or mismatched .templ file
+	ReasonConflict      SkipReason = "conflict"       // Output file shared with other inputs, or guard region edited by hand
+	ReasonQueueFull     SkipReason = "queue-full"     // Job queue full
+)
+
+// SkipReasons lists the valid reason codes.
+var SkipReasons = []SkipReason{
+	ReasonExcluded, ReasonUnchanged, ReasonMinified, ReasonIgnored, ReasonMissingOutput, ReasonConflict, ReasonQueueFull,
+}
+
+// Reason returns the reason code of the skip type.
+func (t SkipType) Reason() SkipReason {
+	switch t {
+	case SkipExcluded:
+		return ReasonExcluded
+	case SkipUnchangedFile:
+		return ReasonUnchanged
+	case SkipPrebuilt:
+		return ReasonMinified
+	case SkipUnsupportedFile, SkipSymlink:
+		return ReasonIgnored
+	case SkipMissingTemplFile, SkipMismatchedPath:
+		return ReasonMissingOutput
+	case SkipOutputConflict, SkipManualEdits:
+		return ReasonConflict
+	case SkipQueueFull:
+		return ReasonQueueFull
+	}
+	return ""
+}
+
+// ParseSkipReasons validates the reason codes, e.g. of '--fail-on-skip'.
+func ParseSkipReasons(values []string) ([]SkipReason, error) {
+	reasons := make([]SkipReason, 0, len(values))
+	for _, value := range values {
+		reason := SkipReason(strings.TrimSpace(value))
+		if !slices.Contains(SkipReasons, reason) {
+			valid := make([]string, len(SkipReasons))
+			for i, r := range SkipReasons {
+				valid[i] = string(r)
+			}
+			return nil, apperrors.Wrap("invalid skip reason %s (valid: %s)", value, strings.Join(valid, ", "))
+		}
+		reasons = append(reasons, reason)
+	}
+	return reasons, nil
+}
+
+// CountSkipReasons returns the number of skipped files by reason code.
+func CountSkipReasons(skippedFiles []ProcessingError) map[SkipReason]int {
+	counts := make(map[SkipReason]int)
+	for _, f := range skippedFiles {
+		if reason := f.SkipType.Reason(); reason != "" {
+			counts[reason]++
+		}
+	}
+	return counts
+}
+
 // SkippedFile holds metadata about a skipped file.
 type SkippedFile struct {
 	Source    string   // Path to the source file
@@ -32,11 +107,13 @@ type SkippedFile struct {
 
 // ProcessingError stores detailed error info.
 type ProcessingError struct {
-	Source   string   `json:"source"`         // Source file path
-	Dest     string   `json:"dest,omitempty"` // Expected output file path (if applicable)
-	Message  string   `json:"message,omitempty"`
-	Reason   string   `json:"reason,omitempty"`    // Why it was skipped
-	SkipType SkipType `json:"skip_type,omitempty"` // Type of skip reason
+	Source     string     `json:"source"`         // Source file path
+	Dest       string     `json:"dest,omitempty"` // Expected output file path (if applicable)
+	Message    string     `json:"message,omitempty"`
+	Code       string     `json:"code,omitempty"`        // Error code, e.g. TEMPO-E015 (if any)
+	Reason     string     `json:"reason,omitempty"`      // Why it was skipped
+	SkipType   SkipType   `json:"skip_type,omitempty"`   // Type of skip reason
+	ReasonCode SkipReason `json:"reason_code,omitempty"` // Reason code of the skip type, e.g. missing-output
 }
 
 // CollectErrors collects and aggregates errors or skipped files.
@@ -64,6 +141,7 @@ func FormatError(filePath string, err error) ProcessingError {
 	return ProcessingError{
 		Source:  filePath,
 		Message: err.Error(),
+		Code:    apperrors.CodeOf(err),
 	}
 }
 
@@ -91,10 +169,11 @@ func FormatSkipReason(skipped SkippedFile) ProcessingError {
 	}
 
 	return ProcessingError{
-		Reason:   skipped.Reason,
-		SkipType: skipped.SkipType,
-		Source:   relSource,
-		Dest:     relDest,
+		Reason:     skipped.Reason,
+		SkipType:   skipped.SkipType,
+		ReasonCode: skipped.SkipType.Reason(),
+		Source:     relSource,
+		Dest:       relDest,
 	}
 }
 
@@ -109,9 +188,9 @@ func PrintErrors(errors []ProcessingError) {
 
 	for _, err := range errors {
 		if err.Reason != "" {
-			fmt.Fprintf(&sb, "- Skipped File: %s\n  Reason: %s (Type: %s)\n", err.Source, err.Reason, err.SkipType)
+			fmt.Fprintf(&sb, "- Skipped File: %s\n  Reason: %s (Type: %s)\n", logger.DisplayPath(err.Source), err.Reason, err.SkipType)
 		} else {
-			fmt.Fprintf(&sb, "- File: %s\n  Error: %s\n", err.Source, err.Message)
+			fmt.Fprintf(&sb, "- File: %s\n  Error: %s\n", logger.DisplayPath(err.Source), err.Message)
 		}
 	}
 
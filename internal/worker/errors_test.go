@@ -2,6 +2,8 @@ package worker
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"testing"
 
 	"github.com/indaco/tempo/internal/testutils"
@@ -133,10 +135,53 @@ func TestFormatSkipReason(t *testing.T) {
 			if skip.SkipType != tt.expSkipType {
 				t.Errorf("Expected SkipType to be '%s', got '%s'", tt.expSkipType, skip.SkipType)
 			}
+			if skip.ReasonCode != ReasonMissingOutput {
+				t.Errorf("Expected ReasonCode to be '%s', got '%s'", ReasonMissingOutput, skip.ReasonCode)
+			}
 		})
 	}
 }
 
+func TestSkipType_Reason(t *testing.T) {
+	for _, skipType := range []SkipType{
+		SkipUnsupportedFile, SkipMismatchedPath, SkipMissingTemplFile, SkipUnchangedFile, SkipQueueFull,
+		SkipExcluded, SkipOutputConflict, SkipSymlink, SkipManualEdits, SkipPrebuilt,
+	} {
+		if reason := skipType.Reason(); !slices.Contains(SkipReasons, reason) {
+			t.Errorf("Expected a valid reason code for %s, got %q", skipType, reason)
+		}
+	}
+	if reason := SkipType("unknown").Reason(); reason != "" {
+		t.Errorf("Expected no reason code for an unknown skip type, got %q", reason)
+	}
+}
+
+func TestParseSkipReasons(t *testing.T) {
+	reasons, err := ParseSkipReasons([]string{"missing-output", " conflict"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(reasons, []SkipReason{ReasonMissingOutput, ReasonConflict}) {
+		t.Errorf("Unexpected reasons: %v", reasons)
+	}
+
+	if _, err := ParseSkipReasons([]string{"missing_templ"}); err == nil {
+		t.Error("Expected an error for an unknown reason code")
+	}
+}
+
+func TestCountSkipReasons(t *testing.T) {
+	counts := CountSkipReasons([]ProcessingError{
+		{Source: "a.css", SkipType: SkipMissingTemplFile},
+		{Source: "b.css", SkipType: SkipMismatchedPath},
+		{Source: "c.css", SkipType: SkipManualEdits},
+	})
+	expected := map[SkipReason]int{ReasonMissingOutput: 2, ReasonConflict: 1}
+	if !maps.Equal(counts, expected) {
+		t.Errorf("Expected counts %v, got %v", expected, counts)
+	}
+}
+
 func TestPrintErrors(t *testing.T) {
 	// Test case 1: errors slice is not empty
 	t.Run("No errors", func(t *testing.T) {
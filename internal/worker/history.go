@@ -0,0 +1,174 @@
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// timeRegressionRatio and timeRegressionMin define when a run is reported as slower
+// than the previous one: both the ratio and the absolute delta must be exceeded.
+const (
+	timeRegressionRatio = 1.2
+	timeRegressionMin   = 100 * time.Millisecond
+)
+
+// RunSummary is the persisted outcome of a sync run, compared with the next run.
+type RunSummary struct {
+	Time           time.Time           `json:"time"`
+	Elapsed        time.Duration       `json:"elapsed"`
+	FilesProcessed int                 `json:"files_processed"`
+	Failed         map[string]string   `json:"failed"`  // Source path -> error message
+	Skipped        map[string]SkipType `json:"skipped"` // Source path -> skip type
+}
+
+// SummaryDiff holds the changes between two sync runs.
+type SummaryDiff struct {
+	NewlyFailing    []ProcessingError // Files failing now but not in the previous run
+	Fixed           []string          // Files failing in the previous run only
+	NewlySkipped    []ProcessingError // Files skipped now but not in the previous run
+	PreviousElapsed time.Duration
+	CurrentElapsed  time.Duration
+	TimeRegression  bool
+}
+
+// RunSummary captures the current metrics with the collected errors and skipped files.
+// Unchanged files are left out of the skipped files, as they are expected between runs.
+func (m *Metrics) RunSummary(errors []ProcessingError, skippedFiles []ProcessingError) RunSummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	summary := RunSummary{
+		Time:           m.StartTime,
+		Elapsed:        time.Since(m.StartTime),
+		FilesProcessed: m.FilesProcessed,
+		Failed:         make(map[string]string, len(errors)),
+		Skipped:        make(map[string]SkipType, len(skippedFiles)),
+	}
+	for _, e := range errors {
+		summary.Failed[e.Source] = e.Message
+	}
+	for _, s := range skippedFiles {
+		if s.SkipType != SkipUnchangedFile {
+			summary.Skipped[s.Source] = s.SkipType
+		}
+	}
+	return summary
+}
+
+// SaveRunSummary writes the run summary as JSON to path.
+func SaveRunSummary(path string, summary RunSummary) error {
+	return utils.WriteJSONToFile(path, summary)
+}
+
+// LoadRunSummary reads a run summary from path. It returns false when no summary
+// has been saved yet.
+func LoadRunSummary(path string) (RunSummary, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return RunSummary{}, false, nil
+		}
+		return RunSummary{}, false, err
+	}
+
+	var summary RunSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return RunSummary{}, false, err
+	}
+	return summary, true, nil
+}
+
+// CompareRunSummaries returns the changes from the previous run to the current one.
+func CompareRunSummaries(previous, current RunSummary) SummaryDiff {
+	diff := SummaryDiff{
+		PreviousElapsed: previous.Elapsed,
+		CurrentElapsed:  current.Elapsed,
+	}
+
+	for _, source := range slices.Sorted(maps.Keys(current.Failed)) {
+		if _, failed := previous.Failed[source]; !failed {
+			diff.NewlyFailing = append(diff.NewlyFailing, ProcessingError{Source: source, Message: current.Failed[source]})
+		}
+	}
+	for _, source := range slices.Sorted(maps.Keys(previous.Failed)) {
+		if _, failed := current.Failed[source]; !failed {
+			diff.Fixed = append(diff.Fixed, source)
+		}
+	}
+	for _, source := range slices.Sorted(maps.Keys(current.Skipped)) {
+		if previous.Skipped[source] != current.Skipped[source] {
+			diff.NewlySkipped = append(diff.NewlySkipped, ProcessingError{Source: source, SkipType: current.Skipped[source]})
+		}
+	}
+
+	delta := current.Elapsed - previous.Elapsed
+	diff.TimeRegression = previous.Elapsed > 0 &&
+		delta > timeRegressionMin &&
+		float64(current.Elapsed) > float64(previous.Elapsed)*timeRegressionRatio
+
+	return diff
+}
+
+// HasRegressions reports whether the current run fails, skips or slows down more
+// than the previous one.
+func (d SummaryDiff) HasRegressions() bool {
+	return len(d.NewlyFailing) > 0 || len(d.NewlySkipped) > 0 || d.TimeRegression
+}
+
+// String returns the comparison in human-readable text format.
+func (d SummaryDiff) String() string {
+	var sb strings.Builder
+	faint := color.New(color.Faint).SprintFunc()
+
+	sb.WriteString("\n" + logger.Prefix("compare") + "Compared to Last Run:\n")
+	fmt.Fprintf(&sb, "  - Elapsed time: %s %s %s (%s)\n",
+		formatElapsedTime(d.PreviousElapsed), logger.Icon("arrow"), formatElapsedTime(d.CurrentElapsed), formatElapsedDelta(d.CurrentElapsed-d.PreviousElapsed))
+
+	if !d.HasRegressions() && len(d.Fixed) == 0 {
+		sb.WriteString("  - No changes in failing or skipped files\n")
+		return sb.String()
+	}
+
+	if len(d.NewlyFailing) > 0 {
+		fmt.Fprintf(&sb, "\n%sNewly Failing (%d):\n", logger.Prefix("failed"), len(d.NewlyFailing))
+		for _, e := range d.NewlyFailing {
+			fmt.Fprintf(&sb, "    - file: %s %s %s\n", faint(logger.DisplayPath(e.Source)), logger.Icon("arrow"), e.Message)
+		}
+	}
+	if len(d.Fixed) > 0 {
+		fmt.Fprintf(&sb, "\n%sFixed (%d):\n", logger.Prefix("processed"), len(d.Fixed))
+		for _, source := range d.Fixed {
+			fmt.Fprintf(&sb, "    - file: %s\n", faint(logger.DisplayPath(source)))
+		}
+	}
+	if len(d.NewlySkipped) > 0 {
+		fmt.Fprintf(&sb, "\n%sNewly Skipped (%d):\n", logger.Prefix("skipped"), len(d.NewlySkipped))
+		for _, s := range d.NewlySkipped {
+			fmt.Fprintf(&sb, "    - file: %s (%s)\n", faint(logger.DisplayPath(s.Source)), s.SkipType)
+		}
+	}
+	if d.TimeRegression {
+		yellowIcon := color.New(color.FgYellow, color.Bold).Sprint(logger.Icon("warning"))
+		sb.WriteString("\n" + yellowIcon + " Sync is noticeably slower than the last run.\n")
+	}
+
+	return sb.String()
+}
+
+// formatElapsedDelta formats a duration difference with an explicit sign.
+func formatElapsedDelta(delta time.Duration) string {
+	if delta < 0 {
+		return "-" + formatElapsedTime(-delta)
+	}
+	return "+" + formatElapsedTime(delta)
+}
@@ -0,0 +1,117 @@
+package worker
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics_RunSummary(t *testing.T) {
+	m := NewMetrics()
+	m.RecordProcessedFile("a.css")
+
+	errors := []ProcessingError{{Source: "b.css", Message: "boom"}}
+	skipped := []ProcessingError{
+		{Source: "c.txt", SkipType: SkipUnsupportedFile},
+		{Source: "d.css", SkipType: SkipUnchangedFile},
+	}
+
+	summary := m.RunSummary(errors, skipped)
+
+	if summary.FilesProcessed != 1 {
+		t.Errorf("Expected 1 processed file, got %d", summary.FilesProcessed)
+	}
+	if summary.Failed["b.css"] != "boom" {
+		t.Errorf("Expected b.css to be failing, got %v", summary.Failed)
+	}
+	if len(summary.Skipped) != 1 || summary.Skipped["c.txt"] != SkipUnsupportedFile {
+		t.Errorf("Expected only c.txt to be skipped, got %v", summary.Skipped)
+	}
+}
+
+func TestSaveAndLoadRunSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tempo-files", "last-sync-summary.json")
+
+	if _, found, err := LoadRunSummary(path); err != nil || found {
+		t.Fatalf("Expected no summary yet, got found=%v err=%v", found, err)
+	}
+
+	want := RunSummary{
+		Time:           time.Now().Truncate(time.Second),
+		Elapsed:        1500 * time.Millisecond,
+		FilesProcessed: 3,
+		Failed:         map[string]string{"b.css": "boom"},
+		Skipped:        map[string]SkipType{"c.txt": SkipUnsupportedFile},
+	}
+	if err := SaveRunSummary(path, want); err != nil {
+		t.Fatalf("SaveRunSummary failed: %v", err)
+	}
+
+	got, found, err := LoadRunSummary(path)
+	if err != nil || !found {
+		t.Fatalf("Expected saved summary, got found=%v err=%v", found, err)
+	}
+	if !got.Time.Equal(want.Time) || got.Elapsed != want.Elapsed || got.FilesProcessed != want.FilesProcessed ||
+		got.Failed["b.css"] != "boom" || got.Skipped["c.txt"] != SkipUnsupportedFile {
+		t.Errorf("Loaded summary mismatch: got %+v, want %+v", got, want)
+	}
+}
+
+func TestCompareRunSummaries(t *testing.T) {
+	previous := RunSummary{
+		Elapsed: time.Second,
+		Failed:  map[string]string{"fixed.css": "old error", "still.css": "error"},
+		Skipped: map[string]SkipType{"c.txt": SkipUnsupportedFile},
+	}
+	current := RunSummary{
+		Elapsed: 2 * time.Second,
+		Failed:  map[string]string{"still.css": "error", "new.css": "new error"},
+		Skipped: map[string]SkipType{"c.txt": SkipUnsupportedFile, "e.css": SkipMissingTemplFile},
+	}
+
+	diff := CompareRunSummaries(previous, current)
+
+	if len(diff.NewlyFailing) != 1 || diff.NewlyFailing[0].Source != "new.css" {
+		t.Errorf("Expected new.css to be newly failing, got %v", diff.NewlyFailing)
+	}
+	if len(diff.Fixed) != 1 || diff.Fixed[0] != "fixed.css" {
+		t.Errorf("Expected fixed.css to be fixed, got %v", diff.Fixed)
+	}
+	if len(diff.NewlySkipped) != 1 || diff.NewlySkipped[0].Source != "e.css" {
+		t.Errorf("Expected e.css to be newly skipped, got %v", diff.NewlySkipped)
+	}
+	if !diff.TimeRegression {
+		t.Errorf("Expected a time regression")
+	}
+	if !diff.HasRegressions() {
+		t.Errorf("Expected HasRegressions to be true")
+	}
+
+	output := diff.String()
+	for _, want := range []string{"Compared to Last Run", "Newly Failing (1)", "new.css", "Fixed (1)", "Newly Skipped (1)", "slower"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected diff output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestCompareRunSummaries_NoChanges(t *testing.T) {
+	run := RunSummary{Elapsed: time.Second, Failed: map[string]string{}, Skipped: map[string]SkipType{}}
+	faster := run
+	faster.Elapsed = 500 * time.Millisecond
+
+	diff := CompareRunSummaries(run, faster)
+	if diff.HasRegressions() {
+		t.Errorf("Expected no regressions, got %+v", diff)
+	}
+	if !strings.Contains(diff.String(), "No changes in failing or skipped files") {
+		t.Errorf("Expected no changes message, got:\n%s", diff.String())
+	}
+
+	// Small absolute slowdowns are not reported
+	slightlySlower := RunSummary{Elapsed: 50 * time.Millisecond}
+	if CompareRunSummaries(RunSummary{Elapsed: 10 * time.Millisecond}, slightlySlower).TimeRegression {
+		t.Errorf("Expected no time regression below the minimum delta")
+	}
+}
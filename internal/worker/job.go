@@ -1,7 +1,23 @@
 package worker
 
+import "github.com/indaco/tempo/internal/processor"
+
 // Job represents a file processing task.
 type Job struct {
 	InputPath  string
 	OutputPath string
+	Region     string // Guard region of the output file, empty for the default one
+}
+
+// Marker returns the guard marker name of the job region.
+func (j Job) Marker(markerName string) string {
+	return processor.RegionMarker(markerName, j.Region)
+}
+
+// Target returns the output file of the job, followed by its region when it has one.
+func (j Job) Target() string {
+	if j.Region == "" {
+		return j.OutputPath
+	}
+	return j.OutputPath + " [" + j.Region + "]"
 }
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// LastRunFile is the name of the file, in the working directory, storing the
+// timestamp of the last successful sync.
+const LastRunFile = ".tempo-lastrun"
+
+// LastSummaryFile is the name of the file, in the tempo root folder, storing the
+// summary of the last sync run for '--compare-last'.
+const LastSummaryFile = "last-sync-summary.json"
+
+// LastRunTime returns the time of the last successful sync run in the given
+// directory, or false if sync has never completed there.
+func LastRunTime(dir string) (time.Time, bool) {
+	ts := ReadLastRunTimestamp(filepath.Join(dir, LastRunFile))
+	if ts == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(ts, 0), true
+}
+
+// ReadLastRunTimestamp returns the Unix timestamp stored in cacheFile, or 0 when
+// the file is missing or invalid (meaning everything should be processed).
+func ReadLastRunTimestamp(cacheFile string) int64 {
+	data, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return 0
+	}
+
+	ts, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return ts
+}
+
+// SaveLastRunTimestamp stores the current time in cacheFile.
+func SaveLastRunTimestamp(cacheFile string) error {
+	timestamp := fmt.Sprintf("%d", time.Now().Unix())
+	return utils.WriteStringToFile(cacheFile, timestamp)
+}
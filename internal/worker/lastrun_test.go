@@ -1,4 +1,4 @@
-package synccmd
+package worker
 
 import (
 	"os"
@@ -9,7 +9,7 @@ import (
 )
 
 /* ------------------------------------------------------------------------- */
-/* Test getLastRunTimestamp                                                  */
+/* Test ReadLastRunTimestamp                                                 */
 /* ------------------------------------------------------------------------- */
 
 func TestGetLastRunTimestamp(t *testing.T) {
@@ -17,7 +17,7 @@ func TestGetLastRunTimestamp(t *testing.T) {
 	cacheFile := filepath.Join(tempDir, ".tempo-lastrun")
 
 	// Case 1: File does not exist, should return 0
-	if ts := getLastRunTimestamp(cacheFile); ts != 0 {
+	if ts := ReadLastRunTimestamp(cacheFile); ts != 0 {
 		t.Errorf("Expected timestamp 0 for non-existent file, got %d", ts)
 	}
 
@@ -27,7 +27,7 @@ func TestGetLastRunTimestamp(t *testing.T) {
 		t.Fatalf("Failed to create test cache file: %v", err)
 	}
 
-	ts := getLastRunTimestamp(cacheFile)
+	ts := ReadLastRunTimestamp(cacheFile)
 	if ts == 0 {
 		t.Errorf("Expected a valid timestamp, got 0")
 	}
@@ -37,7 +37,7 @@ func TestGetLastRunTimestamp(t *testing.T) {
 		t.Fatalf("Failed to create invalid timestamp file: %v", err)
 	}
 
-	ts = getLastRunTimestamp(cacheFile)
+	ts = ReadLastRunTimestamp(cacheFile)
 	if ts != 0 {
 		t.Errorf("Expected timestamp 0 for invalid data, got %d", ts)
 	}
@@ -47,7 +47,7 @@ func TestGetLastRunTimestamp(t *testing.T) {
 		t.Fatalf("Failed to create empty timestamp file: %v", err)
 	}
 
-	ts = getLastRunTimestamp(cacheFile)
+	ts = ReadLastRunTimestamp(cacheFile)
 	if ts != 0 {
 		t.Errorf("Expected timestamp 0 for empty file, got %d", ts)
 	}
@@ -60,7 +60,7 @@ func TestGetLastRunTimestamp(t *testing.T) {
 				t.Logf("Warning: Failed to restore file permissions for %s: %v", cacheFile, err)
 			}
 		}()
-		ts = getLastRunTimestamp(cacheFile)
+		ts = ReadLastRunTimestamp(cacheFile)
 		if ts != 0 {
 			t.Errorf("Expected timestamp 0 for unreadable file, got %d", ts)
 		}
@@ -70,7 +70,7 @@ func TestGetLastRunTimestamp(t *testing.T) {
 }
 
 /* ------------------------------------------------------------------------- */
-/* Test saveLastRunTimestamp                                                 */
+/* Test SaveLastRunTimestamp                                                 */
 /* ------------------------------------------------------------------------- */
 
 func TestSaveLastRunTimestamp(t *testing.T) {
@@ -78,7 +78,7 @@ func TestSaveLastRunTimestamp(t *testing.T) {
 	cacheFile := filepath.Join(tempDir, ".tempo-lastrun")
 
 	// Case 1: Save the last run timestamp
-	err := saveLastRunTimestamp(cacheFile)
+	err := SaveLastRunTimestamp(cacheFile)
 	if err != nil {
 		t.Fatalf("Failed to save last run timestamp: %v", err)
 	}
@@ -100,41 +100,30 @@ func TestSaveLastRunTimestamp(t *testing.T) {
 	}
 	readonlyCacheFile := filepath.Join(readOnlyDir, ".tempo-lastrun")
 
-	err = saveLastRunTimestamp(readonlyCacheFile)
+	err = SaveLastRunTimestamp(readonlyCacheFile)
 	if err == nil {
 		t.Errorf("Expected an error when writing to a read-only directory, but got none")
 	}
 }
 
 /* ------------------------------------------------------------------------- */
-/* Test getFileLastModifiedTime                                              */
+/* Test LastRunTime                                                          */
 /* ------------------------------------------------------------------------- */
 
-func TestGetFileLastModifiedTime(t *testing.T) {
+func TestLastRunTime(t *testing.T) {
 	tempDir := t.TempDir()
-	testFile := filepath.Join(tempDir, "testfile.txt")
 
-	// Create test file
-	err := os.WriteFile(testFile, []byte("test content"), 0644)
-	if err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
-	}
-
-	// Get last modified time
-	ts, err := getFileLastModifiedTime(testFile)
-	if err != nil {
-		t.Fatalf("Failed to get last modified time: %v", err)
+	if _, ok := LastRunTime(tempDir); ok {
+		t.Errorf("Expected no last run time without %s", LastRunFile)
 	}
 
-	if ts <= 0 {
-		t.Errorf("Expected a valid last modified timestamp, got %d", ts)
+	expected := time.Unix(1700000000, 0)
+	if err := os.WriteFile(filepath.Join(tempDir, LastRunFile), []byte("1700000000"), 0644); err != nil {
+		t.Fatalf("Failed to create test cache file: %v", err)
 	}
 
-	// Case 1: Non-existent file should return an error
-	nonExistentFile := filepath.Join(tempDir, "nonexistent.txt")
-	_, err = getFileLastModifiedTime(nonExistentFile)
-	if err == nil {
-		t.Errorf("Expected an error for non-existent file, but got none")
+	lastRun, ok := LastRunTime(tempDir)
+	if !ok || !lastRun.Equal(expected) {
+		t.Errorf("Expected last run time %v, got %v (ok: %t)", expected, lastRun, ok)
 	}
-
 }
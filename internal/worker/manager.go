@@ -3,6 +3,8 @@ package worker
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/fs"
 	"path/filepath"
 	"runtime"
 	"sync"
@@ -10,6 +12,10 @@ import (
 
 	"github.com/indaco/tempo/internal/apperrors"
 	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/progress"
+	"github.com/indaco/tempo/internal/tags"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/watermark"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -21,12 +27,32 @@ import (
 type WorkerPoolOptions struct {
 	Context              context.Context
 	InputDir             string
+	InputFS              fs.FS // Reads the input files from this file system, under InputDir (nil for the OS one)
 	OutputDir            string
 	ExcludeDir           string
 	MarkerName           string
+	Watermark            *watermark.Watermark // Watermark added before the injected content
+	Transformers         []processor.ExternalTransformer
+	Guards               *processor.InjectionGuards // Limits on the injected content (nil to disable)
+	ScopedCSS            *processor.CSSScoper       // Scopes the CSS class names to their component (nil to disable)
+	JSInjector           *processor.JSInjector      // Escapes and wraps the injected JS (nil to inject it as is)
+	Languages            processor.AssetLanguages   // File types synced besides CSS and JS (nil for none)
+	InputSkipper         *InputSkipper              // Skips the minified and binary input files (nil to disable)
+	Tags                 *tags.Filter               // Selects the components synced by their tags (nil for all)
+	SymlinkPolicy        string                     // How symbolic links in the input folder are handled (see WalkInputDir)
+	FileModes            *utils.FileModePolicy      // Permissions of the written .templ files (nil for the defaults)
+	OutputRules          []OutputRule               // Output folders of the matching input files, over OutputDir
+	Retry                RetryPolicy                // Retries of the files failing with transient file system errors
+	Checksum             *processor.GuardChecksum   // Checksum of the guard regions, detecting the manual edits (nil to disable)
+	ChangedFiles         map[string]bool            // Absolute paths of the files changed since a git ref, processed instead of the files modified since the last run (nil to use the modification times)
+	ForcedOutputs        map[string]bool            // Absolute paths of the output files whose inputs are processed regardless of the modification times (e.g. after a guard marker repair)
+	IOThrottle           *IOThrottle                // Limit on the disk IO of the workers (nil to disable)
+	Progress             *progress.Reporter         // Streams the progress events of the files (nil to disable)
+	Output               io.Writer                  // Receives the per-file execution times (nil for os.Stdout)
 	NumWorkers           int
 	IsProduction         bool // If `--prod` is set, process everything
 	IsForce              bool // If `--force` is set, process everything
+	IsFailFast           bool // If `--fail-fast` is set, stop on the first processing error
 	IsTrackExecutionTime bool
 }
 
@@ -40,6 +66,14 @@ func WithExcludeDir(dir string) WorkerPoolOption {
 	}
 }
 
+// WithInputFS reads the input files from fsys, e.g. a zip bundle, instead of the OS
+// file system. The root of fsys stands for the input folder.
+func WithInputFS(fsys fs.FS) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.InputFS = fsys
+	}
+}
+
 // WithMarkerName sets the guard marker name used in .templ files.
 func WithMarkerName(name string) WorkerPoolOption {
 	return func(o *WorkerPoolOptions) {
@@ -47,6 +81,93 @@ func WithMarkerName(name string) WorkerPoolOption {
 	}
 }
 
+// WithWatermark sets the watermark added before the content injected in .templ files.
+func WithWatermark(w *watermark.Watermark) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.Watermark = w
+	}
+}
+
+// WithTransformers sets the external transformers applied to the input files content.
+func WithTransformers(transformers []processor.ExternalTransformer) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.Transformers = transformers
+	}
+}
+
+// WithGuards sets the limits enforced on the content injected in .templ files.
+func WithGuards(guards *processor.InjectionGuards) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.Guards = guards
+	}
+}
+
+// WithJSInjector escapes and wraps the JS content injected into the script blocks.
+func WithJSInjector(injector *processor.JSInjector) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.JSInjector = injector
+	}
+}
+
+// WithAssetLanguages syncs the files of the given asset languages besides CSS and JS.
+func WithAssetLanguages(languages processor.AssetLanguages) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.Languages = languages
+	}
+}
+
+// WithInputSkipper sets the detection of the minified and binary input files left out
+// of sync.
+func WithInputSkipper(skipper *InputSkipper) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.InputSkipper = skipper
+	}
+}
+
+// WithScopedCSS scopes the class names of the CSS files to their component and
+// generates the Go maps of the scoped names.
+func WithScopedCSS(scoper *processor.CSSScoper) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.ScopedCSS = scoper
+	}
+}
+
+// WithTags syncs only the components selected by filter.
+func WithTags(filter *tags.Filter) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.Tags = filter
+	}
+}
+
+// WithSymlinkPolicy sets how symbolic links in the input folder are handled:
+// SymlinkFollow, SymlinkSkip or SymlinkError.
+func WithSymlinkPolicy(policy string) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.SymlinkPolicy = policy
+	}
+}
+
+// WithFileModes sets the permissions applied when writing the .templ files.
+func WithFileModes(modes *utils.FileModePolicy) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.FileModes = modes
+	}
+}
+
+// WithOutputRules sets the rules sending input files to other output folders.
+func WithOutputRules(rules []OutputRule) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.OutputRules = rules
+	}
+}
+
+// WithRetry sets the retry policy of the files failing with transient file system errors.
+func WithRetry(policy RetryPolicy) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.Retry = policy
+	}
+}
+
 // WithNumWorkers sets the number of concurrent workers.
 func WithNumWorkers(n int) WorkerPoolOption {
 	return func(o *WorkerPoolOptions) {
@@ -68,6 +189,67 @@ func WithForce(force bool) WorkerPoolOption {
 	}
 }
 
+// WithChecksum records the checksum of the guard regions, detecting the regions edited
+// by hand since the last sync.
+func WithChecksum(checksum *processor.GuardChecksum) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.Checksum = checksum
+	}
+}
+
+// WithChangedFiles processes only the given files, by absolute path, instead of the
+// files modified since the last run.
+func WithChangedFiles(paths []string) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.ChangedFiles = make(map[string]bool, len(paths))
+		for _, path := range paths {
+			o.ChangedFiles[path] = true
+		}
+	}
+}
+
+// WithForcedOutputs processes the input files of the given output files regardless of
+// the modification times, as `--force` does for all the files.
+func WithForcedOutputs(paths []string) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.ForcedOutputs = make(map[string]bool, len(paths))
+		for _, path := range paths {
+			if absPath, err := filepath.Abs(path); err == nil {
+				o.ForcedOutputs[absPath] = true
+			}
+		}
+	}
+}
+
+// WithIOThrottle limits the disk IO of the workers.
+func WithIOThrottle(throttle *IOThrottle) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.IOThrottle = throttle
+	}
+}
+
+// WithProgress streams the progress events of the files to reporter.
+func WithProgress(reporter *progress.Reporter) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.Progress = reporter
+	}
+}
+
+// WithOutput writes the per-file execution times to w instead of os.Stdout, e.g. when
+// the pool runs inside a host application.
+func WithOutput(w io.Writer) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.Output = w
+	}
+}
+
+// WithFailFast stops processing on the first file that fails.
+func WithFailFast(failFast bool) WorkerPoolOption {
+	return func(o *WorkerPoolOptions) {
+		o.IsFailFast = failFast
+	}
+}
+
 // WithTrackExecutionTime enables per-file execution time tracking.
 func WithTrackExecutionTime(track bool) WorkerPoolOption {
 	return func(o *WorkerPoolOptions) {
@@ -94,6 +276,10 @@ func NewWorkerPoolOptions(ctx context.Context, inputDir, outputDir string, opts
 		return WorkerPoolOptions{}, apperrors.Wrap(fmt.Sprintf("NumWorkers must be greater than 0, got %d", o.NumWorkers))
 	}
 
+	if err := ValidateSymlinkPolicy(o.SymlinkPolicy); err != nil {
+		return WorkerPoolOptions{}, err
+	}
+
 	return o, nil
 }
 
@@ -111,10 +297,21 @@ type WorkerPoolManager struct {
 	Metrics        *Metrics
 	Factory        processor.ProcessorFactoryInterface
 	InputDir       string
+	Input          *processor.InputFS // File system of the input files (nil for the OS one)
 	OutputDir      string
+	OutputRules    []OutputRule
+	Retry          RetryPolicy
+	Throttle       *IOThrottle
+	Scoper         *processor.CSSScoper
+	Languages      processor.AssetLanguages
+	FileModes      *utils.FileModePolicy
 	MarkerName     string
+	Progress       *progress.Reporter
+	Output         io.Writer // Receives the per-file execution times (nil for os.Stdout)
+	FailFast       bool
 	ExecutionTimes []JobExecutionTime
 	mu             sync.Mutex
+	outputLocks    sync.Map // Output file path -> *sync.Mutex
 }
 
 // NewWorkerPoolManager initializes a worker pool manager.
@@ -131,16 +328,41 @@ func NewWorkerPoolManager(opts WorkerPoolOptions) *WorkerPoolManager {
 	// Pre-clean paths once to avoid repeated allocations in hot paths
 	inputDir := filepath.Clean(opts.InputDir)
 	outputDir := filepath.Clean(opts.OutputDir)
+	input := processor.NewInputFS(opts.InputFS, inputDir)
+	metrics := NewMetrics()
+	metrics.SetIOThrottle(opts.IOThrottle)
 
 	return &WorkerPoolManager{
-		JobChan:        make(chan Job, bufferSize),
-		ErrorsChan:     make(chan ProcessingError, bufferSize),
-		SkippedChan:    make(chan ProcessingError, bufferSize),
-		Metrics:        NewMetrics(),
-		Factory:        &processor.ProcessorFactory{Production: opts.IsProduction},
+		JobChan:     make(chan Job, bufferSize),
+		ErrorsChan:  make(chan ProcessingError, bufferSize),
+		SkippedChan: make(chan ProcessingError, bufferSize),
+		Metrics:     metrics,
+		Factory: &processor.ProcessorFactory{
+			Production:   opts.IsProduction,
+			Watermark:    opts.Watermark,
+			InputDir:     inputDir,
+			Transformers: opts.Transformers,
+			Scoper:       opts.ScopedCSS,
+			Guards:       opts.Guards,
+			FileModes:    opts.FileModes,
+			Input:        input,
+			Checksum:     opts.Checksum,
+			JS:           opts.JSInjector,
+			Languages:    opts.Languages,
+		},
 		InputDir:       inputDir,
+		Input:          input,
 		OutputDir:      outputDir,
+		OutputRules:    opts.OutputRules,
+		Retry:          opts.Retry,
+		Throttle:       opts.IOThrottle,
+		Scoper:         opts.ScopedCSS,
+		Languages:      opts.Languages,
+		FileModes:      opts.FileModes,
 		MarkerName:     opts.MarkerName,
+		Progress:       opts.Progress,
+		Output:         opts.Output,
+		FailFast:       opts.IsFailFast,
 		ExecutionTimes: make([]JobExecutionTime, 0, opts.NumWorkers*10),
 	}
 }
@@ -150,6 +372,9 @@ func NewWorkerPoolManager(opts WorkerPoolOptions) *WorkerPoolManager {
 /* ------------------------------------------------------------------------- */
 
 // StartWorkers launches worker goroutines using `errgroup`.
+// Processing errors are reported on ErrorsChan and do not stop the pool,
+// unless FailFast is set: the first failure then cancels the remaining workers
+// and is returned.
 func (m *WorkerPoolManager) StartWorkers(ctx context.Context, numWorkers int, trackExecution bool) error {
 	if ctx == nil {
 		return apperrors.Wrap("context is nil in StartWorkers")
@@ -172,3 +397,12 @@ func (m *WorkerPoolManager) StartWorkers(ctx context.Context, numWorkers int, tr
 	// Ensure all workers complete before returning
 	return g.Wait()
 }
+
+// lockOutput locks the output file at path for the calling worker and returns the
+// function releasing it.
+func (m *WorkerPoolManager) lockOutput(path string) func() {
+	lock, _ := m.outputLocks.LoadOrStore(path, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
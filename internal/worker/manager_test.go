@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/watermark"
 )
 
 /* ------------------------------------------------------------------------- */
@@ -251,6 +254,30 @@ func TestNewWorkerPoolOptions(t *testing.T) {
 	})
 }
 
+func TestNewWorkerPoolManager_Watermark(t *testing.T) {
+	w, err := watermark.New("{{ .ComponentName }}", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts, err := NewWorkerPoolOptions(context.Background(), "/input", "/output", WithWatermark(w))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	manager := NewWorkerPoolManager(opts)
+	factory, ok := manager.Factory.(*processor.ProcessorFactory)
+	if !ok {
+		t.Fatalf("expected *processor.ProcessorFactory, got %T", manager.Factory)
+	}
+	if factory.Watermark != w {
+		t.Errorf("expected the watermark to be passed to the processor factory")
+	}
+	if factory.InputDir != filepath.Clean("/input") {
+		t.Errorf("expected InputDir=/input, got %s", factory.InputDir)
+	}
+}
+
 func TestNewWorkerPoolManager_PanicsOnInvalidNumWorkers(t *testing.T) {
 	for _, numWorkers := range []int{0, -1} {
 		t.Run(fmt.Sprintf("NumWorkers=%d", numWorkers), func(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/indaco/tempo/internal/logger"
 )
 
 // Metrics tracks processing statistics.
@@ -17,8 +18,15 @@ type Metrics struct {
 	DirectoriesProcessed int       `json:"directories_processed"`
 	ErrorsEncountered    int       `json:"errors_encountered"`
 	SkippedFiles         int       `json:"skipped_files"`
+	Retries              int       `json:"retries,omitempty"`
+	IOOperations         int       `json:"io_operations,omitempty"` // File reads and writes, counted when throttled
+	IOBytes              int64     `json:"io_bytes,omitempty"`
+	IOThrottle           string    `json:"io_throttle,omitempty"` // IO limit, e.g. "5MB/s"
+	IORate               string    `json:"io_rate,omitempty"`     // Achieved IO rate, e.g. "4.9 MB/s"
 	StartTime            time.Time `json:"start_time"`
 	ElapsedTime          string    `json:"elapsed_time"`
+	ProcessedFiles       []string  `json:"-"`
+	throttle             *IOThrottle
 	mu                   sync.Mutex
 }
 
@@ -28,8 +36,15 @@ type metricsExport struct {
 	DirectoriesProcessed int       `json:"directories_processed"`
 	ErrorsEncountered    int       `json:"errors_encountered"`
 	SkippedFiles         int       `json:"skipped_files"`
+	Retries              int       `json:"retries,omitempty"`
+	IOOperations         int       `json:"io_operations,omitempty"`
+	IOBytes              int64     `json:"io_bytes,omitempty"`
+	IOThrottle           string    `json:"io_throttle,omitempty"`
+	IORate               string    `json:"io_rate,omitempty"`
 	StartTime            time.Time `json:"start_time"`
 	ElapsedTime          string    `json:"elapsed_time"`
+	// SkippedByReason counts the skipped files by reason code
+	SkippedByReason map[SkipReason]int `json:"skipped_by_reason,omitempty"`
 }
 
 // SummaryFormat defines available summary output formats.
@@ -37,9 +52,12 @@ type SummaryFormat string
 
 // SummaryOptions holds configuration for summary output.
 type SummaryOptions struct {
-	Format     SummaryFormat // Output format: text, json, none
-	ReportFile string        // File path to export JSON summary
-	IsVerbose  bool
+	Format      SummaryFormat // Output format: text, json, none
+	ReportFile  string        // File path to export JSON summary
+	IsVerbose   bool
+	CompareLast bool         // Compare with the summary of the previous run
+	HistoryFile string       // File path where the run summary is persisted for the next run
+	FailOnSkip  []SkipReason // Reason codes of the skipped files failing the run
 }
 
 const (
@@ -63,6 +81,11 @@ func (m *Metrics) Reset() {
 	m.DirectoriesProcessed = 0
 	m.ErrorsEncountered = 0
 	m.SkippedFiles = 0
+	m.Retries = 0
+	m.IOOperations = 0
+	m.IOBytes = 0
+	m.IORate = ""
+	m.ProcessedFiles = nil
 	m.ElapsedTime = ""
 	m.StartTime = time.Now()
 }
@@ -74,6 +97,14 @@ func (m *Metrics) IncrementFile() {
 	m.FilesProcessed++
 }
 
+// RecordProcessedFile updates the file counter and remembers the processed path.
+func (m *Metrics) RecordProcessedFile(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.FilesProcessed++
+	m.ProcessedFiles = append(m.ProcessedFiles, path)
+}
+
 // IncrementDirectory updates the directory counter.
 func (m *Metrics) IncrementDirectory() {
 	m.mu.Lock()
@@ -95,24 +126,53 @@ func (m *Metrics) IncrementSkippedFile() {
 	m.SkippedFiles++
 }
 
+// IncrementRetry updates the counter of the retried operations.
+func (m *Metrics) IncrementRetry() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Retries++
+}
+
+// SetIOThrottle sets the IO throttle whose achieved rate is reported in the summary.
+func (m *Metrics) SetIOThrottle(t *IOThrottle) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.throttle = t
+	if t != nil {
+		m.IOThrottle = t.String()
+	}
+}
+
+// RecordIO updates the counters of the throttled IO operations.
+func (m *Metrics) RecordIO(size int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.IOOperations++
+	m.IOBytes += size
+}
+
 // SummaryAsString generates and returns the processing summary in the requested format.
 func (m *Metrics) SummaryAsString(errors []ProcessingError, skippedFiles []ProcessingError, summaryOpts *SummaryOptions) (string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Update elapsed time before returning the summary
-	m.ElapsedTime = formatElapsedTime(time.Since(m.StartTime))
+	// Update elapsed time and IO rate before returning the summary
+	elapsed := time.Since(m.StartTime)
+	m.ElapsedTime = formatElapsedTime(elapsed)
+	if m.throttle != nil {
+		m.IORate = m.throttle.Achieved(m.IOOperations, m.IOBytes, elapsed)
+	}
 
 	// Determine format using a switch statement
 	switch summaryOpts.Format {
 	case FormatJSON:
 		return m.summaryAsJSON(errors, skippedFiles)
 	case FormatLong:
-		return m.summaryAsText(skippedFiles, summaryOpts.IsVerbose, false), nil
+		return m.summaryAsText(skippedFiles, summaryOpts.IsVerbose, false) + m.resultsBreakdown(errors, summaryOpts.IsVerbose), nil
 	case FormatCompact, "": // Default to compact
 		fallthrough
 	default:
-		return m.summaryAsText(skippedFiles, summaryOpts.IsVerbose, true), nil
+		return m.summaryAsText(skippedFiles, summaryOpts.IsVerbose, true) + m.resultsBreakdown(errors, summaryOpts.IsVerbose), nil
 	}
 }
 
@@ -161,7 +221,7 @@ func (m *Metrics) ToJSONFile(errors []ProcessingError, skippedFiles []Processing
 // summaryAsText returns the summary in human-readable text format.
 func (m *Metrics) summaryAsText(skippedFiles []ProcessingError, verbose bool, compact bool) string {
 	var sb strings.Builder
-	sb.WriteString("\n📋 Processing Summary:\n")
+	sb.WriteString("\n" + logger.Prefix("summary") + "Processing Summary:\n")
 
 	if compact {
 		sb.WriteString(m.generateCompactSummary())
@@ -180,28 +240,70 @@ func (m *Metrics) summaryAsText(skippedFiles []ProcessingError, verbose bool, co
 
 	if m.ErrorsEncountered > 0 {
 		bold := color.New(color.Bold).SprintFunc()
-		redIcon := color.New(color.FgRed, color.Bold).Sprint("✘")
+		redIcon := color.New(color.FgRed, color.Bold).Sprint(logger.Icon("error"))
 		sb.WriteString("\n" + redIcon + bold(" Some errors occurred. Check logs for details."))
 	}
 
 	return sb.String()
 }
 
-// generateCompactSummary creates a one-line summary.
+// resultsBreakdown lists processed and failed files when verbose is set.
+func (m *Metrics) resultsBreakdown(errors []ProcessingError, verbose bool) string {
+	if !verbose || (len(m.ProcessedFiles) == 0 && len(errors) == 0) {
+		return ""
+	}
+
+	var sb strings.Builder
+	faint := color.New(color.Faint).SprintFunc()
+
+	if len(m.ProcessedFiles) > 0 {
+		sb.WriteString("\n" + logger.Prefix("processed") + "Processed Files:\n")
+		for _, path := range m.ProcessedFiles {
+			fmt.Fprintf(&sb, "    - file: %s\n", faint(logger.DisplayPath(path)))
+		}
+	}
+
+	if len(errors) > 0 {
+		sb.WriteString("\n" + logger.Prefix("failed") + "Failed Files:\n")
+		for _, e := range errors {
+			fmt.Fprintf(&sb, "    - file: %s %s %s\n", faint(logger.DisplayPath(e.Source)), logger.Icon("arrow"), e.Message)
+		}
+	}
+
+	return sb.String()
+}
+
+// generateCompactSummary creates a one-line summary. Retries are shown when any occurred,
+// the IO rate when throttled.
 func (m *Metrics) generateCompactSummary() string {
-	return fmt.Sprintf("Files: %d | Dirs: %d | Skipped: %d | Errors: %d | Time: %s\n",
-		m.FilesProcessed, m.DirectoriesProcessed, m.SkippedFiles, m.ErrorsEncountered, m.ElapsedTime)
+	var retries, io string
+	if m.Retries > 0 {
+		retries = fmt.Sprintf(" | Retries: %d", m.Retries)
+	}
+	if m.IOThrottle != "" {
+		io = fmt.Sprintf(" | IO: %s (limit %s)", m.IORate, m.IOThrottle)
+	}
+	return fmt.Sprintf("Files: %d | Dirs: %d | Skipped: %d | Errors: %d%s%s | Time: %s\n",
+		m.FilesProcessed, m.DirectoriesProcessed, m.SkippedFiles, m.ErrorsEncountered, retries, io, m.ElapsedTime)
 }
 
-// generateDetailedSummary creates a multi-line summary.
+// generateDetailedSummary creates a multi-line summary. Retries are shown when any occurred,
+// the IO rate when throttled.
 func (m *Metrics) generateDetailedSummary() string {
-	return fmt.Sprintf("  - Total files processed: %d\n  - Total directories processed: %d\n  - Total skipped files: %d\n  - Total errors encountered: %d\n  - Elapsed time: %s\n",
-		m.FilesProcessed, m.DirectoriesProcessed, m.SkippedFiles, m.ErrorsEncountered, m.ElapsedTime)
+	var retries, io string
+	if m.Retries > 0 {
+		retries = fmt.Sprintf("  - Total retries: %d\n", m.Retries)
+	}
+	if m.IOThrottle != "" {
+		io = fmt.Sprintf("  - IO rate: %s (limit %s, %d operations)\n", m.IORate, m.IOThrottle, m.IOOperations)
+	}
+	return fmt.Sprintf("  - Total files processed: %d\n  - Total directories processed: %d\n  - Total skipped files: %d\n  - Total errors encountered: %d\n%s%s  - Elapsed time: %s\n",
+		m.FilesProcessed, m.DirectoriesProcessed, m.SkippedFiles, m.ErrorsEncountered, retries, io, m.ElapsedTime)
 }
 
 // appendSkippedFilesBreakdown processes and appends skipped file details.
 func (m *Metrics) appendSkippedFilesBreakdown(sb *strings.Builder, skippedFiles []ProcessingError) {
-	sb.WriteString("\n📌 Skipped Files Breakdown:\n")
+	sb.WriteString("\n" + logger.Prefix("skipped") + "Skipped Files Breakdown:\n")
 
 	categorized := m.groupSkippedFiles(skippedFiles)
 
@@ -212,6 +314,10 @@ func (m *Metrics) appendSkippedFilesBreakdown(sb *strings.Builder, skippedFiles
 		SkipUnchangedFile:    color.New(color.FgCyan, color.Bold).SprintFunc(),
 		SkipQueueFull:        color.New(color.FgRed, color.Bold).SprintFunc(),
 		SkipExcluded:         color.New(color.FgWhite, color.Bold).SprintFunc(),
+		SkipOutputConflict:   color.New(color.FgRed, color.Bold).SprintFunc(),
+		SkipSymlink:          color.New(color.FgBlue, color.Bold).SprintFunc(),
+		SkipManualEdits:      color.New(color.FgYellow, color.Bold).SprintFunc(),
+		SkipPrebuilt:         color.New(color.FgWhite, color.Bold).SprintFunc(),
 	}
 
 	// Output categorized skipped files
@@ -230,6 +336,18 @@ func (m *Metrics) appendSkippedFilesBreakdown(sb *strings.Builder, skippedFiles
 	formatSkippedCategory(sb, "Queue Overflow (Increase Workers)", categorized[SkipQueueFull], colorMap[SkipQueueFull], "Consider increasing the number of workers (--workers) to prevent queue overflow.")
 
 	formatSkippedCategory(sb, "Excluded Files (System & User-Specified)", categorized[SkipExcluded], colorMap[SkipExcluded], "Excluded as system files (e.g., .DS_Store) or by the '--exclude' flag.")
+
+	formatSkippedCategory(sb, "Output Conflicts", categorized[SkipOutputConflict], colorMap[SkipOutputConflict],
+		"Several input files target the same .templ file. Rename or merge them so each output has a single source.")
+
+	formatSkippedCategory(sb, "Symbolic Links", categorized[SkipSymlink], colorMap[SkipSymlink],
+		"Links skipped by the symlink policy, forming a cycle or leading to files already in the input folder. Set 'processor.symlinks' or '--symlinks' to change the policy.")
+
+	formatSkippedCategory(sb, "Kept Manual Edits", categorized[SkipManualEdits], colorMap[SkipManualEdits],
+		"Guard regions edited by hand and kept during '--interactive'. Move the changes to the source assets to keep them across syncs.")
+
+	formatSkippedCategory(sb, "Prebuilt Files", categorized[SkipPrebuilt], colorMap[SkipPrebuilt],
+		"Minified or binary files, matched by 'processor.skip_inputs.patterns' or detected by content. Sync their sources instead, or set 'processor.skip_inputs.enabled: false'.")
 }
 
 // groupSkippedFiles organizes skipped files into categories.
@@ -240,6 +358,10 @@ func (m *Metrics) groupSkippedFiles(skippedFiles []ProcessingError) map[SkipType
 		SkipMissingTemplFile: {},
 		SkipUnchangedFile:    {},
 		SkipQueueFull:        {},
+		SkipOutputConflict:   {},
+		SkipSymlink:          {},
+		SkipManualEdits:      {},
+		SkipPrebuilt:         {},
 	}
 
 	for _, file := range skippedFiles {
@@ -257,23 +379,37 @@ func (m *Metrics) summaryAsJSON(errors []ProcessingError, skippedFiles []Process
 		DirectoriesProcessed: m.DirectoriesProcessed,
 		ErrorsEncountered:    m.ErrorsEncountered,
 		SkippedFiles:         m.SkippedFiles,
+		Retries:              m.Retries,
+		IOOperations:         m.IOOperations,
+		IOBytes:              m.IOBytes,
+		IOThrottle:           m.IOThrottle,
+		IORate:               m.IORate,
 		StartTime:            m.StartTime,
 		ElapsedTime:          m.ElapsedTime,
 	}
+	if len(skippedFiles) > 0 {
+		exportData.SkippedByReason = CountSkipReasons(skippedFiles)
+	}
 
+	skippedFiles = displayErrors(skippedFiles)
 	data := struct {
 		Metrics      metricsExport                `json:"metrics"`
+		Succeeded    []string                     `json:"succeeded,omitempty"`
 		Errors       []ProcessingError            `json:"errors"`
 		SkippedFiles map[string][]ProcessingError `json:"skipped_files"`
 	}{
-		Metrics: exportData,
-		Errors:  errors,
+		Metrics:   exportData,
+		Succeeded: displayPaths(m.ProcessedFiles),
+		Errors:    displayErrors(errors),
 		SkippedFiles: map[string][]ProcessingError{
 			"unsupported_file":  filterSkippedFiles(skippedFiles, SkipUnsupportedFile),
 			"mismatched_output": filterSkippedFiles(skippedFiles, SkipMismatchedPath),
 			"missing_templ":     filterSkippedFiles(skippedFiles, SkipMissingTemplFile),
 			"unchanged_file":    filterSkippedFiles(skippedFiles, SkipUnchangedFile),
 			"queue_full":        filterSkippedFiles(skippedFiles, SkipQueueFull),
+			"output_conflict":   filterSkippedFiles(skippedFiles, SkipOutputConflict),
+			"symlink":           filterSkippedFiles(skippedFiles, SkipSymlink),
+			"prebuilt_file":     filterSkippedFiles(skippedFiles, SkipPrebuilt),
 		},
 	}
 
@@ -295,6 +431,31 @@ func filterSkippedFiles(files []ProcessingError, skipType SkipType) []Processing
 	return filtered
 }
 
+// displayPaths returns the paths as printed (see logger.DisplayPath).
+func displayPaths(paths []string) []string {
+	if paths == nil {
+		return nil
+	}
+	displayed := make([]string, len(paths))
+	for i, path := range paths {
+		displayed[i] = logger.DisplayPath(path)
+	}
+	return displayed
+}
+
+// displayErrors returns a copy of errs with their paths as printed (see logger.DisplayPath).
+func displayErrors(errs []ProcessingError) []ProcessingError {
+	if errs == nil {
+		return nil
+	}
+	displayed := make([]ProcessingError, len(errs))
+	for i, e := range errs {
+		e.Source, e.Dest = logger.DisplayPath(e.Source), logger.DisplayPath(e.Dest)
+		displayed[i] = e
+	}
+	return displayed
+}
+
 // formatSkippedCategory prints a section for a given category
 func formatSkippedCategory(
 	sb *strings.Builder,
@@ -323,9 +484,9 @@ func formatSkippedCategory(
 	// Print skipped file entries
 	for _, entry := range entries {
 		if entry.Dest != "" {
-			fmt.Fprintf(sb, "    - file: %s → Expected: %s\n", faint(entry.Source), faint(entry.Dest))
+			fmt.Fprintf(sb, "    - file: %s %s Expected: %s\n", faint(logger.DisplayPath(entry.Source)), logger.Icon("arrow"), faint(logger.DisplayPath(entry.Dest)))
 		} else {
-			fmt.Fprintf(sb, "    - file: %s\n", faint(entry.Source))
+			fmt.Fprintf(sb, "    - file: %s\n", faint(logger.DisplayPath(entry.Source)))
 		}
 	}
 }
@@ -332,7 +332,8 @@ func TestSummaryAsJSON(t *testing.T) {
             "errors_encountered": 1,
             "skipped_files": 3,
             "start_time": "0001-01-01T00:00:00Z",
-            "elapsed_time": "2.345s"
+            "elapsed_time": "2.345s",
+            "skipped_by_reason": {"ignored": 1, "missing-output": 1, "unchanged": 1}
           },
           "errors": [],
           "skipped_files": {
@@ -345,6 +346,9 @@ func TestSummaryAsJSON(t *testing.T) {
             ],
             "missing_templ": null,
             "queue_full": null,
+            "output_conflict": null,
+            "symlink": null,
+            "prebuilt_file": null,
             "unchanged_file": [
               {
                 "source": "input/template.templ",
@@ -404,7 +408,8 @@ func TestSummaryAsString_FormatJSON(t *testing.T) {
             "directories_processed": 2,
             "errors_encountered": 1,
             "skipped_files": 3,
-            "start_time": "0001-01-01T00:00:00Z"
+            "start_time": "0001-01-01T00:00:00Z",
+            "skipped_by_reason": {"ignored": 1, "missing-output": 1, "unchanged": 1}
           },
           "errors": [],
           "skipped_files": {
@@ -417,6 +422,9 @@ func TestSummaryAsString_FormatJSON(t *testing.T) {
             ],
             "missing_templ": null,
             "queue_full": null,
+            "output_conflict": null,
+            "symlink": null,
+            "prebuilt_file": null,
             "unchanged_file": [
               {
                 "source": "input/template.templ",
@@ -488,3 +496,62 @@ Files: 10 | Dirs: 2 | Skipped: 3 | Errors: 1
 		t.Errorf("Expected summary output:\n%s\nGot:\n%s", expectedOutput, result)
 	}
 }
+
+func TestMetrics_RecordProcessedFile(t *testing.T) {
+	m := NewMetrics()
+	m.RecordProcessedFile("assets/button.css")
+	m.RecordProcessedFile("assets/button.js")
+
+	if m.FilesProcessed != 2 {
+		t.Errorf("Expected 2 files processed, got %d", m.FilesProcessed)
+	}
+	if !reflect.DeepEqual(m.ProcessedFiles, []string{"assets/button.css", "assets/button.js"}) {
+		t.Errorf("Unexpected processed files: %v", m.ProcessedFiles)
+	}
+
+	m.Reset()
+	if len(m.ProcessedFiles) != 0 {
+		t.Errorf("Expected processed files to be cleared on reset, got %v", m.ProcessedFiles)
+	}
+}
+
+func TestSummaryAsString_ResultsBreakdown(t *testing.T) {
+	m := NewMetrics()
+	m.RecordProcessedFile("assets/ok.css")
+	m.IncrementError()
+
+	failed := []ProcessingError{{Source: "assets/broken.css", Message: "boom"}}
+
+	verbose, err := m.SummaryAsString(failed, nil, &SummaryOptions{Format: FormatLong, IsVerbose: true})
+	if err != nil {
+		t.Fatalf("SummaryAsString() returned error: %v", err)
+	}
+	for _, want := range []string{"Processed Files:", "assets/ok.css", "Failed Files:", "assets/broken.css", "boom"} {
+		if !strings.Contains(verbose, want) {
+			t.Errorf("Expected verbose summary to contain %q, got:\n%s", want, verbose)
+		}
+	}
+
+	compact, err := m.SummaryAsString(failed, nil, &SummaryOptions{Format: FormatCompact})
+	if err != nil {
+		t.Fatalf("SummaryAsString() returned error: %v", err)
+	}
+	if strings.Contains(compact, "Processed Files:") {
+		t.Errorf("Expected non-verbose summary to omit the results breakdown, got:\n%s", compact)
+	}
+
+	jsonSummary, err := m.SummaryAsString(failed, nil, &SummaryOptions{Format: FormatJSON})
+	if err != nil {
+		t.Fatalf("SummaryAsString() returned error: %v", err)
+	}
+	var parsed struct {
+		Succeeded []string          `json:"succeeded"`
+		Errors    []ProcessingError `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(jsonSummary), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON summary: %v", err)
+	}
+	if !reflect.DeepEqual(parsed.Succeeded, []string{"assets/ok.css"}) || len(parsed.Errors) != 1 {
+		t.Errorf("Unexpected JSON summary: %s", jsonSummary)
+	}
+}
@@ -0,0 +1,191 @@
+package worker
+
+import (
+	"bytes"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// OutputRule sends the input files matching a glob to another output folder.
+//
+// Input is a slash-separated glob relative to the input folder, where "**" matches
+// any number of folders (e.g. "admin/**" or "**/*.css"). Output is a template of the
+// output folder, with .OutputDir (the default output folder) and .Component (the
+// component folder of the matched file) as data. A matched file keeps its path
+// relative to the static prefix of the glob: with "admin/**" and
+// "internal/admin/ui", assets/admin/button/button.css is injected into
+// internal/admin/ui/button/button.templ.
+//
+// File, when set, is a template of the .templ file name in the output folder, with
+// .Name (the matched file name without extension) as extra data: all the matched
+// files go to that single file. Region, when set, names the guard region the files
+// are injected into, e.g. "base" for the [tempo:base] markers, so that several
+// inputs can share the same .templ file.
+type OutputRule struct {
+	Input  string
+	Output string
+	File   string
+	Region string
+	base   string // Leading folders of Input without wildcards
+	tmpl   *template.Template
+	file   *template.Template
+}
+
+// outputRuleData is the data of the OutputRule templates.
+type outputRuleData struct {
+	OutputDir string
+	Component string
+	Name      string
+}
+
+// regionNamePattern matches the valid guard region names.
+var regionNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// NewOutputRule validates the glob and parses the output template of a rule.
+func NewOutputRule(input, output string) (OutputRule, error) {
+	input = strings.Trim(filepath.ToSlash(strings.TrimSpace(input)), "/")
+	if input == "" {
+		return OutputRule{}, apperrors.WrapCode(apperrors.CodeInvalidOutputRules, "output rule: missing input glob")
+	}
+	for _, segment := range strings.Split(input, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return OutputRule{}, apperrors.WrapCode(apperrors.CodeInvalidOutputRules, "output rule: invalid input glob %s", err, input)
+		}
+	}
+	if strings.TrimSpace(output) == "" {
+		return OutputRule{}, apperrors.WrapCode(apperrors.CodeInvalidOutputRules, "output rule %s: missing output folder", input)
+	}
+
+	tmpl, err := template.New(input).Option("missingkey=error").Parse(output)
+	if err != nil {
+		return OutputRule{}, apperrors.WrapCode(apperrors.CodeInvalidOutputRules, "output rule %s: invalid output template", err, input)
+	}
+	if err := tmpl.Execute(&bytes.Buffer{}, outputRuleData{}); err != nil {
+		return OutputRule{}, apperrors.WrapCode(apperrors.CodeInvalidOutputRules, "output rule %s: invalid output template", err, input)
+	}
+
+	return OutputRule{Input: input, Output: output, base: globBase(input), tmpl: tmpl}, nil
+}
+
+// WithRegion returns a copy of the rule sending the matched files to the .templ file
+// named by the file template, when not empty, and to the guard region named region,
+// when not empty.
+func (r OutputRule) WithRegion(file, region string) (OutputRule, error) {
+	if file = strings.TrimSpace(file); file != "" {
+		tmpl, err := template.New(r.Input).Option("missingkey=error").Parse(file)
+		if err != nil {
+			return OutputRule{}, apperrors.WrapCode(apperrors.CodeInvalidOutputRules, "output rule %s: invalid file template", err, r.Input)
+		}
+		if err := tmpl.Execute(&bytes.Buffer{}, outputRuleData{}); err != nil {
+			return OutputRule{}, apperrors.WrapCode(apperrors.CodeInvalidOutputRules, "output rule %s: invalid file template", err, r.Input)
+		}
+		r.File, r.file = file, tmpl
+	}
+	if region = strings.TrimSpace(region); region != "" {
+		if !regionNamePattern.MatchString(region) {
+			return OutputRule{}, apperrors.WrapCode(apperrors.CodeInvalidOutputRules, "output rule %s: invalid region name %s", r.Input, region)
+		}
+		r.Region = region
+	}
+	return r, nil
+}
+
+// OutputPath returns the .templ file an input file is injected into: the one given by
+// the first rule matching the file, or the same path in outputDir otherwise.
+func OutputPath(source, inputDir, outputDir string, rules []OutputRule) string {
+	dest, _ := OutputTarget(source, inputDir, outputDir, rules)
+	return dest
+}
+
+// OutputTarget returns the .templ file an input file is injected into, as OutputPath,
+// and the guard region set by the matching rule, empty for the default one.
+func OutputTarget(source, inputDir, outputDir string, rules []OutputRule) (dest, region string) {
+	if len(rules) > 0 {
+		rel, err := filepath.Rel(inputDir, source)
+		if err == nil && !strings.HasPrefix(rel, "..") {
+			rel = filepath.ToSlash(rel)
+			for _, rule := range rules {
+				if dest, ok := rule.outputPath(rel, outputDir); ok {
+					return dest, rule.Region
+				}
+			}
+		}
+	}
+	return utils.RebasePathToOutput(source, inputDir, outputDir), ""
+}
+
+// outputPath returns the .templ file of the input file at rel, relative to the input
+// folder, when it matches the rule.
+func (r OutputRule) outputPath(rel, outputDir string) (string, bool) {
+	if r.tmpl == nil || !matchGlob(r.Input, rel) {
+		return "", false
+	}
+
+	rest := strings.TrimPrefix(strings.TrimPrefix(rel, r.base), "/")
+	component := path.Base(r.base)
+	if first, _, found := strings.Cut(rest, "/"); found {
+		component = first
+	}
+
+	name := path.Base(rel)
+	data := outputRuleData{OutputDir: outputDir, Component: component, Name: strings.TrimSuffix(name, path.Ext(name))}
+	var dir bytes.Buffer
+	if err := r.tmpl.Execute(&dir, data); err != nil {
+		return "", false
+	}
+	if r.file != nil {
+		var file bytes.Buffer
+		if err := r.file.Execute(&file, data); err != nil || strings.TrimSpace(file.String()) == "" {
+			return "", false
+		}
+		rest = file.String()
+	}
+	return utils.ToTemplFilename(filepath.Join(filepath.Clean(dir.String()), filepath.FromSlash(rest))), true
+}
+
+// globBase returns the leading folders of pattern without wildcards. The last
+// segment is never part of it, as it names the matched files.
+func globBase(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	var base []string
+	for _, segment := range segments[:len(segments)-1] {
+		if strings.ContainsAny(segment, "*?[\\") {
+			break
+		}
+		base = append(base, segment)
+	}
+	return strings.Join(base, "/")
+}
+
+// matchGlob reports whether the slash-separated name matches pattern, where "**"
+// matches zero or more folders and other segments follow path.Match.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
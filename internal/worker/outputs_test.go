@@ -0,0 +1,155 @@
+package worker
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNewOutputRule_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		output string
+	}{
+		{"Missing input", "", "ui"},
+		{"Invalid glob", "admin/[", "ui"},
+		{"Missing output", "admin/**", " "},
+		{"Invalid template", "admin/**", "ui/{{ .Component"},
+		{"Unknown field", "admin/**", "ui/{{ .Package }}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewOutputRule(tt.input, tt.output); err == nil {
+				t.Errorf("Expected an error for input %q and output %q", tt.input, tt.output)
+			}
+		})
+	}
+}
+
+func TestOutputPath(t *testing.T) {
+	newRule := func(input, output string) OutputRule {
+		t.Helper()
+		rule, err := NewOutputRule(input, output)
+		if err != nil {
+			t.Fatalf("Failed to create output rule: %v", err)
+		}
+		return rule
+	}
+
+	rules := []OutputRule{
+		newRule("admin/**", "internal/admin/ui"),
+		newRule("**/*.js", "{{ .OutputDir }}/scripts/{{ .Component }}"),
+		newRule("tokens/colors.css", "theme"),
+	}
+
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"assets/button/base.css", "components/button/base.templ"},
+		{"assets/admin/table/css/base.css", "internal/admin/ui/table/css/base.templ"},
+		{"assets/modal/modal.js", "components/scripts/modal/modal/modal.templ"},
+		{"assets/tokens/colors.css", "theme/colors.templ"},
+		{"assets/tokens/sizes.css", "components/tokens/sizes.templ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			got := OutputPath(filepath.FromSlash(tt.source), "assets", "components", rules)
+			if got != filepath.FromSlash(tt.want) {
+				t.Errorf("OutputPath(%s) = %s, want %s", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputTarget(t *testing.T) {
+	newRule := func(input, output, file, region string) OutputRule {
+		t.Helper()
+		rule, err := NewOutputRule(input, output)
+		if err == nil {
+			rule, err = rule.WithRegion(file, region)
+		}
+		if err != nil {
+			t.Fatalf("Failed to create output rule: %v", err)
+		}
+		return rule
+	}
+
+	rules := []OutputRule{
+		newRule("*/css/base.css", "{{ .OutputDir }}/{{ .Component }}", "{{ .Component }}", "base"),
+		newRule("*/css/variants/*.css", "{{ .OutputDir }}/{{ .Component }}", "{{ .Component }}", "variants"),
+		newRule("card/**", "{{ .OutputDir }}/card", "", "card"),
+		newRule("theme/*.css", "theme", "{{ .Name }}-tokens.templ", ""),
+	}
+
+	tests := []struct {
+		source string
+		want   string
+		region string
+	}{
+		{"assets/button/css/base.css", "components/button/button.templ", "base"},
+		{"assets/button/css/variants/primary.css", "components/button/button.templ", "variants"},
+		{"assets/card/css/card.css", "components/card/css/card.templ", "card"},
+		{"assets/theme/colors.css", "theme/colors-tokens.templ", ""},
+		{"assets/modal/modal.css", "components/modal/modal.templ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			got, region := OutputTarget(filepath.FromSlash(tt.source), "assets", "components", rules)
+			if got != filepath.FromSlash(tt.want) || region != tt.region {
+				t.Errorf("OutputTarget(%s) = %s, %q, want %s, %q", tt.source, got, region, tt.want, tt.region)
+			}
+		})
+	}
+}
+
+func TestOutputRule_WithRegion_Invalid(t *testing.T) {
+	rule, err := NewOutputRule("button/**", "ui")
+	if err != nil {
+		t.Fatalf("Failed to create output rule: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		file   string
+		region string
+	}{
+		{"Invalid file template", "{{ .Name", ""},
+		{"Unknown field", "{{ .Package }}", ""},
+		{"Invalid region", "", "base region"},
+		{"Region with a bracket", "", "base]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := rule.WithRegion(tt.file, tt.region); err == nil {
+				t.Errorf("Expected an error for file %q and region %q", tt.file, tt.region)
+			}
+		})
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"admin/**", "admin/button/base.css", true},
+		{"admin/**", "button/base.css", false},
+		{"**/*.css", "base.css", true},
+		{"**/*.css", "a/b/base.css", true},
+		{"**/*.css", "a/b/base.js", false},
+		{"*/css/*.css", "button/css/base.css", true},
+		{"*/css/*.css", "button/base.css", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"time"
+)
+
+// Default values of a RetryPolicy.
+const (
+	DefaultRetryBackoff    = 100 * time.Millisecond
+	DefaultRetryMaxBackoff = 5 * time.Second
+)
+
+// transientErrors are the file system errors worth retrying, as sporadically returned
+// by network file systems.
+var transientErrors = []error{
+	syscall.EBUSY,
+	syscall.ETIMEDOUT,
+	syscall.EAGAIN,
+	syscall.EINTR,
+	syscall.ESTALE,
+}
+
+// RetryPolicy retries the processing of a file failing with a transient file system
+// error. The delay before a retry starts at Backoff and doubles after each retry, up
+// to MaxBackoff. The zero value does not retry.
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt (0 to disable)
+	Backoff    time.Duration // Delay before the first retry (default: DefaultRetryBackoff)
+	MaxBackoff time.Duration // Maximum delay between retries (default: DefaultRetryMaxBackoff)
+}
+
+// IsTransientError reports whether err is a file system error that may succeed when retried.
+func IsTransientError(err error) bool {
+	for _, target := range transientErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Do runs fn until it succeeds, fails with a non-transient error, the retries are
+// exhausted or ctx is done. onRetry is called before each retry.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error, onRetry func(attempt int, err error)) error {
+	err := fn()
+	for attempt := 1; attempt <= p.MaxRetries && err != nil && IsTransientError(err); attempt++ {
+		timer := time.NewTimer(p.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		if onRetry != nil {
+			onRetry(attempt, err)
+		}
+		err = fn()
+	}
+	return err
+}
+
+// delay returns the wait before the given retry, starting from 1.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	backoff := p.Backoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+
+	delay := backoff
+	for i := 1; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
@@ -0,0 +1,138 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestIsTransientError(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "button.templ", Err: syscall.EBUSY}
+
+	if !IsTransientError(pathErr) {
+		t.Error("Expected a wrapped EBUSY to be transient")
+	}
+	if IsTransientError(&fs.PathError{Op: "open", Path: "button.templ", Err: syscall.ENOENT}) {
+		t.Error("Expected ENOENT not to be transient")
+	}
+	if IsTransientError(errors.New("boom")) {
+		t.Error("Expected a plain error not to be transient")
+	}
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond}
+
+	t.Run("Transient error then success", func(t *testing.T) {
+		calls, retries := 0, 0
+		err := policy.Do(context.Background(), func() error {
+			calls++
+			if calls < 3 {
+				return syscall.ETIMEDOUT
+			}
+			return nil
+		}, func(int, error) { retries++ })
+
+		if err != nil || calls != 3 || retries != 2 {
+			t.Errorf("Expected success after 2 retries, got err=%v calls=%d retries=%d", err, calls, retries)
+		}
+	})
+
+	t.Run("Retries exhausted", func(t *testing.T) {
+		calls := 0
+		err := policy.Do(context.Background(), func() error {
+			calls++
+			return syscall.EBUSY
+		}, nil)
+
+		if !errors.Is(err, syscall.EBUSY) || calls != 4 {
+			t.Errorf("Expected the last error after 4 attempts, got err=%v calls=%d", err, calls)
+		}
+	})
+
+	t.Run("Permanent error", func(t *testing.T) {
+		calls := 0
+		err := policy.Do(context.Background(), func() error {
+			calls++
+			return errors.New("invalid guard markers")
+		}, nil)
+
+		if err == nil || calls != 1 {
+			t.Errorf("Expected no retry of a permanent error, got err=%v calls=%d", err, calls)
+		}
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		calls := 0
+		err := RetryPolicy{MaxRetries: 3, Backoff: time.Hour}.Do(ctx, func() error {
+			calls++
+			return syscall.EBUSY
+		}, nil)
+
+		if err == nil || calls != 1 {
+			t.Errorf("Expected no retry once canceled, got err=%v calls=%d", err, calls)
+		}
+	})
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	for attempt, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 300 * time.Millisecond,
+		8: 300 * time.Millisecond,
+	} {
+		if got := policy.delay(attempt); got != want {
+			t.Errorf("delay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+
+	if got := (RetryPolicy{}).delay(1); got != DefaultRetryBackoff {
+		t.Errorf("Expected the default backoff, got %v", got)
+	}
+}
+
+// flakyProcessor fails with EBUSY until failures reaches zero.
+type flakyProcessor struct {
+	failures int
+}
+
+func (p *flakyProcessor) Process(input, output, marker string) error {
+	if p.failures > 0 {
+		p.failures--
+		return &fs.PathError{Op: "write", Path: output, Err: syscall.EBUSY}
+	}
+	return nil
+}
+
+func TestProcessFile_Retry(t *testing.T) {
+	manager := &WorkerPoolManager{
+		Factory: &MockProcessorFactory{Processor: &flakyProcessor{failures: 2}},
+		Metrics: NewMetrics(),
+		Retry:   RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond},
+	}
+
+	if err := processFile(context.Background(), Job{InputPath: "style.css", OutputPath: "style.templ"}, manager, false); err != nil {
+		t.Fatalf("Expected the file to be processed after retries, got %v", err)
+	}
+	if manager.Metrics.Retries != 2 {
+		t.Errorf("Expected 2 retries in the metrics, got %d", manager.Metrics.Retries)
+	}
+
+	summary, err := manager.Metrics.SummaryAsString(nil, nil, &SummaryOptions{Format: FormatCompact})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(summary, "Retries: 2") {
+		t.Errorf("Expected the retries in the summary, got %q", summary)
+	}
+}
@@ -0,0 +1,157 @@
+package worker
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/processor"
+)
+
+// DefaultSkipPatterns are the globs of the minified assets skipped when none is configured.
+var DefaultSkipPatterns = []string{"*.min.css", "*.min.js"}
+
+const (
+	// sniffSize is the number of bytes read from the start of a file to detect its content.
+	sniffSize = 8 * 1024
+	// minifiedMinSize is the smallest sample read as minified: short files have few lines anyway.
+	minifiedMinSize = 512
+	// minifiedLineLength is the average line length above which CSS or JS reads as minified.
+	minifiedLineLength = 250
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// InputSkipper detects the already built input files, e.g. minified bundles or binary
+// files, which are left out of sync. A nil InputSkipper skips nothing.
+type InputSkipper struct {
+	patterns []string
+	sniff    bool
+}
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTOR                                                               */
+/* ------------------------------------------------------------------------- */
+
+// NewInputSkipper validates the globs of the skipped files, matched against the file
+// name when they have no slash and against the path relative to the input folder
+// otherwise, and defaults them to DefaultSkipPatterns. With sniff, the start of the
+// files is read to detect the minified CSS and JS and the binary files.
+func NewInputSkipper(patterns []string, sniff bool) (*InputSkipper, error) {
+	if len(patterns) == 0 {
+		patterns = DefaultSkipPatterns
+	}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			return nil, apperrors.Wrap("invalid processor.skip_inputs: empty pattern")
+		}
+		for segment := range strings.SplitSeq(pattern, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return nil, apperrors.Wrap("invalid processor.skip_inputs pattern %s", err, pattern)
+			}
+		}
+	}
+	return &InputSkipper{patterns: patterns, sniff: sniff}, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* PUBLIC METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// SkipReason returns why the input file at source, in inputDir, is skipped, or an
+// empty string when it is synced. Only the files of a synced type are sniffed, read
+// from input (nil for the OS file system).
+func (s *InputSkipper) SkipReason(source, inputDir string, input *processor.InputFS, languages processor.AssetLanguages) (string, error) {
+	if s == nil {
+		return "", nil
+	}
+
+	rel, err := filepath.Rel(inputDir, source)
+	if err != nil {
+		rel = source
+	}
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range s.patterns {
+		if matchSkipPattern(pattern, rel) {
+			return "Matches the skip_inputs pattern " + pattern, nil
+		}
+	}
+
+	if !s.sniff || !languages.Supports(source) {
+		return "", nil
+	}
+	sample, err := readSample(input, source)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case isBinary(sample):
+		return "Binary content", nil
+	case processor.GetLoader(filepath.Ext(source)) != api.LoaderNone && isMinified(sample):
+		return "Minified content", nil
+	}
+	return "", nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// matchSkipPattern reports whether rel, a slash-separated path relative to the input
+// folder, matches pattern: by file name for a pattern without slash, by path otherwise.
+func matchSkipPattern(pattern, rel string) bool {
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(rel))
+		return ok
+	}
+	return matchGlob(pattern, rel)
+}
+
+// readSample reads up to sniffSize bytes from the start of the input file at source.
+func readSample(input *processor.InputFS, source string) ([]byte, error) {
+	f, err := input.Open(source)
+	if err != nil {
+		return nil, apperrors.Wrap("failed to open file", err, source)
+	}
+	defer f.Close()
+
+	sample := make([]byte, sniffSize)
+	n, err := io.ReadFull(f, sample)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, apperrors.Wrap("failed to read file", err, source)
+	}
+	return sample[:n], nil
+}
+
+// isBinary reports whether sample holds a NUL byte or is not valid UTF-8, ignoring a
+// rune cut by the end of the sample.
+func isBinary(sample []byte) bool {
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return true
+	}
+	for i := 0; i < utf8.UTFMax && len(sample) > 0; i++ {
+		if utf8.Valid(sample) {
+			return false
+		}
+		sample = sample[:len(sample)-1]
+	}
+	return len(sample) > 0
+}
+
+// isMinified reports whether the lines of sample are too long to be written by hand.
+func isMinified(sample []byte) bool {
+	if len(sample) < minifiedMinSize {
+		return false
+	}
+	lines := bytes.Count(sample, []byte("\n")) + 1
+	return len(sample)/lines > minifiedLineLength
+}
@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/indaco/tempo/internal/processor"
+)
+
+func TestInputSkipper_SkipReason(t *testing.T) {
+	languages, err := processor.NewAssetLanguages(processor.AssetLanguage{Extension: ".json"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	fsys := fstest.MapFS{
+		"button/button.css":      {Data: []byte(".btn {\n  color: red;\n}\n")},
+		"button/button.min.css":  {Data: []byte(".btn{color:red}")},
+		"button/script.js":       {Data: []byte(strings.Repeat("var a=1;", 100))},
+		"button/tokens.json":     {Data: []byte(`{"color":"` + strings.Repeat("a", 1000) + `"}`)},
+		"button/image.css":       {Data: []byte("\x89PNG\r\n\x1a\n\x00\x00")},
+		"vendor/lib/theme.css":   {Data: []byte("body {}\n")},
+		"button/short.js":        {Data: []byte("var a=1;")},
+		"button/cut-rune.css":    {Data: []byte(strings.Repeat("a {}\n", 1638) + "xé")},
+		"button/unsupported.txt": {Data: []byte("\x00")},
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		sniff    bool
+		file     string
+		want     string
+	}{
+		{"hand-written CSS", nil, true, "button/button.css", ""},
+		{"default pattern", nil, true, "button/button.min.css", "Matches the skip_inputs pattern *.min.css"},
+		{"minified JS", nil, true, "button/script.js", "Minified content"},
+		{"long JSON is not minified", nil, true, "button/tokens.json", ""},
+		{"binary", nil, true, "button/image.css", "Binary content"},
+		{"short file", nil, true, "button/short.js", ""},
+		{"rune cut by the sample", nil, true, "button/cut-rune.css", ""},
+		{"unsupported file is not sniffed", nil, true, "button/unsupported.txt", ""},
+		{"no sniffing", nil, false, "button/script.js", ""},
+		{"path pattern", []string{"vendor/**"}, true, "vendor/lib/theme.css", "Matches the skip_inputs pattern vendor/**"},
+		{"custom patterns replace the defaults", []string{"vendor/**"}, false, "button/button.min.css", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skipper, err := NewInputSkipper(tt.patterns, tt.sniff)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			input := processor.NewInputFS(fsys, "assets")
+			got, err := skipper.SkipReason(filepath.Join("assets", filepath.FromSlash(tt.file)), "assets", input, languages)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected reason %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInputSkipper_Nil(t *testing.T) {
+	var skipper *InputSkipper
+	got, err := skipper.SkipReason("assets/button.min.css", "assets", nil, nil)
+	if err != nil || got != "" {
+		t.Errorf("Expected a nil skipper to skip nothing, got %q, %v", got, err)
+	}
+}
+
+func TestNewInputSkipper_InvalidPattern(t *testing.T) {
+	for _, patterns := range [][]string{{" "}, {"vendor/[a"}} {
+		if _, err := NewInputSkipper(patterns, true); err == nil {
+			t.Errorf("Expected an error for patterns %q", patterns)
+		}
+	}
+}
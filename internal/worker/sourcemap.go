@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// SourceMapVersion is the version of the source map file format.
+const SourceMapVersion = 1
+
+// LineRange is an inclusive, 1-based range of lines. End is lower than Start
+// when the range is empty.
+type LineRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// SourceMapEntry maps the guard region of a .templ file back to its source asset.
+type SourceMapEntry struct {
+	Templ       string    `json:"templ"`
+	Region      LineRange `json:"region"` // Lines between the guard markers, watermark included
+	Source      string    `json:"source"`
+	SourceLines LineRange `json:"source_lines"`
+}
+
+// SourceMap maps the injected .templ regions back to the editable asset files.
+type SourceMap struct {
+	Version int              `json:"version"`
+	Entries []SourceMapEntry `json:"entries"`
+}
+
+// BuildSourceMap builds the source map for the given jobs from the current content of
+// their input and output files, the input files being read from input (nil for the OS
+// file system). Jobs with an unsupported input, a missing output, no valid guard
+// markers or an output shared with other inputs are left out.
+func BuildSourceMap(jobs []Job, markerName string, input *processor.InputFS) (SourceMap, error) {
+	sourceMap := SourceMap{Version: SourceMapVersion, Entries: []SourceMapEntry{}}
+	conflicts := FindOutputConflicts(jobs, nil)
+
+	for _, job := range jobs {
+		if processor.GetLoader(filepath.Ext(job.InputPath)) == api.LoaderNone {
+			continue
+		}
+		if _, ok := conflicts[job.Target()]; ok {
+			continue
+		}
+
+		output, err := os.ReadFile(job.OutputPath)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return SourceMap{}, apperrors.Wrap("failed to read output file", err, job.OutputPath)
+		}
+
+		start, end, ok := processor.GuardRegion(string(output), job.Marker(markerName))
+		if !ok {
+			continue
+		}
+
+		input, err := input.ReadFile(job.InputPath)
+		if err != nil {
+			return SourceMap{}, apperrors.Wrap("failed to read input file", err, job.InputPath)
+		}
+
+		sourceMap.Entries = append(sourceMap.Entries, SourceMapEntry{
+			Templ:       filepath.ToSlash(job.OutputPath),
+			Region:      LineRange{Start: start, End: end},
+			Source:      filepath.ToSlash(job.InputPath),
+			SourceLines: LineRange{Start: 1, End: countLines(input)},
+		})
+	}
+
+	slices.SortFunc(sourceMap.Entries, func(a, b SourceMapEntry) int {
+		return strings.Compare(a.Templ, b.Templ)
+	})
+	return sourceMap, nil
+}
+
+// SaveSourceMap writes the source map as JSON to path.
+func SaveSourceMap(path string, sourceMap SourceMap) error {
+	return utils.WriteJSONToFile(path, sourceMap)
+}
+
+// countLines returns the number of lines of data, ignoring a trailing newline.
+func countLines(data []byte) int {
+	if len(data) == 0 {
+		return 0
+	}
+	return bytes.Count(bytes.TrimSuffix(data, []byte("\n")), []byte("\n")) + 1
+}
@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestBuildSourceMap(t *testing.T) {
+	tempDir := t.TempDir()
+	begin, end := processor.GuardMarkers("tempo")
+
+	files := map[string]string{
+		"assets/button.css":       ".a {}\n.b {}\n.c {}\n",
+		"components/button.templ": strings.Join([]string{"templ Button() {", "<style>", begin, ".a{}.b{}.c{}", end, "</style>", "}"}, "\n"),
+		"assets/card.css":         ".card {}",
+		"components/card.templ":   "templ Card() {}",
+		"assets/notes.txt":        "ignored",
+		"assets/missing.css":      ".missing {}",
+	}
+	for path, content := range files {
+		testutils.CreateFile(t, filepath.Join(tempDir, path), content)
+	}
+
+	job := func(name, ext string) Job {
+		return Job{
+			InputPath:  filepath.Join(tempDir, "assets", name+ext),
+			OutputPath: filepath.Join(tempDir, "components", name+".templ"),
+		}
+	}
+	jobs := []Job{job("card", ".css"), job("button", ".css"), job("notes", ".txt"), job("missing", ".css")}
+
+	sourceMap, err := BuildSourceMap(jobs, "tempo", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if sourceMap.Version != SourceMapVersion {
+		t.Errorf("Expected version %d, got %d", SourceMapVersion, sourceMap.Version)
+	}
+	if len(sourceMap.Entries) != 1 {
+		t.Fatalf("Expected only the button entry, got %+v", sourceMap.Entries)
+	}
+
+	entry := sourceMap.Entries[0]
+	if !strings.HasSuffix(entry.Templ, "components/button.templ") || !strings.HasSuffix(entry.Source, "assets/button.css") {
+		t.Errorf("Unexpected paths: %+v", entry)
+	}
+	if entry.Region != (LineRange{Start: 4, End: 4}) {
+		t.Errorf("Expected region 4-4, got %+v", entry.Region)
+	}
+	if entry.SourceLines != (LineRange{Start: 1, End: 3}) {
+		t.Errorf("Expected source lines 1-3, got %+v", entry.SourceLines)
+	}
+}
+
+func TestBuildSourceMap_SkipsConflictingOutputs(t *testing.T) {
+	tempDir := t.TempDir()
+	begin, end := processor.GuardMarkers("tempo")
+
+	output := filepath.Join(tempDir, "components", "base.templ")
+	testutils.CreateFile(t, output, strings.Join([]string{"<style>", begin, end, "</style>"}, "\n"))
+	testutils.CreateFile(t, filepath.Join(tempDir, "assets", "base.css"), ".a {}")
+	testutils.CreateFile(t, filepath.Join(tempDir, "assets", "base.js"), "let a;")
+
+	jobs := []Job{
+		{InputPath: filepath.Join(tempDir, "assets", "base.css"), OutputPath: output},
+		{InputPath: filepath.Join(tempDir, "assets", "base.js"), OutputPath: output},
+	}
+
+	sourceMap, err := BuildSourceMap(jobs, "tempo", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sourceMap.Entries) != 0 {
+		t.Errorf("Expected no entries for a conflicting output, got %+v", sourceMap.Entries)
+	}
+}
+
+func TestSaveSourceMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sourcemap.json")
+	want := SourceMap{
+		Version: SourceMapVersion,
+		Entries: []SourceMapEntry{{
+			Templ:       "components/button.templ",
+			Region:      LineRange{Start: 4, End: 10},
+			Source:      "assets/button.css",
+			SourceLines: LineRange{Start: 1, End: 7},
+		}},
+	}
+
+	if err := SaveSourceMap(path, want); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read source map: %v", err)
+	}
+	var got SourceMap
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Failed to parse source map: %v", err)
+	}
+	if got.Version != want.Version || len(got.Entries) != 1 || got.Entries[0] != want.Entries[0] {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+func TestCountLines(t *testing.T) {
+	tests := map[string]int{
+		"":           0,
+		"a":          1,
+		"a\n":        1,
+		"a\nb":       2,
+		"a\nb\n":     2,
+		"a\n\nb\n\n": 4,
+	}
+	for input, want := range tests {
+		if got := countLines([]byte(input)); got != want {
+			t.Errorf("countLines(%q) = %d, want %d", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+// opsSuffix is the suffix of an IO throttle in operations per second.
+const opsSuffix = "ops/s"
+
+// IOThrottle limits the disk IO of all the workers to Rate bytes, or operations with
+// PerOp, per second. Each file read or write counts as one operation. Operations are
+// paced rather than burst: an operation waits until the previous ones fit in the rate.
+type IOThrottle struct {
+	Rate  float64 // Bytes or operations per second
+	PerOp bool    // Rate counts operations instead of bytes
+	limit string  // Limit as set, e.g. "5MB/s"
+	next  time.Time
+	mu    sync.Mutex
+}
+
+// ParseIOThrottle parses an IO throttle such as "5MB/s", "512KB/s" or "200ops/s".
+// An empty value returns nil, disabling the throttling.
+func ParseIOThrottle(value string) (*IOThrottle, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	t := &IOThrottle{limit: value}
+	var err error
+	if number, ok := strings.CutSuffix(value, opsSuffix); ok {
+		t.PerOp = true
+		t.Rate, err = strconv.ParseFloat(strings.TrimSpace(number), 64)
+	} else if size, ok := strings.CutSuffix(value, "/s"); ok {
+		var bytes int64
+		bytes, err = utils.ParseBytes(size)
+		t.Rate = float64(bytes)
+	} else {
+		err = fmt.Errorf("missing unit, expected <size>/s or <n>%s", opsSuffix)
+	}
+	if err == nil && t.Rate <= 0 {
+		err = fmt.Errorf("rate must be positive")
+	}
+	if err != nil {
+		return nil, apperrors.Wrap("invalid IO throttle '%s'", err, value)
+	}
+	return t, nil
+}
+
+// String returns the limit as set, e.g. "5MB/s".
+func (t *IOThrottle) String() string {
+	return t.limit
+}
+
+// Wait blocks until an operation of size bytes fits in the rate, or ctx is done.
+// A nil IOThrottle does not wait.
+func (t *IOThrottle) Wait(ctx context.Context, size int64) error {
+	if t == nil {
+		return nil
+	}
+
+	cost := 1.0
+	if !t.PerOp {
+		cost = float64(size)
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	start := t.next
+	if start.Before(now) {
+		start = now
+	}
+	t.next = start.Add(time.Duration(cost / t.Rate * float64(time.Second)))
+	t.mu.Unlock()
+
+	delay := time.Until(start)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Achieved returns the rate of the given operations and bytes over elapsed, in the
+// unit of the throttle, e.g. "4.9 MB/s".
+func (t *IOThrottle) Achieved(ops int, bytes int64, elapsed time.Duration) string {
+	seconds := elapsed.Seconds()
+	if seconds <= 0 {
+		return ""
+	}
+	if t.PerOp {
+		return fmt.Sprintf("%.1f %s", float64(ops)/seconds, opsSuffix)
+	}
+	return utils.FormatBytes(int64(float64(bytes)/seconds)) + "/s"
+}
@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIOThrottle(t *testing.T) {
+	tests := []struct {
+		value   string
+		rate    float64
+		perOp   bool
+		wantErr bool
+	}{
+		{value: "5MB/s", rate: 5 << 20},
+		{value: "512 KB/s", rate: 512 << 10},
+		{value: "200ops/s", rate: 200, perOp: true},
+		{value: "0.5 ops/s", rate: 0.5, perOp: true},
+		{value: "5MB", wantErr: true},
+		{value: "0ops/s", wantErr: true},
+		{value: "fastops/s", wantErr: true},
+		{value: "5PB/s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		throttle, err := ParseIOThrottle(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error", tt.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.value, err)
+		}
+		if throttle.Rate != tt.rate || throttle.PerOp != tt.perOp || throttle.String() != tt.value {
+			t.Errorf("%q: unexpected throttle %+v", tt.value, throttle)
+		}
+	}
+
+	if throttle, err := ParseIOThrottle(""); throttle != nil || err != nil {
+		t.Errorf("Expected no throttle for an empty value, got %v, %v", throttle, err)
+	}
+}
+
+func TestIOThrottle_Wait(t *testing.T) {
+	var disabled *IOThrottle
+	if err := disabled.Wait(context.Background(), 1<<30); err != nil {
+		t.Errorf("Expected a nil throttle not to wait, got %v", err)
+	}
+
+	throttle, err := ParseIOThrottle("100ops/s")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	start := time.Now()
+	for range 4 {
+		if err := throttle.Wait(context.Background(), 0); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	// The first operation starts at once, the next ones every 10ms
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Expected the operations to be paced, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow, _ := ParseIOThrottle("1KB/s")
+	_ = slow.Wait(ctx, 1<<20)
+	if err := slow.Wait(ctx, 1<<20); err == nil {
+		t.Error("Expected the wait to stop with the canceled context")
+	}
+}
+
+func TestIOThrottle_Achieved(t *testing.T) {
+	bytes, _ := ParseIOThrottle("5MB/s")
+	if got := bytes.Achieved(10, 4<<20, 2*time.Second); got != "2.0 MB/s" {
+		t.Errorf("Expected 2.0 MB/s, got %q", got)
+	}
+	ops, _ := ParseIOThrottle("200ops/s")
+	if got := ops.Achieved(50, 0, 500*time.Millisecond); got != "100.0 ops/s" {
+		t.Errorf("Expected 100.0 ops/s, got %q", got)
+	}
+}
+
+func TestMetrics_IOThrottle(t *testing.T) {
+	throttle, _ := ParseIOThrottle("5MB/s")
+	metrics := NewMetrics()
+	metrics.SetIOThrottle(throttle)
+	metrics.RecordIO(1024)
+	metrics.RecordIO(2048)
+
+	if metrics.IOOperations != 2 || metrics.IOBytes != 3072 {
+		t.Errorf("Unexpected IO counters: %d operations, %d bytes", metrics.IOOperations, metrics.IOBytes)
+	}
+
+	summary, err := metrics.SummaryAsString(nil, nil, &SummaryOptions{Format: FormatCompact})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(summary, "| IO: ") || !strings.Contains(summary, "(limit 5MB/s)") {
+		t.Errorf("Expected the IO rate in the summary, got %q", summary)
+	}
+	if metrics.IORate == "" {
+		t.Error("Expected the achieved IO rate to be set")
+	}
+}
@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+)
+
+// Symbolic link policies applied when walking the input folder.
+const (
+	SymlinkFollow = "follow" // Walk linked files and folders as if they were inside the input folder
+	SymlinkSkip   = "skip"   // Leave symbolic links out, reporting them as skipped
+	SymlinkError  = "error"  // Report symbolic links as errors
+)
+
+// DefaultSymlinkPolicy is the policy used when none is configured.
+const DefaultSymlinkPolicy = SymlinkFollow
+
+// SymlinkPolicies lists the valid symbolic link policies.
+var SymlinkPolicies = []string{SymlinkFollow, SymlinkSkip, SymlinkError}
+
+var (
+	// ErrSymlinkSkipped is passed to the walk function for links left out by the skip policy.
+	ErrSymlinkSkipped = errors.New("symbolic link skipped by the symlink policy")
+	// ErrSymlinkNotAllowed is passed to the walk function for links met with the error policy.
+	ErrSymlinkNotAllowed = errors.New("symbolic links are not allowed by the symlink policy")
+	// ErrSymlinkCycle is passed to the walk function for links to a folder containing
+	// the folders being walked.
+	ErrSymlinkCycle = errors.New("symbolic link cycle")
+	// ErrSymlinkDuplicate is passed to the walk function for links to a file or folder
+	// already walked through its real path, which would otherwise be processed twice.
+	ErrSymlinkDuplicate = errors.New("symbolic link to a path already in the input folder")
+)
+
+// ValidateSymlinkPolicy returns an error when policy is neither empty nor a valid policy.
+func ValidateSymlinkPolicy(policy string) error {
+	if policy != "" && !slices.Contains(SymlinkPolicies, policy) {
+		return apperrors.Wrap("invalid symlink policy %s (valid: %s)", policy, strings.Join(SymlinkPolicies, ", "))
+	}
+	return nil
+}
+
+// WalkInputDir walks root like filepath.WalkDir, applying policy to the symbolic links
+// it meets. With the follow policy, linked folders are walked under the path of the link,
+// so that output paths are rebased from the link location and not from its target, and
+// linked files are reported with the entry of their target. Links that are not followed
+// are reported with an error: ErrSymlinkCycle or ErrSymlinkDuplicate when the target
+// contains or is inside a folder being walked, ErrSymlinkSkipped or ErrSymlinkNotAllowed
+// when left out by the policy. The walk function decides whether these errors stop the walk.
+func WalkInputDir(root, policy string, fn fs.WalkDirFunc) error {
+	if err := ValidateSymlinkPolicy(policy); err != nil {
+		return err
+	}
+	if policy == "" {
+		policy = DefaultSymlinkPolicy
+	}
+
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return fn(root, nil, err)
+	}
+	return walkInputDir(realRoot, root, policy, []string{realRoot}, fn)
+}
+
+// WalkInputFS walks fsys like WalkInputDir, reporting its entries under root, the input
+// folder it stands for, so that their output paths are resolved as for files of root.
+func WalkInputFS(fsys fs.FS, root string, fn fs.WalkDirFunc) error {
+	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		return fn(filepath.Join(root, filepath.FromSlash(name)), d, err)
+	})
+}
+
+// walkInputDir walks the real folder dir, reporting its entries under displayDir.
+// chain holds the real paths of the folders being walked, from the root.
+func walkInputDir(dir, displayDir, policy string, chain []string, fn fs.WalkDirFunc) error {
+	return filepath.WalkDir(dir, func(realPath string, d fs.DirEntry, err error) error {
+		path := displayDir
+		if rel, relErr := filepath.Rel(dir, realPath); relErr == nil && rel != "." {
+			path = filepath.Join(displayDir, rel)
+		}
+		if err != nil || d.Type()&fs.ModeSymlink == 0 {
+			return fn(path, d, err)
+		}
+
+		switch policy {
+		case SymlinkSkip:
+			return fn(path, d, ErrSymlinkSkipped)
+		case SymlinkError:
+			return fn(path, d, ErrSymlinkNotAllowed)
+		}
+
+		target, err := filepath.EvalSymlinks(realPath)
+		if err != nil {
+			return fn(path, d, err) // Broken link
+		}
+		info, err := os.Stat(target)
+		if err != nil {
+			return fn(path, d, err)
+		}
+
+		switch {
+		case info.IsDir() && slices.ContainsFunc(chain, func(walked string) bool { return isWithin(walked, target) }):
+			return fn(path, d, ErrSymlinkCycle)
+		case slices.ContainsFunc(chain, func(walked string) bool { return isWithin(target, walked) }):
+			return fn(path, d, ErrSymlinkDuplicate)
+		case !info.IsDir():
+			return fn(path, linkedEntry{name: d.Name(), info: info}, nil)
+		}
+		return walkInputDir(target, path, policy, append(slices.Clip(chain), target), fn)
+	})
+}
+
+// isWithin reports whether path is dir or inside it.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// linkedEntry is the fs.DirEntry of a followed link to a file, named after the
+// link and described by its target.
+type linkedEntry struct {
+	name string
+	info fs.FileInfo
+}
+
+func (e linkedEntry) Name() string               { return e.name }
+func (e linkedEntry) IsDir() bool                { return e.info.IsDir() }
+func (e linkedEntry) Type() fs.FileMode          { return e.info.Mode().Type() }
+func (e linkedEntry) Info() (fs.FileInfo, error) { return e.info, nil }
@@ -0,0 +1,165 @@
+package worker
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+// walkResult records the files reported by WalkInputDir and the errors of the
+// entries it did not follow.
+type walkResult struct {
+	files  []string
+	errors map[string]error
+}
+
+func walk(t *testing.T, root, policy string) walkResult {
+	t.Helper()
+
+	result := walkResult{errors: make(map[string]error)}
+	err := WalkInputDir(root, policy, func(path string, d fs.DirEntry, err error) error {
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			t.Fatalf("Unexpected path outside the root: %s", path)
+		}
+		rel = filepath.ToSlash(rel)
+		if err != nil {
+			result.errors[rel] = err
+			return nil
+		}
+		if !d.IsDir() {
+			result.files = append(result.files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	slices.Sort(result.files)
+	return result
+}
+
+func symlink(t *testing.T, target, link string) {
+	t.Helper()
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("Symbolic links not supported: %v", err)
+	}
+}
+
+// setupSymlinkTree creates an input folder with a link to a shared tokens folder
+// outside of it, a link to a file inside it and a link to its parent.
+func setupSymlinkTree(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "tokens", "colors.css"), ":root{}")
+	writeTestFile(t, filepath.Join(dir, "assets", "button", "base.css"), ".btn{}")
+
+	symlink(t, filepath.Join(dir, "tokens"), filepath.Join(dir, "assets", "tokens"))
+	symlink(t, filepath.Join(dir, "assets", "button", "base.css"), filepath.Join(dir, "assets", "alias.css"))
+	symlink(t, dir, filepath.Join(dir, "assets", "button", "up"))
+	return filepath.Join(dir, "assets")
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}
+
+func TestWalkInputDir_Follow(t *testing.T) {
+	root := setupSymlinkTree(t)
+
+	result := walk(t, root, SymlinkFollow)
+
+	expected := []string{"button/base.css", "tokens/colors.css"}
+	if !slices.Equal(result.files, expected) {
+		t.Errorf("Expected files %v, got %v", expected, result.files)
+	}
+	if !errors.Is(result.errors["button/up"], ErrSymlinkCycle) {
+		t.Errorf("Expected a cycle for button/up, got %v", result.errors["button/up"])
+	}
+	if !errors.Is(result.errors["alias.css"], ErrSymlinkDuplicate) {
+		t.Errorf("Expected a duplicate for alias.css, got %v", result.errors["alias.css"])
+	}
+}
+
+func TestWalkInputDir_Skip(t *testing.T) {
+	root := setupSymlinkTree(t)
+
+	result := walk(t, root, SymlinkSkip)
+
+	if !slices.Equal(result.files, []string{"button/base.css"}) {
+		t.Errorf("Expected only the regular file, got %v", result.files)
+	}
+	for _, link := range []string{"tokens", "alias.css", "button/up"} {
+		if !errors.Is(result.errors[link], ErrSymlinkSkipped) {
+			t.Errorf("Expected %s to be skipped, got %v", link, result.errors[link])
+		}
+	}
+}
+
+func TestWalkInputDir_Error(t *testing.T) {
+	root := setupSymlinkTree(t)
+
+	result := walk(t, root, SymlinkError)
+
+	if !errors.Is(result.errors["tokens"], ErrSymlinkNotAllowed) {
+		t.Errorf("Expected tokens to be rejected, got %v", result.errors["tokens"])
+	}
+}
+
+func TestWalkInputDir_SymlinkedRoot(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "shared", "base.css"), ".btn{}")
+	root := filepath.Join(dir, "assets")
+	symlink(t, filepath.Join(dir, "shared"), root)
+
+	result := walk(t, root, "")
+
+	if !slices.Equal(result.files, []string{"base.css"}) {
+		t.Errorf("Expected the files of the linked root, got %v", result.files)
+	}
+}
+
+func TestWalkInputDir_InvalidPolicy(t *testing.T) {
+	err := WalkInputDir(t.TempDir(), "copy", func(string, fs.DirEntry, error) error { return nil })
+	if err == nil {
+		t.Fatal("Expected an error for an invalid policy")
+	}
+}
+
+func TestWalkInputFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"button/css/base.css": {Data: []byte(".btn{}")},
+		"card/js/card.js":     {Data: []byte("let a;")},
+	}
+	root := filepath.Join("web", "assets")
+
+	var files []string
+	err := WalkInputFS(fsys, root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, filepath.ToSlash(path))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{"web/assets/button/css/base.css", "web/assets/card/js/card.js"}
+	if !slices.Equal(files, want) {
+		t.Errorf("Expected the files under the input folder %v, got %v", want, files)
+	}
+}
@@ -3,17 +3,22 @@ package worker
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"time"
 
-	"github.com/evanw/esbuild/pkg/api"
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/logger"
 	"github.com/indaco/tempo/internal/processor"
-	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/progress"
 )
 
-// WorkerPool processes files concurrently and updates metrics.
+// WorkerPool processes files concurrently and updates metrics. The written .templ files
+// are recorded on the file recorder of the generator.ActionHooks found in ctx, if any.
 func WorkerPool(ctx context.Context, m *WorkerPoolManager, trackExecution bool) error {
+	recorder := generator.ActionHooksFromContext(ctx).Recorder
 	for {
 		select {
 		case <-ctx.Done(): // Exit when context is canceled
@@ -23,7 +28,7 @@ func WorkerPool(ctx context.Context, m *WorkerPoolManager, trackExecution bool)
 				return nil
 			}
 
-			if skipReason, skipType := shouldSkipFile(job, m.InputDir, m.OutputDir); skipReason != "" {
+			if skipReason, skipType := shouldSkipFile(job, m.InputDir, m.OutputDir, m.OutputRules, m.Languages); skipReason != "" {
 				// Note: Do not increment skipped count here - the collector goroutine
 				// in sync.go handles counting all skipped files (both from workers
 				// and from queueing) to avoid double-counting.
@@ -41,16 +46,24 @@ func WorkerPool(ctx context.Context, m *WorkerPoolManager, trackExecution bool)
 				continue
 			}
 
-			if err := processFile(job, m, trackExecution); err != nil {
+			m.Progress.Emit(progress.Record{Event: progress.EventStarted, File: job.InputPath, Dest: job.OutputPath})
+			if err := processFile(ctx, job, m, trackExecution); err != nil {
 				m.Metrics.IncrementError()
 				select {
 				case m.ErrorsChan <- FormatError(job.InputPath, err):
 				default:
 				}
+				if m.FailFast {
+					return apperrors.Wrap("failed to process file", err, job.InputPath)
+				}
 				continue
 			}
 
-			m.Metrics.IncrementFile()
+			m.Metrics.RecordProcessedFile(job.InputPath)
+			if recorder != nil {
+				recorder.Record(job.OutputPath)
+			}
+			m.Progress.Emit(progress.Record{Event: progress.EventDone, File: job.InputPath, Dest: job.OutputPath})
 		}
 	}
 }
@@ -60,16 +73,14 @@ func WorkerPool(ctx context.Context, m *WorkerPoolManager, trackExecution bool)
 /* ------------------------------------------------------------------------- */
 
 // shouldSkipFile checks if a file should be skipped and returns the reason.
-func shouldSkipFile(job Job, inputDir, outputDir string) (string, SkipType) {
-	ext := filepath.Ext(job.InputPath)
-
+func shouldSkipFile(job Job, inputDir, outputDir string, rules []OutputRule, languages processor.AssetLanguages) (string, SkipType) {
 	// Unsupported file type
-	if processor.GetLoader(ext) == api.LoaderNone {
-		return "Unsupported file type (not CSS or JS)", SkipUnsupportedFile
+	if !languages.Supports(job.InputPath) {
+		return "Unsupported file type (not CSS, JS or a processor.languages extension)", SkipUnsupportedFile
 	}
 
 	// Ensure output structure matches expectations
-	expectedOutput := utils.RebasePathToOutput(job.InputPath, inputDir, outputDir)
+	expectedOutput := OutputPath(job.InputPath, inputDir, outputDir, rules)
 	// Ensure the expected `.templ` file actually exists
 	if _, err := os.Stat(expectedOutput); os.IsNotExist(err) {
 		return "Missing corresponding .templ file in output directory", SkipMissingTemplFile
@@ -83,12 +94,30 @@ func shouldSkipFile(job Job, inputDir, outputDir string) (string, SkipType) {
 	return "", "" // No skipping required
 }
 
-// processFile processes a single job and optionally tracks execution time.
-func processFile(job Job, m *WorkerPoolManager, trackExecution bool) error {
+// processFile processes a single job, retrying it on transient file system errors
+// as set by the retry policy, and optionally tracks execution time.
+func processFile(ctx context.Context, job Job, m *WorkerPoolManager, trackExecution bool) error {
 	start := time.Now()
 
 	processor := m.Factory.GetProcessor(job.InputPath)
-	err := processor.Process(job.InputPath, job.OutputPath, m.MarkerName)
+	err := m.Retry.Do(ctx, func() error {
+		if err := throttleIO(ctx, m, job.InputPath, m.Input.Stat); err != nil {
+			return err
+		}
+		// Jobs injecting into distinct regions of the same file must not interleave
+		unlock := m.lockOutput(job.OutputPath)
+		err := processor.Process(job.InputPath, job.OutputPath, job.Marker(m.MarkerName))
+		unlock()
+		if err != nil {
+			return err
+		}
+		return throttleIO(ctx, m, job.OutputPath, os.Stat)
+	}, func(int, error) {
+		m.Metrics.IncrementRetry()
+	})
+	if err == nil && m.Scoper != nil && filepath.Ext(job.InputPath) == ".css" {
+		err = writeScopedClasses(m, job)
+	}
 
 	// Ensure execution time tracking is recorded
 	if trackExecution {
@@ -98,6 +127,31 @@ func processFile(job Job, m *WorkerPoolManager, trackExecution bool) error {
 	return err
 }
 
+// throttleIO waits for the IO throttle, if any, on an operation on the file at path,
+// sized with stat, and records it in the metrics.
+func throttleIO(ctx context.Context, m *WorkerPoolManager, path string, stat func(string) (fs.FileInfo, error)) error {
+	if m.Throttle == nil {
+		return nil
+	}
+	var size int64
+	if info, err := stat(path); err == nil {
+		size = info.Size()
+	}
+	m.Metrics.RecordIO(size)
+	return m.Throttle.Wait(ctx, size)
+}
+
+// writeScopedClasses writes the Go map of the scoped class names of a CSS job next to
+// its .templ file.
+func writeScopedClasses(m *WorkerPoolManager, job Job) error {
+	content, err := m.Input.ReadFile(job.InputPath)
+	if err != nil {
+		return apperrors.Wrap("failed to read input file", err, job.InputPath)
+	}
+	_, classes := m.Scoper.Scope(job.InputPath, string(content))
+	return m.Scoper.WriteClassesFile(job.InputPath, job.OutputPath, classes, m.FileModes)
+}
+
 // recordExecutionTime safely stores job execution time in WorkerPoolManager.
 func recordExecutionTime(m *WorkerPoolManager, filePath string, duration time.Duration) {
 	// Store execution time with mutex protection
@@ -106,7 +160,11 @@ func recordExecutionTime(m *WorkerPoolManager, filePath string, duration time.Du
 	m.mu.Unlock()
 
 	// Print outside mutex to avoid holding lock during I/O
-	fmt.Printf("Processed %s (took %v)\n", filePath, duration)
+	output := m.Output
+	if output == nil {
+		output = os.Stdout
+	}
+	fmt.Fprintf(output, "Processed %s (took %v)\n", logger.DisplayPath(filePath), duration)
 }
 
 // isValidOutputPath checks if the generated output path matches expectations.
@@ -6,9 +6,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/indaco/tempo/internal/generator"
 	"github.com/indaco/tempo/internal/processor"
 	"github.com/indaco/tempo/internal/utils"
 )
@@ -148,7 +151,7 @@ func TestShouldSkipFile(t *testing.T) {
 	}
 
 	// Case 1: Unsupported file type
-	skipReason, skipType := shouldSkipFile(Job{InputPath: unsupportedFile}, inputDir, outputDir)
+	skipReason, skipType := shouldSkipFile(Job{InputPath: unsupportedFile}, inputDir, outputDir, nil, nil)
 	if skipReason == "" || skipType != SkipUnsupportedFile {
 		t.Errorf("Expected unsupported file type skip, got: %s (%v)", skipReason, skipType)
 	}
@@ -159,7 +162,7 @@ func TestShouldSkipFile(t *testing.T) {
 		t.Fatalf("Failed to create CSS file: %v", err)
 	}
 
-	skipReason, skipType = shouldSkipFile(Job{InputPath: cssFile}, inputDir, outputDir)
+	skipReason, skipType = shouldSkipFile(Job{InputPath: cssFile}, inputDir, outputDir, nil, nil)
 	if skipReason == "" || skipType != SkipMissingTemplFile {
 		t.Errorf("Expected missing .templ file skip, got: %s (%v)", skipReason, skipType)
 	}
@@ -171,14 +174,14 @@ func TestShouldSkipFile(t *testing.T) {
 	}
 
 	invalidOutputFile := filepath.Join(outputDir, "invalid-style.templ")
-	skipReason, skipType = shouldSkipFile(Job{InputPath: cssFile, OutputPath: invalidOutputFile}, inputDir, outputDir)
+	skipReason, skipType = shouldSkipFile(Job{InputPath: cssFile, OutputPath: invalidOutputFile}, inputDir, outputDir, nil, nil)
 	if skipReason == "" || skipType != SkipMismatchedPath {
 		t.Errorf("Expected mismatched output structure skip, got: %s (%v)", skipReason, skipType)
 	}
 
 	// Case 4: Valid case (no skipping required)
 	validJob := Job{InputPath: cssFile, OutputPath: expectedTemplFile}
-	skipReason, skipType = shouldSkipFile(validJob, inputDir, outputDir)
+	skipReason, skipType = shouldSkipFile(validJob, inputDir, outputDir, nil, nil)
 	if skipReason != "" || skipType != "" {
 		t.Errorf("Expected no skipping for valid case, but got: %s (%v)", skipReason, skipType)
 	}
@@ -216,7 +219,7 @@ func TestProcessFile(t *testing.T) {
 		OutputPath: "style.templ",
 	}
 
-	err := processFile(job, mockManager, false)
+	err := processFile(context.Background(), job, mockManager, false)
 	if err != nil {
 		t.Fatalf("Expected processFile to succeed but got error: %v", err)
 	}
@@ -305,3 +308,183 @@ func TestRecordExecutionTime(t *testing.T) {
 		t.Errorf("Expected console output containing: %q, got: %q", expectedOutput, output)
 	}
 }
+
+func TestRecordExecutionTime_Output(t *testing.T) {
+	var buf strings.Builder
+	m := &WorkerPoolManager{Output: &buf}
+
+	recordExecutionTime(m, "testfile.css", time.Second)
+
+	if got, want := buf.String(), "Processed testfile.css (took 1s)\n"; got != want {
+		t.Errorf("Expected output %q, got %q", want, got)
+	}
+}
+
+type failingProcessor struct{}
+
+func (p *failingProcessor) Process(input, _, _ string) error {
+	return fmt.Errorf("cannot process %s", filepath.Base(input))
+}
+
+func TestWorkerPool_FailFast(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	outputDir := filepath.Join(tempDir, "output")
+
+	names := []string{"a", "b", "c"}
+	for _, name := range names {
+		for _, path := range []string{filepath.Join(inputDir, name+".css"), filepath.Join(outputDir, name+".templ")} {
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatalf("Failed to create directories: %v", err)
+			}
+			if err := os.WriteFile(path, []byte(""), 0644); err != nil {
+				t.Fatalf("Failed to create file %s: %v", path, err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name           string
+		failFast       bool
+		expectErr      bool
+		expectedErrors int
+	}{
+		{name: "drain all jobs by default", failFast: false, expectErr: false, expectedErrors: len(names)},
+		{name: "stop on first failure", failFast: true, expectErr: true, expectedErrors: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewWorkerPoolManager(WorkerPoolOptions{
+				Context:    context.Background(),
+				InputDir:   inputDir,
+				OutputDir:  outputDir,
+				NumWorkers: 1,
+				IsFailFast: tt.failFast,
+			})
+			manager.Factory = &MockProcessorFactory{Processor: &failingProcessor{}}
+
+			for _, name := range names {
+				manager.JobChan <- Job{
+					InputPath:  filepath.Join(inputDir, name+".css"),
+					OutputPath: filepath.Join(outputDir, name+".templ"),
+				}
+			}
+			close(manager.JobChan)
+
+			err := manager.StartWorkers(context.Background(), 1, false)
+			if tt.expectErr != (err != nil) {
+				t.Fatalf("StartWorkers() error = %v, expectErr %v", err, tt.expectErr)
+			}
+
+			close(manager.ErrorsChan)
+			collected := CollectErrors(manager.ErrorsChan)
+			if len(collected) != tt.expectedErrors {
+				t.Errorf("Expected %d collected errors, got %d", tt.expectedErrors, len(collected))
+			}
+			if manager.Metrics.ErrorsEncountered != tt.expectedErrors {
+				t.Errorf("Expected %d errors in metrics, got %d", tt.expectedErrors, manager.Metrics.ErrorsEncountered)
+			}
+		})
+	}
+}
+
+func TestWorkerPool_RecordsWrittenFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	outputDir := filepath.Join(tempDir, "output")
+
+	names := []string{"a", "b"}
+	for _, name := range names {
+		for _, path := range []string{filepath.Join(inputDir, name+".css"), filepath.Join(outputDir, name+".templ")} {
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatalf("Failed to create directories: %v", err)
+			}
+			if err := os.WriteFile(path, []byte(""), 0644); err != nil {
+				t.Fatalf("Failed to create file %s: %v", path, err)
+			}
+		}
+	}
+
+	recorder := generator.NewFileRecorder()
+	ctx := generator.WithActionHooks(context.Background(), func(h *generator.ActionHooks) { h.Recorder = recorder })
+
+	manager := NewWorkerPoolManager(WorkerPoolOptions{
+		Context:    ctx,
+		InputDir:   inputDir,
+		OutputDir:  outputDir,
+		NumWorkers: 1,
+	})
+	manager.Factory = &MockProcessorFactory{Processor: &MockProcessor{}}
+
+	// Both regions of b.templ are injected: the file is recorded once
+	for _, name := range []string{"a", "b", "b"} {
+		manager.JobChan <- Job{
+			InputPath:  filepath.Join(inputDir, name+".css"),
+			OutputPath: filepath.Join(outputDir, name+".templ"),
+		}
+	}
+	close(manager.JobChan)
+
+	if err := manager.StartWorkers(ctx, 1, false); err != nil {
+		t.Fatalf("StartWorkers() error = %v", err)
+	}
+
+	expected := []string{filepath.Join(outputDir, "a.templ"), filepath.Join(outputDir, "b.templ")}
+	if got := recorder.Paths(); !slices.Equal(got, expected) {
+		t.Errorf("Expected the written files %v, got %v", expected, got)
+	}
+}
+
+func TestProcessFile_ScopedCSS(t *testing.T) {
+	tempDir := t.TempDir()
+	inputPath := filepath.Join(tempDir, "assets", "button", "button.css")
+	outputPath := filepath.Join(tempDir, "components", "button", "button.templ")
+
+	if err := os.MkdirAll(filepath.Dir(inputPath), 0755); err != nil {
+		t.Fatalf("Failed to create input folder: %v", err)
+	}
+	if err := os.WriteFile(inputPath, []byte(".btn { color: red; }"), 0644); err != nil {
+		t.Fatalf("Failed to write input file: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		t.Fatalf("Failed to create output folder: %v", err)
+	}
+	templ := "package button\n\n/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo] END */\n"
+	if err := os.WriteFile(outputPath, []byte(templ), 0644); err != nil {
+		t.Fatalf("Failed to write templ file: %v", err)
+	}
+
+	scoper, err := processor.NewCSSScoper(processor.ScopePrefix, filepath.Join(tempDir, "assets"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	opts := WorkerPoolOptions{
+		InputDir:   filepath.Join(tempDir, "assets"),
+		OutputDir:  filepath.Join(tempDir, "components"),
+		MarkerName: "tempo",
+		NumWorkers: 1,
+		ScopedCSS:  scoper,
+	}
+	manager := NewWorkerPoolManager(opts)
+
+	if err := processFile(context.Background(), Job{InputPath: inputPath, OutputPath: outputPath}, manager, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read templ file: %v", err)
+	}
+	if !strings.Contains(string(content), ".button__btn { color: red; }") {
+		t.Errorf("Expected scoped class in templ file, got:\n%s", content)
+	}
+
+	classes, err := os.ReadFile(filepath.Join(filepath.Dir(outputPath), "button"+processor.ClassesFileSuffix))
+	if err != nil {
+		t.Fatalf("Expected classes file: %v", err)
+	}
+	if !strings.Contains(string(classes), `"btn": "button__btn"`) || !strings.Contains(string(classes), "package button") {
+		t.Errorf("Unexpected classes file:\n%s", classes)
+	}
+}
@@ -0,0 +1,242 @@
+// Package actions is a builder API to define the tempo actions files in Go, with type
+// safety and IDE completion instead of hand-written JSON.
+//
+// A definitions program declares the action sets and calls Main:
+//
+//	package main
+//
+//	import "github.com/indaco/tempo/pkg/actions"
+//
+//	func main() {
+//		actions.Define("component").
+//			RenderFile("component/templ/component.templ.gotxt", "{{ .GoPackage }}/{{ .ComponentName }}/{{ .ComponentName }}.templ").
+//			RenderFile("component/assets/js/script.js.gotxt", "{{ .AssetsDir }}/{{ .ComponentName }}/js/script.js", actions.OnlyIfJs())
+//		actions.Main()
+//	}
+//
+// `tempo define from-go ./defs` runs the program and writes each set to the actions
+// folder, e.g. component.json.
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/utils"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Action is an action as written to the actions files.
+type Action = generator.JSONAction
+
+// Option sets an optional field of an action.
+type Option func(*Action)
+
+// Set is a named list of actions, written to the <name>.json actions file. Its methods
+// record the invalid actions, reported by Actions.
+type Set struct {
+	name    string
+	actions []Action
+	errs    []error
+}
+
+// registry holds the sets declared with Define, in declaration order.
+var registry []*Set
+
+/* ------------------------------------------------------------------------- */
+/* CONSTRUCTORS                                                              */
+/* ------------------------------------------------------------------------- */
+
+// New returns an empty set of actions named name, e.g. "component" for component.json.
+func New(name string) *Set {
+	return &Set{name: name}
+}
+
+// Define returns a new set of actions named name and registers it for Main.
+func Define(name string) *Set {
+	s := New(name)
+	registry = append(registry, s)
+	return s
+}
+
+/* ------------------------------------------------------------------------- */
+/* OPTIONS                                                                   */
+/* ------------------------------------------------------------------------- */
+
+// OnlyIfJs includes the action only with --js (or the full tier).
+func OnlyIfJs() Option { return func(a *Action) { a.OnlyIfJs = true } }
+
+// OnlyIfTsDecls includes the action only when the TypeScript declarations are enabled.
+func OnlyIfTsDecls() Option { return func(a *Action) { a.OnlyIfTsDecls = true } }
+
+// SkipIfExists leaves the output file unchanged when it already exists.
+func SkipIfExists() Option { return func(a *Action) { a.SkipIfExists = true } }
+
+// Force overwrites the output files when they exist.
+func Force() Option { return func(a *Action) { a.Force = true } }
+
+// Engine sets the template engine, e.g. "handlebars".
+func Engine(name string) Option { return func(a *Action) { a.Engine = name } }
+
+// Mode sets the octal mode of the rendered files, e.g. "0664".
+func Mode(mode string) Option { return func(a *Action) { a.Mode = mode } }
+
+// Tier sets the tier of the definition, e.g. "full".
+func Tier(tier string) Option { return func(a *Action) { a.Tier = tier } }
+
+// Type selects a plugin action, e.g. "header".
+func Type(actionType string) Option { return func(a *Action) { a.Type = actionType } }
+
+/* ------------------------------------------------------------------------- */
+/* BUILDER METHODS                                                           */
+/* ------------------------------------------------------------------------- */
+
+// Name returns the name of the set.
+func (s *Set) Name() string {
+	return s.name
+}
+
+// RenderFile adds an action rendering templateFile, relative to the templates folder,
+// to path.
+func (s *Set) RenderFile(templateFile, path string, opts ...Option) *Set {
+	return s.add(Action{Item: "file", TemplateFile: templateFile, Path: path}, opts)
+}
+
+// RenderFolder adds an action rendering the templates under source, relative to the
+// templates folder, into destination.
+func (s *Set) RenderFolder(source, destination string, opts ...Option) *Set {
+	return s.add(Action{Item: "folder", Source: source, Destination: destination}, opts)
+}
+
+// Actions returns the actions of the set, and an error listing the invalid ones.
+func (s *Set) Actions() ([]Action, error) {
+	errs := slices.Clone(s.errs)
+	if err := validateName(s.name); err != nil {
+		errs = append([]error{err}, errs...)
+	}
+	if len(errs) > 0 {
+		return nil, joinErrors(s.name, errs)
+	}
+	return slices.Clone(s.actions), nil
+}
+
+// JSON returns the set in the canonical format of the actions files.
+func (s *Set) JSON() ([]byte, error) {
+	actions, err := s.Actions()
+	if err != nil {
+		return nil, err
+	}
+	return generator.MarshalActionsJSON(actions)
+}
+
+/* ------------------------------------------------------------------------- */
+/* PROGRAM OUTPUT                                                            */
+/* ------------------------------------------------------------------------- */
+
+// Encode writes the sets to w as a JSON object of the actions by set name, as read by
+// Decode.
+func Encode(w io.Writer, sets ...*Set) error {
+	out := make(map[string][]Action, len(sets))
+	for _, s := range sets {
+		actions, err := s.Actions()
+		if err != nil {
+			return err
+		}
+		if _, ok := out[s.name]; ok {
+			return apperrors.Wrap("action set '%s' is defined twice", s.name)
+		}
+		out[s.name] = actions
+	}
+
+	content, err := json.Marshal(out)
+	if err != nil {
+		return apperrors.Wrap("failed to encode action sets", err)
+	}
+	_, err = w.Write(append(content, '\n'))
+	return err
+}
+
+// Decode reads the sets written by Encode, and validates their names.
+func Decode(r io.Reader) (map[string][]Action, error) {
+	var sets map[string][]Action
+	if err := json.NewDecoder(r).Decode(&sets); err != nil {
+		return nil, apperrors.Wrap("failed to decode action sets", err)
+	}
+	for name := range sets {
+		if err := validateName(name); err != nil {
+			return nil, err
+		}
+	}
+	return sets, nil
+}
+
+// Main writes the sets declared with Define to the standard output (see Encode), and
+// exits with status 1 when one is invalid. It is called by the main function of the
+// definitions program.
+func Main() {
+	if err := Encode(os.Stdout, registry...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// add applies opts to action, validates it and appends it to the set.
+func (s *Set) add(action Action, opts []Option) *Set {
+	for _, opt := range opts {
+		opt(&action)
+	}
+	if err := validateAction(action); err != nil {
+		s.errs = append(s.errs, apperrors.Wrap("action %s", err, len(s.actions)+len(s.errs)+1))
+		return s
+	}
+	s.actions = append(s.actions, action)
+	return s
+}
+
+// validateAction reports the missing fields and the invalid mode and tier of action.
+func validateAction(action Action) error {
+	switch {
+	case action.Item == "file" && (action.TemplateFile == "" || action.Path == ""):
+		return apperrors.Wrap("a file action needs a template file and a path")
+	case action.Item == "folder" && (action.Source == "" || action.Destination == ""):
+		return apperrors.Wrap("a folder action needs a source and a destination")
+	case action.Tier != "" && !slices.Contains(generator.ComponentTiers, action.Tier):
+		return apperrors.Wrap("invalid tier '%s' (valid: %s)", action.Tier, strings.Join(generator.ComponentTiers, ", "))
+	}
+	if action.Mode != "" {
+		if _, err := utils.ParseFileMode(action.Mode); err != nil {
+			return apperrors.Wrap("invalid mode", err, action.Mode)
+		}
+	}
+	return nil
+}
+
+// validateName reports a set name that is not a plain file name.
+func validateName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) || strings.HasSuffix(name, ".json") {
+		return apperrors.Wrap("invalid action set name '%s': use a file name without extension, e.g. component", name)
+	}
+	return nil
+}
+
+// joinErrors reports the errors of the set name.
+func joinErrors(name string, errs []error) error {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return apperrors.Wrap("invalid action set '%s': %s", name, strings.Join(msgs, "; "))
+}
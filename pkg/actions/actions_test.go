@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSet_JSON(t *testing.T) {
+	s := New("component").
+		RenderFile("component/templ/component.templ.gotxt", "{{ .GoPackage }}/{{ .ComponentName }}/{{ .ComponentName }}.templ").
+		RenderFile("component/assets/js/script.js.gotxt", "{{ .AssetsDir }}/{{ .ComponentName }}/js/script.js", OnlyIfJs(), Mode("0644")).
+		RenderFolder("component/docs", "docs/{{ .ComponentName }}", SkipIfExists())
+
+	content, err := s.JSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`"templateFile": "component/templ/component.templ.gotxt"`,
+		`"onlyIfJs": true`,
+		`"mode": "0644"`,
+		`"source": "component/docs"`,
+		`"skipIfExists": true`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected %s in:\n%s", want, content)
+		}
+	}
+}
+
+func TestSet_Invalid(t *testing.T) {
+	tests := map[string]*Set{
+		"missing path":   New("component").RenderFile("component/name.gotxt", ""),
+		"missing source": New("component").RenderFolder("", "out"),
+		"invalid mode":   New("component").RenderFile("a.gotxt", "a", Mode("999")),
+		"invalid tier":   New("component").RenderFile("a.gotxt", "a", Tier("huge")),
+		"invalid name":   New("actions/component.json").RenderFile("a.gotxt", "a"),
+	}
+	for name, s := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := s.Actions(); err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	var buf bytes.Buffer
+	component := New("component").RenderFile("component/name.gotxt", "out/name.templ")
+	variant := New("variant").RenderFile("component-variant/name.gotxt", "out/variant.templ", Force())
+	if err := Encode(&buf, component, variant); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sets, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sets) != 2 || sets["component"][0].Path != "out/name.templ" || !sets["variant"][0].Force {
+		t.Errorf("Unexpected sets: %+v", sets)
+	}
+
+	if err := Encode(&buf, component, component); err == nil {
+		t.Error("Expected an error for a set defined twice, got nil")
+	}
+	if _, err := Decode(strings.NewReader(`{"../component": []}`)); err == nil {
+		t.Error("Expected an error for an invalid set name, got nil")
+	}
+}
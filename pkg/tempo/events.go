@@ -0,0 +1,94 @@
+package tempo
+
+import (
+	"context"
+
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/progress"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// Events are the callbacks notified while Sync and Generate run, so that a host can
+// render its own progress instead of reading the standard output. Nil callbacks are
+// left out. The callbacks of a run are never called concurrently.
+type Events struct {
+	OnFileProcessed  func(FileEvent)   // An input file has been injected into its templ file
+	OnActionExecuted func(ActionEvent) // A generator action has been executed, or has failed
+	OnError          func(FileResult)  // An input file or a generator action has failed
+}
+
+// FileEvent describes an input file processed during a sync.
+type FileEvent struct {
+	Source string // Input file
+	Dest   string // templ file the input file was injected into
+}
+
+// ActionEvent describes a generator action executed by Generate.
+type ActionEvent struct {
+	Action Action
+	Err    error // Error the action failed with (nil on success)
+}
+
+type eventsKey struct{}
+
+/* ------------------------------------------------------------------------- */
+/* CONTEXT                                                                   */
+/* ------------------------------------------------------------------------- */
+
+// WithEvents returns a copy of ctx carrying the given events: Sync and Generate, run
+// with this context, notify them. Generate then no longer prints its dry-run messages.
+func WithEvents(ctx context.Context, events *Events) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, eventsKey{}, events)
+}
+
+// eventsFromContext returns the events stored in ctx, or nil if none is set.
+func eventsFromContext(ctx context.Context) *Events {
+	events, _ := ctx.Value(eventsKey{}).(*Events)
+	return events
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER METHODS                                                            */
+/* ------------------------------------------------------------------------- */
+
+// reporter returns the progress reporter of a sync run notifying the events, or nil
+// when e is nil.
+func (e *Events) reporter() *progress.Reporter {
+	if e == nil {
+		return nil
+	}
+	return progress.NewFunc(func(rec progress.Record) {
+		switch rec.Event {
+		case progress.EventDone:
+			if e.OnFileProcessed != nil {
+				e.OnFileProcessed(FileEvent{Source: rec.File, Dest: rec.Dest})
+			}
+		case progress.EventError:
+			if e.OnError != nil {
+				e.OnError(FileResult{Source: rec.File, Dest: rec.Dest, Message: rec.Error})
+			}
+		}
+	})
+}
+
+// actionObserver returns the generator action observer notifying the events.
+func (e *Events) actionObserver() generator.ActionObserver {
+	return func(action Action, err error) {
+		if e.OnActionExecuted != nil {
+			e.OnActionExecuted(ActionEvent{Action: action, Err: err})
+		}
+		if err != nil && e.OnError != nil {
+			source, dest := action.TemplateFile, action.Path
+			if action.Item == "folder" {
+				source, dest = action.Source, action.Destination
+			}
+			e.OnError(FileResult{Source: source, Dest: dest, Message: err.Error()})
+		}
+	}
+}
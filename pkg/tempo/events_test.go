@@ -0,0 +1,83 @@
+package tempo
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestSync_Events(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "assets")
+	outputDir := filepath.Join(tempDir, "components")
+
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "base.css"), ".btn { color: red; }")
+	testutils.CreateFile(t, filepath.Join(outputDir, "button", "base.templ"), templWithMarkers)
+	testutils.CreateFile(t, filepath.Join(inputDir, "card", "base.css"), ".card { background: url(javascript:alert(1)); }")
+	testutils.CreateFile(t, filepath.Join(outputDir, "card", "base.templ"), templWithMarkers)
+
+	cfg := DefaultConfig()
+	cfg.App.AssetsDir = inputDir
+	cfg.App.GoPackage = outputDir
+	cfg.Processor.Guards.ForbiddenPatterns = []string{"javascript:"}
+
+	var (
+		processed []FileEvent
+		failed    []FileResult
+	)
+	ctx := WithEvents(context.Background(), &Events{
+		OnFileProcessed: func(e FileEvent) { processed = append(processed, e) },
+		OnError:         func(r FileResult) { failed = append(failed, r) },
+	})
+
+	if _, err := Sync(ctx, cfg, SyncOptions{Workers: 2}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if len(processed) != 1 || !strings.HasSuffix(processed[0].Source, filepath.Join("button", "base.css")) ||
+		!strings.HasSuffix(processed[0].Dest, filepath.Join("button", "base.templ")) {
+		t.Errorf("Expected the button CSS to be reported as processed, got %+v", processed)
+	}
+	if len(failed) != 1 || !strings.HasSuffix(failed[0].Source, filepath.Join("card", "base.css")) || failed[0].Message == "" {
+		t.Errorf("Expected the card CSS to be reported as failed, got %+v", failed)
+	}
+}
+
+func TestGenerate_Events(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := DefaultConfig()
+	cfg.TempoRoot = filepath.Join(tempDir, ".tempo-files")
+	cfg.App.GoPackage = filepath.Join(tempDir, "components")
+
+	data := NewTemplateData(cfg, "button")
+	testutils.CreateFile(t, filepath.Join(data.TemplatesDir, "component", "name.templ.gotxt"), "package {{ .ComponentName }}\n")
+
+	actions := []Action{
+		{Item: "file", TemplateFile: "component/name.templ.gotxt", Path: filepath.Join(cfg.App.GoPackage, "button", "button.templ")},
+		{Item: "file", TemplateFile: "component/missing.templ.gotxt", Path: filepath.Join(cfg.App.GoPackage, "button", "missing.templ")},
+	}
+
+	var (
+		executed []ActionEvent
+		failed   []FileResult
+	)
+	ctx := WithEvents(context.Background(), &Events{
+		OnActionExecuted: func(e ActionEvent) { executed = append(executed, e) },
+		OnError:          func(r FileResult) { failed = append(failed, r) },
+	})
+
+	if err := Generate(ctx, actions, data); err == nil {
+		t.Fatal("Expected an error for the missing template")
+	}
+
+	if len(executed) != 2 || executed[0].Err != nil || executed[1].Err == nil {
+		t.Errorf("Expected a successful then a failed action, got %+v", executed)
+	}
+	if len(failed) != 1 || failed[0].Source != "component/missing.templ.gotxt" {
+		t.Errorf("Expected the missing template to be reported as failed, got %+v", failed)
+	}
+}
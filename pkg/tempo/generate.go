@@ -0,0 +1,94 @@
+package tempo
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/logger"
+	"github.com/indaco/tempo/internal/rendercache"
+	"github.com/indaco/tempo/internal/templatefuncs/providers/gonameprovider"
+	"github.com/indaco/tempo/internal/templates"
+)
+
+// NewTemplateData returns the template data for a component, filled from the
+// configuration as the 'component new' command does. Set its Templates to any fs.FS,
+// e.g. a LayeredFS over a zip bundle, to read the templates from elsewhere.
+func NewTemplateData(cfg *Config, componentName string) *TemplateData {
+	templatesDir, actionsDir := config.DerivedFolderPaths(cfg.TempoRoot)
+
+	data := &TemplateData{
+		TemplatesDir:     templatesDir,
+		ActionsDir:       actionsDir,
+		GoModule:         cfg.App.GoModule,
+		GoModuleOptional: !cfg.App.RequiresGoModule(),
+		GoPackage:        cfg.App.GoPackage,
+		ComponentName:    gonameprovider.ToGoPackageName(componentName),
+		AssetsDir:        cfg.App.AssetsDir,
+		WithJs:           cfg.App.JsEnabled(),
+		WithTsDecls:      cfg.App.TsDeclsEnabled(),
+		CssLayer:         cfg.App.CssLayer,
+		GuardMarker:      cfg.Templates.GuardMarker,
+		Watermark:        cfg.Templates.Watermark,
+		UserData:         cfg.Templates.UserData,
+		Header:           cfg.Templates.Header,
+		FileModes:        cfg.FileModes,
+		RenderCache:      rendercache.Open(cfg.TempoRoot, cfg.Templates.RenderCacheEnabled(), cfg.Templates.FunctionProviders),
+	}
+	data.SetTemplateLayers(cfg.Templates.LayeredEnabled())
+	return data
+}
+
+// NewLayeredFS returns the file system of the given template layers, first to last: a
+// file of a layer hides the files with the same name in the next layers.
+func NewLayeredFS(layers ...fs.FS) *LayeredFS {
+	return templates.NewLayeredFS(layers...)
+}
+
+// BuiltInTemplates returns the file system of the built-in templates, e.g. as the last
+// layer of a LayeredFS.
+func BuiltInTemplates() fs.FS {
+	return templates.EmbeddedFiles
+}
+
+// LoadActions reads an actions JSON file, such as the ones created by 'tempo component
+// define'. Actions without an explicit type are render actions.
+func LoadActions(filePath string) ([]Action, error) {
+	actions, err := generator.LoadUserActions(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return generator.JSONActionList(actions).ToActions(generator.RenderActionID), nil
+}
+
+// Generate executes the actions with the given template data, writing the generated
+// files. Actions without a type are render actions. Files are overwritten only when
+// data.Force or the action Force is set. The Events carried by ctx (see WithEvents) are
+// notified of each executed action.
+func Generate(ctx context.Context, actions []Action, data *TemplateData) error {
+	if data == nil {
+		return apperrors.Wrap("template data is required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	resolved := make([]Action, len(actions))
+	for i, action := range actions {
+		if action.Type == "" {
+			action.Type = generator.RenderActionID
+		}
+		action.Force = action.Force || data.Force
+		resolved[i] = action
+	}
+
+	log := logger.NewDefaultLogger()
+	if events := eventsFromContext(ctx); events != nil {
+		// The host renders the progress: keep the messages off the standard output
+		log.StartRecording()
+		ctx = generator.WithActionHooks(ctx, func(h *generator.ActionHooks) { h.Observer = events.actionObserver() })
+	}
+	return generator.ProcessActions(ctx, log, resolved, data)
+}
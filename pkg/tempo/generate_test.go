@@ -0,0 +1,71 @@
+package tempo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestNewTemplateData(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.App.GoModule = "example.com/app"
+	withJs := true
+	cfg.App.WithJs = &withJs
+
+	data := NewTemplateData(cfg, "Icon Button")
+
+	if data.ComponentName != "icon_button" {
+		t.Errorf("Expected component name 'icon_button', got %q", data.ComponentName)
+	}
+	if data.GoModule != "example.com/app" || !data.WithJs || data.GuardMarker != "tempo" {
+		t.Errorf("Expected template data from config, got %+v", data)
+	}
+	if data.TemplatesDir != filepath.Join(".tempo-files", "templates") {
+		t.Errorf("Unexpected templates folder: %s", data.TemplatesDir)
+	}
+}
+
+func TestLoadActionsAndGenerate(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := DefaultConfig()
+	cfg.TempoRoot = filepath.Join(tempDir, ".tempo-files")
+	cfg.App.GoPackage = filepath.Join(tempDir, "components")
+
+	data := NewTemplateData(cfg, "button")
+	testutils.CreateFile(t, filepath.Join(data.TemplatesDir, "component", "name.templ.gotxt"),
+		"package {{ .ComponentName }}\n\ntempl {{ .ComponentName | goExportedName }}() {}\n")
+	actionsFile := filepath.Join(data.ActionsDir, "component.json")
+	testutils.CreateFile(t, actionsFile,
+		`[{"item": "file", "templateFile": "component/name.templ.gotxt", "path": "{{ .GoPackage }}/{{ .ComponentName }}/{{ .ComponentName }}.templ"}]`)
+
+	actions, err := LoadActions(actionsFile)
+	if err != nil {
+		t.Fatalf("LoadActions failed: %v", err)
+	}
+	if len(actions) != 1 || actions[0].Type != "render" {
+		t.Fatalf("Expected a single render action, got %+v", actions)
+	}
+
+	if err := Generate(context.Background(), actions, data); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(cfg.App.GoPackage, "button", "button.templ"))
+	if err != nil {
+		t.Fatalf("Expected generated file: %v", err)
+	}
+	if !strings.Contains(string(content), "templ Button() {}") {
+		t.Errorf("Unexpected generated content:\n%s", content)
+	}
+}
+
+func TestGenerate_NilData(t *testing.T) {
+	if err := Generate(context.Background(), nil, nil); err == nil {
+		t.Error("Expected error for nil template data")
+	}
+}
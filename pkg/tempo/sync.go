@@ -0,0 +1,260 @@
+package tempo
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/indaco/tempo/internal/apperrors"
+	"github.com/indaco/tempo/internal/processor"
+	"github.com/indaco/tempo/internal/progress"
+	"github.com/indaco/tempo/internal/utils"
+	"github.com/indaco/tempo/internal/worker"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+// SyncOptions configures a sync run. Zero values fall back to the configuration.
+type SyncOptions struct {
+	InputDir   string // Folder with the CSS and JS assets (default: cfg.App.AssetsDir)
+	InputFS    fs.FS  // Reads the assets from this file system, e.g. a zip bundle or an embed.FS, as if it were InputDir
+	OutputDir  string // Folder with the templ files (default: cfg.App.GoPackage)
+	ExcludeDir string // Folder, inside InputDir, to leave out
+	Workers    int    // Number of workers (default: cfg.Processor.Workers)
+	Production bool   // Minify the injected assets
+	FailFast   bool   // Stop on the first file that fails
+
+	Tags        []string // Only the components with one of these tags (components.tags)
+	ExcludeTags []string // Leave out the components with one of these tags
+}
+
+// SyncResult is the outcome of a sync run.
+type SyncResult struct {
+	Processed []string     // Input files injected into their templ file
+	Failed    []FileResult // Input files that could not be processed
+	Skipped   []FileResult // Input files left out, with the reason
+}
+
+/* ------------------------------------------------------------------------- */
+/* SYNC                                                                      */
+/* ------------------------------------------------------------------------- */
+
+// Sync injects every CSS and JS asset of the input folder into the guard markers of
+// the matching templ file of the output folder. Unlike the CLI, it does not keep track
+// of the last run: all files are processed. Per-file failures are reported in the
+// result; the returned error is set when the run itself cannot complete. The Events
+// carried by ctx (see WithEvents) are notified of the processed and failed files.
+func Sync(ctx context.Context, cfg *Config, opts SyncOptions) (*SyncResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	poolOpts, err := newWorkerPoolOptions(ctx, cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	reporter := eventsFromContext(ctx).reporter()
+	poolOpts.Progress = reporter
+
+	// Step 1: Collect the jobs, leaving out files sharing the same output
+	jobs, skipped, err := collectJobs(poolOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Run the workers while feeding them the jobs
+	// The feeder is canceled once the workers stop, e.g. on fail-fast
+	feedCtx, cancelFeed := context.WithCancel(ctx)
+	defer cancelFeed()
+
+	manager := worker.NewWorkerPoolManager(poolOpts)
+	result := &SyncResult{Skipped: skipped}
+
+	var (
+		mu        sync.Mutex
+		collector sync.WaitGroup
+	)
+	collector.Add(2)
+	go func() {
+		defer collector.Done()
+		for e := range manager.ErrorsChan {
+			reporter.Emit(progress.Record{Event: progress.EventError, File: e.Source, Dest: e.Dest, Error: e.Message})
+			mu.Lock()
+			result.Failed = append(result.Failed, e)
+			mu.Unlock()
+		}
+	}()
+	go func() {
+		defer collector.Done()
+		for s := range manager.SkippedChan {
+			mu.Lock()
+			result.Skipped = append(result.Skipped, s)
+			mu.Unlock()
+		}
+	}()
+
+	go func() {
+		defer close(manager.JobChan)
+		for _, job := range jobs {
+			select {
+			case manager.JobChan <- job:
+			case <-feedCtx.Done():
+				return
+			}
+		}
+	}()
+
+	workersErr := manager.StartWorkers(ctx, poolOpts.NumWorkers, false)
+	cancelFeed()
+
+	close(manager.ErrorsChan)
+	close(manager.SkippedChan)
+	collector.Wait()
+
+	// Step 3: Report the results
+	result.Processed = slices.Clone(manager.Metrics.ProcessedFiles)
+	if workersErr != nil {
+		return result, apperrors.Wrap("sync stopped before processing all files", workersErr)
+	}
+	return result, nil
+}
+
+/* ------------------------------------------------------------------------- */
+/* HELPER FUNCTIONS                                                          */
+/* ------------------------------------------------------------------------- */
+
+// newWorkerPoolOptions resolves the sync options against the configuration.
+func newWorkerPoolOptions(ctx context.Context, cfg *Config, opts SyncOptions) (worker.WorkerPoolOptions, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	inputDir := cmp.Or(opts.InputDir, cfg.App.AssetsDir)
+	outputDir := cmp.Or(opts.OutputDir, cfg.App.GoPackage)
+	dirs := []string{outputDir}
+	if opts.InputFS == nil {
+		dirs = append(dirs, inputDir)
+	}
+	for _, dir := range dirs {
+		exists, err := utils.DirExists(dir)
+		if err != nil {
+			return worker.WorkerPoolOptions{}, err
+		}
+		if !exists {
+			return worker.WorkerPoolOptions{}, apperrors.Wrap("folder does not exist", dir)
+		}
+	}
+
+	numWorkers := opts.Workers
+	if numWorkers == 0 {
+		numWorkers = cfg.Processor.Workers
+	}
+
+	configOpts, err := worker.ConfigOptions(ctx, cfg, inputDir, worker.ConfigOverrides{
+		IncludeTags: opts.Tags,
+		ExcludeTags: opts.ExcludeTags,
+	})
+	if err != nil {
+		return worker.WorkerPoolOptions{}, err
+	}
+
+	return worker.NewWorkerPoolOptions(ctx, inputDir, outputDir, append(configOpts,
+		worker.WithInputFS(opts.InputFS),
+		worker.WithExcludeDir(opts.ExcludeDir),
+		worker.WithNumWorkers(numWorkers),
+		worker.WithProduction(opts.Production),
+		worker.WithForce(true),
+		worker.WithFailFast(opts.FailFast),
+	)...)
+}
+
+// collectJobs walks the input folder, leaving out ExcludeDir, and returns the jobs to
+// process with the skipped files: the prebuilt ones (see worker.InputSkipper) and those
+// sharing their output file with other inputs.
+func collectJobs(opts worker.WorkerPoolOptions) ([]worker.Job, []FileResult, error) {
+	var excludeDir string
+	if opts.ExcludeDir != "" {
+		abs, err := filepath.Abs(opts.ExcludeDir)
+		if err != nil {
+			return nil, nil, apperrors.Wrap("invalid exclude folder", err, opts.ExcludeDir)
+		}
+		excludeDir = abs
+	}
+
+	var (
+		jobs    []worker.Job
+		skipped []FileResult
+	)
+	input := processor.NewInputFS(opts.InputFS, opts.InputDir)
+	walk := func(fn fs.WalkDirFunc) error {
+		if opts.InputFS != nil {
+			return worker.WalkInputFS(opts.InputFS, opts.InputDir, fn)
+		}
+		return worker.WalkInputDir(opts.InputDir, opts.SymlinkPolicy, fn)
+	}
+	err := walk(func(source string, d os.DirEntry, err error) error {
+		if errors.Is(err, worker.ErrSymlinkSkipped) || errors.Is(err, worker.ErrSymlinkCycle) || errors.Is(err, worker.ErrSymlinkDuplicate) {
+			skipped = append(skipped, FileResult{Source: source, Reason: err.Error(), SkipType: worker.SkipSymlink, ReasonCode: worker.ReasonIgnored})
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if excludeDir != "" {
+			if abs, err := filepath.Abs(source); err == nil && strings.HasPrefix(abs, excludeDir) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+
+		if !d.IsDir() {
+			output, region := worker.OutputTarget(source, opts.InputDir, opts.OutputDir, opts.OutputRules)
+			reason, err := opts.InputSkipper.SkipReason(source, opts.InputDir, input, opts.Languages)
+			if err != nil {
+				return err
+			}
+			if reason != "" {
+				skipped = append(skipped, FileResult{Source: source, Dest: output, Reason: reason, SkipType: worker.SkipPrebuilt, ReasonCode: worker.ReasonMinified})
+				return nil
+			}
+			jobs = append(jobs, worker.Job{InputPath: source, OutputPath: output, Region: region})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, apperrors.Wrap("failed to scan input folder", err, opts.InputDir)
+	}
+
+	conflicts := worker.FindOutputConflicts(jobs, opts.Languages)
+	if len(conflicts) == 0 {
+		return jobs, nil, nil
+	}
+
+	kept := jobs[:0]
+	for _, job := range jobs {
+		if inputs, ok := conflicts[job.Target()]; ok && slices.Contains(inputs, job.InputPath) {
+			skipped = append(skipped, FileResult{
+				Source:     job.InputPath,
+				Dest:       job.OutputPath,
+				Reason:     fmt.Sprintf("Output file matched by %d input files", len(inputs)),
+				SkipType:   worker.SkipOutputConflict,
+				ReasonCode: worker.ReasonConflict,
+			})
+			continue
+		}
+		kept = append(kept, job)
+	}
+	return kept, skipped, nil
+}
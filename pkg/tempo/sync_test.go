@@ -0,0 +1,141 @@
+package tempo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+const templWithMarkers = "<style>\n/* [tempo] BEGIN - Do not edit! This section is auto-generated. */\n/* [tempo] END */\n</style>\n"
+
+func TestSync(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "assets")
+	outputDir := filepath.Join(tempDir, "components")
+
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "css", "base.css"), ".btn { color: red; }")
+	testutils.CreateFile(t, filepath.Join(outputDir, "button", "css", "base.templ"), templWithMarkers)
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "README.md"), "docs")
+
+	cfg := DefaultConfig()
+	cfg.App.AssetsDir = inputDir
+	cfg.App.GoPackage = outputDir
+
+	result, err := Sync(context.Background(), cfg, SyncOptions{Workers: 2})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if len(result.Processed) != 1 || len(result.Failed) != 0 {
+		t.Errorf("Expected 1 processed file and no failures, got %+v", result)
+	}
+	if len(result.Skipped) != 1 || !strings.HasSuffix(result.Skipped[0].Source, "README.md") {
+		t.Errorf("Expected README.md to be skipped, got %+v", result.Skipped)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "button", "css", "base.templ"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), ".btn { color: red; }") {
+		t.Errorf("Expected CSS to be injected, got:\n%s", content)
+	}
+}
+
+func TestSync_ManualEditsRefused(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "assets")
+	outputDir := filepath.Join(tempDir, "components")
+	templPath := filepath.Join(outputDir, "button", "css", "base.templ")
+
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "css", "base.css"), ".btn { color: red; }")
+	testutils.CreateFile(t, templPath, templWithMarkers)
+
+	cfg := DefaultConfig()
+	cfg.App.AssetsDir = inputDir
+	cfg.App.GoPackage = outputDir
+	cfg.Templates.ManualEdits = "refuse"
+
+	if _, err := Sync(context.Background(), cfg, SyncOptions{Workers: 1}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	// The guard region is edited by hand since the last sync
+	content, err := os.ReadFile(templPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(content), "color: red", "color: blue", 1)
+	testutils.CreateFile(t, templPath, edited)
+
+	result, err := Sync(context.Background(), cfg, SyncOptions{Workers: 1})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if len(result.Failed) != 1 || len(result.Processed) != 0 {
+		t.Errorf("Expected the edited region to be refused, got %+v", result)
+	}
+	if content, _ := os.ReadFile(templPath); string(content) != edited {
+		t.Errorf("Expected the manual edit to be kept, got:\n%s", content)
+	}
+}
+
+func TestSync_OutputConflicts(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "assets")
+	outputDir := filepath.Join(tempDir, "components")
+
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "base.css"), ".a {}")
+	testutils.CreateFile(t, filepath.Join(inputDir, "button", "base.js"), "let a;")
+	testutils.CreateFile(t, filepath.Join(outputDir, "button", "base.templ"), templWithMarkers)
+
+	result, err := Sync(context.Background(), nil, SyncOptions{InputDir: inputDir, OutputDir: outputDir, Workers: 1})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if len(result.Processed) != 0 || len(result.Skipped) != 2 {
+		t.Errorf("Expected both conflicting inputs to be skipped, got %+v", result)
+	}
+}
+
+func TestSync_MissingFolder(t *testing.T) {
+	_, err := Sync(context.Background(), nil, SyncOptions{InputDir: filepath.Join(t.TempDir(), "missing"), OutputDir: t.TempDir()})
+	if err == nil {
+		t.Error("Expected error for missing input folder")
+	}
+}
+
+func TestSync_InputFS(t *testing.T) {
+	tempDir := t.TempDir()
+	outputDir := filepath.Join(tempDir, "components")
+	testutils.CreateFile(t, filepath.Join(outputDir, "button", "css", "base.templ"), templWithMarkers)
+
+	bundle := fstest.MapFS{
+		"button/css/base.css": {Data: []byte(".btn { color: blue; }")},
+	}
+	result, err := Sync(context.Background(), nil, SyncOptions{
+		InputDir:  filepath.Join(tempDir, "assets"), // Not on disk: read from the bundle
+		InputFS:   bundle,
+		OutputDir: outputDir,
+		Workers:   1,
+	})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if len(result.Processed) != 1 || len(result.Failed) != 0 {
+		t.Fatalf("Expected 1 processed file and no failures, got %+v", result)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "button", "css", "base.templ"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), ".btn { color: blue; }") {
+		t.Errorf("Expected CSS from the bundle to be injected, got:\n%s", content)
+	}
+}
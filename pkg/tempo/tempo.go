@@ -0,0 +1,62 @@
+// Package tempo exposes the building blocks of the tempo CLI as a Go library, so that
+// build tools can embed them instead of shelling out:
+//
+//   - configuration loading (LoadConfig, DefaultConfig);
+//   - the generator: actions and template data (LoadActions, NewTemplateData, Generate);
+//   - the sync worker pool injecting CSS and JS assets into templ files (Sync);
+//   - the events notified while they run, for hosts rendering their own progress (WithEvents).
+//
+// The types declared here are the stable API of tempo. Aliased types mirror the fields
+// of the tempo.yaml file and of the actions JSON files.
+package tempo
+
+import (
+	"github.com/indaco/tempo/internal/config"
+	"github.com/indaco/tempo/internal/generator"
+	"github.com/indaco/tempo/internal/templates"
+	"github.com/indaco/tempo/internal/worker"
+)
+
+/* ------------------------------------------------------------------------- */
+/* TYPES                                                                     */
+/* ------------------------------------------------------------------------- */
+
+type (
+	// Config is the tempo configuration, as read from tempo.yaml.
+	Config = config.Config
+
+	// Action is a generator action: render or copy a template file or folder.
+	Action = generator.Action
+
+	// TemplateData is the data available to the templates rendered by the actions.
+	TemplateData = generator.TemplateData
+
+	// LayeredFS looks up the templates in a list of file systems, e.g. a project
+	// folder, a zip bundle and the built-in templates (see NewLayeredFS).
+	LayeredFS = templates.LayeredFS
+
+	// FileResult describes a file that failed or was skipped during a sync.
+	FileResult = worker.ProcessingError
+)
+
+/* ------------------------------------------------------------------------- */
+/* CONFIGURATION                                                             */
+/* ------------------------------------------------------------------------- */
+
+// DefaultConfig returns the configuration used when no tempo.yaml file exists.
+func DefaultConfig() *Config {
+	return config.DefaultConfig()
+}
+
+// LoadConfig loads the configuration the same way the CLI does: the user-level config
+// is applied first, then the tempo.yaml (or tempo.yml) file of the current working
+// directory. Paths in the configuration are relative to the working directory.
+func LoadConfig() (*Config, error) {
+	return config.LoadConfig()
+}
+
+// LoadConfigFrom loads the configuration as LoadConfig, from the project config file at
+// path instead of the one of the working directory when path is not empty.
+func LoadConfigFrom(path string) (*Config, error) {
+	return config.LoadConfigFrom(path)
+}
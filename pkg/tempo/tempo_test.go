@@ -0,0 +1,46 @@
+package tempo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/indaco/tempo/internal/testutils"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.App.GoPackage != "components" || cfg.App.AssetsDir != "assets" {
+		t.Errorf("Unexpected default folders: %s, %s", cfg.App.GoPackage, cfg.App.AssetsDir)
+	}
+	if cfg.Templates.GuardMarker != "tempo" {
+		t.Errorf("Expected default guard marker 'tempo', got %q", cfg.Templates.GuardMarker)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tempDir, "xdg"))
+	t.Chdir(tempDir)
+
+	testutils.CreateFile(t, filepath.Join(tempDir, "tempo.yaml"), "app:\n  go_package: ui\n  assets_dir: static\n")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.App.GoPackage != "ui" || cfg.App.AssetsDir != "static" {
+		t.Errorf("Expected folders from tempo.yaml, got %s, %s", cfg.App.GoPackage, cfg.App.AssetsDir)
+	}
+
+	if err := os.Remove(filepath.Join(tempDir, "tempo.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.App.GoPackage != "components" {
+		t.Errorf("Expected default config without tempo.yaml, got %s", cfg.App.GoPackage)
+	}
+}